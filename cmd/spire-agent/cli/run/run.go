@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/hcl"
@@ -21,6 +22,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/health"
@@ -54,20 +56,22 @@ type Config struct {
 }
 
 type agentConfig struct {
-	DataDir             string    `hcl:"data_dir"`
-	DeprecatedEnableSDS *bool     `hcl:"enable_sds"`
-	InsecureBootstrap   bool      `hcl:"insecure_bootstrap"`
-	JoinToken           string    `hcl:"join_token"`
-	LogFile             string    `hcl:"log_file"`
-	LogFormat           string    `hcl:"log_format"`
-	LogLevel            string    `hcl:"log_level"`
-	SDS                 sdsConfig `hcl:"sds"`
-	ServerAddress       string    `hcl:"server_address"`
-	ServerPort          int       `hcl:"server_port"`
-	SocketPath          string    `hcl:"socket_path"`
-	TrustBundlePath     string    `hcl:"trust_bundle_path"`
-	TrustBundleURL      string    `hcl:"trust_bundle_url"`
-	TrustDomain         string    `hcl:"trust_domain"`
+	AgentSVIDKeyType        string    `hcl:"agent_svid_key_type"`
+	DataDir                 string    `hcl:"data_dir"`
+	DeprecatedEnableSDS     *bool     `hcl:"enable_sds"`
+	InsecureBootstrap       bool      `hcl:"insecure_bootstrap"`
+	JoinToken               string    `hcl:"join_token"`
+	LogFile                 string    `hcl:"log_file"`
+	LogFormat               string    `hcl:"log_format"`
+	LogLevel                string    `hcl:"log_level"`
+	SDS                     sdsConfig `hcl:"sds"`
+	ServerAddress           string    `hcl:"server_address"`
+	ServerPort              int       `hcl:"server_port"`
+	SocketPath              string    `hcl:"socket_path"`
+	TrustBundlePath         string    `hcl:"trust_bundle_path"`
+	TrustBundleURL          string    `hcl:"trust_bundle_url"`
+	TrustDomain             string    `hcl:"trust_domain"`
+	WorkloadX509SVIDKeyType string    `hcl:"workload_x509_svid_key_type"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -336,6 +340,22 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		}
 	}
 
+	if c.Agent.AgentSVIDKeyType != "" {
+		var err error
+		ac.AgentSVIDKeyType, err = keyTypeFromString(c.Agent.AgentSVIDKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse agent_svid_key_type: %v", err)
+		}
+	}
+
+	if c.Agent.WorkloadX509SVIDKeyType != "" {
+		var err error
+		ac.WorkloadX509SVIDKeyType, err = keyTypeFromString(c.Agent.WorkloadX509SVIDKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse workload_x509_svid_key_type: %v", err)
+		}
+	}
+
 	serverHostPort := net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))
 	ac.ServerAddress = fmt.Sprintf("dns:///%s", serverHostPort)
 
@@ -515,3 +535,18 @@ func parseTrustBundle(path string) ([]*x509.Certificate, error) {
 
 	return bundle, nil
 }
+
+func keyTypeFromString(s string) (keymanager.KeyType, error) {
+	switch strings.ToLower(s) {
+	case "rsa-2048":
+		return keymanager.KeyType_RSA_2048, nil
+	case "rsa-4096":
+		return keymanager.KeyType_RSA_4096, nil
+	case "ec-p256":
+		return keymanager.KeyType_EC_P256, nil
+	case "ec-p384":
+		return keymanager.KeyType_EC_P384, nil
+	default:
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", s)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/test/util"
@@ -159,6 +160,16 @@ func TestMergeInput(t *testing.T) {
 		cliInput  func(*agentConfig)
 		test      func(*testing.T, *Config)
 	}{
+		{
+			msg: "agent_svid_key_type should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "rsa-2048"
+			},
+			cliInput: func(c *agentConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "rsa-2048", c.Agent.AgentSVIDKeyType)
+			},
+		},
 		{
 			msg: "data_dir should be configurable by file",
 			fileInput: func(c *Config) {
@@ -571,6 +582,16 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "", c.Agent.TrustDomain)
 			},
 		},
+		{
+			msg: "workload_x509_svid_key_type should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "ec-p384"
+			},
+			cliInput: func(c *agentConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "ec-p384", c.Agent.WorkloadX509SVIDKeyType)
+			},
+		},
 		{
 			msg: "trust_domain should be configurable by file",
 			fileInput: func(c *Config) {
@@ -804,6 +825,112 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg:   "agent_svid_key_type defaults to unspecified",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.AgentSVIDKeyType)
+			},
+		},
+		{
+			msg: "rsa-2048 agent_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.AgentSVIDKeyType)
+			},
+		},
+		{
+			msg: "RSA-4096 agent_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "RSA-4096"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.AgentSVIDKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 agent_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.AgentSVIDKeyType)
+			},
+		},
+		{
+			msg: "ec-p384 agent_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.AgentSVIDKeyType)
+			},
+		},
+		{
+			msg:         "unsupported agent_svid_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.AgentSVIDKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:   "workload_x509_svid_key_type defaults to unspecified",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.WorkloadX509SVIDKeyType)
+			},
+		},
+		{
+			msg: "rsa-2048 workload_x509_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.WorkloadX509SVIDKeyType)
+			},
+		},
+		{
+			msg: "RSA-4096 workload_x509_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "RSA-4096"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.WorkloadX509SVIDKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 workload_x509_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.WorkloadX509SVIDKeyType)
+			},
+		},
+		{
+			msg: "ec-p384 workload_x509_svid_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.WorkloadX509SVIDKeyType)
+			},
+		},
+		{
+			msg:         "unsupported workload_x509_svid_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadX509SVIDKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 	}
 
 	for _, testCase := range cases {
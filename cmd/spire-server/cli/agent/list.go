@@ -5,9 +5,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
@@ -18,6 +20,21 @@ import (
 type ListConfig struct {
 	// Socket path of registration API
 	RegistrationUDSPath string
+
+	// Only list agents attested with this attestation type
+	AttestationType string
+
+	// Only list agents whose selectors match these selectors
+	Selectors common_cli.StringsFlag
+
+	// How agent selectors are matched: exact, subset, any or superset
+	MatchSelectorsOn string
+
+	// Only list agents that are (or are not) banned
+	Banned common_cli.OptionalBoolFlag
+
+	// Only list agents that can (or cannot) re-attest
+	CanReattest common_cli.OptionalBoolFlag
 }
 
 // Validate will perform a basic validation on config fields
@@ -25,9 +42,43 @@ func (c *ListConfig) Validate() (err error) {
 	if c.RegistrationUDSPath == "" {
 		return errors.New("a socket path for registration api is required")
 	}
+	if _, err := util.ParseSelectorMatch(c.MatchSelectorsOn); err != nil {
+		return err
+	}
 	return nil
 }
 
+// listAgentsRequest builds the request that lists the agents matching the
+// configured filters
+func (c *ListConfig) listAgentsRequest() (*registration.ListAgentsRequest, error) {
+	req := &registration.ListAgentsRequest{
+		ByAttestationType: c.AttestationType,
+	}
+
+	for _, s := range c.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		req.BySelectors = append(req.BySelectors, selector)
+	}
+	if len(req.BySelectors) > 0 {
+		match, err := util.ParseSelectorMatch(c.MatchSelectorsOn)
+		if err != nil {
+			return nil, err
+		}
+		req.SelectorMatch = match
+	}
+
+	if c.Banned.IsSet {
+		req.ByBanned = &wrappers.BoolValue{Value: c.Banned.Value}
+	}
+	if c.CanReattest.IsSet {
+		req.ByCanReattest = &wrappers.BoolValue{Value: c.CanReattest.Value}
+	}
+	return req, nil
+}
+
 //ListCLI command for listing attested nodes
 type ListCLI struct {
 	registrationClient registration.RegistrationClient
@@ -66,7 +117,13 @@ func (c *ListCLI) Run(args []string) int {
 		}
 	}
 
-	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{})
+	req, err := config.listAgentsRequest()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	listResponse, err := c.registrationClient.ListAgents(ctx, req)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing attested agents: %v \n", err)
 		return 1
@@ -81,6 +138,11 @@ func (ListCLI) parseConfig(args []string) (*ListConfig, error) {
 	c := &ListConfig{}
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.AttestationType, "attestationType", "", "Only list agents attested with this attestation type")
+	f.Var(&c.Selectors, "selector", "A colon-delimited type:value selector the agents must match. Can be used more than once")
+	f.StringVar(&c.MatchSelectorsOn, "matchSelectorsOn", "exact", "How agent selectors are matched against the selectors: exact, subset, any or superset")
+	f.Var(&c.Banned, "banned", "Only list agents that are (true) or are not (false) banned")
+	f.Var(&c.CanReattest, "canReattest", "Only list agents that can (true) or cannot (false) re-attest")
 
 	return c, f.Parse(args)
 }
@@ -95,10 +157,18 @@ func (c ListCLI) printAttestedNodes() {
 	}
 
 	for _, node := range c.nodeList {
-		fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
-		fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
-		fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
-		fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+		printAttestedNode(node)
+		for _, s := range node.Selectors {
+			fmt.Printf("Selectors         : %s:%s\n", s.Type, s.Value)
+		}
 		fmt.Println()
 	}
 }
+
+func parseSelector(s string) (*common.Selector, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector %q must be formatted as type:value", s)
+	}
+	return &common.Selector{Type: parts[0], Value: parts[1]}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
@@ -46,6 +47,49 @@ func (s *ListTestSuite) TestRun() {
 	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
 }
 
+func (s *ListTestSuite) TestRunWithFilters() {
+	req := &registration.ListAgentsRequest{
+		ByAttestationType: "x509pop",
+		BySelectors: []*common.Selector{
+			{Type: "x509pop", Value: "subject:cn:foo"},
+			{Type: "x509pop", Value: "ca:fingerprint:abc"},
+		},
+		SelectorMatch: registration.SelectorMatch_MATCH_SUBSET,
+		ByBanned:      &wrappers.BoolValue{Value: false},
+		ByCanReattest: &wrappers.BoolValue{Value: true},
+	}
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{
+				SpiffeId:            "spiffe://example.org/spire/agent/x509pop/foo",
+				AttestationDataType: "x509pop",
+				CertSerialNumber:    "1",
+				AgentVersion:        "0.10.1",
+				AgentPlugins:        []*common.AgentPlugin{{Type: "NodeAttestor", Name: "x509pop"}},
+				LastSeen:            1234,
+				CanReattest:         true,
+				Selectors:           []*common.Selector{{Type: "x509pop", Value: "subject:cn:foo"}},
+			},
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{
+		"-attestationType", "x509pop",
+		"-selector", "x509pop:subject:cn:foo",
+		"-selector", "x509pop:ca:fingerprint:abc",
+		"-matchSelectorsOn", "subset",
+		"-banned", "false",
+		"-canReattest", "true",
+	}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunWithInvalidFilters() {
+	s.Require().Equal(1, s.cli.Run([]string{"-selector", "invalid"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-matchSelectorsOn", "invalid"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-banned", "maybe"}))
+}
+
 func (s *ListTestSuite) TestRunWithNoAgentsInDatastore() {
 	req := &registration.ListAgentsRequest{}
 	resp := &registration.ListAgentsResponse{}
@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
@@ -144,10 +143,7 @@ func (c ShowCLI) fetchSelectors(ctx context.Context, spiffeID string) ([]*common
 
 func (c ShowCLI) printAttestedNode() {
 	fmt.Printf("Found an attested agent given its SPIFFE ID\n\n")
-	fmt.Printf("Spiffe ID         : %s\n", c.node.SpiffeId)
-	fmt.Printf("Attestation type  : %s\n", c.node.AttestationDataType)
-	fmt.Printf("Expiration time   : %s\n", time.Unix(c.node.CertNotAfter, 0))
-	fmt.Printf("Serial number     : %s\n", c.node.CertSerialNumber)
+	printAttestedNode(c.node)
 
 	if c.selectors != nil {
 		for _, s := range c.selectors {
@@ -0,0 +1,28 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// printAttestedNode prints the details of an attested agent, except for its
+// selectors
+func printAttestedNode(node *common.AttestedNode) {
+	fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
+	fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
+	fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
+	fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+	fmt.Printf("Banned            : %t\n", node.Banned)
+	fmt.Printf("Can re-attest     : %t\n", node.CanReattest)
+	if node.AgentVersion != "" {
+		fmt.Printf("Agent version     : %s\n", node.AgentVersion)
+	}
+	if node.LastSeen != 0 {
+		fmt.Printf("Last seen         : %s\n", time.Unix(node.LastSeen, 0))
+	}
+	for _, p := range node.AgentPlugins {
+		fmt.Printf("Plugins           : %s:%s\n", p.Type, p.Name)
+	}
+}
@@ -0,0 +1,147 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// serverDataStore is the datastore configured in a server configuration file
+type serverDataStore struct {
+	datastore.DataStore
+
+	// trustDomainID is the ID of the trust domain of the server
+	trustDomainID string
+
+	// registrationUDSPath is the path of the registration API socket, which
+	// the server listens on while it runs
+	registrationUDSPath string
+}
+
+// dataStoreLoader loads the datastore configured in the given server
+// configuration file. The returned function closes the datastore.
+type dataStoreLoader func(ctx context.Context, env *common_cli.Env, configPath string, expandEnv bool) (*serverDataStore, func(), error)
+
+// loadDataStore is the default datastore loader. Only the DataStore plugin
+// is loaded, so the server does not need to be running. Logs are written to
+// stderr so they do not mix with the command output.
+func loadDataStore(ctx context.Context, env *common_cli.Env, configPath string, expandEnv bool) (*serverDataStore, func(), error) {
+	config, err := run.ParseFile(configPath, expandEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.Server == nil {
+		return nil, nil, errors.New("server section must be configured")
+	}
+	if config.Plugins == nil {
+		return nil, nil, errors.New("plugins section must be configured")
+	}
+	registrationUDSPath := config.Server.RegistrationUDSPath
+	if registrationUDSPath == "" {
+		registrationUDSPath = util.DefaultSocketPath
+	}
+
+	logger, err := log.NewLogger(log.WithLevel(config.Server.LogLevel), log.WithFormat(config.Server.LogFormat))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not start logger: %v", err)
+	}
+	logger.SetOutput(env.Stderr)
+
+	repo, err := catalog.LoadDataStore(ctx, catalog.Config{
+		Log: logger.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		GlobalConfig: catalog.GlobalConfig{
+			TrustDomain: config.Server.TrustDomain,
+		},
+		PluginConfig: *config.Plugins,
+		MetricsService: metricsservice.New(metricsservice.Config{
+			Metrics: telemetry.Blackhole{},
+		}),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &serverDataStore{
+		DataStore:           repo,
+		trustDomainID:       idutil.TrustDomainID(config.Server.TrustDomain),
+		registrationUDSPath: registrationUDSPath,
+	}, repo.Close, nil
+}
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *common_cli.Env, *serverDataStore) error
+}
+
+type adapter struct {
+	env    *common_cli.Env
+	loader dataStoreLoader
+	cmd    command
+
+	configPath string
+	expandEnv  bool
+	flags      *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *common_cli.Env, loader dataStoreLoader, cmd command) *adapter {
+	a := &adapter{
+		env:    env,
+		loader: loader,
+		cmd:    cmd,
+	}
+
+	f := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	f.SetOutput(env.Stderr)
+	f.StringVar(&a.configPath, "config", "conf/server/server.conf", "Path to the SPIRE server configuration file")
+	f.BoolVar(&a.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	a.cmd.appendFlags(f)
+	a.flags = f
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.flags.Parse(args); err != nil {
+		_ = a.env.ErrPrintln(err)
+		return 1
+	}
+
+	ds, closeDataStore, err := a.loader(ctx, a.env, a.env.JoinPath(a.configPath), a.expandEnv)
+	if err != nil {
+		_ = a.env.ErrPrintf("Failed to load datastore: %v\n", err)
+		return 1
+	}
+	defer closeDataStore()
+
+	if err := a.cmd.run(ctx, a.env, ds); err != nil {
+		_ = a.env.ErrPrintln(err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	return a.flags.Parse([]string{"-h"}).Error()
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
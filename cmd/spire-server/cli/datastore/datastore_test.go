@@ -0,0 +1,226 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bundle = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("cert")}},
+	}
+	joinToken = &datastore.JoinToken{
+		Token:  "foo",
+		Expiry: 1000,
+	}
+)
+
+func TestExportToStdout(t *testing.T) {
+	test := setupTest(t, newExportCommand)
+	defer test.cleanup()
+	test.createRecords(t)
+
+	require.Equal(t, 0, test.cmd.Run(nil))
+	require.Empty(t, test.stderr.String())
+
+	s, err := snapshot.Read(test.stdout)
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{bundle}, s.Bundles)
+	spiretest.RequireProtoListEqual(t, []*datastore.JoinToken{joinToken}, s.JoinTokens)
+}
+
+func TestExportToFile(t *testing.T) {
+	test := setupTest(t, newExportCommand)
+	defer test.cleanup()
+	test.createRecords(t)
+
+	require.Equal(t, 0, test.cmd.Run([]string{"-output", "snapshot.json"}))
+	require.Empty(t, test.stderr.String())
+	require.Equal(t, "Exported 1 bundles, 0 federation relationships, 0 registration entries, 0 attested nodes and 1 join tokens.\n", test.stdout.String())
+
+	path := filepath.Join(test.dir, "snapshot.json")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	s, err := snapshot.Read(f)
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{bundle}, s.Bundles)
+}
+
+func TestImport(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+		expectImported bool
+		serverRunning  bool
+	}{
+		{
+			name:           "missing input",
+			args:           []string{},
+			expectedCode:   1,
+			expectedStderr: "input is required\n",
+		},
+		{
+			name:           "unknown mode",
+			args:           []string{"-input", "snapshot.json", "-mode", "foo"},
+			expectedCode:   1,
+			expectedStderr: "unknown import mode \"foo\"\n",
+		},
+		{
+			name:           "dry run",
+			args:           []string{"-input", "snapshot.json", "-dryRun"},
+			expectedStdout: "create bundle spiffe://example.org\ncreate join token foo\nDry run: 2 changes would be made.\n",
+		},
+		{
+			name:           "merge",
+			args:           []string{"-input", "snapshot.json"},
+			expectedStdout: "create bundle spiffe://example.org\ncreate join token foo\n2 changes made.\n",
+			expectImported: true,
+		},
+		{
+			name:           "server running",
+			args:           []string{"-input", "snapshot.json"},
+			expectedCode:   1,
+			serverRunning:  true,
+			expectedStderr: "the server is running, since its registration API socket \"registration.sock\" accepts connections; stop it before importing or pass -force\n",
+		},
+		{
+			name:           "dry run with server running",
+			args:           []string{"-input", "snapshot.json", "-dryRun"},
+			serverRunning:  true,
+			expectedStdout: "create bundle spiffe://example.org\ncreate join token foo\nDry run: 2 changes would be made.\n",
+		},
+		{
+			name:           "forced with server running",
+			args:           []string{"-input", "snapshot.json", "-force"},
+			serverRunning:  true,
+			expectedStdout: "create bundle spiffe://example.org\ncreate join token foo\n2 changes made.\n",
+			expectImported: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newImportCommand)
+			defer test.cleanup()
+			if tt.serverRunning {
+				listener, err := net.Listen("unix", filepath.Join(test.dir, "registration.sock"))
+				require.NoError(t, err)
+				defer listener.Close()
+			}
+			test.writeSnapshot(t, "snapshot.json", &snapshot.Snapshot{
+				Version:    snapshot.Version,
+				Bundles:    []*common.Bundle{bundle},
+				JoinTokens: []*datastore.JoinToken{joinToken},
+			})
+
+			require.Equal(t, tt.expectedCode, test.cmd.Run(tt.args))
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+
+			resp, err := test.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+				TrustDomainId: bundle.TrustDomainId,
+			})
+			require.NoError(t, err)
+			if tt.expectImported {
+				spiretest.RequireProtoEqual(t, bundle, resp.Bundle)
+			} else {
+				require.Nil(t, resp.Bundle)
+			}
+		})
+	}
+}
+
+func TestLoadDataStoreFailure(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	env := &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: new(bytes.Buffer),
+		Stderr: stderr,
+	}
+	loader := func(context.Context, *common_cli.Env, string, bool) (*serverDataStore, func(), error) {
+		return nil, nil, errors.New("oh no")
+	}
+
+	cmd := newExportCommand(env, loader)
+	require.Equal(t, 1, cmd.Run(nil))
+	require.Equal(t, "Failed to load datastore: oh no\n", stderr.String())
+}
+
+type datastoreTest struct {
+	dir    string
+	ds     *fakedatastore.DataStore
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	cmd    cli.Command
+}
+
+func setupTest(t *testing.T, newCmd func(*common_cli.Env, dataStoreLoader) cli.Command) *datastoreTest {
+	dir, err := ioutil.TempDir("", "spire-server-cli-datastore-")
+	require.NoError(t, err)
+
+	ds := fakedatastore.New()
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	env := &common_cli.Env{
+		Stdin:   new(bytes.Buffer),
+		Stdout:  stdout,
+		Stderr:  stderr,
+		BaseDir: dir,
+	}
+	loader := func(ctx context.Context, env *common_cli.Env, configPath string, expandEnv bool) (*serverDataStore, func(), error) {
+		require.Equal(t, filepath.Join(dir, "conf/server/server.conf"), configPath)
+		return &serverDataStore{
+			DataStore:           ds,
+			trustDomainID:       "spiffe://example.org",
+			registrationUDSPath: "registration.sock",
+		}, func() {}, nil
+	}
+
+	return &datastoreTest{
+		dir:    dir,
+		ds:     ds,
+		stdout: stdout,
+		stderr: stderr,
+		cmd:    newCmd(env, loader),
+	}
+}
+
+func (d *datastoreTest) cleanup() {
+	os.RemoveAll(d.dir)
+}
+
+func (d *datastoreTest) createRecords(t *testing.T) {
+	_, err := d.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+	_, err = d.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{JoinToken: joinToken})
+	require.NoError(t, err)
+}
+
+func (d *datastoreTest) writeSnapshot(t *testing.T, name string, s *snapshot.Snapshot) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, snapshot.Write(buf, s))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(d.dir, name), buf.Bytes(), 0600))
+}
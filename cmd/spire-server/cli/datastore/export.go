@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+)
+
+// NewExportCommand creates a new "export" subcommand for "datastore" command.
+func NewExportCommand() cli.Command {
+	return newExportCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newExportCommand(env *common_cli.Env, loader dataStoreLoader) cli.Command {
+	return adaptCommand(env, loader, new(exportCommand))
+}
+
+type exportCommand struct {
+	// Path to write the snapshot to. Defaults to stdout.
+	output string
+}
+
+func (c *exportCommand) name() string {
+	return "datastore export"
+}
+
+func (c *exportCommand) synopsis() string {
+	return "Exports the datastore contents to a snapshot"
+}
+
+func (c *exportCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.output, "output", "", "Path to write the snapshot to. If unset, the snapshot is written to stdout")
+}
+
+func (c *exportCommand) run(ctx context.Context, env *common_cli.Env, ds *serverDataStore) error {
+	s, err := snapshot.Export(ctx, ds)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		return snapshot.Write(env.Stdout, s)
+	}
+
+	// The snapshot holds join tokens, so it is only readable by the owner
+	f, err := os.OpenFile(env.JoinPath(c.output), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Write(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return env.Printf("Exported %d bundles, %d federation relationships, %d registration entries, %d attested nodes and %d join tokens.\n",
+		len(s.Bundles), len(s.FederationRelationships), len(s.RegistrationEntries), len(s.AttestedNodes), len(s.JoinTokens))
+}
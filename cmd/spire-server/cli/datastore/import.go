@@ -0,0 +1,115 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/snapshot"
+)
+
+// serverDialTimeout is how long to wait for the server to accept a
+// connection on the registration API socket when checking whether it runs
+const serverDialTimeout = time.Second
+
+// NewImportCommand creates a new "import" subcommand for "datastore" command.
+func NewImportCommand() cli.Command {
+	return newImportCommand(common_cli.DefaultEnv, loadDataStore)
+}
+
+func newImportCommand(env *common_cli.Env, loader dataStoreLoader) cli.Command {
+	return adaptCommand(env, loader, new(importCommand))
+}
+
+type importCommand struct {
+	// Path to read the snapshot from
+	input string
+
+	// Import mode (i.e. merge or replace)
+	mode string
+
+	// Whether to only print the changes the import would make
+	dryRun bool
+
+	// Whether to import even if the server is running
+	force bool
+}
+
+func (c *importCommand) name() string {
+	return "datastore import"
+}
+
+func (c *importCommand) synopsis() string {
+	return "Imports a snapshot into the datastore"
+}
+
+func (c *importCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.input, "input", "", "Path to the snapshot to import")
+	fs.StringVar(&c.mode, "mode", "merge", "Import mode: merge keeps the records that are not in the snapshot, replace deletes them")
+	fs.BoolVar(&c.dryRun, "dryRun", false, "Print the changes the import would make without making them")
+	fs.BoolVar(&c.force, "force", false, "Import even if the server is running")
+}
+
+func (c *importCommand) run(ctx context.Context, env *common_cli.Env, ds *serverDataStore) error {
+	if c.input == "" {
+		return errors.New("input is required")
+	}
+	mode, err := snapshot.ParseMode(c.mode)
+	if err != nil {
+		return err
+	}
+
+	// The server caches the datastore contents and keeps writing to it, so
+	// changing the datastore under a running server is unsafe
+	if !c.dryRun && !c.force && serverRunning(env.JoinPath(ds.registrationUDSPath)) {
+		return fmt.Errorf("the server is running, since its registration API socket %q accepts connections; stop it before importing or pass -force", ds.registrationUDSPath)
+	}
+
+	f, err := os.Open(env.JoinPath(c.input))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := snapshot.Read(f)
+	if err != nil {
+		return err
+	}
+
+	changes, err := snapshot.Import(ctx, ds, ds.trustDomainID, s, mode, c.dryRun)
+	for _, change := range changes {
+		if err := env.Println(change); err != nil {
+			return err
+		}
+	}
+	switch {
+	case err != nil && len(changes) > 0 && !c.dryRun:
+		return fmt.Errorf("%v; the changes made before the failure are kept", err)
+	case err != nil:
+		return err
+	}
+
+	msg := util.Pluralizer(fmt.Sprintf("%d change", len(changes)), "", "s", len(changes))
+	if c.dryRun {
+		return env.Printf("Dry run: %s would be made.\n", msg)
+	}
+	return env.Printf("%s made.\n", msg)
+}
+
+// serverRunning returns whether a server accepts connections on the given
+// registration API socket.
+func serverRunning(socketPath string) bool {
+	conn, err := net.DialTimeout("unix", socketPath, serverDialTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -31,6 +31,10 @@ type CreateConfig struct {
 	SpiffeID string
 	TTL      int
 
+	// Lifetime of JWT-SVIDs based on this entry. The server default is used
+	// if zero.
+	JWTSVIDTTL int
+
 	// List of SPIFFE IDs of trust domains the registration entry is federated with
 	FederatesWith StringsFlag
 
@@ -48,6 +52,15 @@ type CreateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// X509-SVID profile for SVIDs based on this entry
+	CommonName          string
+	Countries           StringsFlag
+	Organizations       StringsFlag
+	OrganizationalUnits StringsFlag
+	IPAddresses         StringsFlag
+	URIs                StringsFlag
+	ExtKeyUsages        StringsFlag
 }
 
 // Validate performs basic validation, even on fields that we
@@ -82,6 +95,10 @@ func (rc *CreateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("a JWT-SVID TTL must not be negative")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -162,9 +179,12 @@ func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntr
 		ParentId:    config.ParentID,
 		SpiffeId:    config.SpiffeID,
 		Ttl:         int32(config.TTL),
+		JwtSvidTtl:  int32(config.JWTSVIDTTL),
 		Downstream:  config.Downstream,
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
+		X509SvidProfile: buildX509SVIDProfile(config.CommonName, config.Countries, config.Organizations,
+			config.OrganizationalUnits, config.IPAddresses, config.URIs, config.ExtKeyUsages),
 	}
 
 	// If the node flag is set, then set the Parent ID to the server's expected SPIFFE ID
@@ -231,7 +251,8 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.ParentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
-	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for SVIDs issued based on this registration entry")
+	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for X509-SVIDs issued based on this registration entry")
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. The server default is used if zero")
 
 	f.StringVar(&c.Path, "data", "", "Path to a file containing registration JSON (optional)")
 
@@ -245,5 +266,13 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 
+	f.StringVar(&c.CommonName, "commonName", "", "The subject common name of X509-SVIDs issued based on this entry. Overridden by the first DNS name, if any")
+	f.Var(&c.Countries, "country", "A subject country of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.Organizations, "organization", "A subject organization of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.OrganizationalUnits, "organizationalUnit", "A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.IPAddresses, "ip", "An IP address that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.URIs, "uri", "A URI, other than a SPIFFE ID, that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.ExtKeyUsages, "extKeyUsage", "An extended key usage (serverAuth or clientAuth) X509-SVIDs issued based on this entry are restricted to. Can be used more than once")
+
 	return c, f.Parse(args)
 }
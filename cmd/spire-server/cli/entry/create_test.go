@@ -59,6 +59,7 @@ func TestCreateParseConfig(t *testing.T) {
 		ParentID:            "spiffe://example.org/foo",
 		SpiffeID:            "spiffe://example.org/bar",
 		TTL:                 60,
+		JWTSVIDTTL:          30,
 		Selectors:           StringsFlag{"unix:uid:1000", "unix:gid:1000"},
 		FederatesWith:       StringsFlag{"spiffe://domain1.test", "spiffe://domain2.test"},
 		Admin:               true,
@@ -69,9 +70,10 @@ func TestCreateParseConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	expectedEntry := &common.RegistrationEntry{
-		ParentId: "spiffe://example.org/foo",
-		SpiffeId: "spiffe://example.org/bar",
-		Ttl:      60,
+		ParentId:   "spiffe://example.org/foo",
+		SpiffeId:   "spiffe://example.org/bar",
+		Ttl:        60,
+		JwtSvidTtl: 30,
 		Selectors: []*common.Selector{
 			{Type: "unix", Value: "uid:1000"},
 			{Type: "unix", Value: "gid:1000"},
@@ -88,6 +90,48 @@ func TestCreateParseConfig(t *testing.T) {
 	assert.Equal(t, expectedEntries, entries)
 }
 
+func TestCreateParseConfigWithX509SVIDProfile(t *testing.T) {
+	c, err := CreateCLI{}.newConfig([]string{
+		"-parentID", "spiffe://example.org/foo",
+		"-spiffeID", "spiffe://example.org/bar",
+		"-selector", "unix:uid:1000",
+		"-commonName", "bar",
+		"-organization", "Example",
+		"-organization", "Example Two",
+		"-ip", "10.0.0.1",
+		"-uri", "https://example.org/bar",
+		"-extKeyUsage", "serverAuth",
+	})
+	require.NoError(t, err)
+
+	entries, err := CreateCLI{}.parseConfig(c)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, &common.X509SVIDProfile{
+		Subject: &common.X509Subject{
+			Organization: []string{"Example", "Example Two"},
+			CommonName:   "bar",
+		},
+		IpAddresses:  []string{"10.0.0.1"},
+		Uris:         []string{"https://example.org/bar"},
+		ExtKeyUsages: []string{"serverAuth"},
+	}, entries[0].X509SvidProfile)
+
+	// without profile flags, entries have no profile
+	c, err = CreateCLI{}.newConfig([]string{
+		"-parentID", "spiffe://example.org/foo",
+		"-spiffeID", "spiffe://example.org/bar",
+		"-selector", "unix:uid:1000",
+	})
+	require.NoError(t, err)
+
+	entries, err = CreateCLI{}.parseConfig(c)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Nil(t, entries[0].X509SvidProfile)
+}
+
 func TestCreateNodeParseConfig(t *testing.T) {
 	c := &CreateConfig{
 		RegistrationUDSPath: cmdutil.DefaultSocketPath,
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 
@@ -17,6 +18,9 @@ type DeleteConfig struct {
 
 	// ID of the record to delete
 	EntryID string
+
+	// Revision number the entry is expected to have, if not negative
+	Revision int64
 }
 
 // Perform basic validation
@@ -60,9 +64,12 @@ func (d DeleteCLI) Run(args []string) int {
 		return d.printErr(err)
 	}
 
-	req := &registration.RegistrationEntryID{
+	req := &registration.DeleteEntryRequest{
 		Id: config.EntryID,
 	}
+	if config.Revision >= 0 {
+		req.ExpectedRevisionNumber = &wrappers.Int64Value{Value: config.Revision}
+	}
 	e, err := cl.DeleteEntry(ctx, req)
 	if err != nil {
 		return d.printErr(err)
@@ -79,6 +86,7 @@ func (DeleteCLI) newConfig(args []string) (*DeleteConfig, error) {
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to delete")
+	f.Int64Var(&c.Revision, "revision", -1, "The revision number the entry is expected to have. If set, the delete fails if the entry was updated since (optional)")
 
 	return c, f.Parse(args)
 }
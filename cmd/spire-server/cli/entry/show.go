@@ -4,7 +4,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	commonutil "github.com/spiffe/spire/pkg/common/util"
@@ -14,6 +16,10 @@ import (
 	"golang.org/x/net/context"
 )
 
+// listEntriesPageSize is the number of entries fetched at once when entries
+// are listed with a filter
+const listEntriesPageSize = 500
+
 // ShowConfig is a configuration struct for the
 // `spire-server entry show` CLI command
 type ShowConfig struct {
@@ -24,26 +30,95 @@ type ShowConfig struct {
 	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
 	Selectors StringsFlag
 
+	// How entry selectors are matched: exact, subset, any or superset
+	MatchSelectorsOn string
+
 	EntryID  string
 	ParentID string
 	SpiffeID string
 
 	FederatesWith StringsFlag
 	Downstream    bool
+	Admin         bool
+	DNSName       string
+
+	// Expiry window, in seconds since epoch
+	ExpiresAfter  int64
+	ExpiresBefore int64
 }
 
 // Validate ensures that the values in ShowConfig are valid
 func (sc *ShowConfig) Validate() error {
 	// If entryID is given, it should be the only constraint
 	if sc.EntryID != "" {
-		if sc.ParentID != "" || sc.SpiffeID != "" || len(sc.Selectors) > 0 {
+		if sc.ParentID != "" || sc.SpiffeID != "" || len(sc.Selectors) > 0 || sc.hasListFilters() {
 			return errors.New("the -entryID flag can't be combined with others")
 		}
 	}
 
+	if _, err := util.ParseSelectorMatch(sc.MatchSelectorsOn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// hasListFilters returns true if any of the filters that are only applied
+// when listing entries with a filter are configured
+func (sc *ShowConfig) hasListFilters() bool {
+	return !strings.EqualFold(sc.MatchSelectorsOn, "exact") ||
+		len(sc.FederatesWith) > 0 ||
+		sc.Downstream ||
+		sc.Admin ||
+		sc.DNSName != "" ||
+		sc.ExpiresAfter != 0 ||
+		sc.ExpiresBefore != 0
+}
+
+// listAllEntriesFilter builds the filter that lists the entries matching
+// the configured constraints
+func (sc *ShowConfig) listAllEntriesFilter() (*registration.ListAllEntriesRequest_Filter, error) {
+	filter := &registration.ListAllEntriesRequest_Filter{
+		ByFederatesWith: sc.FederatesWith,
+	}
+	if sc.ParentID != "" {
+		filter.ByParentId = &wrappers.StringValue{Value: sc.ParentID}
+	}
+	if sc.SpiffeID != "" {
+		filter.BySpiffeId = &wrappers.StringValue{Value: sc.SpiffeID}
+	}
+	for _, sel := range sc.Selectors {
+		selector, err := parseSelector(sel)
+		if err != nil {
+			return nil, err
+		}
+		filter.BySelectors = append(filter.BySelectors, selector)
+	}
+	if len(filter.BySelectors) > 0 {
+		match, err := util.ParseSelectorMatch(sc.MatchSelectorsOn)
+		if err != nil {
+			return nil, err
+		}
+		filter.SelectorMatch = match
+	}
+	if sc.Downstream {
+		filter.ByDownstream = &wrappers.BoolValue{Value: true}
+	}
+	if sc.Admin {
+		filter.ByAdmin = &wrappers.BoolValue{Value: true}
+	}
+	if sc.DNSName != "" {
+		filter.ByDnsName = &wrappers.StringValue{Value: sc.DNSName}
+	}
+	if sc.ExpiresAfter != 0 {
+		filter.ByExpiresAfter = &wrappers.Int64Value{Value: sc.ExpiresAfter}
+	}
+	if sc.ExpiresBefore != 0 {
+		filter.ByExpiresBefore = &wrappers.Int64Value{Value: sc.ExpiresBefore}
+	}
+	return filter, nil
+}
+
 // ShowCLI is a struct which represents an invocation of the
 // `spire-server entry show` CLI command
 type ShowCLI struct {
@@ -75,6 +150,11 @@ func (s *ShowCLI) Run(args []string) int {
 		return 1
 	}
 
+	if err := s.Config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
 	if s.Client == nil {
 		s.Client, err = util.NewRegistrationClient(s.Config.RegistrationUDSPath)
 		if err != nil {
@@ -83,6 +163,18 @@ func (s *ShowCLI) Run(args []string) int {
 		}
 	}
 
+	// Entries listed with a filter are fully filtered by the server
+	if s.Config.EntryID == "" && s.Config.hasListFilters() {
+		err = s.fetchWithFilter(ctx)
+		if err != nil {
+			fmt.Printf("Error fetching entries: %s\n", err)
+			return 1
+		}
+		commonutil.SortRegistrationEntries(s.Entries)
+		s.printEntries()
+		return 0
+	}
+
 	err = s.fetchEntries(ctx)
 	if err != nil {
 		return 1
@@ -94,6 +186,34 @@ func (s *ShowCLI) Run(args []string) int {
 	return 0
 }
 
+// fetchWithFilter fetches all registration entries matching the configured
+// constraints, one page at a time
+func (s *ShowCLI) fetchWithFilter(ctx context.Context) error {
+	filter, err := s.Config.listAllEntriesFilter()
+	if err != nil {
+		return err
+	}
+
+	var token string
+	for {
+		resp, err := s.Client.ListAllEntriesWithPages(ctx, &registration.ListAllEntriesRequest{
+			Filter: filter,
+			Pagination: &registration.Pagination{
+				Token:    token,
+				PageSize: listEntriesPageSize,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		s.Entries = append(s.Entries, resp.Entries...)
+		token = resp.Pagination.GetToken()
+		if token == "" {
+			return nil
+		}
+	}
+}
+
 func (s *ShowCLI) fetchEntries(ctx context.Context) error {
 	// If an Entry ID was specified, look it up directly
 	if s.Config.EntryID != "" {
@@ -293,7 +413,12 @@ func (s *ShowCLI) loadConfig(args []string) error {
 	f.StringVar(&c.EntryID, "entryID", "", "The Entry ID of the records to show")
 	f.StringVar(&c.ParentID, "parentID", "", "The Parent ID of the records to show")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the records to show")
-	f.BoolVar(&c.Downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
+	f.BoolVar(&c.Downstream, "downstream", false, "A boolean value that, when set, shows only entries describing a downstream SPIRE server")
+	f.BoolVar(&c.Admin, "admin", false, "A boolean value that, when set, shows only admin entries")
+	f.StringVar(&c.DNSName, "dns", "", "A DNS name the records to show must have")
+	f.StringVar(&c.MatchSelectorsOn, "matchSelectorsOn", "exact", "How entry selectors are matched against the selectors: exact, subset, any or superset")
+	f.Int64Var(&c.ExpiresAfter, "expiresAfter", 0, "Shows only entries expiring after this time, from epoch in seconds. Entries without an expiry are included")
+	f.Int64Var(&c.ExpiresBefore, "expiresBefore", 0, "Shows only entries expiring before this time, from epoch in seconds")
 
 	f.Var(&c.Selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
 	f.Var(&c.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain an entry is federate with. Can be used more than once")
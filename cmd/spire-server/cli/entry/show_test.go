@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -162,10 +163,17 @@ func (s *ShowTestSuite) TestRunWithParentIDAndSelectors() {
 }
 
 func (s *ShowTestSuite) TestRunWithFederatesWith() {
-	resp := &common.RegistrationEntries{
-		Entries: s.registrationEntries(4),
+	req := &registration.ListAllEntriesRequest{
+		Filter: &registration.ListAllEntriesRequest_Filter{
+			ByFederatesWith: []string{"spiffe://domain.test"},
+		},
+		Pagination: &registration.Pagination{PageSize: listEntriesPageSize},
+	}
+	resp := &registration.ListAllEntriesResponse{
+		Entries:    s.registrationEntries(4)[2:3],
+		Pagination: &registration.Pagination{PageSize: listEntriesPageSize},
 	}
-	s.mockClient.EXPECT().FetchEntries(gomock.Any(), &common.Empty{}).Return(resp, nil)
+	s.mockClient.EXPECT().ListAllEntriesWithPages(gomock.Any(), req).Return(resp, nil)
 
 	args := []string{
 		"-federatesWith",
@@ -179,6 +187,59 @@ func (s *ShowTestSuite) TestRunWithFederatesWith() {
 	s.Assert().Equal(expectEntries, s.cli.Entries)
 }
 
+func (s *ShowTestSuite) TestRunWithListFilters() {
+	entries := s.registrationEntries(4)
+
+	args := []string{
+		"-parentID", "spiffe://example.org/mother",
+		"-selector", "baz:bat",
+		"-matchSelectorsOn", "superset",
+		"-downstream",
+		"-admin",
+		"-dns", "example.org",
+		"-expiresAfter", "1000",
+		"-expiresBefore", "2000",
+	}
+
+	filter := &registration.ListAllEntriesRequest_Filter{
+		ByParentId:      &wrappers.StringValue{Value: "spiffe://example.org/mother"},
+		BySelectors:     []*common.Selector{{Type: "baz", Value: "bat"}},
+		SelectorMatch:   registration.SelectorMatch_MATCH_SUPERSET,
+		ByDownstream:    &wrappers.BoolValue{Value: true},
+		ByAdmin:         &wrappers.BoolValue{Value: true},
+		ByDnsName:       &wrappers.StringValue{Value: "example.org"},
+		ByExpiresAfter:  &wrappers.Int64Value{Value: 1000},
+		ByExpiresBefore: &wrappers.Int64Value{Value: 2000},
+	}
+
+	// Entries are fetched one page at a time
+	s.mockClient.EXPECT().ListAllEntriesWithPages(gomock.Any(), &registration.ListAllEntriesRequest{
+		Filter:     filter,
+		Pagination: &registration.Pagination{PageSize: listEntriesPageSize},
+	}).Return(&registration.ListAllEntriesResponse{
+		Entries:    entries[3:4],
+		Pagination: &registration.Pagination{Token: "token", PageSize: listEntriesPageSize},
+	}, nil)
+	s.mockClient.EXPECT().ListAllEntriesWithPages(gomock.Any(), &registration.ListAllEntriesRequest{
+		Filter:     filter,
+		Pagination: &registration.Pagination{Token: "token", PageSize: listEntriesPageSize},
+	}).Return(&registration.ListAllEntriesResponse{
+		Entries:    entries[2:3],
+		Pagination: &registration.Pagination{PageSize: listEntriesPageSize},
+	}, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+
+	expectEntries := entries[2:4]
+	util.SortRegistrationEntries(expectEntries)
+	s.Assert().Equal(expectEntries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithInvalidFilters() {
+	s.Require().Equal(1, s.cli.Run([]string{"-selector", "foo:bar", "-matchSelectorsOn", "invalid"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-entryID", "123456", "-downstream"}))
+}
+
 // registrationEntries returns `count` registration entry records. At most 4.
 func (ShowTestSuite) registrationEntries(count int) []*common.RegistrationEntry {
 	selectors := []*common.Selector{
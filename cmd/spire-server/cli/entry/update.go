@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
@@ -35,6 +37,10 @@ type UpdateConfig struct {
 	Downstream bool
 	TTL        int
 
+	// Lifetime of JWT-SVIDs based on this entry. The server default is used
+	// if zero.
+	JWTSVIDTTL int
+
 	// List of SPIFFE IDs of trust domains the registration entry is federated with
 	FederatesWith StringsFlag
 
@@ -46,6 +52,22 @@ type UpdateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// X509-SVID profile for SVIDs based on this entry
+	CommonName          string
+	Countries           StringsFlag
+	Organizations       StringsFlag
+	OrganizationalUnits StringsFlag
+	IPAddresses         StringsFlag
+	URIs                StringsFlag
+	ExtKeyUsages        StringsFlag
+
+	// Revision number the entry is expected to have, if not negative
+	Revision int64
+
+	// Fields of the registration entry set through flags. Only these
+	// fields are updated.
+	Mask *common.RegistrationEntryMask
 }
 
 // Validate performs basic validation, even on fields that we
@@ -57,6 +79,9 @@ func (rc *UpdateConfig) Validate() (err error) {
 
 	// If a path is set, we have all we need
 	if rc.Path != "" {
+		if rc.Revision >= 0 {
+			return errors.New("the revision cannot be used with a data file")
+		}
 		return nil
 	}
 
@@ -64,15 +89,19 @@ func (rc *UpdateConfig) Validate() (err error) {
 		return errors.New("entry ID is required")
 	}
 
-	if len(rc.Selectors) < 1 {
+	if rc.Mask == nil || proto.Equal(rc.Mask, &common.RegistrationEntryMask{}) {
+		return errors.New("at least one field to update is required")
+	}
+
+	if rc.Mask.Selectors && len(rc.Selectors) < 1 {
 		return errors.New("at least one selector is required")
 	}
 
-	if rc.ParentID == "" {
+	if rc.Mask.ParentId && rc.ParentID == "" {
 		return errors.New("a parent ID is required")
 	}
 
-	if rc.SpiffeID == "" {
+	if rc.Mask.SpiffeId && rc.SpiffeID == "" {
 		return errors.New("a SPIFFE ID is required")
 	}
 
@@ -80,14 +109,22 @@ func (rc *UpdateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("a JWT-SVID TTL must not be negative")
+	}
+
 	// make sure all SPIFFE ID's are well formed
-	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
-	if err != nil {
-		return err
+	if rc.Mask.SpiffeId {
+		rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
+		if err != nil {
+			return err
+		}
 	}
-	rc.ParentID, err = idutil.NormalizeSpiffeID(rc.ParentID, idutil.AllowAny())
-	if err != nil {
-		return err
+	if rc.Mask.ParentId {
+		rc.ParentID, err = idutil.NormalizeSpiffeID(rc.ParentID, idutil.AllowAny())
+		if err != nil {
+			return err
+		}
 	}
 	for i := range rc.FederatesWith {
 		rc.FederatesWith[i], err = idutil.NormalizeSpiffeID(rc.FederatesWith[i], idutil.AllowAny())
@@ -124,11 +161,14 @@ func (c UpdateCLI) Run(args []string) int {
 		return 1
 	}
 
+	// Entries read from a data file are updated as a whole
 	var entries []*common.RegistrationEntry
+	var mask *common.RegistrationEntryMask
 	if config.Path != "" {
 		entries, err = c.parseFile(config.Path)
 	} else {
 		entries, err = c.parseConfig(config)
+		mask = config.Mask
 	}
 	if err != nil {
 		fmt.Println(err.Error())
@@ -141,7 +181,12 @@ func (c UpdateCLI) Run(args []string) int {
 		return 1
 	}
 
-	err = c.registerEntries(ctx, cl, entries)
+	var expectedRevision *wrappers.Int64Value
+	if config.Revision >= 0 {
+		expectedRevision = &wrappers.Int64Value{Value: config.Revision}
+	}
+
+	err = c.registerEntries(ctx, cl, entries, mask, expectedRevision)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
@@ -157,9 +202,12 @@ func (c UpdateCLI) parseConfig(config *UpdateConfig) ([]*common.RegistrationEntr
 		ParentId:    config.ParentID,
 		SpiffeId:    config.SpiffeID,
 		Ttl:         int32(config.TTL),
+		JwtSvidTtl:  int32(config.JWTSVIDTTL),
 		Downstream:  config.Downstream,
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
+		X509SvidProfile: buildX509SVIDProfile(config.CommonName, config.Countries, config.Organizations,
+			config.OrganizationalUnits, config.IPAddresses, config.URIs, config.ExtKeyUsages),
 	}
 
 	selectors := []*common.Selector{}
@@ -192,10 +240,12 @@ func (UpdateCLI) parseFile(path string) ([]*common.RegistrationEntry, error) {
 	return entries.Entries, nil
 }
 
-func (UpdateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry) error {
+func (UpdateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry, mask *common.RegistrationEntryMask, expectedRevision *wrappers.Int64Value) error {
 	for _, e := range entries {
 		updated, err := c.UpdateEntry(ctx, &registration.UpdateEntryRequest{
-			Entry: e,
+			Entry:                  e,
+			ExpectedRevisionNumber: expectedRevision,
+			InputMask:              mask,
 		})
 		if err != nil {
 			fmt.Println("FAILED to update the following entry:")
@@ -217,7 +267,8 @@ func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.ParentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
-	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for SVIDs issued based on this registration entry")
+	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for X509-SVIDs issued based on this registration entry")
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. The server default is used if zero")
 
 	f.StringVar(&c.Path, "data", "", "Path to a file containing registration JSON (optional)")
 
@@ -231,5 +282,47 @@ func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 
-	return c, f.Parse(args)
+	f.StringVar(&c.CommonName, "commonName", "", "The subject common name of X509-SVIDs issued based on this entry. Overridden by the first DNS name, if any")
+	f.Var(&c.Countries, "country", "A subject country of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.Organizations, "organization", "A subject organization of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.OrganizationalUnits, "organizationalUnit", "A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.IPAddresses, "ip", "An IP address that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.URIs, "uri", "A URI, other than a SPIFFE ID, that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.ExtKeyUsages, "extKeyUsage", "An extended key usage (serverAuth or clientAuth) X509-SVIDs issued based on this entry are restricted to. Can be used more than once")
+
+	f.Int64Var(&c.Revision, "revision", -1, "The revision number the entry is expected to have. If set, the update fails if the entry was updated since (optional)")
+
+	if err := f.Parse(args); err != nil {
+		return c, err
+	}
+
+	c.Mask = &common.RegistrationEntryMask{}
+	f.Visit(func(set *flag.Flag) {
+		switch set.Name {
+		case "selector":
+			c.Mask.Selectors = true
+		case "parentID":
+			c.Mask.ParentId = true
+		case "spiffeID":
+			c.Mask.SpiffeId = true
+		case "ttl":
+			c.Mask.Ttl = true
+		case "jwtSVIDTTL":
+			c.Mask.JwtSvidTtl = true
+		case "federatesWith":
+			c.Mask.FederatesWith = true
+		case "admin":
+			c.Mask.Admin = true
+		case "downstream":
+			c.Mask.Downstream = true
+		case "entryExpiry":
+			c.Mask.EntryExpiry = true
+		case "dns":
+			c.Mask.DnsNames = true
+		case "commonName", "country", "organization", "organizationalUnit", "ip", "uri", "extKeyUsage":
+			// The profile is updated as a whole
+			c.Mask.X509SvidProfile = true
+		}
+	})
+	return c, nil
 }
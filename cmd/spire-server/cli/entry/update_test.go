@@ -48,11 +48,93 @@ func TestUpdateCLI(t *testing.T) {
 		Admin:               true,
 		EntryExpiry:         1552410266,
 		DNSNames:            StringsFlag{"unu1000", "ung1000", "aa2000", "zz2000"},
+		Revision:            -1,
+		Mask: &common.RegistrationEntryMask{
+			Selectors:     true,
+			ParentId:      true,
+			SpiffeId:      true,
+			Ttl:           true,
+			FederatesWith: true,
+			Admin:         true,
+			EntryExpiry:   true,
+			DnsNames:      true,
+		},
 	}
 
 	assert.Equal(t, updatedConfig, c)
 }
 
+func TestUpdateCLIOnlyProvidedFields(t *testing.T) {
+	c, err := UpdateCLI{}.newConfig([]string{
+		"-entryID", "ENTRYID",
+		"-ttl", "60",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	assert.Equal(t, &common.RegistrationEntryMask{Ttl: true}, c.Mask)
+
+	c, err = UpdateCLI{}.newConfig([]string{
+		"-entryID", "ENTRYID",
+		"-admin=false",
+		"-dns", "example.org",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	assert.Equal(t, &common.RegistrationEntryMask{Admin: true, DnsNames: true}, c.Mask)
+
+	c, err = UpdateCLI{}.newConfig([]string{
+		"-entryID", "ENTRYID",
+		"-ip", "10.0.0.1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	assert.Equal(t, &common.RegistrationEntryMask{X509SvidProfile: true}, c.Mask)
+
+	c, err = UpdateCLI{}.newConfig([]string{
+		"-entryID", "ENTRYID",
+		"-jwtSVIDTTL", "300",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	assert.Equal(t, &common.RegistrationEntryMask{JwtSvidTtl: true}, c.Mask)
+}
+
+func TestUpdateValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "missing entry ID",
+			args: []string{"-ttl", "60"},
+			err:  "entry ID is required",
+		},
+		{
+			name: "no fields to update",
+			args: []string{"-entryID", "ENTRYID"},
+			err:  "at least one field to update is required",
+		},
+		{
+			name: "empty parent ID",
+			args: []string{"-entryID", "ENTRYID", "-parentID", ""},
+			err:  "a parent ID is required",
+		},
+		{
+			name: "malformed SPIFFE ID",
+			args: []string{"-entryID", "ENTRYID", "-spiffeID", "foo"},
+			err:  `"foo" is not a valid SPIFFE ID: invalid scheme`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := UpdateCLI{}.newConfig(tt.args)
+			require.NoError(t, err)
+			require.EqualError(t, c.Validate(), tt.err)
+		})
+	}
+}
+
 func TestUpdateParseConfig(t *testing.T) {
 	c := &UpdateConfig{
 		RegistrationUDSPath: cmdutil.DefaultSocketPath,
@@ -123,3 +205,23 @@ func TestUpdateParseFile(t *testing.T) {
 	}
 	assert.Equal(t, expectedEntries, entries)
 }
+
+func TestUpdateValidateRevision(t *testing.T) {
+	c, err := UpdateCLI{}.newConfig([]string{
+		"-data", "entries.json",
+		"-revision", "2",
+	})
+	require.NoError(t, err)
+	require.EqualError(t, c.Validate(), "the revision cannot be used with a data file")
+
+	c, err = UpdateCLI{}.newConfig([]string{
+		"-entryID", "ENTRYID",
+		"-parentID", "spiffe://example.org/foo",
+		"-spiffeID", "spiffe://example.org/bar",
+		"-selector", "unix:uid:1000",
+		"-revision", "2",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	require.Equal(t, int64(2), c.Revision)
+}
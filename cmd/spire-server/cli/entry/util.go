@@ -1,6 +1,7 @@
 package entry
 
 import (
+	"crypto/x509/pkix"
 	"fmt"
 	"strings"
 
@@ -60,6 +61,7 @@ func printEntry(e *common.RegistrationEntry) {
 	fmt.Printf("Entry ID      : %s\n", e.EntryId)
 	fmt.Printf("SPIFFE ID     : %s\n", e.SpiffeId)
 	fmt.Printf("Parent ID     : %s\n", e.ParentId)
+	fmt.Printf("Revision      : %d\n", e.RevisionNumber)
 
 	if e.Downstream {
 		fmt.Printf("Downstream    : %t\n", e.Downstream)
@@ -71,6 +73,10 @@ func printEntry(e *common.RegistrationEntry) {
 		fmt.Printf("TTL           : %d\n", e.Ttl)
 	}
 
+	if e.JwtSvidTtl != 0 {
+		fmt.Printf("JWT-SVID TTL  : %d\n", e.JwtSvidTtl)
+	}
+
 	for _, s := range e.Selectors {
 		fmt.Printf("Selector      : %s:%s\n", s.Type, s.Value)
 	}
@@ -80,6 +86,26 @@ func printEntry(e *common.RegistrationEntry) {
 	for _, dnsName := range e.DnsNames {
 		fmt.Printf("DNS name      : %s\n", dnsName)
 	}
+	if profile := e.X509SvidProfile; profile != nil {
+		if subject := profile.Subject; subject != nil {
+			name := pkix.Name{
+				Country:            subject.Country,
+				Organization:       subject.Organization,
+				OrganizationalUnit: subject.OrganizationalUnit,
+				CommonName:         subject.CommonName,
+			}
+			fmt.Printf("Subject       : %s\n", name)
+		}
+		for _, ip := range profile.IpAddresses {
+			fmt.Printf("IP address    : %s\n", ip)
+		}
+		for _, uri := range profile.Uris {
+			fmt.Printf("URI           : %s\n", uri)
+		}
+		for _, eku := range profile.ExtKeyUsages {
+			fmt.Printf("Ext key usage : %s\n", eku)
+		}
+	}
 
 	// admin is rare, so only show admin if true to keep
 	// from muddying the output.
@@ -90,6 +116,31 @@ func printEntry(e *common.RegistrationEntry) {
 	fmt.Println()
 }
 
+// buildX509SVIDProfile builds the X509-SVID profile of an entry from the CLI
+// flags. It returns nil if none of the profile flags were set.
+func buildX509SVIDProfile(commonName string, countries, organizations, organizationalUnits, ipAddresses, uris, extKeyUsages StringsFlag) *common.X509SVIDProfile {
+	var subject *common.X509Subject
+	if commonName != "" || len(countries) > 0 || len(organizations) > 0 || len(organizationalUnits) > 0 {
+		subject = &common.X509Subject{
+			Country:            countries,
+			Organization:       organizations,
+			OrganizationalUnit: organizationalUnits,
+			CommonName:         commonName,
+		}
+	}
+
+	if subject == nil && len(ipAddresses) == 0 && len(uris) == 0 && len(extKeyUsages) == 0 {
+		return nil
+	}
+
+	return &common.X509SVIDProfile{
+		Subject:      subject,
+		IpAddresses:  ipAddresses,
+		Uris:         uris,
+		ExtKeyUsages: extKeyUsages,
+	}
+}
+
 // StringsFlag defines a custom type for string lists. Doing
 // this allows us to support repeatable string flags.
 type StringsFlag []string
@@ -0,0 +1,182 @@
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+)
+
+const (
+	profileHTTPSSPIFFE = "https_spiffe"
+	profileHTTPSWeb    = "https_web"
+)
+
+var (
+	// this is the default environment used by commands
+	defaultEnv = &env{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+)
+
+type clients struct {
+	f federation.FederationClient
+}
+
+type clientsMaker func(registrationUDSPath string) (*clients, error)
+
+// newClients is the default client maker
+func newClients(registrationUDSPath string) (*clients, error) {
+	federationClient, err := util.NewFederationClient(registrationUDSPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients{
+		f: federationClient,
+	}, nil
+}
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *env, *clients) error
+}
+
+type adapter struct {
+	env          *env
+	clientsMaker clientsMaker
+	cmd          command
+
+	registrationUDSPath string
+	flags               *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *env, clientsMaker clientsMaker, cmd command) *adapter {
+	a := &adapter{
+		clientsMaker: clientsMaker,
+		cmd:          cmd,
+		env:          env,
+	}
+
+	f := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	f.SetOutput(env.stderr)
+	f.StringVar(&a.registrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	a.cmd.appendFlags(f)
+	a.flags = f
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.flags.Parse(args); err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	clients, err := a.clientsMaker(a.registrationUDSPath)
+	if err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	if err := a.cmd.run(ctx, a.env, clients); err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	return a.flags.Parse([]string{"-h"}).Error()
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
+
+// env provides input and output facilities to commands
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (e *env) Printf(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(e.stdout, format, args...)
+	return err
+}
+
+func (e *env) Println(args ...interface{}) error {
+	_, err := fmt.Fprintln(e.stdout, args...)
+	return err
+}
+
+// relationshipFlags holds the flags that describe a federation relationship
+type relationshipFlags struct {
+	trustDomain           string
+	bundleEndpointURL     string
+	bundleEndpointProfile string
+	endpointSpiffeID      string
+}
+
+func (f *relationshipFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.trustDomain, "trustDomain", "", "Name of the trust domain to federate with (e.g., example.org)")
+	fs.StringVar(&f.bundleEndpointURL, "bundleEndpointURL", "", "URL of the bundle endpoint of the trust domain (must use the https scheme)")
+	fs.StringVar(&f.bundleEndpointProfile, "bundleEndpointProfile", "", fmt.Sprintf("Bundle endpoint profile: one of %s or %s", profileHTTPSSPIFFE, profileHTTPSWeb))
+	fs.StringVar(&f.endpointSpiffeID, "endpointSpiffeID", "", "SPIFFE ID of the bundle endpoint server (https_spiffe profile only)")
+}
+
+func profileFromFlag(profile string) (types.BundleEndpointProfile, error) {
+	switch profile {
+	case "", profileHTTPSSPIFFE:
+		return types.BundleEndpointProfile_HTTPS_SPIFFE, nil
+	case profileHTTPSWeb:
+		return types.BundleEndpointProfile_HTTPS_WEB, nil
+	default:
+		return types.BundleEndpointProfile_HTTPS_SPIFFE, fmt.Errorf("unsupported bundle endpoint profile %q", profile)
+	}
+}
+
+func profileToString(profile types.BundleEndpointProfile) string {
+	switch profile {
+	case types.BundleEndpointProfile_HTTPS_SPIFFE:
+		return profileHTTPSSPIFFE
+	case types.BundleEndpointProfile_HTTPS_WEB:
+		return profileHTTPSWeb
+	default:
+		return fmt.Sprintf("unknown(%d)", profile)
+	}
+}
+
+func printRelationship(env *env, r *types.FederationRelationship) error {
+	if err := env.Printf("Trust domain            : %s\n", r.TrustDomain); err != nil {
+		return err
+	}
+	if err := env.Printf("Bundle endpoint URL     : %s\n", r.BundleEndpointUrl); err != nil {
+		return err
+	}
+	if err := env.Printf("Bundle endpoint profile : %s\n", profileToString(r.BundleEndpointProfile)); err != nil {
+		return err
+	}
+	if r.EndpointSpiffeId != "" {
+		if err := env.Printf("Endpoint SPIFFE ID      : %s\n", r.EndpointSpiffeId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
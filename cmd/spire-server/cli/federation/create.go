@@ -0,0 +1,79 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"google.golang.org/grpc/codes"
+)
+
+// NewCreateCommand creates a new "create" subcommand for "federation" command.
+func NewCreateCommand() cli.Command {
+	return newCreateCommand(defaultEnv, newClients)
+}
+
+func newCreateCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(createCommand))
+}
+
+type createCommand struct {
+	relationshipFlags
+}
+
+func (c *createCommand) name() string {
+	return "federation create"
+}
+
+func (c *createCommand) synopsis() string {
+	return "Creates a federation relationship with a trust domain"
+}
+
+func (c *createCommand) appendFlags(fs *flag.FlagSet) {
+	c.relationshipFlags.appendFlags(fs)
+}
+
+func (c *createCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.trustDomain == "" {
+		return errors.New("trustDomain is required")
+	}
+	if c.bundleEndpointURL == "" {
+		return errors.New("bundleEndpointURL is required")
+	}
+
+	profile, err := profileFromFlag(c.bundleEndpointProfile)
+	if err != nil {
+		return err
+	}
+
+	resp, err := clients.f.BatchCreateFederationRelationship(ctx, &federation.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{
+			{
+				TrustDomain:           c.trustDomain,
+				BundleEndpointUrl:     c.bundleEndpointURL,
+				BundleEndpointProfile: profile,
+				EndpointSpiffeId:      c.endpointSpiffeID,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	result := resp.Results[0]
+	if codes.Code(result.Status.Code) != codes.OK {
+		return fmt.Errorf("failed to create federation relationship: %s", result.Status.Message)
+	}
+
+	if err := env.Println("Federation relationship created."); err != nil {
+		return err
+	}
+	if err := env.Println(); err != nil {
+		return err
+	}
+	return printRelationship(env, result.FederationRelationship)
+}
@@ -0,0 +1,58 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// NewDeleteCommand creates a new "delete" subcommand for "federation" command.
+func NewDeleteCommand() cli.Command {
+	return newDeleteCommand(defaultEnv, newClients)
+}
+
+func newDeleteCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(deleteCommand))
+}
+
+type deleteCommand struct {
+	// Name of the trust domain of the relationship
+	trustDomain string
+}
+
+func (c *deleteCommand) name() string {
+	return "federation delete"
+}
+
+func (c *deleteCommand) synopsis() string {
+	return "Deletes a federation relationship with a trust domain"
+}
+
+func (c *deleteCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.trustDomain, "trustDomain", "", "Name of the trust domain of the federation relationship (e.g., example.org)")
+}
+
+func (c *deleteCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.trustDomain == "" {
+		return errors.New("trustDomain is required")
+	}
+
+	resp, err := clients.f.BatchDeleteFederationRelationship(ctx, &federation.BatchDeleteFederationRelationshipRequest{
+		TrustDomains: []string{c.trustDomain},
+	})
+	if err != nil {
+		return err
+	}
+
+	result := resp.Results[0]
+	if codes.Code(result.Status.Code) != codes.OK {
+		return fmt.Errorf("failed to delete federation relationship: %s", result.Status.Message)
+	}
+
+	return env.Println("Federation relationship deleted.")
+}
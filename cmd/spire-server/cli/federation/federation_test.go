@@ -0,0 +1,287 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	federationv1 "github.com/spiffe/spire/pkg/server/api/federation/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	federationpb "github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestCreate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectedStdout string
+		expectedStderr string
+		expected       *datastore.FederationRelationship
+	}{
+		{
+			name:           "missing trust domain",
+			args:           []string{"-bundleEndpointURL", "https://example.org"},
+			expectedStderr: "trustDomain is required\n",
+		},
+		{
+			name:           "missing bundle endpoint URL",
+			args:           []string{"-trustDomain", "example.org"},
+			expectedStderr: "bundleEndpointURL is required\n",
+		},
+		{
+			name:           "unsupported profile",
+			args:           []string{"-trustDomain", "example.org", "-bundleEndpointURL", "https://example.org", "-bundleEndpointProfile", "foo"},
+			expectedStderr: "unsupported bundle endpoint profile \"foo\"\n",
+		},
+		{
+			name:           "server trust domain",
+			args:           []string{"-trustDomain", "domain.test", "-bundleEndpointURL", "https://domain.test"},
+			expectedStderr: "failed to create federation relationship: failed to convert federation relationship: cannot federate with the server trust domain\n",
+		},
+		{
+			name: "https_spiffe",
+			args: []string{"-trustDomain", "example.org", "-bundleEndpointURL", "https://example.org:8443", "-endpointSpiffeID", "spiffe://example.org/bundle-server"},
+			expectedStdout: `Federation relationship created.
+
+Trust domain            : example.org
+Bundle endpoint URL     : https://example.org:8443
+Bundle endpoint profile : https_spiffe
+Endpoint SPIFFE ID      : spiffe://example.org/bundle-server
+`,
+			expected: &datastore.FederationRelationship{
+				TrustDomainId:     "spiffe://example.org",
+				BundleEndpointUrl: "https://example.org:8443",
+				EndpointSpiffeId:  "spiffe://example.org/bundle-server",
+			},
+		},
+		{
+			name: "https_web",
+			args: []string{"-trustDomain", "example.org", "-bundleEndpointURL", "https://example.org", "-bundleEndpointProfile", "https_web"},
+			expectedStdout: `Federation relationship created.
+
+Trust domain            : example.org
+Bundle endpoint URL     : https://example.org
+Bundle endpoint profile : https_web
+`,
+			expected: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newCreateCommand)
+			defer test.done()
+
+			code := test.cmd.Run(tt.args)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, code)
+				return
+			}
+			require.Equal(t, 0, code)
+			spiretest.AssertProtoEqual(t, tt.expected, test.fetchRelationship(t, "spiffe://example.org"))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectedStderr string
+		expected       *datastore.FederationRelationship
+	}{
+		{
+			name:           "missing trust domain",
+			args:           []string{"-bundleEndpointURL", "https://example.org"},
+			expectedStderr: "trustDomain is required\n",
+		},
+		{
+			name:           "nothing to update",
+			args:           []string{"-trustDomain", "example.org"},
+			expectedStderr: "at least one of bundleEndpointURL, bundleEndpointProfile or endpointSpiffeID is required\n",
+		},
+		{
+			name:           "not found",
+			args:           []string{"-trustDomain", "not-found.org", "-bundleEndpointURL", "https://not-found.org"},
+			expectedStderr: "failed to update federation relationship: federation relationship not found\n",
+		},
+		{
+			name: "only provided fields are updated",
+			args: []string{"-trustDomain", "example.org", "-bundleEndpointURL", "https://example.org/new"},
+			expected: &datastore.FederationRelationship{
+				TrustDomainId:     "spiffe://example.org",
+				BundleEndpointUrl: "https://example.org/new",
+				EndpointSpiffeId:  "spiffe://example.org/bundle-server",
+			},
+		},
+		{
+			name: "switching to https_web clears the endpoint SPIFFE ID",
+			args: []string{"-trustDomain", "example.org", "-bundleEndpointProfile", "https_web"},
+			expected: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newUpdateCommand)
+			defer test.done()
+			test.createRelationship(t, &datastore.FederationRelationship{
+				TrustDomainId:     "spiffe://example.org",
+				BundleEndpointUrl: "https://example.org",
+				EndpointSpiffeId:  "spiffe://example.org/bundle-server",
+			})
+
+			code := test.cmd.Run(tt.args)
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, code)
+				return
+			}
+			require.Equal(t, 0, code)
+			require.Contains(t, test.stdout.String(), "Federation relationship updated.")
+			spiretest.AssertProtoEqual(t, tt.expected, test.fetchRelationship(t, "spiffe://example.org"))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	test := setupTest(t, newListCommand)
+	defer test.done()
+
+	require.Equal(t, 0, test.cmd.Run(nil))
+	require.Equal(t, "Found 0 federation relationships\n", test.stdout.String())
+
+	test.createRelationship(t, &datastore.FederationRelationship{
+		TrustDomainId:     "spiffe://example.org",
+		BundleEndpointUrl: "https://example.org",
+	})
+	test.createRelationship(t, &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://other.org",
+		BundleEndpointUrl:     "https://other.org",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	})
+
+	test.stdout.Reset()
+	require.Equal(t, 0, test.cmd.Run(nil))
+	require.Equal(t, `Found 2 federation relationships
+
+Trust domain            : example.org
+Bundle endpoint URL     : https://example.org
+Bundle endpoint profile : https_spiffe
+
+Trust domain            : other.org
+Bundle endpoint URL     : https://other.org
+Bundle endpoint profile : https_web
+`, test.stdout.String())
+
+	test.stdout.Reset()
+	require.Equal(t, 0, test.cmd.Run([]string{"-trustDomain", "other.org"}))
+	require.Equal(t, `Found 1 federation relationship
+
+Trust domain            : other.org
+Bundle endpoint URL     : https://other.org
+Bundle endpoint profile : https_web
+`, test.stdout.String())
+
+	test.stdout.Reset()
+	require.Equal(t, 1, test.cmd.Run([]string{"-trustDomain", "not-found.org"}))
+	require.Contains(t, test.stderr.String(), "federation relationship not found")
+}
+
+func TestDelete(t *testing.T) {
+	test := setupTest(t, newDeleteCommand)
+	defer test.done()
+
+	require.Equal(t, 1, test.cmd.Run(nil))
+	require.Equal(t, "trustDomain is required\n", test.stderr.String())
+
+	test.stderr.Reset()
+	require.Equal(t, 1, test.cmd.Run([]string{"-trustDomain", "example.org"}))
+	require.Equal(t, "failed to delete federation relationship: federation relationship not found\n", test.stderr.String())
+
+	test.createRelationship(t, &datastore.FederationRelationship{
+		TrustDomainId:     "spiffe://example.org",
+		BundleEndpointUrl: "https://example.org",
+	})
+
+	require.Equal(t, 0, test.cmd.Run([]string{"-trustDomain", "example.org"}))
+	require.Equal(t, "Federation relationship deleted.\n", test.stdout.String())
+	require.Nil(t, test.fetchRelationship(t, "spiffe://example.org"))
+}
+
+type federationTest struct {
+	ds     *fakedatastore.DataStore
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	cmd    cli.Command
+	done   func()
+}
+
+func setupTest(t *testing.T, newCmd func(*env, clientsMaker) cli.Command) *federationTest {
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	service := federationv1.New(federationv1.Config{
+		Datastore:   ds,
+		TrustDomain: spiffeid.RequireTrustDomainFromString("domain.test"),
+	})
+	registerFn := func(s *grpc.Server) {
+		federationv1.RegisterService(s, service)
+	}
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	testEnv := &env{
+		stdin:  new(bytes.Buffer),
+		stdout: stdout,
+		stderr: stderr,
+	}
+	clientsMaker := func(string) (*clients, error) {
+		return &clients{
+			f: federationpb.NewFederationClient(conn),
+		}, nil
+	}
+
+	return &federationTest{
+		ds:     ds,
+		stdout: stdout,
+		stderr: stderr,
+		cmd:    newCmd(testEnv, clientsMaker),
+		done:   done,
+	}
+}
+
+func (f *federationTest) createRelationship(t *testing.T, r *datastore.FederationRelationship) {
+	_, err := f.ds.CreateFederationRelationship(context.Background(), &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: r,
+	})
+	require.NoError(t, err)
+}
+
+func (f *federationTest) fetchRelationship(t *testing.T, trustDomainID string) *datastore.FederationRelationship {
+	resp, err := f.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+	})
+	require.NoError(t, err)
+	return resp.FederationRelationship
+}
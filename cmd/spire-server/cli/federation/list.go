@@ -0,0 +1,81 @@
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+)
+
+// NewListCommand creates a new "list" subcommand for "federation" command.
+func NewListCommand() cli.Command {
+	return newListCommand(defaultEnv, newClients)
+}
+
+func newListCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(listCommand))
+}
+
+type listCommand struct {
+	// Name of the trust domain of the relationship to show. If unset, every
+	// relationship is listed.
+	trustDomain string
+}
+
+func (c *listCommand) name() string {
+	return "federation list"
+}
+
+func (c *listCommand) synopsis() string {
+	return "Lists federation relationships"
+}
+
+func (c *listCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.trustDomain, "trustDomain", "", "Name of the trust domain of the federation relationship to show (e.g., example.org)")
+}
+
+func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error {
+	var relationships []*types.FederationRelationship
+	if c.trustDomain != "" {
+		relationship, err := clients.f.GetFederationRelationship(ctx, &federation.GetFederationRelationshipRequest{
+			TrustDomain: c.trustDomain,
+		})
+		if err != nil {
+			return err
+		}
+		relationships = append(relationships, relationship)
+	} else {
+		req := &federation.ListFederationRelationshipsRequest{}
+		for {
+			resp, err := clients.f.ListFederationRelationships(ctx, req)
+			if err != nil {
+				return err
+			}
+			relationships = append(relationships, resp.FederationRelationships...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	}
+
+	msg := fmt.Sprintf("Found %d federation ", len(relationships))
+	msg = util.Pluralizer(msg, "relationship", "relationships", len(relationships))
+	if err := env.Println(msg); err != nil {
+		return err
+	}
+
+	for _, relationship := range relationships {
+		if err := env.Println(); err != nil {
+			return err
+		}
+		if err := printRelationship(env, relationship); err != nil {
+			return err
+		}
+	}
+	return nil
+}
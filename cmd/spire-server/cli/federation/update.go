@@ -0,0 +1,94 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"google.golang.org/grpc/codes"
+)
+
+// NewUpdateCommand creates a new "update" subcommand for "federation" command.
+func NewUpdateCommand() cli.Command {
+	return newUpdateCommand(defaultEnv, newClients)
+}
+
+func newUpdateCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(updateCommand))
+}
+
+type updateCommand struct {
+	relationshipFlags
+}
+
+func (c *updateCommand) name() string {
+	return "federation update"
+}
+
+func (c *updateCommand) synopsis() string {
+	return "Updates a federation relationship with a trust domain"
+}
+
+func (c *updateCommand) appendFlags(fs *flag.FlagSet) {
+	c.relationshipFlags.appendFlags(fs)
+}
+
+func (c *updateCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.trustDomain == "" {
+		return errors.New("trustDomain is required")
+	}
+
+	relationship := &types.FederationRelationship{
+		TrustDomain:       c.trustDomain,
+		BundleEndpointUrl: c.bundleEndpointURL,
+		EndpointSpiffeId:  c.endpointSpiffeID,
+	}
+
+	// Only the fields that were provided are updated
+	mask := &types.FederationRelationshipMask{
+		BundleEndpointUrl: c.bundleEndpointURL != "",
+		EndpointSpiffeId:  c.endpointSpiffeID != "",
+	}
+	if c.bundleEndpointProfile != "" {
+		profile, err := profileFromFlag(c.bundleEndpointProfile)
+		if err != nil {
+			return err
+		}
+		relationship.BundleEndpointProfile = profile
+		mask.BundleEndpointProfile = true
+		// The endpoint SPIFFE ID is meaningless with the https_web profile,
+		// so it is cleared when switching to it
+		if profile == types.BundleEndpointProfile_HTTPS_WEB {
+			mask.EndpointSpiffeId = true
+		}
+	}
+
+	if !mask.BundleEndpointUrl && !mask.BundleEndpointProfile && !mask.EndpointSpiffeId {
+		return errors.New("at least one of bundleEndpointURL, bundleEndpointProfile or endpointSpiffeID is required")
+	}
+
+	resp, err := clients.f.BatchUpdateFederationRelationship(ctx, &federation.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{relationship},
+		InputMask:               mask,
+	})
+	if err != nil {
+		return err
+	}
+
+	result := resp.Results[0]
+	if codes.Code(result.Status.Code) != codes.OK {
+		return fmt.Errorf("failed to update federation relationship: %s", result.Status.Message)
+	}
+
+	if err := env.Println("Federation relationship updated."); err != nil {
+		return err
+	}
+	if err := env.Println(); err != nil {
+		return err
+	}
+	return printRelationship(env, result.FederationRelationship)
+}
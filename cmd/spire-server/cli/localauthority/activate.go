@@ -0,0 +1,59 @@
+package localauthority
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+)
+
+// NewX509ActivateCommand creates a new "activate" subcommand for "localauthority x509" command.
+func NewX509ActivateCommand() cli.Command {
+	return newActivateCommand(defaultEnv, newClients, x509Authority)
+}
+
+// NewJWTActivateCommand creates a new "activate" subcommand for "localauthority jwt" command.
+func NewJWTActivateCommand() cli.Command {
+	return newActivateCommand(defaultEnv, newClients, jwtAuthority)
+}
+
+func newActivateCommand(env *env, clientsMaker clientsMaker, kind authorityKind) cli.Command {
+	return adaptCommand(env, clientsMaker, &activateCommand{kind: kind})
+}
+
+type activateCommand struct {
+	kind authorityKind
+
+	// ID of the prepared authority
+	authorityID string
+}
+
+func (c *activateCommand) name() string {
+	return fmt.Sprintf("localauthority %s activate", c.kind)
+}
+
+func (c *activateCommand) synopsis() string {
+	return fmt.Sprintf("Activates the prepared local %s authority", c.kind.displayName())
+}
+
+func (c *activateCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.authorityID, "authorityID", "", "ID of the prepared authority")
+}
+
+func (c *activateCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.authorityID == "" {
+		return errors.New("authorityID is required")
+	}
+
+	active, err := clients.activate(ctx, c.kind, c.authorityID)
+	if err != nil {
+		return err
+	}
+
+	if err := env.Printf("Activated %s authority:\n", c.kind.displayName()); err != nil {
+		return err
+	}
+	return printAuthority(env, active)
+}
@@ -0,0 +1,249 @@
+package localauthority
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire-next/api/server/localauthority/v1"
+)
+
+var (
+	// this is the default environment used by commands
+	defaultEnv = &env{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+)
+
+// authorityKind is the kind of authority a command operates on
+type authorityKind int
+
+const (
+	x509Authority authorityKind = iota
+	jwtAuthority
+)
+
+// String returns the name of the kind as used in command names
+func (k authorityKind) String() string {
+	switch k {
+	case x509Authority:
+		return "x509"
+	case jwtAuthority:
+		return "jwt"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(k))
+	}
+}
+
+// displayName returns the name of the kind as used in command output
+func (k authorityKind) displayName() string {
+	switch k {
+	case x509Authority:
+		return "X509"
+	case jwtAuthority:
+		return "JWT"
+	default:
+		return k.String()
+	}
+}
+
+type clients struct {
+	l localauthority.LocalAuthorityClient
+}
+
+type clientsMaker func(registrationUDSPath string) (*clients, error)
+
+// newClients is the default client maker
+func newClients(registrationUDSPath string) (*clients, error) {
+	localAuthorityClient, err := util.NewLocalAuthorityClient(registrationUDSPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients{
+		l: localAuthorityClient,
+	}, nil
+}
+
+func (c *clients) getState(ctx context.Context, kind authorityKind) (active, prepared *localauthority.AuthorityState, err error) {
+	switch kind {
+	case x509Authority:
+		resp, err := c.l.GetX509AuthorityState(ctx, &localauthority.GetX509AuthorityStateRequest{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Active, resp.Prepared, nil
+	default:
+		resp, err := c.l.GetJWTAuthorityState(ctx, &localauthority.GetJWTAuthorityStateRequest{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Active, resp.Prepared, nil
+	}
+}
+
+func (c *clients) prepare(ctx context.Context, kind authorityKind) (*localauthority.AuthorityState, error) {
+	switch kind {
+	case x509Authority:
+		resp, err := c.l.PrepareX509Authority(ctx, &localauthority.PrepareX509AuthorityRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Prepared, nil
+	default:
+		resp, err := c.l.PrepareJWTAuthority(ctx, &localauthority.PrepareJWTAuthorityRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Prepared, nil
+	}
+}
+
+func (c *clients) activate(ctx context.Context, kind authorityKind, authorityID string) (*localauthority.AuthorityState, error) {
+	switch kind {
+	case x509Authority:
+		resp, err := c.l.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{
+			AuthorityId: authorityID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Active, nil
+	default:
+		resp, err := c.l.ActivateJWTAuthority(ctx, &localauthority.ActivateJWTAuthorityRequest{
+			AuthorityId: authorityID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Active, nil
+	}
+}
+
+func (c *clients) taint(ctx context.Context, kind authorityKind, authorityID string) error {
+	var err error
+	switch kind {
+	case x509Authority:
+		_, err = c.l.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{
+			AuthorityId: authorityID,
+		})
+	default:
+		_, err = c.l.TaintJWTAuthority(ctx, &localauthority.TaintJWTAuthorityRequest{
+			AuthorityId: authorityID,
+		})
+	}
+	return err
+}
+
+func (c *clients) revoke(ctx context.Context, kind authorityKind, authorityID string) error {
+	var err error
+	switch kind {
+	case x509Authority:
+		_, err = c.l.RevokeX509Authority(ctx, &localauthority.RevokeX509AuthorityRequest{
+			AuthorityId: authorityID,
+		})
+	default:
+		_, err = c.l.RevokeJWTAuthority(ctx, &localauthority.RevokeJWTAuthorityRequest{
+			AuthorityId: authorityID,
+		})
+	}
+	return err
+}
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *env, *clients) error
+}
+
+type adapter struct {
+	env          *env
+	clientsMaker clientsMaker
+	cmd          command
+
+	registrationUDSPath string
+	flags               *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *env, clientsMaker clientsMaker, cmd command) *adapter {
+	a := &adapter{
+		clientsMaker: clientsMaker,
+		cmd:          cmd,
+		env:          env,
+	}
+
+	f := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	f.SetOutput(env.stderr)
+	f.StringVar(&a.registrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	a.cmd.appendFlags(f)
+	a.flags = f
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.flags.Parse(args); err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	clients, err := a.clientsMaker(a.registrationUDSPath)
+	if err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	if err := a.cmd.run(ctx, a.env, clients); err != nil {
+		fmt.Fprintln(a.env.stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	return a.flags.Parse([]string{"-h"}).Error()
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
+
+// env provides input and output facilities to commands
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (e *env) Printf(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(e.stdout, format, args...)
+	return err
+}
+
+func (e *env) Println(args ...interface{}) error {
+	_, err := fmt.Fprintln(e.stdout, args...)
+	return err
+}
+
+func printAuthority(env *env, state *localauthority.AuthorityState) error {
+	if err := env.Printf("  Authority ID : %s\n", state.AuthorityId); err != nil {
+		return err
+	}
+	if err := env.Printf("  Issued at    : %s\n", time.Unix(state.IssuedAt, 0).UTC()); err != nil {
+		return err
+	}
+	return env.Printf("  Expires at   : %s\n", time.Unix(state.ExpiresAt, 0).UTC())
+}
@@ -0,0 +1,378 @@
+package localauthority
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/sirupsen/logrus/hooks/test"
+	localauthorityv1 "github.com/spiffe/spire/pkg/server/api/localauthority/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	localauthoritypb "github.com/spiffe/spire/proto/spire-next/api/server/localauthority/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	activeState = &ca.AuthorityState{
+		AuthorityID: "active-id",
+		IssuedAt:    time.Unix(1590000000, 0),
+		ExpiresAt:   time.Unix(1600000000, 0),
+	}
+	preparedState = &ca.AuthorityState{
+		AuthorityID: "prepared-id",
+		IssuedAt:    time.Unix(1595000000, 0),
+		ExpiresAt:   time.Unix(1605000000, 0),
+	}
+)
+
+func TestShow(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		kind           authorityKind
+		prepared       *ca.AuthorityState
+		expectedStdout string
+	}{
+		{
+			name: "x509 without prepared authority",
+			kind: x509Authority,
+			expectedStdout: `Active X509 authority:
+  Authority ID : active-id
+  Issued at    : 2020-05-20 18:40:00 +0000 UTC
+  Expires at   : 2020-09-13 12:26:40 +0000 UTC
+
+Prepared X509 authority:
+  No prepared authority found
+`,
+		},
+		{
+			name:     "jwt with prepared authority",
+			kind:     jwtAuthority,
+			prepared: preparedState,
+			expectedStdout: `Active JWT authority:
+  Authority ID : active-id
+  Issued at    : 2020-05-20 18:40:00 +0000 UTC
+  Expires at   : 2020-09-13 12:26:40 +0000 UTC
+
+Prepared JWT authority:
+  Authority ID : prepared-id
+  Issued at    : 2020-07-17 15:33:20 +0000 UTC
+  Expires at   : 2020-11-10 09:20:00 +0000 UTC
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newShowCommand, tt.kind)
+			defer test.done()
+			test.m.prepared = tt.prepared
+
+			code := test.cmd.Run(nil)
+			require.Equal(t, "", test.stderr.String())
+			require.Equal(t, 0, code)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+		})
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	for _, kind := range []authorityKind{x509Authority, jwtAuthority} {
+		kind := kind
+		t.Run(kind.String(), func(t *testing.T) {
+			test := setupTest(t, newPrepareCommand, kind)
+			defer test.done()
+
+			code := test.cmd.Run(nil)
+			require.Equal(t, "", test.stderr.String())
+			require.Equal(t, 0, code)
+			require.Equal(t, "Prepared "+kind.displayName()+` authority:
+  Authority ID : prepared-id
+  Issued at    : 2020-07-17 15:33:20 +0000 UTC
+  Expires at   : 2020-11-10 09:20:00 +0000 UTC
+`, test.stdout.String())
+		})
+	}
+}
+
+func TestActivate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		kind           authorityKind
+		args           []string
+		err            error
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "missing authority ID",
+			kind:           x509Authority,
+			expectedStderr: "authorityID is required\n",
+		},
+		{
+			name:           "manager fails",
+			kind:           x509Authority,
+			args:           []string{"-authorityID", "prepared-id"},
+			err:            status.Error(codes.FailedPrecondition, "no X509 CA has been prepared"),
+			expectedStderr: "rpc error: code = FailedPrecondition desc = failed to activate X509 authority: no X509 CA has been prepared\n",
+		},
+		{
+			name: "success",
+			kind: jwtAuthority,
+			args: []string{"-authorityID", "prepared-id"},
+			expectedStdout: `Activated JWT authority:
+  Authority ID : prepared-id
+  Issued at    : 2020-07-17 15:33:20 +0000 UTC
+  Expires at   : 2020-11-10 09:20:00 +0000 UTC
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newActivateCommand, tt.kind)
+			defer test.done()
+			test.m.prepared = preparedState
+			test.m.err = tt.err
+
+			code := test.cmd.Run(tt.args)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, code)
+				return
+			}
+			require.Equal(t, 0, code)
+			require.Equal(t, "prepared-id", test.m.activated)
+		})
+	}
+}
+
+func TestTaint(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		kind           authorityKind
+		args           []string
+		err            error
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "missing authority ID",
+			kind:           x509Authority,
+			expectedStderr: "authorityID is required\n",
+		},
+		{
+			name:           "active authority",
+			kind:           jwtAuthority,
+			args:           []string{"-authorityID", "active-id"},
+			err:            status.Error(codes.FailedPrecondition, "the active JWT key cannot be tainted or revoked"),
+			expectedStderr: "rpc error: code = FailedPrecondition desc = failed to taint JWT authority: the active JWT key cannot be tainted or revoked\n",
+		},
+		{
+			name:           "success",
+			kind:           x509Authority,
+			args:           []string{"-authorityID", "old-id"},
+			expectedStdout: "Tainted X509 authority: old-id\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newTaintCommand, tt.kind)
+			defer test.done()
+			test.m.err = tt.err
+
+			code := test.cmd.Run(tt.args)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, code)
+				return
+			}
+			require.Equal(t, 0, code)
+			require.Equal(t, "old-id", test.m.tainted)
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		kind           authorityKind
+		args           []string
+		err            error
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "missing authority ID",
+			kind:           jwtAuthority,
+			expectedStderr: "authorityID is required\n",
+		},
+		{
+			name:           "not tainted",
+			kind:           x509Authority,
+			args:           []string{"-authorityID", "old-id"},
+			err:            status.Error(codes.FailedPrecondition, "root CA must be tainted before it can be revoked"),
+			expectedStderr: "rpc error: code = FailedPrecondition desc = failed to revoke X509 authority: root CA must be tainted before it can be revoked\n",
+		},
+		{
+			name:           "success",
+			kind:           jwtAuthority,
+			args:           []string{"-authorityID", "old-id"},
+			expectedStdout: "Revoked JWT authority: old-id\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newRevokeCommand, tt.kind)
+			defer test.done()
+			test.m.err = tt.err
+
+			code := test.cmd.Run(tt.args)
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, code)
+				return
+			}
+			require.Equal(t, 0, code)
+			require.Equal(t, "old-id", test.m.revoked)
+		})
+	}
+}
+
+type localAuthorityTest struct {
+	m      *fakeAuthorityManager
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	cmd    cli.Command
+	done   func()
+}
+
+func setupTest(t *testing.T, newCmd func(*env, clientsMaker, authorityKind) cli.Command, kind authorityKind) *localAuthorityTest {
+	m := &fakeAuthorityManager{
+		active: activeState,
+	}
+	log, _ := test.NewNullLogger()
+
+	service := localauthorityv1.New(localauthorityv1.Config{
+		Manager: m,
+	})
+	registerFn := func(s *grpc.Server) {
+		localauthorityv1.RegisterService(s, service)
+	}
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	testEnv := &env{
+		stdin:  new(bytes.Buffer),
+		stdout: stdout,
+		stderr: stderr,
+	}
+	clientsMaker := func(string) (*clients, error) {
+		return &clients{
+			l: localauthoritypb.NewLocalAuthorityClient(conn),
+		}, nil
+	}
+
+	return &localAuthorityTest{
+		m:      m,
+		stdout: stdout,
+		stderr: stderr,
+		cmd:    newCmd(testEnv, clientsMaker, kind),
+		done:   done,
+	}
+}
+
+// fakeAuthorityManager serves the same state for the X509 CA and the JWT key
+// and records the authority IDs it is called with.
+type fakeAuthorityManager struct {
+	active    *ca.AuthorityState
+	prepared  *ca.AuthorityState
+	activated string
+	tainted   string
+	revoked   string
+	err       error
+}
+
+func (m *fakeAuthorityManager) GetX509CAState() (active, prepared *ca.AuthorityState) {
+	return m.active, m.prepared
+}
+
+func (m *fakeAuthorityManager) PrepareX509CA(ctx context.Context) (*ca.AuthorityState, error) {
+	return m.prepare()
+}
+
+func (m *fakeAuthorityManager) ActivateX509CA(ctx context.Context, authorityID string) (*ca.AuthorityState, error) {
+	return m.activate(authorityID)
+}
+
+func (m *fakeAuthorityManager) TaintX509CA(ctx context.Context, authorityID string) error {
+	return m.taint(authorityID)
+}
+
+func (m *fakeAuthorityManager) RevokeX509CA(ctx context.Context, authorityID string) error {
+	return m.revoke(authorityID)
+}
+
+func (m *fakeAuthorityManager) GetJWTKeyState() (active, prepared *ca.AuthorityState) {
+	return m.active, m.prepared
+}
+
+func (m *fakeAuthorityManager) PrepareJWTKey(ctx context.Context) (*ca.AuthorityState, error) {
+	return m.prepare()
+}
+
+func (m *fakeAuthorityManager) ActivateJWTKey(ctx context.Context, authorityID string) (*ca.AuthorityState, error) {
+	return m.activate(authorityID)
+}
+
+func (m *fakeAuthorityManager) TaintJWTKey(ctx context.Context, authorityID string) error {
+	return m.taint(authorityID)
+}
+
+func (m *fakeAuthorityManager) RevokeJWTKey(ctx context.Context, authorityID string) error {
+	return m.revoke(authorityID)
+}
+
+func (m *fakeAuthorityManager) prepare() (*ca.AuthorityState, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.prepared = preparedState
+	return m.prepared, nil
+}
+
+func (m *fakeAuthorityManager) activate(authorityID string) (*ca.AuthorityState, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.activated = authorityID
+	m.active, m.prepared = m.prepared, nil
+	return m.active, nil
+}
+
+func (m *fakeAuthorityManager) taint(authorityID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.tainted = authorityID
+	return nil
+}
+
+func (m *fakeAuthorityManager) revoke(authorityID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.revoked = authorityID
+	return nil
+}
@@ -0,0 +1,50 @@
+package localauthority
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+)
+
+// NewX509PrepareCommand creates a new "prepare" subcommand for "localauthority x509" command.
+func NewX509PrepareCommand() cli.Command {
+	return newPrepareCommand(defaultEnv, newClients, x509Authority)
+}
+
+// NewJWTPrepareCommand creates a new "prepare" subcommand for "localauthority jwt" command.
+func NewJWTPrepareCommand() cli.Command {
+	return newPrepareCommand(defaultEnv, newClients, jwtAuthority)
+}
+
+func newPrepareCommand(env *env, clientsMaker clientsMaker, kind authorityKind) cli.Command {
+	return adaptCommand(env, clientsMaker, &prepareCommand{kind: kind})
+}
+
+type prepareCommand struct {
+	kind authorityKind
+}
+
+func (c *prepareCommand) name() string {
+	return fmt.Sprintf("localauthority %s prepare", c.kind)
+}
+
+func (c *prepareCommand) synopsis() string {
+	return fmt.Sprintf("Prepares a new local %s authority", c.kind.displayName())
+}
+
+func (c *prepareCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *prepareCommand) run(ctx context.Context, env *env, clients *clients) error {
+	prepared, err := clients.prepare(ctx, c.kind)
+	if err != nil {
+		return err
+	}
+
+	if err := env.Printf("Prepared %s authority:\n", c.kind.displayName()); err != nil {
+		return err
+	}
+	return printAuthority(env, prepared)
+}
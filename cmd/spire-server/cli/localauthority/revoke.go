@@ -0,0 +1,55 @@
+package localauthority
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+)
+
+// NewX509RevokeCommand creates a new "revoke" subcommand for "localauthority x509" command.
+func NewX509RevokeCommand() cli.Command {
+	return newRevokeCommand(defaultEnv, newClients, x509Authority)
+}
+
+// NewJWTRevokeCommand creates a new "revoke" subcommand for "localauthority jwt" command.
+func NewJWTRevokeCommand() cli.Command {
+	return newRevokeCommand(defaultEnv, newClients, jwtAuthority)
+}
+
+func newRevokeCommand(env *env, clientsMaker clientsMaker, kind authorityKind) cli.Command {
+	return adaptCommand(env, clientsMaker, &revokeCommand{kind: kind})
+}
+
+type revokeCommand struct {
+	kind authorityKind
+
+	// ID of the authority to revoke
+	authorityID string
+}
+
+func (c *revokeCommand) name() string {
+	return fmt.Sprintf("localauthority %s revoke", c.kind)
+}
+
+func (c *revokeCommand) synopsis() string {
+	return fmt.Sprintf("Removes a tainted local %s authority from the bundle", c.kind.displayName())
+}
+
+func (c *revokeCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.authorityID, "authorityID", "", "ID of the tainted authority to revoke")
+}
+
+func (c *revokeCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.authorityID == "" {
+		return errors.New("authorityID is required")
+	}
+
+	if err := clients.revoke(ctx, c.kind, c.authorityID); err != nil {
+		return err
+	}
+
+	return env.Printf("Revoked %s authority: %s\n", c.kind.displayName(), c.authorityID)
+}
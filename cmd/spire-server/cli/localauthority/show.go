@@ -0,0 +1,64 @@
+package localauthority
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+)
+
+// NewX509ShowCommand creates a new "show" subcommand for "localauthority x509" command.
+func NewX509ShowCommand() cli.Command {
+	return newShowCommand(defaultEnv, newClients, x509Authority)
+}
+
+// NewJWTShowCommand creates a new "show" subcommand for "localauthority jwt" command.
+func NewJWTShowCommand() cli.Command {
+	return newShowCommand(defaultEnv, newClients, jwtAuthority)
+}
+
+func newShowCommand(env *env, clientsMaker clientsMaker, kind authorityKind) cli.Command {
+	return adaptCommand(env, clientsMaker, &showCommand{kind: kind})
+}
+
+type showCommand struct {
+	kind authorityKind
+}
+
+func (c *showCommand) name() string {
+	return fmt.Sprintf("localauthority %s show", c.kind)
+}
+
+func (c *showCommand) synopsis() string {
+	return fmt.Sprintf("Shows the active and prepared local %s authorities", c.kind.displayName())
+}
+
+func (c *showCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *showCommand) run(ctx context.Context, env *env, clients *clients) error {
+	active, prepared, err := clients.getState(ctx, c.kind)
+	if err != nil {
+		return err
+	}
+
+	if err := env.Printf("Active %s authority:\n", c.kind.displayName()); err != nil {
+		return err
+	}
+	if active != nil {
+		if err := printAuthority(env, active); err != nil {
+			return err
+		}
+	} else if err := env.Println("  No active authority found"); err != nil {
+		return err
+	}
+
+	if err := env.Printf("\nPrepared %s authority:\n", c.kind.displayName()); err != nil {
+		return err
+	}
+	if prepared != nil {
+		return printAuthority(env, prepared)
+	}
+	return env.Println("  No prepared authority found")
+}
@@ -0,0 +1,55 @@
+package localauthority
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+)
+
+// NewX509TaintCommand creates a new "taint" subcommand for "localauthority x509" command.
+func NewX509TaintCommand() cli.Command {
+	return newTaintCommand(defaultEnv, newClients, x509Authority)
+}
+
+// NewJWTTaintCommand creates a new "taint" subcommand for "localauthority jwt" command.
+func NewJWTTaintCommand() cli.Command {
+	return newTaintCommand(defaultEnv, newClients, jwtAuthority)
+}
+
+func newTaintCommand(env *env, clientsMaker clientsMaker, kind authorityKind) cli.Command {
+	return adaptCommand(env, clientsMaker, &taintCommand{kind: kind})
+}
+
+type taintCommand struct {
+	kind authorityKind
+
+	// ID of the authority to taint
+	authorityID string
+}
+
+func (c *taintCommand) name() string {
+	return fmt.Sprintf("localauthority %s taint", c.kind)
+}
+
+func (c *taintCommand) synopsis() string {
+	return fmt.Sprintf("Marks a previously active local %s authority as tainted", c.kind.displayName())
+}
+
+func (c *taintCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.authorityID, "authorityID", "", "ID of the authority to taint")
+}
+
+func (c *taintCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.authorityID == "" {
+		return errors.New("authorityID is required")
+	}
+
+	if err := clients.taint(ctx, c.kind, c.authorityID); err != nil {
+		return err
+	}
+
+	return env.Printf("Tainted %s authority: %s\n", c.kind.displayName(), c.authorityID)
+}
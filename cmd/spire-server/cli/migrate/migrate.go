@@ -0,0 +1,177 @@
+package migrate
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+)
+
+const commandName = "migrate"
+
+// NewMigrateCommand creates a new "migrate" command
+func NewMigrateCommand() cli.Command {
+	return newMigrateCommand(common_cli.DefaultEnv)
+}
+
+func newMigrateCommand(env *common_cli.Env) *migrateCommand {
+	c := &migrateCommand{
+		env: env,
+	}
+
+	f := flag.NewFlagSet(commandName, flag.ContinueOnError)
+	f.SetOutput(env.Stderr)
+	f.StringVar(&c.configPath, "config", "conf/server/server.conf", "Path to the SPIRE server configuration file")
+	f.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	f.BoolVar(&c.dryRun, "dryRun", false, "Print the migration steps without applying them")
+	c.flags = f
+
+	return c
+}
+
+type migrateCommand struct {
+	env *common_cli.Env
+
+	configPath string
+	expandEnv  bool
+	dryRun     bool
+	flags      *flag.FlagSet
+}
+
+func (c *migrateCommand) Help() string {
+	return c.flags.Parse([]string{"-h"}).Error()
+}
+
+func (c *migrateCommand) Synopsis() string {
+	return "Migrates the schema of the SQL datastore"
+}
+
+func (c *migrateCommand) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+
+	if err := c.run(); err != nil {
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *migrateCommand) run() error {
+	config, err := run.ParseFile(c.env.JoinPath(c.configPath), c.expandEnv)
+	if err != nil {
+		return err
+	}
+
+	pluginConfig, err := sqlPluginConfig(config)
+	if err != nil {
+		return err
+	}
+
+	level := hclog.Info
+	if config.Server != nil && config.Server.LogLevel != "" {
+		level = hclog.LevelFromString(config.Server.LogLevel)
+	}
+	// Logs are written to stderr so they do not mix with the plan
+	m, err := sql.NewMigrator(pluginConfig, hclog.New(&hclog.LoggerOptions{
+		Name:   "sql",
+		Level:  level,
+		Output: c.env.Stderr,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+	defer m.Close()
+
+	plan, err := m.Plan()
+	if err != nil {
+		return err
+	}
+	if err := c.printPlan(plan); err != nil {
+		return err
+	}
+
+	switch {
+	case !plan.NewDatabase && len(plan.Steps) == 0:
+		return c.env.Println("Schema is up to date.")
+	case c.dryRun:
+		return c.env.Println("Dry run: no changes made.")
+	}
+
+	plan, err = m.Migrate()
+	if err != nil {
+		return fmt.Errorf("failed to migrate schema: %v", err)
+	}
+	if plan.NewDatabase {
+		return c.env.Printf("Initialized schema at version %d.\n", plan.TargetVersion)
+	}
+	return c.env.Printf("Migrated schema from version %d to version %d.\n", plan.SchemaVersion, plan.TargetVersion)
+}
+
+func (c *migrateCommand) printPlan(plan *sql.MigrationPlan) error {
+	if err := c.env.Printf("Database type          : %s\n", plan.DatabaseType); err != nil {
+		return err
+	}
+	switch {
+	case plan.NewDatabase:
+		if err := c.env.Println("Current schema version : none (new database)"); err != nil {
+			return err
+		}
+	case plan.CodeVersion != "":
+		if err := c.env.Printf("Current schema version : %d (migrated by SPIRE %s)\n", plan.SchemaVersion, plan.CodeVersion); err != nil {
+			return err
+		}
+	default:
+		if err := c.env.Printf("Current schema version : %d\n", plan.SchemaVersion); err != nil {
+			return err
+		}
+	}
+	if err := c.env.Printf("Target schema version  : %d\n", plan.TargetVersion); err != nil {
+		return err
+	}
+
+	if len(plan.Steps) == 0 {
+		return nil
+	}
+	if err := c.env.Println("Migration steps:"); err != nil {
+		return err
+	}
+	for _, step := range plan.Steps {
+		if err := c.env.Printf("  %d -> %d: %s\n", step.Version-1, step.Version, step.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqlPluginConfig returns the configuration of the DataStore plugin, which
+// must be the built-in sql plugin
+func sqlPluginConfig(config *run.Config) (string, error) {
+	if config.Plugins == nil {
+		return "", errors.New("plugins section must be configured")
+	}
+
+	pluginConfigs, err := catalog.PluginConfigFromHCL(catalog.HCLPluginConfigMap{
+		datastore.Type: (*config.Plugins)[datastore.Type],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(pluginConfigs) != 1:
+		return "", errors.New("exactly one DataStore plugin must be configured")
+	case pluginConfigs[0].Name != "sql" || pluginConfigs[0].Path != "":
+		return "", errors.New("only the built-in sql DataStore plugin can be migrated")
+	}
+	return pluginConfigs[0].Data, nil
+}
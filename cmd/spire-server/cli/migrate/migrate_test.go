@@ -0,0 +1,169 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sqlConfig = `
+server {
+	trust_domain = "example.org"
+	log_level = "ERROR"
+}
+
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = "%s"
+		}
+	}
+}
+`
+)
+
+func TestSynopsis(t *testing.T) {
+	require.Equal(t, "Migrates the schema of the SQL datastore", newMigrateCommand(common_cli.DefaultEnv).Synopsis())
+}
+
+func TestMigrate(t *testing.T) {
+	test := setupTest(t)
+	defer test.cleanup()
+	test.writeConfig(t, fmt.Sprintf(sqlConfig, filepath.Join(test.dir, "datastore.sqlite3")))
+
+	// A dry run does not initialize the database
+	for i := 0; i < 2; i++ {
+		require.Equal(t, 0, test.run("-dryRun"))
+		require.Equal(t, `Database type          : sqlite3
+Current schema version : none (new database)
+Target schema version  : 17
+Dry run: no changes made.
+`, test.stdout.String())
+		require.Empty(t, test.stderr.String())
+	}
+
+	require.Equal(t, 0, test.run())
+	require.Equal(t, `Database type          : sqlite3
+Current schema version : none (new database)
+Target schema version  : 17
+Initialized schema at version 17.
+`, test.stdout.String())
+	require.Empty(t, test.stderr.String())
+
+	require.Equal(t, 0, test.run())
+	require.Equal(t, `Database type          : sqlite3
+Current schema version : 17
+Target schema version  : 17
+Schema is up to date.
+`, test.stdout.String())
+	require.Empty(t, test.stderr.String())
+}
+
+func TestMigrateInvalidConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name:        "no plugins",
+			config:      `server {}`,
+			expectedErr: "plugins section must be configured\n",
+		},
+		{
+			name:        "no datastore",
+			config:      `plugins {}`,
+			expectedErr: "exactly one DataStore plugin must be configured\n",
+		},
+		{
+			name: "external datastore",
+			config: `plugins {
+				DataStore "sql" {
+					plugin_cmd = "./datastore"
+					plugin_data {}
+				}
+			}`,
+			expectedErr: "only the built-in sql DataStore plugin can be migrated\n",
+		},
+		{
+			name: "other datastore",
+			config: `plugins {
+				DataStore "other" {
+					plugin_data {}
+				}
+			}`,
+			expectedErr: "only the built-in sql DataStore plugin can be migrated\n",
+		},
+		{
+			name: "invalid sql config",
+			config: `plugins {
+				DataStore "sql" {
+					plugin_data {
+						database_type = "sqlite3"
+					}
+				}
+			}`,
+			expectedErr: "failed to connect to the database: connection_string must be set\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t)
+			defer test.cleanup()
+			test.writeConfig(t, tt.config)
+
+			require.Equal(t, 1, test.run())
+			require.Empty(t, test.stdout.String())
+			require.Equal(t, tt.expectedErr, test.stderr.String())
+		})
+	}
+}
+
+type migrateTest struct {
+	dir    string
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	env    *common_cli.Env
+}
+
+func setupTest(t *testing.T) *migrateTest {
+	dir, err := ioutil.TempDir("", "spire-server-cli-migrate-")
+	require.NoError(t, err)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	return &migrateTest{
+		dir:    dir,
+		stdout: stdout,
+		stderr: stderr,
+		env: &common_cli.Env{
+			Stdin:   new(bytes.Buffer),
+			Stdout:  stdout,
+			Stderr:  stderr,
+			BaseDir: dir,
+		},
+	}
+}
+
+func (m *migrateTest) cleanup() {
+	os.RemoveAll(m.dir)
+}
+
+func (m *migrateTest) writeConfig(t *testing.T, config string) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(m.dir, "server.conf"), []byte(config), 0600))
+}
+
+// run runs a new migrate command against the test configuration file
+func (m *migrateTest) run(args ...string) int {
+	m.stdout.Reset()
+	m.stderr.Reset()
+	return newMigrateCommand(m.env).Run(append([]string{"-config", "server.conf"}, args...))
+}
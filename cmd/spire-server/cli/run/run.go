@@ -28,8 +28,10 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 )
@@ -42,6 +44,9 @@ const (
 	defaultLogLevel           = "INFO"
 	defaultBundleEndpointPort = 443
 	defaultUpstreamBundle     = true
+
+	caJournalStorageDisk      = "disk"
+	caJournalStorageDataStore = "datastore"
 )
 
 var (
@@ -61,22 +66,29 @@ type Config struct {
 }
 
 type serverConfig struct {
-	BindAddress         string             `hcl:"bind_address"`
-	BindPort            int                `hcl:"bind_port"`
-	CAKeyType           string             `hcl:"ca_key_type"`
-	CASubject           *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL               string             `hcl:"ca_ttl"`
-	DataDir             string             `hcl:"data_dir"`
-	Experimental        experimentalConfig `hcl:"experimental"`
-	JWTIssuer           string             `hcl:"jwt_issuer"`
-	LogFile             string             `hcl:"log_file"`
-	LogLevel            string             `hcl:"log_level"`
-	LogFormat           string             `hcl:"log_format"`
-	RegistrationUDSPath string             `hcl:"registration_uds_path"`
-	DeprecatedSVIDTTL   string             `hcl:"svid_ttl"`
-	DefaultSVIDTTL      string             `hcl:"default_svid_ttl"`
-	TrustDomain         string             `hcl:"trust_domain"`
-	UpstreamBundle      *bool              `hcl:"upstream_bundle"`
+	AgentPruning            *agentPruningConfig `hcl:"agent_pruning"`
+	AuditLogFile            string              `hcl:"audit_log_file"`
+	AuthorizationPolicyFile string              `hcl:"authorization_policy_file"`
+	BindAddress             string              `hcl:"bind_address"`
+	BindPort                int                 `hcl:"bind_port"`
+	CAJournal               *caJournalConfig    `hcl:"ca_journal"`
+	CAKeyType               string              `hcl:"ca_key_type"`
+	CASubject               *caSubjectConfig    `hcl:"ca_subject"`
+	CATTL                   string              `hcl:"ca_ttl"`
+	DataDir                 string              `hcl:"data_dir"`
+	Experimental            experimentalConfig  `hcl:"experimental"`
+	JWTIssuer               string              `hcl:"jwt_issuer"`
+	JWTKeyType              string              `hcl:"jwt_key_type"`
+	JWTSVIDTTL              string              `hcl:"jwt_svid_ttl"`
+	LogFile                 string              `hcl:"log_file"`
+	LogLevel                string              `hcl:"log_level"`
+	LogFormat               string              `hcl:"log_format"`
+	RateLimit               *rateLimitConfig    `hcl:"rate_limit"`
+	RegistrationUDSPath     string              `hcl:"registration_uds_path"`
+	DeprecatedSVIDTTL       string              `hcl:"svid_ttl"`
+	DefaultSVIDTTL          string              `hcl:"default_svid_ttl"`
+	TrustDomain             string              `hcl:"trust_domain"`
+	UpstreamBundle          *bool               `hcl:"upstream_bundle"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -102,6 +114,33 @@ type experimentalConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type agentPruningConfig struct {
+	ExpiredFor string   `hcl:"expired_for"`
+	Notify     bool     `hcl:"notify"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type caJournalConfig struct {
+	Storage            string   `hcl:"storage"`
+	CoordinateRotation bool     `hcl:"coordinate_rotation"`
+	UnusedKeys         []string `hcl:",unusedKeys"`
+}
+
+type rateLimitConfig struct {
+	Attestation *callerLimitsConfig `hcl:"attestation"`
+	Signing     *callerLimitsConfig `hcl:"signing"`
+	JWTSigning  *callerLimitsConfig `hcl:"jwt_signing"`
+	PushJWTKey  *callerLimitsConfig `hcl:"push_jwt_key"`
+	UnusedKeys  []string            `hcl:",unusedKeys"`
+}
+
+type callerLimitsConfig struct {
+	Agent      int      `hcl:"agent"`
+	Downstream int      `hcl:"downstream"`
+	IP         int      `hcl:"ip"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 type caSubjectConfig struct {
 	Country      []string `hcl:"country"`
 	Organization []string `hcl:"organization"`
@@ -205,7 +244,7 @@ func (cmd *Command) Run(args []string) int {
 	return 0
 }
 
-//Synopsis of the command
+// Synopsis of the command
 func (*Command) Synopsis() string {
 	return "Runs the server"
 }
@@ -251,6 +290,7 @@ func parseFlags(name string, args []string, output io.Writer) (*serverConfig, er
 	flags.SetOutput(output)
 	c := &serverConfig{}
 
+	flags.StringVar(&c.AuditLogFile, "auditLogFile", "", "File to write the audit log to")
 	flags.StringVar(&c.BindAddress, "bindAddress", "", "IP address or DNS name of the SPIRE server")
 	flags.IntVar(&c.BindPort, "serverPort", 0, "Port number of the SPIRE server")
 	flags.StringVar(&c.ConfigPath, "config", "", "Path to a SPIRE config file")
@@ -333,6 +373,16 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 	sc.Log = logger
 
+	if c.Server.AuditLogFile != "" {
+		auditLogger, err := log.NewLogger(
+			log.WithFormat(log.JSONFormat),
+			log.WithOutputFile(c.Server.AuditLogFile))
+		if err != nil {
+			return nil, fmt.Errorf("could not start audit logger: %s", err)
+		}
+		sc.AuditLog = auditLogger
+	}
+
 	if c.Server.UpstreamBundle != nil {
 		sc.UpstreamBundle = *c.Server.UpstreamBundle
 	} else {
@@ -386,6 +436,14 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 		sc.SVIDTTL = ttl
 	}
 
+	if c.Server.JWTSVIDTTL != "" {
+		ttl, err := time.ParseDuration(c.Server.JWTSVIDTTL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse JWT-SVID ttl %q: %v", c.Server.JWTSVIDTTL, err)
+		}
+		sc.JWTSVIDTTL = ttl
+	}
+
 	if c.Server.CATTL != "" {
 		ttl, err := time.ParseDuration(c.Server.CATTL)
 		if err != nil {
@@ -399,7 +457,17 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 
 	if c.Server.CAKeyType != "" {
-		sc.CAKeyType, err = caKeyTypeFromString(c.Server.CAKeyType)
+		sc.CAKeyType, err = keyTypeFromString("CA", c.Server.CAKeyType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The JWT signing key has the same type as the CA unless configured
+	// otherwise
+	sc.JWTKeyType = sc.CAKeyType
+	if c.Server.JWTKeyType != "" {
+		sc.JWTKeyType, err = keyTypeFromString("JWT", c.Server.JWTKeyType)
 		if err != nil {
 			return nil, err
 		}
@@ -407,6 +475,35 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 
 	sc.JWTIssuer = c.Server.JWTIssuer
 
+	if c.Server.AuthorizationPolicyFile != "" {
+		sc.AuthorizationPolicy, err = endpoints.LoadPolicy(c.Server.AuthorizationPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rl := c.Server.RateLimit; rl != nil {
+		sc.RateLimits = endpoints.RateLimitConfig{
+			Attestation: rl.Attestation.callerLimits(),
+			Signing:     rl.Signing.callerLimits(),
+			JWTSigning:  rl.JWTSigning.callerLimits(),
+			PushJWTKey:  rl.PushJWTKey.callerLimits(),
+		}
+	}
+
+	if ap := c.Server.AgentPruning; ap != nil {
+		sc.PruneAgentsExpiredFor, err = time.ParseDuration(ap.ExpiredFor)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse agent pruning expired_for %q: %v", ap.ExpiredFor, err)
+		}
+		sc.NotifyAgentPruning = ap.Notify
+	}
+
+	if cj := c.Server.CAJournal; cj != nil {
+		sc.JournalInDataStore = cj.Storage == caJournalStorageDataStore
+		sc.CoordinateRotation = cj.CoordinateRotation
+	}
+
 	if subject := c.Server.CASubject; subject != nil {
 		sc.CASubject = pkix.Name{
 			Organization: subject.Organization,
@@ -482,6 +579,24 @@ func validateConfig(c *Config) error {
 		}
 	}
 
+	if rl := c.Server.RateLimit; rl != nil {
+		if err := rl.validate(); err != nil {
+			return err
+		}
+	}
+
+	if ap := c.Server.AgentPruning; ap != nil {
+		if err := ap.validate(); err != nil {
+			return err
+		}
+	}
+
+	if cj := c.Server.CAJournal; cj != nil {
+		if err := cj.validate(); err != nil {
+			return err
+		}
+	}
+
 	// TODO: Remove this check at 0.11.0 (after warnOnUnknownConfig bails out instead of only display a warning)
 	if c.Server.DeprecatedSVIDTTL != "" {
 		return errors.New(`the "svid_ttl" configurable has been deprecated and renamed to "default_svid_ttl"; please update your configuration`)
@@ -490,6 +605,68 @@ func validateConfig(c *Config) error {
 	return nil
 }
 
+func (c *agentPruningConfig) validate() error {
+	if c.ExpiredFor == "" {
+		return errors.New("agent_pruning expired_for must be configured")
+	}
+	expiredFor, err := time.ParseDuration(c.ExpiredFor)
+	if err != nil {
+		return fmt.Errorf("could not parse agent pruning expired_for %q: %v", c.ExpiredFor, err)
+	}
+	if expiredFor <= 0 {
+		return errors.New("agent_pruning expired_for must be positive")
+	}
+	return nil
+}
+
+func (c *caJournalConfig) validate() error {
+	switch c.Storage {
+	case "", caJournalStorageDisk, caJournalStorageDataStore:
+	default:
+		return fmt.Errorf("ca_journal storage %q is not supported; use %q or %q", c.Storage, caJournalStorageDisk, caJournalStorageDataStore)
+	}
+	if c.CoordinateRotation && c.Storage != caJournalStorageDataStore {
+		return errors.New("ca_journal coordinate_rotation requires the journal to be stored in the datastore")
+	}
+	return nil
+}
+
+// limits returns the configured limits, keyed by configuration name
+func (c *rateLimitConfig) limits() map[string]*callerLimitsConfig {
+	return map[string]*callerLimitsConfig{
+		"attestation":  c.Attestation,
+		"signing":      c.Signing,
+		"jwt_signing":  c.JWTSigning,
+		"push_jwt_key": c.PushJWTKey,
+	}
+}
+
+func (c *rateLimitConfig) validate() error {
+	for name, limits := range c.limits() {
+		if limits == nil {
+			continue
+		}
+		if limits.Agent < 0 || limits.Downstream < 0 || limits.IP < 0 {
+			return fmt.Errorf("%s rate limits must not be negative", name)
+		}
+	}
+	if a := c.Attestation; a != nil && (a.Agent != 0 || a.Downstream != 0) {
+		return errors.New("attestation rate limits only support the ip limit")
+	}
+	return nil
+}
+
+func (c *callerLimitsConfig) callerLimits() middleware.CallerLimits {
+	if c == nil {
+		return middleware.CallerLimits{}
+	}
+	return middleware.CallerLimits{
+		Agent:      c.Agent,
+		Downstream: c.Downstream,
+		IP:         c.IP,
+	}
+}
+
 func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
 	if len(c.UnusedKeys) != 0 {
 		l.Warnf("Detected unknown top-level config options: %q; this will be fatal in a future release.", c.UnusedKeys)
@@ -504,6 +681,25 @@ func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
 			l.Warnf("Detected unknown CA Subject config options: %q; this will be fatal in a future release.", cs.UnusedKeys)
 		}
 
+		if ap := c.Server.AgentPruning; ap != nil && len(ap.UnusedKeys) != 0 {
+			l.Warnf("Detected unknown agent pruning config options: %q; this will be fatal in a future release.", ap.UnusedKeys)
+		}
+
+		if cj := c.Server.CAJournal; cj != nil && len(cj.UnusedKeys) != 0 {
+			l.Warnf("Detected unknown CA journal config options: %q; this will be fatal in a future release.", cj.UnusedKeys)
+		}
+
+		if rl := c.Server.RateLimit; rl != nil {
+			if len(rl.UnusedKeys) != 0 {
+				l.Warnf("Detected unknown rate limit config options: %q; this will be fatal in a future release.", rl.UnusedKeys)
+			}
+			for name, limits := range rl.limits() {
+				if limits != nil && len(limits.UnusedKeys) != 0 {
+					l.Warnf("Detected unknown %s rate limit config options: %q; this will be fatal in a future release.", name, limits.UnusedKeys)
+				}
+			}
+		}
+
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -576,7 +772,7 @@ func defaultConfig() *Config {
 	}
 }
 
-func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
+func keyTypeFromString(kind, s string) (keymanager.KeyType, error) {
 	switch strings.ToLower(s) {
 	case "rsa-2048":
 		return keymanager.KeyType_RSA_2048, nil
@@ -587,7 +783,7 @@ func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
 	case "ec-p384":
 		return keymanager.KeyType_EC_P384, nil
 	default:
-		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("CA key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", s)
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("%s key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", kind, s)
 	}
 }
 
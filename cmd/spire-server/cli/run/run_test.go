@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,7 +16,9 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -184,6 +187,26 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "rsa-2048", c.Server.CAKeyType)
 			},
 		},
+		{
+			msg: "jwt_key_type should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Server.JWTKeyType = "rsa-2048"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "rsa-2048", c.Server.JWTKeyType)
+			},
+		},
+		{
+			msg: "jwt_svid_ttl should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Server.JWTSVIDTTL = "5m"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "5m", c.Server.JWTSVIDTTL)
+			},
+		},
 		{
 			msg: "ca_subject should be configurable by file",
 			fileInput: func(c *Config) {
@@ -242,6 +265,38 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "bar", c.Server.DataDir)
 			},
 		},
+		{
+			msg: "audit_log_file should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Server.AuditLogFile = "foo"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "foo", c.Server.AuditLogFile)
+			},
+		},
+		{
+			msg: "audit_log_file specified by CLI flag should take precedence over file",
+			fileInput: func(c *Config) {
+				c.Server.AuditLogFile = "foo"
+			},
+			cliInput: func(c *serverConfig) {
+				c.AuditLogFile = "bar"
+			},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "bar", c.Server.AuditLogFile)
+			},
+		},
+		{
+			msg: "authorization_policy_file should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Server.AuthorizationPolicyFile = "foo"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "foo", c.Server.AuthorizationPolicyFile)
+			},
+		},
 		{
 			msg: "jwt_issuer should be configurable by file",
 			fileInput: func(c *Config) {
@@ -531,6 +586,10 @@ func TestMergeInput(t *testing.T) {
 }
 
 func TestNewServerConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-run-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
 	cases := []struct {
 		msg         string
 		expectError bool
@@ -619,6 +678,137 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, &logrus.TextFormatter{}, l.Formatter)
 			},
 		},
+		{
+			msg:   "audit log is not set by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.AuditLog)
+			},
+		},
+		{
+			msg: "audit log gets set correctly",
+			input: func(c *Config) {
+				c.Server.AuditLogFile = filepath.Join(dir, "audit.log")
+				c.Server.LogFormat = "TEXT"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.AuditLog)
+
+				l := c.AuditLog.(*log.Logger)
+				require.Equal(t, &logrus.JSONFormatter{}, l.Formatter)
+				require.NoError(t, l.Close())
+				require.FileExists(t, filepath.Join(dir, "audit.log"))
+			},
+		},
+		{
+			msg:   "agent pruning is disabled by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Zero(t, c.PruneAgentsExpiredFor)
+				require.False(t, c.NotifyAgentPruning)
+			},
+		},
+		{
+			msg: "agent pruning is correctly configured",
+			input: func(c *Config) {
+				c.Server.AgentPruning = &agentPruningConfig{
+					ExpiredFor: "72h",
+					Notify:     true,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 72*time.Hour, c.PruneAgentsExpiredFor)
+				require.True(t, c.NotifyAgentPruning)
+			},
+		},
+		{
+			msg:   "ca journal is kept on disk by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.False(t, c.JournalInDataStore)
+				require.False(t, c.CoordinateRotation)
+			},
+		},
+		{
+			msg: "ca journal is kept in the datastore with coordinated rotation",
+			input: func(c *Config) {
+				c.Server.CAJournal = &caJournalConfig{
+					Storage:            "datastore",
+					CoordinateRotation: true,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.JournalInDataStore)
+				require.True(t, c.CoordinateRotation)
+			},
+		},
+		{
+			msg: "invalid agent pruning expired_for returns an error",
+			input: func(c *Config) {
+				c.Server.AgentPruning = &agentPruningConfig{ExpiredFor: "b"}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:   "rate limits are not set by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, endpoints.RateLimitConfig{}, c.RateLimits)
+			},
+		},
+		{
+			msg: "rate limits are correctly configured",
+			input: func(c *Config) {
+				c.Server.RateLimit = &rateLimitConfig{
+					Attestation: &callerLimitsConfig{IP: 5},
+					Signing:     &callerLimitsConfig{Agent: 100, Downstream: 200, IP: 1000},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, endpoints.RateLimitConfig{
+					Attestation: middleware.CallerLimits{IP: 5},
+					Signing:     middleware.CallerLimits{Agent: 100, Downstream: 200, IP: 1000},
+				}, c.RateLimits)
+			},
+		},
+		{
+			msg:   "authorization policy is not set by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.AuthorizationPolicy)
+			},
+		},
+		{
+			msg: "authorization policy gets loaded from the file",
+			input: func(c *Config) {
+				path := filepath.Join(dir, "policy.hcl")
+				require.NoError(t, ioutil.WriteFile(path, []byte(`rule {
+					caller_local = true
+					methods = ["*"]
+				}`), 0600))
+				c.Server.AuthorizationPolicyFile = path
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.AuthorizationPolicy)
+				require.Len(t, c.AuthorizationPolicy.Rules, 1)
+				require.True(t, c.AuthorizationPolicy.Rules[0].CallerLocal)
+			},
+		},
+		{
+			msg:         "invalid authorization policy returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				path := filepath.Join(dir, "invalid-policy.hcl")
+				require.NoError(t, ioutil.WriteFile(path, []byte(`rule { caller_local = true }`), 0600))
+				c.Server.AuthorizationPolicyFile = path
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "log_level and log_format are case insensitive",
 			input: func(c *Config) {
@@ -753,6 +943,25 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "jwt_svid_ttl is correctly parsed",
+			input: func(c *Config) {
+				c.Server.JWTSVIDTTL = "5m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 5*time.Minute, c.JWTSVIDTTL)
+			},
+		},
+		{
+			msg:         "invalid jwt_svid_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.JWTSVIDTTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "rsa-2048 ca_key_type is correctly parsed",
 			input: func(c *Config) {
@@ -799,6 +1008,45 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "jwt_key_type defaults to ca_key_type",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.JWTKeyType)
+			},
+		},
+		{
+			msg: "rsa-2048 jwt_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+				c.Server.JWTKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.JWTKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 jwt_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.JWTKeyType)
+			},
+		},
+		{
+			msg:         "unsupported jwt_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "ca_ttl is correctly parsed",
 			input: func(c *Config) {
@@ -954,6 +1202,48 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: "spiffe://domain.test bundle_endpoint_address must be configured",
 		},
+		{
+			name: "agent pruning expired_for must be configured",
+			applyConf: func(c *Config) {
+				c.Server.AgentPruning = &agentPruningConfig{Notify: true}
+			},
+			expectedErr: "agent_pruning expired_for must be configured",
+		},
+		{
+			name: "agent pruning expired_for must be positive",
+			applyConf: func(c *Config) {
+				c.Server.AgentPruning = &agentPruningConfig{ExpiredFor: "-1h"}
+			},
+			expectedErr: "agent_pruning expired_for must be positive",
+		},
+		{
+			name: "ca journal storage must be supported",
+			applyConf: func(c *Config) {
+				c.Server.CAJournal = &caJournalConfig{Storage: "etcd"}
+			},
+			expectedErr: `ca_journal storage "etcd" is not supported; use "disk" or "datastore"`,
+		},
+		{
+			name: "ca journal rotation can only be coordinated through the datastore",
+			applyConf: func(c *Config) {
+				c.Server.CAJournal = &caJournalConfig{CoordinateRotation: true}
+			},
+			expectedErr: "ca_journal coordinate_rotation requires the journal to be stored in the datastore",
+		},
+		{
+			name: "rate limits must not be negative",
+			applyConf: func(c *Config) {
+				c.Server.RateLimit = &rateLimitConfig{Signing: &callerLimitsConfig{Agent: -1}}
+			},
+			expectedErr: "signing rate limits must not be negative",
+		},
+		{
+			name: "attestation rate limits only support the ip limit",
+			applyConf: func(c *Config) {
+				c.Server.RateLimit = &rateLimitConfig{Attestation: &callerLimitsConfig{Agent: 1}}
+			},
+			expectedErr: "attestation rate limits only support the ip limit",
+		},
 		{
 			name:        "deprecated configurable `svid_ttl` must not be set",
 			applyConf:   func(c *Config) { c.Server.DeprecatedSVIDTTL = "1h" },
@@ -1007,6 +1297,26 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 		//	testFilePath:   fmt.Sprintf("%v/server_bad_nested_experimental_block.conf", testFileDir),
 		//	expectedLogMsg: "Detected unknown experimental config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
 		//},
+		{
+			msg:            "in nested agent_pruning block",
+			testFilePath:   fmt.Sprintf("%v/server_bad_nested_agent_pruning_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown agent pruning config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
+		{
+			msg:            "in nested ca_journal block",
+			testFilePath:   fmt.Sprintf("%v/server_bad_nested_ca_journal_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown CA journal config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
+		{
+			msg:            "in nested rate_limit block",
+			testFilePath:   fmt.Sprintf("%v/server_bad_nested_rate_limit_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown rate limit config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
+		{
+			msg:            "in nested rate_limit signing block",
+			testFilePath:   fmt.Sprintf("%v/server_bad_nested_rate_limit_signing_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown signing rate limit config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
 		{
 			msg:            "in nested bundle_endpoint_acme block",
 			testFilePath:   fmt.Sprintf("%v/server_bad_nested_bundle_endpoint_acme_block.conf", testFileDir),
@@ -1,9 +1,13 @@
 package util
 
 import (
+	"fmt"
 	"net"
+	"strings"
 	"time"
 
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/api/server/localauthority/v1"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"google.golang.org/grpc"
 )
@@ -20,6 +24,22 @@ func NewRegistrationClient(socketPath string) (registration.RegistrationClient,
 	return registration.NewRegistrationClient(conn), err
 }
 
+func NewFederationClient(socketPath string) (federation.FederationClient, error) {
+	conn, err := grpc.Dial(socketPath, grpc.WithInsecure(), grpc.WithDialer(dialer)) //nolint: staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return federation.NewFederationClient(conn), err
+}
+
+func NewLocalAuthorityClient(socketPath string) (localauthority.LocalAuthorityClient, error) {
+	conn, err := grpc.Dial(socketPath, grpc.WithInsecure(), grpc.WithDialer(dialer)) //nolint: staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return localauthority.NewLocalAuthorityClient(conn), err
+}
+
 func dialer(addr string, timeout time.Duration) (net.Conn, error) {
 	return net.DialTimeout("unix", addr, timeout)
 }
@@ -37,3 +57,20 @@ func Pluralizer(msg string, singular string, plural string, val int) string {
 
 	return result
 }
+
+// ParseSelectorMatch parses the selector match behavior used to filter
+// entries and agents by selectors: exact, subset, any or superset.
+func ParseSelectorMatch(s string) (registration.SelectorMatch, error) {
+	switch strings.ToLower(s) {
+	case "exact":
+		return registration.SelectorMatch_MATCH_EXACT, nil
+	case "subset":
+		return registration.SelectorMatch_MATCH_SUBSET, nil
+	case "any":
+		return registration.SelectorMatch_MATCH_ANY, nil
+	case "superset":
+		return registration.SelectorMatch_MATCH_SUPERSET, nil
+	default:
+		return 0, fmt.Errorf("match behavior %q unknown: must be exact, subset, any or superset", s)
+	}
+}
@@ -174,6 +174,7 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemet
 		SVIDCachePath:     a.agentSVIDPath(),
 		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Attestor),
 		ServerAddress:     a.c.ServerAddress,
+		SVIDKeyType:       a.c.AgentSVIDKeyType,
 	}
 	return attestor.New(&config).Attest(ctx)
 }
@@ -191,6 +192,8 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		SyncInterval:    a.c.SyncInterval,
+		SVIDKeyType:     a.c.AgentSVIDKeyType,
+		WorkloadKeyType: a.c.WorkloadX509SVIDKeyType,
 	}
 
 	mgr, err := manager.New(config)
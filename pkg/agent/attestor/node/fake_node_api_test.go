@@ -6,12 +6,14 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"math/big"
 	"net"
 	"net/url"
 	"testing"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/version"
 	servernodeattestor "github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -81,6 +83,10 @@ func (n *fakeNodeAPI) Attest(stream node.Node_AttestServer) error {
 			return errors.New("attestation has been purposefully failed")
 		}
 
+		if req.AgentVersion != version.Version() {
+			return fmt.Errorf("unexpected agent version %q", req.AgentVersion)
+		}
+
 		csr, err := x509.ParseCertificateRequest(req.Csr)
 		if err != nil {
 			return err
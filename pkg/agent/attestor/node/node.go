@@ -2,7 +2,7 @@ package attestor
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -14,15 +14,18 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/version"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
@@ -37,7 +40,7 @@ const (
 
 type AttestationResult struct {
 	SVID   []*x509.Certificate
-	Key    *ecdsa.PrivateKey
+	Key    crypto.Signer
 	Bundle *bundleutil.Bundle
 }
 
@@ -56,6 +59,9 @@ type Config struct {
 	SVIDCachePath     string
 	Log               logrus.FieldLogger
 	ServerAddress     string
+
+	// SVIDKeyType is the type of the key generated for the agent SVID
+	SVIDKeyType keymanager.KeyType
 }
 
 type attestor struct {
@@ -93,7 +99,7 @@ func (a *attestor) Attest(ctx context.Context) (res *AttestationResult, err erro
 }
 
 // Load the current SVID and key. The returned SVID is nil to indicate a new SVID should be created.
-func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
+func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, crypto.Signer, error) {
 	km := a.c.Catalog.GetKeyManager()
 	fetchRes, err := km.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
 	if err != nil {
@@ -107,9 +113,9 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 
 	switch {
 	case privateKeyExists && svidExists && !svidIsExpired:
-		key, err := x509.ParseECPrivateKey(fetchRes.PrivateKey)
+		key, err := keyutil.ParsePrivateKey(fetchRes.PrivateKey)
 		if err != nil {
-			return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
+			return nil, nil, fmt.Errorf("parse key from keymanager: %v", err)
 		}
 		return svid, key, nil
 	case privateKeyExists && svidExists && svidIsExpired:
@@ -122,13 +128,15 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 		// Neither private key nor SVID were found.
 	}
 
-	generateRes, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	generateRes, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{
+		KeyType: a.c.SVIDKeyType,
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("generate key pair: %s", err)
 	}
-	key, err := x509.ParseECPrivateKey(generateRes.PrivateKey)
+	key, err := keyutil.ParsePrivateKey(generateRes.PrivateKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
+		return nil, nil, fmt.Errorf("parse key from keymanager: %v", err)
 	}
 	return nil, key, nil
 }
@@ -215,7 +223,7 @@ func (a *attestor) readSVIDFromDisk() []*x509.Certificate {
 
 // newSVID obtains an agent svid for the given private key by performing node attesatation. The bundle is
 // necessary in order to validate the SPIRE server we are attesting to. Returns the SVID and an updated bundle.
-func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *bundleutil.Bundle) (newSVID []*x509.Certificate, newBundle *bundleutil.Bundle, err error) {
+func (a *attestor) newSVID(ctx context.Context, key crypto.Signer, bundle *bundleutil.Bundle) (newSVID []*x509.Certificate, newBundle *bundleutil.Bundle, err error) {
 	counter := telemetry_agent.StartNodeAttestorNewSVIDCall(a.c.Metrics)
 	attestorName := ""
 	defer func() {
@@ -297,6 +305,8 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 			AttestationData: data.AttestationData,
 			Csr:             csr,
 			Response:        data.Response,
+			AgentVersion:    version.Version(),
+			AgentPlugins:    a.agentPlugins(),
 		}
 
 		if err := attestStream.Send(attestReq); err != nil {
@@ -425,3 +435,21 @@ func (a *attestor) parseAttestationResponse(r *node.AttestResponse) (string, []*
 
 	return agentID, svid, bundle, nil
 }
+
+// agentPlugins returns the plugins loaded by the agent, which are reported to
+// the server at attestation.
+func (a *attestor) agentPlugins() []*common.AgentPlugin {
+	var plugins []*common.AgentPlugin
+	add := func(pluginType string, info catalog.PluginInfo) {
+		if info != nil {
+			plugins = append(plugins, &common.AgentPlugin{Type: pluginType, Name: info.Name()})
+		}
+	}
+
+	add(keymanager.Type, a.c.Catalog.GetKeyManager().PluginInfo)
+	add(nodeattestor.Type, a.c.Catalog.GetNodeAttestor().PluginInfo)
+	for _, workloadAttestor := range a.c.Catalog.GetWorkloadAttestors() {
+		add(workloadattestor.Type, workloadAttestor.PluginInfo)
+	}
+	return plugins
+}
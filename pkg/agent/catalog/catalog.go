@@ -32,6 +32,7 @@ type Catalog interface {
 type GlobalConfig = catalog.GlobalConfig
 type HCLPluginConfig = catalog.HCLPluginConfig
 type HCLPluginConfigMap = catalog.HCLPluginConfigMap
+type PluginInfo = catalog.PluginInfo
 
 func KnownPlugins() []catalog.PluginClient {
 	return []catalog.PluginClient{
@@ -64,6 +65,7 @@ func BuiltIns() []catalog.Plugin {
 }
 
 type KeyManager struct {
+	catalog.PluginInfo
 	keymanager.KeyManager
 }
 
@@ -2,7 +2,7 @@ package client
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"io"
@@ -274,7 +274,7 @@ func createClient(nodeClient *mock_node.MockNodeClient) *client {
 	return client
 }
 
-func keysAndBundle() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
+func keysAndBundle() ([]*x509.Certificate, crypto.Signer, []*x509.Certificate) {
 	return nil, nil, nil
 }
 
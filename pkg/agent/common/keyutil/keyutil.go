@@ -0,0 +1,58 @@
+package keyutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+)
+
+// GenerateKey generates a private key of the given type. An EC P256 key is
+// generated if the type is unspecified.
+func GenerateKey(keyType keymanager.KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case keymanager.KeyType_UNSPECIFIED_KEY_TYPE, keymanager.KeyType_EC_P256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case keymanager.KeyType_EC_P384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case keymanager.KeyType_RSA_1024:
+		return rsa.GenerateKey(rand.Reader, 1024)
+	case keymanager.KeyType_RSA_2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case keymanager.KeyType_RSA_4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, fmt.Errorf("unknown key type %q", keyType)
+	}
+}
+
+// MarshalPrivateKey encodes the private key in PKCS #8 ASN.1 DER form, which
+// is the form exchanged with the KeyManager plugin.
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(key)
+}
+
+// ParsePrivateKey parses a private key in PKCS #8 ASN.1 DER form. EC private
+// keys in SEC 1 ASN.1 DER form, as stored by previous versions of the agent,
+// are also accepted.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	key, err := x509.ParsePKCS8PrivateKey(data)
+	if err != nil {
+		ecKey, ecErr := x509.ParseECPrivateKey(data)
+		if ecErr != nil {
+			return nil, err
+		}
+		return ecKey, nil
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("expected crypto.Signer; got %T", key)
+	}
+	return signer, nil
+}
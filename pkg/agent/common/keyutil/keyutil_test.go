@@ -0,0 +1,66 @@
+package keyutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKey(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		keyType keymanager.KeyType
+		curve   elliptic.Curve
+		bits    int
+	}{
+		{name: "unspecified", keyType: keymanager.KeyType_UNSPECIFIED_KEY_TYPE, curve: elliptic.P256()},
+		{name: "ec-p256", keyType: keymanager.KeyType_EC_P256, curve: elliptic.P256()},
+		{name: "ec-p384", keyType: keymanager.KeyType_EC_P384, curve: elliptic.P384()},
+		{name: "rsa-2048", keyType: keymanager.KeyType_RSA_2048, bits: 2048},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := GenerateKey(tt.keyType)
+			require.NoError(t, err)
+
+			switch key := key.(type) {
+			case *ecdsa.PrivateKey:
+				require.Equal(t, tt.curve, key.Curve)
+			case *rsa.PrivateKey:
+				require.Equal(t, tt.bits, key.N.BitLen())
+			default:
+				require.FailNowf(t, "unexpected key type", "%T", key)
+			}
+
+			// keys survive a round trip through the KeyManager encoding
+			data, err := MarshalPrivateKey(key)
+			require.NoError(t, err)
+			parsed, err := ParsePrivateKey(data)
+			require.NoError(t, err)
+			require.Equal(t, key, parsed)
+		})
+	}
+
+	_, err := GenerateKey(keymanager.KeyType(100))
+	require.EqualError(t, err, `unknown key type "100"`)
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := GenerateKey(keymanager.KeyType_EC_P256)
+	require.NoError(t, err)
+
+	// EC keys stored by previous versions of the agent
+	data, err := x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	parsed, err := ParsePrivateKey(data)
+	require.NoError(t, err)
+	require.Equal(t, key, parsed)
+
+	_, err = ParsePrivateKey([]byte("malformed"))
+	require.Error(t, err)
+}
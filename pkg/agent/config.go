@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -63,6 +64,12 @@ type Config struct {
 
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
+
+	// AgentSVIDKeyType is the key type of the agent SVID
+	AgentSVIDKeyType keymanager.KeyType
+
+	// WorkloadX509SVIDKeyType is the key type of workload X509-SVIDs
+	WorkloadX509SVIDKeyType keymanager.KeyType
 }
 
 func New(c *Config) *Agent {
@@ -1,7 +1,7 @@
 package manager
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 	"net/url"
@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
@@ -20,7 +21,7 @@ import (
 type Config struct {
 	// Agent SVID and key resulting from successful attestation.
 	SVID             []*x509.Certificate
-	SVIDKey          *ecdsa.PrivateKey
+	SVIDKey          crypto.Signer
 	Bundle           *cache.Bundle
 	Catalog          catalog.Catalog
 	TrustDomain      url.URL
@@ -32,6 +33,14 @@ type Config struct {
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
 
+	// SVIDKeyType is the type of the keys generated when the agent SVID is
+	// rotated.
+	SVIDKeyType keymanager.KeyType
+
+	// WorkloadKeyType is the type of the keys generated for workload
+	// X509-SVIDs.
+	WorkloadKeyType keymanager.KeyType
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
@@ -67,6 +76,7 @@ func newManager(c *Config) (*manager, error) {
 		Metrics:      c.Metrics,
 		SVID:         c.SVID,
 		SVIDKey:      c.SVIDKey,
+		SVIDKeyType:  c.SVIDKeyType,
 		SpiffeID:     spiffeID,
 		BundleStream: cache.SubscribeToBundleChanges(),
 		ServerAddr:   c.ServerAddr,
@@ -2,7 +2,7 @@ package manager
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -12,6 +12,7 @@ import (
 	observer "github.com/imkira/go-observer"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
@@ -161,7 +162,7 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 	now := m.clk.Now()
 
 	cachedSVID, ok := m.cache.GetJWTSVID(spiffeID, audience)
-	if ok && !rotationutil.JWTSVIDExpiresSoon(cachedSVID, now) {
+	if ok && !rotationutil.JWTSVIDExpiresSoon(cachedSVID, now) && !m.signedByTaintedKey(cachedSVID) {
 		return cachedSVID, nil
 	}
 
@@ -184,6 +185,16 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 	return newSVID, nil
 }
 
+// signedByTaintedKey returns true if the JWT-SVID was signed by a JWT key
+// that has been tainted in the trust domain bundle.
+func (m *manager) signedByTaintedKey(svid *client.JWTSVID) bool {
+	bundle := m.cache.Bundle()
+	if bundle == nil {
+		return false
+	}
+	return rotationutil.JWTSVIDSignedByTaintedKey(svid, bundle.TaintedJWTSigningKeys())
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
 	for {
 		select {
@@ -252,9 +263,9 @@ func (m *manager) storeBundle(bundle *bundleutil.Bundle) {
 	}
 }
 
-func (m *manager) storePrivateKey(ctx context.Context, key *ecdsa.PrivateKey) error {
+func (m *manager) storePrivateKey(ctx context.Context, key crypto.Signer) error {
 	km := m.c.Catalog.GetKeyManager()
-	keyBytes, err := x509.MarshalECPrivateKey(key)
+	keyBytes, err := keyutil.MarshalPrivateKey(key)
 	if err != nil {
 		return err
 	}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/disk"
@@ -231,7 +232,7 @@ func TestStoreKeyOnStartup(t *testing.T) {
 		t.Fatalf("No error expected but got: %v", err)
 	}
 
-	storedKey, err := x509.ParseECPrivateKey(kresp.PrivateKey)
+	storedKey, err := keyutil.ParsePrivateKey(kresp.PrivateKey)
 	if err != nil {
 		t.Fatalf("No error expected but got: %v", err)
 	}
@@ -374,6 +375,7 @@ func TestSVIDRotation(t *testing.T) {
 		RotationInterval: baseTTLSeconds / 2,
 		SyncInterval:     1 * time.Hour,
 		Clk:              mockClk,
+		SVIDKeyType:      keymanager.KeyType_EC_P384,
 	}
 
 	m, closer := initializeAndRunNewManager(t, c)
@@ -443,6 +445,10 @@ func TestSVIDRotation(t *testing.T) {
 	if key == baseSVIDKey {
 		t.Fatal("PrivateKey did not rotate")
 	}
+
+	// The new key has the configured type
+	require.IsType(t, &ecdsa.PrivateKey{}, key)
+	require.Equal(t, "P-384", key.(*ecdsa.PrivateKey).Curve.Params().Name)
 }
 
 func TestSynchronization(t *testing.T) {
@@ -492,6 +498,7 @@ func TestSynchronization(t *testing.T) {
 		SyncInterval:     time.Hour,
 		Clk:              mockClk,
 		Catalog:          cat,
+		WorkloadKeyType:  keymanager.KeyType_EC_P384,
 	}
 
 	m := makeManager(t, c)
@@ -534,6 +541,12 @@ func TestSynchronization(t *testing.T) {
 		require.Equal(t, eb, eu, "identity received does not match identity on cache")
 	}
 
+	// Workload keys have the configured type
+	for _, identity := range u.Identities {
+		require.IsType(t, &ecdsa.PrivateKey{}, identity.PrivateKey)
+		require.Equal(t, "P-384", identity.PrivateKey.(*ecdsa.PrivateKey).Curve.Params().Name)
+	}
+
 	// SVIDs expire after 3 seconds, so we shouldn't expect any updates after
 	// 1 second has elapsed.
 	mockClk.Add(time.Second)
@@ -2,14 +2,15 @@ package manager
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto"
 	"crypto/x509"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -39,6 +40,8 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	var csrs []csrRequest
 	var expiring int
 	var outdated int
+	var tainted int
+	taintedAuthorities := taintedX509Authorities(update.Bundles[m.c.TrustDomain.String()])
 	m.cache.UpdateEntries(update, func(existingEntry, newEntry *common.RegistrationEntry, svid *cache.X509SVID) bool {
 		switch {
 		case svid == nil:
@@ -51,12 +54,18 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 			}).Warn("cached X509 SVID is empty")
 		case rotationutil.ShouldRotateX509(m.c.Clk.Now(), svid.Chain[0]):
 			expiring++
+		case rotationutil.X509IssuedByTaintedAuthority(svid.Chain, taintedAuthorities):
+			// SVID was issued by an authority that is no longer trusted
+			tainted++
 		case existingEntry != nil && !stringsEqual(existingEntry.DnsNames, newEntry.DnsNames):
 			// DNS Names have changed
 			outdated++
 		case existingEntry != nil && existingEntry.Ttl != newEntry.Ttl:
 			// TTL has changed
 			outdated++
+		case existingEntry != nil && !proto.Equal(existingEntry.X509SvidProfile, newEntry.X509SvidProfile):
+			// X509-SVID profile has changed
+			outdated++
 		default:
 			// SVID is good
 			return false
@@ -74,6 +83,10 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		telemetry_agent.AddCacheManagerOutdatedSVIDsSample(m.c.Metrics, float32(outdated))
 		m.c.Log.WithField(telemetry.OutdatedSVIDs, outdated).Debug("Updating SVIDs with outdated attributes in cache")
 	}
+	if tainted > 0 {
+		telemetry_agent.AddCacheManagerTaintedSVIDsSample(m.c.Metrics, float32(tainted))
+		m.c.Log.WithField(telemetry.TaintedSVIDs, tainted).Info("Updating SVIDs issued by a tainted authority in cache")
+	}
 
 	staleEntries := m.cache.GetStaleEntries()
 	if len(staleEntries) > 0 {
@@ -113,7 +126,7 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 		Csrs: make(map[string][]byte),
 	}
 
-	privateKeys := make(map[string]*ecdsa.PrivateKey, len(csrs))
+	privateKeys := make(map[string]crypto.Signer, len(csrs))
 	for _, csr := range csrs {
 		log := m.c.Log.WithField("spiffe_id", csr.SpiffeID)
 		if !csr.CurrentSVIDExpiresAt.IsZero() {
@@ -127,7 +140,7 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 		}
 
 		log.Info("Renewing X509-SVID")
-		privateKey, csrBytes, err := newCSR(csr.SpiffeID)
+		privateKey, csrBytes, err := newCSR(csr.SpiffeID, m.c.WorkloadKeyType)
 		if err != nil {
 			return nil, err
 		}
@@ -186,8 +199,8 @@ func (m *manager) fetchEntries(ctx context.Context) (_ *cache.UpdateEntries, err
 	}, nil
 }
 
-func newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err error) {
-	pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func newCSR(spiffeID string, keyType keymanager.KeyType) (pk crypto.Signer, csr []byte, err error) {
+	pk, err = keyutil.GenerateKey(keyType)
 	if err != nil {
 		return
 	}
@@ -210,6 +223,15 @@ func parseBundles(bundles map[string]*common.Bundle) (map[string]*cache.Bundle,
 	return out, nil
 }
 
+// taintedX509Authorities returns the tainted root CAs of the given bundle, if
+// any
+func taintedX509Authorities(bundle *cache.Bundle) []*x509.Certificate {
+	if bundle == nil {
+		return nil
+	}
+	return bundle.TaintedRootCAs()
+}
+
 // stringsEqual determines whether two string slices are equal or not
 func stringsEqual(x, y []string) bool {
 	if len(x) != len(y) {
@@ -2,9 +2,6 @@ package disk
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"crypto/x509"
 	"errors"
 	"io/ioutil"
@@ -13,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/diskutil"
@@ -49,18 +47,18 @@ func New() *Plugin {
 	}
 }
 
-func (d *Plugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
-	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func (d *Plugin) GenerateKeyPair(ctx context.Context, req *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+	key, err := keyutil.GenerateKey(req.KeyType)
 	if err != nil {
 		return nil, err
 	}
 
-	privData, err := x509.MarshalECPrivateKey(key)
+	privData, err := keyutil.MarshalPrivateKey(key)
 	if err != nil {
 		return nil, err
 	}
 
-	pubData, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	pubData, err := x509.MarshalPKIXPublicKey(key.Public())
 	if err != nil {
 		return nil, err
 	}
@@ -101,13 +99,17 @@ func (d *Plugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyReq
 		return nil, err
 	}
 
-	// Check key integrity first
-	key, err := x509.ParseECPrivateKey(data)
+	// Check key integrity first. Keys stored by previous versions are
+	// converted to PKCS #8.
+	key, err := keyutil.ParsePrivateKey(data)
 	if err != nil {
 		return nil, err
 	}
 
-	resp.PrivateKey, _ = x509.MarshalECPrivateKey(key)
+	resp.PrivateKey, err = keyutil.MarshalPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
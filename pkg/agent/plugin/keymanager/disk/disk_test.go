@@ -2,6 +2,8 @@ package disk
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -14,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 )
@@ -41,8 +44,26 @@ func TestDisk_GenerateKeyPair(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, genResp.PrivateKey, fileData)
 
-	_, err = x509.ParseECPrivateKey(genResp.PrivateKey)
+	key, err := keyutil.ParsePrivateKey(genResp.PrivateKey)
 	require.NoError(t, err)
+	require.IsType(t, &ecdsa.PrivateKey{}, key)
+}
+
+func TestDisk_GenerateKeyPairWithKeyType(t *testing.T) {
+	plugin := New()
+
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{
+		KeyType: keymanager.KeyType_RSA_2048,
+	})
+	require.NoError(t, err)
+
+	key, err := keyutil.ParsePrivateKey(genResp.PrivateKey)
+	require.NoError(t, err)
+	require.IsType(t, &rsa.PrivateKey{}, key)
+
+	publicKey, err := x509.ParsePKIXPublicKey(genResp.PublicKey)
+	require.NoError(t, err)
+	require.Equal(t, key.Public(), publicKey)
 }
 
 func TestDisk_FetchPrivateKey(t *testing.T) {
@@ -62,6 +83,27 @@ func TestDisk_FetchPrivateKey(t *testing.T) {
 	assert.Equal(t, genResp.PrivateKey, fetchResp.PrivateKey)
 }
 
+func TestDisk_FetchLegacyPrivateKey(t *testing.T) {
+	plugin := New()
+	tempDir, err := ioutil.TempDir("", "km-disk-test")
+	require.NoError(t, err)
+	plugin.dir = tempDir
+	defer os.RemoveAll(tempDir)
+
+	// keys stored by previous versions are EC keys in SEC 1 form
+	key, err := keyutil.GenerateKey(keymanager.KeyType_EC_P256)
+	require.NoError(t, err)
+	legacyData, err := x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(tempDir, keyFileName), legacyData, 0600))
+
+	fetchResp, err := plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.NoError(t, err)
+	expected, err := keyutil.MarshalPrivateKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, expected, fetchResp.PrivateKey)
+}
+
 func TestDisk_Configure(t *testing.T) {
 	tempDir, err := ioutil.TempDir("", "km-disk-test")
 	require.NoError(t, err)
@@ -18,12 +18,19 @@ type GenerateKeyPairRequest = keymanager.GenerateKeyPairRequest               //
 type GenerateKeyPairResponse = keymanager.GenerateKeyPairResponse             //nolint: golint
 type KeyManagerClient = keymanager.KeyManagerClient                           //nolint: golint
 type KeyManagerServer = keymanager.KeyManagerServer                           //nolint: golint
+type KeyType = keymanager.KeyType                                             //nolint: golint
 type StorePrivateKeyRequest = keymanager.StorePrivateKeyRequest               //nolint: golint
 type StorePrivateKeyResponse = keymanager.StorePrivateKeyResponse             //nolint: golint
 type UnimplementedKeyManagerServer = keymanager.UnimplementedKeyManagerServer //nolint: golint
 
 const (
-	Type = "KeyManager"
+	Type                         = "KeyManager"
+	KeyType_EC_P256              = keymanager.KeyType_EC_P256              //nolint: golint
+	KeyType_EC_P384              = keymanager.KeyType_EC_P384              //nolint: golint
+	KeyType_RSA_1024             = keymanager.KeyType_RSA_1024             //nolint: golint
+	KeyType_RSA_2048             = keymanager.KeyType_RSA_2048             //nolint: golint
+	KeyType_RSA_4096             = keymanager.KeyType_RSA_4096             //nolint: golint
+	KeyType_UNSPECIFIED_KEY_TYPE = keymanager.KeyType_UNSPECIFIED_KEY_TYPE //nolint: golint
 )
 
 // KeyManager is the client interface for the service type KeyManager interface.
@@ -2,12 +2,11 @@ package memory
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto"
 	"crypto/x509"
 	"sync"
 
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
@@ -26,7 +25,7 @@ func builtin(p *Plugin) catalog.Plugin {
 }
 
 type Plugin struct {
-	key *ecdsa.PrivateKey
+	key crypto.Signer
 	mtx sync.RWMutex
 }
 
@@ -34,16 +33,16 @@ func New() *Plugin {
 	return &Plugin{}
 }
 
-func (m *Plugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
-	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func (m *Plugin) GenerateKeyPair(ctx context.Context, req *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+	key, err := keyutil.GenerateKey(req.KeyType)
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := x509.MarshalECPrivateKey(key)
+	privateKey, err := keyutil.MarshalPrivateKey(key)
 	if err != nil {
 		return nil, err
 	}
-	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +56,7 @@ func (m *Plugin) StorePrivateKey(ctx context.Context, req *keymanager.StorePriva
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	key, err := x509.ParseECPrivateKey(req.PrivateKey)
+	key, err := keyutil.ParsePrivateKey(req.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +74,7 @@ func (m *Plugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyReq
 		return &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}, nil
 	}
 
-	privateKey, err := x509.MarshalECPrivateKey(m.key)
+	privateKey, err := keyutil.MarshalPrivateKey(m.key)
 	if err != nil {
 		return &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}, err
 	}
@@ -2,12 +2,13 @@ package memory
 
 import (
 	"context"
-	"crypto/x509"
+	"crypto/ecdsa"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 )
@@ -22,8 +23,24 @@ func TestMemory_GenerateKeyPair(t *testing.T) {
 	require.NoError(t, e)
 	_, e = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: data.PrivateKey})
 	require.NoError(t, e)
-	priv, err := x509.ParseECPrivateKey(data.PrivateKey)
+	priv, err := keyutil.ParsePrivateKey(data.PrivateKey)
 	require.NoError(t, err)
+	assert.IsType(t, &ecdsa.PrivateKey{}, priv)
+	assert.Equal(t, plugin.key, priv)
+}
+
+func TestMemory_GenerateKeyPairWithKeyType(t *testing.T) {
+	plugin := New()
+	data, e := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{
+		KeyType: keymanager.KeyType_EC_P384,
+	})
+	require.NoError(t, e)
+	_, e = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: data.PrivateKey})
+	require.NoError(t, e)
+	priv, err := keyutil.ParsePrivateKey(data.PrivateKey)
+	require.NoError(t, err)
+	require.IsType(t, &ecdsa.PrivateKey{}, priv)
+	assert.Equal(t, "P-384", priv.(*ecdsa.PrivateKey).Curve.Params().Name)
 	assert.Equal(t, plugin.key, priv)
 }
 
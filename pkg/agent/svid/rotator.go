@@ -2,7 +2,7 @@ package svid
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -12,6 +12,7 @@ import (
 	observer "github.com/imkira/go-observer"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keyutil"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
@@ -51,7 +52,7 @@ type rotator struct {
 
 type State struct {
 	SVID []*x509.Certificate
-	Key  *ecdsa.PrivateKey
+	Key  crypto.Signer
 }
 
 // Run runs the rotator. It monitors the server SVID for expiration and rotates
@@ -109,7 +110,7 @@ func (r *rotator) SetRotationFinishedHook(f func()) {
 
 // rotateSVID asks SPIRE's server for a new agent's SVID.
 func (r *rotator) rotateSVID(ctx context.Context) (err error) {
-	if !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0]) {
+	if !r.shouldRotate() {
 		return nil
 	}
 
@@ -177,12 +178,31 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 	return nil
 }
 
-func (r *rotator) newKey(ctx context.Context) (*ecdsa.PrivateKey, error) {
+// shouldRotate returns true if the agent SVID is about to expire or was
+// issued by an authority that has been tainted in the trust domain bundle.
+func (r *rotator) shouldRotate() bool {
+	svid := r.state.Value().(State).SVID
+	if rotationutil.ShouldRotateX509(r.clk.Now(), svid[0]) {
+		return true
+	}
+
+	r.bsm.RLock()
+	bundle := r.c.BundleStream.Value()[r.c.TrustDomain.String()]
+	r.bsm.RUnlock()
+	if bundle == nil {
+		return false
+	}
+	return rotationutil.X509IssuedByTaintedAuthority(svid, bundle.TaintedRootCAs())
+}
+
+func (r *rotator) newKey(ctx context.Context) (crypto.Signer, error) {
 	km := r.c.Catalog.GetKeyManager()
-	resp, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	resp, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{
+		KeyType: r.c.SVIDKeyType,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("generate key pair: %v", err)
 	}
 
-	return x509.ParseECPrivateKey(resp.PrivateKey)
+	return keyutil.ParsePrivateKey(resp.PrivateKey)
 }
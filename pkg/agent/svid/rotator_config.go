@@ -1,7 +1,7 @@
 package svid
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"net/url"
 	"sync"
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -27,7 +28,10 @@ type RotatorConfig struct {
 	ServerAddr  string
 	// Initial SVID and key
 	SVID    []*x509.Certificate
-	SVIDKey *ecdsa.PrivateKey
+	SVIDKey crypto.Signer
+
+	// Type of the keys generated on rotation
+	SVIDKeyType keymanager.KeyType
 
 	BundleStream *cache.BundleStream
 
@@ -66,7 +70,7 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 		Log:         c.Log,
 		Addr:        c.ServerAddr,
 		RotMtx:      rotMtx,
-		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
+		KeysAndBundle: func() ([]*x509.Certificate, crypto.Signer, []*x509.Certificate) {
 			s := state.Value().(State)
 
 			bsm.RLock()
@@ -13,8 +13,10 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	mock_client "github.com/spiffe/spire/test/mock/agent/client"
@@ -48,7 +50,9 @@ func (s *RotatorTestSuite) SetupTest() {
 
 	b, err := util.LoadBundleFixture()
 	s.Require().NoError(err)
-	s.bundle = observer.NewProperty(b)
+	s.bundle = observer.NewProperty(map[string]*cache.Bundle{
+		"spiffe://example.org": bundleutil.BundleFromRootCAs("spiffe://example.org", b),
+	})
 
 	cat := fakeagentcatalog.New()
 	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
@@ -183,6 +187,57 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().True(goodCert.Equal(state.SVID[0]))
 }
 
+func (s *RotatorTestSuite) TestRotateSVIDIssuedByTaintedAuthority() {
+	caTemp, err := util.NewCATemplate(s.mockClock, "example.org")
+	s.Require().NoError(err)
+	caTemp.SubjectKeyId = []byte("tainted")
+	ca, caKey, err := util.SelfSign(caTemp)
+	s.Require().NoError(err)
+
+	// Cert that's valid for 1hr but issued by the CA
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	issuedCert, _, err := util.Sign(temp, ca, caKey)
+	s.Require().NoError(err)
+
+	temp, err = util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	state := State{
+		SVID: []*x509.Certificate{issuedCert},
+	}
+	s.r.state = observer.NewProperty(state)
+	stream := s.r.Subscribe()
+
+	// The SVID is not rotated while the CA is trusted
+	err = s.r.rotateSVID(context.Background())
+	s.Assert().NoError(err)
+	s.Require().False(stream.HasNext())
+
+	// Taint the CA. The SVID should be rotated right away.
+	bundle := common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: ca.Raw, TaintedKey: true}},
+	}
+	taintedBundle, err := bundleutil.BundleFromProto(&bundle)
+	s.Require().NoError(err)
+	s.bundle.Update(map[string]*cache.Bundle{
+		"spiffe://example.org": taintedBundle,
+	})
+	s.r.c.BundleStream.Next()
+
+	s.expectSVIDRotation(goodCert)
+	err = s.r.rotateSVID(context.Background())
+	s.Assert().NoError(err)
+	s.Require().True(stream.HasNext())
+
+	state = stream.Next().(State)
+	s.Require().Len(state.SVID, 1)
+	s.Assert().True(goodCert.Equal(state.SVID[0]))
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
@@ -1,6 +1,7 @@
 package bundleutil
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/x509"
 	"errors"
@@ -12,6 +13,8 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Bundle struct {
@@ -89,6 +92,31 @@ func (b *Bundle) JWTSigningKeys() map[string]crypto.PublicKey {
 	return b.jwtSigningKeys
 }
 
+// TaintedRootCAs returns the root CAs whose key has been tainted.
+func (b *Bundle) TaintedRootCAs() []*x509.Certificate {
+	var tainted []*x509.Certificate
+	for i, rootCA := range b.b.RootCas {
+		if rootCA.TaintedKey && i < len(b.rootCAs) {
+			tainted = append(tainted, b.rootCAs[i])
+		}
+	}
+	return tainted
+}
+
+// TaintedJWTSigningKeys returns the JWT signing keys that have been tainted,
+// keyed by key ID.
+func (b *Bundle) TaintedJWTSigningKeys() map[string]crypto.PublicKey {
+	tainted := make(map[string]crypto.PublicKey)
+	for _, jwtSigningKey := range b.b.JwtSigningKeys {
+		if jwtSigningKey.TaintedKey {
+			if key, ok := b.jwtSigningKeys[jwtSigningKey.Kid]; ok {
+				tainted[jwtSigningKey.Kid] = key
+			}
+		}
+	}
+	return tainted
+}
+
 // RefreshHint returns the bundle refresh hint.
 func (b *Bundle) RefreshHint() time.Duration {
 	return time.Second * time.Duration(b.b.RefreshHint)
@@ -187,24 +215,26 @@ func JWTSigningKeysFromBundleProto(b *common.Bundle) (map[string]crypto.PublicKe
 func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	c := cloneBundle(a)
 
+	// Root CAs and JWT signing keys are compared without their tainted flag
+	// so that merging in an untainted copy doesn't duplicate a tainted one.
 	rootCAs := make(map[string]bool)
 	for _, rootCA := range a.RootCas {
-		rootCAs[rootCA.String()] = true
+		rootCAs[rootCAKey(rootCA)] = true
 	}
 	jwtSigningKeys := make(map[string]bool)
 	for _, jwtSigningKey := range a.JwtSigningKeys {
-		jwtSigningKeys[jwtSigningKey.String()] = true
+		jwtSigningKeys[jwtSigningKeyKey(jwtSigningKey)] = true
 	}
 
 	var changed bool
 	for _, rootCA := range b.RootCas {
-		if !rootCAs[rootCA.String()] {
+		if !rootCAs[rootCAKey(rootCA)] {
 			c.RootCas = append(c.RootCas, rootCA)
 			changed = true
 		}
 	}
 	for _, jwtSigningKey := range b.JwtSigningKeys {
-		if !jwtSigningKeys[jwtSigningKey.String()] {
+		if !jwtSigningKeys[jwtSigningKeyKey(jwtSigningKey)] {
 			c.JwtSigningKeys = append(c.JwtSigningKeys, jwtSigningKey)
 			changed = true
 		}
@@ -269,6 +299,116 @@ pruneRootCA:
 	return newBundle, changed, nil
 }
 
+// TaintX509CA marks the root CA with the given subject key ID as tainted. It
+// fails with NotFound if there is no such root CA and FailedPrecondition if
+// it is already tainted.
+func TaintX509CA(bundle *common.Bundle, subjectKeyID []byte) error {
+	rootCA, err := findRootCA(bundle, subjectKeyID)
+	if err != nil {
+		return err
+	}
+	if rootCA.TaintedKey {
+		return status.Errorf(codes.FailedPrecondition, "root CA %x is already tainted", subjectKeyID)
+	}
+	rootCA.TaintedKey = true
+	return nil
+}
+
+// RevokeX509CA removes the root CA with the given subject key ID from the
+// bundle. Only tainted root CAs can be revoked. It fails with NotFound if
+// there is no such root CA and FailedPrecondition if it is not tainted.
+func RevokeX509CA(bundle *common.Bundle, subjectKeyID []byte) error {
+	rootCA, err := findRootCA(bundle, subjectKeyID)
+	if err != nil {
+		return err
+	}
+	if !rootCA.TaintedKey {
+		return status.Errorf(codes.FailedPrecondition, "root CA %x must be tainted before it can be revoked", subjectKeyID)
+	}
+
+	rootCAs := bundle.RootCas[:0]
+	for _, each := range bundle.RootCas {
+		if each != rootCA {
+			rootCAs = append(rootCAs, each)
+		}
+	}
+	bundle.RootCas = rootCAs
+	return nil
+}
+
+// TaintJWTKey marks the JWT signing key with the given key ID as tainted. It
+// fails with NotFound if there is no such key and FailedPrecondition if it is
+// already tainted.
+func TaintJWTKey(bundle *common.Bundle, kid string) error {
+	jwtSigningKey, err := findJWTSigningKey(bundle, kid)
+	if err != nil {
+		return err
+	}
+	if jwtSigningKey.TaintedKey {
+		return status.Errorf(codes.FailedPrecondition, "JWT signing key %q is already tainted", kid)
+	}
+	jwtSigningKey.TaintedKey = true
+	return nil
+}
+
+// RevokeJWTKey removes the JWT signing key with the given key ID from the
+// bundle. Only tainted keys can be revoked. It fails with NotFound if there is
+// no such key and FailedPrecondition if it is not tainted.
+func RevokeJWTKey(bundle *common.Bundle, kid string) error {
+	jwtSigningKey, err := findJWTSigningKey(bundle, kid)
+	if err != nil {
+		return err
+	}
+	if !jwtSigningKey.TaintedKey {
+		return status.Errorf(codes.FailedPrecondition, "JWT signing key %q must be tainted before it can be revoked", kid)
+	}
+
+	jwtSigningKeys := bundle.JwtSigningKeys[:0]
+	for _, each := range bundle.JwtSigningKeys {
+		if each != jwtSigningKey {
+			jwtSigningKeys = append(jwtSigningKeys, each)
+		}
+	}
+	bundle.JwtSigningKeys = jwtSigningKeys
+	return nil
+}
+
+func findRootCA(bundle *common.Bundle, subjectKeyID []byte) (*common.Certificate, error) {
+	if len(subjectKeyID) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "subject key ID is required")
+	}
+	for i, rootCA := range bundle.RootCas {
+		cert, err := x509.ParseCertificate(rootCA.DerBytes)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to parse root CA %d: %v", i, err)
+		}
+		if bytes.Equal(cert.SubjectKeyId, subjectKeyID) {
+			return rootCA, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no root CA with subject key ID %x", subjectKeyID)
+}
+
+func findJWTSigningKey(bundle *common.Bundle, kid string) (*common.PublicKey, error) {
+	if kid == "" {
+		return nil, status.Error(codes.InvalidArgument, "key ID is required")
+	}
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if jwtSigningKey.Kid == kid {
+			return jwtSigningKey, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no JWT signing key with key ID %q", kid)
+}
+
+func rootCAKey(rootCA *common.Certificate) string {
+	return string(rootCA.DerBytes)
+}
+
+func jwtSigningKeyKey(jwtSigningKey *common.PublicKey) string {
+	return fmt.Sprintf("%s:%x:%d", jwtSigningKey.Kid, jwtSigningKey.PkixBytes, jwtSigningKey.NotAfter)
+}
+
 func cloneBundle(b *common.Bundle) *common.Bundle {
 	return proto.Clone(b).(*common.Bundle)
 }
@@ -3,6 +3,7 @@ package bundleutil
 // Basic imports
 import (
 	"crypto/x509"
+	"math/big"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
+	"google.golang.org/grpc/codes"
 )
 
 func TestBundleUtilSuite(t *testing.T) {
@@ -112,3 +114,69 @@ func (s *BundleUtilSuite) createBundle(certs []*x509.Certificate, jwtKeys []*com
 	bundle.JwtSigningKeys = jwtKeys
 	return bundle
 }
+
+func (s *BundleUtilSuite) TestMergeBundlesIgnoresTaintedFlag() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{{Kid: "KID", PkixBytes: []byte("KEY")}},
+	)
+	bundle.RootCas[0].TaintedKey = true
+	bundle.JwtSigningKeys[0].TaintedKey = true
+
+	merged, changed := MergeBundles(bundle, s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{{Kid: "KID", PkixBytes: []byte("KEY")}},
+	))
+	s.False(changed)
+	s.Equal(bundle, merged)
+}
+
+func (s *BundleUtilSuite) TestTaintAndRevokeX509CA() {
+	skid := []byte{1, 2, 3}
+	ca := createCertificate(s.T(), &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		SubjectKeyId: skid,
+	})
+	bundle := s.createBundle([]*x509.Certificate{s.certNotExpired, ca}, nil)
+
+	// Revoking requires the root CA to be tainted first
+	s.RequireGRPCStatusContains(RevokeX509CA(bundle, skid), codes.FailedPrecondition, "must be tainted")
+	s.RequireGRPCStatusContains(TaintX509CA(bundle, []byte{4}), codes.NotFound, "no root CA")
+	s.RequireGRPCStatusContains(TaintX509CA(bundle, nil), codes.InvalidArgument, "subject key ID is required")
+
+	s.Require().NoError(TaintX509CA(bundle, skid))
+	s.False(bundle.RootCas[0].TaintedKey)
+	s.True(bundle.RootCas[1].TaintedKey)
+	s.RequireGRPCStatusContains(TaintX509CA(bundle, skid), codes.FailedPrecondition, "already tainted")
+
+	tainted, err := BundleFromProto(bundle)
+	s.Require().NoError(err)
+	s.Equal([]*x509.Certificate{ca}, tainted.TaintedRootCAs())
+
+	s.Require().NoError(RevokeX509CA(bundle, skid))
+	s.Equal(s.createBundle([]*x509.Certificate{s.certNotExpired}, nil), bundle)
+	s.RequireGRPCStatusContains(RevokeX509CA(bundle, skid), codes.NotFound, "no root CA")
+}
+
+func (s *BundleUtilSuite) TestTaintAndRevokeJWTKey() {
+	bundle := s.createBundle([]*x509.Certificate{s.certNotExpired}, []*common.PublicKey{
+		{Kid: "KID1", PkixBytes: []byte("KEY1")},
+		{Kid: "KID2", PkixBytes: []byte("KEY2")},
+	})
+
+	// Revoking requires the key to be tainted first
+	s.RequireGRPCStatusContains(RevokeJWTKey(bundle, "KID2"), codes.FailedPrecondition, "must be tainted")
+	s.RequireGRPCStatusContains(TaintJWTKey(bundle, "KID3"), codes.NotFound, "no JWT signing key")
+	s.RequireGRPCStatusContains(TaintJWTKey(bundle, ""), codes.InvalidArgument, "key ID is required")
+
+	s.Require().NoError(TaintJWTKey(bundle, "KID2"))
+	s.False(bundle.JwtSigningKeys[0].TaintedKey)
+	s.True(bundle.JwtSigningKeys[1].TaintedKey)
+	s.RequireGRPCStatusContains(TaintJWTKey(bundle, "KID2"), codes.FailedPrecondition, "already tainted")
+
+	s.Require().NoError(RevokeJWTKey(bundle, "KID2"))
+	s.Equal([]*common.PublicKey{{Kid: "KID1", PkixBytes: []byte("KEY1")}}, bundle.JwtSigningKeys)
+	s.RequireGRPCStatusContains(RevokeJWTKey(bundle, "KID2"), codes.NotFound, "no JWT signing key")
+}
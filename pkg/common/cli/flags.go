@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,3 +46,27 @@ func (s *StringsFlag) Set(val string) error {
 	*s = append(*s, val)
 	return nil
 }
+
+// OptionalBoolFlag facilitates parsing boolean flags that are unset unless
+// explicitly provided (e.g. -banned true)
+type OptionalBoolFlag struct {
+	Value bool
+	IsSet bool
+}
+
+func (f OptionalBoolFlag) String() string {
+	if !f.IsSet {
+		return ""
+	}
+	return strconv.FormatBool(f.Value)
+}
+
+func (f *OptionalBoolFlag) Set(v string) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	f.Value = b
+	f.IsSet = true
+	return nil
+}
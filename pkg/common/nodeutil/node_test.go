@@ -0,0 +1,15 @@
+package nodeutil
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAgentBanned(t *testing.T) {
+	require.True(t, IsAgentBanned(&common.AttestedNode{}))
+	require.False(t, IsAgentBanned(&common.AttestedNode{CertSerialNumber: "1"}))
+	require.False(t, IsAgentBanned(&common.AttestedNode{NewCertSerialNumber: "1"}))
+	require.False(t, IsAgentBanned(&common.AttestedNode{CertSerialNumber: "1", NewCertSerialNumber: "1"}))
+}
@@ -1,10 +1,13 @@
 package rotationutil
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/x509"
 	"time"
 
 	"github.com/spiffe/spire/pkg/agent/client"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 // ShouldRotateX509 determines if a given SVID should be rotated, based
@@ -31,6 +34,37 @@ func JWTSVIDExpired(svid *client.JWTSVID, now time.Time) bool {
 	return !now.Before(svid.ExpiresAt)
 }
 
+// X509IssuedByTaintedAuthority determines if any certificate in the given
+// SVID chain was issued by one of the tainted authorities, in which case the
+// SVID should be rotated.
+func X509IssuedByTaintedAuthority(chain []*x509.Certificate, taintedAuthorities []*x509.Certificate) bool {
+	for _, cert := range chain {
+		if len(cert.AuthorityKeyId) == 0 {
+			continue
+		}
+		for _, authority := range taintedAuthorities {
+			if bytes.Equal(cert.AuthorityKeyId, authority.SubjectKeyId) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JWTSVIDSignedByTaintedKey determines if the given JWT SVID was signed by
+// one of the tainted keys, in which case the SVID should be renewed.
+func JWTSVIDSignedByTaintedKey(svid *client.JWTSVID, taintedKeys map[string]crypto.PublicKey) bool {
+	if len(taintedKeys) == 0 {
+		return false
+	}
+	token, err := jwt.ParseSigned(svid.Token)
+	if err != nil || len(token.Headers) == 0 {
+		return false
+	}
+	_, tainted := taintedKeys[token.Headers[0].KeyID]
+	return tainted
+}
+
 func shouldRotate(now, beginTime, expiryTime time.Time) bool {
 	ttl := expiryTime.Sub(now)
 	lifetime := expiryTime.Sub(beginTime)
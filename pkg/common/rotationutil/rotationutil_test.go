@@ -1,6 +1,11 @@
 package rotationutil
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"testing"
 	"time"
 
@@ -9,6 +14,8 @@ import (
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 func TestShouldRotateX509(t *testing.T) {
@@ -58,3 +65,52 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 
 	assert.True(t, JWTSVIDExpiresSoon(expiredJWT, mockClk.Now()))
 }
+
+func TestX509IssuedByTaintedAuthority(t *testing.T) {
+	mockClk := clock.NewMock(t)
+
+	newCA := func(subjectKeyID []byte) (*x509.Certificate, crypto.Signer) {
+		temp, err := util.NewCATemplate(mockClk, "example.org")
+		require.NoError(t, err)
+		temp.SubjectKeyId = subjectKeyID
+		ca, key, err := util.SelfSign(temp)
+		require.NoError(t, err)
+		return ca, key
+	}
+	tainted, taintedKey := newCA([]byte("tainted"))
+	untainted, untaintedKey := newCA([]byte("untainted"))
+
+	temp, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	issuedByTainted, _, err := util.Sign(temp, tainted, taintedKey)
+	require.NoError(t, err)
+
+	temp, err = util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	issuedByUntainted, _, err := util.Sign(temp, untainted, untaintedKey)
+	require.NoError(t, err)
+
+	taintedAuthorities := []*x509.Certificate{tainted}
+	assert.True(t, X509IssuedByTaintedAuthority([]*x509.Certificate{issuedByTainted}, taintedAuthorities))
+	assert.False(t, X509IssuedByTaintedAuthority([]*x509.Certificate{issuedByUntainted}, taintedAuthorities))
+	assert.False(t, X509IssuedByTaintedAuthority([]*x509.Certificate{issuedByTainted}, nil))
+}
+
+func TestJWTSVIDSignedByTaintedKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.ES256,
+		Key:       key,
+	}, new(jose.SignerOptions).WithHeader("kid", "tainted"))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "spiffe://example.org/test"}).CompactSerialize()
+	require.NoError(t, err)
+	svid := &client.JWTSVID{Token: token}
+
+	assert.True(t, JWTSVIDSignedByTaintedKey(svid, map[string]crypto.PublicKey{"tainted": key.Public()}))
+	assert.False(t, JWTSVIDSignedByTaintedKey(svid, map[string]crypto.PublicKey{"other": key.Public()}))
+	assert.False(t, JWTSVIDSignedByTaintedKey(svid, nil))
+	assert.False(t, JWTSVIDSignedByTaintedKey(&client.JWTSVID{Token: "malformed"}, map[string]crypto.PublicKey{"tainted": key.Public()}))
+}
@@ -37,4 +37,10 @@ func AddCacheManagerOutdatedSVIDsSample(m telemetry.Metrics, count float32) {
 	m.AddSample([]string{telemetry.CacheManager, telemetry.OutdatedSVIDs}, count)
 }
 
+// AddCacheManagerTaintedSVIDsSample count of SVIDs issued by a tainted
+// authority according to agent cache manager
+func AddCacheManagerTaintedSVIDsSample(m telemetry.Metrics, count float32) {
+	m.AddSample([]string{telemetry.CacheManager, telemetry.TaintedSVIDs}, count)
+}
+
 // End Add Samples
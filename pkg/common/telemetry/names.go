@@ -55,6 +55,14 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// Reload functionality related to reloading some entity from its source
+	// of truth; should be used with other tags to add clarity
+	Reload = "reload"
+
+	// Revoke functionality related to revoking some entity, such as a tainted
+	// authority; should be used with other tags to add clarity
+	Revoke = "revoke"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -67,6 +75,10 @@ const (
 	// to add clarity
 	Sign = "sign"
 
+	// Taint functionality related to tainting some entity, such as a
+	// compromised authority; should be used with other tags to add clarity
+	Taint = "taint"
+
 	// Sync functionality for syncing (such as CA manager updates). Should
 	// be used with other tags to add clarity
 	Sync = "sync"
@@ -94,6 +106,13 @@ const (
 	// Audience tags some audience for a token
 	Audience = "audience"
 
+	// AuthorityID tags the ID of a local X509 CA or JWT key
+	AuthorityID = "authority_id"
+
+	// CallerClass tags the class of an API caller, i.e. agent, downstream or
+	// IP address, as seen by a rate limiter
+	CallerClass = "caller_class"
+
 	// CallerID tags an API caller; should be used with other tags
 	// to add clarity
 	CallerID = "caller_id"
@@ -135,6 +154,9 @@ const (
 	// non-error level.
 	Error = "error"
 
+	// Evicted flagging something has been evicted
+	Evicted = "evicted"
+
 	// Expect tags an expected value, as opposed to the one received. Message should clarify
 	// what kind of value was expected, and a different field should show the received value
 	Expect = "expect"
@@ -171,6 +193,9 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// Limiter tags the name of a rate limiter
+	Limiter = "limiter"
+
 	// NodeAttestorType declares the type of node attestation.
 	NodeAttestorType = "node_attestor_type"
 
@@ -256,6 +281,10 @@ const (
 	// SerialNumber tags a certificate serial number
 	SerialNumber = "serial_num"
 
+	// ServerID tags the ID of a server sharing the CA journal with other
+	// servers
+	ServerID = "server_id"
+
 	// SDSPID tags an SDS PID
 	SDSPID = "sds_pid"
 
@@ -265,6 +294,10 @@ const (
 	// SPIFFEID tags a SPIFFE ID
 	SPIFFEID = "spiffe_id"
 
+	// Staleness tags how long ago some cached data was last known to be up
+	// to date, in seconds
+	Staleness = "staleness"
+
 	// Status tags status of call (OK, or some error), or status of some process
 	Status = "status"
 
@@ -281,6 +314,9 @@ const (
 	// SVIDUpdated tags that for some entity the SVID was updated
 	SVIDUpdated = "svid_updated"
 
+	// Throttled flagging something has been throttled by a rate limiter
+	Throttled = "throttled"
+
 	// TTL functionality related to a time-to-live field; should be used
 	// with other tags to add clarity
 	TTL = "ttl"
@@ -335,6 +371,10 @@ const (
 	// to add clarity
 	CA = "ca"
 
+	// CAJournal functionality related to the journal of a CA manager;
+	// should be used with other tags to add clarity
+	CAJournal = "ca_journal"
+
 	// CAManager functionality related to a CA manager
 	CAManager = "ca_manager"
 
@@ -344,6 +384,10 @@ const (
 	// Catalog functionality related to plugin catalog
 	Catalog = "catalog"
 
+	// ChangeEvent functionality related to a recorded datastore change event;
+	// should be used with other tags to add clarity
+	ChangeEvent = "change_event"
+
 	// Datastore functionality related to datastore plugin
 	Datastore = "datastore"
 
@@ -354,6 +398,10 @@ const (
 	// to add clarity
 	Entry = "entry"
 
+	// EntryCache functionality related to the server in-memory cache of
+	// registration entries and node selectors
+	EntryCache = "entry_cache"
+
 	// Event tag some event that has occurred, for a notifier, watcher, listener, etc.
 	Event = "event"
 
@@ -363,10 +411,17 @@ const (
 	// OutdatedSVIDs tags SVID with outdated attributes count/list
 	OutdatedSVIDs = "outdated_svids"
 
+	// TaintedSVIDs tags SVIDs issued by a tainted authority count/list
+	TaintedSVIDs = "tainted_svids"
+
 	// FederatedBundle functionality related to a federated bundle; should be used
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// FederationRelationship functionality related to a federation relationship;
+	// should be used with other tags to add clarity
+	FederationRelationship = "federation_relationship"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -400,6 +455,10 @@ const (
 	// to add clarity
 	Notifier = "notifier"
 
+	// RateLimit functionality related to rate limiting; should be used with
+	// other tags to add clarity
+	RateLimit = "rate_limit"
+
 	// ServerCA functionality related to a server CA; should be used with other tags
 	// to add clarity
 	ServerCA = "server_ca"
@@ -43,12 +43,36 @@ func StartPruneBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.Prune)
 }
 
+// StartRevokeJWTKeyCall return metric
+// for server's datastore, on revoking a JWT key in a bundle.
+func StartRevokeJWTKeyCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.JWTKey, telemetry.Revoke)
+}
+
+// StartRevokeX509CACall return metric
+// for server's datastore, on revoking an X.509 CA in a bundle.
+func StartRevokeX509CACall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.X509CA, telemetry.Revoke)
+}
+
 // StartSetBundleCall return metric
 // for server's datastore, on sets the bundle.
 func StartSetBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.Set)
 }
 
+// StartTaintJWTKeyCall return metric
+// for server's datastore, on tainting a JWT key in a bundle.
+func StartTaintJWTKeyCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.JWTKey, telemetry.Taint)
+}
+
+// StartTaintX509CACall return metric
+// for server's datastore, on tainting an X.509 CA in a bundle.
+func StartTaintX509CACall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.X509CA, telemetry.Taint)
+}
+
 // StartUpdateBundleCall return metric
 // for server's datastore, on updating a bundle.
 func StartUpdateBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
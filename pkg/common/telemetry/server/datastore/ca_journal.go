@@ -0,0 +1,22 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartFetchCAJournalCall return metric
+// for server's datastore, on fetching a CA journal.
+func StartFetchCAJournalCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.CAJournal, telemetry.Fetch)
+}
+
+// StartSetCAJournalCall return metric
+// for server's datastore, on setting a CA journal.
+func StartSetCAJournalCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.CAJournal, telemetry.Set)
+}
+
+// End Call Counters
@@ -0,0 +1,22 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartListChangeEventsCall return metric
+// for server's datastore, on listing change events.
+func StartListChangeEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.ChangeEvent, telemetry.List)
+}
+
+// StartPruneChangeEventsCall return metric
+// for server's datastore, on pruning change events.
+func StartPruneChangeEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.ChangeEvent, telemetry.Prune)
+}
+
+// End Call Counters
@@ -37,6 +37,12 @@ func StartGetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Selectors, telemetry.Fetch)
 }
 
+// StartListNodeSelectorsCall return metric
+// for server's datastore, on listing selectors for all nodes.
+func StartListNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Selectors, telemetry.List)
+}
+
 // StartSetNodeSelectorsCall return metric
 // for server's datastore, on setting selectors for a node.
 func StartSetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
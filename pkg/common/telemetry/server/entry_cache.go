@@ -0,0 +1,47 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartEntryCacheReloadCall return metric for
+// the server entry cache fully reloading from the datastore
+func StartEntryCacheReloadCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.EntryCache, telemetry.Reload)
+}
+
+// StartEntryCacheSyncCall return metric for
+// the server entry cache applying the change events recorded in the datastore
+func StartEntryCacheSyncCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.EntryCache, telemetry.Sync)
+}
+
+// End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrEntryCacheEventCounter indicates that change events were applied
+// to the server entry cache. Takes the number of applied events.
+func IncrEntryCacheEventCounter(m telemetry.Metrics, count int) {
+	m.IncrCounter([]string{telemetry.EntryCache, telemetry.Event}, float32(count))
+}
+
+// End Counters
+
+// Gauge (remember previous value set)
+
+// SetEntryCacheStalenessGauge sets the number of seconds since the server
+// entry cache was last known to be up to date with the datastore
+func SetEntryCacheStalenessGauge(m telemetry.Metrics, seconds float64) {
+	m.SetGauge([]string{telemetry.EntryCache, telemetry.Staleness}, float32(seconds))
+}
+
+// SetEntryCacheSizeGauges sets the number of registration entries and nodes
+// with selectors held by the server entry cache
+func SetEntryCacheSizeGauges(m telemetry.Metrics, entries, nodes int) {
+	m.SetGauge([]string{telemetry.EntryCache, telemetry.Entry, telemetry.Count}, float32(entries))
+	m.SetGauge([]string{telemetry.EntryCache, telemetry.Node, telemetry.Count}, float32(nodes))
+}
+
+// End Gauge
@@ -0,0 +1,25 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Counters (literal increments, not call counters)
+
+// IncrRateLimitThrottledCounter indicates that a call was throttled
+// by a rate limiter. Takes the limiter name and the caller class.
+func IncrRateLimitThrottledCounter(m telemetry.Metrics, limiter, callerClass string) {
+	m.IncrCounterWithLabels([]string{telemetry.RateLimit, telemetry.Throttled}, 1, []telemetry.Label{
+		{Name: telemetry.Limiter, Value: limiter},
+		{Name: telemetry.CallerClass, Value: callerClass},
+	})
+}
+
+// IncrRateLimitEvictedCounter indicates that stale per-caller limiters
+// were evicted from a rate limiter. Takes the limiter name and the number
+// of evicted limiters.
+func IncrRateLimitEvictedCounter(m telemetry.Metrics, limiter string, count int) {
+	m.IncrCounterWithLabels([]string{telemetry.RateLimit, telemetry.Evicted}, float32(count), []telemetry.Label{
+		{Name: telemetry.Limiter, Value: limiter},
+	})
+}
+
+// End Counters
@@ -11,4 +11,32 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneChangeEventsCall returns metric for
+// for server registration manager change event pruning
+func StartRegistrationManagerPruneChangeEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.ChangeEvent, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneAgentsCall returns metric for
+// for server registration manager expired agent pruning
+func StartRegistrationManagerPruneAgentsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneJoinTokensCall returns metric for
+// for server registration manager join token pruning
+func StartRegistrationManagerPruneJoinTokensCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.JoinToken, telemetry.Manager, telemetry.Prune)
+}
+
 // End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrRegistrationManagerPrunedAgentCounter indicate registration
+// manager having pruned an expired agent
+func IncrRegistrationManagerPrunedAgentCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.Node, telemetry.Manager, telemetry.Pruned}, 1)
+}
+
+// End Counters
@@ -20,8 +20,7 @@ func MakeCSR(privateKey interface{}, spiffeID string) ([]byte, error) {
 			Country:      []string{"US"},
 			Organization: []string{"SPIRE"},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{uri},
+		URIs: []*url.URL{uri},
 	})
 }
 
@@ -31,10 +30,11 @@ func MakeCSRWithoutURISAN(privateKey interface{}) ([]byte, error) {
 			Country:      []string{"US"},
 			Organization: []string{"SPIRE"},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
 	})
 }
 
+// makeCSR creates a CSR signed by the private key. The signature algorithm is
+// left for the x509 package to pick, so any supported key type can be used.
 func makeCSR(privateKey interface{}, template *x509.CertificateRequest) ([]byte, error) {
 	csr, err := x509.CreateCertificateRequest(rand.Reader, template, privateKey)
 	if err != nil {
@@ -0,0 +1,83 @@
+package x509util
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+var extKeyUsages = map[string]x509.ExtKeyUsage{
+	"serverAuth": x509.ExtKeyUsageServerAuth,
+	"clientAuth": x509.ExtKeyUsageClientAuth,
+}
+
+// SVIDProfile holds the X509-SVID customizations of a registration entry
+type SVIDProfile struct {
+	// Subject of the X509-SVID. The default subject is used if it is empty.
+	Subject pkix.Name
+
+	// IPAddresses are added as IP SANs
+	IPAddresses []net.IP
+
+	// URIs are added as URI SANs, after the SPIFFE ID
+	URIs []*url.URL
+
+	// ExtKeyUsage restricts the extended key usages of the X509-SVID. Server
+	// and client authentication are used if it is empty.
+	ExtKeyUsage []x509.ExtKeyUsage
+}
+
+// ParseSVIDProfile validates and parses the X509-SVID profile of a
+// registration entry. A nil profile results in an empty SVIDProfile.
+func ParseSVIDProfile(profile *common.X509SVIDProfile) (*SVIDProfile, error) {
+	p := new(SVIDProfile)
+	if profile == nil {
+		return p, nil
+	}
+
+	if subject := profile.Subject; subject != nil {
+		p.Subject = pkix.Name{
+			Country:            subject.Country,
+			Organization:       subject.Organization,
+			OrganizationalUnit: subject.OrganizationalUnit,
+			CommonName:         subject.CommonName,
+		}
+	}
+
+	for _, s := range profile.IpAddresses {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		p.IPAddresses = append(p.IPAddresses, ip)
+	}
+
+	for _, s := range profile.Uris {
+		u, err := url.Parse(s)
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("invalid URI %q: %v", s, err)
+		case u.Scheme == "":
+			return nil, fmt.Errorf("invalid URI %q: scheme is missing", s)
+		case strings.EqualFold(u.Scheme, "spiffe"):
+			// the SPIFFE ID of the entry is the only SPIFFE ID of an SVID
+			return nil, fmt.Errorf("invalid URI %q: SPIFFE IDs are not allowed", s)
+		}
+		p.URIs = append(p.URIs, u)
+	}
+
+	for _, s := range profile.ExtKeyUsages {
+		extKeyUsage, ok := extKeyUsages[s]
+		if !ok {
+			return nil, fmt.Errorf("unsupported extended key usage %q; expected \"serverAuth\" or \"clientAuth\"", s)
+		}
+		p.ExtKeyUsage = append(p.ExtKeyUsage, extKeyUsage)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,97 @@
+package x509util_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSVIDProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  *common.X509SVIDProfile
+		expected *x509util.SVIDProfile
+		err      string
+	}{
+		{
+			name:     "nil profile",
+			expected: &x509util.SVIDProfile{},
+		},
+		{
+			name: "full profile",
+			profile: &common.X509SVIDProfile{
+				Subject: &common.X509Subject{
+					Country:            []string{"US"},
+					Organization:       []string{"Acme"},
+					OrganizationalUnit: []string{"Legacy"},
+					CommonName:         "billing",
+				},
+				IpAddresses:  []string{"10.0.0.1", "2001:db8::1"},
+				Uris:         []string{"urn:acme:billing", "https://billing.example.org"},
+				ExtKeyUsages: []string{"clientAuth"},
+			},
+			expected: &x509util.SVIDProfile{
+				Subject: pkix.Name{
+					Country:            []string{"US"},
+					Organization:       []string{"Acme"},
+					OrganizationalUnit: []string{"Legacy"},
+					CommonName:         "billing",
+				},
+				IPAddresses: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("2001:db8::1")},
+				URIs: []*url.URL{
+					{Scheme: "urn", Opaque: "acme:billing"},
+					{Scheme: "https", Host: "billing.example.org"},
+				},
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			},
+		},
+		{
+			name: "invalid IP address",
+			profile: &common.X509SVIDProfile{
+				IpAddresses: []string{"10.0.0"},
+			},
+			err: `invalid IP address "10.0.0"`,
+		},
+		{
+			name: "URI without scheme",
+			profile: &common.X509SVIDProfile{
+				Uris: []string{"billing.example.org"},
+			},
+			err: `invalid URI "billing.example.org": scheme is missing`,
+		},
+		{
+			name: "SPIFFE ID URI",
+			profile: &common.X509SVIDProfile{
+				Uris: []string{"spiffe://example.org/other"},
+			},
+			err: `invalid URI "spiffe://example.org/other": SPIFFE IDs are not allowed`,
+		},
+		{
+			name: "unsupported extended key usage",
+			profile: &common.X509SVIDProfile{
+				ExtKeyUsages: []string{"codeSigning"},
+			},
+			err: `unsupported extended key usage "codeSigning"; expected "serverAuth" or "clientAuth"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := x509util.ParseSVIDProfile(tt.profile)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, profile)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, profile)
+		})
+	}
+}
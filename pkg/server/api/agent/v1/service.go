@@ -0,0 +1,619 @@
+package agent
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/nodeattestutil"
+	"github.com/spiffe/spire/proto/spire-next/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterService registers the agent service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	agent.RegisterAgentServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	Catalog     catalog.Catalog
+	Clock       clock.Clock
+	Datastore   datastore.DataStore
+	ServerCA    ca.ServerCA
+	TrustDomain spiffeid.TrustDomain
+}
+
+// New creates a new agent service
+func New(config Config) *Service {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	return &Service{
+		clk: config.Clock,
+		ds:  config.Datastore,
+		ca:  config.ServerCA,
+		td:  config.TrustDomain,
+		attestor: nodeattestutil.New(nodeattestutil.Config{
+			Catalog:     config.Catalog,
+			TrustDomain: config.TrustDomain,
+			Clock:       config.Clock,
+		}),
+	}
+}
+
+// Service implements the v1 agent service
+type Service struct {
+	clk      clock.Clock
+	ds       datastore.DataStore
+	ca       ca.ServerCA
+	td       spiffeid.TrustDomain
+	attestor *nodeattestutil.Attestor
+}
+
+func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest) (*agent.ListAgentsResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListAttestedNodesRequest{}
+
+	// Set pagination parameters
+	if req.PageSize > 0 {
+		listReq.Pagination = &datastore.Pagination{
+			PageSize: req.PageSize,
+			Token:    req.PageToken,
+		}
+	}
+
+	filter := req.Filter
+	if filter == nil {
+		filter = &agent.ListAgentsRequest_Filter{}
+	}
+
+	if filter.ByBanned {
+		listReq.ByBanned = &wrappers.BoolValue{Value: true}
+	}
+
+	if filter.ByAttestationType != "" {
+		listReq.ByAttestationType = filter.ByAttestationType
+	}
+
+	if filter.BySelectorMatch != nil {
+		bySelectors, err := api.BySelectorsFromProto(filter.BySelectorMatch)
+		if err != nil {
+			log.WithError(err).Error("Invalid request: malformed selectors filter")
+			return nil, status.Errorf(codes.InvalidArgument, "malformed selectors filter: %v", err)
+		}
+		listReq.BySelectorMatch = bySelectors
+	}
+
+	// Selectors are only loaded when they are part of the output
+	listReq.FetchSelectors = req.OutputMask == nil || req.OutputMask.Selectors
+
+	dsResp, err := s.ds.ListAttestedNodes(ctx, listReq)
+	if err != nil {
+		log.WithError(err).Error("Failed to list agents")
+		return nil, status.Errorf(codes.Internal, "failed to list agents: %v", err)
+	}
+
+	resp := &agent.ListAgentsResponse{}
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+
+	for _, node := range dsResp.Nodes {
+		// Agents outside of the caller scope are left out of the page
+		if !api.IDStringInCallerScope(ctx, node.SpiffeId) {
+			continue
+		}
+		a, err := nodeToProto(node)
+		if err != nil {
+			log.WithError(err).WithField(telemetry.SPIFFEID, node.SpiffeId).Error("Failed to convert agent")
+			return nil, status.Errorf(codes.Internal, "failed to convert agent %q: %v", node.SpiffeId, err)
+		}
+		applyMask(a, req.OutputMask)
+		resp.Agents = append(resp.Agents, a)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) GetAgent(ctx context.Context, req *agent.GetAgentRequest) (*types.Agent, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := s.agentIDFromProto(req.Id)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: invalid agent ID")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID: %v", err)
+	}
+	log = log.WithField(telemetry.AgentID, agentID.String())
+
+	if !api.IDInCallerScope(ctx, agentID) {
+		log.Error("Permission denied: agent is outside of the caller scope")
+		return nil, status.Error(codes.PermissionDenied, "agent is outside of the caller scope")
+	}
+
+	node, err := s.fetchAttestedNode(ctx, agentID)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch agent")
+		return nil, status.Errorf(codes.Internal, "failed to fetch agent: %v", err)
+	}
+	if node == nil {
+		log.Error("Agent not found")
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	if req.OutputMask == nil || req.OutputMask.Selectors {
+		node.Selectors, err = s.getNodeSelectors(ctx, node.SpiffeId)
+		if err != nil {
+			log.WithError(err).Error("Failed to get node selectors")
+			return nil, status.Errorf(codes.Internal, "failed to get node selectors: %v", err)
+		}
+	}
+
+	a, err := nodeToProto(node)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert agent")
+		return nil, status.Errorf(codes.Internal, "failed to convert agent: %v", err)
+	}
+	applyMask(a, req.OutputMask)
+
+	return a, nil
+}
+
+func (s *Service) DeleteAgent(ctx context.Context, req *agent.DeleteAgentRequest) (*empty.Empty, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := s.agentIDFromProto(req.Id)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: invalid agent ID")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID: %v", err)
+	}
+	log = log.WithField(telemetry.AgentID, agentID.String())
+
+	if !api.IDInCallerScope(ctx, agentID) {
+		log.Error("Permission denied: agent is outside of the caller scope")
+		return nil, status.Error(codes.PermissionDenied, "agent is outside of the caller scope")
+	}
+
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+		SpiffeId: agentID.String(),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Agent deleted")
+		return &empty.Empty{}, nil
+	case codes.NotFound:
+		log.WithError(err).Error("Agent not found")
+		return nil, status.Error(codes.NotFound, "agent not found")
+	default:
+		log.WithError(err).Error("Failed to delete agent")
+		return nil, status.Errorf(codes.Internal, "failed to delete agent: %v", err)
+	}
+}
+
+func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*empty.Empty, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := s.agentIDFromProto(req.Id)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: invalid agent ID")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID: %v", err)
+	}
+	log = log.WithField(telemetry.AgentID, agentID.String())
+
+	if !api.IDInCallerScope(ctx, agentID) {
+		log.Error("Permission denied: agent is outside of the caller scope")
+		return nil, status.Error(codes.PermissionDenied, "agent is outside of the caller scope")
+	}
+
+	// The agent is banned by clearing its serial numbers. The attested node
+	// is kept around so the ban persists until the agent is deleted.
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId: agentID.String(),
+		InputMask: &datastore.AttestedNodeMask{
+			CertSerialNumber:    true,
+			NewCertSerialNumber: true,
+		},
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Agent banned")
+		return &empty.Empty{}, nil
+	case codes.NotFound:
+		log.WithError(err).Error("Agent not found")
+		return nil, status.Error(codes.NotFound, "agent not found")
+	default:
+		log.WithError(err).Error("Failed to ban agent")
+		return nil, status.Errorf(codes.Internal, "failed to ban agent: %v", err)
+	}
+}
+
+func (s *Service) AttestAgent(stream agent.Agent_AttestAgentServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		log.WithError(err).Error("Rejecting request due to node attestation rate limiting")
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		log.WithError(err).Error("Failed to receive request from stream")
+		return status.Errorf(codes.InvalidArgument, "failed to receive request from stream: %v", err)
+	}
+
+	params := req.GetParams()
+	switch {
+	case params == nil:
+		log.Error("Invalid request: missing params")
+		return status.Error(codes.InvalidArgument, "missing params")
+	case params.Data == nil:
+		log.Error("Invalid request: missing attestation data")
+		return status.Error(codes.InvalidArgument, "missing attestation data")
+	case params.Data.Type == "":
+		log.Error("Invalid request: missing attestation data type")
+		return status.Error(codes.InvalidArgument, "missing attestation data type")
+	case params.Params == nil:
+		log.Error("Invalid request: missing X509-SVID params")
+		return status.Error(codes.InvalidArgument, "missing X509-SVID params")
+	case len(params.Params.Csr) == 0:
+		log.Error("Invalid request: missing CSR")
+		return status.Error(codes.InvalidArgument, "missing CSR")
+	}
+
+	attestorType := params.Data.Type
+	log = log.WithField(telemetry.NodeAttestorType, attestorType)
+
+	attestResp, err := s.attestor.Attest(ctx, log, nodeattestutil.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: attestorType,
+			Data: []byte(params.Data.Payload),
+		},
+		Challenge: func(challenge []byte) ([]byte, error) {
+			if err := stream.Send(&agent.AttestAgentResponse{
+				Step: &agent.AttestAgentResponse_Challenge{
+					Challenge: challenge,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to send challenge to agent: %v", err)
+			}
+
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to receive challenge response from agent: %v", err)
+			}
+
+			challengeResponse := req.GetChallengeResponse()
+			if len(challengeResponse) == 0 {
+				return nil, status.Error(codes.InvalidArgument, "missing challenge response")
+			}
+			return challengeResponse, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	agentID, err := spiffeid.FromString(attestResp.AgentId)
+	if err != nil {
+		log.WithError(err).Error("Attestor returned invalid agent ID")
+		return status.Errorf(codes.Internal, "attestor returned invalid agent ID: %v", err)
+	}
+	log = log.WithField(telemetry.AgentID, agentID.String())
+
+	svid, err := s.signSVID(ctx, agentID, params.Params.Csr)
+	if err != nil {
+		log.WithError(err).Error("Failed to sign X509-SVID")
+		return err
+	}
+
+	if err := s.attestor.UpdateNode(ctx, log, attestorType, attestResp, svid[0], nodeattestutil.AgentInfo{}); err != nil {
+		return err
+	}
+
+	if err := stream.Send(&agent.AttestAgentResponse{
+		Step: &agent.AttestAgentResponse_Result_{
+			Result: &agent.AttestAgentResponse_Result{
+				Svid: makeX509SVID(agentID, svid),
+			},
+		},
+	}); err != nil {
+		log.WithError(err).Error("Failed to send response over stream")
+		return status.Errorf(codes.Internal, "failed to send response over stream: %v", err)
+	}
+
+	if addr := rpccontext.CallerAddr(ctx); addr != nil {
+		log = log.WithField(telemetry.Address, addr.String())
+	}
+	log.Info("Agent attestation request completed")
+
+	return nil
+}
+
+func (s *Service) RenewAgent(stream agent.Agent_RenewAgentServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		log.WithError(err).Error("Rejecting request due to renew agent rate limiting")
+		return err
+	}
+
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		log.Error("Caller ID missing from request context")
+		return status.Error(codes.Internal, "caller ID missing from request context")
+	}
+	log = log.WithField(telemetry.AgentID, callerID.String())
+
+	req, err := stream.Recv()
+	if err != nil {
+		log.WithError(err).Error("Failed to receive request from stream")
+		return status.Errorf(codes.InvalidArgument, "failed to receive request from stream: %v", err)
+	}
+
+	params := req.GetParams()
+	switch {
+	case params == nil:
+		log.Error("Invalid request: missing params")
+		return status.Error(codes.InvalidArgument, "missing params")
+	case len(params.Csr) == 0:
+		log.Error("Invalid request: missing CSR")
+		return status.Error(codes.InvalidArgument, "missing CSR")
+	}
+
+	svid, err := s.signSVID(ctx, callerID, params.Csr)
+	if err != nil {
+		log.WithError(err).Error("Failed to sign X509-SVID")
+		return err
+	}
+
+	if err := stream.Send(&agent.RenewAgentResponse{
+		Svid: makeX509SVID(callerID, svid),
+	}); err != nil {
+		log.WithError(err).Error("Failed to send response over stream")
+		return status.Errorf(codes.Internal, "failed to send response over stream: %v", err)
+	}
+
+	// The new X509-SVID is only recorded once the agent acknowledges that it
+	// has received it. Until then the agent keeps using its current SVID.
+	req, err = stream.Recv()
+	if err != nil {
+		log.WithError(err).Error("Failed to receive acknowledgement from stream")
+		return status.Errorf(codes.InvalidArgument, "failed to receive acknowledgement from stream: %v", err)
+	}
+	if req.GetAck() == nil {
+		log.Error("Invalid request: expected acknowledgement")
+		return status.Error(codes.InvalidArgument, "expected acknowledgement")
+	}
+
+	// The new serial number becomes the active one the first time the agent
+	// presents the new X509-SVID.
+	if _, err := s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:            callerID.String(),
+		NewCertNotAfter:     svid[0].NotAfter.Unix(),
+		NewCertSerialNumber: svid[0].SerialNumber.String(),
+		InputMask: &datastore.AttestedNodeMask{
+			NewCertSerialNumber: true,
+			NewCertNotAfter:     true,
+		},
+	}); err != nil {
+		log.WithError(err).Error("Failed to update agent")
+		return status.Errorf(codes.Internal, "failed to update agent: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateJoinToken(ctx context.Context, req *agent.CreateJoinTokenRequest) (*types.JoinToken, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.Ttl < 1 {
+		log.Error("Invalid request: ttl is required")
+		return nil, status.Error(codes.InvalidArgument, "ttl is required, you must provide one")
+	}
+
+	var agentID spiffeid.ID
+	if req.AgentId != nil {
+		var err error
+		agentID, err = s.agentIDFromProto(req.AgentId)
+		if err != nil {
+			log.WithError(err).Error("Invalid request: invalid agent ID")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID: %v", err)
+		}
+		log = log.WithField(telemetry.AgentID, agentID.String())
+	}
+
+	// Generate a token if one wasn't specified
+	token := req.Token
+	if token == "" {
+		u, err := uuid.NewV4()
+		if err != nil {
+			log.WithError(err).Error("Failed to generate token UUID")
+			return nil, status.Errorf(codes.Internal, "failed to generate token UUID: %v", err)
+		}
+		token = u.String()
+	}
+
+	expiry := s.clk.Now().Add(time.Duration(req.Ttl) * time.Second).Unix()
+
+	if _, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  token,
+			Expiry: expiry,
+		},
+	}); err != nil {
+		log.WithError(err).Error("Failed to create token")
+		return nil, status.Errorf(codes.Internal, "failed to create token: %v", err)
+	}
+
+	if req.AgentId != nil {
+		if err := s.createJoinTokenRegistrationEntry(ctx, token, agentID); err != nil {
+			log.WithError(err).Error("Failed to create join token registration entry")
+			return nil, status.Errorf(codes.Internal, "failed to create join token registration entry: %v", err)
+		}
+	}
+
+	return &types.JoinToken{
+		Value:     token,
+		ExpiresAt: expiry,
+	}, nil
+}
+
+// agentIDFromProto converts the given SPIFFE ID and makes sure it belongs
+// to the server trust domain.
+func (s *Service) agentIDFromProto(protoID *types.SPIFFEID) (spiffeid.ID, error) {
+	id, err := api.IDFromProto(protoID)
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+	if !id.MemberOf(s.td) {
+		return spiffeid.ID{}, fmt.Errorf("%q is not a member of trust domain %q", id, s.td)
+	}
+	return id, nil
+}
+
+func (s *Service) fetchAttestedNode(ctx context.Context, agentID spiffeid.ID) (*common.AttestedNode, error) {
+	resp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Node, nil
+}
+
+func (s *Service) getNodeSelectors(ctx context.Context, spiffeID string) ([]*common.Selector, error) {
+	resp, err := s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: spiffeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Selectors == nil {
+		return nil, nil
+	}
+	return resp.Selectors.Selectors, nil
+}
+
+// signSVID signs an agent X509-SVID for the public key in the CSR. Other
+// attributes in the CSR are ignored.
+func (s *Service) signSVID(ctx context.Context, agentID spiffeid.ID, csrBytes []byte) ([]*x509.Certificate, error) {
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "malformed CSR: %v", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid CSR signature")
+	}
+
+	svid, err := s.ca.SignX509SVID(ctx, ca.X509SVIDParams{
+		SpiffeID:  agentID.String(),
+		PublicKey: csr.PublicKey,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign X509-SVID: %v", err)
+	}
+
+	return svid, nil
+}
+
+// createJoinTokenRegistrationEntry maps the agent ID assigned by join token
+// attestation to the requested agent ID.
+func (s *Service) createJoinTokenRegistrationEntry(ctx context.Context, token string, agentID spiffeid.ID) error {
+	parentID := s.joinTokenAgentID(token)
+	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId: parentID.String(),
+			SpiffeId: agentID.String(),
+			Selectors: []*common.Selector{
+				{Type: "spiffe_id", Value: parentID.String()},
+			},
+		},
+	})
+	return err
+}
+
+func (s *Service) joinTokenAgentID(token string) spiffeid.ID {
+	return s.td.NewID(path.Join("spire", "agent", nodeattestutil.JoinTokenType, token))
+}
+
+func nodeToProto(node *common.AttestedNode) (*types.Agent, error) {
+	if node == nil {
+		return nil, errors.New("missing node")
+	}
+
+	id, err := spiffeid.FromString(node.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Agent{
+		Id:                   api.ProtoFromID(id),
+		AttestationType:      node.AttestationDataType,
+		X509SvidSerialNumber: node.CertSerialNumber,
+		X509SvidExpiresAt:    node.CertNotAfter,
+		Selectors:            api.ProtoFromSelectors(node.Selectors),
+		Banned:               nodeutil.IsAgentBanned(node),
+	}, nil
+}
+
+func makeX509SVID(id spiffeid.ID, svid []*x509.Certificate) *types.X509SVID {
+	return &types.X509SVID{
+		Id:        api.ProtoFromID(id),
+		CertChain: x509util.RawCertsFromCertificates(svid),
+		ExpiresAt: svid[0].NotAfter.Unix(),
+	}
+}
+
+func applyMask(a *types.Agent, mask *types.AgentMask) {
+	if mask == nil {
+		return
+	}
+
+	if !mask.Id {
+		a.Id = nil
+	}
+
+	if !mask.AttestationType {
+		a.AttestationType = ""
+	}
+
+	if !mask.X509SvidSerialNumber {
+		a.X509SvidSerialNumber = ""
+	}
+
+	if !mask.X509SvidExpiresAt {
+		a.X509SvidExpiresAt = 0
+	}
+
+	if !mask.Selectors {
+		a.Selectors = nil
+	}
+
+	if !mask.Banned {
+		a.Banned = false
+	}
+}
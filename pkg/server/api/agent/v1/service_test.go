@@ -0,0 +1,902 @@
+package agent_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/agent/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	agentpb "github.com/spiffe/spire/proto/spire-next/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakenoderesolver"
+	"github.com/spiffe/spire/test/fakes/fakeserverca"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/fakes/fakeservernodeattestor"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testKey  = testkey.MustEC256()
+	td       = spiffeid.RequireTrustDomainFromString("example.org")
+	agent1ID = td.NewID("spire/agent/test/agent1")
+	agent2ID = td.NewID("spire/agent/other/agent2")
+)
+
+func TestListAgents(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	node1 := test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:            agent1ID.String(),
+		AttestationDataType: "test",
+		CertSerialNumber:    "1",
+		CertNotAfter:        1234,
+	}, &common.Selector{Type: "a", Value: "1"}, &common.Selector{Type: "b", Value: "2"})
+	node2 := test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:            agent2ID.String(),
+		AttestationDataType: "other",
+	}, &common.Selector{Type: "a", Value: "1"})
+
+	agent1 := &types.Agent{
+		Id:                   api.ProtoFromID(agent1ID),
+		AttestationType:      node1.AttestationDataType,
+		X509SvidSerialNumber: node1.CertSerialNumber,
+		X509SvidExpiresAt:    node1.CertNotAfter,
+		Selectors: []*types.Selector{
+			{Type: "a", Value: "1"},
+			{Type: "b", Value: "2"},
+		},
+	}
+	agent2 := &types.Agent{
+		Id:              api.ProtoFromID(agent2ID),
+		AttestationType: node2.AttestationDataType,
+		Selectors: []*types.Selector{
+			{Type: "a", Value: "1"},
+		},
+		Banned: true,
+	}
+
+	for _, tt := range []struct {
+		name         string
+		req          *agentpb.ListAgentsRequest
+		expectAgents []*types.Agent
+		code         codes.Code
+		err          string
+	}{
+		{
+			name:         "no filter",
+			req:          &agentpb.ListAgentsRequest{},
+			expectAgents: []*types.Agent{agent2, agent1},
+		},
+		{
+			name: "by banned",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{ByBanned: true},
+			},
+			expectAgents: []*types.Agent{agent2},
+		},
+		{
+			name: "by attestation type",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{ByAttestationType: "test"},
+			},
+			expectAgents: []*types.Agent{agent1},
+		},
+		{
+			name: "by selector match exact",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					BySelectorMatch: &types.SelectorMatch{
+						Selectors: []*types.Selector{{Type: "a", Value: "1"}},
+						Match:     types.SelectorMatch_MATCH_EXACT,
+					},
+				},
+			},
+			expectAgents: []*types.Agent{agent2},
+		},
+		{
+			name: "by selector match subset",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					BySelectorMatch: &types.SelectorMatch{
+						Selectors: []*types.Selector{
+							{Type: "a", Value: "1"},
+							{Type: "b", Value: "2"},
+							{Type: "c", Value: "3"},
+						},
+						Match: types.SelectorMatch_MATCH_SUBSET,
+					},
+				},
+			},
+			expectAgents: []*types.Agent{agent2, agent1},
+		},
+		{
+			name: "output mask",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{AttestationType: true},
+			},
+			expectAgents: []*types.Agent{
+				{AttestationType: "other"},
+				{AttestationType: "test"},
+			},
+		},
+		{
+			name: "malformed selector filter",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					BySelectorMatch: &types.SelectorMatch{
+						Selectors: []*types.Selector{{Value: "1"}},
+					},
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  "malformed selectors filter: missing selector type",
+		},
+		{
+			name: "empty selector filter",
+			req: &agentpb.ListAgentsRequest{
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					BySelectorMatch: &types.SelectorMatch{},
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  "malformed selectors filter: empty selector set",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.ListAgents(context.Background(), tt.req)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectAgents, resp.Agents)
+		})
+	}
+}
+
+func TestGetAgent(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:            agent1ID.String(),
+		AttestationDataType: "test",
+		CertSerialNumber:    "1",
+		CertNotAfter:        1234,
+	}, &common.Selector{Type: "a", Value: "1"})
+
+	for _, tt := range []struct {
+		name        string
+		req         *agentpb.GetAgentRequest
+		expectAgent *types.Agent
+		code        codes.Code
+		err         string
+		logMsg      string
+	}{
+		{
+			name: "success",
+			req:  &agentpb.GetAgentRequest{Id: api.ProtoFromID(agent1ID)},
+			expectAgent: &types.Agent{
+				Id:                   api.ProtoFromID(agent1ID),
+				AttestationType:      "test",
+				X509SvidSerialNumber: "1",
+				X509SvidExpiresAt:    1234,
+				Selectors:            []*types.Selector{{Type: "a", Value: "1"}},
+			},
+		},
+		{
+			name: "output mask",
+			req: &agentpb.GetAgentRequest{
+				Id:         api.ProtoFromID(agent1ID),
+				OutputMask: &types.AgentMask{X509SvidSerialNumber: true},
+			},
+			expectAgent: &types.Agent{
+				X509SvidSerialNumber: "1",
+			},
+		},
+		{
+			name:   "missing ID",
+			req:    &agentpb.GetAgentRequest{},
+			code:   codes.InvalidArgument,
+			err:    "invalid agent ID: request must specify SPIFFE ID",
+			logMsg: "Invalid request: invalid agent ID",
+		},
+		{
+			name:   "ID from another trust domain",
+			req:    &agentpb.GetAgentRequest{Id: &types.SPIFFEID{TrustDomain: "another.org", Path: "/spire/agent/test/agent1"}},
+			code:   codes.InvalidArgument,
+			err:    `invalid agent ID: "spiffe://another.org/spire/agent/test/agent1" is not a member of trust domain "example.org"`,
+			logMsg: "Invalid request: invalid agent ID",
+		},
+		{
+			name:   "not found",
+			req:    &agentpb.GetAgentRequest{Id: api.ProtoFromID(agent2ID)},
+			code:   codes.NotFound,
+			err:    "agent not found",
+			logMsg: "Agent not found",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.GetAgent(context.Background(), tt.req)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expectAgent, resp)
+		})
+	}
+}
+
+func TestDeleteAgent(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:         agent1ID.String(),
+		CertSerialNumber: "1",
+	})
+
+	_, err := test.client.DeleteAgent(context.Background(), &agentpb.DeleteAgentRequest{
+		Id: api.ProtoFromID(agent1ID),
+	})
+	require.NoError(t, err)
+	require.Nil(t, test.fetchAttestedNode(t, agent1ID))
+
+	_, err = test.client.DeleteAgent(context.Background(), &agentpb.DeleteAgentRequest{
+		Id: api.ProtoFromID(agent1ID),
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "agent not found")
+
+	_, err = test.client.DeleteAgent(context.Background(), &agentpb.DeleteAgentRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "invalid agent ID: request must specify SPIFFE ID")
+}
+
+func TestBanAgent(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:            agent1ID.String(),
+		AttestationDataType: "test",
+		CertSerialNumber:    "1",
+		CertNotAfter:        1234,
+		NewCertSerialNumber: "2",
+		NewCertNotAfter:     5678,
+	})
+
+	_, err := test.client.BanAgent(context.Background(), &agentpb.BanAgentRequest{
+		Id: api.ProtoFromID(agent1ID),
+	})
+	require.NoError(t, err)
+
+	// The attested node is kept with the serial numbers cleared
+	spiretest.AssertProtoEqual(t, &common.AttestedNode{
+		SpiffeId:            agent1ID.String(),
+		AttestationDataType: "test",
+		CertNotAfter:        1234,
+		NewCertNotAfter:     5678,
+		Banned:              true,
+	}, test.fetchAttestedNode(t, agent1ID))
+
+	_, err = test.client.BanAgent(context.Background(), &agentpb.BanAgentRequest{
+		Id: api.ProtoFromID(agent2ID),
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "agent not found")
+
+	_, err = test.client.BanAgent(context.Background(), &agentpb.BanAgentRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "invalid agent ID: request must specify SPIFFE ID")
+}
+
+func TestAgentCallerScope(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createAttestedNode(t, &common.AttestedNode{SpiffeId: agent1ID.String(), CertSerialNumber: "1"})
+	test.createAttestedNode(t, &common.AttestedNode{SpiffeId: agent2ID.String(), CertSerialNumber: "2"})
+	test.scope = []spiffeid.ID{td.NewID("spire/agent/test")}
+
+	resp, err := test.client.ListAgents(context.Background(), &agentpb.ListAgentsRequest{
+		OutputMask: &types.AgentMask{Id: true},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentpb.ListAgentsResponse{
+		Agents: []*types.Agent{{Id: api.ProtoFromID(agent1ID)}},
+	}, resp)
+
+	_, err = test.client.GetAgent(context.Background(), &agentpb.GetAgentRequest{Id: api.ProtoFromID(agent1ID)})
+	require.NoError(t, err)
+
+	_, err = test.client.GetAgent(context.Background(), &agentpb.GetAgentRequest{Id: api.ProtoFromID(agent2ID)})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "agent is outside of the caller scope")
+
+	_, err = test.client.BanAgent(context.Background(), &agentpb.BanAgentRequest{Id: api.ProtoFromID(agent2ID)})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "agent is outside of the caller scope")
+
+	_, err = test.client.DeleteAgent(context.Background(), &agentpb.DeleteAgentRequest{Id: api.ProtoFromID(agent2ID)})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "agent is outside of the caller scope")
+	require.NotNil(t, test.fetchAttestedNode(t, agent2ID))
+}
+
+func TestAttestAgentWithJoinToken(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	joinTokenID := td.NewID("spire/agent/join_token/TOKEN")
+	now := test.clk.Now()
+
+	for _, tt := range []struct {
+		name   string
+		token  *datastore.JoinToken
+		data   *types.AttestationData
+		code   codes.Code
+		err    string
+		logMsg string
+	}{
+		{
+			name:  "success",
+			token: &datastore.JoinToken{Token: "TOKEN", Expiry: now.Add(time.Minute).Unix()},
+			data:  &types.AttestationData{Type: "join_token", Payload: "TOKEN"},
+		},
+		{
+			name:   "token already used",
+			data:   &types.AttestationData{Type: "join_token", Payload: "TOKEN"},
+			code:   codes.Unknown,
+			err:    "failed to attest: join token has already been used",
+			logMsg: "Failed to attest",
+		},
+		{
+			name:   "token does not exist",
+			data:   &types.AttestationData{Type: "join_token", Payload: "UNKNOWN"},
+			code:   codes.Unknown,
+			err:    "failed to attest: no such token",
+			logMsg: "Failed to attest",
+		},
+		{
+			name:   "token expired",
+			token:  &datastore.JoinToken{Token: "EXPIRED", Expiry: now.Add(-time.Minute).Unix()},
+			data:   &types.AttestationData{Type: "join_token", Payload: "EXPIRED"},
+			code:   codes.Unknown,
+			err:    "failed to attest: join token expired",
+			logMsg: "Failed to attest",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.token != nil {
+				_, err := test.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+					JoinToken: tt.token,
+				})
+				require.NoError(t, err)
+			}
+
+			svid, err := test.attestAgent(t, tt.data, nil)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+			require.NoError(t, err)
+			test.requireAgentSVID(t, joinTokenID, svid)
+
+			// The token cannot be used again
+			resp, err := test.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: "TOKEN",
+			})
+			require.NoError(t, err)
+			require.Nil(t, resp.JoinToken)
+
+			node := test.fetchAttestedNode(t, joinTokenID)
+			require.NotNil(t, node)
+			require.Equal(t, "join_token", node.AttestationDataType)
+			require.Equal(t, svid[0].SerialNumber.String(), node.CertSerialNumber)
+		})
+	}
+}
+
+func TestAttestAgentWithChallengeResponse(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.addAttestor(t, fakeservernodeattestor.Config{
+		Data:       map[string]string{"data": "agent1"},
+		Challenges: map[string][]string{"agent1": {"challenge1", "challenge2"}},
+		Selectors:  map[string][]string{"agent1": {"attested"}},
+	})
+	test.addResolver(t, fakenoderesolver.Config{
+		Selectors: map[string][]string{agent1ID.String(): {"resolved"}},
+	})
+
+	svid, err := test.attestAgent(t, &types.AttestationData{Type: "test", Payload: "data"}, []string{"challenge1", "challenge2"})
+	require.NoError(t, err)
+	test.requireAgentSVID(t, agent1ID, svid)
+
+	node := test.fetchAttestedNode(t, agent1ID)
+	require.NotNil(t, node)
+	require.Equal(t, "test", node.AttestationDataType)
+	require.Equal(t, svid[0].SerialNumber.String(), node.CertSerialNumber)
+	require.Equal(t, svid[0].NotAfter.Unix(), node.CertNotAfter)
+
+	selectors, err := test.ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+		SpiffeId: agent1ID.String(),
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Selector{
+		{Type: "test", Value: "resolved"},
+		{Type: "test", Value: "attested"},
+	}, selectors.Selectors.Selectors)
+
+	// Reattestation updates the serial number
+	test.clk.Add(time.Second)
+	svid, err = test.attestAgent(t, &types.AttestationData{Type: "test", Payload: "data"}, []string{"challenge1", "challenge2"})
+	require.NoError(t, err)
+
+	node = test.fetchAttestedNode(t, agent1ID)
+	require.Equal(t, svid[0].SerialNumber.String(), node.CertSerialNumber)
+	require.Empty(t, node.NewCertSerialNumber)
+	require.Zero(t, node.NewCertNotAfter)
+}
+
+func TestAttestAgentBanned(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.addAttestor(t, fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "agent1"},
+	})
+	test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:         agent1ID.String(),
+		CertSerialNumber: "1",
+	})
+
+	_, err := test.client.BanAgent(context.Background(), &agentpb.BanAgentRequest{
+		Id: api.ProtoFromID(agent1ID),
+	})
+	require.NoError(t, err)
+
+	_, err = test.attestAgent(t, &types.AttestationData{Type: "test", Payload: "data"}, nil)
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "failed to attest: agent is banned")
+	require.Equal(t, "Agent is banned", test.logHook.LastEntry().Message)
+
+	// Deleting the agent lifts the ban
+	_, err = test.client.DeleteAgent(context.Background(), &agentpb.DeleteAgentRequest{
+		Id: api.ProtoFromID(agent1ID),
+	})
+	require.NoError(t, err)
+
+	svid, err := test.attestAgent(t, &types.AttestationData{Type: "test", Payload: "data"}, nil)
+	require.NoError(t, err)
+	test.requireAgentSVID(t, agent1ID, svid)
+}
+
+func TestAttestAgentFailures(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.addAttestor(t, fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "agent1"},
+	})
+
+	for _, tt := range []struct {
+		name        string
+		req         *agentpb.AttestAgentRequest
+		rateLimiter error
+		code        codes.Code
+		err         string
+		logMsg      string
+	}{
+		{
+			name:   "missing params",
+			req:    &agentpb.AttestAgentRequest{},
+			code:   codes.InvalidArgument,
+			err:    "missing params",
+			logMsg: "Invalid request: missing params",
+		},
+		{
+			name:   "missing attestation data",
+			req:    attestRequest(nil, createCSR(t)),
+			code:   codes.InvalidArgument,
+			err:    "missing attestation data",
+			logMsg: "Invalid request: missing attestation data",
+		},
+		{
+			name:   "missing attestation data type",
+			req:    attestRequest(&types.AttestationData{Payload: "data"}, createCSR(t)),
+			code:   codes.InvalidArgument,
+			err:    "missing attestation data type",
+			logMsg: "Invalid request: missing attestation data type",
+		},
+		{
+			name:   "missing CSR",
+			req:    attestRequest(&types.AttestationData{Type: "test", Payload: "data"}, nil),
+			code:   codes.InvalidArgument,
+			err:    "missing CSR",
+			logMsg: "Invalid request: missing CSR",
+		},
+		{
+			name:   "malformed CSR",
+			req:    attestRequest(&types.AttestationData{Type: "test", Payload: "data"}, []byte("malformed")),
+			code:   codes.InvalidArgument,
+			err:    "malformed CSR: asn1: structure error",
+			logMsg: "Failed to sign X509-SVID",
+		},
+		{
+			name:   "unknown attestor",
+			req:    attestRequest(&types.AttestationData{Type: "unknown", Payload: "data"}, createCSR(t)),
+			code:   codes.Unimplemented,
+			err:    `could not find node attestor type "unknown"`,
+			logMsg: "Could not find node attestor type",
+		},
+		{
+			name:   "attestor failure",
+			req:    attestRequest(&types.AttestationData{Type: "test", Payload: "bad"}, createCSR(t)),
+			code:   codes.Unknown,
+			err:    `no ID configured for attestation data "bad"`,
+			logMsg: "Failed to attest",
+		},
+		{
+			name:        "rate limited",
+			req:         attestRequest(&types.AttestationData{Type: "test", Payload: "data"}, createCSR(t)),
+			rateLimiter: status.Error(codes.ResourceExhausted, "rate limit exceeded"),
+			code:        codes.ResourceExhausted,
+			err:         "rate limit exceeded",
+			logMsg:      "Rejecting request due to node attestation rate limiting",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.rateLimiter.err = tt.rateLimiter
+
+			stream, err := test.client.AttestAgent(context.Background())
+			require.NoError(t, err)
+			// The server may have already failed the stream, in which case
+			// the send reports EOF and the status is returned by Recv.
+			if err := stream.Send(tt.req); err != nil {
+				require.Equal(t, io.EOF, err)
+			}
+
+			_, err = stream.Recv()
+			spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+			require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+		})
+	}
+}
+
+func TestRenewAgent(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createAttestedNode(t, &common.AttestedNode{
+		SpiffeId:         agent1ID.String(),
+		CertSerialNumber: "1",
+		CertNotAfter:     1234,
+	})
+
+	t.Run("success", func(t *testing.T) {
+		test.withCallerID = true
+		defer func() { test.withCallerID = false }()
+
+		stream, err := test.client.RenewAgent(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&agentpb.RenewAgentRequest{
+			Step: &agentpb.RenewAgentRequest_Params{
+				Params: &agentpb.AgentX509SVIDParams{Csr: createCSR(t)},
+			},
+		}))
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		svid, err := x509util.RawCertsToCertificates(resp.Svid.CertChain)
+		require.NoError(t, err)
+		test.requireAgentSVID(t, agent1ID, svid)
+
+		// The new SVID is not recorded until acknowledged
+		require.Empty(t, test.fetchAttestedNode(t, agent1ID).NewCertSerialNumber)
+
+		require.NoError(t, stream.Send(&agentpb.RenewAgentRequest{
+			Step: &agentpb.RenewAgentRequest_Ack_{
+				Ack: &agentpb.RenewAgentRequest_Ack{},
+			},
+		}))
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+
+		spiretest.AssertProtoEqual(t, &common.AttestedNode{
+			SpiffeId:            agent1ID.String(),
+			CertSerialNumber:    "1",
+			CertNotAfter:        1234,
+			NewCertSerialNumber: svid[0].SerialNumber.String(),
+			NewCertNotAfter:     svid[0].NotAfter.Unix(),
+		}, test.fetchAttestedNode(t, agent1ID))
+	})
+
+	t.Run("missing ack", func(t *testing.T) {
+		test.withCallerID = true
+		defer func() { test.withCallerID = false }()
+
+		stream, err := test.client.RenewAgent(context.Background())
+		require.NoError(t, err)
+		params := &agentpb.RenewAgentRequest{
+			Step: &agentpb.RenewAgentRequest_Params{
+				Params: &agentpb.AgentX509SVIDParams{Csr: createCSR(t)},
+			},
+		}
+		require.NoError(t, stream.Send(params))
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		require.NoError(t, stream.Send(params))
+		_, err = stream.Recv()
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "expected acknowledgement")
+		require.Equal(t, "Invalid request: expected acknowledgement", test.logHook.LastEntry().Message)
+	})
+
+	t.Run("missing CSR", func(t *testing.T) {
+		test.withCallerID = true
+		defer func() { test.withCallerID = false }()
+
+		stream, err := test.client.RenewAgent(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&agentpb.RenewAgentRequest{
+			Step: &agentpb.RenewAgentRequest_Params{
+				Params: &agentpb.AgentX509SVIDParams{},
+			},
+		}))
+		_, err = stream.Recv()
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing CSR")
+	})
+
+	t.Run("no caller ID", func(t *testing.T) {
+		stream, err := test.client.RenewAgent(context.Background())
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "caller ID missing from request context")
+	})
+}
+
+func TestCreateJoinToken(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	now := test.clk.Now()
+
+	t.Run("generated token", func(t *testing.T) {
+		token, err := test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{
+			Ttl: 60,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, token.Value)
+		require.Equal(t, now.Add(time.Minute).Unix(), token.ExpiresAt)
+
+		resp, err := test.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+			Token: token.Value,
+		})
+		require.NoError(t, err)
+		require.Equal(t, token.ExpiresAt, resp.JoinToken.Expiry)
+	})
+
+	t.Run("custom token with agent ID", func(t *testing.T) {
+		token, err := test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{
+			Ttl:     60,
+			Token:   "TOKEN",
+			AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "TOKEN", token.Value)
+
+		resp, err := test.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		require.Equal(t, "spiffe://example.org/spire/agent/join_token/TOKEN", resp.Entries[0].ParentId)
+		require.Equal(t, "spiffe://example.org/host", resp.Entries[0].SpiffeId)
+		spiretest.RequireProtoListEqual(t, []*common.Selector{
+			{Type: "spiffe_id", Value: "spiffe://example.org/spire/agent/join_token/TOKEN"},
+		}, resp.Entries[0].Selectors)
+	})
+
+	t.Run("missing TTL", func(t *testing.T) {
+		_, err := test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "ttl is required, you must provide one")
+	})
+
+	t.Run("agent ID from another trust domain", func(t *testing.T) {
+		_, err := test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{
+			Ttl:     60,
+			AgentId: &types.SPIFFEID{TrustDomain: "another.org", Path: "/host"},
+		})
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, `invalid agent ID: "spiffe://another.org/host" is not a member of trust domain "example.org"`)
+	})
+}
+
+type serviceTest struct {
+	client       agentpb.AgentClient
+	ds           *fakedatastore.DataStore
+	cat          *fakeservercatalog.Catalog
+	ca           *fakeserverca.CA
+	clk          *clock.Mock
+	logHook      *test.Hook
+	rateLimiter  *fakeRateLimiter
+	withCallerID bool
+	scope        []spiffeid.ID
+	done         func()
+	pluginDones  []func()
+}
+
+func (s *serviceTest) Cleanup() {
+	s.done()
+	for _, done := range s.pluginDones {
+		done()
+	}
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	cat := fakeservercatalog.New()
+	cat.SetDataStore(ds)
+	ca := fakeserverca.New(t, td.String(), &fakeserverca.Options{Clock: clk})
+
+	service := agent.New(agent.Config{
+		Catalog:     cat,
+		Clock:       clk,
+		Datastore:   ds,
+		ServerCA:    ca,
+		TrustDomain: td,
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s *grpc.Server) {
+		agent.RegisterService(s, service)
+	}
+
+	test := &serviceTest{
+		ds:          ds,
+		cat:         cat,
+		ca:          ca,
+		clk:         clk,
+		logHook:     logHook,
+		rateLimiter: &fakeRateLimiter{},
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		ctx = rpccontext.WithLogger(ctx, log)
+		ctx = rpccontext.WithRateLimiter(ctx, test.rateLimiter)
+		if test.withCallerID {
+			ctx = rpccontext.WithCallerID(ctx, agent1ID)
+		}
+		if test.scope != nil {
+			ctx = rpccontext.WithCallerScope(ctx, test.scope)
+		}
+		return ctx
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	test.client = agentpb.NewAgentClient(conn)
+	test.done = done
+
+	return test
+}
+
+func (s *serviceTest) addAttestor(t *testing.T, config fakeservernodeattestor.Config) {
+	var p nodeattestor.NodeAttestor
+	s.pluginDones = append(s.pluginDones, spiretest.LoadPlugin(t, catalog.MakePlugin("test", nodeattestor.PluginServer(fakeservernodeattestor.New("test", config))), &p))
+	s.cat.AddNodeAttestorNamed("test", p)
+}
+
+func (s *serviceTest) addResolver(t *testing.T, config fakenoderesolver.Config) {
+	var p noderesolver.NodeResolver
+	s.pluginDones = append(s.pluginDones, spiretest.LoadPlugin(t, catalog.MakePlugin("test", noderesolver.PluginServer(fakenoderesolver.New("test", config))), &p))
+	s.cat.AddNodeResolverNamed("test", p)
+}
+
+func (s *serviceTest) createAttestedNode(t *testing.T, node *common.AttestedNode, selectors ...*common.Selector) *common.AttestedNode {
+	_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	require.NoError(t, err)
+
+	_, err = s.ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  node.SpiffeId,
+			Selectors: selectors,
+		},
+	})
+	require.NoError(t, err)
+	return node
+}
+
+func (s *serviceTest) fetchAttestedNode(t *testing.T, id spiffeid.ID) *common.AttestedNode {
+	resp, err := s.ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
+		SpiffeId: id.String(),
+	})
+	require.NoError(t, err)
+	return resp.Node
+}
+
+// attestAgent runs the attestation flow, answering each of the expected
+// challenges by echoing it back.
+func (s *serviceTest) attestAgent(t *testing.T, data *types.AttestationData, challenges []string) ([]*x509.Certificate, error) {
+	stream, err := s.client.AttestAgent(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(attestRequest(data, createCSR(t))))
+
+	for _, challenge := range challenges {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		require.Equal(t, challenge, string(resp.GetChallenge()))
+		require.NoError(t, stream.Send(&agentpb.AttestAgentRequest{
+			Step: &agentpb.AttestAgentRequest_ChallengeResponse{
+				ChallengeResponse: resp.GetChallenge(),
+			},
+		}))
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	require.NotNil(t, resp.GetResult())
+	return x509util.RawCertsToCertificates(resp.GetResult().Svid.CertChain)
+}
+
+func (s *serviceTest) requireAgentSVID(t *testing.T, id spiffeid.ID, svid []*x509.Certificate) {
+	require.NotEmpty(t, svid)
+	require.Len(t, svid[0].URIs, 1)
+	require.Equal(t, id.String(), svid[0].URIs[0].String())
+	require.Equal(t, testKey.Public(), svid[0].PublicKey)
+}
+
+func attestRequest(data *types.AttestationData, csr []byte) *agentpb.AttestAgentRequest {
+	return &agentpb.AttestAgentRequest{
+		Step: &agentpb.AttestAgentRequest_Params_{
+			Params: &agentpb.AttestAgentRequest_Params{
+				Data: data,
+				Params: &agentpb.AgentX509SVIDParams{
+					Csr: csr,
+				},
+			},
+		},
+	}
+}
+
+func createCSR(tb testing.TB) []byte {
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testKey)
+	require.NoError(tb, err)
+	return csr
+}
+
+type fakeRateLimiter struct {
+	err error
+}
+
+func (f *fakeRateLimiter) RateLimit(ctx context.Context, count int) error {
+	return f.err
+}
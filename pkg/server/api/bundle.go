@@ -0,0 +1,91 @@
+package api
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// ProtoToBundle converts a types.Bundle to a common.Bundle, validating the
+// trust domain and the X.509 and JWT authorities
+func ProtoToBundle(b *types.Bundle) (*common.Bundle, error) {
+	if b == nil {
+		return nil, errors.New("missing bundle")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %v", err)
+	}
+
+	rootCAs, err := ParseX509Authorities(b.X509Authorities)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSigningKeys, err := ParseJWTAuthorities(b.JwtAuthorities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Bundle{
+		TrustDomainId:  td.IDString(),
+		RefreshHint:    b.RefreshHint,
+		RootCas:        rootCAs,
+		JwtSigningKeys: jwtSigningKeys,
+	}, nil
+}
+
+// ParseX509Authorities validates and converts a slice of
+// types.X509Certificate to a slice of common.Certificate
+func ParseX509Authorities(certs []*types.X509Certificate) ([]*common.Certificate, error) {
+	var rootCAs []*common.Certificate
+	for _, cert := range certs {
+		if _, err := x509.ParseCertificate(cert.Asn1); err != nil {
+			return nil, fmt.Errorf("invalid X.509 authority: %v", err)
+		}
+
+		rootCAs = append(rootCAs, &common.Certificate{
+			DerBytes: cert.Asn1,
+		})
+	}
+	return rootCAs, nil
+}
+
+// ParseJWTAuthorities validates and converts a slice of types.JWTKey to a
+// slice of common.PublicKey
+func ParseJWTAuthorities(keys []*types.JWTKey) ([]*common.PublicKey, error) {
+	var jwtKeys []*common.PublicKey
+	for _, key := range keys {
+		jwtKey, err := ParseJWTAuthority(key)
+		if err != nil {
+			return nil, err
+		}
+		jwtKeys = append(jwtKeys, jwtKey)
+	}
+	return jwtKeys, nil
+}
+
+// ParseJWTAuthority validates and converts a types.JWTKey to a
+// common.PublicKey
+func ParseJWTAuthority(key *types.JWTKey) (*common.PublicKey, error) {
+	if key == nil {
+		return nil, errors.New("missing JWT authority")
+	}
+	if key.KeyId == "" {
+		return nil, errors.New("invalid JWT authority: missing key ID")
+	}
+	if _, err := x509.ParsePKIXPublicKey(key.PublicKey); err != nil {
+		return nil, fmt.Errorf("invalid JWT authority: %v", err)
+	}
+
+	return &common.PublicKey{
+		PkixBytes: key.PublicKey,
+		Kid:       key.KeyId,
+		NotAfter:  key.ExpiresAt,
+	}, nil
+}
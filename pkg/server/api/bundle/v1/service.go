@@ -2,10 +2,13 @@ package bundle
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire-next/api/server/bundle/v1"
@@ -29,10 +32,27 @@ func RegisterService(s *grpc.Server, service *Service) {
 	bundle.RegisterBundleServer(s, service)
 }
 
+// UpstreamPublisher publishes JWT authorities, either upstream or to the
+// bundle of the server trust domain, and returns the resulting JWT
+// authorities.
+type UpstreamPublisher interface {
+	PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error)
+}
+
+// UpstreamPublisherFunc is an implementation of UpstreamPublisher that is a
+// function.
+type UpstreamPublisherFunc func(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error)
+
+// PublishJWTKey publishes the JWT key by calling the function.
+func (fn UpstreamPublisherFunc) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+	return fn(ctx, jwtKey)
+}
+
 // Config is the service configuration
 type Config struct {
-	Datastore   datastore.DataStore
-	TrustDomain spiffeid.TrustDomain
+	Datastore         datastore.DataStore
+	TrustDomain       spiffeid.TrustDomain
+	UpstreamPublisher UpstreamPublisher
 }
 
 // New creates a new bundle service
@@ -40,6 +60,7 @@ func New(config Config) *Service {
 	return &Service{
 		ds: config.Datastore,
 		td: config.TrustDomain,
+		up: config.UpstreamPublisher,
 	}
 }
 
@@ -47,6 +68,7 @@ func New(config Config) *Service {
 type Service struct {
 	ds datastore.DataStore
 	td spiffeid.TrustDomain
+	up UpstreamPublisher
 }
 
 func (s *Service) GetBundle(ctx context.Context, req *bundle.GetBundleRequest) (*types.Bundle, error) {
@@ -75,11 +97,107 @@ func (s *Service) GetBundle(ctx context.Context, req *bundle.GetBundleRequest) (
 }
 
 func (s *Service) AppendBundle(ctx context.Context, req *bundle.AppendBundleRequest) (*types.Bundle, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AppendBundle not implemented")
+	log := rpccontext.Logger(ctx)
+
+	if req.Bundle == nil {
+		log.Error("Invalid request: missing bundle")
+		return nil, status.Error(codes.InvalidArgument, "missing bundle")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(req.Bundle.TrustDomain)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: invalid trust domain")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid trust domain: %v", err)
+	}
+	if s.td.Compare(td) != 0 {
+		log.Errorf("Invalid request: %q is not the trust domain of this server", td.String())
+		return nil, status.Errorf(codes.InvalidArgument, "%q is not the trust domain of this server", td.String())
+	}
+
+	inputMask := req.InputMask
+	if inputMask == nil {
+		inputMask = defaultMask
+	}
+
+	appendBundle := &common.Bundle{
+		TrustDomainId: s.td.IDString(),
+	}
+	if inputMask.X509Authorities {
+		appendBundle.RootCas, err = api.ParseX509Authorities(req.Bundle.X509Authorities)
+		if err != nil {
+			log.WithError(err).Error("Invalid request: failed to convert X.509 authorities")
+			return nil, status.Errorf(codes.InvalidArgument, "failed to convert X.509 authorities: %v", err)
+		}
+	}
+	if inputMask.JwtAuthorities {
+		appendBundle.JwtSigningKeys, err = api.ParseJWTAuthorities(req.Bundle.JwtAuthorities)
+		if err != nil {
+			log.WithError(err).Error("Invalid request: failed to convert JWT authorities")
+			return nil, status.Errorf(codes.InvalidArgument, "failed to convert JWT authorities: %v", err)
+		}
+	}
+
+	// The datastore creates missing bundles when appending, but the
+	// bundle for the trust domain of the server must already exist.
+	fetchResp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: s.td.IDString(),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch bundle")
+		return nil, status.Errorf(codes.Internal, "failed to fetch bundle: %v", err)
+	}
+	if fetchResp.Bundle == nil {
+		log.Error("Bundle not found")
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	dsResp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: appendBundle,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to append bundle")
+		return nil, status.Errorf(codes.Internal, "failed to append bundle: %v", err)
+	}
+
+	b, err := applyMask(dsResp.Bundle, req.OutputMask)
+	if err != nil {
+		log.WithError(err).Error("Failed to apply mask")
+		return nil, status.Errorf(codes.Internal, "failed to apply mask: %v", err)
+	}
+
+	return b, nil
 }
 
 func (s *Service) PublishJWTAuthority(ctx context.Context, req *bundle.PublishJWTAuthorityRequest) (*bundle.PublishJWTAuthorityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PublishJWTAuthority not implemented")
+	log := rpccontext.Logger(ctx)
+
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		log.WithError(err).Error("Rejecting request due to JWT authority publishing rate limiting")
+		return nil, err
+	}
+
+	jwtKey, err := api.ParseJWTAuthority(req.JwtAuthority)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: failed to convert JWT authority")
+		return nil, status.Errorf(codes.InvalidArgument, "failed to convert JWT authority: %v", err)
+	}
+
+	jwtKeys, err := s.up.PublishJWTKey(ctx, jwtKey)
+	if err != nil {
+		log.WithError(err).Error("Failed to publish JWT authority")
+		return nil, status.Errorf(codes.Internal, "failed to publish JWT authority: %v", err)
+	}
+
+	resp := &bundle.PublishJWTAuthorityResponse{}
+	for _, key := range jwtKeys {
+		resp.JwtAuthorities = append(resp.JwtAuthorities, &types.JWTKey{
+			PublicKey: key.PkixBytes,
+			KeyId:     key.Kid,
+			ExpiresAt: key.NotAfter,
+		})
+	}
+
+	return resp, nil
 }
 
 func (s *Service) ListFederatedBundles(ctx context.Context, req *bundle.ListFederatedBundlesRequest) (*bundle.ListFederatedBundlesResponse, error) {
@@ -171,19 +289,299 @@ func (s *Service) GetFederatedBundle(ctx context.Context, req *bundle.GetFederat
 }
 
 func (s *Service) BatchCreateFederatedBundle(ctx context.Context, req *bundle.BatchCreateFederatedBundleRequest) (*bundle.BatchCreateFederatedBundleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateFederatedBundle not implemented")
+	var results []*bundle.BatchCreateFederatedBundleResponse_Result
+	for _, b := range req.Bundle {
+		results = append(results, s.createFederatedBundle(ctx, b, req.OutputMask))
+	}
+
+	return &bundle.BatchCreateFederatedBundleResponse{
+		Results: results,
+	}, nil
 }
 
 func (s *Service) BatchUpdateFederatedBundle(ctx context.Context, req *bundle.BatchUpdateFederatedBundleRequest) (*bundle.BatchUpdateFederatedBundleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateFederatedBundle not implemented")
+	var results []*bundle.BatchUpdateFederatedBundleResponse_Result
+	for _, b := range req.Bundle {
+		results = append(results, s.updateFederatedBundle(ctx, b, req.InputMask, req.OutputMask))
+	}
+
+	return &bundle.BatchUpdateFederatedBundleResponse{
+		Results: results,
+	}, nil
 }
 
 func (s *Service) BatchSetFederatedBundle(ctx context.Context, req *bundle.BatchSetFederatedBundleRequest) (*bundle.BatchSetFederatedBundleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchSetFederatedBundle not implemented")
+	var results []*bundle.BatchSetFederatedBundleResponse_Result
+	for _, b := range req.Bundle {
+		results = append(results, s.setFederatedBundle(ctx, b, req.OutputMask))
+	}
+
+	return &bundle.BatchSetFederatedBundleResponse{
+		Results: results,
+	}, nil
 }
 
 func (s *Service) BatchDeleteFederatedBundle(ctx context.Context, req *bundle.BatchDeleteFederatedBundleRequest) (*bundle.BatchDeleteFederatedBundleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteFederatedBundle not implemented")
+	log := rpccontext.Logger(ctx)
+
+	mode, err := deleteModeFromProto(req.Mode)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: invalid delete mode")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delete mode: %v", err)
+	}
+
+	var results []*bundle.BatchDeleteFederatedBundleResponse_Result
+	for _, trustDomain := range req.TrustDomains {
+		results = append(results, s.deleteFederatedBundle(ctx, trustDomain, mode))
+	}
+
+	return &bundle.BatchDeleteFederatedBundleResponse{
+		Results: results,
+	}, nil
+}
+
+// createFederatedBundle creates a single federated bundle and returns its
+// result
+func (s *Service) createFederatedBundle(ctx context.Context, b *types.Bundle, outputMask *types.BundleMask) *bundle.BatchCreateFederatedBundleResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	dsBundle, err := s.protoToFederatedBundle(b)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert bundle")
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert bundle: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, dsBundle.TrustDomainId)
+
+	existing, err := s.fetchBundle(ctx, dsBundle.TrustDomainId)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch bundle")
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to fetch bundle: %v", err),
+		}
+	}
+	if existing != nil {
+		log.Error("Bundle already exists")
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.AlreadyExists, "bundle already exists"),
+		}
+	}
+
+	dsResp, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: dsBundle,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to create bundle")
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to create bundle: %v", err),
+		}
+	}
+
+	out, err := applyMask(dsResp.Bundle, outputMask)
+	if err != nil {
+		log.WithError(err).Error("Failed to apply mask")
+		return &bundle.BatchCreateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to apply mask: %v", err),
+		}
+	}
+
+	return &bundle.BatchCreateFederatedBundleResponse_Result{
+		Status: api.CreateStatus(codes.OK, "OK"),
+		Bundle: out,
+	}
+}
+
+// updateFederatedBundle updates the fields of a single federated bundle that
+// are enabled in the input mask and returns its result
+func (s *Service) updateFederatedBundle(ctx context.Context, b *types.Bundle, inputMask, outputMask *types.BundleMask) *bundle.BatchUpdateFederatedBundleResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	dsBundle, err := s.protoToFederatedBundle(b)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert bundle")
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert bundle: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, dsBundle.TrustDomainId)
+
+	existing, err := s.fetchBundle(ctx, dsBundle.TrustDomainId)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch bundle")
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to fetch bundle: %v", err),
+		}
+	}
+	if existing == nil {
+		log.Error("Bundle not found")
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.NotFound, "bundle not found"),
+		}
+	}
+	mergeBundle(existing, dsBundle, inputMask)
+
+	dsResp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: existing,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to update bundle")
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to update bundle: %v", err),
+		}
+	}
+
+	out, err := applyMask(dsResp.Bundle, outputMask)
+	if err != nil {
+		log.WithError(err).Error("Failed to apply mask")
+		return &bundle.BatchUpdateFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to apply mask: %v", err),
+		}
+	}
+
+	return &bundle.BatchUpdateFederatedBundleResponse_Result{
+		Status: api.CreateStatus(codes.OK, "OK"),
+		Bundle: out,
+	}
+}
+
+// setFederatedBundle creates or replaces a single federated bundle and
+// returns its result
+func (s *Service) setFederatedBundle(ctx context.Context, b *types.Bundle, outputMask *types.BundleMask) *bundle.BatchSetFederatedBundleResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	dsBundle, err := s.protoToFederatedBundle(b)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert bundle")
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert bundle: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, dsBundle.TrustDomainId)
+
+	dsResp, err := s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: dsBundle,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to set bundle")
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to set bundle: %v", err),
+		}
+	}
+
+	out, err := applyMask(dsResp.Bundle, outputMask)
+	if err != nil {
+		log.WithError(err).Error("Failed to apply mask")
+		return &bundle.BatchSetFederatedBundleResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to apply mask: %v", err),
+		}
+	}
+
+	return &bundle.BatchSetFederatedBundleResponse_Result{
+		Status: api.CreateStatus(codes.OK, "OK"),
+		Bundle: out,
+	}
+}
+
+// deleteFederatedBundle deletes a single federated bundle and returns its
+// result
+func (s *Service) deleteFederatedBundle(ctx context.Context, trustDomain string, mode datastore.DeleteBundleRequest_Mode) *bundle.BatchDeleteFederatedBundleResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: malformed trust domain")
+		return &bundle.BatchDeleteFederatedBundleResponse_Result{
+			TrustDomain: trustDomain,
+			Status:      api.CreateStatus(codes.InvalidArgument, "malformed trust domain: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, td.IDString())
+
+	if s.td.Compare(td) == 0 {
+		log.Error("Invalid argument: removing the bundle for the server trust domain is not allowed")
+		return &bundle.BatchDeleteFederatedBundleResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.InvalidArgument, "removing the bundle for the server trust domain is not allowed"),
+		}
+	}
+
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: td.IDString(),
+		Mode:          mode,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return &bundle.BatchDeleteFederatedBundleResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.OK, "OK"),
+		}
+	case codes.NotFound:
+		log.Error("Bundle not found")
+		return &bundle.BatchDeleteFederatedBundleResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.NotFound, "bundle not found"),
+		}
+	default:
+		log.WithError(err).Error("Failed to delete federated bundle")
+		return &bundle.BatchDeleteFederatedBundleResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.Internal, "failed to delete federated bundle: %v", err),
+		}
+	}
+}
+
+// protoToFederatedBundle converts the bundle and validates that it does not
+// belong to the server trust domain.
+func (s *Service) protoToFederatedBundle(b *types.Bundle) (*common.Bundle, error) {
+	dsBundle, err := api.ProtoToBundle(b)
+	if err != nil {
+		return nil, err
+	}
+	if dsBundle.TrustDomainId == s.td.IDString() {
+		return nil, errors.New("only the trust domain of federated bundles can be managed")
+	}
+	return dsBundle, nil
+}
+
+func (s *Service) fetchBundle(ctx context.Context, trustDomainID string) (*common.Bundle, error) {
+	resp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Bundle, nil
+}
+
+// mergeBundle sets the fields of dst from src for every field enabled in the
+// mask. A nil mask updates every field.
+func mergeBundle(dst, src *common.Bundle, mask *types.BundleMask) {
+	if mask == nil {
+		mask = defaultMask
+	}
+	if mask.RefreshHint {
+		dst.RefreshHint = src.RefreshHint
+	}
+	if mask.X509Authorities {
+		dst.RootCas = src.RootCas
+	}
+	if mask.JwtAuthorities {
+		dst.JwtSigningKeys = src.JwtSigningKeys
+	}
+}
+
+func deleteModeFromProto(mode bundle.BatchDeleteFederatedBundleRequest_Mode) (datastore.DeleteBundleRequest_Mode, error) {
+	switch mode {
+	case bundle.BatchDeleteFederatedBundleRequest_RESTRICT:
+		return datastore.DeleteBundleRequest_RESTRICT, nil
+	case bundle.BatchDeleteFederatedBundleRequest_DELETE:
+		return datastore.DeleteBundleRequest_DELETE, nil
+	case bundle.BatchDeleteFederatedBundleRequest_DISSOCIATE:
+		return datastore.DeleteBundleRequest_DISSOCIATE, nil
+	default:
+		return datastore.DeleteBundleRequest_RESTRICT, fmt.Errorf("unknown delete mode %q", mode)
+	}
 }
 
 func applyMask(b *common.Bundle, mask *types.BundleMask) (*types.Bundle, error) {
@@ -2,6 +2,8 @@ package bundle_test
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"testing"
@@ -13,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -21,8 +24,11 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -340,6 +346,411 @@ func TestListFederatedBundles(t *testing.T) {
 	}
 }
 
+func TestAppendBundle(t *testing.T) {
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	jwtKey := newJWTKey(t, "appended-key-id")
+
+	for _, tt := range []struct {
+		name          string
+		bundle        *types.Bundle
+		inputMask     *types.BundleMask
+		setBundle     bool
+		code          codes.Code
+		err           string
+		logMsg        string
+		expectRootCAs int
+		expectJWTKeys int
+	}{
+		{
+			name: "append X.509 and JWT authorities",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.org",
+				X509Authorities: []*types.X509Certificate{{Asn1: caCert.Raw}},
+				JwtAuthorities:  []*types.JWTKey{jwtKey},
+			},
+			setBundle:     true,
+			expectRootCAs: 2,
+			expectJWTKeys: 2,
+		},
+		{
+			name: "input mask filters authorities",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.org",
+				X509Authorities: []*types.X509Certificate{{Asn1: caCert.Raw}},
+				JwtAuthorities:  []*types.JWTKey{jwtKey},
+			},
+			inputMask:     &types.BundleMask{JwtAuthorities: true},
+			setBundle:     true,
+			expectRootCAs: 1,
+			expectJWTKeys: 2,
+		},
+		{
+			name:   "missing bundle",
+			code:   codes.InvalidArgument,
+			err:    "missing bundle",
+			logMsg: "Invalid request: missing bundle",
+		},
+		{
+			name:   "bundle of another trust domain",
+			bundle: &types.Bundle{TrustDomain: "another-example.org"},
+			code:   codes.InvalidArgument,
+			err:    `"another-example.org" is not the trust domain of this server`,
+			logMsg: `Invalid request: "another-example.org" is not the trust domain of this server`,
+		},
+		{
+			name: "invalid X.509 authority",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.org",
+				X509Authorities: []*types.X509Certificate{{Asn1: []byte("malformed")}},
+			},
+			setBundle: true,
+			code:      codes.InvalidArgument,
+			err:       "failed to convert X.509 authorities: invalid X.509 authority",
+			logMsg:    "Invalid request: failed to convert X.509 authorities",
+		},
+		{
+			name: "invalid JWT authority",
+			bundle: &types.Bundle{
+				TrustDomain:    "example.org",
+				JwtAuthorities: []*types.JWTKey{{PublicKey: jwtKey.PublicKey}},
+			},
+			setBundle: true,
+			code:      codes.InvalidArgument,
+			err:       "failed to convert JWT authorities: invalid JWT authority: missing key ID",
+			logMsg:    "Invalid request: failed to convert JWT authorities",
+		},
+		{
+			name:   "bundle not found",
+			bundle: &types.Bundle{TrustDomain: "example.org"},
+			code:   codes.NotFound,
+			err:    "bundle not found",
+			logMsg: "Bundle not found",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			if tt.setBundle {
+				test.setBundle(t, tdBundle)
+			}
+
+			b, err := test.client.AppendBundle(ctx, &bundlepb.AppendBundleRequest{
+				Bundle:    tt.bundle,
+				InputMask: tt.inputMask,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, b)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "example.org", b.TrustDomain)
+			require.Len(t, b.X509Authorities, tt.expectRootCAs)
+			require.Len(t, b.JwtAuthorities, tt.expectJWTKeys)
+		})
+	}
+}
+
+func TestPublishJWTAuthority(t *testing.T) {
+	jwtKey := newJWTKey(t, "published-key-id")
+
+	for _, tt := range []struct {
+		name         string
+		jwtAuthority *types.JWTKey
+		rateLimitErr error
+		publishErr   error
+		code         codes.Code
+		err          string
+		logMsg       string
+	}{
+		{
+			name:         "publish succeeds",
+			jwtAuthority: jwtKey,
+		},
+		{
+			name:   "missing JWT authority",
+			code:   codes.InvalidArgument,
+			err:    "failed to convert JWT authority: missing JWT authority",
+			logMsg: "Invalid request: failed to convert JWT authority",
+		},
+		{
+			name:         "malformed public key",
+			jwtAuthority: &types.JWTKey{KeyId: "key-id", PublicKey: []byte("malformed")},
+			code:         codes.InvalidArgument,
+			err:          "failed to convert JWT authority: invalid JWT authority",
+			logMsg:       "Invalid request: failed to convert JWT authority",
+		},
+		{
+			name:         "rate limit fails",
+			jwtAuthority: jwtKey,
+			rateLimitErr: status.Error(codes.Unknown, "rate limit fails"),
+			code:         codes.Unknown,
+			err:          "rate limit fails",
+			logMsg:       "Rejecting request due to JWT authority publishing rate limiting",
+		},
+		{
+			name:         "publish fails",
+			jwtAuthority: jwtKey,
+			publishErr:   errors.New("oh no"),
+			code:         codes.Internal,
+			err:          "failed to publish JWT authority: oh no",
+			logMsg:       "Failed to publish JWT authority",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.rateLimiter.err = tt.rateLimitErr
+			test.publishErr = tt.publishErr
+
+			resp, err := test.client.PublishJWTAuthority(ctx, &bundlepb.PublishJWTAuthorityRequest{
+				JwtAuthority: tt.jwtAuthority,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.JwtAuthorities, 2)
+			spiretest.AssertProtoEqual(t, jwtKey, resp.JwtAuthorities[1])
+		})
+	}
+}
+
+func TestBatchCreateFederatedBundle(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.setBundle(t, federatedBundle)
+	newBundle := newFederatedBundle(t, "new-example.org")
+
+	resp, err := test.client.BatchCreateFederatedBundle(ctx, &bundlepb.BatchCreateFederatedBundleRequest{
+		Bundle: []*types.Bundle{
+			newBundle,
+			{TrustDomain: "another-example.org"},
+			{TrustDomain: "example.org"},
+			{TrustDomain: "//not-valid"},
+		},
+		OutputMask: &types.BundleMask{TrustDomain: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 4)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &types.Bundle{TrustDomain: "new-example.org"}, resp.Results[0].Bundle)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.AlreadyExists, "bundle already exists"), resp.Results[1].Status)
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[2].Status.Code)
+	require.Contains(t, resp.Results[2].Status.Message, "only the trust domain of federated bundles can be managed")
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[3].Status.Code)
+	require.Contains(t, resp.Results[3].Status.Message, "failed to convert bundle: invalid trust domain")
+
+	created := test.fetchBundle(t, "spiffe://new-example.org")
+	require.NotNil(t, created)
+	require.Len(t, created.RootCas, 1)
+	require.Len(t, created.JwtSigningKeys, 1)
+	require.Equal(t, newBundle.RefreshHint, created.RefreshHint)
+}
+
+func TestBatchUpdateFederatedBundle(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		inputMask         *types.BundleMask
+		expectRefreshHint int64
+		expectJWTKeyID    string
+	}{
+		{
+			name:              "nil input mask updates every field",
+			expectRefreshHint: 30,
+			expectJWTKeyID:    "new-key-id",
+		},
+		{
+			name:              "input mask limits updated fields",
+			inputMask:         &types.BundleMask{RefreshHint: true},
+			expectRefreshHint: 30,
+			expectJWTKeyID:    "key-id-1",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.setBundle(t, federatedBundle)
+			update := newFederatedBundle(t, "another-example.org")
+			update.RefreshHint = 30
+			update.JwtAuthorities[0].KeyId = "new-key-id"
+
+			resp, err := test.client.BatchUpdateFederatedBundle(ctx, &bundlepb.BatchUpdateFederatedBundleRequest{
+				Bundle: []*types.Bundle{
+					update,
+					{TrustDomain: "not-found.org"},
+				},
+				InputMask: tt.inputMask,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 2)
+
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+			require.Equal(t, tt.expectRefreshHint, resp.Results[0].Bundle.RefreshHint)
+			require.Equal(t, tt.expectJWTKeyID, resp.Results[0].Bundle.JwtAuthorities[0].KeyId)
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.NotFound, "bundle not found"), resp.Results[1].Status)
+
+			updated := test.fetchBundle(t, "spiffe://another-example.org")
+			require.Equal(t, tt.expectRefreshHint, updated.RefreshHint)
+			require.Equal(t, tt.expectJWTKeyID, updated.JwtSigningKeys[0].Kid)
+		})
+	}
+}
+
+func TestBatchSetFederatedBundle(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.setBundle(t, federatedBundle)
+	replacement := newFederatedBundle(t, "another-example.org")
+	created := newFederatedBundle(t, "new-example.org")
+
+	resp, err := test.client.BatchSetFederatedBundle(ctx, &bundlepb.BatchSetFederatedBundleRequest{
+		Bundle: []*types.Bundle{
+			replacement,
+			created,
+			{TrustDomain: "example.org"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, replacement, resp.Results[0].Bundle)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[1].Status)
+	spiretest.AssertProtoEqual(t, created, resp.Results[1].Bundle)
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[2].Status.Code)
+
+	require.Equal(t, replacement.JwtAuthorities[0].KeyId, test.fetchBundle(t, "spiffe://another-example.org").JwtSigningKeys[0].Kid)
+	require.NotNil(t, test.fetchBundle(t, "spiffe://new-example.org"))
+}
+
+func TestBatchDeleteFederatedBundle(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		mode                bundlepb.BatchDeleteFederatedBundleRequest_Mode
+		expectStatus        *types.Status
+		expectDeleted       bool
+		expectEntry         bool
+		expectFederatesWith []string
+	}{
+		{
+			name:                "restrict fails when entries federate with the bundle",
+			mode:                bundlepb.BatchDeleteFederatedBundleRequest_RESTRICT,
+			expectStatus:        api.CreateStatus(codes.Internal, "failed to delete federated bundle: cannot delete bundle; federated with 1 registration entries"),
+			expectEntry:         true,
+			expectFederatesWith: []string{"spiffe://another-example.org"},
+		},
+		{
+			name:          "delete removes the federated entries",
+			mode:          bundlepb.BatchDeleteFederatedBundleRequest_DELETE,
+			expectStatus:  api.CreateStatus(codes.OK, "OK"),
+			expectDeleted: true,
+		},
+		{
+			name:          "dissociate removes the federation from entries",
+			mode:          bundlepb.BatchDeleteFederatedBundleRequest_DISSOCIATE,
+			expectStatus:  api.CreateStatus(codes.OK, "OK"),
+			expectDeleted: true,
+			expectEntry:   true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.setBundle(t, federatedBundle)
+			entryResp, err := test.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+				Entry: &common.RegistrationEntry{
+					ParentId:      "spiffe://example.org/agent",
+					SpiffeId:      "spiffe://example.org/workload",
+					Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+					FederatesWith: []string{"spiffe://another-example.org"},
+				},
+			})
+			require.NoError(t, err)
+
+			resp, err := test.client.BatchDeleteFederatedBundle(ctx, &bundlepb.BatchDeleteFederatedBundleRequest{
+				TrustDomains: []string{"another-example.org", "not-found.org", "example.org", "//not-valid"},
+				Mode:         tt.mode,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 4)
+
+			require.Equal(t, "another-example.org", resp.Results[0].TrustDomain)
+			spiretest.AssertProtoEqual(t, tt.expectStatus, resp.Results[0].Status)
+			require.Equal(t, "not-found.org", resp.Results[1].TrustDomain)
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.NotFound, "bundle not found"), resp.Results[1].Status)
+			require.Equal(t, "example.org", resp.Results[2].TrustDomain)
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument, "removing the bundle for the server trust domain is not allowed"), resp.Results[2].Status)
+			require.Equal(t, "//not-valid", resp.Results[3].TrustDomain)
+			require.Equal(t, int32(codes.InvalidArgument), resp.Results[3].Status.Code)
+
+			require.Equal(t, tt.expectDeleted, test.fetchBundle(t, "spiffe://another-example.org") == nil)
+
+			fetchResp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+				EntryId: entryResp.Entry.EntryId,
+			})
+			require.NoError(t, err)
+			if !tt.expectEntry {
+				require.Nil(t, fetchResp.Entry)
+				return
+			}
+			require.NotNil(t, fetchResp.Entry)
+			require.ElementsMatch(t, tt.expectFederatesWith, fetchResp.Entry.FederatesWith)
+		})
+	}
+}
+
+func TestBatchDeleteFederatedBundleInvalidMode(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	resp, err := test.client.BatchDeleteFederatedBundle(ctx, &bundlepb.BatchDeleteFederatedBundleRequest{
+		TrustDomains: []string{"another-example.org"},
+		Mode:         bundlepb.BatchDeleteFederatedBundleRequest_Mode(42),
+	})
+	spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, "invalid delete mode")
+	require.Nil(t, resp)
+}
+
+func newJWTKey(t *testing.T, keyID string) *types.JWTKey {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testkey.NewEC256(t).Public())
+	require.NoError(t, err)
+	return &types.JWTKey{
+		PublicKey: pkixBytes,
+		KeyId:     keyID,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newFederatedBundle(t *testing.T, td string) *types.Bundle {
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	return &types.Bundle{
+		TrustDomain:     td,
+		RefreshHint:     60,
+		X509Authorities: []*types.X509Certificate{{Asn1: caCert.Raw}},
+		JwtAuthorities:  []*types.JWTKey{newJWTKey(t, fmt.Sprintf("key-id-%s", td))},
+	}
+}
+
 func createBundle(t *testing.T, test *serviceTest, td string) *common.Bundle {
 	b := &common.Bundle{
 		TrustDomainId: td,
@@ -397,14 +808,24 @@ func (c *serviceTest) setBundle(t *testing.T, b *common.Bundle) {
 	require.NoError(t, err)
 }
 
+func (c *serviceTest) fetchBundle(t *testing.T, trustDomainID string) *common.Bundle {
+	resp, err := c.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	require.NoError(t, err)
+	return resp.Bundle
+}
+
 type serviceTest struct {
-	client  bundlepb.BundleClient
-	ds      datastore.DataStore
-	logHook *test.Hook
-	done    func()
-	isAdmin bool
-	isAgent bool
-	isLocal bool
+	client      bundlepb.BundleClient
+	ds          datastore.DataStore
+	logHook     *test.Hook
+	done        func()
+	rateLimiter *fakeRateLimiter
+	publishErr  error
+	isAdmin     bool
+	isAgent     bool
+	isLocal     bool
 }
 
 func (c *serviceTest) Cleanup() {
@@ -413,23 +834,31 @@ func (c *serviceTest) Cleanup() {
 
 func setupServiceTest(t *testing.T) *serviceTest {
 	ds := fakedatastore.New()
+	log, logHook := test.NewNullLogger()
+	test := &serviceTest{
+		ds:          ds,
+		logHook:     logHook,
+		rateLimiter: &fakeRateLimiter{},
+	}
+
 	service := bundle.New(bundle.Config{
 		Datastore:   ds,
 		TrustDomain: trustDomain,
+		UpstreamPublisher: bundle.UpstreamPublisherFunc(func(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+			if test.publishErr != nil {
+				return nil, test.publishErr
+			}
+			return append([]*common.PublicKey{tdBundle.JwtSigningKeys[0]}, jwtKey), nil
+		}),
 	})
 
-	log, logHook := test.NewNullLogger()
 	registerFn := func(s *grpc.Server) {
 		bundle.RegisterService(s, service)
 	}
 
-	test := &serviceTest{
-		ds:      ds,
-		logHook: logHook,
-	}
-
 	contextFn := func(ctx context.Context) context.Context {
 		ctx = rpccontext.WithLogger(ctx, log)
+		ctx = rpccontext.WithRateLimiter(ctx, test.rateLimiter)
 		if test.isAdmin {
 			ctx = rpccontext.WithCallerAdminEntries(ctx, []*types.Entry{{Admin: true}})
 		}
@@ -451,3 +880,11 @@ func setupServiceTest(t *testing.T) *serviceTest {
 
 	return test
 }
+
+type fakeRateLimiter struct {
+	err error
+}
+
+func (f *fakeRateLimiter) RateLimit(ctx context.Context, count int) error {
+	return f.err
+}
@@ -0,0 +1,99 @@
+package api_test
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoToBundle(t *testing.T) {
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testkey.NewEC256(t).Public())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		bundle *types.Bundle
+		expect *common.Bundle
+		err    string
+	}{
+		{
+			name: "success",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.org",
+				RefreshHint:     60,
+				X509Authorities: []*types.X509Certificate{{Asn1: caCert.Raw}},
+				JwtAuthorities: []*types.JWTKey{
+					{PublicKey: pkixBytes, KeyId: "key-id", ExpiresAt: 1590514224},
+				},
+			},
+			expect: &common.Bundle{
+				TrustDomainId: "spiffe://example.org",
+				RefreshHint:   60,
+				RootCas:       []*common.Certificate{{DerBytes: caCert.Raw}},
+				JwtSigningKeys: []*common.PublicKey{
+					{PkixBytes: pkixBytes, Kid: "key-id", NotAfter: 1590514224},
+				},
+			},
+		},
+		{
+			name: "missing bundle",
+			err:  "missing bundle",
+		},
+		{
+			name:   "invalid trust domain",
+			bundle: &types.Bundle{TrustDomain: "//not-valid"},
+			err:    "invalid trust domain:",
+		},
+		{
+			name: "malformed X.509 authority",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.org",
+				X509Authorities: []*types.X509Certificate{{Asn1: []byte("malformed")}},
+			},
+			err: "invalid X.509 authority:",
+		},
+		{
+			name: "JWT authority missing key ID",
+			bundle: &types.Bundle{
+				TrustDomain:    "example.org",
+				JwtAuthorities: []*types.JWTKey{{PublicKey: pkixBytes}},
+			},
+			err: "invalid JWT authority: missing key ID",
+		},
+		{
+			name: "malformed JWT authority",
+			bundle: &types.Bundle{
+				TrustDomain:    "example.org",
+				JwtAuthorities: []*types.JWTKey{{PublicKey: []byte("malformed"), KeyId: "key-id"}},
+			},
+			err: "invalid JWT authority:",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := api.ProtoToBundle(tt.bundle)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.Nil(t, bundle)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expect, bundle)
+		})
+	}
+}
+
+func TestParseJWTAuthority(t *testing.T) {
+	_, err := api.ParseJWTAuthority(nil)
+	require.EqualError(t, err, "missing JWT authority")
+}
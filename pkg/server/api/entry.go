@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// AuthorizedEntryFetcher fetches the entries an agent is authorized for.
+type AuthorizedEntryFetcher interface {
+	FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error)
+}
+
+// AuthorizedEntryFetcherFunc implements AuthorizedEntryFetcher with a function
+type AuthorizedEntryFetcherFunc func(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error)
+
+// FetchAuthorizedEntries fetches the entries an agent is authorized for.
+func (fn AuthorizedEntryFetcherFunc) FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
+	return fn(ctx, agentID)
+}
+
+// RegistrationEntriesToProto converts a slice of common.RegistrationEntry to
+// a slice of types.Entry
+func RegistrationEntriesToProto(es []*common.RegistrationEntry) ([]*types.Entry, error) {
+	if es == nil {
+		return nil, nil
+	}
+	pbs := make([]*types.Entry, 0, len(es))
+	for _, e := range es {
+		pb, err := RegistrationEntryToProto(e)
+		if err != nil {
+			return nil, err
+		}
+		pbs = append(pbs, pb)
+	}
+	return pbs, nil
+}
+
+// RegistrationEntryToProto converts a common.RegistrationEntry to a
+// types.Entry
+func RegistrationEntryToProto(e *common.RegistrationEntry) (*types.Entry, error) {
+	if e == nil {
+		return nil, errors.New("missing registration entry")
+	}
+
+	spiffeID, err := spiffeid.FromString(e.SpiffeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %v", err)
+	}
+
+	parentID, err := spiffeid.FromString(e.ParentId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %v", err)
+	}
+
+	var federatesWith []string
+	if len(e.FederatesWith) > 0 {
+		federatesWith = make([]string, 0, len(e.FederatesWith))
+		for _, trustDomainID := range e.FederatesWith {
+			td, err := spiffeid.TrustDomainFromString(trustDomainID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid federated trust domain: %v", err)
+			}
+			federatesWith = append(federatesWith, td.String())
+		}
+	}
+
+	return &types.Entry{
+		Id:              e.EntryId,
+		SpiffeId:        ProtoFromID(spiffeID),
+		ParentId:        ProtoFromID(parentID),
+		Selectors:       ProtoFromSelectors(e.Selectors),
+		Ttl:             e.Ttl,
+		FederatesWith:   federatesWith,
+		Admin:           e.Admin,
+		Downstream:      e.Downstream,
+		ExpiresAt:       e.EntryExpiry,
+		DnsNames:        append([]string(nil), e.DnsNames...),
+		RevisionNumber:  e.RevisionNumber,
+		X509SvidProfile: ProtoFromX509SVIDProfile(e.X509SvidProfile),
+		JwtSvidTtl:      e.JwtSvidTtl,
+	}, nil
+}
+
+// ProtoToRegistrationEntry converts and validates a types.Entry and returns
+// the equivalent common.RegistrationEntry
+func ProtoToRegistrationEntry(e *types.Entry) (*common.RegistrationEntry, error) {
+	if e == nil {
+		return nil, errors.New("missing entry")
+	}
+
+	spiffeID, err := IDFromProto(e.SpiffeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %v", err)
+	}
+
+	parentID, err := IDFromProto(e.ParentId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %v", err)
+	}
+
+	var federatesWith []string
+	if len(e.FederatesWith) > 0 {
+		federatesWith = make([]string, 0, len(e.FederatesWith))
+		for _, trustDomainName := range e.FederatesWith {
+			td, err := spiffeid.TrustDomainFromString(trustDomainName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid federated trust domain: %v", err)
+			}
+			federatesWith = append(federatesWith, td.IDString())
+		}
+	}
+
+	if len(e.Selectors) == 0 {
+		return nil, errors.New("selector list is empty")
+	}
+
+	selectors, err := SelectorsFromProto(e.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dnsName := range e.DnsNames {
+		if err := x509util.ValidateDNS(dnsName); err != nil {
+			return nil, fmt.Errorf("invalid DNS name: %v", err)
+		}
+	}
+
+	if e.Ttl < 0 {
+		return nil, errors.New("invalid TTL: value must be zero or positive")
+	}
+
+	if e.JwtSvidTtl < 0 {
+		return nil, errors.New("invalid JWT-SVID TTL: value must be zero or positive")
+	}
+
+	x509SVIDProfile := X509SVIDProfileFromProto(e.X509SvidProfile)
+	if _, err := x509util.ParseSVIDProfile(x509SVIDProfile); err != nil {
+		return nil, fmt.Errorf("invalid X509-SVID profile: %v", err)
+	}
+
+	return &common.RegistrationEntry{
+		EntryId:         e.Id,
+		ParentId:        parentID.String(),
+		SpiffeId:        spiffeID.String(),
+		Admin:           e.Admin,
+		Downstream:      e.Downstream,
+		EntryExpiry:     e.ExpiresAt,
+		DnsNames:        append([]string(nil), e.DnsNames...),
+		FederatesWith:   federatesWith,
+		Selectors:       selectors,
+		Ttl:             e.Ttl,
+		X509SvidProfile: x509SVIDProfile,
+		JwtSvidTtl:      e.JwtSvidTtl,
+	}, nil
+}
+
+// ProtoFromX509SVIDProfile converts a common.X509SVIDProfile to a
+// types.X509SVIDProfile
+func ProtoFromX509SVIDProfile(p *common.X509SVIDProfile) *types.X509SVIDProfile {
+	if p == nil {
+		return nil
+	}
+
+	pb := &types.X509SVIDProfile{
+		IpAddresses:  append([]string(nil), p.IpAddresses...),
+		Uris:         append([]string(nil), p.Uris...),
+		ExtKeyUsages: append([]string(nil), p.ExtKeyUsages...),
+	}
+	if subject := p.Subject; subject != nil {
+		pb.Subject = &types.X509Subject{
+			Country:            append([]string(nil), subject.Country...),
+			Organization:       append([]string(nil), subject.Organization...),
+			OrganizationalUnit: append([]string(nil), subject.OrganizationalUnit...),
+			CommonName:         subject.CommonName,
+		}
+	}
+	return pb
+}
+
+// X509SVIDProfileFromProto converts a types.X509SVIDProfile to a
+// common.X509SVIDProfile
+func X509SVIDProfileFromProto(pb *types.X509SVIDProfile) *common.X509SVIDProfile {
+	if pb == nil {
+		return nil
+	}
+
+	p := &common.X509SVIDProfile{
+		IpAddresses:  append([]string(nil), pb.IpAddresses...),
+		Uris:         append([]string(nil), pb.Uris...),
+		ExtKeyUsages: append([]string(nil), pb.ExtKeyUsages...),
+	}
+	if subject := pb.Subject; subject != nil {
+		p.Subject = &common.X509Subject{
+			Country:            append([]string(nil), subject.Country...),
+			Organization:       append([]string(nil), subject.Organization...),
+			OrganizationalUnit: append([]string(nil), subject.OrganizationalUnit...),
+			CommonName:         subject.CommonName,
+		}
+	}
+	return p
+}
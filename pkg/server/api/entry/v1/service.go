@@ -2,10 +2,20 @@ package entry
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire-next/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,46 +28,595 @@ func RegisterService(s *grpc.Server, service *Service) {
 
 // Config is the service configuration
 type Config struct {
-	Datastore datastore.DataStore
+	Datastore    datastore.DataStore
+	EntryFetcher api.AuthorizedEntryFetcher
+	TrustDomain  spiffeid.TrustDomain
 }
 
 // New creates a new entry service
 func New(config Config) *Service {
 	return &Service{
 		ds: config.Datastore,
+		ef: config.EntryFetcher,
+		td: config.TrustDomain,
 	}
 }
 
 // Service implements the v1 entry service
 type Service struct {
 	ds datastore.DataStore
+	ef api.AuthorizedEntryFetcher
+	td spiffeid.TrustDomain
 }
 
 func (s *Service) ListEntries(ctx context.Context, req *entry.ListEntriesRequest) (*entry.ListEntriesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListEntries not implemented")
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListRegistrationEntriesRequest{}
+
+	// Set pagination parameters
+	if req.PageSize > 0 {
+		listReq.Pagination = &datastore.Pagination{
+			PageSize: req.PageSize,
+			Token:    req.PageToken,
+		}
+	}
+
+	if req.Filter != nil {
+		if req.Filter.BySpiffeId != nil {
+			id, err := api.IDFromProto(req.Filter.BySpiffeId)
+			if err != nil {
+				log.WithError(err).Error("Invalid request: malformed SPIFFE ID filter")
+				return nil, status.Errorf(codes.InvalidArgument, "malformed SPIFFE ID filter: %v", err)
+			}
+			listReq.BySpiffeId = &wrappers.StringValue{
+				Value: id.String(),
+			}
+		}
+
+		if req.Filter.ByParentId != nil {
+			id, err := api.IDFromProto(req.Filter.ByParentId)
+			if err != nil {
+				log.WithError(err).Error("Invalid request: malformed parent ID filter")
+				return nil, status.Errorf(codes.InvalidArgument, "malformed parent ID filter: %v", err)
+			}
+			listReq.ByParentId = &wrappers.StringValue{
+				Value: id.String(),
+			}
+		}
+
+		if req.Filter.BySelectors != nil {
+			bySelectors, err := api.BySelectorsFromProto(req.Filter.BySelectors)
+			if err != nil {
+				log.WithError(err).Error("Invalid request: malformed selectors filter")
+				return nil, status.Errorf(codes.InvalidArgument, "malformed selectors filter: %v", err)
+			}
+			listReq.BySelectors = bySelectors
+		}
+
+		if len(req.Filter.ByFederatesWith) > 0 {
+			byFederatesWith := &datastore.ByFederatesWith{}
+			for _, trustDomainName := range req.Filter.ByFederatesWith {
+				td, err := spiffeid.TrustDomainFromString(trustDomainName)
+				if err != nil {
+					log.WithError(err).Error("Invalid request: malformed federates with filter")
+					return nil, status.Errorf(codes.InvalidArgument, "malformed federates with filter: %v", err)
+				}
+				byFederatesWith.TrustDomains = append(byFederatesWith.TrustDomains, td.IDString())
+			}
+			listReq.ByFederatesWith = byFederatesWith
+		}
+	}
+
+	dsResp, err := s.ds.ListRegistrationEntries(ctx, listReq)
+	if err != nil {
+		log.WithError(err).Error("Failed to list entries")
+		return nil, status.Errorf(codes.Internal, "failed to list entries: %v", err)
+	}
+
+	resp := &entry.ListEntriesResponse{}
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+
+	for _, regEntry := range dsResp.Entries {
+		// Entries outside of the caller scope are left out of the page
+		if !api.IDStringInCallerScope(ctx, regEntry.SpiffeId) {
+			continue
+		}
+		e, err := api.RegistrationEntryToProto(regEntry)
+		if err != nil {
+			log.WithError(err).WithField(telemetry.RegistrationID, regEntry.EntryId).Error("Failed to convert entry")
+			return nil, status.Errorf(codes.Internal, "failed to convert entry %q: %v", regEntry.EntryId, err)
+		}
+		applyMask(e, req.OutputMask)
+		resp.Entries = append(resp.Entries, e)
+	}
+
+	return resp, nil
 }
 
 func (s *Service) GetEntry(ctx context.Context, req *entry.GetEntryRequest) (*types.Entry, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetEntry not implemented")
+	log := rpccontext.Logger(ctx)
+
+	if req.Id == "" {
+		log.Error("Invalid request: missing ID")
+		return nil, status.Error(codes.InvalidArgument, "missing ID")
+	}
+	log = log.WithField(telemetry.RegistrationID, req.Id)
+
+	dsResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: req.Id,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch entry")
+		return nil, status.Errorf(codes.Internal, "failed to fetch entry: %v", err)
+	}
+
+	if dsResp.Entry == nil {
+		log.Error("Entry not found")
+		return nil, status.Error(codes.NotFound, "entry not found")
+	}
+
+	if !api.IDStringInCallerScope(ctx, dsResp.Entry.SpiffeId) {
+		log.Error("Permission denied: entry is outside of the caller scope")
+		return nil, status.Error(codes.PermissionDenied, "entry is outside of the caller scope")
+	}
+
+	e, err := api.RegistrationEntryToProto(dsResp.Entry)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert entry")
+		return nil, status.Errorf(codes.Internal, "failed to convert entry: %v", err)
+	}
+	applyMask(e, req.OutputMask)
+
+	return e, nil
 }
 
 func (s *Service) BatchCreateEntry(ctx context.Context, req *entry.BatchCreateEntryRequest) (*entry.BatchCreateEntryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method BatchCreateEntry not implemented")
+	resp := &entry.BatchCreateEntryResponse{}
+
+	for _, e := range req.Entries {
+		resp.Results = append(resp.Results, s.createEntry(ctx, e, req.OutputMask))
+	}
+
+	return resp, nil
 }
 
 func (s *Service) BatchUpdateEntry(ctx context.Context, req *entry.BatchUpdateEntryRequest) (*entry.BatchUpdateEntryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method BatchUpdateEntry not implemented")
+	resp := &entry.BatchUpdateEntryResponse{}
+
+	for _, e := range req.Entries {
+		resp.Results = append(resp.Results, s.updateEntry(ctx, e, req.InputMask, req.OutputMask))
+	}
+
+	return resp, nil
 }
 
 func (s *Service) BatchDeleteEntry(ctx context.Context, req *entry.BatchDeleteEntryRequest) (*entry.BatchDeleteEntryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method BatchDeleteEntry not implemented")
+	resp := &entry.BatchDeleteEntryResponse{}
+
+	for _, id := range req.Ids {
+		var expectedRevision *wrappers.Int64Value
+		if revision, ok := req.ExpectedRevisionNumbers[id]; ok {
+			expectedRevision = &wrappers.Int64Value{Value: revision}
+		}
+		resp.Results = append(resp.Results, s.deleteEntry(ctx, id, expectedRevision))
+	}
+
+	return resp, nil
 }
 
 func (s *Service) GetAuthorizedEntries(ctx context.Context, req *entry.GetAuthorizedEntriesRequest) (*entry.GetAuthorizedEntriesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetAuthorizedEntries not implemented")
+	log := rpccontext.Logger(ctx)
+
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		log.Error("Caller ID missing from request context")
+		return nil, status.Error(codes.Internal, "caller ID missing from request context")
+	}
+
+	entries, err := s.ef.FetchAuthorizedEntries(ctx, callerID)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch entries")
+		return nil, status.Errorf(codes.Internal, "failed to fetch entries: %v", err)
+	}
+
+	resp := &entry.GetAuthorizedEntriesResponse{}
+	for _, e := range entries {
+		if id, err := api.IDFromProto(e.SpiffeId); err != nil || !api.IDInCallerScope(ctx, id) {
+			continue
+		}
+		e = proto.Clone(e).(*types.Entry)
+		applyMask(e, req.OutputMask)
+		resp.Entries = append(resp.Entries, e)
+	}
+
+	return resp, nil
+}
+
+// createEntry creates a single entry and returns its result
+func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask) *entry.BatchCreateEntryResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	regEntry, err := s.protoToRegistrationEntry(e)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert entry")
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert entry: %v", err),
+		}
+	}
+	// The entry ID is output only
+	regEntry.EntryId = ""
+
+	log = log.WithField(telemetry.SPIFFEID, regEntry.SpiffeId)
+
+	if err := checkEntryInCallerScope(ctx, regEntry); err != nil {
+		log.WithError(err).Error("Permission denied: entry is not allowed by the caller scope")
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.PermissionDenied, "%v", err),
+		}
+	}
+
+	existingEntry, err := s.getExistingEntry(ctx, regEntry)
+	if err != nil {
+		log.WithError(err).Error("Failed to list similar entries")
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to list similar entries: %v", err),
+		}
+	}
+
+	resultStatus := api.CreateStatus(codes.OK, "OK")
+	if existingEntry != nil {
+		resultStatus = api.CreateStatus(codes.AlreadyExists, "similar entry already exists")
+		regEntry = existingEntry
+	} else {
+		dsResp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: regEntry,
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to create entry")
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.CreateStatus(codes.Internal, "failed to create entry: %v", err),
+			}
+		}
+		regEntry = dsResp.Entry
+	}
+
+	tEntry, err := api.RegistrationEntryToProto(regEntry)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert entry")
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert entry: %v", err),
+		}
+	}
+	applyMask(tEntry, outputMask)
+
+	return &entry.BatchCreateEntryResponse_Result{
+		Status: resultStatus,
+		Entry:  tEntry,
+	}
 }
 
-func applyMask(e *types.Entry, mask *types.EntryMask) { //nolint: unused,deadcode
+// updateEntry updates a single entry and returns its result
+func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask, outputMask *types.EntryMask) *entry.BatchUpdateEntryResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	if e == nil || e.Id == "" {
+		log.Error("Invalid argument: missing entry ID")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "missing entry ID"),
+		}
+	}
+	log = log.WithField(telemetry.RegistrationID, e.Id)
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: e.Id,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch entry")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to fetch entry: %v", err),
+		}
+	}
+	if fetchResp.Entry == nil {
+		log.Error("Entry not found")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.NotFound, "entry not found"),
+		}
+	}
+	if !api.IDStringInCallerScope(ctx, fetchResp.Entry.SpiffeId) {
+		log.Error("Permission denied: entry is outside of the caller scope")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.PermissionDenied, "entry is outside of the caller scope"),
+		}
+	}
+
+	current, err := api.RegistrationEntryToProto(fetchResp.Entry)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert entry")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert entry: %v", err),
+		}
+	}
+	mergeEntry(current, e, inputMask)
+
+	regEntry, err := s.protoToRegistrationEntry(current)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert entry")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert entry: %v", err),
+		}
+	}
+
+	// The entry cannot be moved out of the caller scope either
+	if err := checkEntryInCallerScope(ctx, regEntry); err != nil {
+		log.WithError(err).Error("Permission denied: updated entry is not allowed by the caller scope")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.PermissionDenied, "updated %v", err),
+		}
+	}
+
+	existingEntry, err := s.getExistingEntry(ctx, regEntry)
+	if err != nil {
+		log.WithError(err).Error("Failed to list similar entries")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to list similar entries: %v", err),
+		}
+	}
+	if existingEntry != nil {
+		log.Error("Similar entry already exists")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.AlreadyExists, "similar entry already exists"),
+		}
+	}
+
+	// The revision number is not updated but checked, when requested
+	var expectedRevision *wrappers.Int64Value
+	if inputMask != nil && inputMask.RevisionNumber {
+		expectedRevision = &wrappers.Int64Value{Value: e.RevisionNumber}
+	}
+
+	dsResp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:                  regEntry,
+		ExpectedRevisionNumber: expectedRevision,
+		InputMask:              registrationEntryMask(inputMask),
+	})
+	if status.Code(err) == codes.Aborted {
+		log.WithError(err).Error("Entry revision conflict")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Aborted, "failed to update entry: %v", status.Convert(err).Message()),
+		}
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to update entry")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to update entry: %v", err),
+		}
+	}
+
+	tEntry, err := api.RegistrationEntryToProto(dsResp.Entry)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert entry")
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert entry: %v", err),
+		}
+	}
+	applyMask(tEntry, outputMask)
+
+	return &entry.BatchUpdateEntryResponse_Result{
+		Status: api.CreateStatus(codes.OK, "OK"),
+		Entry:  tEntry,
+	}
+}
+
+// deleteEntry deletes a single entry and returns its result
+func (s *Service) deleteEntry(ctx context.Context, id string, expectedRevision *wrappers.Int64Value) *entry.BatchDeleteEntryResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	if id == "" {
+		log.Error("Invalid argument: missing entry ID")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "missing entry ID"),
+		}
+	}
+	log = log.WithField(telemetry.RegistrationID, id)
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: id,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch entry")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Id:     id,
+			Status: api.CreateStatus(codes.Internal, "failed to fetch entry: %v", err),
+		}
+	}
+	if fetchResp.Entry == nil {
+		log.Error("Entry not found")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Id:     id,
+			Status: api.CreateStatus(codes.NotFound, "entry not found"),
+		}
+	}
+	if !api.IDStringInCallerScope(ctx, fetchResp.Entry.SpiffeId) {
+		log.Error("Permission denied: entry is outside of the caller scope")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Id:     id,
+			Status: api.CreateStatus(codes.PermissionDenied, "entry is outside of the caller scope"),
+		}
+	}
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                id,
+		ExpectedRevisionNumber: expectedRevision,
+	})
+	if status.Code(err) == codes.Aborted {
+		log.WithError(err).Error("Entry revision conflict")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Id:     id,
+			Status: api.CreateStatus(codes.Aborted, "failed to delete entry: %v", status.Convert(err).Message()),
+		}
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to delete entry")
+		return &entry.BatchDeleteEntryResponse_Result{
+			Id:     id,
+			Status: api.CreateStatus(codes.Internal, "failed to delete entry: %v", err),
+		}
+	}
+
+	return &entry.BatchDeleteEntryResponse_Result{
+		Id:     id,
+		Status: api.CreateStatus(codes.OK, "OK"),
+	}
+}
+
+// protoToRegistrationEntry converts the entry and validates that the IDs
+// belong to the server trust domain.
+func (s *Service) protoToRegistrationEntry(e *types.Entry) (*common.RegistrationEntry, error) {
+	regEntry, err := api.ProtoToRegistrationEntry(e)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := spiffeid.FromString(regEntry.ParentId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent ID: %v", err)
+	}
+	if !parentID.MemberOf(s.td) {
+		return nil, fmt.Errorf("invalid parent ID: %q does not belong to trust domain %q", parentID, s.td)
+	}
+
+	spiffeID, err := spiffeid.FromString(regEntry.SpiffeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %v", err)
+	}
+	if err := idutil.ValidateTrustDomainWorkload(spiffeID, s.td); err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID: %v", err)
+	}
+
+	return regEntry, nil
+}
+
+// getExistingEntry returns an entry, other than the given entry itself, with
+// the same SPIFFE ID, parent ID and selectors as the given entry, if any.
+func (s *Service) getExistingEntry(ctx context.Context, e *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySpiffeId: &wrappers.StringValue{
+			Value: e.SpiffeId,
+		},
+		ByParentId: &wrappers.StringValue{
+			Value: e.ParentId,
+		},
+		BySelectors: &datastore.BySelectors{
+			Match:     datastore.BySelectors_MATCH_EXACT,
+			Selectors: e.Selectors,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range resp.Entries {
+		if existing.EntryId != e.EntryId {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkEntryInCallerScope returns an error if a scoped caller is not allowed
+// to create or update the entry. Both the SPIFFE ID and the parent ID must be
+// in the caller scope, and the entry cannot be an admin or downstream entry,
+// since those grant privileges beyond the scope.
+func checkEntryInCallerScope(ctx context.Context, e *common.RegistrationEntry) error {
+	if _, ok := rpccontext.CallerScope(ctx); !ok {
+		return nil
+	}
+	switch {
+	case !api.IDStringInCallerScope(ctx, e.SpiffeId):
+		return errors.New("entry is outside of the caller scope")
+	case !api.IDStringInCallerScope(ctx, e.ParentId):
+		return errors.New("entry parent is outside of the caller scope")
+	case e.Admin:
+		return errors.New("entry is an admin entry, which is not allowed by the caller scope")
+	case e.Downstream:
+		return errors.New("entry is a downstream entry, which is not allowed by the caller scope")
+	}
+	return nil
+}
+
+// mergeEntry sets the fields of dst from src for every field enabled in the
+// mask. A nil mask updates every field.
+func mergeEntry(dst, src *types.Entry, mask *types.EntryMask) {
+	if mask == nil || mask.SpiffeId {
+		dst.SpiffeId = src.SpiffeId
+	}
+
+	if mask == nil || mask.ParentId {
+		dst.ParentId = src.ParentId
+	}
+
+	if mask == nil || mask.Selectors {
+		dst.Selectors = src.Selectors
+	}
+
+	if mask == nil || mask.Ttl {
+		dst.Ttl = src.Ttl
+	}
+
+	if mask == nil || mask.FederatesWith {
+		dst.FederatesWith = src.FederatesWith
+	}
+
+	if mask == nil || mask.Admin {
+		dst.Admin = src.Admin
+	}
+
+	if mask == nil || mask.Downstream {
+		dst.Downstream = src.Downstream
+	}
+
+	if mask == nil || mask.ExpiresAt {
+		dst.ExpiresAt = src.ExpiresAt
+	}
+
+	if mask == nil || mask.DnsNames {
+		dst.DnsNames = src.DnsNames
+	}
+
+	if mask == nil || mask.X509SvidProfile {
+		dst.X509SvidProfile = src.X509SvidProfile
+	}
+
+	if mask == nil || mask.JwtSvidTtl {
+		dst.JwtSvidTtl = src.JwtSvidTtl
+	}
+}
+
+// registrationEntryMask converts the input mask so that the datastore only
+// updates the fields in it, leaving concurrent changes to other fields intact.
+func registrationEntryMask(mask *types.EntryMask) *common.RegistrationEntryMask {
+	if mask == nil {
+		return nil
+	}
+	return &common.RegistrationEntryMask{
+		Selectors:       mask.Selectors,
+		ParentId:        mask.ParentId,
+		SpiffeId:        mask.SpiffeId,
+		Ttl:             mask.Ttl,
+		FederatesWith:   mask.FederatesWith,
+		Admin:           mask.Admin,
+		Downstream:      mask.Downstream,
+		EntryExpiry:     mask.ExpiresAt,
+		DnsNames:        mask.DnsNames,
+		X509SvidProfile: mask.X509SvidProfile,
+		JwtSvidTtl:      mask.JwtSvidTtl,
+	}
+}
+
+func applyMask(e *types.Entry, mask *types.EntryMask) {
 	if mask == nil {
 		return
 	}
@@ -101,4 +660,16 @@ func applyMask(e *types.Entry, mask *types.EntryMask) { //nolint: unused,deadcod
 	if !mask.DnsNames {
 		e.DnsNames = nil
 	}
+
+	if !mask.X509SvidProfile {
+		e.X509SvidProfile = nil
+	}
+
+	if !mask.JwtSvidTtl {
+		e.JwtSvidTtl = 0
+	}
+
+	if !mask.RevisionNumber {
+		e.RevisionNumber = 0
+	}
 }
@@ -2,32 +2,877 @@ package entry_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/entry/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	entrypb "github.com/spiffe/spire/proto/spire-next/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
-type serviceTest struct { //nolint: unused,deadcode
-	client  entrypb.EntryClient
-	done    func()
-	ds      *fakedatastore.DataStore
-	logHook *test.Hook
+var (
+	ctx         = context.Background()
+	trustDomain = spiffeid.RequireTrustDomainFromString("example.org")
+	agentID     = spiffeid.Must("example.org", "spire", "agent", "foo")
+)
+
+func TestListEntries(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	entry1 := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:      agentID.String(),
+		SpiffeId:      "spiffe://example.org/workload1",
+		Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		FederatesWith: []string{"spiffe://domain1.org"},
+	})
+	entry2 := test.createEntry(t, &common.RegistrationEntry{
+		ParentId: agentID.String(),
+		SpiffeId: "spiffe://example.org/workload2",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "gid:1000"},
+			{Type: "unix", Value: "uid:1000"},
+		},
+		FederatesWith: []string{"spiffe://domain1.org", "spiffe://domain2.org"},
+	})
+	entry3 := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/agent/bar",
+		SpiffeId:  "spiffe://example.org/workload3",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	})
+
+	for _, tt := range []struct {
+		name          string
+		request       *entrypb.ListEntriesRequest
+		expectEntries []*common.RegistrationEntry
+		expectToken   string
+		expectCode    codes.Code
+		expectMsg     string
+	}{
+		{
+			name:          "all entries",
+			request:       &entrypb.ListEntriesRequest{},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2, entry3},
+		},
+		{
+			name: "filter by SPIFFE ID",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					BySpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload3"},
+				},
+			},
+			expectEntries: []*common.RegistrationEntry{entry3},
+		},
+		{
+			name: "filter by parent ID",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByParentId: api.ProtoFromID(agentID),
+				},
+			},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name: "filter by exact selectors",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					BySelectors: &types.SelectorMatch{
+						Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+						Match:     types.SelectorMatch_MATCH_EXACT,
+					},
+				},
+			},
+			expectEntries: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name: "filter by subset selectors",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					BySelectors: &types.SelectorMatch{
+						Selectors: []*types.Selector{
+							{Type: "unix", Value: "uid:1000"},
+							{Type: "unix", Value: "gid:1000"},
+						},
+						Match: types.SelectorMatch_MATCH_SUBSET,
+					},
+				},
+			},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name: "filter by federates with",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByFederatesWith: []string{"domain1.org", "domain2.org"},
+				},
+			},
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "filter by federates with paged",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByFederatesWith: []string{"domain2.org"},
+				},
+				PageSize: 1,
+			},
+			expectEntries: []*common.RegistrationEntry{entry2},
+			expectToken:   entry2.EntryId,
+		},
+		{
+			name: "first page",
+			request: &entrypb.ListEntriesRequest{
+				PageSize: 2,
+			},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+			expectToken:   entry2.EntryId,
+		},
+		{
+			name: "last page",
+			request: &entrypb.ListEntriesRequest{
+				PageSize:  2,
+				PageToken: entry2.EntryId,
+			},
+			expectEntries: []*common.RegistrationEntry{entry3},
+			expectToken:   entry3.EntryId,
+		},
+		{
+			name: "malformed SPIFFE ID filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					BySpiffeId: &types.SPIFFEID{Path: "/workload"},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "malformed SPIFFE ID filter: spiffeid: trust domain is empty",
+		},
+		{
+			name: "malformed parent ID filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByParentId: &types.SPIFFEID{Path: "/agent"},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "malformed parent ID filter: spiffeid: trust domain is empty",
+		},
+		{
+			name: "empty selectors filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					BySelectors: &types.SelectorMatch{},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "malformed selectors filter: empty selector set",
+		},
+		{
+			name: "malformed federates with filter",
+			request: &entrypb.ListEntriesRequest{
+				Filter: &entrypb.ListEntriesRequest_Filter{
+					ByFederatesWith: []string{""},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "malformed federates with filter: spiffeid: trust domain is empty",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.ListEntries(ctx, tt.request)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectToken, resp.NextPageToken)
+			spiretest.RequireProtoListEqual(t, toProto(t, tt.expectEntries...), resp.Entries)
+		})
+	}
+}
+
+func TestListEntriesOutputMask(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	regEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Ttl:       60,
+	})
+
+	resp, err := test.client.ListEntries(ctx, &entrypb.ListEntriesRequest{
+		OutputMask: &types.EntryMask{
+			SpiffeId: true,
+		},
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*types.Entry{
+		{SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"}},
+	}, resp.Entries)
+
+	resp, err = test.client.ListEntries(ctx, &entrypb.ListEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, toProto(t, regEntry), resp.Entries)
+}
+
+func TestGetEntry(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	regEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		DnsNames:  []string{"dns1"},
+	})
+
+	for _, tt := range []struct {
+		name        string
+		id          string
+		outputMask  *types.EntryMask
+		expectEntry *types.Entry
+		expectCode  codes.Code
+		expectMsg   string
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:        "success",
+			id:          regEntry.EntryId,
+			expectEntry: toProto(t, regEntry)[0],
+		},
+		{
+			name: "success with output mask",
+			id:   regEntry.EntryId,
+			outputMask: &types.EntryMask{
+				Id:       true,
+				DnsNames: true,
+			},
+			expectEntry: &types.Entry{
+				Id:       regEntry.EntryId,
+				DnsNames: []string{"dns1"},
+			},
+		},
+		{
+			name:       "missing ID",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "missing ID",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid request: missing ID",
+				},
+			},
+		},
+		{
+			name:       "not found",
+			id:         "does-not-exist",
+			expectCode: codes.NotFound,
+			expectMsg:  "entry not found",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry not found",
+					Data: logrus.Fields{
+						telemetry.RegistrationID: "does-not-exist",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+
+			resp, err := test.client.GetEntry(ctx, &entrypb.GetEntryRequest{
+				Id:         tt.id,
+				OutputMask: tt.outputMask,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expectEntry, resp)
+		})
+	}
+}
+
+func TestBatchCreateEntry(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	existing := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/existing",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:            "ignored",
+				ParentId:      api.ProtoFromID(agentID),
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+				FederatesWith: []string{"domain1.org"},
+				Ttl:           60,
+			},
+			{
+				ParentId:  api.ProtoFromID(agentID),
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/existing"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+			{
+				ParentId:  api.ProtoFromID(agentID),
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "another.org", Path: "/workload"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+			{
+				ParentId:  api.ProtoFromID(agentID),
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				DnsNames:  []string{"abc-"},
+			},
+			{
+				ParentId:  &types.SPIFFEID{TrustDomain: "another.org", Path: "/spire/agent/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+		OutputMask: &types.EntryMask{
+			SpiffeId:      true,
+			FederatesWith: true,
+			Ttl:           true,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 5)
+
+	// Created
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &types.Entry{
+		SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		FederatesWith: []string{"domain1.org"},
+		Ttl:           60,
+	}, resp.Results[0].Entry)
+
+	// Already exists
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.AlreadyExists, "similar entry already exists"), resp.Results[1].Status)
+	spiretest.AssertProtoEqual(t, &types.Entry{
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/existing"},
+	}, resp.Results[1].Entry)
+
+	// Invalid
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument,
+		`failed to convert entry: invalid SPIFFE ID: "spiffe://another.org/workload" does not belong to trust domain "example.org"`),
+		resp.Results[2].Status)
+	require.Nil(t, resp.Results[2].Entry)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument,
+		"failed to convert entry: invalid DNS name: label does not match regex: abc-"),
+		resp.Results[3].Status)
+	require.Nil(t, resp.Results[3].Entry)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument,
+		`failed to convert entry: invalid parent ID: "spiffe://another.org/spire/agent/foo" does not belong to trust domain "example.org"`),
+		resp.Results[4].Status)
+	require.Nil(t, resp.Results[4].Entry)
+
+	// Only the new entry was created, and the requested ID was ignored
+	listResp, err := test.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Entries, 2)
+	for _, e := range listResp.Entries {
+		require.NotEqual(t, "ignored", e.EntryId)
+		if e.EntryId != existing.EntryId {
+			require.Equal(t, "spiffe://example.org/workload", e.SpiffeId)
+			require.Equal(t, []string{"spiffe://domain1.org"}, e.FederatesWith)
+		}
+	}
+}
+
+func TestBatchUpdateEntry(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	regEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Ttl:       60,
+	})
+
+	resp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:       regEntry.EntryId,
+				Ttl:      120,
+				DnsNames: []string{"dns1"},
+			},
+			{
+				Id:  "does-not-exist",
+				Ttl: 120,
+			},
+			{
+				Ttl: 120,
+			},
+		},
+		InputMask: &types.EntryMask{
+			Ttl:      true,
+			DnsNames: true,
+		},
+		OutputMask: &types.EntryMask{
+			Id:        true,
+			Ttl:       true,
+			Selectors: true,
+			DnsNames:  true,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, &types.Entry{
+		Id:        regEntry.EntryId,
+		Ttl:       120,
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		DnsNames:  []string{"dns1"},
+	}, resp.Results[0].Entry)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.NotFound, "entry not found"), resp.Results[1].Status)
+	require.Nil(t, resp.Results[1].Entry)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument, "missing entry ID"), resp.Results[2].Status)
+	require.Nil(t, resp.Results[2].Entry)
+
+	// Updating an entry so it is similar to another entry fails
+	otherEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	})
+	resp, err = test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:        otherEntry.EntryId,
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+		InputMask: &types.EntryMask{
+			Selectors: true,
+		},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.AlreadyExists, "similar entry already exists"), resp.Results[0].Status)
+	require.Nil(t, resp.Results[0].Entry)
+
+	fetchResp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: otherEntry.EntryId,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, otherEntry, fetchResp.Entry)
+
+	// Without an input mask, every field is replaced and validated
+	resp, err = test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:  regEntry.EntryId,
+				Ttl: 120,
+			},
+		},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.InvalidArgument, "failed to convert entry: invalid SPIFFE ID: request must specify SPIFFE ID"), resp.Results[0].Status)
+
+	// The revision number is checked when it is in the input mask
+	resp, err = test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{Id: regEntry.EntryId, Ttl: 240, RevisionNumber: 0},
+			{Id: regEntry.EntryId, Ttl: 240, RevisionNumber: 1},
+		},
+		InputMask: &types.EntryMask{
+			Ttl:            true,
+			RevisionNumber: true,
+		},
+		OutputMask: &types.EntryMask{
+			Ttl:            true,
+			RevisionNumber: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.Aborted, "failed to update entry: registration entry revision number mismatch: expected 0, current 1"), resp.Results[0].Status)
+	require.Nil(t, resp.Results[0].Entry)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[1].Status)
+	spiretest.AssertProtoEqual(t, &types.Entry{Ttl: 240, RevisionNumber: 2}, resp.Results[1].Entry)
+}
+
+func TestBatchDeleteEntry(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	regEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	resp, err := test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+		Ids: []string{regEntry.EntryId, "does-not-exist", ""},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.BatchDeleteEntryResponse{
+		Results: []*entrypb.BatchDeleteEntryResponse_Result{
+			{
+				Id:     regEntry.EntryId,
+				Status: api.CreateStatus(codes.OK, "OK"),
+			},
+			{
+				Id:     "does-not-exist",
+				Status: api.CreateStatus(codes.NotFound, "entry not found"),
+			},
+			{
+				Status: api.CreateStatus(codes.InvalidArgument, "missing entry ID"),
+			},
+		},
+	}, resp)
+
+	fetchResp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: regEntry.EntryId,
+	})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Entry)
+
+	// Entries are not deleted unless they have the expected revision number
+	otherEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	})
+	resp, err = test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+		Ids:                     []string{otherEntry.EntryId},
+		ExpectedRevisionNumbers: map[string]int64{otherEntry.EntryId: 1},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.BatchDeleteEntryResponse{
+		Results: []*entrypb.BatchDeleteEntryResponse_Result{
+			{
+				Id:     otherEntry.EntryId,
+				Status: api.CreateStatus(codes.Aborted, "failed to delete entry: registration entry revision number mismatch: expected 1, current 0"),
+			},
+		},
+	}, resp)
+
+	resp, err = test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+		Ids:                     []string{otherEntry.EntryId},
+		ExpectedRevisionNumbers: map[string]int64{otherEntry.EntryId: 0},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+}
+
+func TestCallerScope(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	teamANodeID := spiffeid.Must("example.org", "team-a", "node")
+	teamAEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  teamANodeID.String(),
+		SpiffeId:  "spiffe://example.org/team-a/db",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	teamBEntry := test.createEntry(t, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  "spiffe://example.org/team-b/db",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	test.scope = []spiffeid.ID{spiffeid.Must("example.org", "team-a")}
+
+	t.Run("list", func(t *testing.T) {
+		resp, err := test.client.ListEntries(ctx, &entrypb.ListEntriesRequest{
+			OutputMask: &types.EntryMask{Id: true},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, &entrypb.ListEntriesResponse{
+			Entries: []*types.Entry{{Id: teamAEntry.EntryId}},
+		}, resp)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		_, err := test.client.GetEntry(ctx, &entrypb.GetEntryRequest{Id: teamAEntry.EntryId})
+		require.NoError(t, err)
+
+		_, err = test.client.GetEntry(ctx, &entrypb.GetEntryRequest{Id: teamBEntry.EntryId})
+		spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "entry is outside of the caller scope")
+	})
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+			Entries: []*types.Entry{
+				{
+					ParentId:  api.ProtoFromID(teamANodeID),
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/web"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+				},
+				{
+					ParentId:  api.ProtoFromID(teamANodeID),
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-b/web"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+				},
+				{
+					ParentId:  api.ProtoFromID(agentID),
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/api"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+				},
+				{
+					ParentId:  api.ProtoFromID(teamANodeID),
+					SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/admin"},
+					Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+					Admin:     true,
+				},
+				{
+					ParentId:   api.ProtoFromID(teamANodeID),
+					SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-a/downstream"},
+					Selectors:  []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+					Downstream: true,
+				},
+			},
+			OutputMask: &types.EntryMask{},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 5)
+		require.Equal(t, int32(codes.OK), resp.Results[0].Status.Code)
+		spiretest.AssertProtoEqual(t, api.CreateStatus(codes.PermissionDenied, "entry is outside of the caller scope"), resp.Results[1].Status)
+		spiretest.AssertProtoEqual(t, api.CreateStatus(codes.PermissionDenied, "entry parent is outside of the caller scope"), resp.Results[2].Status)
+		spiretest.AssertProtoEqual(t, api.CreateStatus(codes.PermissionDenied, "entry is an admin entry, which is not allowed by the caller scope"), resp.Results[3].Status)
+		spiretest.AssertProtoEqual(t, api.CreateStatus(codes.PermissionDenied, "entry is a downstream entry, which is not allowed by the caller scope"), resp.Results[4].Status)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: teamBEntry.EntryId, Ttl: 60},
+				{Id: teamAEntry.EntryId, SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/team-b/db2"}},
+			},
+			InputMask:  &types.EntryMask{Ttl: true, SpiffeId: true},
+			OutputMask: &types.EntryMask{},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, &entrypb.BatchUpdateEntryResponse{
+			Results: []*entrypb.BatchUpdateEntryResponse_Result{
+				{Status: api.CreateStatus(codes.PermissionDenied, "entry is outside of the caller scope")},
+				{Status: api.CreateStatus(codes.PermissionDenied, "updated entry is outside of the caller scope")},
+			},
+		}, resp)
+
+		resp, err = test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+			Entries:    []*types.Entry{{Id: teamAEntry.EntryId, Ttl: 60}},
+			InputMask:  &types.EntryMask{Ttl: true},
+			OutputMask: &types.EntryMask{},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+
+		for _, tt := range []struct {
+			entry     *types.Entry
+			mask      *types.EntryMask
+			expectMsg string
+		}{
+			{
+				entry:     &types.Entry{Id: teamAEntry.EntryId, ParentId: api.ProtoFromID(agentID)},
+				mask:      &types.EntryMask{ParentId: true},
+				expectMsg: "updated entry parent is outside of the caller scope",
+			},
+			{
+				entry:     &types.Entry{Id: teamAEntry.EntryId, Admin: true},
+				mask:      &types.EntryMask{Admin: true},
+				expectMsg: "updated entry is an admin entry, which is not allowed by the caller scope",
+			},
+			{
+				entry:     &types.Entry{Id: teamAEntry.EntryId, Downstream: true},
+				mask:      &types.EntryMask{Downstream: true},
+				expectMsg: "updated entry is a downstream entry, which is not allowed by the caller scope",
+			},
+		} {
+			resp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+				Entries:    []*types.Entry{tt.entry},
+				InputMask:  tt.mask,
+				OutputMask: &types.EntryMask{},
+			})
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, &entrypb.BatchUpdateEntryResponse{
+				Results: []*entrypb.BatchUpdateEntryResponse_Result{
+					{Status: api.CreateStatus(codes.PermissionDenied, tt.expectMsg)},
+				},
+			}, resp)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := test.client.BatchDeleteEntry(ctx, &entrypb.BatchDeleteEntryRequest{
+			Ids: []string{teamBEntry.EntryId},
+		})
+		require.NoError(t, err)
+		spiretest.AssertProtoEqual(t, &entrypb.BatchDeleteEntryResponse{
+			Results: []*entrypb.BatchDeleteEntryResponse_Result{
+				{
+					Id:     teamBEntry.EntryId,
+					Status: api.CreateStatus(codes.PermissionDenied, "entry is outside of the caller scope"),
+				},
+			},
+		}, resp)
+	})
+}
+
+func TestGetAuthorizedEntries(t *testing.T) {
+	entry1 := &types.Entry{
+		Id:        "entry-1",
+		ParentId:  api.ProtoFromID(agentID),
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload1"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	entry2 := &types.Entry{
+		Id:        "entry-2",
+		ParentId:  api.ProtoFromID(agentID),
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload2"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		noCallerID    bool
+		fetcherErr    error
+		outputMask    *types.EntryMask
+		expectEntries []*types.Entry
+		expectCode    codes.Code
+		expectMsg     string
+	}{
+		{
+			name:          "success",
+			expectEntries: []*types.Entry{entry1, entry2},
+		},
+		{
+			name:       "success with output mask",
+			outputMask: &types.EntryMask{Id: true},
+			expectEntries: []*types.Entry{
+				{Id: "entry-1"},
+				{Id: "entry-2"},
+			},
+		},
+		{
+			name:       "no caller ID",
+			noCallerID: true,
+			expectCode: codes.Internal,
+			expectMsg:  "caller ID missing from request context",
+		},
+		{
+			name:       "fetcher fails",
+			fetcherErr: errors.New("ohno"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch entries: ohno",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.noCallerID = tt.noCallerID
+			test.ef.err = tt.fetcherErr
+			test.ef.entries = []*types.Entry{entry1, entry2}
+
+			resp, err := test.client.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{
+				OutputMask: tt.outputMask,
+			})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, &entrypb.GetAuthorizedEntriesResponse{
+				Entries: tt.expectEntries,
+			}, resp)
+
+			// The fetched entries are not modified by the mask
+			require.Equal(t, []*types.Entry{entry1, entry2}, test.ef.entries)
+		})
+	}
+}
+
+func toProto(t *testing.T, entries ...*common.RegistrationEntry) []*types.Entry {
+	out, err := api.RegistrationEntriesToProto(entries)
+	require.NoError(t, err)
+	return out
+}
+
+type entryFetcher struct {
+	entries []*types.Entry
+	err     error
+}
+
+func (f *entryFetcher) FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.entries, nil
+}
+
+type serviceTest struct {
+	client     entrypb.EntryClient
+	done       func()
+	ds         *fakedatastore.DataStore
+	ef         *entryFetcher
+	logHook    *test.Hook
+	noCallerID bool
+	scope      []spiffeid.ID
 }
 
 func (c *serviceTest) Cleanup() {
 	c.done()
 }
 
-func setupServiceTest(t *testing.T) *serviceTest { //nolint: unused,deadcode
+func (c *serviceTest) createEntry(t *testing.T, e *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := c.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: e,
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
 	ds := fakedatastore.New()
+	// Entries can only federate with trust domains that have a bundle
+	for _, td := range []string{"spiffe://domain1.org", "spiffe://domain2.org"} {
+		_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: &common.Bundle{TrustDomainId: td},
+		})
+		require.NoError(t, err)
+	}
+	ef := &entryFetcher{}
 	service := entry.New(entry.Config{
-		Datastore: ds,
+		Datastore:    ds,
+		EntryFetcher: ef,
+		TrustDomain:  trustDomain,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -37,11 +882,18 @@ func setupServiceTest(t *testing.T) *serviceTest { //nolint: unused,deadcode
 
 	test := &serviceTest{
 		ds:      ds,
+		ef:      ef,
 		logHook: logHook,
 	}
 
 	contextFn := func(ctx context.Context) context.Context {
 		ctx = rpccontext.WithLogger(ctx, log)
+		if !test.noCallerID {
+			ctx = rpccontext.WithCallerID(ctx, agentID)
+		}
+		if test.scope != nil {
+			ctx = rpccontext.WithCallerScope(ctx, test.scope)
+		}
 		return ctx
 	}
 
@@ -0,0 +1,287 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationEntryToProto(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		entry       *common.RegistrationEntry
+		err         string
+		expectEntry *types.Entry
+	}{
+		{
+			name: "success",
+			entry: &common.RegistrationEntry{
+				EntryId:        "entry1",
+				ParentId:       "spiffe://example.org/foo",
+				SpiffeId:       "spiffe://example.org/bar",
+				FederatesWith:  []string{"spiffe://domain1.com", "spiffe://domain2.com"},
+				Admin:          true,
+				Downstream:     true,
+				Ttl:            60,
+				JwtSvidTtl:     30,
+				EntryExpiry:    1234,
+				DnsNames:       []string{"dns1", "dns2"},
+				RevisionNumber: 3,
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+			},
+			expectEntry: &types.Entry{
+				Id:             "entry1",
+				ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				FederatesWith:  []string{"domain1.com", "domain2.com"},
+				Admin:          true,
+				Downstream:     true,
+				Ttl:            60,
+				JwtSvidTtl:     30,
+				ExpiresAt:      1234,
+				DnsNames:       []string{"dns1", "dns2"},
+				RevisionNumber: 3,
+				Selectors: []*types.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+			},
+		},
+		{
+			name: "with X509-SVID profile",
+			entry: &common.RegistrationEntry{
+				EntryId:  "entry1",
+				ParentId: "spiffe://example.org/foo",
+				SpiffeId: "spiffe://example.org/bar",
+				X509SvidProfile: &common.X509SVIDProfile{
+					Subject:      &common.X509Subject{Organization: []string{"Acme"}, CommonName: "bar"},
+					IpAddresses:  []string{"10.0.0.1"},
+					Uris:         []string{"urn:acme:bar"},
+					ExtKeyUsages: []string{"clientAuth"},
+				},
+			},
+			expectEntry: &types.Entry{
+				Id:       "entry1",
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				X509SvidProfile: &types.X509SVIDProfile{
+					Subject:      &types.X509Subject{Organization: []string{"Acme"}, CommonName: "bar"},
+					IpAddresses:  []string{"10.0.0.1"},
+					Uris:         []string{"urn:acme:bar"},
+					ExtKeyUsages: []string{"clientAuth"},
+				},
+			},
+		},
+		{
+			name: "missing entry",
+			err:  "missing registration entry",
+		},
+		{
+			name: "malformed ParentId",
+			entry: &common.RegistrationEntry{
+				ParentId: "malformed ParentID",
+				SpiffeId: "spiffe://example.org/bar",
+			},
+			err: "invalid parent ID: spiffeid: invalid scheme",
+		},
+		{
+			name: "malformed SpiffeId",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/foo",
+				SpiffeId: "malformed SpiffeID",
+			},
+			err: "invalid SPIFFE ID: spiffeid: invalid scheme",
+		},
+		{
+			name: "malformed FederatesWith",
+			entry: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/foo",
+				SpiffeId:      "spiffe://example.org/bar",
+				FederatesWith: []string{""},
+			},
+			err: "invalid federated trust domain: spiffeid: trust domain is empty",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := api.RegistrationEntryToProto(tt.entry)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, entry)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectEntry, entry)
+		})
+	}
+}
+
+func TestProtoToRegistrationEntry(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		entry       *types.Entry
+		err         string
+		expectEntry *common.RegistrationEntry
+	}{
+		{
+			name: "success",
+			entry: &types.Entry{
+				Id:            "entry1",
+				ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				FederatesWith: []string{"domain1.com"},
+				Admin:         true,
+				Downstream:    true,
+				ExpiresAt:     1234,
+				DnsNames:      []string{"dns1"},
+				Ttl:           60,
+				JwtSvidTtl:    30,
+				Selectors: []*types.Selector{
+					{Type: "unix", Value: "uid:1000"},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{
+				EntryId:       "entry1",
+				ParentId:      "spiffe://example.org/foo",
+				SpiffeId:      "spiffe://example.org/bar",
+				FederatesWith: []string{"spiffe://domain1.com"},
+				Admin:         true,
+				Downstream:    true,
+				EntryExpiry:   1234,
+				DnsNames:      []string{"dns1"},
+				Ttl:           60,
+				JwtSvidTtl:    30,
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+				},
+			},
+		},
+		{
+			name: "with X509-SVID profile",
+			entry: &types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				X509SvidProfile: &types.X509SVIDProfile{
+					Subject:     &types.X509Subject{OrganizationalUnit: []string{"Legacy"}},
+					IpAddresses: []string{"10.0.0.1"},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/foo",
+				SpiffeId:  "spiffe://example.org/bar",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				X509SvidProfile: &common.X509SVIDProfile{
+					Subject:     &common.X509Subject{OrganizationalUnit: []string{"Legacy"}},
+					IpAddresses: []string{"10.0.0.1"},
+				},
+			},
+		},
+		{
+			name: "missing entry",
+			err:  "missing entry",
+		},
+		{
+			name: "missing SPIFFE ID",
+			entry: &types.Entry{
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+			},
+			err: "invalid SPIFFE ID: request must specify SPIFFE ID",
+		},
+		{
+			name: "missing parent ID",
+			entry: &types.Entry{
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+			},
+			err: "invalid parent ID: request must specify SPIFFE ID",
+		},
+		{
+			name: "malformed federates with",
+			entry: &types.Entry{
+				ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				FederatesWith: []string{""},
+			},
+			err: "invalid federated trust domain: spiffeid: trust domain is empty",
+		},
+		{
+			name: "missing selectors",
+			entry: &types.Entry{
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+			},
+			err: "selector list is empty",
+		},
+		{
+			name: "malformed selector",
+			entry: &types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Value: "uid:1000"}},
+			},
+			err: "missing selector type",
+		},
+		{
+			name: "malformed DNS name",
+			entry: &types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				DnsNames:  []string{"abc-"},
+			},
+			err: "invalid DNS name: label does not match regex: abc-",
+		},
+		{
+			name: "negative TTL",
+			entry: &types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Ttl:       -1,
+			},
+			err: "invalid TTL: value must be zero or positive",
+		},
+		{
+			name: "negative JWT-SVID TTL",
+			entry: &types.Entry{
+				ParentId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:  []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				JwtSvidTtl: -1,
+			},
+			err: "invalid JWT-SVID TTL: value must be zero or positive",
+		},
+		{
+			name: "malformed X509-SVID profile",
+			entry: &types.Entry{
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				X509SvidProfile: &types.X509SVIDProfile{
+					Uris: []string{"spiffe://example.org/other"},
+				},
+			},
+			err: `invalid X509-SVID profile: invalid URI "spiffe://example.org/other": SPIFFE IDs are not allowed`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := api.ProtoToRegistrationEntry(tt.entry)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, entry)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectEntry, entry)
+		})
+	}
+}
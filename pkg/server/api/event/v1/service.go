@@ -0,0 +1,185 @@
+package event
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/eventutil"
+	"github.com/spiffe/spire/proto/spire-next/api/server/event/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPollInterval is how often the datastore is polled for new
+	// change events
+	defaultPollInterval = time.Second
+
+	// listLimit is the maximum number of change events listed from the
+	// datastore at once
+	listLimit = 1000
+)
+
+// RegisterService registers the event service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	event.RegisterEventServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	Datastore    datastore.DataStore
+	Clock        clock.Clock
+	PollInterval time.Duration
+}
+
+// New creates a new event service
+func New(config Config) *Service {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	return &Service{
+		ds:           config.Datastore,
+		clk:          config.Clock,
+		pollInterval: config.PollInterval,
+	}
+}
+
+// Service implements the v1 event service
+type Service struct {
+	ds           datastore.DataStore
+	clk          clock.Clock
+	pollInterval time.Duration
+}
+
+func (s *Service) StreamEvents(req *event.StreamEventsRequest, stream event.Event_StreamEventsServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if req.AfterRevision < 0 {
+		log.Error("Invalid argument: negative after revision")
+		return status.Error(codes.InvalidArgument, "after revision cannot be negative")
+	}
+
+	resourceTypes := make(map[event.Event_ResourceType]bool)
+	for _, resourceType := range req.ResourceTypes {
+		if _, ok := event.Event_ResourceType_name[int32(resourceType)]; !ok {
+			log.Error("Invalid argument: unknown resource type")
+			return status.Errorf(codes.InvalidArgument, "unknown resource type %d", resourceType)
+		}
+		resourceTypes[resourceType] = true
+	}
+
+	afterRevision := req.AfterRevision
+	if afterRevision == 0 {
+		resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+			AfterRevision: math.MaxInt64,
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to list change events")
+			return status.Errorf(codes.Internal, "failed to list change events: %v", err)
+		}
+		afterRevision = resp.LatestRevision
+	}
+
+	// The cursor resume revision is sent to clients as the revision to
+	// resume the stream after, so that skipped events are not missed.
+	cursor := eventutil.NewCursor(afterRevision)
+	revision := cursor.ResumeRevision()
+	if err := stream.Send(&event.StreamEventsResponse{Revision: revision}); err != nil {
+		return err
+	}
+
+	ticker := s.clk.Ticker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		events, err := s.pollEvents(ctx, cursor, resourceTypes)
+		if err != nil {
+			log.WithError(err).Error("Failed to list change events")
+			return status.Errorf(codes.Internal, "failed to list change events: %v", err)
+		}
+
+		if len(events) == 0 && cursor.ResumeRevision() == revision {
+			continue
+		}
+		revision = cursor.ResumeRevision()
+		if err := stream.Send(&event.StreamEventsResponse{
+			Events:   events,
+			Revision: revision,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// pollEvents lists the change events that have not been delivered yet and
+// returns the ones with one of the requested resource types.
+func (s *Service) pollEvents(ctx context.Context, cursor *eventutil.Cursor, resourceTypes map[event.Event_ResourceType]bool) ([]*event.Event, error) {
+	now := s.clk.Now()
+	cursor.ExpireGaps(now)
+
+	var events []*event.Event
+	afterRevision := cursor.ResumeRevision()
+	for {
+		resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+			AfterRevision: afterRevision,
+			Limit:         listLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, changeEvent := range resp.Events {
+			afterRevision = changeEvent.Revision
+			if !cursor.Observe(changeEvent.Revision, now) {
+				continue
+			}
+			e := changeEventToProto(changeEvent)
+			if len(resourceTypes) > 0 && !resourceTypes[e.ResourceType] {
+				continue
+			}
+			events = append(events, e)
+		}
+
+		if len(resp.Events) < listLimit {
+			return events, nil
+		}
+	}
+}
+
+func changeEventToProto(changeEvent *datastore.ChangeEvent) *event.Event {
+	e := &event.Event{
+		Revision:   changeEvent.Revision,
+		Type:       event.Event_Type(changeEvent.Type),
+		ResourceId: changeEvent.ResourceId,
+		CreatedAt:  changeEvent.CreatedAt,
+	}
+
+	switch changeEvent.ResourceType {
+	case datastore.ChangeEvent_REGISTRATION_ENTRY:
+		e.ResourceType = event.Event_REGISTRATION_ENTRY
+	case datastore.ChangeEvent_ATTESTED_NODE:
+		e.ResourceType = event.Event_AGENT
+	case datastore.ChangeEvent_BUNDLE:
+		e.ResourceType = event.Event_BUNDLE
+		// Bundles are identified by trust domain name in the API
+		if td, err := spiffeid.TrustDomainFromString(changeEvent.ResourceId); err == nil {
+			e.ResourceId = td.String()
+		}
+	}
+	return e
+}
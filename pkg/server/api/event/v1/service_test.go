@@ -0,0 +1,234 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/api/event/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	eventpb "github.com/spiffe/spire/proto/spire-next/api/server/event/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+)
+
+const (
+	pollInterval = 5 * time.Second
+)
+
+func TestStreamEventsFromLatestRevision(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createBundle(t, "spiffe://existing.org")
+
+	stream, err := test.client.StreamEvents(ctx, &eventpb.StreamEventsRequest{})
+	require.NoError(t, err)
+
+	// The stream starts after the latest recorded event
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &eventpb.StreamEventsResponse{Revision: 1}, resp)
+	test.clk.WaitForTicker(time.Minute, "waiting for the poll ticker")
+
+	entry := test.createEntry(t)
+	test.createBundle(t, "spiffe://federated.org")
+	_, err = test.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{SpiffeId: "spiffe://example.org/spire/agent/test"},
+	})
+	require.NoError(t, err)
+
+	test.clk.Add(pollInterval)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &eventpb.StreamEventsResponse{
+		Events: []*eventpb.Event{
+			{
+				Revision:     2,
+				Type:         eventpb.Event_CREATED,
+				ResourceType: eventpb.Event_REGISTRATION_ENTRY,
+				ResourceId:   entry.EntryId,
+			},
+			{
+				Revision:     3,
+				Type:         eventpb.Event_CREATED,
+				ResourceType: eventpb.Event_BUNDLE,
+				ResourceId:   "federated.org",
+			},
+			{
+				Revision:     4,
+				Type:         eventpb.Event_CREATED,
+				ResourceType: eventpb.Event_AGENT,
+				ResourceId:   "spiffe://example.org/spire/agent/test",
+			},
+		},
+		Revision: 4,
+	}, clearCreatedAt(resp))
+}
+
+func TestStreamEventsResumeWithFilter(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	entry := test.createEntry(t)
+	test.createBundle(t, "spiffe://federated.org")
+	_, err := test.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	require.NoError(t, err)
+
+	stream, err := test.client.StreamEvents(ctx, &eventpb.StreamEventsRequest{
+		AfterRevision: 1,
+		ResourceTypes: []eventpb.Event_ResourceType{eventpb.Event_REGISTRATION_ENTRY},
+	})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &eventpb.StreamEventsResponse{Revision: 1}, resp)
+	test.clk.WaitForTicker(time.Minute, "waiting for the poll ticker")
+
+	// Events after the revision are streamed, except the filtered out ones.
+	// The revision still moves past the filtered out events.
+	test.clk.Add(pollInterval)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &eventpb.StreamEventsResponse{
+		Events: []*eventpb.Event{
+			{
+				Revision:     3,
+				Type:         eventpb.Event_DELETED,
+				ResourceType: eventpb.Event_REGISTRATION_ENTRY,
+				ResourceId:   entry.EntryId,
+			},
+		},
+		Revision: 3,
+	}, clearCreatedAt(resp))
+
+	// Events for filtered out resource types only move the revision
+	test.createBundle(t, "spiffe://another.org")
+	test.clk.Add(pollInterval)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &eventpb.StreamEventsResponse{Revision: 4}, resp)
+}
+
+func TestStreamEventsInvalidArgument(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		req       *eventpb.StreamEventsRequest
+		expectMsg string
+		expectLog string
+	}{
+		{
+			name:      "negative after revision",
+			req:       &eventpb.StreamEventsRequest{AfterRevision: -1},
+			expectMsg: "after revision cannot be negative",
+			expectLog: "Invalid argument: negative after revision",
+		},
+		{
+			name:      "unknown resource type",
+			req:       &eventpb.StreamEventsRequest{ResourceTypes: []eventpb.Event_ResourceType{7}},
+			expectMsg: "unknown resource type 7",
+			expectLog: "Invalid argument: unknown resource type",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			stream, err := test.client.StreamEvents(ctx, tt.req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: tt.expectLog,
+				},
+			})
+		})
+	}
+}
+
+func clearCreatedAt(resp *eventpb.StreamEventsResponse) *eventpb.StreamEventsResponse {
+	for _, e := range resp.Events {
+		e.CreatedAt = 0
+	}
+	return resp
+}
+
+func (c *serviceTest) createBundle(t *testing.T, trustDomainID string) {
+	_, err := c.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{TrustDomainId: trustDomainID},
+	})
+	require.NoError(t, err)
+}
+
+func (c *serviceTest) createEntry(t *testing.T) *common.RegistrationEntry {
+	resp, err := c.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/spire/agent/test",
+			SpiffeId:  "spiffe://example.org/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+type serviceTest struct {
+	client  eventpb.EventClient
+	ds      datastore.DataStore
+	clk     *clock.Mock
+	logHook *test.Hook
+	done    func()
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New()
+	clk := clock.NewMock(t)
+	log, logHook := test.NewNullLogger()
+	test := &serviceTest{
+		ds:      ds,
+		clk:     clk,
+		logHook: logHook,
+	}
+
+	service := event.New(event.Config{
+		Datastore:    ds,
+		Clock:        clk,
+		PollInterval: pollInterval,
+	})
+
+	registerFn := func(s *grpc.Server) {
+		event.RegisterService(s, service)
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	test.done = done
+	test.client = eventpb.NewEventClient(conn)
+
+	return test
+}
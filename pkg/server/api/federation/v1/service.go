@@ -0,0 +1,362 @@
+package federation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var defaultMask = &types.FederationRelationshipMask{
+	BundleEndpointUrl:     true,
+	BundleEndpointProfile: true,
+	EndpointSpiffeId:      true,
+}
+
+// RegisterService registers the federation service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	federation.RegisterFederationServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	Datastore   datastore.DataStore
+	TrustDomain spiffeid.TrustDomain
+}
+
+// New creates a new federation service
+func New(config Config) *Service {
+	return &Service{
+		ds: config.Datastore,
+		td: config.TrustDomain,
+	}
+}
+
+// Service implements the v1 federation service
+type Service struct {
+	ds datastore.DataStore
+	td spiffeid.TrustDomain
+}
+
+func (s *Service) ListFederationRelationships(ctx context.Context, req *federation.ListFederationRelationshipsRequest) (*federation.ListFederationRelationshipsResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListFederationRelationshipsRequest{}
+
+	// Set pagination parameters
+	if req.PageSize > 0 {
+		listReq.Pagination = &datastore.Pagination{
+			PageSize: req.PageSize,
+			Token:    req.PageToken,
+		}
+	}
+
+	dsResp, err := s.ds.ListFederationRelationships(ctx, listReq)
+	if err != nil {
+		log.WithError(err).Error("Failed to list federation relationships")
+		return nil, status.Errorf(codes.Internal, "failed to list federation relationships: %v", err)
+	}
+
+	resp := &federation.ListFederationRelationshipsResponse{}
+
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+
+	for _, dsRelationship := range dsResp.FederationRelationships {
+		relationship, err := api.FederationRelationshipToProto(dsRelationship)
+		if err != nil {
+			log.WithError(err).WithField(telemetry.TrustDomainID, dsRelationship.TrustDomainId).Error("Failed to convert federation relationship")
+			return nil, status.Errorf(codes.Internal, "failed to convert federation relationship: %v", err)
+		}
+		resp.FederationRelationships = append(resp.FederationRelationships, relationship)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) GetFederationRelationship(ctx context.Context, req *federation.GetFederationRelationshipRequest) (*types.FederationRelationship, error) {
+	log := rpccontext.Logger(ctx)
+
+	td, err := spiffeid.TrustDomainFromString(req.TrustDomain)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: malformed trust domain")
+		return nil, status.Errorf(codes.InvalidArgument, "malformed trust domain: %v", err)
+	}
+	log = log.WithField(telemetry.TrustDomainID, td.IDString())
+
+	dsRelationship, err := s.fetchFederationRelationship(ctx, td.IDString())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return nil, status.Errorf(codes.Internal, "failed to fetch federation relationship: %v", err)
+	}
+	if dsRelationship == nil {
+		log.Error("Federation relationship not found")
+		return nil, status.Error(codes.NotFound, "federation relationship not found")
+	}
+
+	relationship, err := api.FederationRelationshipToProto(dsRelationship)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert federation relationship")
+		return nil, status.Errorf(codes.Internal, "failed to convert federation relationship: %v", err)
+	}
+
+	return relationship, nil
+}
+
+func (s *Service) BatchCreateFederationRelationship(ctx context.Context, req *federation.BatchCreateFederationRelationshipRequest) (*federation.BatchCreateFederationRelationshipResponse, error) {
+	var results []*federation.BatchCreateFederationRelationshipResponse_Result
+	for _, relationship := range req.FederationRelationships {
+		results = append(results, s.createFederationRelationship(ctx, relationship))
+	}
+
+	return &federation.BatchCreateFederationRelationshipResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) BatchUpdateFederationRelationship(ctx context.Context, req *federation.BatchUpdateFederationRelationshipRequest) (*federation.BatchUpdateFederationRelationshipResponse, error) {
+	var results []*federation.BatchUpdateFederationRelationshipResponse_Result
+	for _, relationship := range req.FederationRelationships {
+		results = append(results, s.updateFederationRelationship(ctx, relationship, req.InputMask))
+	}
+
+	return &federation.BatchUpdateFederationRelationshipResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) BatchDeleteFederationRelationship(ctx context.Context, req *federation.BatchDeleteFederationRelationshipRequest) (*federation.BatchDeleteFederationRelationshipResponse, error) {
+	var results []*federation.BatchDeleteFederationRelationshipResponse_Result
+	for _, trustDomain := range req.TrustDomains {
+		results = append(results, s.deleteFederationRelationship(ctx, trustDomain))
+	}
+
+	return &federation.BatchDeleteFederationRelationshipResponse{
+		Results: results,
+	}, nil
+}
+
+// createFederationRelationship creates a single federation relationship and
+// returns its result
+func (s *Service) createFederationRelationship(ctx context.Context, r *types.FederationRelationship) *federation.BatchCreateFederationRelationshipResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	dsRelationship, err := s.protoToFederationRelationship(r)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert federation relationship")
+		return &federation.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert federation relationship: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, dsRelationship.TrustDomainId)
+
+	existing, err := s.fetchFederationRelationship(ctx, dsRelationship.TrustDomainId)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return &federation.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to fetch federation relationship: %v", err),
+		}
+	}
+	if existing != nil {
+		log.Error("Federation relationship already exists")
+		return &federation.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.AlreadyExists, "federation relationship already exists"),
+		}
+	}
+
+	dsResp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: dsRelationship,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to create federation relationship")
+		return &federation.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to create federation relationship: %v", err),
+		}
+	}
+
+	out, err := api.FederationRelationshipToProto(dsResp.FederationRelationship)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert federation relationship")
+		return &federation.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert federation relationship: %v", err),
+		}
+	}
+
+	return &federation.BatchCreateFederationRelationshipResponse_Result{
+		Status:                 api.CreateStatus(codes.OK, "OK"),
+		FederationRelationship: out,
+	}
+}
+
+// updateFederationRelationship updates the fields of a single federation
+// relationship that are enabled in the input mask and returns its result
+func (s *Service) updateFederationRelationship(ctx context.Context, r *types.FederationRelationship, inputMask *types.FederationRelationshipMask) *federation.BatchUpdateFederationRelationshipResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	if r == nil {
+		log.Error("Invalid argument: missing federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "missing federation relationship"),
+		}
+	}
+
+	td, err := spiffeid.TrustDomainFromString(r.TrustDomain)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: malformed trust domain")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "malformed trust domain: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, td.IDString())
+
+	existing, err := s.fetchFederationRelationship(ctx, td.IDString())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to fetch federation relationship: %v", err),
+		}
+	}
+	if existing == nil {
+		log.Error("Federation relationship not found")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.NotFound, "federation relationship not found"),
+		}
+	}
+
+	merged, err := api.FederationRelationshipToProto(existing)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert federation relationship: %v", err),
+		}
+	}
+	mergeFederationRelationship(merged, r, inputMask)
+
+	dsRelationship, err := s.protoToFederationRelationship(merged)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: failed to convert federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.InvalidArgument, "failed to convert federation relationship: %v", err),
+		}
+	}
+
+	dsResp, err := s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: dsRelationship,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to update federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to update federation relationship: %v", err),
+		}
+	}
+
+	out, err := api.FederationRelationshipToProto(dsResp.FederationRelationship)
+	if err != nil {
+		log.WithError(err).Error("Failed to convert federation relationship")
+		return &federation.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.CreateStatus(codes.Internal, "failed to convert federation relationship: %v", err),
+		}
+	}
+
+	return &federation.BatchUpdateFederationRelationshipResponse_Result{
+		Status:                 api.CreateStatus(codes.OK, "OK"),
+		FederationRelationship: out,
+	}
+}
+
+// deleteFederationRelationship deletes a single federation relationship and
+// returns its result
+func (s *Service) deleteFederationRelationship(ctx context.Context, trustDomain string) *federation.BatchDeleteFederationRelationshipResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		log.WithError(err).Error("Invalid argument: malformed trust domain")
+		return &federation.BatchDeleteFederationRelationshipResponse_Result{
+			TrustDomain: trustDomain,
+			Status:      api.CreateStatus(codes.InvalidArgument, "malformed trust domain: %v", err),
+		}
+	}
+	log = log.WithField(telemetry.TrustDomainID, td.IDString())
+
+	existing, err := s.fetchFederationRelationship(ctx, td.IDString())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return &federation.BatchDeleteFederationRelationshipResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.Internal, "failed to fetch federation relationship: %v", err),
+		}
+	}
+	if existing == nil {
+		log.Error("Federation relationship not found")
+		return &federation.BatchDeleteFederationRelationshipResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.NotFound, "federation relationship not found"),
+		}
+	}
+
+	if _, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: td.IDString(),
+	}); err != nil {
+		log.WithError(err).Error("Failed to delete federation relationship")
+		return &federation.BatchDeleteFederationRelationshipResponse_Result{
+			TrustDomain: td.String(),
+			Status:      api.CreateStatus(codes.Internal, "failed to delete federation relationship: %v", err),
+		}
+	}
+
+	return &federation.BatchDeleteFederationRelationshipResponse_Result{
+		TrustDomain: td.String(),
+		Status:      api.CreateStatus(codes.OK, "OK"),
+	}
+}
+
+// protoToFederationRelationship converts the federation relationship and
+// validates that it is not with the server trust domain.
+func (s *Service) protoToFederationRelationship(r *types.FederationRelationship) (*datastore.FederationRelationship, error) {
+	dsRelationship, err := api.ProtoToFederationRelationship(r)
+	if err != nil {
+		return nil, err
+	}
+	if dsRelationship.TrustDomainId == s.td.IDString() {
+		return nil, errors.New("cannot federate with the server trust domain")
+	}
+	return dsRelationship, nil
+}
+
+func (s *Service) fetchFederationRelationship(ctx context.Context, trustDomainID string) (*datastore.FederationRelationship, error) {
+	resp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.FederationRelationship, nil
+}
+
+// mergeFederationRelationship sets the fields of dst from src for every field
+// enabled in the mask. A nil mask updates every field.
+func mergeFederationRelationship(dst, src *types.FederationRelationship, mask *types.FederationRelationshipMask) {
+	if mask == nil {
+		mask = defaultMask
+	}
+	if mask.BundleEndpointUrl {
+		dst.BundleEndpointUrl = src.BundleEndpointUrl
+	}
+	if mask.BundleEndpointProfile {
+		dst.BundleEndpointProfile = src.BundleEndpointProfile
+	}
+	if mask.EndpointSpiffeId {
+		dst.EndpointSpiffeId = src.EndpointSpiffeId
+	}
+}
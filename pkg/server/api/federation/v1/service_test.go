@@ -0,0 +1,304 @@
+package federation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/federation/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	federationpb "github.com/spiffe/spire/proto/spire-next/api/server/federation/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx          = context.Background()
+	trustDomain  = spiffeid.RequireTrustDomainFromString("example.org")
+	relationship = &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://another-example.org",
+		BundleEndpointUrl:     "https://another-example.org/bundle",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_SPIFFE,
+		EndpointSpiffeId:      "spiffe://another-example.org/bundle-server",
+	}
+)
+
+func TestListFederationRelationships(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createRelationship(t, relationship)
+	test.createRelationship(t, &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://web-example.org",
+		BundleEndpointUrl:     "https://web-example.org",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	})
+
+	resp, err := test.client.ListFederationRelationships(ctx, &federationpb.ListFederationRelationshipsRequest{
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.NextPageToken)
+	require.Len(t, resp.FederationRelationships, 1)
+	spiretest.AssertProtoEqual(t, &types.FederationRelationship{
+		TrustDomain:       "another-example.org",
+		BundleEndpointUrl: "https://another-example.org/bundle",
+		EndpointSpiffeId:  "spiffe://another-example.org/bundle-server",
+	}, resp.FederationRelationships[0])
+
+	resp, err = test.client.ListFederationRelationships(ctx, &federationpb.ListFederationRelationshipsRequest{
+		PageSize:  1,
+		PageToken: resp.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.FederationRelationships, 1)
+	spiretest.AssertProtoEqual(t, &types.FederationRelationship{
+		TrustDomain:           "web-example.org",
+		BundleEndpointUrl:     "https://web-example.org",
+		BundleEndpointProfile: types.BundleEndpointProfile_HTTPS_WEB,
+	}, resp.FederationRelationships[0])
+
+	resp, err = test.client.ListFederationRelationships(ctx, &federationpb.ListFederationRelationshipsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.NextPageToken)
+	require.Len(t, resp.FederationRelationships, 2)
+}
+
+func TestGetFederationRelationship(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createRelationship(t, relationship)
+
+	for _, tt := range []struct {
+		name        string
+		trustDomain string
+		code        codes.Code
+		err         string
+	}{
+		{
+			name:        "success",
+			trustDomain: "another-example.org",
+		},
+		{
+			name:        "malformed trust domain",
+			trustDomain: "//not-valid",
+			code:        codes.InvalidArgument,
+			err:         "malformed trust domain:",
+		},
+		{
+			name:        "not found",
+			trustDomain: "not-found.org",
+			code:        codes.NotFound,
+			err:         "federation relationship not found",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.GetFederationRelationship(ctx, &federationpb.GetFederationRelationshipRequest{
+				TrustDomain: tt.trustDomain,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "another-example.org", resp.TrustDomain)
+			require.Equal(t, "https://another-example.org/bundle", resp.BundleEndpointUrl)
+		})
+	}
+}
+
+func TestBatchCreateFederationRelationship(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createRelationship(t, relationship)
+
+	newRelationship := &types.FederationRelationship{
+		TrustDomain:           "new-example.org",
+		BundleEndpointUrl:     "https://new-example.org",
+		BundleEndpointProfile: types.BundleEndpointProfile_HTTPS_WEB,
+	}
+
+	resp, err := test.client.BatchCreateFederationRelationship(ctx, &federationpb.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{
+			newRelationship,
+			{TrustDomain: "another-example.org", BundleEndpointUrl: "https://another-example.org"},
+			{TrustDomain: "example.org", BundleEndpointUrl: "https://example.org"},
+			{TrustDomain: "invalid-url.org", BundleEndpointUrl: "http://invalid-url.org"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 4)
+
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+	spiretest.AssertProtoEqual(t, newRelationship, resp.Results[0].FederationRelationship)
+	spiretest.AssertProtoEqual(t, api.CreateStatus(codes.AlreadyExists, "federation relationship already exists"), resp.Results[1].Status)
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[2].Status.Code)
+	require.Contains(t, resp.Results[2].Status.Message, "cannot federate with the server trust domain")
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[3].Status.Code)
+	require.Contains(t, resp.Results[3].Status.Message, "scheme must be https")
+
+	created := test.fetchRelationship(t, "spiffe://new-example.org")
+	require.NotNil(t, created)
+	require.Equal(t, datastore.FederationRelationship_HTTPS_WEB, created.BundleEndpointProfile)
+}
+
+func TestBatchUpdateFederationRelationship(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		inputMask        *types.FederationRelationshipMask
+		expectURL        string
+		expectEndpointID string
+	}{
+		{
+			name:      "nil input mask updates every field",
+			expectURL: "https://another-example.org/new",
+		},
+		{
+			name:             "input mask limits updated fields",
+			inputMask:        &types.FederationRelationshipMask{BundleEndpointUrl: true},
+			expectURL:        "https://another-example.org/new",
+			expectEndpointID: "spiffe://another-example.org/bundle-server",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createRelationship(t, relationship)
+
+			resp, err := test.client.BatchUpdateFederationRelationship(ctx, &federationpb.BatchUpdateFederationRelationshipRequest{
+				FederationRelationships: []*types.FederationRelationship{
+					{TrustDomain: "another-example.org", BundleEndpointUrl: "https://another-example.org/new"},
+					{TrustDomain: "not-found.org", BundleEndpointUrl: "https://not-found.org"},
+				},
+				InputMask: tt.inputMask,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 2)
+
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.OK, "OK"), resp.Results[0].Status)
+			require.Equal(t, tt.expectURL, resp.Results[0].FederationRelationship.BundleEndpointUrl)
+			require.Equal(t, tt.expectEndpointID, resp.Results[0].FederationRelationship.EndpointSpiffeId)
+			spiretest.AssertProtoEqual(t, api.CreateStatus(codes.NotFound, "federation relationship not found"), resp.Results[1].Status)
+
+			updated := test.fetchRelationship(t, "spiffe://another-example.org")
+			require.Equal(t, tt.expectURL, updated.BundleEndpointUrl)
+			require.Equal(t, tt.expectEndpointID, updated.EndpointSpiffeId)
+		})
+	}
+}
+
+func TestBatchUpdateFederationRelationshipInvalid(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createRelationship(t, relationship)
+
+	resp, err := test.client.BatchUpdateFederationRelationship(ctx, &federationpb.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{
+			{TrustDomain: "//not-valid"},
+			{TrustDomain: "another-example.org", BundleEndpointUrl: "ftp://another-example.org"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[0].Status.Code)
+	require.Contains(t, resp.Results[0].Status.Message, "malformed trust domain")
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[1].Status.Code)
+	require.Contains(t, resp.Results[1].Status.Message, "scheme must be https")
+
+	spiretest.AssertProtoEqual(t, relationship, test.fetchRelationship(t, "spiffe://another-example.org"))
+}
+
+func TestBatchDeleteFederationRelationship(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createRelationship(t, relationship)
+
+	resp, err := test.client.BatchDeleteFederationRelationship(ctx, &federationpb.BatchDeleteFederationRelationshipRequest{
+		TrustDomains: []string{"another-example.org", "not-found.org", "//not-valid"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+
+	spiretest.AssertProtoEqual(t, &federationpb.BatchDeleteFederationRelationshipResponse_Result{
+		TrustDomain: "another-example.org",
+		Status:      api.CreateStatus(codes.OK, "OK"),
+	}, resp.Results[0])
+	spiretest.AssertProtoEqual(t, &federationpb.BatchDeleteFederationRelationshipResponse_Result{
+		TrustDomain: "not-found.org",
+		Status:      api.CreateStatus(codes.NotFound, "federation relationship not found"),
+	}, resp.Results[1])
+	require.Equal(t, "//not-valid", resp.Results[2].TrustDomain)
+	require.Equal(t, int32(codes.InvalidArgument), resp.Results[2].Status.Code)
+
+	require.Nil(t, test.fetchRelationship(t, "spiffe://another-example.org"))
+}
+
+func (c *serviceTest) createRelationship(t *testing.T, r *datastore.FederationRelationship) {
+	_, err := c.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: r,
+	})
+	require.NoError(t, err)
+}
+
+func (c *serviceTest) fetchRelationship(t *testing.T, trustDomainID string) *datastore.FederationRelationship {
+	resp, err := c.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+	})
+	require.NoError(t, err)
+	return resp.FederationRelationship
+}
+
+type serviceTest struct {
+	client  federationpb.FederationClient
+	ds      datastore.DataStore
+	logHook *test.Hook
+	done    func()
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New()
+	log, logHook := test.NewNullLogger()
+	test := &serviceTest{
+		ds:      ds,
+		logHook: logHook,
+	}
+
+	service := federation.New(federation.Config{
+		Datastore:   ds,
+		TrustDomain: trustDomain,
+	})
+
+	registerFn := func(s *grpc.Server) {
+		federation.RegisterService(s, service)
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	test.done = done
+	test.client = federationpb.NewFederationClient(conn)
+
+	return test
+}
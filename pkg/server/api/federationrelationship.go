@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+)
+
+// ProtoToFederationRelationship converts a types.FederationRelationship to a
+// datastore.FederationRelationship, validating the trust domain, the bundle
+// endpoint URL and the endpoint SPIFFE ID
+func ProtoToFederationRelationship(r *types.FederationRelationship) (*datastore.FederationRelationship, error) {
+	if r == nil {
+		return nil, errors.New("missing federation relationship")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(r.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %v", err)
+	}
+
+	if err := validateBundleEndpointURL(r.BundleEndpointUrl); err != nil {
+		return nil, err
+	}
+
+	var profile datastore.FederationRelationship_BundleEndpointProfile
+	switch r.BundleEndpointProfile {
+	case types.BundleEndpointProfile_HTTPS_SPIFFE:
+		profile = datastore.FederationRelationship_HTTPS_SPIFFE
+	case types.BundleEndpointProfile_HTTPS_WEB:
+		profile = datastore.FederationRelationship_HTTPS_WEB
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", r.BundleEndpointProfile)
+	}
+
+	var endpointSPIFFEID string
+	if r.EndpointSpiffeId != "" {
+		if profile != datastore.FederationRelationship_HTTPS_SPIFFE {
+			return nil, errors.New("endpoint SPIFFE ID is only valid with the https_spiffe profile")
+		}
+		id, err := spiffeid.FromString(r.EndpointSpiffeId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint SPIFFE ID: %v", err)
+		}
+		endpointSPIFFEID = id.String()
+	}
+
+	return &datastore.FederationRelationship{
+		TrustDomainId:         td.IDString(),
+		BundleEndpointUrl:     r.BundleEndpointUrl,
+		BundleEndpointProfile: profile,
+		EndpointSpiffeId:      endpointSPIFFEID,
+	}, nil
+}
+
+// FederationRelationshipToProto converts a datastore.FederationRelationship
+// to a types.FederationRelationship
+func FederationRelationshipToProto(r *datastore.FederationRelationship) (*types.FederationRelationship, error) {
+	if r == nil {
+		return nil, errors.New("missing federation relationship")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(r.TrustDomainId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %v", err)
+	}
+
+	var profile types.BundleEndpointProfile
+	switch r.BundleEndpointProfile {
+	case datastore.FederationRelationship_HTTPS_SPIFFE:
+		profile = types.BundleEndpointProfile_HTTPS_SPIFFE
+	case datastore.FederationRelationship_HTTPS_WEB:
+		profile = types.BundleEndpointProfile_HTTPS_WEB
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", r.BundleEndpointProfile)
+	}
+
+	return &types.FederationRelationship{
+		TrustDomain:           td.String(),
+		BundleEndpointUrl:     r.BundleEndpointUrl,
+		BundleEndpointProfile: profile,
+		EndpointSpiffeId:      r.EndpointSpiffeId,
+	}, nil
+}
+
+func validateBundleEndpointURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("missing bundle endpoint URL")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid bundle endpoint URL: %v", err)
+	}
+	switch {
+	case u.Scheme != "https":
+		return errors.New("invalid bundle endpoint URL: scheme must be https")
+	case u.Host == "":
+		return errors.New("invalid bundle endpoint URL: missing host")
+	case u.User != nil:
+		return errors.New("invalid bundle endpoint URL: cannot contain user info")
+	case u.RawQuery != "" || u.Fragment != "":
+		return errors.New("invalid bundle endpoint URL: cannot contain a query or fragment")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoToFederationRelationship(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		relationship *types.FederationRelationship
+		expect       *datastore.FederationRelationship
+		err          string
+	}{
+		{
+			name: "https_spiffe",
+			relationship: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://example.org:8443/bundle",
+				EndpointSpiffeId:  "spiffe://example.org/bundle-server",
+			},
+			expect: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org:8443/bundle",
+				BundleEndpointProfile: datastore.FederationRelationship_HTTPS_SPIFFE,
+				EndpointSpiffeId:      "spiffe://example.org/bundle-server",
+			},
+		},
+		{
+			name: "https_web",
+			relationship: &types.FederationRelationship{
+				TrustDomain:           "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: types.BundleEndpointProfile_HTTPS_WEB,
+			},
+			expect: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+			},
+		},
+		{
+			name: "missing relationship",
+			err:  "missing federation relationship",
+		},
+		{
+			name:         "invalid trust domain",
+			relationship: &types.FederationRelationship{TrustDomain: "//not-valid"},
+			err:          "invalid trust domain:",
+		},
+		{
+			name:         "missing bundle endpoint URL",
+			relationship: &types.FederationRelationship{TrustDomain: "example.org"},
+			err:          "missing bundle endpoint URL",
+		},
+		{
+			name: "bundle endpoint URL not https",
+			relationship: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "http://example.org",
+			},
+			err: "invalid bundle endpoint URL: scheme must be https",
+		},
+		{
+			name: "bundle endpoint URL missing host",
+			relationship: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https:///bundle",
+			},
+			err: "invalid bundle endpoint URL: missing host",
+		},
+		{
+			name: "bundle endpoint URL with query",
+			relationship: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://example.org/bundle?foo=bar",
+			},
+			err: "invalid bundle endpoint URL: cannot contain a query or fragment",
+		},
+		{
+			name: "unknown profile",
+			relationship: &types.FederationRelationship{
+				TrustDomain:           "example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: 99,
+			},
+			err: `unknown bundle endpoint profile "99"`,
+		},
+		{
+			name: "endpoint SPIFFE ID with https_web",
+			relationship: &types.FederationRelationship{
+				TrustDomain:           "example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: types.BundleEndpointProfile_HTTPS_WEB,
+				EndpointSpiffeId:      "spiffe://example.org/bundle-server",
+			},
+			err: "endpoint SPIFFE ID is only valid with the https_spiffe profile",
+		},
+		{
+			name: "invalid endpoint SPIFFE ID",
+			relationship: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://example.org",
+				EndpointSpiffeId:  "not-an-id",
+			},
+			err: "invalid endpoint SPIFFE ID:",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			relationship, err := api.ProtoToFederationRelationship(tt.relationship)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.Nil(t, relationship)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expect, relationship)
+		})
+	}
+}
+
+func TestFederationRelationshipToProto(t *testing.T) {
+	relationship, err := api.FederationRelationshipToProto(&datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://example.org",
+		BundleEndpointUrl:     "https://example.org",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &types.FederationRelationship{
+		TrustDomain:           "example.org",
+		BundleEndpointUrl:     "https://example.org",
+		BundleEndpointProfile: types.BundleEndpointProfile_HTTPS_WEB,
+	}, relationship)
+
+	_, err = api.FederationRelationshipToProto(nil)
+	require.EqualError(t, err, "missing federation relationship")
+
+	_, err = api.FederationRelationshipToProto(&datastore.FederationRelationship{TrustDomainId: "//not-valid"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid trust domain:")
+}
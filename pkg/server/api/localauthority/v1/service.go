@@ -0,0 +1,190 @@
+package localauthority
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/proto/spire-next/api/server/localauthority/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterService registers the local authority service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	localauthority.RegisterLocalAuthorityServer(s, service)
+}
+
+// AuthorityManager manages the X509 and JWT authorities of the server. It is
+// implemented by the CA manager.
+type AuthorityManager interface {
+	GetX509CAState() (active, prepared *ca.AuthorityState)
+	PrepareX509CA(ctx context.Context) (*ca.AuthorityState, error)
+	ActivateX509CA(ctx context.Context, authorityID string) (*ca.AuthorityState, error)
+	TaintX509CA(ctx context.Context, authorityID string) error
+	RevokeX509CA(ctx context.Context, authorityID string) error
+
+	GetJWTKeyState() (active, prepared *ca.AuthorityState)
+	PrepareJWTKey(ctx context.Context) (*ca.AuthorityState, error)
+	ActivateJWTKey(ctx context.Context, authorityID string) (*ca.AuthorityState, error)
+	TaintJWTKey(ctx context.Context, authorityID string) error
+	RevokeJWTKey(ctx context.Context, authorityID string) error
+}
+
+// Config is the service configuration
+type Config struct {
+	Manager AuthorityManager
+}
+
+// New creates a new local authority service
+func New(config Config) *Service {
+	return &Service{
+		m: config.Manager,
+	}
+}
+
+// Service implements the v1 local authority service
+type Service struct {
+	m AuthorityManager
+}
+
+func (s *Service) GetX509AuthorityState(ctx context.Context, req *localauthority.GetX509AuthorityStateRequest) (*localauthority.GetX509AuthorityStateResponse, error) {
+	active, prepared := s.m.GetX509CAState()
+	return &localauthority.GetX509AuthorityStateResponse{
+		Active:   authorityStateToProto(active),
+		Prepared: authorityStateToProto(prepared),
+	}, nil
+}
+
+func (s *Service) PrepareX509Authority(ctx context.Context, req *localauthority.PrepareX509AuthorityRequest) (*localauthority.PrepareX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	prepared, err := s.m.PrepareX509CA(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to prepare X509 authority")
+		return nil, managerStatus(err, "failed to prepare X509 authority")
+	}
+
+	return &localauthority.PrepareX509AuthorityResponse{
+		Prepared: authorityStateToProto(prepared),
+	}, nil
+}
+
+func (s *Service) ActivateX509Authority(ctx context.Context, req *localauthority.ActivateX509AuthorityRequest) (*localauthority.ActivateX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	active, err := s.m.ActivateX509CA(ctx, req.AuthorityId)
+	if err != nil {
+		log.WithError(err).Error("Failed to activate X509 authority")
+		return nil, managerStatus(err, "failed to activate X509 authority")
+	}
+
+	return &localauthority.ActivateX509AuthorityResponse{
+		Active: authorityStateToProto(active),
+	}, nil
+}
+
+func (s *Service) TaintX509Authority(ctx context.Context, req *localauthority.TaintX509AuthorityRequest) (*localauthority.TaintX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	if err := s.m.TaintX509CA(ctx, req.AuthorityId); err != nil {
+		log.WithError(err).Error("Failed to taint X509 authority")
+		return nil, managerStatus(err, "failed to taint X509 authority")
+	}
+
+	return &localauthority.TaintX509AuthorityResponse{}, nil
+}
+
+func (s *Service) RevokeX509Authority(ctx context.Context, req *localauthority.RevokeX509AuthorityRequest) (*localauthority.RevokeX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	if err := s.m.RevokeX509CA(ctx, req.AuthorityId); err != nil {
+		log.WithError(err).Error("Failed to revoke X509 authority")
+		return nil, managerStatus(err, "failed to revoke X509 authority")
+	}
+
+	return &localauthority.RevokeX509AuthorityResponse{}, nil
+}
+
+func (s *Service) GetJWTAuthorityState(ctx context.Context, req *localauthority.GetJWTAuthorityStateRequest) (*localauthority.GetJWTAuthorityStateResponse, error) {
+	active, prepared := s.m.GetJWTKeyState()
+	return &localauthority.GetJWTAuthorityStateResponse{
+		Active:   authorityStateToProto(active),
+		Prepared: authorityStateToProto(prepared),
+	}, nil
+}
+
+func (s *Service) PrepareJWTAuthority(ctx context.Context, req *localauthority.PrepareJWTAuthorityRequest) (*localauthority.PrepareJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	prepared, err := s.m.PrepareJWTKey(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to prepare JWT authority")
+		return nil, managerStatus(err, "failed to prepare JWT authority")
+	}
+
+	return &localauthority.PrepareJWTAuthorityResponse{
+		Prepared: authorityStateToProto(prepared),
+	}, nil
+}
+
+func (s *Service) ActivateJWTAuthority(ctx context.Context, req *localauthority.ActivateJWTAuthorityRequest) (*localauthority.ActivateJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	active, err := s.m.ActivateJWTKey(ctx, req.AuthorityId)
+	if err != nil {
+		log.WithError(err).Error("Failed to activate JWT authority")
+		return nil, managerStatus(err, "failed to activate JWT authority")
+	}
+
+	return &localauthority.ActivateJWTAuthorityResponse{
+		Active: authorityStateToProto(active),
+	}, nil
+}
+
+func (s *Service) TaintJWTAuthority(ctx context.Context, req *localauthority.TaintJWTAuthorityRequest) (*localauthority.TaintJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	if err := s.m.TaintJWTKey(ctx, req.AuthorityId); err != nil {
+		log.WithError(err).Error("Failed to taint JWT authority")
+		return nil, managerStatus(err, "failed to taint JWT authority")
+	}
+
+	return &localauthority.TaintJWTAuthorityResponse{}, nil
+}
+
+func (s *Service) RevokeJWTAuthority(ctx context.Context, req *localauthority.RevokeJWTAuthorityRequest) (*localauthority.RevokeJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.AuthorityID, req.AuthorityId)
+
+	if err := s.m.RevokeJWTKey(ctx, req.AuthorityId); err != nil {
+		log.WithError(err).Error("Failed to revoke JWT authority")
+		return nil, managerStatus(err, "failed to revoke JWT authority")
+	}
+
+	return &localauthority.RevokeJWTAuthorityResponse{}, nil
+}
+
+// managerStatus returns the error returned by the authority manager with the
+// given message. The status code set by the manager is kept; errors without a
+// status code are internal errors.
+func managerStatus(err error, msg string) error {
+	st := status.Convert(err)
+	code := st.Code()
+	if code == codes.Unknown {
+		code = codes.Internal
+	}
+	return status.Errorf(code, "%s: %s", msg, st.Message())
+}
+
+func authorityStateToProto(state *ca.AuthorityState) *localauthority.AuthorityState {
+	if state == nil {
+		return nil
+	}
+	return &localauthority.AuthorityState{
+		AuthorityId: state.AuthorityID,
+		IssuedAt:    state.IssuedAt.Unix(),
+		ExpiresAt:   state.ExpiresAt.Unix(),
+	}
+}
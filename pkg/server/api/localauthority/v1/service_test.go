@@ -0,0 +1,321 @@
+package localauthority_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/api/localauthority/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	localauthoritypb "github.com/spiffe/spire/proto/spire-next/api/server/localauthority/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx = context.Background()
+
+	activeState = &ca.AuthorityState{
+		AuthorityID: "active",
+		IssuedAt:    time.Unix(1000, 0),
+		ExpiresAt:   time.Unix(2000, 0),
+	}
+	preparedState = &ca.AuthorityState{
+		AuthorityID: "prepared",
+		IssuedAt:    time.Unix(1500, 0),
+		ExpiresAt:   time.Unix(2500, 0),
+	}
+)
+
+func TestGetX509AuthorityState(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	resp, err := test.client.GetX509AuthorityState(ctx, &localauthoritypb.GetX509AuthorityStateRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &localauthoritypb.GetX509AuthorityStateResponse{
+		Active: &localauthoritypb.AuthorityState{
+			AuthorityId: "active",
+			IssuedAt:    1000,
+			ExpiresAt:   2000,
+		},
+	}, resp)
+
+	test.m.prepared = preparedState
+	resp, err = test.client.GetX509AuthorityState(ctx, &localauthoritypb.GetX509AuthorityStateRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &localauthoritypb.AuthorityState{
+		AuthorityId: "prepared",
+		IssuedAt:    1500,
+		ExpiresAt:   2500,
+	}, resp.Prepared)
+}
+
+func TestPrepareX509Authority(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	resp, err := test.client.PrepareX509Authority(ctx, &localauthoritypb.PrepareX509AuthorityRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "prepared", resp.Prepared.AuthorityId)
+
+	test.m.err = errors.New("oh no")
+	resp, err = test.client.PrepareX509Authority(ctx, &localauthoritypb.PrepareX509AuthorityRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to prepare X509 authority: oh no")
+	require.Nil(t, resp)
+	spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.ErrorLevel,
+			Message: "Failed to prepare X509 authority",
+			Data: logrus.Fields{
+				logrus.ErrorKey: "oh no",
+			},
+		},
+	})
+}
+
+func TestActivateX509Authority(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.m.prepared = preparedState
+	resp, err := test.client.ActivateX509Authority(ctx, &localauthoritypb.ActivateX509AuthorityRequest{
+		AuthorityId: "prepared",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "prepared", resp.Active.AuthorityId)
+	require.Equal(t, "prepared", test.m.activated)
+
+	test.m.err = status.Error(codes.FailedPrecondition, "no X509 CA has been prepared")
+	resp, err = test.client.ActivateX509Authority(ctx, &localauthoritypb.ActivateX509AuthorityRequest{
+		AuthorityId: "prepared",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "failed to activate X509 authority: no X509 CA has been prepared")
+	require.Nil(t, resp)
+}
+
+func TestTaintAndRevokeX509Authority(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	_, err := test.client.TaintX509Authority(ctx, &localauthoritypb.TaintX509AuthorityRequest{
+		AuthorityId: "old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "old", test.m.tainted)
+
+	_, err = test.client.RevokeX509Authority(ctx, &localauthoritypb.RevokeX509AuthorityRequest{
+		AuthorityId: "old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "old", test.m.revoked)
+
+	test.m.err = status.Error(codes.NotFound, "no root CA with subject key ID")
+	_, err = test.client.TaintX509Authority(ctx, &localauthoritypb.TaintX509AuthorityRequest{
+		AuthorityId: "unknown",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "failed to taint X509 authority: no root CA with subject key ID")
+	_, err = test.client.RevokeX509Authority(ctx, &localauthoritypb.RevokeX509AuthorityRequest{
+		AuthorityId: "unknown",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "failed to revoke X509 authority: no root CA with subject key ID")
+}
+
+func TestGetJWTAuthorityState(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.m.prepared = preparedState
+	resp, err := test.client.GetJWTAuthorityState(ctx, &localauthoritypb.GetJWTAuthorityStateRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &localauthoritypb.GetJWTAuthorityStateResponse{
+		Active: &localauthoritypb.AuthorityState{
+			AuthorityId: "active",
+			IssuedAt:    1000,
+			ExpiresAt:   2000,
+		},
+		Prepared: &localauthoritypb.AuthorityState{
+			AuthorityId: "prepared",
+			IssuedAt:    1500,
+			ExpiresAt:   2500,
+		},
+	}, resp)
+}
+
+func TestPrepareAndActivateJWTAuthority(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	prepareResp, err := test.client.PrepareJWTAuthority(ctx, &localauthoritypb.PrepareJWTAuthorityRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "prepared", prepareResp.Prepared.AuthorityId)
+
+	activateResp, err := test.client.ActivateJWTAuthority(ctx, &localauthoritypb.ActivateJWTAuthorityRequest{
+		AuthorityId: "prepared",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "prepared", activateResp.Active.AuthorityId)
+	require.Equal(t, "prepared", test.m.activated)
+
+	test.m.err = status.Error(codes.InvalidArgument, "authority ID is required")
+	_, err = test.client.ActivateJWTAuthority(ctx, &localauthoritypb.ActivateJWTAuthorityRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "failed to activate JWT authority: authority ID is required")
+}
+
+func TestTaintAndRevokeJWTAuthority(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	_, err := test.client.TaintJWTAuthority(ctx, &localauthoritypb.TaintJWTAuthorityRequest{
+		AuthorityId: "old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "old", test.m.tainted)
+
+	_, err = test.client.RevokeJWTAuthority(ctx, &localauthoritypb.RevokeJWTAuthorityRequest{
+		AuthorityId: "old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "old", test.m.revoked)
+
+	test.m.err = status.Error(codes.FailedPrecondition, "the active JWT key cannot be tainted or revoked")
+	_, err = test.client.TaintJWTAuthority(ctx, &localauthoritypb.TaintJWTAuthorityRequest{
+		AuthorityId: "active",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "failed to taint JWT authority: the active JWT key cannot be tainted or revoked")
+	_, err = test.client.RevokeJWTAuthority(ctx, &localauthoritypb.RevokeJWTAuthorityRequest{
+		AuthorityId: "active",
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "failed to revoke JWT authority: the active JWT key cannot be tainted or revoked")
+}
+
+type serviceTest struct {
+	client  localauthoritypb.LocalAuthorityClient
+	m       *fakeAuthorityManager
+	logHook *test.Hook
+	done    func()
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	m := &fakeAuthorityManager{
+		active: activeState,
+	}
+	log, logHook := test.NewNullLogger()
+	test := &serviceTest{
+		m:       m,
+		logHook: logHook,
+	}
+
+	service := localauthority.New(localauthority.Config{
+		Manager: m,
+	})
+
+	registerFn := func(s *grpc.Server) {
+		localauthority.RegisterService(s, service)
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	test.done = done
+	test.client = localauthoritypb.NewLocalAuthorityClient(conn)
+
+	return test
+}
+
+// fakeAuthorityManager serves the same state for the X509 CA and the JWT key
+// and records the authority IDs it is called with.
+type fakeAuthorityManager struct {
+	active    *ca.AuthorityState
+	prepared  *ca.AuthorityState
+	activated string
+	tainted   string
+	revoked   string
+	err       error
+}
+
+func (m *fakeAuthorityManager) GetX509CAState() (active, prepared *ca.AuthorityState) {
+	return m.active, m.prepared
+}
+
+func (m *fakeAuthorityManager) PrepareX509CA(ctx context.Context) (*ca.AuthorityState, error) {
+	return m.prepare()
+}
+
+func (m *fakeAuthorityManager) ActivateX509CA(ctx context.Context, authorityID string) (*ca.AuthorityState, error) {
+	return m.activate(authorityID)
+}
+
+func (m *fakeAuthorityManager) TaintX509CA(ctx context.Context, authorityID string) error {
+	return m.taint(authorityID)
+}
+
+func (m *fakeAuthorityManager) RevokeX509CA(ctx context.Context, authorityID string) error {
+	return m.revoke(authorityID)
+}
+
+func (m *fakeAuthorityManager) GetJWTKeyState() (active, prepared *ca.AuthorityState) {
+	return m.active, m.prepared
+}
+
+func (m *fakeAuthorityManager) PrepareJWTKey(ctx context.Context) (*ca.AuthorityState, error) {
+	return m.prepare()
+}
+
+func (m *fakeAuthorityManager) ActivateJWTKey(ctx context.Context, authorityID string) (*ca.AuthorityState, error) {
+	return m.activate(authorityID)
+}
+
+func (m *fakeAuthorityManager) TaintJWTKey(ctx context.Context, authorityID string) error {
+	return m.taint(authorityID)
+}
+
+func (m *fakeAuthorityManager) RevokeJWTKey(ctx context.Context, authorityID string) error {
+	return m.revoke(authorityID)
+}
+
+func (m *fakeAuthorityManager) prepare() (*ca.AuthorityState, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.prepared = preparedState
+	return m.prepared, nil
+}
+
+func (m *fakeAuthorityManager) activate(authorityID string) (*ca.AuthorityState, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.activated = authorityID
+	m.active, m.prepared = m.prepared, nil
+	return m.active, nil
+}
+
+func (m *fakeAuthorityManager) taint(authorityID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.tainted = authorityID
+	return nil
+}
+
+func (m *fakeAuthorityManager) revoke(authorityID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.revoked = authorityID
+	return nil
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// redactedValue replaces secrets (i.e. join tokens) in audited requests
+	redactedValue = "[REDACTED]"
+)
+
+var auditMarshaler = jsonpb.Marshaler{OrigName: true}
+
+// UnaryAuditLogInterceptor returns an interceptor that emits one structured
+// audit record to the given log for every call to one of the audited
+// methods, keyed by full method name. The record contains the caller, the
+// request parameters, the per-item results of batch operations and the
+// status code. Since it does not rely on the rest of the middleware, it can
+// wrap both the spire-next server APIs and the legacy APIs.
+func UnaryAuditLogInterceptor(log logrus.FieldLogger, audited map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if audited[info.FullMethod] {
+			auditCall(ctx, log, info.FullMethod, req, resp, err)
+		}
+		return resp, err
+	}
+}
+
+func auditCall(ctx context.Context, log logrus.FieldLogger, fullMethod string, req, resp interface{}, rpcErr error) {
+	names := makeNames(fullMethod)
+	fields := logrus.Fields{
+		"service":     names.Service,
+		"method":      names.Method,
+		"status_code": status.Code(rpcErr).String(),
+	}
+	if rpcErr != nil {
+		fields["status_message"] = status.Convert(rpcErr).Message()
+	}
+
+	for key, value := range auditCallerFields(ctx) {
+		fields[key] = value
+	}
+
+	if msg, ok := req.(proto.Message); ok {
+		request, err := marshalAuditJSON(redactRequest(msg))
+		if err != nil {
+			log.WithError(err).WithField("method", names.Method).Error("Failed to marshal audited request")
+		}
+		fields["request"] = request
+	}
+
+	if results := responseResults(resp); results != nil {
+		marshaled, err := marshalAuditResults(results)
+		if err != nil {
+			log.WithError(err).WithField("method", names.Method).Error("Failed to marshal audited results")
+		}
+		fields["results"] = marshaled
+	}
+
+	log.WithFields(fields).Info("API call")
+}
+
+// auditCallerFields returns the fields that identify the caller, i.e. the
+// caller address and either its SPIFFE ID (TCP callers) or its UID (callers
+// over the UDS).
+func auditCallerFields(ctx context.Context) logrus.Fields {
+	fields := logrus.Fields{}
+
+	callerCtx, err := callerContextFromContext(ctx)
+	if err != nil {
+		return fields
+	}
+	fields["caller_addr"] = rpccontext.CallerAddr(callerCtx).String()
+
+	if id, ok := rpccontext.CallerID(callerCtx); ok {
+		fields["caller_id"] = id.String()
+	}
+	if caller, ok := peertracker.CallerFromContext(ctx); ok {
+		fields["caller_uid"] = caller.UID
+	}
+	return fields
+}
+
+// redactRequest returns a copy of the request with secrets removed. The only
+// secret carried in the audited requests is the join token in the legacy
+// CreateJoinToken request.
+func redactRequest(msg proto.Message) proto.Message {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return msg
+	}
+	token := v.Elem().FieldByName("Token")
+	if !token.IsValid() || token.Kind() != reflect.String || token.String() == "" {
+		return msg
+	}
+
+	redacted := proto.Clone(msg)
+	reflect.ValueOf(redacted).Elem().FieldByName("Token").SetString(redactedValue)
+	return redacted
+}
+
+// responseResults returns the per-item results of a batch operation, or nil
+// if the response does not hold any.
+func responseResults(resp interface{}) []proto.Message {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	results := v.Elem().FieldByName("Results")
+	if !results.IsValid() || results.Kind() != reflect.Slice {
+		return nil
+	}
+
+	out := []proto.Message{}
+	for i := 0; i < results.Len(); i++ {
+		result, ok := results.Index(i).Interface().(proto.Message)
+		if !ok {
+			return nil
+		}
+		out = append(out, result)
+	}
+	return out
+}
+
+func marshalAuditResults(results []proto.Message) (auditJSON, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('[')
+	for i, result := range results {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := auditMarshaler.Marshal(buf, result); err != nil {
+			return "null", err
+		}
+	}
+	buf.WriteByte(']')
+	return auditJSON(buf.String()), nil
+}
+
+func marshalAuditJSON(msg proto.Message) (auditJSON, error) {
+	s, err := auditMarshaler.MarshalToString(msg)
+	if err != nil {
+		return "null", err
+	}
+	return auditJSON(s), nil
+}
+
+// auditJSON holds an already marshaled JSON value. It is embedded as-is by
+// the JSON formatter instead of being quoted as a string.
+type auditJSON string
+
+func (j auditJSON) MarshalJSON() ([]byte, error) {
+	return []byte(j), nil
+}
@@ -0,0 +1,209 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/proto/spire-next/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	batchDeleteEntryMethod = "/spire.api.server.entry.v1.Entry/BatchDeleteEntry"
+	createJoinTokenMethod  = "/spire.api.registration.Registration/CreateJoinToken"
+)
+
+func TestUnaryAuditLogInterceptor(t *testing.T) {
+	adminID := spiffeid.Must("example.org", "admin")
+
+	udsPeer := &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix", Name: "/tmp/spire-registration.sock"},
+		AuthInfo: peertracker.AuthInfo{
+			Caller: peertracker.CallerInfo{UID: 1000},
+		},
+	}
+	mtlsPeer := &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 1234},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				HandshakeComplete: true,
+				PeerCertificates:  []*x509.Certificate{{URIs: []*url.URL{adminID.URL()}}},
+			},
+		},
+	}
+
+	batchDeleteResp := &entry.BatchDeleteEntryResponse{
+		Results: []*entry.BatchDeleteEntryResponse_Result{
+			{Status: &types.Status{Message: "OK"}, Id: "1"},
+			{Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"}, Id: "2"},
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		peer       *peer.Peer
+		fullMethod string
+		req        interface{}
+		resp       interface{}
+		err        error
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "batch call over UDS",
+			peer:       udsPeer,
+			fullMethod: batchDeleteEntryMethod,
+			req:        &entry.BatchDeleteEntryRequest{Ids: []string{"1", "2"}},
+			resp:       batchDeleteResp,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API call",
+					Data: logrus.Fields{
+						"service":     "entry.v1.Entry",
+						"method":      "BatchDeleteEntry",
+						"caller_addr": "/tmp/spire-registration.sock",
+						"caller_uid":  "1000",
+						"request":     `{"ids":["1","2"]}`,
+						"results":     `[{"status":{"message":"OK"},"id":"1"},{"status":{"code":5,"message":"entry not found"},"id":"2"}]`,
+						"status_code": "OK",
+					},
+				},
+			},
+		},
+		{
+			name:       "failed call over TCP",
+			peer:       mtlsPeer,
+			fullMethod: batchDeleteEntryMethod,
+			req:        &entry.BatchDeleteEntryRequest{Ids: []string{"1"}},
+			err:        status.Error(codes.PermissionDenied, "authorization denied"),
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API call",
+					Data: logrus.Fields{
+						"service":        "entry.v1.Entry",
+						"method":         "BatchDeleteEntry",
+						"caller_addr":    "1.1.1.1:1234",
+						"caller_id":      "spiffe://example.org/admin",
+						"request":        `{"ids":["1"]}`,
+						"status_code":    "PermissionDenied",
+						"status_message": "authorization denied",
+					},
+				},
+			},
+		},
+		{
+			name:       "legacy call with redacted join token",
+			peer:       udsPeer,
+			fullMethod: createJoinTokenMethod,
+			req:        &registration.JoinToken{Token: "secret", Ttl: 60},
+			resp:       &registration.JoinToken{Token: "secret", Ttl: 60},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API call",
+					Data: logrus.Fields{
+						"service":     "spire.api.registration.Registration",
+						"method":      "CreateJoinToken",
+						"caller_addr": "/tmp/spire-registration.sock",
+						"caller_uid":  "1000",
+						"request":     `{"token":"[REDACTED]","ttl":60}`,
+						"status_code": "OK",
+					},
+				},
+			},
+		},
+		{
+			name:       "not audited",
+			peer:       udsPeer,
+			fullMethod: fakeFullMethod,
+			req:        &entry.BatchDeleteEntryRequest{Ids: []string{"1"}},
+			resp:       batchDeleteResp,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			interceptor := middleware.UnaryAuditLogInterceptor(log, map[string]bool{
+				batchDeleteEntryMethod: true,
+				createJoinTokenMethod:  true,
+			})
+
+			req := tt.req
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return tt.resp, tt.err
+			}
+
+			ctx := peer.NewContext(context.Background(), tt.peer)
+			resp, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, handler)
+			require.Equal(t, tt.err, err)
+			require.Equal(t, tt.resp, resp)
+
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+		})
+	}
+
+	// The original request must not be modified by the redaction
+	req := &registration.JoinToken{Token: "secret"}
+	log, _ := test.NewNullLogger()
+	interceptor := middleware.UnaryAuditLogInterceptor(log, map[string]bool{createJoinTokenMethod: true})
+	_, err := interceptor(peer.NewContext(context.Background(), udsPeer), req, &grpc.UnaryServerInfo{FullMethod: createJoinTokenMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			require.Equal(t, "secret", req.(*registration.JoinToken).Token)
+			return req, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, "secret", req.Token)
+}
+
+func TestUnaryAuditLogInterceptorWritesJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	log := logrus.New()
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.JSONFormatter{DisableTimestamp: true})
+
+	interceptor := middleware.UnaryAuditLogInterceptor(log, map[string]bool{batchDeleteEntryMethod: true})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix", Name: "/tmp/spire-registration.sock"},
+	})
+	_, err := interceptor(ctx, &entry.BatchDeleteEntryRequest{Ids: []string{"1"}}, &grpc.UnaryServerInfo{FullMethod: batchDeleteEntryMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &entry.BatchDeleteEntryResponse{
+				Results: []*entry.BatchDeleteEntryResponse_Result{
+					{Status: &types.Status{Message: "OK"}, Id: "1"},
+				},
+			}, nil
+		})
+	require.NoError(t, err)
+
+	// Request parameters and results are embedded as JSON values rather than
+	// as quoted strings.
+	require.JSONEq(t, `{
+		"level": "info",
+		"msg": "API call",
+		"service": "entry.v1.Entry",
+		"method": "BatchDeleteEntry",
+		"caller_addr": "/tmp/spire-registration.sock",
+		"request": {"ids": ["1"]},
+		"results": [{"status": {"message": "OK"}, "id": "1"}],
+		"status_code": "OK"
+	}`, buf.String())
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizeAllOf combines authorizers where all authorizers must succeed for
+// the caller to be authorized. The authorizers are invoked in order and the
+// context returned by each authorizer is passed into the next one. The
+// first failure fails the authorization.
+func AuthorizeAllOf(authorizers ...Authorizer) Authorizer {
+	names := make([]string, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		names = append(names, authorizer.Name())
+	}
+
+	return allOfAuthorizer{
+		names:       names,
+		authorizers: authorizers,
+	}
+}
+
+type allOfAuthorizer struct {
+	names       []string
+	authorizers []Authorizer
+}
+
+func (a allOfAuthorizer) Name() string {
+	return fmt.Sprintf("all-of[%s]", strings.Join(a.names, ","))
+}
+
+func (a allOfAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	if len(a.authorizers) == 0 {
+		rpccontext.Logger(ctx).Error("Authorization misconfigured (no authorizers); this is a bug")
+		return nil, status.Error(codes.Internal, "authorization misconfigured (no authorizers)")
+	}
+
+	for _, authorizer := range a.authorizers {
+		var err error
+		ctx, err = authorizer.AuthorizeCaller(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
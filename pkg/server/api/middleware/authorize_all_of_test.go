@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAllOfAuthorizerName(t *testing.T) {
+	authorizer := middleware.AuthorizeAllOf(authorizerA, authorizerB)
+	require.Equal(t, "all-of[A,B]", authorizer.Name())
+}
+
+func TestAllOfAuthorizer(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		authorizers     []middleware.Authorizer
+		expectCode      codes.Code
+		expectMsg       string
+		expectLogs      []spiretest.LogEntry
+		expectWrapCount int
+	}{
+		{
+			name:       "no authorizers",
+			expectCode: codes.Internal,
+			expectMsg:  "authorization misconfigured (no authorizers)",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Authorization misconfigured (no authorizers); this is a bug",
+				},
+			},
+		},
+		{
+			name:            "codes OK and OK",
+			authorizers:     []middleware.Authorizer{authorizerA, authorizerA},
+			expectCode:      codes.OK,
+			expectWrapCount: 3, // 1 initial + two from authorizerA
+		},
+		{
+			name:        "codes OK and PERMISSION_DENIED",
+			authorizers: []middleware.Authorizer{authorizerA, authorizerB},
+			expectCode:  codes.PermissionDenied,
+			expectMsg:   "PermissionDenied",
+		},
+		{
+			name:        "codes INTERNAL and OK",
+			authorizers: []middleware.Authorizer{authorizerC, authorizerA},
+			expectCode:  codes.Internal,
+			expectMsg:   "Internal",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			ctxIn := rpccontext.WithLogger(context.Background(), log)
+			ctxIn = wrapContext(ctxIn)
+
+			authorizer := middleware.AuthorizeAllOf(tt.authorizers...)
+			ctxOut, err := authorizer.AuthorizeCaller(ctxIn)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+			if tt.expectCode == codes.OK {
+				assert.Equal(t, tt.expectWrapCount, wrapCount(ctxOut))
+			} else {
+				assert.Nil(t, ctxOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizeFirstOf combines authorizers where the first authorizer that
+// succeeds authorizes the caller. Unlike AuthorizeAnyOf, the remaining
+// authorizers are not invoked, so only the context from the succeeding
+// authorizer is passed on. Specifically:
+// 1. If an authorizer returns any status code other than OK or
+// PERMISSION_DENIED, the authorization fails.
+// 2. If an authorizer returns OK, authorization succeeds.
+// 3. If all authorizers return PERMISSION_DENIED, or if there are no
+// authorizers, authorization fails.
+func AuthorizeFirstOf(authorizers ...Authorizer) Authorizer {
+	names := make([]string, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		names = append(names, authorizer.Name())
+	}
+
+	return firstOfAuthorizer{
+		names:       names,
+		authorizers: authorizers,
+	}
+}
+
+type firstOfAuthorizer struct {
+	names       []string
+	authorizers []Authorizer
+}
+
+func (a firstOfAuthorizer) Name() string {
+	return fmt.Sprintf("first-of[%s]", strings.Join(a.names, ","))
+}
+
+func (a firstOfAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	for _, authorizer := range a.authorizers {
+		nextCtx, err := authorizer.AuthorizeCaller(ctx)
+		st := status.Convert(err)
+		switch st.Code() {
+		case codes.OK:
+			return nextCtx, nil
+		case codes.PermissionDenied:
+		default:
+			return nil, err
+		}
+	}
+
+	if len(a.authorizers) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "no authorizer allows the caller")
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "caller must be one of %q", a.names)
+}
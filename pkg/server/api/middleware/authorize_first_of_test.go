@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestFirstOfAuthorizerName(t *testing.T) {
+	authorizer := middleware.AuthorizeFirstOf(authorizerA, authorizerB)
+	require.Equal(t, "first-of[A,B]", authorizer.Name())
+}
+
+func TestFirstOfAuthorizer(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		authorizers     []middleware.Authorizer
+		expectCode      codes.Code
+		expectMsg       string
+		expectWrapCount int
+	}{
+		{
+			name:       "no authorizers",
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no authorizer allows the caller",
+		},
+		{
+			name:            "codes OK and OK",
+			authorizers:     []middleware.Authorizer{authorizerA, authorizerA},
+			expectCode:      codes.OK,
+			expectWrapCount: 2, // 1 initial + one from the first authorizerA
+		},
+		{
+			name:            "codes PERMISSION_DENIED and OK",
+			authorizers:     []middleware.Authorizer{authorizerB, authorizerA},
+			expectCode:      codes.OK,
+			expectWrapCount: 2, // 1 initial + one from authorizerA
+		},
+		{
+			name:        "codes PERMISSION_DENIED and PERMISSION_DENIED",
+			authorizers: []middleware.Authorizer{authorizerB, authorizerB},
+			expectCode:  codes.PermissionDenied,
+			expectMsg:   `caller must be one of ["B" "B"]`,
+		},
+		{
+			name:            "codes OK and INTERNAL",
+			authorizers:     []middleware.Authorizer{authorizerA, authorizerC},
+			expectCode:      codes.OK,
+			expectWrapCount: 2, // 1 initial + one from authorizerA
+		},
+		{
+			name:        "codes INTERNAL and OK",
+			authorizers: []middleware.Authorizer{authorizerC, authorizerA},
+			expectCode:  codes.Internal,
+			expectMsg:   "Internal",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctxIn := wrapContext(context.Background())
+
+			authorizer := middleware.AuthorizeFirstOf(tt.authorizers...)
+			ctxOut, err := authorizer.AuthorizeCaller(ctxIn)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			if tt.expectCode == codes.OK {
+				assert.Equal(t, tt.expectWrapCount, wrapCount(ctxOut))
+			} else {
+				assert.Nil(t, ctxOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizeIDPrefix authorizes callers whose SPIFFE ID is equal to or nested
+// under one of the given prefixes (see api.IDHasPrefix).
+func AuthorizeIDPrefix(prefixes ...spiffeid.ID) Authorizer {
+	return idPrefixAuthorizer{prefixes: prefixes}
+}
+
+type idPrefixAuthorizer struct {
+	prefixes []spiffeid.ID
+}
+
+func (idPrefixAuthorizer) Name() string {
+	return "spiffe-id-prefix"
+}
+
+func (a idPrefixAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	id, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "caller does not have a SPIFFE ID")
+	}
+	for _, prefix := range a.prefixes {
+		if api.IDHasPrefix(id, prefix) {
+			return ctx, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "caller SPIFFE ID %q does not match an authorized prefix", id)
+}
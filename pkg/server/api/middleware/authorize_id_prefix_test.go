@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestIDPrefixAuthorizerName(t *testing.T) {
+	authorizer := middleware.AuthorizeIDPrefix(spiffeid.Must("example.org", "team-a"))
+	require.Equal(t, "spiffe-id-prefix", authorizer.Name())
+}
+
+func TestIDPrefixAuthorizer(t *testing.T) {
+	authorizer := middleware.AuthorizeIDPrefix(
+		spiffeid.Must("example.org", "team-a"),
+		spiffeid.Must("example.org", "team-b", "admin"),
+	)
+
+	for _, tt := range []struct {
+		name      string
+		callerID  *spiffeid.ID
+		expectErr string
+	}{
+		{
+			name:     "caller ID is the prefix",
+			callerID: idPtr(spiffeid.Must("example.org", "team-a")),
+		},
+		{
+			name:     "caller ID is under the prefix",
+			callerID: idPtr(spiffeid.Must("example.org", "team-b", "admin", "ops")),
+		},
+		{
+			name:      "caller ID only shares a string prefix",
+			callerID:  idPtr(spiffeid.Must("example.org", "team-ab")),
+			expectErr: `caller SPIFFE ID "spiffe://example.org/team-ab" does not match an authorized prefix`,
+		},
+		{
+			name:      "caller ID is in another trust domain",
+			callerID:  idPtr(spiffeid.Must("other.org", "team-a")),
+			expectErr: `caller SPIFFE ID "spiffe://other.org/team-a" does not match an authorized prefix`,
+		},
+		{
+			name:      "caller has no ID",
+			expectErr: "caller does not have a SPIFFE ID",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctxIn := context.Background()
+			if tt.callerID != nil {
+				ctxIn = rpccontext.WithCallerID(ctxIn, *tt.callerID)
+			}
+			ctxOut, err := authorizer.AuthorizeCaller(ctxIn)
+			if tt.expectErr != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, tt.expectErr)
+				assert.Nil(t, ctxOut)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ctxIn, ctxOut)
+		})
+	}
+}
+
+func idPtr(id spiffeid.ID) *spiffeid.ID {
+	return &id
+}
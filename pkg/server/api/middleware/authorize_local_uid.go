@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizeLocalUID authorizes local callers running as one of the given
+// UIDs.
+func AuthorizeLocalUID(uids ...uint32) Authorizer {
+	return localUIDAuthorizer{uids: uids}
+}
+
+type localUIDAuthorizer struct {
+	uids []uint32
+}
+
+func (localUIDAuthorizer) Name() string {
+	return "local-uid"
+}
+
+func (a localUIDAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	if !rpccontext.CallerIsLocal(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "caller is not local")
+	}
+
+	caller, ok := peertracker.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "caller UID is not available")
+	}
+	for _, uid := range a.uids {
+		if caller.UID == uid {
+			return ctx, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "caller UID %d is not authorized", caller.UID)
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestLocalUIDAuthorizerName(t *testing.T) {
+	authorizer := middleware.AuthorizeLocalUID(1000)
+	require.Equal(t, "local-uid", authorizer.Name())
+}
+
+func TestLocalUIDAuthorizer(t *testing.T) {
+	authorizer := middleware.AuthorizeLocalUID(0, 1000)
+
+	withUID := func(uid uint32) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.UnixAddr{Net: "unix"},
+			AuthInfo: peertracker.AuthInfo{
+				Caller: peertracker.CallerInfo{UID: uid},
+			},
+		})
+		return rpccontext.WithLocalCaller(ctx)
+	}
+
+	t.Run("caller UID is authorized", func(t *testing.T) {
+		ctxIn := withUID(1000)
+		ctxOut, err := authorizer.AuthorizeCaller(ctxIn)
+		require.NoError(t, err)
+		require.Equal(t, ctxIn, ctxOut)
+	})
+
+	t.Run("caller UID is not authorized", func(t *testing.T) {
+		ctxOut, err := authorizer.AuthorizeCaller(withUID(1001))
+		spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "caller UID 1001 is not authorized")
+		assert.Nil(t, ctxOut)
+	})
+
+	t.Run("caller UID is not available", func(t *testing.T) {
+		ctxOut, err := authorizer.AuthorizeCaller(rpccontext.WithLocalCaller(context.Background()))
+		spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "caller UID is not available")
+		assert.Nil(t, ctxOut)
+	})
+
+	t.Run("caller is not local", func(t *testing.T) {
+		ctxOut, err := authorizer.AuthorizeCaller(context.Background())
+		spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "caller is not local")
+		assert.Nil(t, ctxOut)
+	})
+}
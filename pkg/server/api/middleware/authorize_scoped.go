@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+)
+
+// AuthorizeScoped wraps an authorizer so that callers it authorizes are
+// restricted to data that belongs to SPIFFE IDs under the given prefixes. The
+// restriction is enforced by the API handlers (see api.IDInCallerScope).
+func AuthorizeScoped(authorizer Authorizer, scope ...spiffeid.ID) Authorizer {
+	return scopedAuthorizer{
+		authorizer: authorizer,
+		scope:      scope,
+	}
+}
+
+type scopedAuthorizer struct {
+	authorizer Authorizer
+	scope      []spiffeid.ID
+}
+
+func (a scopedAuthorizer) Name() string {
+	return a.authorizer.Name()
+}
+
+func (a scopedAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	ctx, err := a.authorizer.AuthorizeCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rpccontext.WithCallerScope(ctx, a.scope), nil
+}
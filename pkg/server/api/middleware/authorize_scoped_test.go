@@ -0,0 +1,35 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestScopedAuthorizer(t *testing.T) {
+	scope := []spiffeid.ID{spiffeid.Must("example.org", "team-a")}
+
+	require.Equal(t, "A", middleware.AuthorizeScoped(authorizerA, scope...).Name())
+
+	t.Run("authorized callers are scoped", func(t *testing.T) {
+		ctxOut, err := middleware.AuthorizeScoped(authorizerA, scope...).AuthorizeCaller(wrapContext(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, 2, wrapCount(ctxOut))
+		callerScope, ok := rpccontext.CallerScope(ctxOut)
+		require.True(t, ok)
+		require.Equal(t, scope, callerScope)
+	})
+
+	t.Run("unauthorized callers are rejected", func(t *testing.T) {
+		ctxOut, err := middleware.AuthorizeScoped(authorizerB, scope...).AuthorizeCaller(context.Background())
+		spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "PermissionDenied")
+		assert.Nil(t, ctxOut)
+	})
+}
@@ -8,19 +8,16 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ratelimit"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const (
-	// gcInterval is the interval at which per-ip limiters are garbage
-	// collected.
-	gcInterval = time.Minute
-)
-
 var (
 	// Used to manipulate time in unit tests
 	clk = clock.New()
@@ -36,6 +33,7 @@ var (
 
 // rawRateLimiter represents the raw limiter functionality.
 type rawRateLimiter interface {
+	AllowN(now time.Time, count int) bool
 	WaitN(ctx context.Context, count int) error
 	Limit() rate.Limit
 	Burst() int
@@ -58,7 +56,34 @@ func PerCallLimit(limit int) api.RateLimiter {
 // to a method. It can be shared across methods to enforce per-ip limits for
 // a group of methods.
 func PerIPLimit(limit int) api.RateLimiter {
-	return newPerIPLimiter(limit)
+	return newPerCallerLimiter("", CallerLimits{IP: limit}, nil)
+}
+
+// CallerLimits are the limits, in messages per second, that a per-caller
+// rate limiter imposes on each caller.
+type CallerLimits struct {
+	// Agent, if set, is the limit for each agent, keyed by agent SPIFFE ID.
+	// Otherwise, agents are limited by IP address.
+	Agent int
+
+	// Downstream, if set, is the limit for each downstream workload, keyed
+	// by SPIFFE ID. Otherwise, downstream workloads are limited by IP
+	// address.
+	Downstream int
+
+	// IP is the limit for each IP address, for the rest of the callers.
+	IP int
+}
+
+// PerCallerLimit returns a rate limiter that imposes a per-caller limit on
+// calls to a method. Callers are limited by their SPIFFE ID or their IP
+// address according to their class and the configured limits. Calls that
+// are throttled, and the per-caller limiters evicted after going unused for
+// a GC interval, are counted in the limiter metrics under the given name. It
+// can be shared across methods to enforce per-caller limits for a group of
+// methods.
+func PerCallerLimit(name string, limits CallerLimits, metrics telemetry.Metrics) api.RateLimiter {
+	return newPerCallerLimiter(name, limits, metrics)
 }
 
 // WithRateLimits returns a middleware that performs rate limiting for the
@@ -107,77 +132,93 @@ func (lim *perCallLimiter) RateLimit(ctx context.Context, count int) error {
 	return waitN(ctx, lim.limiter, count)
 }
 
-type perIPLimiter struct {
-	limit int
+type perCallerLimiter struct {
+	name    string
+	limits  CallerLimits
+	metrics telemetry.Metrics
 
 	mtx sync.RWMutex
 
-	// previous holds all of the limiters that were current at the GC
-	previous map[string]rawRateLimiter
-
-	// current holds all of the limiters that have been created or moved
-	// from the previous limiters since the last GC.
-	current map[string]rawRateLimiter
-
-	// lastGC is the last GC
-	lastGC time.Time
+	// limiters holds the limiter of each caller
+	limiters *ratelimit.CallerCache
 }
 
-func newPerIPLimiter(limit int) *perIPLimiter {
-	return &perIPLimiter{limit: limit,
-		current: make(map[string]rawRateLimiter),
-		lastGC:  clk.Now(),
+func newPerCallerLimiter(name string, limits CallerLimits, metrics telemetry.Metrics) *perCallerLimiter {
+	return &perCallerLimiter{
+		name:     name,
+		limits:   limits,
+		metrics:  metrics,
+		limiters: ratelimit.NewCallerCache(ratelimit.GCInterval, clk.Now()),
 	}
 }
 
-func (lim *perIPLimiter) RateLimit(ctx context.Context, count int) error {
-	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+func (lim *perCallerLimiter) RateLimit(ctx context.Context, count int) error {
+	class, key, limit, ok := lim.callerKey(ctx)
 	if !ok {
 		// Calls not via TCP/IP aren't limited
 		return nil
 	}
-	limiter := lim.getLimiter(tcpAddr.IP.String())
+
+	limiter := lim.getLimiter(key, limit)
+	if limiter.AllowN(clk.Now(), count) {
+		return nil
+	}
+
+	if lim.metrics != nil {
+		telemetry_server.IncrRateLimitThrottledCounter(lim.metrics, lim.name, class)
+	}
 	return waitN(ctx, limiter, count)
 }
 
-func (lim *perIPLimiter) getLimiter(ip string) rawRateLimiter {
+// callerKey returns the class of the caller, the key of the caller limiter
+// and the limit that applies to it.
+func (lim *perCallerLimiter) callerKey(ctx context.Context) (string, string, int, bool) {
+	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+	if !ok {
+		return "", "", 0, false
+	}
+
+	if id, ok := rpccontext.CallerID(ctx); ok {
+		switch {
+		case lim.limits.Agent > 0 && rpccontext.CallerIsAgent(ctx):
+			return ratelimit.CallerClassAgent, ratelimit.CallerClassAgent + ":" + id.String(), lim.limits.Agent, true
+		case lim.limits.Downstream > 0 && rpccontext.CallerIsDownstream(ctx):
+			return ratelimit.CallerClassDownstream, ratelimit.CallerClassDownstream + ":" + id.String(), lim.limits.Downstream, true
+		}
+	}
+
+	return ratelimit.CallerClassIP, tcpAddr.IP.String(), lim.limits.IP, true
+}
+
+func (lim *perCallerLimiter) getLimiter(key string, limit int) rawRateLimiter {
 	lim.mtx.RLock()
-	limiter, ok := lim.current[ip]
+	limiter, ok := lim.limiters.Peek(key)
 	if ok {
 		lim.mtx.RUnlock()
-		return limiter
+		return limiter.(rawRateLimiter)
 	}
 	lim.mtx.RUnlock()
 
-	// A limiter does not exist for that address.
+	// A limiter does not exist for that caller.
 	lim.mtx.Lock()
 	defer lim.mtx.Unlock()
 
-	// Check the "current" entries in case another goroutine raced on this IP.
-	if limiter, ok = lim.current[ip]; ok {
-		return limiter
-	}
-
-	// Then check the "previous" entries to see if a limiter exists for this
-	// IP as of the last GC. If so, move it to current and return it.
-	if limiter, ok = lim.previous[ip]; ok {
-		lim.current[ip] = limiter
-		delete(lim.previous, ip)
-		return limiter
+	// Check the limiters again in case another goroutine raced on this
+	// caller. A limiter that has not been used since the last GC moves back
+	// to the current limiters.
+	if limiter, ok = lim.limiters.Get(key); ok {
+		return limiter.(rawRateLimiter)
 	}
 
-	// There is no limiter for this IP. Before we create one, we should see
-	// if we need to do GC.
-	now := clk.Now()
-	if now.Sub(lim.lastGC) >= gcInterval {
-		lim.previous = lim.current
-		lim.current = make(map[string]rawRateLimiter)
-		lim.lastGC = now
+	// There is no limiter for this caller. Before we create one, we should
+	// see if we need to do GC.
+	if evicted, _ := lim.limiters.GC(clk.Now()); evicted > 0 && lim.metrics != nil {
+		telemetry_server.IncrRateLimitEvictedCounter(lim.metrics, lim.name, evicted)
 	}
 
-	limiter = newRawRateLimiter(rate.Limit(lim.limit), lim.limit)
-	lim.current[ip] = limiter
-	return limiter
+	newLimiter := newRawRateLimiter(rate.Limit(limit), limit)
+	lim.limiters.Set(key, newLimiter)
+	return newLimiter
 }
 
 type rateLimitsMiddleware struct {
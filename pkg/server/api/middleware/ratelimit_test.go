@@ -5,12 +5,18 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ratelimit"
+	"github.com/spiffe/spire/proto/spire-next/types"
 	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -99,7 +105,7 @@ func TestPerIPLimitGC(t *testing.T) {
 	// Advance past the GC time and create for limiter for 3.3.3.3. This should
 	// move both 1.1.1.1 and 2.2.2.2 into the "previous" set. There should be
 	// three total limiters now.
-	mockClk.Add(gcInterval)
+	mockClk.Add(ratelimit.GCInterval)
 	require.NoError(t, m.RateLimit(tcpCallerContext("3.3.3.3"), 1))
 	require.Equal(t, 3, limiters.Count)
 
@@ -111,7 +117,7 @@ func TestPerIPLimitGC(t *testing.T) {
 	// Advance to the next GC time. Create a limiter for 4.4.4.4. This should
 	// cause 2.2.2.2 to be removed. 1.1.1.1 and 3.3.3.3 will go into the
 	// "previous set".
-	mockClk.Add(gcInterval)
+	mockClk.Add(ratelimit.GCInterval)
 	require.NoError(t, m.RateLimit(tcpCallerContext("4.4.4.4"), 1))
 	require.Equal(t, 4, limiters.Count)
 
@@ -127,6 +133,121 @@ func TestPerIPLimitGC(t *testing.T) {
 	require.Equal(t, 5, limiters.Count)
 }
 
+func TestPerCallerLimit(t *testing.T) {
+	limiters := NewFakeLimiters()
+	metrics := fakemetrics.New()
+
+	m := PerCallerLimit("csr", CallerLimits{Agent: 5, Downstream: 3, IP: 2}, metrics)
+
+	agentID := spiffeid.Must("example.org", "spire", "agent", "foo")
+	downstreamID := spiffeid.Must("example.org", "downstream")
+	workloadID := spiffeid.Must("example.org", "workload")
+
+	agentCtx := func(ip string, id spiffeid.ID) context.Context {
+		return rpccontext.WithAgentCaller(rpccontext.WithCallerID(tcpCallerContext(ip), id))
+	}
+	downstreamCtx := func(ip string) context.Context {
+		ctx := rpccontext.WithCallerID(tcpCallerContext(ip), downstreamID)
+		return rpccontext.WithCallerDownstreamEntries(ctx, []*types.Entry{{Id: "entry"}})
+	}
+
+	// Does not rate limit non-TCP/IP callers
+	require.NoError(t, m.RateLimit(unixCallerContext(), 99))
+
+	// Agents are limited by SPIFFE ID, even when sharing an IP address.
+	require.NoError(t, m.RateLimit(agentCtx("1.1.1.1", agentID), 5))
+	require.NoError(t, m.RateLimit(agentCtx("2.2.2.2", agentID), 1))
+	require.NoError(t, m.RateLimit(agentCtx("1.1.1.1", spiffeid.Must("example.org", "spire", "agent", "bar")), 1))
+
+	// Downstream workloads are limited by SPIFFE ID with their own limit.
+	err := m.RateLimit(downstreamCtx("1.1.1.1"), 4)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (4) exceeds burst size (3)")
+
+	// Other callers are limited by IP address.
+	err = m.RateLimit(rpccontext.WithCallerID(tcpCallerContext("1.1.1.1"), workloadID), 3)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (3) exceeds burst size (2)")
+
+	// There should be four rate limiters; two agents, the downstream
+	// workload and 1.1.1.1.
+	assert.Equal(t, 4, limiters.Count)
+	assert.Equal(t, []WaitNEvent{
+		{ID: 1, Count: 5},
+		{ID: 1, Count: 1},
+		{ID: 2, Count: 1},
+	}, limiters.WaitNEvents)
+
+	// The fake limiters always throttle, so every limited call is counted.
+	throttled := func(class string) fakemetrics.MetricItem {
+		return fakemetrics.MetricItem{
+			Type: fakemetrics.IncrCounterWithLabelsType,
+			Key:  []string{"rate_limit", "throttled"},
+			Val:  1,
+			Labels: []telemetry.Label{
+				{Name: "limiter", Value: "csr"},
+				{Name: "caller_class", Value: class},
+			},
+		}
+	}
+	assert.Equal(t, []fakemetrics.MetricItem{
+		throttled("agent"),
+		throttled("agent"),
+		throttled("agent"),
+		throttled("downstream"),
+		throttled("ip"),
+	}, metrics.AllMetrics())
+}
+
+func TestPerCallerLimitFallsBackToIP(t *testing.T) {
+	limiters := NewFakeLimiters()
+
+	m := PerCallerLimit("csr", CallerLimits{IP: 2}, nil)
+
+	// Without agent and downstream limits, agents are limited by IP address.
+	agentCtx := rpccontext.WithAgentCaller(rpccontext.WithCallerID(tcpCallerContext("1.1.1.1"),
+		spiffeid.Must("example.org", "spire", "agent", "foo")))
+	err := m.RateLimit(agentCtx, 3)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (3) exceeds burst size (2)")
+	require.NoError(t, m.RateLimit(tcpCallerContext("1.1.1.1"), 2))
+	assert.Equal(t, 1, limiters.Count)
+}
+
+func TestPerCallerLimitEviction(t *testing.T) {
+	mockClk, restoreClk := setupClock(t)
+	defer restoreClk()
+
+	NewFakeLimiters()
+	metrics := fakemetrics.New()
+
+	m := PerCallerLimit("attest", CallerLimits{IP: 2}, metrics)
+	require.NoError(t, m.RateLimit(tcpCallerContext("1.1.1.1"), 1))
+	require.NoError(t, m.RateLimit(tcpCallerContext("2.2.2.2"), 1))
+
+	// After two GC intervals without calls, both limiters are evicted.
+	mockClk.Add(ratelimit.GCInterval)
+	require.NoError(t, m.RateLimit(tcpCallerContext("3.3.3.3"), 1))
+	mockClk.Add(ratelimit.GCInterval)
+	metrics.Reset()
+	require.NoError(t, m.RateLimit(tcpCallerContext("4.4.4.4"), 1))
+
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{"rate_limit", "evicted"},
+			Val:    2,
+			Labels: []telemetry.Label{{Name: "limiter", Value: "attest"}},
+		},
+		{
+			Type: fakemetrics.IncrCounterWithLabelsType,
+			Key:  []string{"rate_limit", "throttled"},
+			Val:  1,
+			Labels: []telemetry.Label{
+				{Name: "limiter", Value: "attest"},
+				{Name: "caller_class", Value: "ip"},
+			},
+		},
+	}, metrics.AllMetrics())
+}
+
 func TestRateLimits(t *testing.T) {
 	unaryInterceptor := UnaryInterceptor(
 		WithRateLimits(
@@ -281,6 +402,11 @@ type fakeLimiter struct {
 	burst int
 }
 
+func (l *fakeLimiter) AllowN(now time.Time, count int) bool {
+	// Always take the slow path so calls are recorded as WaitN events.
+	return false
+}
+
 func (l *fakeLimiter) WaitN(ctx context.Context, count int) error {
 	switch {
 	case l.limit == rate.Inf:
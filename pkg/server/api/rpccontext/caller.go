@@ -16,6 +16,7 @@ type callerAdminEntriesKey struct{}
 type callerDownstreamEntriesKey struct{}
 type callerLocalTagKey struct{}
 type callerAgentTagKey struct{}
+type callerScopeKey struct{}
 
 // WithCallerAddr returns a context with the given address.
 func WithCallerAddr(ctx context.Context, addr net.Addr) context.Context {
@@ -106,3 +107,16 @@ func CallerIsAgent(ctx context.Context) bool {
 	_, ok := ctx.Value(callerAgentTagKey{}).(struct{})
 	return ok
 }
+
+// WithCallerScope returns a context with the given scope, i.e. the SPIFFE ID
+// prefixes the caller is restricted to.
+func WithCallerScope(ctx context.Context, scope []spiffeid.ID) context.Context {
+	return context.WithValue(ctx, callerScopeKey{}, scope)
+}
+
+// CallerScope returns the SPIFFE ID prefixes the caller is restricted to. If
+// the caller is not restricted, it returns false.
+func CallerScope(ctx context.Context) ([]spiffeid.ID, bool) {
+	scope, ok := ctx.Value(callerScopeKey{}).([]spiffeid.ID)
+	return scope, ok
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+)
+
+// IDHasPrefix returns true if the ID is in the same trust domain as the
+// prefix and its path is equal to or nested under the prefix path. A prefix
+// without a path covers the whole trust domain.
+func IDHasPrefix(id, prefix spiffeid.ID) bool {
+	if !id.MemberOf(prefix.TrustDomain()) {
+		return false
+	}
+	prefixPath := strings.TrimSuffix(prefix.Path(), "/")
+	if prefixPath == "" {
+		return true
+	}
+	return id.Path() == prefixPath || strings.HasPrefix(id.Path(), prefixPath+"/")
+}
+
+// IDInCallerScope returns true if the caller is allowed to access data that
+// belongs to the given ID. Callers that are not restricted to a scope can
+// access any data.
+func IDInCallerScope(ctx context.Context, id spiffeid.ID) bool {
+	scope, ok := rpccontext.CallerScope(ctx)
+	if !ok {
+		return true
+	}
+	for _, prefix := range scope {
+		if IDHasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IDStringInCallerScope is like IDInCallerScope but takes the ID as a
+// string. Malformed IDs are only in scope if the caller is not restricted.
+func IDStringInCallerScope(ctx context.Context, id string) bool {
+	if _, ok := rpccontext.CallerScope(ctx); !ok {
+		return true
+	}
+	spiffeID, err := spiffeid.FromString(id)
+	if err != nil {
+		return false
+	}
+	return IDInCallerScope(ctx, spiffeID)
+}
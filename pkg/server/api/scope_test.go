@@ -0,0 +1,43 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDHasPrefix(t *testing.T) {
+	teamA := spiffeid.Must("example.org", "team-a")
+
+	require.True(t, api.IDHasPrefix(teamA, teamA))
+	require.True(t, api.IDHasPrefix(spiffeid.Must("example.org", "team-a", "db"), teamA))
+	require.True(t, api.IDHasPrefix(teamA, spiffeid.Must("example.org")))
+	require.False(t, api.IDHasPrefix(spiffeid.Must("example.org", "team-ab"), teamA))
+	require.False(t, api.IDHasPrefix(spiffeid.Must("example.org"), teamA))
+	require.False(t, api.IDHasPrefix(spiffeid.Must("other.org", "team-a"), teamA))
+}
+
+func TestIDInCallerScope(t *testing.T) {
+	teamA := spiffeid.Must("example.org", "team-a")
+	teamB := spiffeid.Must("example.org", "team-b")
+
+	// Callers without a scope can access anything
+	ctx := context.Background()
+	require.True(t, api.IDInCallerScope(ctx, teamB))
+	require.True(t, api.IDStringInCallerScope(ctx, "malformed"))
+
+	ctx = rpccontext.WithCallerScope(ctx, []spiffeid.ID{teamA})
+	require.True(t, api.IDInCallerScope(ctx, spiffeid.Must("example.org", "team-a", "db")))
+	require.False(t, api.IDInCallerScope(ctx, teamB))
+	require.True(t, api.IDStringInCallerScope(ctx, "spiffe://example.org/team-a/db"))
+	require.False(t, api.IDStringInCallerScope(ctx, "spiffe://example.org/team-b/db"))
+	require.False(t, api.IDStringInCallerScope(ctx, "malformed"))
+
+	// An empty scope allows nothing
+	ctx = rpccontext.WithCallerScope(context.Background(), []spiffeid.ID{})
+	require.False(t, api.IDInCallerScope(ctx, teamA))
+}
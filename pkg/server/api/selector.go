@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// SelectorsFromProto converts a slice of types.Selector to
+// a slice of common.Selector
+func SelectorsFromProto(proto []*types.Selector) ([]*common.Selector, error) {
+	var selectors []*common.Selector
+	for _, s := range proto {
+		switch {
+		case s.Type == "":
+			return nil, errors.New("missing selector type")
+		case s.Value == "":
+			return nil, errors.New("missing selector value")
+		}
+
+		selectors = append(selectors, &common.Selector{
+			Type:  s.Type,
+			Value: s.Value,
+		})
+	}
+
+	return selectors, nil
+}
+
+// ProtoFromSelectors converts a slice of common.Selector to
+// a slice of types.Selector
+func ProtoFromSelectors(in []*common.Selector) []*types.Selector {
+	var out []*types.Selector
+	for _, s := range in {
+		out = append(out, &types.Selector{
+			Type:  s.Type,
+			Value: s.Value,
+		})
+	}
+	return out
+}
+
+// BySelectorsFromProto converts a types.SelectorMatch filter to its
+// datastore equivalent
+func BySelectorsFromProto(match *types.SelectorMatch) (*datastore.BySelectors, error) {
+	if len(match.Selectors) == 0 {
+		return nil, errors.New("empty selector set")
+	}
+
+	selectors, err := SelectorsFromProto(match.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	var behavior datastore.BySelectors_MatchBehavior
+	switch match.Match {
+	case types.SelectorMatch_MATCH_EXACT:
+		behavior = datastore.BySelectors_MATCH_EXACT
+	case types.SelectorMatch_MATCH_SUBSET:
+		behavior = datastore.BySelectors_MATCH_SUBSET
+	case types.SelectorMatch_MATCH_ANY:
+		behavior = datastore.BySelectors_MATCH_ANY
+	case types.SelectorMatch_MATCH_SUPERSET:
+		behavior = datastore.BySelectors_MATCH_SUPERSET
+	default:
+		return nil, fmt.Errorf("unsupported match behavior %q", match.Match)
+	}
+
+	return &datastore.BySelectors{
+		Selectors: selectors,
+		Match:     behavior,
+	}, nil
+}
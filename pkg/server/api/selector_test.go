@@ -0,0 +1,152 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorsFromProto(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		proto     []*types.Selector
+		selectors []*common.Selector
+		err       string
+	}{
+		{
+			name: "success",
+			proto: []*types.Selector{
+				{Type: "unix", Value: "uid:1000"},
+				{Type: "unix", Value: "gid:1000"},
+			},
+			selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:1000"},
+				{Type: "unix", Value: "gid:1000"},
+			},
+		},
+		{
+			name: "empty",
+		},
+		{
+			name:  "missing type",
+			proto: []*types.Selector{{Value: "uid:1000"}},
+			err:   "missing selector type",
+		},
+		{
+			name:  "missing value",
+			proto: []*types.Selector{{Type: "unix"}},
+			err:   "missing selector value",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			selectors, err := api.SelectorsFromProto(tt.proto)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, selectors)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.selectors, selectors)
+		})
+	}
+}
+
+func TestProtoFromSelectors(t *testing.T) {
+	require.Nil(t, api.ProtoFromSelectors(nil))
+	require.Equal(t, []*types.Selector{
+		{Type: "unix", Value: "uid:1000"},
+	}, api.ProtoFromSelectors([]*common.Selector{
+		{Type: "unix", Value: "uid:1000"},
+	}))
+}
+
+func TestBySelectorsFromProto(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		match       *types.SelectorMatch
+		bySelectors *datastore.BySelectors
+		err         string
+	}{
+		{
+			name: "exact",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     types.SelectorMatch_MATCH_EXACT,
+			},
+			bySelectors: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     datastore.BySelectors_MATCH_EXACT,
+			},
+		},
+		{
+			name: "subset",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     types.SelectorMatch_MATCH_SUBSET,
+			},
+			bySelectors: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     datastore.BySelectors_MATCH_SUBSET,
+			},
+		},
+		{
+			name: "any",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     types.SelectorMatch_MATCH_ANY,
+			},
+			bySelectors: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     datastore.BySelectors_MATCH_ANY,
+			},
+		},
+		{
+			name: "superset",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     types.SelectorMatch_MATCH_SUPERSET,
+			},
+			bySelectors: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     datastore.BySelectors_MATCH_SUPERSET,
+			},
+		},
+		{
+			name:  "empty",
+			match: &types.SelectorMatch{},
+			err:   "empty selector set",
+		},
+		{
+			name: "malformed selector",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Value: "uid:1000"}},
+			},
+			err: "missing selector type",
+		},
+		{
+			name: "unsupported match behavior",
+			match: &types.SelectorMatch{
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Match:     99,
+			},
+			err: `unsupported match behavior "99"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bySelectors, err := api.BySelectorsFromProto(tt.match)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, bySelectors)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.bySelectors, bySelectors)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire-next/api/server/svid/v1"
 	"github.com/spiffe/spire/proto/spire-next/types"
 	"google.golang.org/grpc"
@@ -26,21 +27,12 @@ func RegisterService(s *grpc.Server, service *Service) {
 	svid.RegisterSVIDServer(s, service)
 }
 
-type AuthorizedEntryFetcher interface {
-	FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error)
-}
-
-type AuthorizedEntryFetcherFunc func(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error)
-
-func (fn AuthorizedEntryFetcherFunc) FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
-	return fn(ctx, agentID)
-}
-
 // Config is the service configuration
 type Config struct {
-	EntryFetcher AuthorizedEntryFetcher
+	EntryFetcher api.AuthorizedEntryFetcher
 	ServerCA     ca.ServerCA
 	TrustDomain  spiffeid.TrustDomain
+	Datastore    datastore.DataStore
 }
 
 // New creates a new SVID service
@@ -49,14 +41,16 @@ func New(config Config) *Service {
 		ca: config.ServerCA,
 		ef: config.EntryFetcher,
 		td: config.TrustDomain,
+		ds: config.Datastore,
 	}
 }
 
 // Service implements the v1 SVID service
 type Service struct {
 	ca ca.ServerCA
-	ef AuthorizedEntryFetcher
+	ef api.AuthorizedEntryFetcher
 	td spiffeid.TrustDomain
+	ds datastore.DataStore
 }
 
 func (s *Service) MintX509SVID(ctx context.Context, req *svid.MintX509SVIDRequest) (*svid.MintX509SVIDResponse, error) {
@@ -106,11 +100,12 @@ func (s *Service) MintX509SVID(ctx context.Context, req *svid.MintX509SVIDReques
 	}
 
 	x509SVID, err := s.ca.SignX509SVID(ctx, ca.X509SVIDParams{
-		SpiffeID:  id.String(),
-		PublicKey: csr.PublicKey,
-		TTL:       time.Duration(req.Ttl) * time.Second,
-		DNSList:   csr.DNSNames,
-		Subject:   csr.Subject,
+		SpiffeID:    id.String(),
+		PublicKey:   csr.PublicKey,
+		TTL:         time.Duration(req.Ttl) * time.Second,
+		DNSList:     csr.DNSNames,
+		Subject:     csr.Subject,
+		IPAddresses: csr.IPAddresses,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign X509-SVID")
@@ -231,11 +226,22 @@ func (s *Service) newX509SVID(ctx context.Context, param *svid.NewX509SVIDParams
 	}
 	log = log.WithField(telemetry.SPIFFEID, spiffeID.String())
 
+	profile, err := x509util.ParseSVIDProfile(api.X509SVIDProfileFromProto(entry.X509SvidProfile))
+	if err != nil {
+		// This shouldn't be the case unless there is invalid data in the datastore
+		log.WithError(err).Error("Entry has malformed X509-SVID profile")
+		return nil, status.Error(codes.Internal, "entry has malformed X509-SVID profile")
+	}
+
 	x509Svid, err := s.ca.SignX509SVID(ctx, ca.X509SVIDParams{
-		SpiffeID:  spiffeID.String(),
-		PublicKey: csr.PublicKey,
-		DNSList:   entry.DnsNames,
-		TTL:       time.Duration(entry.Ttl) * time.Second,
+		SpiffeID:    spiffeID.String(),
+		PublicKey:   csr.PublicKey,
+		DNSList:     entry.DnsNames,
+		TTL:         time.Duration(entry.Ttl) * time.Second,
+		Subject:     profile.Subject,
+		IPAddresses: profile.IPAddresses,
+		URIs:        profile.URIs,
+		ExtKeyUsage: profile.ExtKeyUsage,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign X509-SVID")
@@ -314,7 +320,7 @@ func (s *Service) NewJWTSVID(ctx context.Context, req *svid.NewJWTSVIDRequest) (
 		return nil, status.Error(codes.NotFound, "entry not found or not authorized")
 	}
 
-	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, req.Audience, entry.Ttl)
+	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, req.Audience, entry.JwtSvidTtl)
 	if err != nil {
 		return nil, err
 	}
@@ -324,6 +330,85 @@ func (s *Service) NewJWTSVID(ctx context.Context, req *svid.NewJWTSVIDRequest) (
 	}, nil
 }
 
-func (s *Service) NewDownstreamX509CA(context.Context, *svid.NewDownstreamX509CARequest) (*svid.NewDownstreamX509CAResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented")
+func (s *Service) NewDownstreamX509CA(ctx context.Context, req *svid.NewDownstreamX509CARequest) (*svid.NewDownstreamX509CAResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if err := rpccontext.RateLimit(ctx, 1); err != nil {
+		log.WithError(err).Error("Rejecting request due to downstream CA signing rate limit")
+		return nil, err
+	}
+
+	switch {
+	case req.EntryId == "":
+		log.Error("Invalid request: missing entry ID")
+		return nil, status.Error(codes.InvalidArgument, "missing entry ID")
+	case len(req.Csr) == 0:
+		log.Error("Invalid request: missing CSR")
+		return nil, status.Error(codes.InvalidArgument, "missing CSR")
+	}
+
+	log = log.WithField(telemetry.RegistrationID, req.EntryId)
+
+	entries, ok := rpccontext.CallerDownstreamEntries(ctx)
+	if !ok {
+		log.Error("Downstream entries missing from request context")
+		return nil, status.Error(codes.Internal, "downstream entries missing from request context")
+	}
+
+	var entry *types.Entry
+	for _, downstreamEntry := range entries {
+		if downstreamEntry.Id == req.EntryId {
+			entry = downstreamEntry
+			break
+		}
+	}
+	if entry == nil {
+		log.Error("Invalid request: entry not found or not authorized")
+		return nil, status.Error(codes.NotFound, "entry not found or not authorized")
+	}
+
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		log.WithError(err).Error("Invalid request: malformed CSR")
+		return nil, status.Errorf(codes.InvalidArgument, "malformed CSR: %v", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		log.WithError(err).Error("Invalid request: invalid CSR signature")
+		return nil, status.Error(codes.InvalidArgument, "invalid CSR signature")
+	}
+
+	// The downstream CA is issued for the trust domain; the entry only
+	// determines the TTL.
+	x509CASVID, err := s.ca.SignX509CASVID(ctx, ca.X509CASVIDParams{
+		SpiffeID:  s.td.IDString(),
+		PublicKey: csr.PublicKey,
+		TTL:       time.Duration(entry.Ttl) * time.Second,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to sign downstream X.509 CA")
+		return nil, status.Errorf(codes.Internal, "failed to sign downstream X.509 CA: %v", err)
+	}
+
+	resp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: s.td.IDString(),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch bundle")
+		return nil, status.Errorf(codes.Internal, "failed to fetch bundle: %v", err)
+	}
+	if resp.Bundle == nil {
+		log.Error("Bundle not found")
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	var x509Authorities [][]byte
+	for _, rootCA := range resp.Bundle.RootCas {
+		x509Authorities = append(x509Authorities, rootCA.DerBytes)
+	}
+
+	return &svid.NewDownstreamX509CAResponse{
+		CaCertChain:     x509util.RawCertsFromCertificates(x509CASVID),
+		X509Authorities: x509Authorities,
+	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"testing"
 	"time"
@@ -19,8 +20,11 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/api/svid/v1"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	svidpb "github.com/spiffe/spire/proto/spire-next/api/server/svid/v1"
 	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/testkey"
@@ -51,6 +55,7 @@ func TestServiceMintX509SVID(t *testing.T) {
 		code        codes.Code
 		csrTemplate *x509.CertificateRequest
 		dns         []string
+		ips         []net.IP
 		err         string
 		expiredAt   time.Time
 		msg         string
@@ -112,6 +117,16 @@ func TestServiceMintX509SVID(t *testing.T) {
 			expiredAt: expiredAt,
 			subject:   "CN=dns1,O=ORG,C=US+C=EN",
 		},
+		{
+			name: "custom ip addresses",
+			csrTemplate: &x509.CertificateRequest{
+				URIs:        []*url.URL{workloadID.URL()},
+				IPAddresses: []net.IP{net.IPv4(10, 0, 0, 1).To4()},
+			},
+			ips:       []net.IP{net.IPv4(10, 0, 0, 1).To4()},
+			expiredAt: expiredAt,
+			subject:   "O=SPIRE,C=US",
+		},
 		{
 			name: "no CSR",
 			code: codes.InvalidArgument,
@@ -267,6 +282,7 @@ func TestServiceMintX509SVID(t *testing.T) {
 				require.Equal(t, tt.dns[0], svid.Subject.CommonName)
 			}
 			require.Equal(t, tt.dns, svid.DNSNames)
+			require.Equal(t, tt.ips, svid.IPAddresses)
 			require.Equal(t, tt.subject, svid.Subject.String())
 		})
 	}
@@ -388,9 +404,16 @@ func TestServiceNewJWTSVID(t *testing.T) {
 		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
 	}
 	entryWithTTL := &types.Entry{
-		Id:       "agent-entry-ttl-id",
+		Id:         "agent-entry-ttl-id",
+		ParentId:   api.ProtoFromID(agentID),
+		SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-ttl"},
+		Ttl:        3600,
+		JwtSvidTtl: 10,
+	}
+	entryWithX509TTL := &types.Entry{
+		Id:       "agent-entry-x509-ttl-id",
 		ParentId: api.ProtoFromID(agentID),
-		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-ttl"},
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-x509-ttl"},
 		Ttl:      10,
 	}
 	invalidEntry := &types.Entry{
@@ -399,7 +422,7 @@ func TestServiceNewJWTSVID(t *testing.T) {
 		SpiffeId: &types.SPIFFEID{},
 	}
 
-	test.ef.entries = []*types.Entry{entry, entryWithTTL, invalidEntry}
+	test.ef.entries = []*types.Entry{entry, entryWithTTL, entryWithX509TTL, invalidEntry}
 	jwtKey := test.ca.JWTKey()
 	now := test.ca.Clock().Now().UTC()
 
@@ -431,6 +454,12 @@ func TestServiceNewJWTSVID(t *testing.T) {
 			entry:     entryWithTTL,
 			expiresAt: now.Add(10 * time.Second),
 		},
+		{
+			name:      "X509-SVID TTL is ignored",
+			audience:  []string{"AUDIENCE"},
+			entry:     entryWithX509TTL,
+			expiresAt: expiresAt,
+		},
 		{
 			name:     "no SPIFFE ID",
 			code:     codes.InvalidArgument,
@@ -517,7 +546,7 @@ func TestServiceNewJWTSVID(t *testing.T) {
 				issuedAt,
 				tt.expiresAt,
 				expiresAt,
-				time.Duration(tt.entry.Ttl)*time.Second)
+				time.Duration(tt.entry.JwtSvidTtl)*time.Second)
 		})
 	}
 }
@@ -921,14 +950,254 @@ func TestServiceBatchNewX509SVID(t *testing.T) {
 	}
 }
 
+func TestServiceBatchNewX509SVIDWithProfile(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	profileEntry := &types.Entry{
+		Id:       "profile",
+		ParentId: api.ProtoFromID(agentID),
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "legacy"},
+		DnsNames: []string{"legacy.example.org"},
+		X509SvidProfile: &types.X509SVIDProfile{
+			Subject: &types.X509Subject{
+				Organization:       []string{"Acme"},
+				OrganizationalUnit: []string{"Legacy"},
+			},
+			IpAddresses:  []string{"10.0.0.1"},
+			Uris:         []string{"urn:acme:legacy"},
+			ExtKeyUsages: []string{"clientAuth"},
+		},
+	}
+	malformedEntry := &types.Entry{
+		Id:       "malformed",
+		ParentId: api.ProtoFromID(agentID),
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "malformed"},
+		X509SvidProfile: &types.X509SVIDProfile{
+			IpAddresses: []string{"10.0.0"},
+		},
+	}
+	test.ef.entries = []*types.Entry{profileEntry, malformedEntry}
+	test.withCallerID = true
+	test.rateLimiter.count = 2
+
+	resp, err := test.client.BatchNewX509SVID(context.Background(), &svidpb.BatchNewX509SVIDRequest{
+		Params: []*svidpb.NewX509SVIDParams{
+			{EntryId: profileEntry.Id, Csr: createCSR(t, &x509.CertificateRequest{})},
+			{EntryId: malformedEntry.Id, Csr: createCSR(t, &x509.CertificateRequest{})},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	certChain, err := x509util.RawCertsToCertificates(resp.Results[0].Bundle.CertChain)
+	require.NoError(t, err)
+	svid := certChain[0]
+	require.Equal(t, []*url.URL{
+		spiffeid.Must("example.org", "legacy").URL(),
+		{Scheme: "urn", Opaque: "acme:legacy"},
+	}, svid.URIs)
+	require.Equal(t, []net.IP{net.IPv4(10, 0, 0, 1).To4()}, svid.IPAddresses)
+	require.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, svid.ExtKeyUsage)
+	require.Equal(t, "CN=legacy.example.org,OU=Legacy,O=Acme", svid.Subject.String())
+
+	require.Nil(t, resp.Results[1].Bundle)
+	require.Equal(t, int32(codes.Internal), resp.Results[1].Status.Code)
+	require.Equal(t, "entry has malformed X509-SVID profile", resp.Results[1].Status.Message)
+}
+
+func TestServiceNewDownstreamX509CA(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	downstreamEntry := &types.Entry{
+		Id:         "downstream",
+		ParentId:   api.ProtoFromID(agentID),
+		SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/downstream"},
+		Downstream: true,
+	}
+	ttlEntry := &types.Entry{
+		Id:         "downstream-ttl",
+		ParentId:   api.ProtoFromID(agentID),
+		SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/downstream-ttl"},
+		Downstream: true,
+		Ttl:        10,
+	}
+
+	x509CA := test.ca.X509CA()
+	now := test.ca.Clock().Now().UTC()
+	csr := createCSR(t, &x509.CertificateRequest{})
+
+	var rootCAs []*common.Certificate
+	var x509Authorities [][]byte
+	for _, rootCA := range test.ca.Bundle() {
+		rootCAs = append(rootCAs, &common.Certificate{DerBytes: rootCA.Raw})
+		x509Authorities = append(x509Authorities, rootCA.Raw)
+	}
+
+	for _, tt := range []struct {
+		name           string
+		entryID        string
+		csr            []byte
+		entries        []*types.Entry
+		setBundle      bool
+		failSigning    bool
+		rateLimiterErr error
+		code           codes.Code
+		err            string
+		logMsg         string
+		expiresAt      time.Time
+	}{
+		{
+			name:      "success",
+			entryID:   "downstream",
+			csr:       csr,
+			entries:   []*types.Entry{downstreamEntry, ttlEntry},
+			setBundle: true,
+			expiresAt: now.Add(test.ca.X509SVIDTTL()),
+		},
+		{
+			name:      "success custom TTL",
+			entryID:   "downstream-ttl",
+			csr:       csr,
+			entries:   []*types.Entry{downstreamEntry, ttlEntry},
+			setBundle: true,
+			expiresAt: now.Add(10 * time.Second),
+		},
+		{
+			name:           "rate limit fails",
+			entryID:        "downstream",
+			csr:            csr,
+			entries:        []*types.Entry{downstreamEntry},
+			rateLimiterErr: status.Error(codes.Internal, "rate limit error"),
+			code:           codes.Internal,
+			err:            "rate limit error",
+			logMsg:         "Rejecting request due to downstream CA signing rate limit",
+		},
+		{
+			name:    "missing entry ID",
+			csr:     csr,
+			entries: []*types.Entry{downstreamEntry},
+			code:    codes.InvalidArgument,
+			err:     "missing entry ID",
+			logMsg:  "Invalid request: missing entry ID",
+		},
+		{
+			name:    "missing CSR",
+			entryID: "downstream",
+			entries: []*types.Entry{downstreamEntry},
+			code:    codes.InvalidArgument,
+			err:     "missing CSR",
+			logMsg:  "Invalid request: missing CSR",
+		},
+		{
+			name:    "no downstream entries",
+			entryID: "downstream",
+			csr:     csr,
+			code:    codes.Internal,
+			err:     "downstream entries missing from request context",
+			logMsg:  "Downstream entries missing from request context",
+		},
+		{
+			name:    "entry not found",
+			entryID: "downstream-ttl",
+			csr:     csr,
+			entries: []*types.Entry{downstreamEntry},
+			code:    codes.NotFound,
+			err:     "entry not found or not authorized",
+			logMsg:  "Invalid request: entry not found or not authorized",
+		},
+		{
+			name:    "malformed CSR",
+			entryID: "downstream",
+			csr:     []byte{1, 2, 3},
+			entries: []*types.Entry{downstreamEntry},
+			code:    codes.InvalidArgument,
+			err:     "malformed CSR",
+			logMsg:  "Invalid request: malformed CSR",
+		},
+		{
+			name:        "signing fails",
+			entryID:     "downstream",
+			csr:         csr,
+			entries:     []*types.Entry{downstreamEntry},
+			failSigning: true,
+			code:        codes.Internal,
+			err:         "failed to sign downstream X.509 CA",
+			logMsg:      "Failed to sign downstream X.509 CA",
+		},
+		{
+			name:    "bundle not found",
+			entryID: "downstream",
+			csr:     csr,
+			entries: []*types.Entry{downstreamEntry},
+			code:    codes.NotFound,
+			err:     "bundle not found",
+			logMsg:  "Bundle not found",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.ca.SetX509CA(x509CA)
+			if tt.failSigning {
+				test.ca.SetX509CA(nil)
+			}
+
+			test.rateLimiter.count = 1
+			test.rateLimiter.err = tt.rateLimiterErr
+			test.downstreamEntries = tt.entries
+
+			_, err := test.ds.DeleteBundle(context.Background(), &datastore.DeleteBundleRequest{
+				TrustDomainId: td.IDString(),
+			})
+			if err != nil {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			}
+			if tt.setBundle {
+				_, err := test.ds.SetBundle(context.Background(), &datastore.SetBundleRequest{
+					Bundle: &common.Bundle{
+						TrustDomainId: td.IDString(),
+						RootCas:       rootCAs,
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			resp, err := test.client.NewDownstreamX509CA(context.Background(), &svidpb.NewDownstreamX509CARequest{
+				EntryId: tt.entryID,
+				Csr:     tt.csr,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, x509Authorities, resp.X509Authorities)
+			require.NotEmpty(t, resp.CaCertChain)
+
+			caCert, err := x509.ParseCertificate(resp.CaCertChain[0])
+			require.NoError(t, err)
+			require.True(t, caCert.IsCA)
+			require.Len(t, caCert.URIs, 1)
+			require.Equal(t, td.IDString(), caCert.URIs[0].String())
+			require.Equal(t, tt.expiresAt.Unix(), caCert.NotAfter.Unix())
+		})
+	}
+}
+
 type serviceTest struct {
-	client       svidpb.SVIDClient
-	ef           *entryFetcher
-	ca           *fakeserverca.CA
-	logHook      *test.Hook
-	rateLimiter  *fakeRateLimiter
-	withCallerID bool
-	done         func()
+	client            svidpb.SVIDClient
+	ef                *entryFetcher
+	ca                *fakeserverca.CA
+	ds                datastore.DataStore
+	logHook           *test.Hook
+	rateLimiter       *fakeRateLimiter
+	withCallerID      bool
+	downstreamEntries []*types.Entry
+	done              func()
 }
 
 func (c *serviceTest) Cleanup() {
@@ -940,10 +1209,12 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	ca := fakeserverca.New(t, trustDomain.String(), &fakeserverca.Options{})
 	ef := &entryFetcher{}
 	rateLimiter := &fakeRateLimiter{}
+	ds := fakedatastore.New()
 	service := svid.New(svid.Config{
 		EntryFetcher: ef,
 		ServerCA:     ca,
 		TrustDomain:  trustDomain,
+		Datastore:    ds,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -954,6 +1225,7 @@ func setupServiceTest(t *testing.T) *serviceTest {
 	test := &serviceTest{
 		ca:          ca,
 		ef:          ef,
+		ds:          ds,
 		logHook:     logHook,
 		rateLimiter: rateLimiter,
 	}
@@ -964,6 +1236,9 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		if test.withCallerID {
 			ctx = rpccontext.WithCallerID(ctx, agentID)
 		}
+		if test.downstreamEntries != nil {
+			ctx = rpccontext.WithCallerDownstreamEntries(ctx, test.downstreamEntries)
+		}
 		return ctx
 	}
 
@@ -2,12 +2,16 @@ package client
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
-	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 )
 
@@ -17,6 +21,10 @@ const (
 	// bundle. It is important to try more than once within a refresh hint
 	// period so we can be resilient to temporary downtime or failures.
 	attemptsPerRefreshHint = 4
+
+	// relationshipsPollInterval is how often the federation relationships
+	// are reloaded from the datastore.
+	relationshipsPollInterval = 10 * time.Second
 )
 
 type TrustDomainConfig struct {
@@ -33,9 +41,12 @@ type TrustDomainConfig struct {
 }
 
 type ManagerConfig struct {
-	Log          logrus.FieldLogger
-	DataStore    datastore.DataStore
-	Clock        clock.Clock
+	Log       logrus.FieldLogger
+	DataStore datastore.DataStore
+	Clock     clock.Clock
+
+	// TrustDomains holds the statically configured trust domains. Federation
+	// relationships stored in the datastore take precedence over them.
 	TrustDomains map[string]TrustDomainConfig
 
 	// newBundleUpdater is a test hook to inject updater behavior
@@ -43,9 +54,25 @@ type ManagerConfig struct {
 }
 
 type Manager struct {
-	log      logrus.FieldLogger
-	clock    clock.Clock
-	updaters map[string]BundleUpdater
+	log              logrus.FieldLogger
+	ds               datastore.DataStore
+	clock            clock.Clock
+	trustDomains     map[string]TrustDomainConfig
+	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
+
+	// relationships holds the federation relationships last loaded from the
+	// datastore. They are kept so the running updaters are left untouched
+	// when the datastore cannot be reached.
+	relationships map[string]TrustDomainConfig
+
+	mu       sync.Mutex
+	updaters map[string]*runningUpdater
+}
+
+type runningUpdater struct {
+	config TrustDomainConfig
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 func NewManager(config ManagerConfig) *Manager {
@@ -56,38 +83,150 @@ func NewManager(config ManagerConfig) *Manager {
 		config.newBundleUpdater = NewBundleUpdater
 	}
 
-	updaters := make(map[string]BundleUpdater)
-	for trustDomain, trustDomainConfig := range config.TrustDomains {
-		updaters[trustDomain] = config.newBundleUpdater(BundleUpdaterConfig{
-			TrustDomainConfig: trustDomainConfig,
-			TrustDomain:       trustDomain,
-			DataStore:         config.DataStore,
-		})
-	}
-
 	return &Manager{
-		log:      config.Log,
-		clock:    config.Clock,
-		updaters: updaters,
+		log:              config.Log,
+		ds:               config.DataStore,
+		clock:            config.Clock,
+		trustDomains:     config.TrustDomains,
+		newBundleUpdater: config.newBundleUpdater,
+		updaters:         make(map[string]*runningUpdater),
 	}
 }
 
+// Run runs a bundle updater for each statically configured trust domain and
+// each federation relationship in the datastore. The federation
+// relationships are polled periodically and updaters are started, restarted
+// or stopped as relationships are created, updated or deleted.
 func (m *Manager) Run(ctx context.Context) error {
-	var tasks []func(context.Context) error
+	defer m.stopUpdaters()
+
+	ticker := m.clock.Ticker(relationshipsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.syncUpdaters(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// syncUpdaters reloads the federation relationships from the datastore and
+// reconciles the running updaters with them.
+func (m *Manager) syncUpdaters(ctx context.Context) {
+	relationships, err := m.loadRelationships(ctx)
+	if err != nil {
+		m.log.WithError(err).Error("Failed to load federation relationships")
+	} else {
+		m.relationships = relationships
+	}
+
+	desired := make(map[string]TrustDomainConfig)
+	for trustDomain, config := range m.trustDomains {
+		desired[trustDomain] = config
+	}
+	for trustDomain, config := range m.relationships {
+		desired[trustDomain] = config
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	for trustDomain, updater := range m.updaters {
-		// alias the loop variables that are used by the closure
-		trustDomain := trustDomain
-		updater := updater
-		tasks = append(tasks, func(ctx context.Context) error {
-			return m.runUpdater(ctx, trustDomain, updater)
-		})
+		if config, ok := desired[trustDomain]; ok && config == updater.config {
+			continue
+		}
+		m.log.WithField(telemetry.TrustDomainID, trustDomain).Info("Stopping bundle updater")
+		updater.cancel()
+		<-updater.done
+		delete(m.updaters, trustDomain)
 	}
 
-	return util.RunTasks(ctx, tasks...)
+	for trustDomain, config := range desired {
+		if _, ok := m.updaters[trustDomain]; ok {
+			continue
+		}
+		m.log.WithField(telemetry.TrustDomainID, trustDomain).Info("Starting bundle updater")
+		m.updaters[trustDomain] = m.startUpdater(ctx, trustDomain, config)
+	}
+}
+
+func (m *Manager) startUpdater(ctx context.Context, trustDomain string, config TrustDomainConfig) *runningUpdater {
+	updater := m.newBundleUpdater(BundleUpdaterConfig{
+		TrustDomainConfig: config,
+		TrustDomain:       trustDomain,
+		DataStore:         m.ds,
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = m.runUpdater(ctx, trustDomain, updater)
+	}()
+
+	return &runningUpdater{
+		config: config,
+		cancel: cancel,
+		done:   done,
+	}
+}
+
+func (m *Manager) stopUpdaters() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for trustDomain, updater := range m.updaters {
+		updater.cancel()
+		<-updater.done
+		delete(m.updaters, trustDomain)
+	}
+}
+
+// runningTrustDomains returns the trust domains that have a running updater.
+func (m *Manager) runningTrustDomains() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trustDomains := make([]string, 0, len(m.updaters))
+	for trustDomain := range m.updaters {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+	return trustDomains
+}
+
+func (m *Manager) loadRelationships(ctx context.Context) (map[string]TrustDomainConfig, error) {
+	resp, err := m.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	relationships := make(map[string]TrustDomainConfig)
+	for _, relationship := range resp.FederationRelationships {
+		td, err := spiffeid.TrustDomainFromString(relationship.TrustDomainId)
+		if err != nil {
+			m.log.WithError(err).WithField(telemetry.TrustDomainID, relationship.TrustDomainId).Warn("Ignoring federation relationship with an invalid trust domain")
+			continue
+		}
+		relationships[td.String()] = trustDomainConfigFromRelationship(relationship)
+	}
+	return relationships, nil
+}
+
+func trustDomainConfigFromRelationship(relationship *datastore.FederationRelationship) TrustDomainConfig {
+	return TrustDomainConfig{
+		EndpointAddress:  strings.TrimPrefix(relationship.BundleEndpointUrl, "https://"),
+		EndpointSpiffeID: relationship.EndpointSpiffeId,
+		UseWebPKI:        relationship.BundleEndpointProfile == datastore.FederationRelationship_HTTPS_WEB,
+	}
 }
 
 func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater BundleUpdater) error {
-	log := m.log.WithField("trust_domain", trustDomain)
+	log := m.log.WithField(telemetry.TrustDomainID, trustDomain)
 	for {
 		var nextRefresh time.Duration
 		log.Debug("Polling for bundle update")
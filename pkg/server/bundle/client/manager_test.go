@@ -3,12 +3,14 @@ package client
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/stretchr/testify/require"
@@ -69,6 +71,107 @@ func TestManager(t *testing.T) {
 	}
 }
 
+func TestManagerFederationRelationships(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	clock := clock.NewMock(t)
+	ds := fakedatastore.New()
+
+	var mu sync.Mutex
+	configs := make(map[string]TrustDomainConfig)
+
+	manager := NewManager(ManagerConfig{
+		Log:       log,
+		DataStore: ds,
+		Clock:     clock,
+		TrustDomains: map[string]TrustDomainConfig{
+			"static.test": {EndpointAddress: "static.test:8443"},
+		},
+		newBundleUpdater: func(config BundleUpdaterConfig) BundleUpdater {
+			mu.Lock()
+			configs[config.TrustDomain] = config.TrustDomainConfig
+			mu.Unlock()
+			return newFakeBundleUpdater(nil, nil)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- manager.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.EqualError(t, <-errCh, "context canceled")
+		require.Empty(t, manager.runningTrustDomains())
+	}()
+
+	clock.WaitForTicker(time.Minute, "timed out waiting for the relationship poll ticker")
+	waitForTrustDomains(t, manager, "static.test")
+
+	// creating relationships starts their updaters; a relationship takes
+	// precedence over the static configuration for the same trust domain
+	_, err := ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:     "spiffe://domain.test",
+			BundleEndpointUrl: "https://domain.test:8443/bundle",
+			EndpointSpiffeId:  "spiffe://domain.test/bundle-server",
+		},
+	})
+	require.NoError(t, err)
+	_, err = ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:         "spiffe://static.test",
+			BundleEndpointUrl:     "https://static.test",
+			BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+		},
+	})
+	require.NoError(t, err)
+
+	clock.Add(relationshipsPollInterval)
+	waitForTrustDomains(t, manager, "domain.test", "static.test")
+	waitForConfig(t, &mu, configs, "static.test", TrustDomainConfig{
+		EndpointAddress: "static.test",
+		UseWebPKI:       true,
+	})
+	mu.Lock()
+	require.Equal(t, TrustDomainConfig{
+		EndpointAddress:  "domain.test:8443/bundle",
+		EndpointSpiffeID: "spiffe://domain.test/bundle-server",
+	}, configs["domain.test"])
+	mu.Unlock()
+
+	// deleting the relationships stops the updater of the relationship that
+	// has no static configuration and restores the static one
+	_, err = ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	require.NoError(t, err)
+	_, err = ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://static.test",
+	})
+	require.NoError(t, err)
+
+	clock.Add(relationshipsPollInterval)
+	waitForTrustDomains(t, manager, "static.test")
+	waitForConfig(t, &mu, configs, "static.test", TrustDomainConfig{
+		EndpointAddress: "static.test:8443",
+	})
+}
+
+func waitForTrustDomains(t *testing.T, manager *Manager, expected ...string) {
+	require.Eventually(t, func() bool {
+		return reflect.DeepEqual(expected, manager.runningTrustDomains())
+	}, time.Minute, 10*time.Millisecond, "timed out waiting for updaters of %v", expected)
+}
+
+func waitForConfig(t *testing.T, mu *sync.Mutex, configs map[string]TrustDomainConfig, trustDomain string, expected TrustDomainConfig) {
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return configs[trustDomain] == expected
+	}, time.Minute, 10*time.Millisecond, "timed out waiting for %q updater config", trustDomain)
+}
+
 func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func() {
 	log, _ := test.NewNullLogger()
 	ds := fakedatastore.New()
@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"net"
 	"net/url"
 	"sync"
 	"time"
@@ -80,6 +81,16 @@ type X509SVIDParams struct {
 
 	// Subject of the SVID. Default subject is used if it is empty.
 	Subject pkix.Name
+
+	// IPAddresses is used to add IP SAN's to the X509 SVID.
+	IPAddresses []net.IP
+
+	// URIs is used to add URI SAN's to the X509 SVID, after the SPIFFE ID.
+	URIs []*url.URL
+
+	// ExtKeyUsage restricts the extended key usages of the X509 SVID. Both
+	// server and client authentication are used if it is empty.
+	ExtKeyUsage []x509.ExtKeyUsage
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -266,6 +277,12 @@ func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certi
 		template.DNSNames = params.DNSList
 	}
 
+	template.IPAddresses = params.IPAddresses
+	template.URIs = append(template.URIs, params.URIs...)
+	if len(params.ExtKeyUsage) > 0 {
+		template.ExtKeyUsage = params.ExtKeyUsage
+	}
+
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
 		return nil, errs.New("unable to create X509 SVID: %v", err)
@@ -2,10 +2,15 @@ package ca
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"math/big"
+	"net"
 	"net/url"
 	"testing"
 	"time"
@@ -18,6 +23,8 @@ import (
 	"github.com/spiffe/spire/test/clock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 var (
@@ -224,6 +231,25 @@ func (s *CATestSuite) TestSignX509SVIDWithSubject() {
 	}
 }
 
+func (s *CATestSuite) TestSignX509SVIDWithProfile() {
+	params := s.createX509SVIDParams()
+	params.IPAddresses = []net.IP{net.ParseIP("10.0.0.1")}
+	params.URIs = []*url.URL{{Scheme: "urn", Opaque: "acme:billing"}}
+	params.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+
+	// the SPIFFE ID remains the first URI SAN
+	s.Require().Len(svid[0].URIs, 2)
+	s.Equal("spiffe://example.org/workload", svid[0].URIs[0].String())
+	s.Equal("urn:acme:billing", svid[0].URIs[1].String())
+	s.Require().Len(svid[0].IPAddresses, 1)
+	s.True(svid[0].IPAddresses[0].Equal(net.ParseIP("10.0.0.1")))
+	s.Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, svid[0].ExtKeyUsage)
+}
+
 func (s *CATestSuite) TestSignX509SVIDReturnsChainIfIntermediate() {
 	s.setX509CA(true)
 
@@ -285,6 +311,53 @@ func (s *CATestSuite) TestSignJWTSVIDUsesDefaultTTLIfTTLUnspecified() {
 	s.Require().Equal(s.clock.Now().Add(DefaultJWTSVIDTTL), expiresAt)
 }
 
+func (s *CATestSuite) TestSignJWTSVIDUsesConfiguredTTLIfTTLUnspecified() {
+	s.ca.c.JWTSVIDTTL = 2 * time.Minute
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().NoError(err)
+	_, expiresAt, err := jwtsvid.GetTokenExpiry(token)
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(2*time.Minute), expiresAt)
+}
+
+func (s *CATestSuite) TestSignJWTSVIDAlgorithm() {
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	s.Require().NoError(err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		name   string
+		signer crypto.Signer
+		alg    jose.SignatureAlgorithm
+	}{
+		{name: "EC P256", signer: testSigner, alg: jose.ES256},
+		{name: "EC P384", signer: ec384Key, alg: jose.ES384},
+		{name: "RSA 2048", signer: rsaKey, alg: jose.RS256},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			s.ca.SetJWTKey(&JWTKey{
+				Signer:   tt.signer,
+				Kid:      "KID",
+				NotAfter: s.clock.Now().Add(10 * time.Minute),
+			})
+
+			token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+			s.Require().NoError(err)
+
+			parsed, err := jwt.ParseSigned(token)
+			s.Require().NoError(err)
+			s.Require().Len(parsed.Headers, 1)
+			s.Require().Equal(string(tt.alg), parsed.Headers[0].Algorithm)
+			s.Require().Equal("KID", parsed.Headers[0].KeyID)
+
+			claims := new(jwt.Claims)
+			s.Require().NoError(parsed.Claims(tt.signer.Public(), claims))
+		})
+	}
+}
+
 func (s *CATestSuite) TestSignJWTSVIDUsesTTLIfSpecified() {
 	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", time.Minute+time.Second))
 	s.Require().NoError(err)
@@ -0,0 +1,175 @@
+package ca
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dataStoreJournalStorage stores the journal in the datastore so that it can
+// be shared by the servers of a trust domain. The datastore record also holds
+// the rotation lease, which lets a single server prepare and activate X509
+// CAs and JWT keys when rotation is coordinated.
+type dataStoreJournalStorage struct {
+	ds            datastore.DataStore
+	trustDomainID string
+	serverID      string
+
+	// overwrite is set when rotation is not coordinated. Saving then replaces
+	// the journal even if another server has changed it since it was loaded.
+	overwrite bool
+
+	mu sync.Mutex
+	// record is the journal record last read from or written to the
+	// datastore. Its revision is used to detect changes by other servers.
+	record *datastore.CAJournal
+}
+
+func newDataStoreJournalStorage(ds datastore.DataStore, trustDomainID, serverID string, overwrite bool) *dataStoreJournalStorage {
+	return &dataStoreJournalStorage{
+		ds:            ds,
+		trustDomainID: trustDomainID,
+		serverID:      serverID,
+		overwrite:     overwrite,
+		record: &datastore.CAJournal{
+			TrustDomainId: trustDomainID,
+		},
+	}
+}
+
+func (s *dataStoreJournalStorage) load(ctx context.Context) (*JournalEntries, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := new(JournalEntries)
+	if err := proto.Unmarshal(record.Data, entries); err != nil {
+		return nil, errs.New("unable to unmarshal entries: %v", err)
+	}
+
+	s.record = record
+	return entries, nil
+}
+
+func (s *dataStoreJournalStorage) save(ctx context.Context, entries *JournalEntries) error {
+	data, err := proto.Marshal(entries)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := proto.Clone(s.record).(*datastore.CAJournal)
+	record.Data = data
+
+	resp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: record,
+	})
+	if status.Code(err) == codes.Aborted && s.overwrite {
+		// Without coordination, the journal follows the last server to
+		// save it.
+		var current *datastore.CAJournal
+		current, err = s.fetch(ctx)
+		if err != nil {
+			return err
+		}
+		record.Revision = current.Revision
+		record.LeaseHolder = current.LeaseHolder
+		record.LeaseExpiresAt = current.LeaseExpiresAt
+		resp, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+			CaJournal: record,
+		})
+	}
+	if err != nil {
+		return errs.New("unable to save journal to datastore: %v", err)
+	}
+
+	s.record = resp.CaJournal
+	return nil
+}
+
+// acquireLease acquires or renews the rotation lease for this server until
+// now+ttl. It returns false if another server holds a lease that has not
+// expired yet, or if another server changed the journal concurrently.
+func (s *dataStoreJournalStorage) acquireLease(ctx context.Context, now time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.fetch(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current.LeaseHolder != "" && current.LeaseHolder != s.serverID && now.Unix() < current.LeaseExpiresAt {
+		return false, nil
+	}
+
+	return s.setLease(ctx, current, now, ttl)
+}
+
+// renewLease renews the rotation lease held by this server until now+ttl.
+// Unlike acquireLease, it returns false if this server no longer holds the
+// lease or if another server changed the journal since it was last read, so
+// that the caller does not act on stale slots.
+func (s *dataStoreJournalStorage) renewLease(ctx context.Context, now time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.fetch(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current.LeaseHolder != s.serverID || current.Revision != s.record.Revision {
+		return false, nil
+	}
+
+	return s.setLease(ctx, current, now, ttl)
+}
+
+// setLease sets the rotation lease on the current journal record. It returns
+// false if another server changed the record concurrently.
+func (s *dataStoreJournalStorage) setLease(ctx context.Context, current *datastore.CAJournal, now time.Time, ttl time.Duration) (bool, error) {
+	record := proto.Clone(current).(*datastore.CAJournal)
+	record.LeaseHolder = s.serverID
+	record.LeaseExpiresAt = now.Add(ttl).Unix()
+
+	resp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: record,
+	})
+	switch {
+	case status.Code(err) == codes.Aborted:
+		return false, nil
+	case err != nil:
+		return false, errs.New("unable to acquire rotation lease: %v", err)
+	}
+
+	s.record = resp.CaJournal
+	return true, nil
+}
+
+// fetch returns the journal record stored in the datastore, or an empty
+// record if the journal has not been saved yet.
+func (s *dataStoreJournalStorage) fetch(ctx context.Context) (*datastore.CAJournal, error) {
+	resp, err := s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: s.trustDomainID,
+	})
+	if err != nil {
+		return nil, errs.New("unable to fetch journal from datastore: %v", err)
+	}
+	if resp.CaJournal == nil {
+		return &datastore.CAJournal{
+			TrustDomainId: s.trustDomainID,
+		}, nil
+	}
+	return resp.CaJournal, nil
+}
@@ -1,6 +1,8 @@
 package ca
 
 import (
+	"bytes"
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -25,41 +27,37 @@ const (
 	journalPEMType = "SPIRE CA JOURNAL"
 )
 
-// Journal stores X509 CAs and JWT keys on disk as they are rotated by the
-// manager. The data format on disk is a PEM encoded protocol buffer.
+// Journal stores X509 CAs and JWT keys as they are rotated by the manager.
+// The journal is stored on disk as a PEM encoded protocol buffer or, when
+// shared by the servers of a trust domain, in the datastore.
 type Journal struct {
-	path string
+	storage journalStorage
 
 	mu      sync.RWMutex
 	entries *JournalEntries
 }
 
+// journalStorage loads and saves the journal entries
+type journalStorage interface {
+	load(ctx context.Context) (*JournalEntries, error)
+	save(ctx context.Context, entries *JournalEntries) error
+}
+
+// LoadJournal loads the journal stored on disk at the given path
 func LoadJournal(path string) (*Journal, error) {
-	j := &Journal{
-		path:    path,
-		entries: new(JournalEntries),
-	}
+	return openJournal(context.Background(), diskJournalStorage{path: path})
+}
 
-	pemBytes, err := ioutil.ReadFile(path)
+func openJournal(ctx context.Context, storage journalStorage) (*Journal, error) {
+	entries, err := storage.load(ctx)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return j, nil
-		}
-		return nil, errs.Wrap(err)
-	}
-	pemBlock, _ := pem.Decode(pemBytes)
-	if pemBlock == nil {
-		return nil, errs.New("invalid PEM block")
-	}
-	if pemBlock.Type != journalPEMType {
-		return nil, errs.New("invalid PEM block type %q", pemBlock.Type)
-	}
-
-	if err := proto.Unmarshal(pemBlock.Bytes, j.entries); err != nil {
-		return nil, errs.New("unable to unmarshal entries: %v", err)
+		return nil, err
 	}
 
-	return j, nil
+	return &Journal{
+		storage: storage,
+		entries: entries,
+	}, nil
 }
 
 func (j *Journal) Entries() *JournalEntries {
@@ -68,7 +66,24 @@ func (j *Journal) Entries() *JournalEntries {
 	return proto.Clone(j.entries).(*JournalEntries)
 }
 
-func (j *Journal) AppendX509CA(slotID string, issuedAt time.Time, x509CA *X509CA) error {
+// Reload loads the journal entries from storage again, in case they were
+// changed by another server. It returns true if the entries changed.
+func (j *Journal) Reload(ctx context.Context) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries, err := j.storage.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	if proto.Equal(entries, j.entries) {
+		return false, nil
+	}
+	j.entries = entries
+	return true, nil
+}
+
+func (j *Journal) AppendX509CA(ctx context.Context, slotID string, issuedAt time.Time, x509CA *X509CA) error {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
@@ -78,6 +93,7 @@ func (j *Journal) AppendX509CA(slotID string, issuedAt time.Time, x509CA *X509CA
 		IssuedAt:      issuedAt.Unix(),
 		Certificate:   x509CA.Certificate.Raw,
 		UpstreamChain: chainDER(x509CA.UpstreamChain),
+		Status:        AuthorityStatus_PREPARED,
 	})
 
 	exceeded := len(j.entries.X509CAs) - journalCap
@@ -88,7 +104,7 @@ func (j *Journal) AppendX509CA(slotID string, issuedAt time.Time, x509CA *X509CA
 		j.entries.X509CAs = x509CAs
 	}
 
-	if err := j.save(); err != nil {
+	if err := j.save(ctx); err != nil {
 		j.entries.X509CAs = backup
 		return err
 	}
@@ -96,7 +112,7 @@ func (j *Journal) AppendX509CA(slotID string, issuedAt time.Time, x509CA *X509CA
 	return nil
 }
 
-func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey) error {
+func (j *Journal) AppendJWTKey(ctx context.Context, slotID string, issuedAt time.Time, jwtKey *JWTKey) error {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
@@ -112,6 +128,7 @@ func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey
 		Kid:       jwtKey.Kid,
 		PublicKey: pkixBytes,
 		NotAfter:  jwtKey.NotAfter.Unix(),
+		Status:    AuthorityStatus_PREPARED,
 	})
 
 	exceeded := len(j.entries.JwtKeys) - journalCap
@@ -122,7 +139,7 @@ func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey
 		j.entries.JwtKeys = jwtKeys
 	}
 
-	if err := j.save(); err != nil {
+	if err := j.save(ctx); err != nil {
 		j.entries.JwtKeys = backup
 		return err
 	}
@@ -130,8 +147,91 @@ func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey
 	return nil
 }
 
-func (j *Journal) save() error {
-	return saveJournalEntries(j.path, j.entries)
+// UpdateX509CAStatus updates the status of the most recent X509 CA entry
+// with the given certificate. The journal is not saved if the status is
+// unchanged.
+func (j *Journal) UpdateX509CAStatus(ctx context.Context, certificate []byte, status AuthorityStatus) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i := len(j.entries.X509CAs) - 1; i >= 0; i-- {
+		entry := j.entries.X509CAs[i]
+		if bytes.Equal(entry.Certificate, certificate) {
+			if entry.Status == status {
+				return nil
+			}
+			backup := entry.Status
+			entry.Status = status
+			if err := j.save(ctx); err != nil {
+				entry.Status = backup
+				return err
+			}
+			return nil
+		}
+	}
+	return errs.New("no journal entry for X509 CA")
+}
+
+// UpdateJWTKeyStatus updates the status of the most recent JWT key entry
+// with the given key ID. The journal is not saved if the status is unchanged.
+func (j *Journal) UpdateJWTKeyStatus(ctx context.Context, kid string, status AuthorityStatus) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i := len(j.entries.JwtKeys) - 1; i >= 0; i-- {
+		entry := j.entries.JwtKeys[i]
+		if entry.Kid == kid {
+			if entry.Status == status {
+				return nil
+			}
+			backup := entry.Status
+			entry.Status = status
+			if err := j.save(ctx); err != nil {
+				entry.Status = backup
+				return err
+			}
+			return nil
+		}
+	}
+	return errs.New("no journal entry for JWT key %q", kid)
+}
+
+func (j *Journal) save(ctx context.Context) error {
+	return j.storage.save(ctx, j.entries)
+}
+
+// diskJournalStorage stores the journal in a file on disk
+type diskJournalStorage struct {
+	path string
+}
+
+func (s diskJournalStorage) load(ctx context.Context) (*JournalEntries, error) {
+	entries := new(JournalEntries)
+
+	pemBytes, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	pemBlock, _ := pem.Decode(pemBytes)
+	if pemBlock == nil {
+		return nil, errs.New("invalid PEM block")
+	}
+	if pemBlock.Type != journalPEMType {
+		return nil, errs.New("invalid PEM block type %q", pemBlock.Type)
+	}
+
+	if err := proto.Unmarshal(pemBlock.Bytes, entries); err != nil {
+		return nil, errs.New("unable to unmarshal entries: %v", err)
+	}
+
+	return entries, nil
+}
+
+func (s diskJournalStorage) save(ctx context.Context, entries *JournalEntries) error {
+	return saveJournalEntries(s.path, entries)
 }
 
 func saveJournalEntries(path string, entries *JournalEntries) error {
@@ -20,6 +20,42 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type AuthorityStatus int32
+
+const (
+	// The status is unknown. Entries written before statuses were tracked
+	// have this status.
+	AuthorityStatus_UNKNOWN AuthorityStatus = 0
+	// The authority has been prepared but not yet activated.
+	AuthorityStatus_PREPARED AuthorityStatus = 1
+	// The authority is the one currently in use.
+	AuthorityStatus_ACTIVE AuthorityStatus = 2
+	// The authority was active at some point but has been superseded.
+	AuthorityStatus_OLD AuthorityStatus = 3
+)
+
+var AuthorityStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PREPARED",
+	2: "ACTIVE",
+	3: "OLD",
+}
+
+var AuthorityStatus_value = map[string]int32{
+	"UNKNOWN":  0,
+	"PREPARED": 1,
+	"ACTIVE":   2,
+	"OLD":      3,
+}
+
+func (x AuthorityStatus) String() string {
+	return proto.EnumName(AuthorityStatus_name, int32(x))
+}
+
+func (AuthorityStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{0}
+}
+
 type X509CAEntry struct {
 	// Which X509 CA slot this entry occupied.
 	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
@@ -28,10 +64,12 @@ type X509CAEntry struct {
 	// DER encoded CA certificate
 	Certificate []byte `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	// DER encoded upstream CA chain. See the X509CA struct for details.
-	UpstreamChain        [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UpstreamChain [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
+	// Status of the authority
+	Status               AuthorityStatus `protobuf:"varint,5,opt,name=status,proto3,enum=AuthorityStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *X509CAEntry) Reset()         { *m = X509CAEntry{} }
@@ -87,6 +125,13 @@ func (m *X509CAEntry) GetUpstreamChain() [][]byte {
 	return nil
 }
 
+func (m *X509CAEntry) GetStatus() AuthorityStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AuthorityStatus_UNKNOWN
+}
+
 type JWTKeyEntry struct {
 	// Which JWT Key slot this entry occupied.
 	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
@@ -97,10 +142,12 @@ type JWTKeyEntry struct {
 	// JWT key id (i.e. "kid" claim)
 	Kid string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
 	// PKIX encoded public key
-	PublicKey            []byte   `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PublicKey []byte `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Status of the authority
+	Status               AuthorityStatus `protobuf:"varint,6,opt,name=status,proto3,enum=AuthorityStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *JWTKeyEntry) Reset()         { *m = JWTKeyEntry{} }
@@ -163,6 +210,13 @@ func (m *JWTKeyEntry) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *JWTKeyEntry) GetStatus() AuthorityStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AuthorityStatus_UNKNOWN
+}
+
 type JournalEntries struct {
 	X509CAs              []*X509CAEntry `protobuf:"bytes,1,rep,name=x509CAs,proto3" json:"x509CAs,omitempty"`
 	JwtKeys              []*JWTKeyEntry `protobuf:"bytes,2,rep,name=jwtKeys,proto3" json:"jwtKeys,omitempty"`
@@ -211,6 +265,7 @@ func (m *JournalEntries) GetJwtKeys() []*JWTKeyEntry {
 }
 
 func init() {
+	proto.RegisterEnum("AuthorityStatus", AuthorityStatus_name, AuthorityStatus_value)
 	proto.RegisterType((*X509CAEntry)(nil), "X509CAEntry")
 	proto.RegisterType((*JWTKeyEntry)(nil), "JWTKeyEntry")
 	proto.RegisterType((*JournalEntries)(nil), "JournalEntries")
@@ -219,23 +274,29 @@ func init() {
 func init() { proto.RegisterFile("journal.proto", fileDescriptor_04fd98cceb1b9191) }
 
 var fileDescriptor_04fd98cceb1b9191 = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x41, 0x4b, 0xc3, 0x30,
-	0x14, 0xc7, 0xc9, 0x32, 0xb7, 0xf5, 0x75, 0x1b, 0x92, 0x8b, 0x81, 0x21, 0x84, 0x81, 0xd2, 0xd3,
-	0x10, 0xc5, 0x83, 0xc7, 0x39, 0x3c, 0xb8, 0xdd, 0x82, 0xa0, 0x78, 0xa9, 0x59, 0x9b, 0x61, 0xb6,
-	0xd9, 0x8c, 0xe4, 0x15, 0xed, 0x77, 0xf0, 0xe2, 0x37, 0x96, 0x74, 0x16, 0x7a, 0xf7, 0x96, 0xf7,
-	0x7b, 0x7f, 0xc2, 0x2f, 0xff, 0xc0, 0x68, 0x6b, 0x4b, 0x57, 0xa8, 0xfd, 0xec, 0xe0, 0x2c, 0xda,
-	0xe9, 0x37, 0x81, 0xf8, 0xe5, 0xf6, 0xea, 0x6e, 0x31, 0x7f, 0x28, 0xd0, 0x55, 0xec, 0x0c, 0xfa,
-	0x7e, 0x6f, 0x31, 0x35, 0x39, 0x27, 0x82, 0x24, 0x91, 0xec, 0x85, 0xf1, 0x31, 0x67, 0x13, 0x88,
-	0x8c, 0xf7, 0xa5, 0xce, 0x53, 0x85, 0xbc, 0x23, 0x48, 0x42, 0xe5, 0xe0, 0x08, 0xe6, 0xc8, 0x04,
-	0xc4, 0x99, 0x76, 0x68, 0x36, 0x26, 0x53, 0xa8, 0x39, 0x15, 0x24, 0x19, 0xca, 0x36, 0x62, 0x17,
-	0x30, 0x2e, 0x0f, 0x1e, 0x9d, 0x56, 0x1f, 0x69, 0xf6, 0xae, 0x4c, 0xc1, 0xbb, 0x82, 0x26, 0x43,
-	0x39, 0x6a, 0xe8, 0x22, 0xc0, 0xe9, 0x0f, 0x81, 0x78, 0xf9, 0xfc, 0xb4, 0xd2, 0xd5, 0x7f, 0x74,
-	0x26, 0x10, 0x15, 0x16, 0x53, 0xb5, 0x41, 0xed, 0x6a, 0x19, 0x2a, 0x07, 0x85, 0xc5, 0x79, 0x98,
-	0xd9, 0x29, 0xd0, 0x9d, 0xc9, 0x79, 0xb7, 0xbe, 0x2e, 0x1c, 0xd9, 0x39, 0xc0, 0xa1, 0x5c, 0xef,
-	0x4d, 0x96, 0xee, 0x74, 0xc5, 0x4f, 0x6a, 0xf9, 0xe8, 0x48, 0x56, 0xba, 0x9a, 0xbe, 0xc1, 0x78,
-	0x79, 0xec, 0x2c, 0x38, 0x19, 0xed, 0xd9, 0x25, 0xf4, 0xbf, 0xea, 0xce, 0x3c, 0x27, 0x82, 0x26,
-	0xf1, 0xf5, 0x70, 0xd6, 0xea, 0x50, 0x36, 0xcb, 0x90, 0xdb, 0x7e, 0xe2, 0x4a, 0x57, 0x9e, 0x77,
-	0xfe, 0x72, 0xad, 0xc7, 0xc9, 0x66, 0x79, 0xdf, 0x7d, 0xed, 0x64, 0x6a, 0xdd, 0xab, 0x7f, 0xe4,
-	0xe6, 0x37, 0x00, 0x00, 0xff, 0xff, 0x58, 0x50, 0x0f, 0x00, 0xa2, 0x01, 0x00, 0x00,
+	// 370 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0x41, 0x8b, 0xd3, 0x40,
+	0x14, 0xc7, 0x9d, 0x4c, 0x4d, 0x9a, 0x97, 0x6c, 0x0d, 0x73, 0x71, 0x60, 0x11, 0xc2, 0x82, 0x12,
+	0x3c, 0x14, 0x59, 0xf1, 0xe0, 0x31, 0x76, 0x73, 0xd8, 0x8d, 0x74, 0x97, 0xb1, 0x5a, 0xf1, 0x12,
+	0xa7, 0xc9, 0x94, 0x4e, 0x5b, 0x93, 0x92, 0x79, 0x41, 0xf3, 0xc5, 0xbc, 0xf8, 0xe5, 0x24, 0x69,
+	0x0b, 0xc1, 0x8b, 0x87, 0xbd, 0xe5, 0xfd, 0xfe, 0x8f, 0xcc, 0x8f, 0xf9, 0x0f, 0x5c, 0x6c, 0xab,
+	0xa6, 0x2e, 0xe5, 0x7e, 0x7a, 0xa8, 0x2b, 0xac, 0xae, 0x7e, 0x13, 0xf0, 0xbe, 0xbe, 0x7b, 0xf3,
+	0x7e, 0x16, 0x27, 0x25, 0xd6, 0x2d, 0x7b, 0x0e, 0x8e, 0xd9, 0x57, 0x98, 0xe9, 0x82, 0x93, 0x90,
+	0x44, 0xae, 0xb0, 0xbb, 0xf1, 0xb6, 0x60, 0x97, 0xe0, 0x6a, 0x63, 0x1a, 0x55, 0x64, 0x12, 0xb9,
+	0x15, 0x92, 0x88, 0x8a, 0xf1, 0x11, 0xc4, 0xc8, 0x42, 0xf0, 0x72, 0x55, 0xa3, 0x5e, 0xeb, 0x5c,
+	0xa2, 0xe2, 0x34, 0x24, 0x91, 0x2f, 0x86, 0x88, 0xbd, 0x84, 0x49, 0x73, 0x30, 0x58, 0x2b, 0xf9,
+	0x23, 0xcb, 0x37, 0x52, 0x97, 0x7c, 0x14, 0xd2, 0xc8, 0x17, 0x17, 0x67, 0x3a, 0xeb, 0x20, 0x8b,
+	0xc0, 0x36, 0x28, 0xb1, 0x31, 0xfc, 0x69, 0x48, 0xa2, 0xc9, 0x75, 0x30, 0x8d, 0x1b, 0xdc, 0x54,
+	0xb5, 0xc6, 0xf6, 0x53, 0xcf, 0xc5, 0x29, 0xbf, 0xfa, 0x43, 0xc0, 0xbb, 0x5b, 0x2e, 0x52, 0xd5,
+	0x3e, 0x46, 0xfc, 0x12, 0xdc, 0xb2, 0xc2, 0x4c, 0xae, 0x51, 0xd5, 0xbd, 0x36, 0x15, 0xe3, 0xb2,
+	0xc2, 0xb8, 0x9b, 0x59, 0x00, 0x74, 0xa7, 0x0b, 0x3e, 0xea, 0x7f, 0xd7, 0x7d, 0xb2, 0x17, 0x00,
+	0x87, 0x66, 0xb5, 0xd7, 0x79, 0xb6, 0x53, 0x6d, 0xaf, 0xe8, 0x0b, 0xf7, 0x48, 0x52, 0xd5, 0x0e,
+	0xec, 0xed, 0xff, 0xd8, 0x7f, 0x87, 0xc9, 0xdd, 0xb1, 0x87, 0xce, 0x5e, 0x2b, 0xc3, 0x5e, 0x81,
+	0xf3, 0xab, 0xef, 0xc1, 0x70, 0x12, 0xd2, 0xc8, 0xbb, 0xf6, 0xa7, 0x83, 0x5e, 0xc4, 0x39, 0xec,
+	0xf6, 0xb6, 0x3f, 0x31, 0x55, 0xad, 0xe1, 0xd6, 0x69, 0x6f, 0x70, 0x0d, 0xe2, 0x1c, 0xbe, 0x8e,
+	0xe1, 0xd9, 0x3f, 0x87, 0x33, 0x0f, 0x9c, 0xcf, 0xf3, 0x74, 0x7e, 0xbf, 0x9c, 0x07, 0x4f, 0x98,
+	0x0f, 0xe3, 0x07, 0x91, 0x3c, 0xc4, 0x22, 0xb9, 0x09, 0x08, 0x03, 0xb0, 0xe3, 0xd9, 0xe2, 0xf6,
+	0x4b, 0x12, 0x58, 0xcc, 0x01, 0x7a, 0xff, 0xf1, 0x26, 0xa0, 0x1f, 0x46, 0xdf, 0xac, 0x5c, 0xae,
+	0xec, 0xfe, 0xa1, 0xbc, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x58, 0x6e, 0x8f, 0x10, 0x39, 0x02,
+	0x00, 0x00,
 }
@@ -139,14 +139,14 @@ func (s *JournalSuite) TestPersistence() {
 
 	journal := s.loadJournal()
 
-	err := journal.AppendX509CA("A", now, &X509CA{
+	err := journal.AppendX509CA(ctx, "A", now, &X509CA{
 		Signer:        testSigner,
 		Certificate:   testChain[0],
 		UpstreamChain: testChain,
 	})
 	s.Require().NoError(err)
 
-	err = journal.AppendJWTKey("B", now, &JWTKey{
+	err = journal.AppendJWTKey(ctx, "B", now, &JWTKey{
 		Signer:   testSigner,
 		Kid:      "KID",
 		NotAfter: now.Add(time.Hour),
@@ -163,7 +163,7 @@ func (s *JournalSuite) TestX509CAOverflow() {
 
 	for i := 0; i < (journalCap + 1); i++ {
 		now = now.Add(time.Minute)
-		err := journal.AppendX509CA("A", now, &X509CA{
+		err := journal.AppendX509CA(ctx, "A", now, &X509CA{
 			Signer:      testSigner,
 			Certificate: testChain[0],
 		})
@@ -183,7 +183,7 @@ func (s *JournalSuite) TestJWTKeyOverflow() {
 
 	for i := 0; i < (journalCap + 1); i++ {
 		now = now.Add(time.Minute)
-		err := journal.AppendJWTKey("B", now, &JWTKey{
+		err := journal.AppendJWTKey(ctx, "B", now, &JWTKey{
 			Signer:   testSigner,
 			Kid:      "KID",
 			NotAfter: now.Add(time.Hour),
@@ -197,6 +197,39 @@ func (s *JournalSuite) TestJWTKeyOverflow() {
 	s.Require().Equal(now, time.Unix(lastEntry.IssuedAt, 0).UTC())
 }
 
+func (s *JournalSuite) TestUpdateStatus() {
+	now := s.now()
+
+	journal := s.loadJournal()
+
+	err := journal.AppendX509CA(ctx, "A", now, &X509CA{
+		Signer:      testSigner,
+		Certificate: testChain[0],
+	})
+	s.Require().NoError(err)
+
+	err = journal.AppendJWTKey(ctx, "A", now, &JWTKey{
+		Signer:   testSigner,
+		Kid:      "KID",
+		NotAfter: now.Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	entries := journal.Entries()
+	s.Require().Equal(AuthorityStatus_PREPARED, entries.X509CAs[0].Status)
+	s.Require().Equal(AuthorityStatus_PREPARED, entries.JwtKeys[0].Status)
+
+	s.Require().NoError(journal.UpdateX509CAStatus(ctx, testChain[0].Raw, AuthorityStatus_ACTIVE))
+	s.Require().NoError(journal.UpdateJWTKeyStatus(ctx, "KID", AuthorityStatus_OLD))
+
+	entries = s.loadJournal().Entries()
+	s.Require().Equal(AuthorityStatus_ACTIVE, entries.X509CAs[0].Status)
+	s.Require().Equal(AuthorityStatus_OLD, entries.JwtKeys[0].Status)
+
+	s.Require().Error(journal.UpdateX509CAStatus(ctx, testChain[1].Raw, AuthorityStatus_ACTIVE))
+	s.Require().Error(journal.UpdateJWTKeyStatus(ctx, "OTHER", AuthorityStatus_ACTIVE))
+}
+
 func (s *JournalSuite) TestBadPEM() {
 	s.writeString(s.journalPath(), "NOT PEM")
 	_, err := LoadJournal(s.journalPath())
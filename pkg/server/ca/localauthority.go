@@ -0,0 +1,332 @@
+package ca
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorityState describes an X509 CA or JWT key held by the manager.
+type AuthorityState struct {
+	// AuthorityID identifies the authority. It is the hex encoded subject key
+	// ID of the CA certificate for X509 CAs and the key ID for JWT keys.
+	AuthorityID string
+
+	// IssuedAt is when the authority was prepared.
+	IssuedAt time.Time
+
+	// ExpiresAt is when the authority expires.
+	ExpiresAt time.Time
+}
+
+// X509AuthorityID returns the authority ID of the given X509 CA
+func X509AuthorityID(x509CA *X509CA) string {
+	return hex.EncodeToString(x509CA.Certificate.SubjectKeyId)
+}
+
+// GetX509CAState returns the state of the active X509 CA and the prepared
+// X509 CA. Either is nil if the slot is empty.
+func (m *Manager) GetX509CAState() (active, prepared *AuthorityState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return x509CAState(m.currentX509CA), x509CAState(m.nextX509CA)
+}
+
+// PrepareX509CA prepares a new X509 CA right away, replacing any X509 CA
+// that has already been prepared. The new X509 CA is added to the bundle but
+// is not used for signing until it is activated.
+func (m *Manager) PrepareX509CA(ctx context.Context) (*AuthorityState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkRotationLease(ctx); err != nil {
+		return nil, err
+	}
+	if m.currentX509CA.IsEmpty() {
+		return nil, status.Error(codes.FailedPrecondition, "no X509 CA is active yet")
+	}
+
+	if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to prepare X509 CA: %v", err)
+	}
+
+	return x509CAState(m.nextX509CA), nil
+}
+
+// ActivateX509CA activates the prepared X509 CA right away. The authority ID
+// must match the prepared X509 CA so that an operator doesn't activate a
+// different X509 CA than the one they inspected. A tainted X509 CA cannot be
+// activated.
+func (m *Manager) ActivateX509CA(ctx context.Context, authorityID string) (*AuthorityState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if authorityID == "" {
+		return nil, status.Error(codes.InvalidArgument, "authority ID is required")
+	}
+	if err := m.checkRotationLease(ctx); err != nil {
+		return nil, err
+	}
+	if m.nextX509CA.IsEmpty() {
+		return nil, status.Error(codes.FailedPrecondition, "no X509 CA has been prepared")
+	}
+	if X509AuthorityID(m.nextX509CA.x509CA) != authorityID {
+		return nil, status.Errorf(codes.FailedPrecondition, "X509 CA %q is not the prepared X509 CA", authorityID)
+	}
+
+	if err := m.activateNextX509CA(ctx); err != nil {
+		return nil, err
+	}
+
+	return x509CAState(m.currentX509CA), nil
+}
+
+// TaintX509CA marks an X509 CA in the bundle as tainted. Agents rotate any
+// SVID issued by a tainted X509 CA. The active and prepared X509 CAs cannot
+// be tainted; prepare and activate a new one first.
+func (m *Manager) TaintX509CA(ctx context.Context, authorityID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subjectKeyID, err := m.checkX509CAAuthorityID(ctx, authorityID)
+	if err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+		SubjectKeyId:  subjectKeyID,
+	}); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.AuthorityID, authorityID).Warn("X509 CA tainted")
+	m.bundleUpdated()
+	return nil
+}
+
+// RevokeX509CA removes a tainted X509 CA from the bundle. SVIDs issued by the
+// X509 CA that have not been rotated yet will no longer be trusted.
+func (m *Manager) RevokeX509CA(ctx context.Context, authorityID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subjectKeyID, err := m.checkX509CAAuthorityID(ctx, authorityID)
+	if err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.RevokeX509CA(ctx, &datastore.RevokeX509CARequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+		SubjectKeyId:  subjectKeyID,
+	}); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.AuthorityID, authorityID).Warn("X509 CA revoked")
+	m.bundleUpdated()
+	return nil
+}
+
+func (m *Manager) checkX509CAAuthorityID(ctx context.Context, authorityID string) ([]byte, error) {
+	if authorityID == "" {
+		return nil, status.Error(codes.InvalidArgument, "authority ID is required")
+	}
+	subjectKeyID, err := hex.DecodeString(authorityID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "malformed authority ID: %v", err)
+	}
+
+	// The bundle holds the upstream roots and not the local X509 CAs when an
+	// UpstreamAuthority is configured.
+	if m.upstreamClient != nil {
+		return nil, status.Error(codes.FailedPrecondition, "local X509 CAs are not in the bundle when an UpstreamAuthority is configured")
+	}
+
+	if err := m.syncSlots(ctx); err != nil {
+		return nil, err
+	}
+	if !m.currentX509CA.IsEmpty() && X509AuthorityID(m.currentX509CA.x509CA) == authorityID {
+		return nil, status.Error(codes.FailedPrecondition, "the active X509 CA cannot be tainted or revoked")
+	}
+	if !m.nextX509CA.IsEmpty() && X509AuthorityID(m.nextX509CA.x509CA) == authorityID {
+		return nil, status.Error(codes.FailedPrecondition, "the prepared X509 CA cannot be tainted or revoked; prepare a new one first")
+	}
+	return subjectKeyID, nil
+}
+
+// GetJWTKeyState returns the state of the active JWT key and the prepared
+// JWT key. Either is nil if the slot is empty.
+func (m *Manager) GetJWTKeyState() (active, prepared *AuthorityState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return jwtKeyState(m.currentJWTKey), jwtKeyState(m.nextJWTKey)
+}
+
+// PrepareJWTKey prepares a new JWT key right away, replacing any JWT key
+// that has already been prepared. The new JWT key is added to the bundle but
+// is not used for signing until it is activated.
+func (m *Manager) PrepareJWTKey(ctx context.Context) (*AuthorityState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkRotationLease(ctx); err != nil {
+		return nil, err
+	}
+	if m.currentJWTKey.IsEmpty() {
+		return nil, status.Error(codes.FailedPrecondition, "no JWT key is active yet")
+	}
+
+	if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to prepare JWT key: %v", err)
+	}
+
+	return jwtKeyState(m.nextJWTKey), nil
+}
+
+// ActivateJWTKey activates the prepared JWT key right away. The authority ID
+// must match the prepared JWT key. A tainted JWT key cannot be activated.
+func (m *Manager) ActivateJWTKey(ctx context.Context, authorityID string) (*AuthorityState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if authorityID == "" {
+		return nil, status.Error(codes.InvalidArgument, "authority ID is required")
+	}
+	if err := m.checkRotationLease(ctx); err != nil {
+		return nil, err
+	}
+	if m.nextJWTKey.IsEmpty() {
+		return nil, status.Error(codes.FailedPrecondition, "no JWT key has been prepared")
+	}
+	if m.nextJWTKey.jwtKey.Kid != authorityID {
+		return nil, status.Errorf(codes.FailedPrecondition, "JWT key %q is not the prepared JWT key", authorityID)
+	}
+
+	if err := m.activateNextJWTKey(ctx); err != nil {
+		return nil, err
+	}
+
+	return jwtKeyState(m.currentJWTKey), nil
+}
+
+// TaintJWTKey marks a JWT key in the bundle as tainted. Agents renew any
+// JWT-SVID signed by a tainted JWT key. The active and prepared JWT keys
+// cannot be tainted; prepare and activate a new one first.
+func (m *Manager) TaintJWTKey(ctx context.Context, authorityID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkJWTKeyAuthorityID(ctx, authorityID); err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+		KeyId:         authorityID,
+	}); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.AuthorityID, authorityID).Warn("JWT key tainted")
+	m.bundleUpdated()
+	return nil
+}
+
+// RevokeJWTKey removes a tainted JWT key from the bundle. JWT-SVIDs signed
+// by the key that have not been renewed yet will no longer be trusted.
+func (m *Manager) RevokeJWTKey(ctx context.Context, authorityID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkJWTKeyAuthorityID(ctx, authorityID); err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.RevokeJWTKey(ctx, &datastore.RevokeJWTKeyRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+		KeyId:         authorityID,
+	}); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.AuthorityID, authorityID).Warn("JWT key revoked")
+	m.bundleUpdated()
+	return nil
+}
+
+func (m *Manager) checkJWTKeyAuthorityID(ctx context.Context, authorityID string) error {
+	if authorityID == "" {
+		return status.Error(codes.InvalidArgument, "authority ID is required")
+	}
+
+	if err := m.syncSlots(ctx); err != nil {
+		return err
+	}
+	if !m.currentJWTKey.IsEmpty() && m.currentJWTKey.jwtKey.Kid == authorityID {
+		return status.Error(codes.FailedPrecondition, "the active JWT key cannot be tainted or revoked")
+	}
+	if !m.nextJWTKey.IsEmpty() && m.nextJWTKey.jwtKey.Kid == authorityID {
+		return status.Error(codes.FailedPrecondition, "the prepared JWT key cannot be tainted or revoked; prepare a new one first")
+	}
+	return nil
+}
+
+// syncSlots syncs the journal if rotation is coordinated, so that checks act
+// on the latest authorities prepared and activated by the lease holder.
+func (m *Manager) syncSlots(ctx context.Context) error {
+	if !m.c.CoordinateRotation {
+		return nil
+	}
+	if err := m.syncJournal(ctx); err != nil {
+		return status.Errorf(codes.Unavailable, "unable to sync journal: %v", err)
+	}
+	return nil
+}
+
+// checkRotationLease fails if rotation is coordinated and this server does
+// not hold the rotation lease, since only the lease holder may prepare and
+// activate X509 CAs and JWT keys. The journal is synced first so that the
+// check acts on the latest authorities.
+func (m *Manager) checkRotationLease(ctx context.Context) error {
+	if err := m.syncSlots(ctx); err != nil {
+		return err
+	}
+	if m.c.CoordinateRotation && !m.leader {
+		return status.Error(codes.FailedPrecondition, "this server does not hold the rotation lease")
+	}
+	return nil
+}
+
+func x509CAState(slot *x509CASlot) *AuthorityState {
+	if slot.IsEmpty() {
+		return nil
+	}
+	return &AuthorityState{
+		AuthorityID: X509AuthorityID(slot.x509CA),
+		IssuedAt:    slot.issuedAt,
+		ExpiresAt:   slot.x509CA.Certificate.NotAfter,
+	}
+}
+
+func jwtKeyState(slot *jwtKeySlot) *AuthorityState {
+	if slot.IsEmpty() {
+		return nil
+	}
+	return &AuthorityState{
+		AuthorityID: slot.jwtKey.Kid,
+		IssuedAt:    slot.issuedAt,
+		ExpiresAt:   slot.jwtKey.NotAfter,
+	}
+}
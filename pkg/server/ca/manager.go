@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/gofrs/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -45,6 +46,11 @@ const (
 	activationThresholdCap = sevenDays
 
 	publishJWKTimeout = 5 * time.Second
+
+	// rotationLeaseTTL is how long the rotation lease is held for when
+	// rotation is coordinated. The lease holder renews it on every rotation
+	// check.
+	rotationLeaseTTL = 6 * rotateInterval
 )
 
 type ManagedCA interface {
@@ -65,6 +71,16 @@ type ManagerConfig struct {
 	Log            logrus.FieldLogger
 	Metrics        telemetry.Metrics
 	Clock          clock.Clock
+
+	// JournalInDataStore stores the journal in the datastore instead of in
+	// Dir, so that servers sharing the datastore and the KeyManager share
+	// the same X509 CAs and JWT keys.
+	JournalInDataStore bool
+
+	// CoordinateRotation, which requires JournalInDataStore, lets only the
+	// server holding the rotation lease prepare and activate X509 CAs and
+	// JWT keys. The other servers use the ones it activates.
+	CoordinateRotation bool
 }
 
 type Manager struct {
@@ -73,6 +89,9 @@ type Manager struct {
 	upstreamClient     *UpstreamClient
 	upstreamPluginName string
 
+	// mu guards the slots, which are rotated on a schedule and on demand by
+	// the local authority admin operations.
+	mu            sync.Mutex
 	currentX509CA *x509CASlot
 	nextX509CA    *x509CASlot
 	currentJWTKey *jwtKeySlot
@@ -80,6 +99,17 @@ type Manager struct {
 
 	journal *Journal
 
+	// dsJournal is the journal storage when the journal is stored in the
+	// datastore. It also manages the rotation lease.
+	dsJournal *dataStoreJournalStorage
+
+	// serverID identifies this server as the rotation lease holder
+	serverID string
+
+	// leader is true if this server prepares and activates X509 CAs and JWT
+	// keys, which is always the case unless rotation is coordinated.
+	leader bool
+
 	// Used to log a warning only once when the UpstreamAuthority does not support JWT-SVIDs.
 	jwtUnimplementedWarnOnce sync.Once
 }
@@ -101,6 +131,8 @@ func NewManager(c ManagerConfig) *Manager {
 	m := &Manager{
 		c:               c,
 		bundleUpdatedCh: make(chan struct{}, 1),
+		serverID:        uuid.Must(uuid.NewV4()).String(),
+		leader:          !c.CoordinateRotation,
 	}
 
 	if upstreamAuthority, ok := c.Catalog.GetUpstreamAuthority(); ok {
@@ -127,6 +159,9 @@ func (m *Manager) Initialize(ctx context.Context) error {
 	if err := m.rotate(ctx); err != nil {
 		return err
 	}
+	if m.c.CoordinateRotation {
+		return m.waitForActiveAuthorities(ctx)
+	}
 	return nil
 }
 
@@ -179,6 +214,19 @@ func (m *Manager) rotateEvery(ctx context.Context, interval time.Duration) error
 }
 
 func (m *Manager) rotate(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.c.CoordinateRotation {
+		if err := m.syncJournal(ctx); err != nil {
+			m.c.Log.WithError(err).Error("Unable to sync journal")
+			return err
+		}
+		if !m.leader {
+			return nil
+		}
+	}
+
 	x509CAErr := m.rotateX509CA(ctx)
 	if x509CAErr != nil {
 		m.c.Log.WithError(x509CAErr).Error("Unable to rotate X509 CA")
@@ -200,7 +248,7 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 		if err := m.prepareX509CA(ctx, m.currentX509CA); err != nil {
 			return err
 		}
-		m.activateX509CA()
+		m.activateX509CA(ctx)
 	}
 
 	// if there is no next keypair set and the current is within the
@@ -212,9 +260,20 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 	}
 
 	if m.currentX509CA.ShouldActivateNext(now) {
-		m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
-		m.nextX509CA.Reset()
-		m.activateX509CA()
+		// a tainted X509 CA is replaced instead of being activated
+		tainted, err := m.isX509CATainted(ctx, m.nextX509CA)
+		if err != nil {
+			return err
+		}
+		if tainted {
+			m.c.Log.WithField(telemetry.Slot, m.nextX509CA.id).Warn("Prepared X509 CA is tainted; preparing a new one")
+			if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
+				return err
+			}
+		}
+		if err := m.activateNextX509CA(ctx); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -227,6 +286,10 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 	log := m.c.Log.WithField(telemetry.Slot, slot.id)
 	log.Debug("Preparing X509 CA")
 
+	if err := m.renewRotationLease(ctx); err != nil {
+		return err
+	}
+
 	slot.Reset()
 
 	now := m.c.Clock.Now()
@@ -258,7 +321,13 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 	slot.issuedAt = now
 	slot.x509CA = x509CA
 
-	if err := m.journal.AppendX509CA(slot.id, slot.issuedAt, slot.x509CA); err != nil {
+	if err := m.journal.AppendX509CA(ctx, slot.id, slot.issuedAt, slot.x509CA); err != nil {
+		// The other servers only learn about the X509 CA through the
+		// journal, so it cannot be used when rotation is coordinated.
+		if m.c.CoordinateRotation {
+			slot.Reset()
+			return errs.New("unable to append X509 CA to journal: %v", err)
+		}
 		log.WithError(err).Error("Unable to append X509 CA to journal")
 	}
 
@@ -272,7 +341,47 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 	return nil
 }
 
-func (m *Manager) activateX509CA() {
+// activateNextX509CA swaps the current and next X509 CA slots, marking the
+// previously active X509 CA as old. It fails if the next X509 CA is tainted.
+func (m *Manager) activateNextX509CA(ctx context.Context) error {
+	tainted, err := m.isX509CATainted(ctx, m.nextX509CA)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to determine if X509 CA is tainted: %v", err)
+	}
+	if tainted {
+		return status.Errorf(codes.FailedPrecondition, "X509 CA %q is tainted and cannot be activated", X509AuthorityID(m.nextX509CA.x509CA))
+	}
+
+	if !m.currentX509CA.IsEmpty() {
+		if err := m.journal.UpdateX509CAStatus(ctx, m.currentX509CA.x509CA.Certificate.Raw, AuthorityStatus_OLD); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.Slot, m.currentX509CA.id).Error("Unable to update X509 CA status in journal")
+		}
+	}
+	m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
+	m.nextX509CA.Reset()
+	m.activateX509CA(ctx)
+	return nil
+}
+
+// isX509CATainted returns whether the X509 CA in the slot is tainted in the
+// bundle.
+func (m *Manager) isX509CATainted(ctx context.Context, slot *x509CASlot) (bool, error) {
+	if slot.IsEmpty() {
+		return false, nil
+	}
+	bundle, err := m.fetchOptionalBundle(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, rootCA := range bundle.GetRootCas() {
+		if rootCA.TaintedKey && bytes.Equal(rootCA.DerBytes, slot.x509CA.Certificate.Raw) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *Manager) activateX509CA(ctx context.Context) {
 	m.c.Log.WithFields(logrus.Fields{
 		telemetry.Slot:       m.currentX509CA.id,
 		telemetry.IssuedAt:   timeField(m.currentX509CA.issuedAt),
@@ -287,6 +396,13 @@ func (m *Manager) activateX509CA() {
 		telemetry.TTL:           ttl.Seconds(),
 	}).Debug("Successfully rotated X.509 CA")
 
+	// Only the lease holder writes the journal when rotation is coordinated
+	if m.leader {
+		if err := m.journal.UpdateX509CAStatus(ctx, m.currentX509CA.x509CA.Certificate.Raw, AuthorityStatus_ACTIVE); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.Slot, m.currentX509CA.id).Error("Unable to update X509 CA status in journal")
+		}
+	}
+
 	m.c.CA.SetX509CA(m.currentX509CA.x509CA)
 }
 
@@ -298,7 +414,7 @@ func (m *Manager) rotateJWTKey(ctx context.Context) error {
 		if err := m.prepareJWTKey(ctx, m.currentJWTKey); err != nil {
 			return err
 		}
-		m.activateJWTKey()
+		m.activateJWTKey(ctx)
 	}
 
 	// if there is no next keypair set and the current is within the
@@ -310,9 +426,20 @@ func (m *Manager) rotateJWTKey(ctx context.Context) error {
 	}
 
 	if m.currentJWTKey.ShouldActivateNext(now) {
-		m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
-		m.nextJWTKey.Reset()
-		m.activateJWTKey()
+		// a tainted JWT key is replaced instead of being activated
+		tainted, err := m.isJWTKeyTainted(ctx, m.nextJWTKey)
+		if err != nil {
+			return err
+		}
+		if tainted {
+			m.c.Log.WithField(telemetry.Slot, m.nextJWTKey.id).Warn("Prepared JWT key is tainted; preparing a new one")
+			if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
+				return err
+			}
+		}
+		if err := m.activateNextJWTKey(ctx); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -325,6 +452,10 @@ func (m *Manager) prepareJWTKey(ctx context.Context, slot *jwtKeySlot) (err erro
 	log := m.c.Log.WithField(telemetry.Slot, slot.id)
 	log.Debug("Preparing JWT key")
 
+	if err := m.renewRotationLease(ctx); err != nil {
+		return err
+	}
+
 	slot.Reset()
 
 	now := m.c.Clock.Now()
@@ -353,7 +484,13 @@ func (m *Manager) prepareJWTKey(ctx context.Context, slot *jwtKeySlot) (err erro
 	slot.issuedAt = now
 	slot.jwtKey = jwtKey
 
-	if err := m.journal.AppendJWTKey(slot.id, slot.issuedAt, slot.jwtKey); err != nil {
+	if err := m.journal.AppendJWTKey(ctx, slot.id, slot.issuedAt, slot.jwtKey); err != nil {
+		// The other servers only learn about the JWT key through the
+		// journal, so it cannot be used when rotation is coordinated.
+		if m.c.CoordinateRotation {
+			slot.Reset()
+			return errs.New("unable to append JWT key to journal: %v", err)
+		}
 		log.WithError(err).Error("Unable to append JWT key to journal")
 	}
 
@@ -410,13 +547,61 @@ func (m *Manager) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) (
 	return resp.Bundle.JwtSigningKeys, nil
 }
 
-func (m *Manager) activateJWTKey() {
+// activateNextJWTKey swaps the current and next JWT key slots, marking the
+// previously active JWT key as old. It fails if the next JWT key is tainted.
+func (m *Manager) activateNextJWTKey(ctx context.Context) error {
+	tainted, err := m.isJWTKeyTainted(ctx, m.nextJWTKey)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to determine if JWT key is tainted: %v", err)
+	}
+	if tainted {
+		return status.Errorf(codes.FailedPrecondition, "JWT key %q is tainted and cannot be activated", m.nextJWTKey.jwtKey.Kid)
+	}
+
+	if !m.currentJWTKey.IsEmpty() {
+		if err := m.journal.UpdateJWTKeyStatus(ctx, m.currentJWTKey.jwtKey.Kid, AuthorityStatus_OLD); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.Slot, m.currentJWTKey.id).Error("Unable to update JWT key status in journal")
+		}
+	}
+	m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
+	m.nextJWTKey.Reset()
+	m.activateJWTKey(ctx)
+	return nil
+}
+
+// isJWTKeyTainted returns whether the JWT key in the slot is tainted in the
+// bundle.
+func (m *Manager) isJWTKeyTainted(ctx context.Context, slot *jwtKeySlot) (bool, error) {
+	if slot.IsEmpty() {
+		return false, nil
+	}
+	bundle, err := m.fetchOptionalBundle(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, jwtSigningKey := range bundle.GetJwtSigningKeys() {
+		if jwtSigningKey.TaintedKey && jwtSigningKey.Kid == slot.jwtKey.Kid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *Manager) activateJWTKey(ctx context.Context) {
 	m.c.Log.WithFields(logrus.Fields{
 		telemetry.Slot:       m.currentJWTKey.id,
 		telemetry.IssuedAt:   timeField(m.currentJWTKey.issuedAt),
 		telemetry.Expiration: timeField(m.currentJWTKey.jwtKey.NotAfter),
 	}).Info("JWT key activated")
 	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
+
+	// Only the lease holder writes the journal when rotation is coordinated
+	if m.leader {
+		if err := m.journal.UpdateJWTKeyStatus(ctx, m.currentJWTKey.jwtKey.Kid, AuthorityStatus_ACTIVE); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.Slot, m.currentJWTKey.id).Error("Unable to update JWT key status in journal")
+		}
+	}
+
 	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
 }
 
@@ -486,17 +671,7 @@ func (m *Manager) appendBundle(ctx context.Context, caChain []*x509.Certificate,
 }
 
 func (m *Manager) loadJournal(ctx context.Context) error {
-	jsonPath := filepath.Join(m.c.Dir, "certs.json")
-	if ok, err := migrateJSONFile(jsonPath, m.journalPath()); err != nil {
-		return errs.New("failed to migrate old JSON data: %v", err)
-	} else if ok {
-		m.c.Log.Info("Migrated data to journal")
-	}
-
-	// Load the journal and see if we can figure out the next and current
-	// X509CA and JWTKey entries, if any.
-	m.c.Log.WithField(telemetry.Path, m.journalPath()).Debug("Loading journal")
-	journal, err := LoadJournal(m.journalPath())
+	journal, err := m.openJournal(ctx)
 	if err != nil {
 		return err
 	}
@@ -505,14 +680,72 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 
 	entries := journal.Entries()
 
-	now := m.c.Clock.Now()
-
 	m.c.Log.WithFields(logrus.Fields{
 		telemetry.X509CAs: len(entries.X509CAs),
 		telemetry.JWTKeys: len(entries.JwtKeys),
 	}).Info("Journal loaded")
 
-	if len(entries.X509CAs) > 0 {
+	if err := m.loadSlots(ctx); err != nil {
+		return err
+	}
+
+	now := m.c.Clock.Now()
+
+	// activate the X509CA and JWT key immediately if they are set and not
+	// within activation time of the next ones. When rotation is coordinated,
+	// the lease holder decides when the next ones are activated so the
+	// current ones are always used.
+	if !m.currentX509CA.IsEmpty() && (m.c.CoordinateRotation || !m.currentX509CA.ShouldActivateNext(now)) {
+		m.activateX509CA(ctx)
+	}
+
+	if !m.currentJWTKey.IsEmpty() && (m.c.CoordinateRotation || !m.currentJWTKey.ShouldActivateNext(now)) {
+		m.activateJWTKey(ctx)
+	}
+
+	return nil
+}
+
+func (m *Manager) openJournal(ctx context.Context) (*Journal, error) {
+	if m.c.JournalInDataStore {
+		m.c.Log.Debug("Loading journal from datastore")
+		m.dsJournal = newDataStoreJournalStorage(m.c.Catalog.GetDataStore(), m.c.TrustDomain.String(), m.serverID, !m.c.CoordinateRotation)
+		return openJournal(ctx, m.dsJournal)
+	}
+
+	jsonPath := filepath.Join(m.c.Dir, "certs.json")
+	if ok, err := migrateJSONFile(jsonPath, m.journalPath()); err != nil {
+		return nil, errs.New("failed to migrate old JSON data: %v", err)
+	} else if ok {
+		m.c.Log.Info("Migrated data to journal")
+	}
+
+	m.c.Log.WithField(telemetry.Path, m.journalPath()).Debug("Loading journal")
+	return LoadJournal(m.journalPath())
+}
+
+// loadSlots figures out the current and next X509CA and JWTKey slots, if
+// any, from the journal entries.
+func (m *Manager) loadSlots(ctx context.Context) (err error) {
+	entries := m.journal.Entries()
+
+	m.currentX509CA, m.nextX509CA = nil, nil
+	m.currentJWTKey, m.nextJWTKey = nil, nil
+
+	if currentEntry, nextEntry, ok := trackedX509CAEntries(entries.X509CAs); ok {
+		// the journal tracks which X509 CA is active, which may not be the
+		// second to last one if the X509 CA was rotated on demand.
+		m.currentX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, currentEntry)
+		if err != nil {
+			return err
+		}
+		if nextEntry != nil {
+			m.nextX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, nextEntry)
+			if err != nil {
+				return err
+			}
+		}
+	} else if len(entries.X509CAs) > 0 {
 		m.nextX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, entries.X509CAs[len(entries.X509CAs)-1])
 		if err != nil {
 			return err
@@ -526,8 +759,11 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		}
 	}
 	switch {
-	case m.currentX509CA != nil:
+	case m.currentX509CA != nil && m.nextX509CA != nil:
 		// both current and next are set
+	case m.currentX509CA != nil:
+		// current is set but not next. initialize next with an empty slot.
+		m.nextX509CA = newX509CASlot(otherSlotID(m.currentX509CA.id))
 	case m.nextX509CA != nil:
 		// next is set but not current. swap them and initialize next with an empty slot.
 		m.currentX509CA, m.nextX509CA = m.nextX509CA, newX509CASlot(otherSlotID(m.nextX509CA.id))
@@ -537,13 +773,20 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		m.nextX509CA = newX509CASlot("B")
 	}
 
-	if !m.currentX509CA.IsEmpty() && !m.currentX509CA.ShouldActivateNext(now) {
-		// activate the X509CA immediately if it is set and not within
-		// activation time of the next X509CA.
-		m.activateX509CA()
-	}
-
-	if len(entries.JwtKeys) > 0 {
+	if currentEntry, nextEntry, ok := trackedJWTKeyEntries(entries.JwtKeys); ok {
+		// the journal tracks which JWT key is active, which may not be the
+		// second to last one if the JWT key was rotated on demand.
+		m.currentJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, currentEntry)
+		if err != nil {
+			return err
+		}
+		if nextEntry != nil {
+			m.nextJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, nextEntry)
+			if err != nil {
+				return err
+			}
+		}
+	} else if len(entries.JwtKeys) > 0 {
 		m.nextJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries.JwtKeys[len(entries.JwtKeys)-1])
 		if err != nil {
 			return err
@@ -557,8 +800,11 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		}
 	}
 	switch {
-	case m.currentJWTKey != nil:
+	case m.currentJWTKey != nil && m.nextJWTKey != nil:
 		// both current and next are set
+	case m.currentJWTKey != nil:
+		// current is set but not next. initialize next with an empty slot.
+		m.nextJWTKey = newJWTKeySlot(otherSlotID(m.currentJWTKey.id))
 	case m.nextJWTKey != nil:
 		// next is set but not current. swap them and initialize next with an empty slot.
 		m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, newJWTKeySlot(otherSlotID(m.nextJWTKey.id))
@@ -568,15 +814,135 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		m.nextJWTKey = newJWTKeySlot("B")
 	}
 
-	if !m.currentJWTKey.IsEmpty() && !m.currentJWTKey.ShouldActivateNext(now) {
-		// activate the JWT key immediately if it is set and not within
-		// activation time of the next JWT key.
-		m.activateJWTKey()
+	return nil
+}
+
+// syncJournal acquires or renews the rotation lease and reloads the journal,
+// loading the X509 CA and JWT key slots again if the journal was changed by
+// another server. The current X509 CA and JWT key are activated if they
+// changed.
+func (m *Manager) syncJournal(ctx context.Context) error {
+	leader, err := m.dsJournal.acquireLease(ctx, m.c.Clock.Now(), rotationLeaseTTL)
+	if err != nil {
+		return err
+	}
+	if leader != m.leader {
+		if leader {
+			m.c.Log.WithField(telemetry.ServerID, m.serverID).Info("Acquired rotation lease")
+		} else {
+			m.c.Log.WithField(telemetry.ServerID, m.serverID).Warn("Lost rotation lease")
+		}
+		m.leader = leader
+	}
+
+	changed, err := m.journal.Reload(ctx)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	m.c.Log.Debug("Journal changed by another server")
+
+	var prevX509CA *X509CA
+	if m.currentX509CA != nil {
+		prevX509CA = m.currentX509CA.x509CA
+	}
+	var prevJWTKey *JWTKey
+	if m.currentJWTKey != nil {
+		prevJWTKey = m.currentJWTKey.jwtKey
+	}
+
+	if err := m.loadSlots(ctx); err != nil {
+		return err
+	}
+
+	if !m.currentX509CA.IsEmpty() && (prevX509CA == nil || !prevX509CA.Certificate.Equal(m.currentX509CA.x509CA.Certificate)) {
+		m.activateX509CA(ctx)
+	}
+	if !m.currentJWTKey.IsEmpty() && (prevJWTKey == nil || prevJWTKey.Kid != m.currentJWTKey.jwtKey.Kid) {
+		m.activateJWTKey(ctx)
 	}
+	return nil
+}
 
+// renewRotationLease renews the rotation lease when rotation is coordinated,
+// so that a server that lost the lease since the journal was last synced does
+// not generate a new key and record it in the journal.
+func (m *Manager) renewRotationLease(ctx context.Context) error {
+	if !m.c.CoordinateRotation {
+		return nil
+	}
+	held, err := m.dsJournal.renewLease(ctx, m.c.Clock.Now(), rotationLeaseTTL)
+	if err != nil {
+		return err
+	}
+	if !held {
+		// the next sync picks up the lease holder and the journal changes
+		return errs.New("rotation lease is no longer held or the journal was changed by another server")
+	}
 	return nil
 }
 
+// waitForActiveAuthorities waits until an X509 CA and a JWT key are active.
+// When rotation is coordinated, a server that does not hold the rotation
+// lease has to wait for the lease holder to activate them.
+func (m *Manager) waitForActiveAuthorities(ctx context.Context) error {
+	ticker := m.c.Clock.Ticker(rotateInterval)
+	defer ticker.Stop()
+
+	for {
+		m.mu.Lock()
+		ready := !m.currentX509CA.IsEmpty() && !m.currentJWTKey.IsEmpty()
+		m.mu.Unlock()
+		if ready {
+			return nil
+		}
+
+		m.c.Log.Info("Waiting for the rotation lease holder to activate the X509 CA and JWT key")
+		select {
+		case <-ticker.C:
+			// rotate() logs its errors and is retried on the next tick
+			_ = m.rotate(ctx)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// trackedX509CAEntries returns the active X509 CA entry and the entry
+// prepared after it, if any. It returns false if the journal has no active
+// entry, i.e. it was written before statuses were tracked.
+func trackedX509CAEntries(entries []*X509CAEntry) (current, next *X509CAEntry, ok bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Status != AuthorityStatus_ACTIVE {
+			continue
+		}
+		if last := entries[len(entries)-1]; i < len(entries)-1 && last.Status != AuthorityStatus_OLD {
+			next = last
+		}
+		return entries[i], next, true
+	}
+	return nil, nil, false
+}
+
+// trackedJWTKeyEntries returns the active JWT key entry and the entry
+// prepared after it, if any. It returns false if the journal has no active
+// entry, i.e. it was written before statuses were tracked.
+func trackedJWTKeyEntries(entries []*JWTKeyEntry) (current, next *JWTKeyEntry, ok bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Status != AuthorityStatus_ACTIVE {
+			continue
+		}
+		if last := entries[len(entries)-1]; i < len(entries)-1 && last.Status != AuthorityStatus_OLD {
+			next = last
+		}
+		return entries[i], next, true
+	}
+	return nil, nil, false
+}
+
 func (m *Manager) journalPath() string {
 	return filepath.Join(m.c.Dir, "journal.pem")
 }
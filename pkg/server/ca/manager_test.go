@@ -1,6 +1,7 @@
 package ca
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
@@ -36,6 +37,7 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeupstreamauthority"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -134,6 +136,137 @@ func (s *ManagerSuite) TestPersistenceFailsIfJournalLost() {
 	s.requireJWTKeyNotEqual(jwtKey, s.currentJWTKey())
 }
 
+func (s *ManagerSuite) TestPersistenceInDataStore() {
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	s.initManager(c)
+	x509CA, jwtKey := s.currentX509CA(), s.currentJWTKey()
+
+	// the journal is not written to the data directory
+	_, err := os.Stat(s.m.journalPath())
+	s.Require().True(os.IsNotExist(err))
+
+	// reinitialize with a different data directory, as another server
+	// sharing the datastore and the key manager would
+	c.Dir = s.TempDir()
+	s.initManager(c)
+	s.requireX509CAEqual(x509CA, s.currentX509CA())
+	s.requireJWTKeyEqual(jwtKey, s.currentJWTKey())
+}
+
+func (s *ManagerSuite) TestCoordinatedRotation() {
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	c.CoordinateRotation = true
+	s.initManager(c)
+	s.Require().True(s.m.leader)
+	firstX509CA, firstJWTKey := s.currentX509CA(), s.currentJWTKey()
+
+	// a second server uses the authorities activated by the lease holder
+	followerCA := new(fakeCA)
+	followerConfig := c
+	followerConfig.CA = followerCA
+	followerConfig.Dir = s.TempDir()
+	follower := NewManager(followerConfig)
+	s.Require().NoError(follower.Initialize(ctx))
+	s.Require().False(follower.leader)
+	s.requireX509CAEqual(firstX509CA, followerCA.X509CA())
+	s.requireJWTKeyEqual(firstJWTKey, followerCA.JWTKey())
+
+	// only the lease holder prepares and activates authorities
+	_, err := follower.PrepareX509CA(ctx)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "this server does not hold the rotation lease")
+	_, err = follower.ActivateJWTKey(ctx, firstJWTKey.Kid)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "this server does not hold the rotation lease")
+
+	// the follower picks up the prepared authorities
+	s.addTimeAndRotate(prepareAfter + time.Minute)
+	secondX509CA, secondJWTKey := s.nextX509CA(), s.nextJWTKey()
+	s.Require().NotNil(secondX509CA)
+	s.Require().NotNil(secondJWTKey)
+	s.Require().NoError(follower.rotate(ctx))
+	s.requireX509CAEqual(secondX509CA, follower.nextX509CA.x509CA)
+	s.requireJWTKeyEqual(secondJWTKey, follower.nextJWTKey.jwtKey)
+	s.requireX509CAEqual(firstX509CA, followerCA.X509CA())
+
+	// and activates them once the lease holder does
+	s.addTimeAndRotate(activateAfter - prepareAfter)
+	s.requireX509CAEqual(secondX509CA, s.currentX509CA())
+	s.Require().NoError(follower.rotate(ctx))
+	s.requireX509CAEqual(secondX509CA, followerCA.X509CA())
+	s.requireJWTKeyEqual(secondJWTKey, followerCA.JWTKey())
+
+	// only the authorities of the lease holder are in the bundle
+	s.requireBundleRootCAs(firstX509CA.Certificate, secondX509CA.Certificate)
+	s.requireBundleJWTKeys(firstJWTKey, secondJWTKey)
+
+	// the follower takes over once the lease expires
+	s.clock.Add(rotationLeaseTTL + time.Second)
+	s.Require().NoError(follower.rotate(ctx))
+	s.Require().True(follower.leader)
+	s.Require().NoError(s.m.rotate(ctx))
+	s.Require().False(s.m.leader)
+}
+
+func (s *ManagerSuite) TestCoordinatedRotationRequiresLeaseToPrepare() {
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	c.CoordinateRotation = true
+	s.initManager(c)
+	s.Require().True(s.m.leader)
+	firstX509CA, firstJWTKey := s.currentX509CA(), s.currentJWTKey()
+
+	// another server takes over once the lease expires, before this server
+	// syncs the journal again
+	s.clock.Add(rotationLeaseTTL + time.Second)
+	followerConfig := c
+	followerConfig.CA = new(fakeCA)
+	followerConfig.Dir = s.TempDir()
+	follower := NewManager(followerConfig)
+	s.Require().NoError(follower.Initialize(ctx))
+	s.Require().True(follower.leader)
+
+	// no new authority is prepared without the lease
+	err := s.m.prepareX509CA(ctx, s.m.nextX509CA)
+	s.Require().EqualError(err, "rotation lease is no longer held or the journal was changed by another server")
+	err = s.m.prepareJWTKey(ctx, s.m.nextJWTKey)
+	s.Require().EqualError(err, "rotation lease is no longer held or the journal was changed by another server")
+	s.Require().Nil(s.nextX509CA())
+	s.Require().Nil(s.nextJWTKey())
+	s.requireBundleRootCAs(firstX509CA.Certificate)
+	s.requireBundleJWTKeys(firstJWTKey)
+	s.Require().Len(s.m.journal.Entries().X509CAs, 1)
+	s.Require().Len(s.m.journal.Entries().JwtKeys, 1)
+}
+
+func (s *ManagerSuite) TestCoordinatedRotationFailsIfJournalAppendFails() {
+	ds := &journalFailingDataStore{DataStore: s.ds}
+	s.cat.SetDataStore(ds)
+
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	c.CoordinateRotation = true
+	s.initManager(c)
+
+	// the authorities that cannot be recorded in the journal are not kept
+	ds.failSave = true
+	s.clock.Add(prepareAfter + time.Minute)
+	err := s.m.rotate(ctx)
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "unable to append X509 CA to journal")
+	s.Require().Contains(err.Error(), "unable to append JWT key to journal")
+	s.Require().Nil(s.nextX509CA())
+	s.Require().Nil(s.nextJWTKey())
+
+	// and are prepared again on the next rotation
+	ds.failSave = false
+	s.Require().NoError(s.m.rotate(ctx))
+	s.Require().NotNil(s.nextX509CA())
+	s.Require().NotNil(s.nextJWTKey())
+	s.Require().Len(s.m.journal.Entries().X509CAs, 2)
+	s.Require().Len(s.m.journal.Entries().JwtKeys, 2)
+}
+
 func (s *ManagerSuite) TestSelfSigning() {
 	s.initSelfSignedManager()
 
@@ -430,6 +563,202 @@ func (s *ManagerSuite) TestJWTKeyRotation() {
 	s.Nil(s.nextJWTKey())
 }
 
+func (s *ManagerSuite) TestForcedX509CARotation() {
+	s.initSelfSignedManager()
+
+	first := s.currentX509CA()
+	active, prepared := s.m.GetX509CAState()
+	s.Require().Equal(X509AuthorityID(first), active.AuthorityID)
+	s.Require().Nil(prepared)
+
+	// activating requires a prepared X509 CA
+	_, err := s.m.ActivateX509CA(ctx, active.AuthorityID)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "no X509 CA has been prepared")
+
+	// prepare a new X509 CA well before the preparation mark
+	prepared, err = s.m.PrepareX509CA(ctx)
+	s.Require().NoError(err)
+	second := s.nextX509CA()
+	s.Require().NotNil(second, "second X509CA should have been prepared")
+	s.Require().Equal(X509AuthorityID(second), prepared.AuthorityID)
+	s.requireX509CAEqual(first, s.currentX509CA())
+	s.requireBundleRootCAs(first.Certificate, second.Certificate)
+
+	// the prepared X509 CA cannot be tainted or revoked
+	err = s.m.TaintX509CA(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "prepared X509 CA")
+	err = s.m.RevokeX509CA(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "prepared X509 CA")
+
+	// the authority ID must match the prepared X509 CA
+	_, err = s.m.ActivateX509CA(ctx, active.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is not the prepared X509 CA")
+
+	active, err = s.m.ActivateX509CA(ctx, prepared.AuthorityID)
+	s.Require().NoError(err)
+	s.Require().Equal(prepared.AuthorityID, active.AuthorityID)
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Require().Nil(s.nextX509CA())
+
+	// the forced rotation survives a restart
+	s.initSelfSignedManager()
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Require().Nil(s.nextX509CA())
+
+	// the active X509 CA cannot be tainted
+	err = s.m.TaintX509CA(ctx, X509AuthorityID(second))
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "active X509 CA")
+
+	// the old X509 CA must be tainted before it is revoked
+	err = s.m.RevokeX509CA(ctx, X509AuthorityID(first))
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "must be tainted")
+
+	s.Require().NoError(s.m.TaintX509CA(ctx, X509AuthorityID(first)))
+	s.RequireProtoEqual(&common.Bundle{
+		RootCas: []*common.Certificate{
+			{DerBytes: first.Certificate.Raw, TaintedKey: true},
+			{DerBytes: second.Certificate.Raw},
+		},
+	}, &common.Bundle{RootCas: s.fetchBundle().RootCas})
+
+	s.Require().NoError(s.m.RevokeX509CA(ctx, X509AuthorityID(first)))
+	s.requireBundleRootCAs(second.Certificate)
+}
+
+func (s *ManagerSuite) TestForcedJWTKeyRotation() {
+	s.initSelfSignedManager()
+
+	first := s.currentJWTKey()
+	active, prepared := s.m.GetJWTKeyState()
+	s.Require().Equal(first.Kid, active.AuthorityID)
+	s.Require().Nil(prepared)
+
+	// activating requires a prepared JWT key
+	_, err := s.m.ActivateJWTKey(ctx, active.AuthorityID)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "no JWT key has been prepared")
+
+	// prepare a new JWT key well before the preparation mark
+	prepared, err = s.m.PrepareJWTKey(ctx)
+	s.Require().NoError(err)
+	second := s.nextJWTKey()
+	s.Require().NotNil(second, "second JWT key should have been prepared")
+	s.Require().Equal(second.Kid, prepared.AuthorityID)
+	s.requireJWTKeyEqual(first, s.currentJWTKey())
+	s.requireBundleJWTKeys(first, second)
+
+	// the prepared JWT key cannot be tainted or revoked
+	err = s.m.TaintJWTKey(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "prepared JWT key")
+	err = s.m.RevokeJWTKey(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "prepared JWT key")
+
+	// the authority ID must match the prepared JWT key
+	_, err = s.m.ActivateJWTKey(ctx, active.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is not the prepared JWT key")
+
+	active, err = s.m.ActivateJWTKey(ctx, prepared.AuthorityID)
+	s.Require().NoError(err)
+	s.Require().Equal(prepared.AuthorityID, active.AuthorityID)
+	s.requireJWTKeyEqual(second, s.currentJWTKey())
+	s.Require().Nil(s.nextJWTKey())
+
+	// the forced rotation survives a restart
+	s.initSelfSignedManager()
+	s.requireJWTKeyEqual(second, s.currentJWTKey())
+	s.Require().Nil(s.nextJWTKey())
+
+	// the active JWT key cannot be tainted
+	err = s.m.TaintJWTKey(ctx, second.Kid)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "active JWT key")
+
+	// the old JWT key must be tainted before it is revoked
+	err = s.m.RevokeJWTKey(ctx, first.Kid)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "must be tainted")
+
+	s.Require().NoError(s.m.TaintJWTKey(ctx, first.Kid))
+	jwtSigningKeys := s.fetchBundle().JwtSigningKeys
+	s.Require().Len(jwtSigningKeys, 2)
+	s.Require().True(jwtSigningKeys[0].TaintedKey)
+	s.Require().False(jwtSigningKeys[1].TaintedKey)
+
+	s.Require().NoError(s.m.RevokeJWTKey(ctx, first.Kid))
+	s.requireBundleJWTKeys(second)
+}
+
+func (s *ManagerSuite) TestTaintedX509CAIsNotActivated() {
+	s.initSelfSignedManager()
+
+	first := s.currentX509CA()
+	prepared, err := s.m.PrepareX509CA(ctx)
+	s.Require().NoError(err)
+	second := s.nextX509CA()
+	s.Require().NotNil(second)
+
+	// taint the prepared X509 CA behind the manager's back, as another
+	// server sharing the datastore could
+	_, err = s.ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: testTrustDomainURL.String(),
+		SubjectKeyId:  second.Certificate.SubjectKeyId,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.m.ActivateX509CA(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is tainted")
+	s.requireX509CAEqual(first, s.currentX509CA())
+	s.requireX509CAEqual(second, s.nextX509CA())
+
+	// at the activation mark, the tainted X509 CA is replaced by a new one
+	// that is activated instead
+	s.setTimeAndRotateX509CA(s.clock.Now().Add(activateAfter + time.Minute))
+	third := s.currentX509CA()
+	s.Require().NotEqual(second.Certificate.Raw, third.Certificate.Raw)
+	s.Require().NotEqual(first.Certificate.Raw, third.Certificate.Raw)
+	s.Require().Nil(s.nextX509CA())
+}
+
+func (s *ManagerSuite) TestTaintedJWTKeyIsNotActivated() {
+	s.initSelfSignedManager()
+
+	first := s.currentJWTKey()
+	prepared, err := s.m.PrepareJWTKey(ctx)
+	s.Require().NoError(err)
+	second := s.nextJWTKey()
+	s.Require().NotNil(second)
+
+	// taint the prepared JWT key behind the manager's back, as another
+	// server sharing the datastore could
+	_, err = s.ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: testTrustDomainURL.String(),
+		KeyId:         second.Kid,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.m.ActivateJWTKey(ctx, prepared.AuthorityID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is tainted")
+	s.requireJWTKeyEqual(first, s.currentJWTKey())
+	s.requireJWTKeyEqual(second, s.nextJWTKey())
+
+	// at the activation mark, the tainted JWT key is replaced by a new one
+	// that is activated instead
+	s.setTimeAndRotateJWTKey(s.clock.Now().Add(activateAfter + time.Minute))
+	third := s.currentJWTKey()
+	s.Require().NotEqual(second.Kid, third.Kid)
+	s.Require().NotEqual(first.Kid, third.Kid)
+	s.Require().Nil(s.nextJWTKey())
+}
+
+func (s *ManagerSuite) TestTaintX509CAWithUpstreamAuthority() {
+	upstreamAuthority, _, upDone := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	defer upDone()
+
+	s.initUpstreamSignedManager(upstreamAuthority, true)
+
+	err := s.m.TaintX509CA(ctx, "0102")
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "UpstreamAuthority is configured")
+}
+
 func (s *ManagerSuite) TestPrune() {
 	notifier, notifyCh := fakenotifier.NotifyWaiter()
 	s.setNotifier(notifier)
@@ -705,8 +1034,12 @@ func (s *ManagerSuite) TestAlternateKeyTypes() {
 }
 
 func (s *ManagerSuite) initSelfSignedManager() {
+	s.initManager(s.selfSignedConfig())
+}
+
+func (s *ManagerSuite) initManager(c ManagerConfig) {
 	s.cat.SetUpstreamAuthority(nil)
-	s.m = NewManager(s.selfSignedConfig())
+	s.m = NewManager(c)
 	s.NoError(s.m.Initialize(context.Background()))
 }
 
@@ -972,3 +1305,25 @@ func (s *fakeCA) SetJWTKey(jwtKey *JWTKey) {
 	defer s.mu.Unlock()
 	s.jwtKey = jwtKey
 }
+
+// journalFailingDataStore fails to save changes to the CA journal while
+// failSave is set. Renewing the rotation lease still succeeds.
+type journalFailingDataStore struct {
+	*fakedatastore.DataStore
+	failSave bool
+}
+
+func (ds *journalFailingDataStore) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	if ds.failSave {
+		resp, err := ds.DataStore.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+			TrustDomainId: req.CaJournal.TrustDomainId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(resp.CaJournal.GetData(), req.CaJournal.Data) {
+			return nil, errors.New("ohno")
+		}
+	}
+	return ds.DataStore.SetCAJournal(ctx, req)
+}
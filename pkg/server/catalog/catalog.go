@@ -9,6 +9,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	ds_kv "github.com/spiffe/spire/pkg/server/plugin/datastore/kv"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
@@ -49,6 +50,7 @@ var (
 	builtIns = []catalog.Plugin{
 		// DataStores
 		ds_sql.BuiltIn(),
+		ds_kv.BuiltIn(),
 		// NodeAttestors
 		na_aws_iid.BuiltIn(),
 		na_gcp_iit.BuiltIn(),
@@ -247,3 +249,43 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		Closer:  closer,
 	}, nil
 }
+
+type DataStoreRepository struct {
+	datastore.DataStore
+	catalog.Closer
+}
+
+// LoadDataStore loads only the DataStore plugin. It is used to operate on
+// the datastore without running the server, so the only host service
+// provided is the metrics service.
+func LoadDataStore(ctx context.Context, config Config) (*DataStoreRepository, error) {
+	pluginConfigs, err := catalog.PluginConfigFromHCL(HCLPluginConfigMap{
+		datastore.Type: config.PluginConfig[datastore.Type],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := new(struct {
+		DataStore datastore.DataStore
+	})
+	closer, err := catalog.Fill(ctx, catalog.Config{
+		Log:           config.Log,
+		GlobalConfig:  config.GlobalConfig,
+		PluginConfig:  pluginConfigs,
+		KnownPlugins:  KnownPlugins(),
+		KnownServices: KnownServices(),
+		BuiltIns:      BuiltIns(),
+		HostServices: []catalog.HostServiceServer{
+			common_services.MetricsServiceHostServiceServer(config.MetricsService),
+		},
+	}, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DataStoreRepository{
+		DataStore: p.DataStore,
+		Closer:    closer,
+	}, nil
+}
@@ -165,6 +165,37 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadDataStore(t *testing.T) {
+	ctx := context.Background()
+
+	ds := fakedatastore.New()
+	_, err := ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{Token: "foo", Expiry: 1},
+	})
+	require.NoError(t, err)
+
+	// Only the DataStore plugin is built in, so loading any other configured
+	// plugin would fail
+	builtIns = []catalog.Plugin{
+		catalog.MakePlugin("fake_ds", datastore.PluginServer(ds)),
+	}
+	log, _ := test.NewNullLogger()
+
+	repo, err := LoadDataStore(ctx, Config{
+		Log:          log,
+		PluginConfig: createDefaultConfig(),
+		MetricsService: metricsservice.New(metricsservice.Config{
+			Metrics: fakemetrics.New(),
+		}),
+	})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	resp, err := repo.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "foo"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.JoinToken)
+}
+
 // createDefaultConfig create a HclPluginConfigMap with the minimal necessary plugins configuration
 func createDefaultConfig() HCLPluginConfigMap {
 	return HCLPluginConfigMap{
@@ -11,6 +11,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 )
@@ -21,6 +22,10 @@ type Config struct {
 
 	Log logrus.FieldLogger
 
+	// AuditLog, if set, receives a structured record for every server API
+	// call that mutates server state.
+	AuditLog logrus.FieldLogger
+
 	// Address of SPIRE server
 	BindAddress *net.TCPAddr
 
@@ -52,6 +57,10 @@ type Config struct {
 	// SVIDTTL is default time-to-live for SVIDs
 	SVIDTTL time.Duration
 
+	// JWTSVIDTTL is the default time-to-live for JWT-SVIDs. If unset, the
+	// CA default is used.
+	JWTSVIDTTL time.Duration
+
 	// CATTL is the time-to-live for the server CA. This only applies to
 	// self-signed CA certificates, otherwise it is up to the upstream CA.
 	CATTL time.Duration
@@ -69,8 +78,37 @@ type Config struct {
 	// HealthChecks provides the configuration for health monitoring
 	HealthChecks health.Config
 
-	// CAKeyType is the key type used for the X509 and JWT signing keys
+	// CAKeyType is the key type used for the X509 signing key, and for the
+	// JWT signing key unless JWTKeyType is set
 	CAKeyType keymanager.KeyType
+
+	// JWTKeyType is the key type used for the JWT signing key
+	JWTKeyType keymanager.KeyType
+
+	// AuthorizationPolicy, if set, replaces the built-in authorization of
+	// the server APIs.
+	AuthorizationPolicy *endpoints.Policy
+
+	// RateLimits are the per-caller rate limits of the node API and the
+	// server APIs
+	RateLimits endpoints.RateLimitConfig
+
+	// PruneAgentsExpiredFor, if non-zero, enables pruning of agents whose
+	// SVID expired more than the given duration ago
+	PruneAgentsExpiredFor time.Duration
+
+	// NotifyAgentPruning, if true, notifies the notifier plugins before
+	// pruning an agent
+	NotifyAgentPruning bool
+
+	// JournalInDataStore, if true, keeps the CA journal in the datastore
+	// instead of the data directory
+	JournalInDataStore bool
+
+	// CoordinateRotation, if true, lets a single server of the trust domain
+	// prepare and activate the X509 CA and JWT key. Requires
+	// JournalInDataStore.
+	CoordinateRotation bool
 }
 
 type ExperimentalConfig struct {
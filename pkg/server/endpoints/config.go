@@ -51,6 +51,19 @@ type Config struct {
 
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
+
+	// RateLimits are the per-caller rate limits of the node API and the
+	// spire-next server APIs
+	RateLimits RateLimitConfig
+
+	// AuthorizationPolicy, if set, replaces the built-in authorization of
+	// the spire-next server APIs and also applies to the legacy registration
+	// API.
+	AuthorizationPolicy *Policy
+
+	// AuditLog, if set, receives a record for every call to a method that
+	// mutates server state.
+	AuditLog logrus.FieldLogger
 }
 
 // New creates new endpoints struct
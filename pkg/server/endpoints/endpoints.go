@@ -11,19 +11,29 @@ import (
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
-	"github.com/spiffe/spire/pkg/common/auth"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/api/agent/v1"
+	bundlev1 "github.com/spiffe/spire/pkg/server/api/bundle/v1"
+	"github.com/spiffe/spire/pkg/server/api/entry/v1"
+	"github.com/spiffe/spire/pkg/server/api/event/v1"
+	"github.com/spiffe/spire/pkg/server/api/federation/v1"
+	"github.com/spiffe/spire/pkg/server/api/localauthority/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/svid/v1"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/endpoints/registration"
+	"github.com/spiffe/spire/pkg/server/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	datastore_pb "github.com/spiffe/spire/pkg/server/plugin/datastore"
 	node_pb "github.com/spiffe/spire/proto/spire/api/node"
@@ -54,16 +64,40 @@ type Endpoints struct {
 // on one of the servers.
 func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	e.c.Log.Debug("Initializing API endpoints")
-	tcpServer := e.createTCPServer(ctx)
-	udsServer := e.createUDSServer()
+	ds := e.c.Catalog.GetDataStore()
+	authorizers := Authorization(e.c.Log, ds, clock.New())
+	var rm middleware.Middleware
+	if e.c.AuthorizationPolicy != nil {
+		e.c.Log.Info("Authorizing server API calls with the configured policy")
+		authorizers = PolicyAuthorization(e.c.AuthorizationPolicy, e.c.Log, ds, clock.New())
+		rm = RegistrationMiddleware(e.c.Log, authorizers)
+	}
+	m := Middleware(e.c.Log, e.c.Metrics, authorizers, RateLimits(e.c.RateLimits, e.c.Metrics))
+
+	entryCache := entrycache.New(entrycache.Config{
+		Log:       e.c.Log.WithField(telemetry.SubsystemName, telemetry.EntryCache),
+		Metrics:   e.c.Metrics,
+		DataStore: ds,
+	})
+	if err := entryCache.Load(ctx); err != nil {
+		return fmt.Errorf("failed to load entry cache: %v", err)
+	}
+
+	tcpServer := e.createTCPServer(ctx, m, rm)
+	udsServer := e.createUDSServer(m, rm)
 
-	err := e.registerNodeAPI(tcpServer)
+	err := e.registerNodeAPI(tcpServer, entryCache)
 	if err != nil {
 		return err
 	}
 	e.registerRegistrationAPI(tcpServer, udsServer)
 
+	if err := e.registerNewAPIs(tcpServer, udsServer, entryCache); err != nil {
+		return err
+	}
+
 	tasks := []func(context.Context) error{
+		entryCache.Run,
 		func(ctx context.Context) error {
 			return e.runTCPServer(ctx, tcpServer)
 		},
@@ -83,14 +117,14 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	return err
 }
 
-func (e *Endpoints) createTCPServer(ctx context.Context) *grpc.Server {
+func (e *Endpoints) createTCPServer(ctx context.Context, m, rm middleware.Middleware) *grpc.Server {
 	tlsConfig := &tls.Config{
 		GetConfigForClient: e.getTLSConfig(ctx),
 	}
 
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		grpc.UnaryInterceptor(auditUnaryInterceptor(e.c.AuditLog, unaryInterceptor(m, rm))),
+		grpc.StreamInterceptor(streamInterceptor(m, rm)),
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionAge: defaultMaxConnectionAge,
@@ -98,10 +132,10 @@ func (e *Endpoints) createTCPServer(ctx context.Context) *grpc.Server {
 	)
 }
 
-func (e *Endpoints) createUDSServer() *grpc.Server {
+func (e *Endpoints) createUDSServer(m, rm middleware.Middleware) *grpc.Server {
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		grpc.UnaryInterceptor(auditUnaryInterceptor(e.c.AuditLog, unaryInterceptor(m, rm))),
+		grpc.StreamInterceptor(streamInterceptor(m, rm)),
 		grpc.Creds(peertracker.NewCredentials()))
 }
 
@@ -143,7 +177,7 @@ func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 
 // registerNodeAPI creates a Node API handler and registers it against
 // the provided gRPC server.
-func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server) error {
+func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server, entryCache *entrycache.Cache) error {
 	n, err := node.NewHandler(node.HandlerConfig{
 		Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.NodeAPI),
 		Metrics:     e.c.Metrics,
@@ -153,6 +187,8 @@ func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server) error {
 		Manager:     e.c.Manager,
 
 		AllowAgentlessNodeAttestors: e.c.AllowAgentlessNodeAttestors,
+		RateLimits:                  e.c.RateLimits.nodeLimits(),
+		EntryFetcher:                entryCache,
 	})
 	if err != nil {
 		return err
@@ -176,6 +212,72 @@ func (e *Endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) {
 	registration_pb.RegisterRegistrationServer(udpServer, r)
 }
 
+// registerNewAPIs creates the spire-next API services and registers them
+// against the provided gRPC servers.
+func (e *Endpoints) registerNewAPIs(tcpServer, udsServer *grpc.Server, entryCache *entrycache.Cache) error {
+	td, err := spiffeid.TrustDomainFromString(e.c.TrustDomain.String())
+	if err != nil {
+		return fmt.Errorf("invalid trust domain: %v", err)
+	}
+
+	ds := e.c.Catalog.GetDataStore()
+
+	entryService := entry.New(entry.Config{
+		Datastore:    ds,
+		EntryFetcher: AuthorizedEntryFetcher(entryCache),
+		TrustDomain:  td,
+	})
+	entry.RegisterService(tcpServer, entryService)
+	entry.RegisterService(udsServer, entryService)
+
+	agentService := agent.New(agent.Config{
+		Catalog:     e.c.Catalog,
+		Datastore:   ds,
+		ServerCA:    e.c.ServerCA,
+		TrustDomain: td,
+	})
+	agent.RegisterService(tcpServer, agentService)
+	agent.RegisterService(udsServer, agentService)
+
+	bundleService := bundlev1.New(bundlev1.Config{
+		Datastore:         ds,
+		TrustDomain:       td,
+		UpstreamPublisher: e.c.Manager,
+	})
+	bundlev1.RegisterService(tcpServer, bundleService)
+	bundlev1.RegisterService(udsServer, bundleService)
+
+	eventService := event.New(event.Config{
+		Datastore: ds,
+	})
+	event.RegisterService(tcpServer, eventService)
+	event.RegisterService(udsServer, eventService)
+
+	federationService := federation.New(federation.Config{
+		Datastore:   ds,
+		TrustDomain: td,
+	})
+	federation.RegisterService(tcpServer, federationService)
+	federation.RegisterService(udsServer, federationService)
+
+	localAuthorityService := localauthority.New(localauthority.Config{
+		Manager: e.c.Manager,
+	})
+	localauthority.RegisterService(tcpServer, localAuthorityService)
+	localauthority.RegisterService(udsServer, localAuthorityService)
+
+	svidService := svid.New(svid.Config{
+		EntryFetcher: AuthorizedEntryFetcher(entryCache),
+		ServerCA:     e.c.ServerCA,
+		TrustDomain:  td,
+		Datastore:    ds,
+	})
+	svid.RegisterService(tcpServer, svidService)
+	svid.RegisterService(udsServer, svidService)
+
+	return nil
+}
+
 // runTCPServer will start the server and block until it exits or we are dying.
 func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error {
 	l, err := net.Listen(e.c.TCPAddr.Network(), e.c.TCPAddr.String())
@@ -14,6 +14,9 @@ import (
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -72,25 +75,34 @@ func (s *EndpointsTestSuite) SetupTest() {
 		TrustDomain: td,
 		Catalog:     catalog,
 		Log:         log,
+		Metrics:     telemetry.Blackhole{},
 	}
 
 	s.e = New(c)
 }
 
 func (s *EndpointsTestSuite) TestCreateTCPServer() {
-	s.Assert().NotNil(s.e.createTCPServer(ctx))
+	s.Assert().NotNil(s.e.createTCPServer(ctx, middleware.Chain(), nil))
 }
 
 func (s *EndpointsTestSuite) TestCreateUDSServer() {
-	s.Assert().NotNil(s.e.createUDSServer())
+	s.Assert().NotNil(s.e.createUDSServer(middleware.Chain(), nil))
 }
 
 func (s *EndpointsTestSuite) TestRegisterNodeAPI() {
-	s.Require().NoError(s.e.registerNodeAPI(s.e.createTCPServer(ctx)))
+	entryCache := entrycache.New(entrycache.Config{DataStore: s.ds})
+	s.Require().NoError(s.e.registerNodeAPI(s.e.createTCPServer(ctx, middleware.Chain(), nil), entryCache))
 }
 
 func (s *EndpointsTestSuite) TestRegisterRegistrationAPI() {
-	s.Assert().NotPanics(func() { s.e.registerRegistrationAPI(s.e.createTCPServer(ctx), s.e.createUDSServer()) })
+	s.Assert().NotPanics(func() {
+		s.e.registerRegistrationAPI(s.e.createTCPServer(ctx, middleware.Chain(), nil), s.e.createUDSServer(middleware.Chain(), nil))
+	})
+}
+
+func (s *EndpointsTestSuite) TestRegisterNewAPIs() {
+	entryCache := entrycache.New(entrycache.Config{DataStore: s.ds})
+	s.Require().NoError(s.e.registerNewAPIs(s.e.createTCPServer(ctx, middleware.Chain(), nil), s.e.createUDSServer(middleware.Chain(), nil), entryCache))
 }
 
 func (s *EndpointsTestSuite) TestListenAndServe() {
@@ -0,0 +1,413 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/auth"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	node_handler "github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/entrycache"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newAPIPrefix is the prefix shared by the full method names of every
+// spire-next server API. Calls to these methods are handled by the
+// middleware; calls to the legacy APIs still authorize through the handler.
+const newAPIPrefix = "/spire.api.server."
+
+// registrationAPIPrefix is the prefix of the full method names of the legacy
+// registration API.
+const registrationAPIPrefix = "/spire.api.registration."
+
+// Middleware returns the middleware chain used by the spire-next server APIs,
+// authorizing calls with the given authorizers and rate limiting them with
+// the given rate limiters.
+func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, authorizers map[string]middleware.Authorizer, rateLimits map[string]api.RateLimiter) middleware.Middleware {
+	return middleware.Chain(
+		middleware.WithLogger(log),
+		middleware.WithMetrics(metrics),
+		middleware.WithAuthorization(authorizers),
+		middleware.WithRateLimits(rateLimits),
+	)
+}
+
+// RegistrationMiddleware returns the middleware chain that authorizes calls
+// to the legacy registration API with the given authorizers. It is used when
+// an authorization policy is configured, so that the policy also applies to
+// the registration API.
+func RegistrationMiddleware(log logrus.FieldLogger, authorizers map[string]middleware.Authorizer) middleware.Middleware {
+	return middleware.Chain(
+		middleware.WithLogger(log),
+		middleware.WithAuthorization(authorizers),
+	)
+}
+
+// Authorization returns the authorizers for each spire-next server API
+// method, keyed by full method name.
+func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clock) map[string]middleware.Authorizer {
+	entryFetcher := EntryFetcher(ds)
+
+	local := middleware.AuthorizeLocal()
+	admin := middleware.AuthorizeAdmin(entryFetcher)
+	agent := middleware.AuthorizeAgent(AgentAuthorizer(log, ds, clk))
+	anyCaller := middleware.AuthorizeAny()
+	downstream := middleware.AuthorizeDownstream(entryFetcher)
+	localOrAdmin := middleware.AuthorizeAnyOf(local, admin)
+	localOrAdminOrAgent := middleware.AuthorizeAnyOf(local, admin, agent)
+
+	return map[string]middleware.Authorizer{
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                 localOrAdmin,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                    localOrAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                            localOrAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                            localOrAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                            localOrAdmin,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                        agent,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                  localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                    localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                 localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                    localOrAdmin,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                 anyCaller,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                  agent,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                             localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                 anyCaller,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                              localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                       downstream,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                      localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                        localOrAdminOrAgent,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                   localOrAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                localOrAdmin,
+		"/spire.api.server.event.v1.Event/StreamEvents":                                localOrAdmin,
+		"/spire.api.server.federation.v1.Federation/ListFederationRelationships":       localOrAdmin,
+		"/spire.api.server.federation.v1.Federation/GetFederationRelationship":         localOrAdmin,
+		"/spire.api.server.federation.v1.Federation/BatchCreateFederationRelationship": localOrAdmin,
+		"/spire.api.server.federation.v1.Federation/BatchUpdateFederationRelationship": localOrAdmin,
+		"/spire.api.server.federation.v1.Federation/BatchDeleteFederationRelationship": localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":     localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":      localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":     localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":        localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":       localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":      localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":       localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":      localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":         localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":        localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                  localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                   localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                              agent,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                    agent,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                           downstream,
+	}
+}
+
+// RateLimitConfig holds the per-caller limits for each kind of rate limited
+// message. The limits are shared by the legacy node API and the spire-next
+// server APIs. Unset IP limits default to the limits of the node API.
+type RateLimitConfig struct {
+	// Attestation limits agent attestation. Since agents are not yet
+	// identified when they attest, only the IP limit applies.
+	Attestation middleware.CallerLimits
+
+	// Signing limits X509-SVID and X509 CA signing, including agent
+	// renewal
+	Signing middleware.CallerLimits
+
+	// JWTSigning limits JWT-SVID signing
+	JWTSigning middleware.CallerLimits
+
+	// PushJWTKey limits JWT authority publishing by downstream servers
+	PushJWTKey middleware.CallerLimits
+}
+
+// withDefaults returns the configuration with the default IP limits in
+// place of the unset ones.
+func (c RateLimitConfig) withDefaults() RateLimitConfig {
+	if c.Attestation.IP == 0 {
+		c.Attestation.IP = node.AttestLimit
+	}
+	if c.Signing.IP == 0 {
+		c.Signing.IP = node.CSRLimit
+	}
+	if c.JWTSigning.IP == 0 {
+		c.JWTSigning.IP = node.JSRLimit
+	}
+	if c.PushJWTKey.IP == 0 {
+		c.PushJWTKey.IP = node.PushJWTKeyLimit
+	}
+	return c
+}
+
+// nodeLimits returns the limits for each message type of the legacy node
+// API.
+func (c RateLimitConfig) nodeLimits() map[int]middleware.CallerLimits {
+	c = c.withDefaults()
+	return map[int]middleware.CallerLimits{
+		node_handler.AttestMsg:  c.Attestation,
+		node_handler.CSRMsg:     c.Signing,
+		node_handler.JSRMsg:     c.JWTSigning,
+		node_handler.PushJWTKey: c.PushJWTKey,
+	}
+}
+
+// RateLimits returns the rate limiters for each spire-next server API
+// method, keyed by full method name. Agent attestation and renewal, SVID
+// signing and JWT authority publishing are limited per caller using the
+// configured limits, which default to the per IP address limits of the
+// legacy node API.
+func RateLimits(config RateLimitConfig, metrics telemetry.Metrics) map[string]api.RateLimiter {
+	config = config.withDefaults()
+
+	noLimit := middleware.NoLimit()
+	attestLimit := middleware.PerCallerLimit("attestation", config.Attestation, metrics)
+	csrLimit := middleware.PerCallerLimit("signing", config.Signing, metrics)
+	jsrLimit := middleware.PerCallerLimit("jwt_signing", config.JWTSigning, metrics)
+	pushJWTKeyLimit := middleware.PerCallerLimit("push_jwt_key", config.PushJWTKey, metrics)
+
+	return map[string]api.RateLimiter{
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                 noLimit,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                    noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                            noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                            noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                            noLimit,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                        noLimit,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                  noLimit,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                    noLimit,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                 noLimit,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                    noLimit,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                 attestLimit,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                  csrLimit,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                             noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                 noLimit,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                              noLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                       pushJWTKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                      noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                        noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                   noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                noLimit,
+		"/spire.api.server.event.v1.Event/StreamEvents":                                noLimit,
+		"/spire.api.server.federation.v1.Federation/ListFederationRelationships":       noLimit,
+		"/spire.api.server.federation.v1.Federation/GetFederationRelationship":         noLimit,
+		"/spire.api.server.federation.v1.Federation/BatchCreateFederationRelationship": noLimit,
+		"/spire.api.server.federation.v1.Federation/BatchUpdateFederationRelationship": noLimit,
+		"/spire.api.server.federation.v1.Federation/BatchDeleteFederationRelationship": noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":     noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":      noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":     noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":        noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":       noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":      noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":       noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":      noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":        noLimit,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                  noLimit,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                   noLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                              csrLimit,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                    jsrLimit,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                           csrLimit,
+	}
+}
+
+// EntryFetcher returns a middleware.EntryFetcher that fetches the entries
+// with the given SPIFFE ID from the datastore.
+func EntryFetcher(ds datastore.DataStore) middleware.EntryFetcher {
+	return middleware.EntryFetcherFunc(func(ctx context.Context, id spiffeid.ID) ([]*types.Entry, error) {
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySpiffeId: &wrappers.StringValue{
+				Value: id.String(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return api.RegistrationEntriesToProto(resp.Entries)
+	})
+}
+
+// AuthorizedEntryFetcher returns an api.AuthorizedEntryFetcher that resolves
+// the entries an agent is authorized for using the entry cache.
+func AuthorizedEntryFetcher(cache *entrycache.Cache) api.AuthorizedEntryFetcher {
+	return api.AuthorizedEntryFetcherFunc(func(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
+		entries, err := cache.FetchAuthorizedEntries(ctx, agentID.String())
+		if err != nil {
+			return nil, err
+		}
+		return api.RegistrationEntriesToProto(entries)
+	})
+}
+
+// AgentAuthorizer returns a middleware.AgentAuthorizer that authorizes
+// agents that are not banned and whose SVID is unexpired and matches the
+// serial number of the attested node in the datastore. If the SVID matches the new serial number,
+// it is activated.
+func AgentAuthorizer(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clock) middleware.AgentAuthorizer {
+	return middleware.AgentAuthorizerFunc(func(ctx context.Context, agentID spiffeid.ID, agentSVID *x509.Certificate) error {
+		id := agentID.String()
+		log := log.WithField(telemetry.AgentID, id)
+
+		// gRPC reuses connections from agents, so the SVID on the connection
+		// may have expired since the initial handshake.
+		if clk.Now().After(agentSVID.NotAfter) {
+			log.Error("Agent SVID is expired")
+			return status.Errorf(codes.PermissionDenied, "agent %q SVID is expired", id)
+		}
+
+		resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+			SpiffeId: id,
+		})
+		switch {
+		case err != nil:
+			log.WithError(err).Error("Unable to look up agent information")
+			return status.Errorf(codes.Internal, "unable to look up agent information: %v", err)
+		case resp.Node == nil:
+			log.Error("Agent is not attested")
+			return status.Errorf(codes.PermissionDenied, "agent %q is not attested", id)
+		case nodeutil.IsAgentBanned(resp.Node):
+			log.Error("Agent is banned")
+			return status.Error(codes.PermissionDenied, "agent is banned")
+		}
+
+		node := resp.Node
+		serialNumber := agentSVID.SerialNumber.String()
+		switch {
+		case node.CertSerialNumber != "" && node.CertSerialNumber == serialNumber:
+			return nil
+		case node.NewCertSerialNumber != "" && node.NewCertSerialNumber == serialNumber:
+			log.WithField(telemetry.SerialNumber, serialNumber).Debug("Activating agent SVID")
+			if _, err := ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+				SpiffeId:         node.SpiffeId,
+				CertSerialNumber: node.NewCertSerialNumber,
+				CertNotAfter:     node.NewCertNotAfter,
+			}); err != nil {
+				log.WithError(err).Error("Unable to activate the new agent SVID")
+				return status.Errorf(codes.Internal, "unable to activate the new agent SVID: %v", err)
+			}
+			return nil
+		default:
+			log.WithField(telemetry.SerialNumber, serialNumber).Error("Agent SVID does not match expected serial number")
+			return status.Errorf(codes.PermissionDenied, "agent %q SVID does not match expected serial number", id)
+		}
+	})
+}
+
+// AuditedMethods returns the full names of the server API methods that are
+// recorded in the audit log, i.e. the methods that mutate registration
+// entries, bundles, federation relationships, agents, join tokens or local
+// authorities. Both the spire-next server APIs and the legacy registration
+// API are covered.
+func AuditedMethods() map[string]bool {
+	return map[string]bool{
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                            true,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                            true,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                            true,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                 true,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                    true,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                             true,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                              true,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                       true,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                true,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                true,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                   true,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                true,
+		"/spire.api.server.federation.v1.Federation/BatchCreateFederationRelationship": true,
+		"/spire.api.server.federation.v1.Federation/BatchUpdateFederationRelationship": true,
+		"/spire.api.server.federation.v1.Federation/BatchDeleteFederationRelationship": true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":      true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":     true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":        true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":       true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":       true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":      true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":         true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":        true,
+		"/spire.api.registration.Registration/CreateEntry":                             true,
+		"/spire.api.registration.Registration/CreateEntryIfNotExists":                  true,
+		"/spire.api.registration.Registration/UpdateEntry":                             true,
+		"/spire.api.registration.Registration/DeleteEntry":                             true,
+		"/spire.api.registration.Registration/CreateFederatedBundle":                   true,
+		"/spire.api.registration.Registration/UpdateFederatedBundle":                   true,
+		"/spire.api.registration.Registration/DeleteFederatedBundle":                   true,
+		"/spire.api.registration.Registration/CreateJoinToken":                         true,
+		"/spire.api.registration.Registration/EvictAgent":                              true,
+	}
+}
+
+// auditUnaryInterceptor wraps the given interceptor so that calls to the
+// audited methods are recorded in the audit log. The audit log sees every
+// call, including the ones rejected during authorization. If no audit log is
+// configured, the interceptor is returned unchanged.
+func auditUnaryInterceptor(auditLog logrus.FieldLogger, next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if auditLog == nil {
+		return next
+	}
+	audit := middleware.UnaryAuditLogInterceptor(auditLog, AuditedMethods())
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return audit(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return next(ctx, req, info, handler)
+		})
+	}
+}
+
+// unaryInterceptor routes calls for the spire-next server APIs through the
+// middleware and calls for the legacy APIs through the handler authorization.
+// If a registration middleware is given, calls to the legacy registration
+// API go through it before the handler authorization.
+func unaryInterceptor(m, registration middleware.Middleware) grpc.UnaryServerInterceptor {
+	newInterceptor := middleware.UnaryInterceptor(m)
+	var registrationInterceptor grpc.UnaryServerInterceptor
+	if registration != nil {
+		registrationInterceptor = middleware.UnaryInterceptor(registration)
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		switch {
+		case strings.HasPrefix(info.FullMethod, newAPIPrefix):
+			return newInterceptor(ctx, req, info, handler)
+		case registrationInterceptor != nil && strings.HasPrefix(info.FullMethod, registrationAPIPrefix):
+			return registrationInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return auth.UnaryAuthorizeCall(ctx, req, info, handler)
+			})
+		}
+		return auth.UnaryAuthorizeCall(ctx, req, info, handler)
+	}
+}
+
+// streamInterceptor routes calls for the spire-next server APIs through the
+// middleware and calls for the legacy APIs through the handler authorization.
+// If a registration middleware is given, calls to the legacy registration
+// API go through it before the handler authorization.
+func streamInterceptor(m, registration middleware.Middleware) grpc.StreamServerInterceptor {
+	newInterceptor := middleware.StreamInterceptor(m)
+	var registrationInterceptor grpc.StreamServerInterceptor
+	if registration != nil {
+		registrationInterceptor = middleware.StreamInterceptor(registration)
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		switch {
+		case strings.HasPrefix(info.FullMethod, newAPIPrefix):
+			return newInterceptor(srv, ss, info, handler)
+		case registrationInterceptor != nil && strings.HasPrefix(info.FullMethod, registrationAPIPrefix):
+			return registrationInterceptor(srv, ss, info, func(srv interface{}, ss grpc.ServerStream) error {
+				return auth.StreamAuthorizeCall(srv, ss, info, handler)
+			})
+		}
+		return auth.StreamAuthorizeCall(srv, ss, info, handler)
+	}
+}
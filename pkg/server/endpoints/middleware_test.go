@@ -0,0 +1,408 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	node_handler "github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/entrycache"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire-next/types"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	agentID    = spiffeid.Must("example.org", "spire", "agent", "foo")
+	workloadID = spiffeid.Must("example.org", "workload")
+
+	newAPIMethods = []string{
+		"/spire.api.server.entry.v1.Entry/ListEntries",
+		"/spire.api.server.entry.v1.Entry/GetEntry",
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry",
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry",
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry",
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries",
+		"/spire.api.server.agent.v1.Agent/ListAgents",
+		"/spire.api.server.agent.v1.Agent/GetAgent",
+		"/spire.api.server.agent.v1.Agent/DeleteAgent",
+		"/spire.api.server.agent.v1.Agent/BanAgent",
+		"/spire.api.server.agent.v1.Agent/AttestAgent",
+		"/spire.api.server.agent.v1.Agent/RenewAgent",
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken",
+		"/spire.api.server.bundle.v1.Bundle/GetBundle",
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle",
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority",
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles",
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle",
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle",
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle",
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle",
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle",
+		"/spire.api.server.event.v1.Event/StreamEvents",
+		"/spire.api.server.federation.v1.Federation/ListFederationRelationships",
+		"/spire.api.server.federation.v1.Federation/GetFederationRelationship",
+		"/spire.api.server.federation.v1.Federation/BatchCreateFederationRelationship",
+		"/spire.api.server.federation.v1.Federation/BatchUpdateFederationRelationship",
+		"/spire.api.server.federation.v1.Federation/BatchDeleteFederationRelationship",
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState",
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState",
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority",
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority",
+		"/spire.api.server.svid.v1.SVID/MintX509SVID",
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID",
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID",
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID",
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA",
+	}
+)
+
+func TestEntryFetcher(t *testing.T) {
+	ds := fakedatastore.New()
+	createEntry(t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  workloadID.String(),
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Admin:     true,
+	})
+	createEntry(t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  spiffeid.Must("example.org", "other").String(),
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	})
+
+	entries, err := EntryFetcher(ds).FetchEntries(context.Background(), workloadID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, workloadID.Path(), entries[0].SpiffeId.Path)
+	require.True(t, entries[0].Admin)
+}
+
+func TestAuthorizedEntryFetcher(t *testing.T) {
+	ds := fakedatastore.New()
+	createEntry(t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  workloadID.String(),
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	cache := entrycache.New(entrycache.Config{
+		DataStore: ds,
+		Metrics:   telemetry.Blackhole{},
+	})
+	require.NoError(t, cache.Load(context.Background()))
+
+	entries, err := AuthorizedEntryFetcher(cache).FetchAuthorizedEntries(context.Background(), agentID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"}, entries[0].SpiffeId)
+}
+
+func TestAgentAuthorizer(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		node         *common.AttestedNode
+		serial       int64
+		expired      bool
+		expectCode   codes.Code
+		expectMsg    string
+		expectSerial string
+	}{
+		{
+			name:       "not attested",
+			serial:     1,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `agent "spiffe://example.org/spire/agent/foo" is not attested`,
+		},
+		{
+			name: "expired",
+			node: &common.AttestedNode{
+				SpiffeId:         agentID.String(),
+				CertSerialNumber: "1",
+			},
+			serial:       1,
+			expired:      true,
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    `agent "spiffe://example.org/spire/agent/foo" SVID is expired`,
+			expectSerial: "1",
+		},
+		{
+			name: "active serial",
+			node: &common.AttestedNode{
+				SpiffeId:         agentID.String(),
+				CertSerialNumber: "1",
+			},
+			serial:       1,
+			expectCode:   codes.OK,
+			expectSerial: "1",
+		},
+		{
+			name: "new serial is activated",
+			node: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				CertSerialNumber:    "1",
+				NewCertSerialNumber: "2",
+			},
+			serial:       2,
+			expectCode:   codes.OK,
+			expectSerial: "2",
+		},
+		{
+			name: "serial does not match",
+			node: &common.AttestedNode{
+				SpiffeId:         agentID.String(),
+				CertSerialNumber: "1",
+			},
+			serial:       3,
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    `agent "spiffe://example.org/spire/agent/foo" SVID does not match expected serial number`,
+			expectSerial: "1",
+		},
+		{
+			name: "banned",
+			node: &common.AttestedNode{
+				SpiffeId: agentID.String(),
+			},
+			serial:     1,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "agent is banned",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			ds := fakedatastore.New()
+			clk := clock.NewMock(t)
+
+			if tt.node != nil {
+				_, err := ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+					Node: tt.node,
+				})
+				require.NoError(t, err)
+			}
+
+			notAfter := clk.Now().Add(time.Hour)
+			if tt.expired {
+				notAfter = clk.Now().Add(-time.Second)
+			}
+
+			authorizer := AgentAuthorizer(log, ds, clk)
+			err := authorizer.AuthorizeAgent(context.Background(), agentID, &x509.Certificate{
+				SerialNumber: big.NewInt(tt.serial),
+				NotAfter:     notAfter,
+			})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+
+			if tt.expectSerial != "" {
+				resp, err := ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
+					SpiffeId: agentID.String(),
+				})
+				require.NoError(t, err)
+				require.Equal(t, tt.expectSerial, resp.Node.CertSerialNumber)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCoversNewAPIs(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	authorizers := Authorization(log, fakedatastore.New(), clock.NewMock(t))
+
+	for _, method := range newAPIMethods {
+		require.Contains(t, authorizers, method)
+	}
+}
+
+func TestRateLimitsCoverAuthorizedMethods(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	authorizers := Authorization(log, fakedatastore.New(), clock.NewMock(t))
+	rateLimits := RateLimits(RateLimitConfig{}, telemetry.Blackhole{})
+
+	// Every method that is authorized must also be rate limited, otherwise
+	// the rate limit middleware fails the call.
+	require.Len(t, rateLimits, len(authorizers))
+	for method := range authorizers {
+		require.Contains(t, rateLimits, method)
+	}
+}
+
+func TestRateLimitConfigNodeLimits(t *testing.T) {
+	// Unset limits fall back to the per IP address defaults
+	require.Equal(t, map[int]middleware.CallerLimits{
+		node_handler.AttestMsg:  {IP: node.AttestLimit},
+		node_handler.CSRMsg:     {IP: node.CSRLimit},
+		node_handler.JSRMsg:     {IP: node.JSRLimit},
+		node_handler.PushJWTKey: {IP: node.PushJWTKeyLimit},
+	}, RateLimitConfig{}.nodeLimits())
+
+	config := RateLimitConfig{
+		Attestation: middleware.CallerLimits{IP: 5},
+		Signing:     middleware.CallerLimits{Agent: 100, Downstream: 200, IP: 1000},
+		JWTSigning:  middleware.CallerLimits{Agent: 100},
+	}
+	require.Equal(t, map[int]middleware.CallerLimits{
+		node_handler.AttestMsg:  {IP: 5},
+		node_handler.CSRMsg:     {Agent: 100, Downstream: 200, IP: 1000},
+		node_handler.JSRMsg:     {Agent: 100, IP: node.JSRLimit},
+		node_handler.PushJWTKey: {IP: node.PushJWTKeyLimit},
+	}, config.nodeLimits())
+}
+
+func TestInterceptorRouting(t *testing.T) {
+	var newAPICalled bool
+	m := middleware.Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		newAPICalled = true
+		return ctx, nil
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	// Calls to the new APIs go through the middleware
+	resp, err := unaryInterceptor(m, nil)(context.Background(), nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.server.entry.v1.Entry/ListEntries",
+	}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+	require.True(t, newAPICalled)
+
+	// Calls to the legacy APIs authorize through the server implementation,
+	// which does not implement an authorizer here.
+	newAPICalled = false
+	_, err = unaryInterceptor(m, nil)(context.Background(), nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.registration.Registration/FetchEntries",
+	}, handler)
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, `server unable to provide authorization for method "/spire.api.registration.Registration/FetchEntries"`)
+	require.False(t, newAPICalled)
+
+	// With a registration middleware, calls to the legacy registration API
+	// go through it first
+	var registrationCalled bool
+	rm := middleware.Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		registrationCalled = true
+		return nil, status.Error(codes.PermissionDenied, "denied by policy")
+	})
+	_, err = unaryInterceptor(m, rm)(context.Background(), nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.registration.Registration/FetchEntries",
+	}, handler)
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "denied by policy")
+	require.True(t, registrationCalled)
+	require.False(t, newAPICalled)
+
+	// ... but calls to the other legacy APIs do not
+	registrationCalled = false
+	_, err = unaryInterceptor(m, rm)(context.Background(), nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.node.Node/FetchX509SVID",
+	}, handler)
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, `server unable to provide authorization for method "/spire.api.node.Node/FetchX509SVID"`)
+	require.False(t, registrationCalled)
+}
+
+func TestAuditedMethodsAreKnown(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	authorizers := Authorization(log, fakedatastore.New(), clock.NewMock(t))
+
+	for method := range AuditedMethods() {
+		if strings.HasPrefix(method, newAPIPrefix) {
+			require.Contains(t, authorizers, method)
+		}
+	}
+}
+
+func TestAuditUnaryInterceptor(t *testing.T) {
+	m := middleware.Preprocess(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		return ctx, nil
+	})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix", Name: "/tmp/spire-registration.sock"},
+	})
+
+	// Without an audit log, the interceptor is not wrapped
+	resp, err := auditUnaryInterceptor(nil, unaryInterceptor(m, nil))(ctx, nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.server.entry.v1.Entry/BatchDeleteEntry",
+	}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+
+	log, hook := test.NewNullLogger()
+	interceptor := auditUnaryInterceptor(log, unaryInterceptor(m, nil))
+
+	// Calls to the new APIs are audited
+	resp, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.server.entry.v1.Entry/BatchDeleteEntry",
+	}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+
+	// Calls to the legacy registration API are audited, even when they fail
+	// authorization
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.registration.Registration/DeleteEntry",
+	}, handler)
+	require.Error(t, err)
+
+	// Calls to read-only methods are not audited
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{
+		FullMethod: "/spire.api.server.entry.v1.Entry/ListEntries",
+	}, handler)
+	require.NoError(t, err)
+
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "API call",
+			Data: logrus.Fields{
+				"service":     "entry.v1.Entry",
+				"method":      "BatchDeleteEntry",
+				"caller_addr": "/tmp/spire-registration.sock",
+				"status_code": "OK",
+			},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "API call",
+			Data: logrus.Fields{
+				"service":        "spire.api.registration.Registration",
+				"method":         "DeleteEntry",
+				"caller_addr":    "/tmp/spire-registration.sock",
+				"status_code":    "PermissionDenied",
+				"status_message": `server unable to provide authorization for method "/spire.api.registration.Registration/DeleteEntry"`,
+			},
+		},
+	})
+}
+
+func createEntry(t *testing.T, ds datastore.DataStore, entry *common.RegistrationEntry) {
+	_, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(t, err)
+}
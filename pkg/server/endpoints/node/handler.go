@@ -8,24 +8,27 @@ import (
 	"io"
 	"net"
 	"net/url"
-	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/errorutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
-	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
-	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/pkg/server/ratelimit"
+	"github.com/spiffe/spire/pkg/server/util/nodeattestutil"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -36,8 +39,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// Number of agentIDs that can be cached
-const fetchSVIDCacheSize = 500_000
+const (
+	// Number of agentIDs that can be cached
+	fetchSVIDCacheSize = 500_000
+
+	// How often the last seen time of an agent is recorded at most. Agents
+	// sync every few seconds, so recording every sync would be wasteful.
+	lastSeenInterval = time.Minute
+)
 
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
@@ -50,14 +59,34 @@ type HandlerConfig struct {
 
 	// Allow agentless SPIFFE IDs when doing node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// Per-caller rate limits, keyed by message type. If unset, the default
+	// limits are used.
+	RateLimits map[int]middleware.CallerLimits
+
+	// EntryFetcher fetches the registration entries agents are authorized
+	// for. If unset, the entries are fetched from the datastore.
+	EntryFetcher AuthorizedEntryFetcher
+}
+
+// AuthorizedEntryFetcher fetches the registration entries a SPIFFE ID is
+// authorized for, either directly or through its descendants.
+type AuthorizedEntryFetcher interface {
+	FetchAuthorizedEntries(ctx context.Context, spiffeID string) ([]*common.RegistrationEntry, error)
 }
 
 type Handler struct {
-	c       HandlerConfig
-	limiter Limiter
+	c        HandlerConfig
+	limiter  Limiter
+	attestor *nodeattestutil.Attestor
 
 	dsCache                       *datastoreCache
 	fetchRegistrationEntriesCache *regentryutil.FetchRegistrationEntriesCache
+
+	// lastSeen holds the last time each agent was recorded as seen. Agents
+	// not seen for a last seen interval are evicted.
+	lastSeenMu sync.Mutex
+	lastSeen   *ratelimit.CallerCache
 }
 
 func NewHandler(config HandlerConfig) (*Handler, error) {
@@ -69,11 +98,27 @@ func NewHandler(config HandlerConfig) (*Handler, error) {
 		return nil, fmt.Errorf("could not create cache: %v", err)
 	}
 
+	trustDomain, err := spiffeid.TrustDomainFromURI(&config.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %v", err)
+	}
+
 	return &Handler{
-		c:                             config,
-		limiter:                       NewLimiter(config.Log),
+		c: config,
+		limiter: NewLimiter(LimiterConfig{
+			Log:     config.Log,
+			Metrics: config.Metrics,
+			Clock:   config.Clock,
+			Limits:  config.RateLimits,
+		}),
+		attestor: nodeattestutil.New(nodeattestutil.Config{
+			Catalog:     config.Catalog,
+			TrustDomain: trustDomain,
+			Clock:       config.Clock,
+		}),
 		dsCache:                       newDatastoreCache(config.Catalog.GetDataStore(), config.Clock),
 		fetchRegistrationEntriesCache: fetchX509SVIDCache,
+		lastSeen:                      ratelimit.NewCallerCache(lastSeenInterval, config.Clock.Now()),
 	}, nil
 }
 
@@ -127,70 +172,30 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Errorf(codes.InvalidArgument, "request CSR is invalid: %v", err)
 	}
 
-	// Pick the right node attestor
-	var attestResponse *nodeattestor.AttestResponse
-	if request.AttestationData.Type != "join_token" {
-		// New attestor plugins don't provide a SPIFFE ID to the agent so the
-		// CSR will not have one. If we have a SPIFFE ID in the CSR then we're
-		// working with a legacy plugin and need to provide deprecated
-		// "attested before" information to the server side plugin so it can
-		// make re-attestation decisions.
-		//
-		// If the CSR does not provide a SPIFFE ID then we tell the plugin
-		// that the agent has already attested to prevent old plugins from
-		// re-attesting unsafely.
-		//
-		// TODO: remove in SPIRE 0.10
-		attestedBefore := true
-		if csr.SpiffeID != "" {
-			attestedBefore, err = h.isAttested(ctx, csr.SpiffeID)
-			if err != nil {
-				log.WithError(err).Error("Failed to determine if agent has already attested")
-				return status.Error(codes.Internal, "failed to determine if agent has already attested")
+	attestResponse, err := h.attestor.Attest(ctx, log, nodeattestutil.AttestRequest{
+		AttestationData: request.AttestationData,
+		CSRSpiffeID:     csr.SpiffeID,
+		Challenge: func(challenge []byte) ([]byte, error) {
+			if err := stream.Send(&node.AttestResponse{
+				Challenge: challenge,
+			}); err != nil {
+				return nil, errorutil.WrapError(err, "failed to send challenge request")
 			}
-		}
 
-		nodeAttestorType := request.AttestationData.Type
-		nodeAttestor, ok := h.c.Catalog.GetNodeAttestorNamed(nodeAttestorType)
-		if !ok {
-			log.WithField(telemetry.NodeAttestorType, nodeAttestorType).Error("Could not find node attestor type")
-			return status.Error(codes.Unimplemented, fmt.Sprintf("could not find node attestor type %q", nodeAttestorType))
-		}
-
-		attestStream, err := nodeAttestor.Attest(ctx)
-		if err != nil {
-			log.WithError(err).Error("Unable to open attest stream")
-			return errorutil.WrapError(err, "unable to open attest stream")
-		}
-
-		attestResponse, err = h.doAttestChallengeResponse(stream, attestStream, request, attestedBefore)
-		if err != nil {
-			log.WithError(err).Error("Failed to do node attest challenge response")
-			return err
-		}
-		if err := attestStream.CloseSend(); err != nil {
-			log.WithError(err).Error("Failed to close send stream")
-			return status.Error(codes.Internal, err.Error())
-		}
-		if _, err := attestStream.Recv(); err != io.EOF {
-			log.WithError(err).Warn("expected EOF on attestation stream")
-		}
-	} else {
-		attestResponse, err = h.attestToken(ctx, request.AttestationData)
-		if err != nil {
-			log.WithError(err).Error("Failed to attest")
-			return errorutil.WrapError(err, "failed to attest")
-		}
+			request, err := stream.Recv()
+			if err != nil {
+				return nil, errorutil.WrapError(err, "failed to receive challenge response")
+			}
+			return request.Response, nil
+		},
+	})
+	if err != nil {
+		return err
 	}
 
 	agentID := attestResponse.AgentId
 	log = log.WithField(telemetry.SPIFFEID, agentID)
 
-	if csr.SpiffeID != "" && agentID != csr.SpiffeID {
-		log.WithField(telemetry.CsrSpiffeID, csr.SpiffeID).Error("Attested SPIFFE ID does not match CSR")
-		return status.Error(codes.NotFound, "attestor returned unexpected response")
-	}
-
 	log.WithField(telemetry.AgentID, agentID).Debugf("Signing CSR for Agent SVID")
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  agentID,
@@ -201,9 +206,11 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.Internal, "failed to sign CSR")
 	}
 
-	if err := h.updateNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type); err != nil {
-		log.WithError(err).Error("Failed to update node selectors")
-		return status.Error(codes.Internal, "failed to update node selectors")
+	if err := h.attestor.UpdateNode(ctx, log, request.AttestationData.Type, attestResponse, svid[0], nodeattestutil.AgentInfo{
+		Version: request.AgentVersion,
+		Plugins: request.AgentPlugins,
+	}); err != nil {
+		return err
 	}
 
 	response, err := h.getAttestResponse(ctx, agentID, svid)
@@ -212,29 +219,6 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.Internal, "failed to compose response")
 	}
 
-	isAttested, err := h.isAttested(ctx, agentID)
-	switch {
-	case err != nil:
-		log.WithError(err).Error("Failed to determine if agent has already attested")
-		return status.Error(codes.Internal, "failed to determine if agent has already attested")
-	case isAttested:
-		req := &datastore.UpdateAttestedNodeRequest{
-			SpiffeId:         agentID,
-			CertNotAfter:     svid[0].NotAfter.Unix(),
-			CertSerialNumber: svid[0].SerialNumber.String(),
-		}
-
-		if err := h.updateAttestedNode(ctx, req); err != nil {
-			log.WithError(err).Error("Failed to update attestation entry")
-			return status.Error(codes.Internal, "failed to update attestation entry")
-		}
-	default:
-		if err := h.createAttestationEntry(ctx, svid[0], request.AttestationData.Type); err != nil {
-			log.WithError(err).Error("Failed to create attestation entry")
-			return status.Error(codes.Internal, "failed to create attestation entry")
-		}
-	}
-
 	p, ok := peer.FromContext(ctx)
 	if ok {
 		log.WithField(telemetry.Address, p.Addr.String()).Info("Node attestation request completed")
@@ -288,7 +272,9 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			return status.Error(codes.InvalidArgument, err.Error())
 		}
 
-		regEntries, err := regentryutil.FetchRegistrationEntriesWithCache(ctx, h.c.Catalog.GetDataStore(), h.fetchRegistrationEntriesCache, agentID)
+		h.updateLastSeen(ctx, log, agentID)
+
+		regEntries, err := h.fetchAuthorizedEntries(ctx, agentID)
 		if err != nil {
 			log.WithError(err).Error("Failed to fetch agent registration entries")
 			return status.Error(codes.Internal, "failed to fetch agent registration entries")
@@ -451,29 +437,35 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	ds := h.c.Catalog.GetDataStore()
-	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, ds, agentID)
+	regEntries, err := h.fetchAuthorizedEntries(ctx, agentID)
 	if err != nil {
 		log.WithError(err).Error("Failed to fetch registration entries")
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	found := false
+	var entry *common.RegistrationEntry
 	for _, candidateEntry := range regEntries {
 		if candidateEntry.SpiffeId == req.Jsr.SpiffeId {
-			found = true
+			entry = candidateEntry
 			break
 		}
 	}
 
-	if !found {
+	if entry == nil {
 		log.Error("Caller is not authorized")
 		return nil, status.Error(codes.PermissionDenied, "caller is not authorized")
 	}
 
+	// The TTL requested by the agent, if any, takes precedence over the one
+	// configured for the entry
+	ttl := req.Jsr.Ttl
+	if ttl == 0 {
+		ttl = entry.JwtSvidTtl
+	}
+
 	token, err := h.c.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
 		SpiffeID: req.Jsr.SpiffeId,
-		TTL:      time.Duration(req.Jsr.Ttl) * time.Second,
+		TTL:      time.Duration(ttl) * time.Second,
 		Audience: req.Jsr.Audience,
 	})
 	if err != nil {
@@ -614,25 +606,6 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (_ conte
 	return ctx, nil
 }
 
-func (h *Handler) isAttested(ctx context.Context, baseSpiffeID string) (bool, error) {
-	ds := h.c.Catalog.GetDataStore()
-
-	fetchRequest := &datastore.FetchAttestedNodeRequest{
-		SpiffeId: baseSpiffeID,
-	}
-	fetchResponse, err := ds.FetchAttestedNode(ctx, fetchRequest)
-	if err != nil {
-		return false, err
-	}
-
-	n := fetchResponse.Node
-	if n != nil && n.SpiffeId == baseSpiffeID {
-		return true, nil
-	}
-
-	return false, nil
-}
-
 func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate) error {
 	ds := h.c.Catalog.GetDataStore()
 
@@ -700,102 +673,6 @@ func (h *Handler) validateDownstreamSVID(ctx context.Context, cert *x509.Certifi
 	return h.getDownstreamEntry(ctx, peerID)
 }
 
-func (h *Handler) doAttestChallengeResponse(
-	nodeStream node.Node_AttestServer,
-	attestStream nodeattestor.NodeAttestor_AttestClient,
-	request *node.AttestRequest, attestedBefore bool) (*nodeattestor.AttestResponse, error) {
-	// challenge/response loop
-	for {
-		response, err := h.attest(attestStream, request, attestedBefore)
-		if err != nil {
-			h.c.Log.WithError(err).Error("Failed to attest")
-			return nil, errorutil.WrapError(err, "failed to attest")
-		}
-		if response.Challenge == nil {
-			return response, nil
-		}
-
-		challengeResponse := &node.AttestResponse{
-			Challenge: response.Challenge,
-		}
-
-		if err := nodeStream.Send(challengeResponse); err != nil {
-			h.c.Log.WithError(err).Error("Failed to send challenge request")
-			return nil, errorutil.WrapError(err, "failed to send challenge request")
-		}
-
-		request, err = nodeStream.Recv()
-		if err != nil {
-			h.c.Log.WithError(err).Error("Failed to receive challenge response")
-			return nil, errorutil.WrapError(err, "failed to receive challenge response")
-		}
-	}
-}
-
-func (h *Handler) attest(attestStream nodeattestor.NodeAttestor_AttestClient, nodeRequest *node.AttestRequest, attestedBefore bool) (*nodeattestor.AttestResponse, error) {
-	attestRequest := &nodeattestor.AttestRequest{
-		AttestationData:          nodeRequest.AttestationData,
-		Response:                 nodeRequest.Response,
-		DEPRECATEDAttestedBefore: attestedBefore,
-	}
-	if err := attestStream.Send(attestRequest); err != nil {
-		return nil, err
-	}
-
-	return attestStream.Recv()
-}
-
-func (h *Handler) attestToken(ctx context.Context, attestationData *common.AttestationData) (*nodeattestor.AttestResponse, error) {
-	tokenValue := string(attestationData.Data)
-
-	agentID := (&url.URL{
-		Scheme: "spiffe",
-		Host:   h.c.TrustDomain.Host,
-		Path:   path.Join("spire", "agent", "join_token", tokenValue),
-	}).String()
-
-	attestedBefore, err := h.isAttested(ctx, agentID)
-	switch {
-	case err != nil:
-		h.c.Log.WithError(err).Error("Failed to determine if agent has already attested")
-		return nil, errorutil.WrapError(err, "failed to determine if agent has already attested")
-	case attestedBefore:
-		return nil, errors.New("join token has already been used")
-	}
-
-	ds := h.c.Catalog.GetDataStore()
-	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
-		Token: tokenValue,
-	})
-	if err != nil {
-		return nil, err
-	}
-	if resp.JoinToken == nil {
-		return nil, errors.New("no such token")
-	}
-	t := resp.JoinToken
-
-	if t.Token == "" {
-		return nil, errors.New("invalid join token")
-	}
-
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
-		return nil, errors.New("join token expired")
-	}
-
-	// If we're here, the token is valid
-	return &nodeattestor.AttestResponse{
-		AgentId: agentID,
-	}, nil
-}
-
 func (h *Handler) updateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) error {
 	ds := h.c.Catalog.GetDataStore()
 	if _, err := ds.UpdateAttestedNode(ctx, req); err != nil {
@@ -805,52 +682,48 @@ func (h *Handler) updateAttestedNode(ctx context.Context, req *datastore.UpdateA
 	return nil
 }
 
-func (h *Handler) createAttestationEntry(ctx context.Context, cert *x509.Certificate, attestationType string) error {
-	ds := h.c.Catalog.GetDataStore()
-	return createAttestationEntry(ctx, ds, cert, attestationType)
-}
-
-func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) error {
-	var selectors []*common.Selector
-
-	// Select node resolver based on request attestation type
-	nodeResolver, ok := h.c.Catalog.GetNodeResolverNamed(attestationType)
-	if ok {
-		//Call node resolver plugin to get a map of spiffeID=>Selector
-		response, err := nodeResolver.Resolve(ctx, &noderesolver.ResolveRequest{
-			BaseSpiffeIdList: []string{baseSpiffeID},
-		})
-		if err != nil {
-			return err
-		}
+// updateLastSeen records that the agent was seen, unless it was already
+// recorded by this server within the last seen interval. Failures are logged
+// but do not fail the sync.
+func (h *Handler) updateLastSeen(ctx context.Context, log logrus.FieldLogger, agentID string) {
+	now := h.c.Clock.Now()
 
-		if resolved := response.Map[baseSpiffeID]; resolved != nil {
-			selectors = append(selectors, resolved.Entries...)
-		}
-	} else {
-		h.c.Log.WithField(telemetry.Attestor, attestationType).Debug("could not find node resolver")
+	h.lastSeenMu.Lock()
+	h.lastSeen.GC(now)
+	if lastSeen, ok := h.lastSeen.Get(agentID); ok && now.Sub(lastSeen.(time.Time)) < lastSeenInterval {
+		h.lastSeenMu.Unlock()
+		return
 	}
+	h.lastSeen.Set(agentID, now)
+	h.lastSeenMu.Unlock()
 
-	selectors = append(selectors, attestResponse.Selectors...)
-
-	ds := h.c.Catalog.GetDataStore()
-	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
-		Selectors: &datastore.NodeSelectors{
-			SpiffeId:  baseSpiffeID,
-			Selectors: selectors,
+	if _, err := h.c.Catalog.GetDataStore().UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId: agentID,
+		LastSeen: now.Unix(),
+		InputMask: &datastore.AttestedNodeMask{
+			LastSeen: true,
 		},
-	})
-	if err != nil {
-		return err
+	}); err != nil {
+		log.WithError(err).WithField(telemetry.SPIFFEID, agentID).Warn("Failed to update agent last seen time")
 	}
+}
 
-	return nil
+// fetchAuthorizedEntries fetches the registration entries the agent is
+// authorized for with the configured fetcher or, if unset, from the
+// datastore through a short-lived cache.
+func (h *Handler) fetchAuthorizedEntries(ctx context.Context, agentID string) ([]*common.RegistrationEntry, error) {
+	if h.c.EntryFetcher != nil {
+		return h.c.EntryFetcher.FetchAuthorizedEntries(ctx, agentID)
+	}
+	return regentryutil.FetchRegistrationEntriesWithCache(ctx, h.c.Catalog.GetDataStore(), h.fetchRegistrationEntriesCache, agentID)
 }
 
 func (h *Handler) getAttestResponse(ctx context.Context, baseSpiffeID string, svid []*x509.Certificate) (*node.AttestResponse, error) {
 	svids := make(map[string]*node.X509SVID)
 	svids[baseSpiffeID] = makeX509SVID(svid)
 
+	// The node selectors were just set, so the entries are fetched from the
+	// datastore to make sure the ones mapped to the node are included.
 	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.GetDataStore(), baseSpiffeID)
 	if err != nil {
 		return nil, err
@@ -1080,11 +953,20 @@ func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries
 		return nil, errors.New("not entitled to sign CSR for given ID type")
 	}
 
+	profile, err := x509util.ParseSVIDProfile(entry.X509SvidProfile)
+	if err != nil {
+		return nil, err
+	}
+
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
-		SpiffeID:  csr.SpiffeID,
-		PublicKey: csr.PublicKey,
-		TTL:       time.Duration(entry.Ttl) * time.Second,
-		DNSList:   entry.DnsNames,
+		SpiffeID:    csr.SpiffeID,
+		PublicKey:   csr.PublicKey,
+		TTL:         time.Duration(entry.Ttl) * time.Second,
+		DNSList:     entry.DnsNames,
+		Subject:     profile.Subject,
+		IPAddresses: profile.IPAddresses,
+		URIs:        profile.URIs,
+		ExtKeyUsage: profile.ExtKeyUsage,
 	})
 	if err != nil {
 		return nil, err
@@ -8,8 +8,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/ratelimit"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc/peer"
@@ -22,38 +27,92 @@ const (
 	PushJWTKey
 )
 
+// notifyInterval is the minimum interval between notifications about a
+// caller being rate limited.
+const notifyInterval = time.Hour
+
 type Limiter interface {
 	Limit(ctx context.Context, msgType, count int) error
 }
 
+// DefaultLimits returns the default per-caller limits for each message type,
+// which limit every caller by IP address.
+func DefaultLimits() map[int]middleware.CallerLimits {
+	return map[int]middleware.CallerLimits{
+		AttestMsg:  {IP: node.AttestLimit},
+		CSRMsg:     {IP: node.CSRLimit},
+		JSRMsg:     {IP: node.JSRLimit},
+		PushJWTKey: {IP: node.PushJWTKeyLimit},
+	}
+}
+
+// LimiterConfig is the configuration for the node api rate.Limiter
+type LimiterConfig struct {
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+
+	// Limits are the per-caller limits, keyed by message type. Unset IP
+	// limits default to the default limits.
+	Limits map[int]middleware.CallerLimits
+}
+
 // Newlimiter returns a new node api rate.Limiter
-func NewLimiter(l logrus.FieldLogger) Limiter {
-	return newLimiter(l)
+func NewLimiter(c LimiterConfig) Limiter {
+	return newLimiter(c)
 }
 
-func newLimiter(l logrus.FieldLogger) *limiter {
+func newLimiter(c LimiterConfig) *limiter {
+	if c.Metrics == nil {
+		c.Metrics = telemetry.Blackhole{}
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	limits := DefaultLimits()
+	for msgType, callerLimits := range c.Limits {
+		defaultLimits, ok := limits[msgType]
+		if !ok {
+			continue
+		}
+		if callerLimits.IP == 0 {
+			callerLimits.IP = defaultLimits.IP
+		}
+		limits[msgType] = callerLimits
+	}
+
+	now := c.Clock.Now()
+	limiters := make(map[int]*ratelimit.CallerCache)
+	for msgType := range limits {
+		limiters[msgType] = ratelimit.NewCallerCache(ratelimit.GCInterval, now)
+	}
+
 	return &limiter{
-		attestRate:   rate.Limit(node.AttestLimit),
-		csrRate:      rate.Limit(node.CSRLimit),
-		jsrRate:      rate.Limit(node.JSRLimit),
-		jwtKeyRate:   rate.Limit(node.PushJWTKeyLimit),
+		limits:       limits,
 		lastNotified: make(map[string]time.Time),
-		limiters:     make(map[int]map[string]*rate.Limiter),
-		log:          l,
+		limiters:     limiters,
+		log:          c.Log,
+		metrics:      c.Metrics,
+		clk:          c.Clock,
 	}
 }
 
 type limiter struct {
-	// Allowed number of messages per second
-	attestRate rate.Limit
-	csrRate    rate.Limit
-	jsrRate    rate.Limit
-	jwtKeyRate rate.Limit
+	// Allowed number of messages per second for each caller, keyed by
+	// message type
+	limits map[int]middleware.CallerLimits
 
 	lastNotified map[string]time.Time
-	limiters     map[int]map[string]*rate.Limiter
-	log          logrus.FieldLogger
-	mtx          sync.Mutex
+
+	// limiters holds the limiter of each caller, keyed by message type. The
+	// limiters of every message type are garbage collected together.
+	limiters map[int]*ratelimit.CallerCache
+
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+	clk     clock.Clock
+	mtx     sync.Mutex
 }
 
 // Limit enforces rate limiting policy by blocking until the specified number of messages can
@@ -61,18 +120,21 @@ type limiter struct {
 // returned if the context is cancelled, an invalid msgType is specified, or if the number
 // of messages exceeds the burst limit.
 func (l *limiter) Limit(ctx context.Context, msgType, count int) error {
-	callerID, err := l.callerID(ctx)
-	if err != nil {
-		return err
+	callerLimits, ok := l.limits[msgType]
+	if !ok {
+		return fmt.Errorf("limiter: unknown message type %v", msgType)
 	}
 
-	rl, err := l.limiterFor(msgType, callerID)
+	callerClass, callerID, limit, err := l.callerKey(ctx, callerLimits)
 	if err != nil {
 		return err
 	}
 
+	rl := l.limiterFor(msgType, callerClass+":"+callerID, limit)
+
 	res := rl.ReserveN(time.Now(), count)
-	if res.Delay() > 0 {
+	if !res.OK() || res.Delay() > 0 {
+		telemetry_server.IncrRateLimitThrottledCounter(l.metrics, limiterName(msgType), callerClass)
 		l.notify(callerID, msgType)
 	}
 	if !res.OK() {
@@ -97,50 +159,73 @@ func (l *limiter) Limit(ctx context.Context, msgType, count int) error {
 	}
 }
 
-func (l *limiter) limiterFor(msgType int, callerID string) (*rate.Limiter, error) {
+func (l *limiter) limiterFor(msgType int, key string, limit int) *rate.Limiter {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
-	limiters := l.limitersFor(msgType)
-
-	var err error
-	rl, ok := limiters[callerID]
-	if !ok {
-		rl, err = l.newLimiterFor(msgType)
-		if err != nil {
-			return nil, err
-		}
-
-		limiters[callerID] = rl
+	limiters := l.limiters[msgType]
+	if rl, ok := limiters.Get(key); ok {
+		return rl.(*rate.Limiter)
 	}
 
-	return rl, nil
+	// There is no limiter for this caller. Before we create one, we should
+	// see if we need to do GC.
+	l.gc()
+
+	rl := rate.NewLimiter(rate.Limit(limit), limit)
+	limiters.Set(key, rl)
+	return rl
 }
 
-// A lock must be held on `l` before calling this function
-func (l *limiter) limitersFor(msgType int) map[string]*rate.Limiter {
-	limiters, ok := l.limiters[msgType]
-	if !ok {
-		limiters = make(map[string]*rate.Limiter)
-		l.limiters[msgType] = limiters
+// gc evicts the limiters that have not been used since the last GC, as well
+// as stale notification times, once per GC interval. A lock must be held on
+// `l` before calling this function
+func (l *limiter) gc() {
+	now := l.clk.Now()
+
+	collected := false
+	for msgType, limiters := range l.limiters {
+		evicted, ok := limiters.GC(now)
+		if evicted > 0 {
+			telemetry_server.IncrRateLimitEvictedCounter(l.metrics, limiterName(msgType), evicted)
+		}
+		collected = collected || ok
+	}
+	if !collected {
+		return
 	}
 
-	return limiters
+	for callerID, lastNotified := range l.lastNotified {
+		if now.Sub(lastNotified) > notifyInterval {
+			delete(l.lastNotified, callerID)
+		}
+	}
 }
 
-func (l *limiter) newLimiterFor(msgType int) (*rate.Limiter, error) {
-	switch msgType {
-	case AttestMsg:
-		return rate.NewLimiter(l.attestRate, node.AttestLimit), nil
-	case CSRMsg:
-		return rate.NewLimiter(l.csrRate, node.CSRLimit), nil
-	case JSRMsg:
-		return rate.NewLimiter(l.jsrRate, node.JSRLimit), nil
-	case PushJWTKey:
-		return rate.NewLimiter(l.jwtKeyRate, node.PushJWTKeyLimit), nil
+// callerKey returns the class and the ID of the caller, and the limit that
+// applies to it. Downstream servers and agents are identified by their
+// SPIFFE ID when a limit is configured for their class. Otherwise, callers
+// are identified by their IP address.
+func (l *limiter) callerKey(ctx context.Context, limits middleware.CallerLimits) (string, string, int, error) {
+	if peerCert, ok := getPeerCertificate(ctx); ok {
+		_, isDownstream := getDownstreamEntry(ctx)
+		switch {
+		case isDownstream && limits.Downstream > 0:
+			if id, err := getSpiffeIDFromCert(peerCert); err == nil {
+				return ratelimit.CallerClassDownstream, id, limits.Downstream, nil
+			}
+		case !isDownstream && limits.Agent > 0:
+			if id, err := getSpiffeIDFromCert(peerCert); err == nil && idutil.ValidateSpiffeID(id, idutil.AllowAnyTrustDomainAgent()) == nil {
+				return ratelimit.CallerClassAgent, id, limits.Agent, nil
+			}
+		}
 	}
 
-	return nil, fmt.Errorf("limiter: unknown message type %v", msgType)
+	callerID, err := l.callerID(ctx)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return ratelimit.CallerClassIP, callerID, limits.IP, nil
 }
 
 func (l *limiter) callerID(ctx context.Context) (string, error) {
@@ -163,8 +248,9 @@ func (l *limiter) callerID(ctx context.Context) (string, error) {
 
 func (l *limiter) notify(callerID string, msgType int) {
 	l.mtx.Lock()
-	if time.Since(l.lastNotified[callerID]) > 1*time.Hour {
-		l.lastNotified[callerID] = time.Now()
+	now := l.clk.Now()
+	if now.Sub(l.lastNotified[callerID]) > notifyInterval {
+		l.lastNotified[callerID] = now
 		l.mtx.Unlock()
 	} else {
 		l.mtx.Unlock()
@@ -190,3 +276,20 @@ func (l *limiter) notify(callerID string, msgType int) {
 		telemetry.Action:   action,
 	}).Info("caller is being ratelimited while attempting action")
 }
+
+// limiterName returns the name of the limiter of the message type, as used
+// in metrics.
+func limiterName(msgType int) string {
+	switch msgType {
+	case AttestMsg:
+		return "attestation"
+	case CSRMsg:
+		return "signing"
+	case JSRMsg:
+		return "jwt_signing"
+	case PushJWTKey:
+		return "push_jwt_key"
+	default:
+		return telemetry.Unknown
+	}
+}
@@ -2,15 +2,24 @@ package node
 
 import (
 	"context"
+	"crypto/x509"
 	"net"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/ratelimit"
 	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc/peer"
 )
 
@@ -50,22 +59,143 @@ func TestLimit(t *testing.T) {
 func TestLimiterFor(t *testing.T) {
 	l, _ := newTestLimiter()
 
-	// New caller for valid message type gets the right limiter
-	li, err := l.limiterFor(AttestMsg, "evan")
-	require.NoError(t, err)
+	// New caller gets a limiter with the given limit
+	li := l.limiterFor(AttestMsg, "ip:evan", node.AttestLimit)
 	require.NotNil(t, li)
 	assert.Equal(t, node.AttestLimit, li.Burst())
-	assert.Equal(t, l.attestRate, li.Limit())
+	assert.Equal(t, rate.Limit(node.AttestLimit), li.Limit())
 
 	// Gets the same limiter when asked for it
-	li2, err := l.limiterFor(AttestMsg, "evan")
-	require.NoError(t, err)
+	li2 := l.limiterFor(AttestMsg, "ip:evan", node.AttestLimit)
 	assert.Equal(t, li, li2)
 
 	// Invalid message type returns an error
-	li, err = l.limiterFor(100, "evan")
-	assert.Error(t, err)
-	assert.Nil(t, li)
+	err := l.Limit(newTestContext(), 100, 1)
+	assert.EqualError(t, err, "limiter: unknown message type 100")
+}
+
+func TestLimiterConfig(t *testing.T) {
+	l := newLimiter(LimiterConfig{
+		Log: logrus.New(),
+		Limits: map[int]middleware.CallerLimits{
+			CSRMsg: {Agent: 10, IP: 20},
+			JSRMsg: {Agent: 10},
+		},
+	})
+
+	// Configured limits replace the defaults, except for unset IP limits
+	assert.Equal(t, map[int]middleware.CallerLimits{
+		AttestMsg:  {IP: node.AttestLimit},
+		CSRMsg:     {Agent: 10, IP: 20},
+		JSRMsg:     {Agent: 10, IP: node.JSRLimit},
+		PushJWTKey: {IP: node.PushJWTKeyLimit},
+	}, l.limits)
+}
+
+func TestCallerKey(t *testing.T) {
+	l, _ := newTestLimiter()
+	limits := middleware.CallerLimits{Agent: 1, Downstream: 2, IP: 3}
+
+	agentCert := &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/spire/agent/foo"}}}
+	downstreamCert := &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/downstream"}}}
+
+	// Agents are identified by SPIFFE ID
+	class, id, limit, err := l.callerKey(withPeerCertificate(newTestContext(), agentCert), limits)
+	require.NoError(t, err)
+	assert.Equal(t, "agent", class)
+	assert.Equal(t, "spiffe://example.org/spire/agent/foo", id)
+	assert.Equal(t, 1, limit)
+
+	// Downstream servers are identified by SPIFFE ID
+	ctx := withDownstreamEntry(withPeerCertificate(newTestContext(), downstreamCert), &common.RegistrationEntry{})
+	class, id, limit, err = l.callerKey(ctx, limits)
+	require.NoError(t, err)
+	assert.Equal(t, "downstream", class)
+	assert.Equal(t, "spiffe://example.org/downstream", id)
+	assert.Equal(t, 2, limit)
+
+	// Agents are identified by IP address without an agent limit
+	class, id, limit, err = l.callerKey(withPeerCertificate(newTestContext(), agentCert), middleware.CallerLimits{IP: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "ip", class)
+	assert.Equal(t, "127.0.0.1", id)
+	assert.Equal(t, 3, limit)
+
+	// Workloads that are not downstream are identified by IP address, even
+	// if an agent limit is configured
+	workloadCert := &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/workload"}}}
+	class, id, limit, err = l.callerKey(withPeerCertificate(newTestContext(), workloadCert), limits)
+	require.NoError(t, err)
+	assert.Equal(t, "ip", class)
+	assert.Equal(t, "127.0.0.1", id)
+	assert.Equal(t, 3, limit)
+
+	// Other callers are identified by IP address
+	class, id, limit, err = l.callerKey(newTestContext(), limits)
+	require.NoError(t, err)
+	assert.Equal(t, "ip", class)
+	assert.Equal(t, "127.0.0.1", id)
+	assert.Equal(t, 3, limit)
+}
+
+func TestLimitThrottledMetrics(t *testing.T) {
+	metrics := fakemetrics.New()
+	l := newLimiter(LimiterConfig{Log: logrus.New(), Metrics: metrics})
+
+	// Calls within the limit are not counted
+	require.NoError(t, l.Limit(newTestContext(), AttestMsg, 1))
+	assert.Empty(t, metrics.AllMetrics())
+
+	// Calls exceeding the burst size are throttled
+	require.Error(t, l.Limit(newTestContext(), AttestMsg, node.AttestLimit+1))
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type: fakemetrics.IncrCounterWithLabelsType,
+			Key:  []string{"rate_limit", "throttled"},
+			Val:  1,
+			Labels: []telemetry.Label{
+				{Name: "limiter", Value: "attestation"},
+				{Name: "caller_class", Value: "ip"},
+			},
+		},
+	}, metrics.AllMetrics())
+}
+
+func TestLimiterGC(t *testing.T) {
+	clk := clock.NewMock(t)
+	metrics := fakemetrics.New()
+	l := newLimiter(LimiterConfig{Log: logrus.New(), Metrics: metrics, Clock: clk})
+
+	li1 := l.limiterFor(CSRMsg, "ip:1.1.1.1", node.CSRLimit)
+	li2 := l.limiterFor(CSRMsg, "ip:2.2.2.2", node.CSRLimit)
+	l.notify("1.1.1.1", CSRMsg)
+
+	// After a GC interval, the existing limiters move to the previous set
+	// and are kept as long as they are used.
+	clk.Add(ratelimit.GCInterval)
+	l.limiterFor(CSRMsg, "ip:3.3.3.3", node.CSRLimit)
+	assert.Equal(t, li1, l.limiterFor(CSRMsg, "ip:1.1.1.1", node.CSRLimit))
+	assert.Empty(t, metrics.AllMetrics())
+
+	// After another GC interval, the unused limiter is evicted.
+	clk.Add(ratelimit.GCInterval)
+	l.limiterFor(CSRMsg, "ip:4.4.4.4", node.CSRLimit)
+	assert.Equal(t, 3, l.limiters[CSRMsg].Len())
+	assert.Equal(t, []fakemetrics.MetricItem{
+		{
+			Type:   fakemetrics.IncrCounterWithLabelsType,
+			Key:    []string{"rate_limit", "evicted"},
+			Val:    1,
+			Labels: []telemetry.Label{{Name: "limiter", Value: "signing"}},
+		},
+	}, metrics.AllMetrics())
+	assert.NotSame(t, li2, l.limiterFor(CSRMsg, "ip:2.2.2.2", node.CSRLimit))
+
+	// Stale notification times are evicted as well.
+	assert.Len(t, l.lastNotified, 1)
+	clk.Add(notifyInterval)
+	l.limiterFor(CSRMsg, "ip:5.5.5.5", node.CSRLimit)
+	assert.Empty(t, l.lastNotified)
 }
 
 func TestCallerID(t *testing.T) {
@@ -115,5 +245,5 @@ func newTestPeer() *peer.Peer {
 
 func newTestLimiter() (*limiter, *test.Hook) {
 	log, hook := test.NewNullLogger()
-	return newLimiter(log), hook
+	return newLimiter(LimiterConfig{Log: log}), hook
 }
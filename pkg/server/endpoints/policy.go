@@ -0,0 +1,322 @@
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/hashicorp/hcl"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// scopedMethods are the spire-next server API methods that enforce the
+// caller scope, i.e. the only methods a scoped policy rule can allow.
+var scopedMethods = map[string]bool{
+	"/spire.api.server.entry.v1.Entry/ListEntries":          true,
+	"/spire.api.server.entry.v1.Entry/GetEntry":             true,
+	"/spire.api.server.entry.v1.Entry/BatchCreateEntry":     true,
+	"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":     true,
+	"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":     true,
+	"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries": true,
+	"/spire.api.server.agent.v1.Agent/ListAgents":           true,
+	"/spire.api.server.agent.v1.Agent/GetAgent":             true,
+	"/spire.api.server.agent.v1.Agent/DeleteAgent":          true,
+	"/spire.api.server.agent.v1.Agent/BanAgent":             true,
+}
+
+// registrationMethods are the full names of the legacy registration API
+// methods. A policy also authorizes calls to these methods, which must then
+// pass the authorization of the registration API as well.
+var registrationMethods = []string{
+	"/spire.api.registration.Registration/CreateEntry",
+	"/spire.api.registration.Registration/CreateEntryIfNotExists",
+	"/spire.api.registration.Registration/DeleteEntry",
+	"/spire.api.registration.Registration/FetchEntry",
+	"/spire.api.registration.Registration/FetchEntries",
+	"/spire.api.registration.Registration/UpdateEntry",
+	"/spire.api.registration.Registration/ListByParentID",
+	"/spire.api.registration.Registration/ListBySelector",
+	"/spire.api.registration.Registration/ListBySelectors",
+	"/spire.api.registration.Registration/ListBySpiffeID",
+	"/spire.api.registration.Registration/ListAllEntriesWithPages",
+	"/spire.api.registration.Registration/CreateFederatedBundle",
+	"/spire.api.registration.Registration/FetchFederatedBundle",
+	"/spire.api.registration.Registration/ListFederatedBundles",
+	"/spire.api.registration.Registration/UpdateFederatedBundle",
+	"/spire.api.registration.Registration/DeleteFederatedBundle",
+	"/spire.api.registration.Registration/CreateJoinToken",
+	"/spire.api.registration.Registration/FetchBundle",
+	"/spire.api.registration.Registration/EvictAgent",
+	"/spire.api.registration.Registration/ListAgents",
+	"/spire.api.registration.Registration/MintX509SVID",
+	"/spire.api.registration.Registration/MintJWTSVID",
+	"/spire.api.registration.Registration/GetNodeSelectors",
+}
+
+// Policy is an authorization policy for the spire-next server APIs and the
+// legacy registration API. When a policy is configured, it replaces the
+// built-in authorization: a call is only authorized if a rule that allows
+// the method matches the caller.
+type Policy struct {
+	Rules []PolicyRule
+}
+
+// PolicyRule allows callers with all of the configured attributes to call
+// the given methods. A rule without caller attributes matches any caller.
+type PolicyRule struct {
+	// CallerLocal matches callers over the registration UDS
+	CallerLocal bool
+
+	// CallerUIDs, if set, matches local callers running as one of the UIDs
+	CallerUIDs []uint32
+
+	// CallerAdmin matches admin workloads
+	CallerAdmin bool
+
+	// CallerAgent matches agents
+	CallerAgent bool
+
+	// CallerDownstream matches downstream workloads
+	CallerDownstream bool
+
+	// CallerIDPrefix, if set, matches callers whose SPIFFE ID is equal to or
+	// nested under the prefix
+	CallerIDPrefix *spiffeid.ID
+
+	// Methods are the full names of the methods the rule allows
+	Methods map[string]bool
+
+	// Scope, if set, restricts the caller to data that belongs to SPIFFE IDs
+	// under one of the prefixes
+	Scope []spiffeid.ID
+}
+
+type policyConfig struct {
+	Rules      []*policyRuleConfig `hcl:"rule"`
+	UnusedKeys []string            `hcl:",unusedKeys"`
+}
+
+type policyRuleConfig struct {
+	CallerLocal          bool     `hcl:"caller_local"`
+	CallerUIDs           []int    `hcl:"caller_uids"`
+	CallerAdmin          bool     `hcl:"caller_admin"`
+	CallerAgent          bool     `hcl:"caller_agent"`
+	CallerDownstream     bool     `hcl:"caller_downstream"`
+	CallerSPIFFEIDPrefix string   `hcl:"caller_spiffe_id_prefix"`
+	Methods              []string `hcl:"methods"`
+	Scope                []string `hcl:"scope"`
+	UnusedKeys           []string `hcl:",unusedKeys"`
+}
+
+// LoadPolicy loads an authorization policy from an HCL or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load authorization policy: %v", err)
+	}
+	policy, err := ParsePolicy(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization policy %q: %v", path, err)
+	}
+	return policy, nil
+}
+
+// ParsePolicy parses and validates an authorization policy.
+func ParsePolicy(data string) (*Policy, error) {
+	config := new(policyConfig)
+	if err := hcl.Decode(config, data); err != nil {
+		return nil, err
+	}
+	if len(config.UnusedKeys) > 0 {
+		return nil, fmt.Errorf("unknown keys %q", config.UnusedKeys)
+	}
+	if len(config.Rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+
+	methods := knownMethods()
+	policy := new(Policy)
+	for i, ruleConfig := range config.Rules {
+		rule, err := parsePolicyRule(ruleConfig, methods)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %v", i, err)
+		}
+		policy.Rules = append(policy.Rules, *rule)
+	}
+	return policy, nil
+}
+
+func parsePolicyRule(c *policyRuleConfig, knownMethods []string) (*PolicyRule, error) {
+	if len(c.UnusedKeys) > 0 {
+		return nil, fmt.Errorf("unknown keys %q", c.UnusedKeys)
+	}
+
+	rule := &PolicyRule{
+		CallerLocal:      c.CallerLocal,
+		CallerAdmin:      c.CallerAdmin,
+		CallerAgent:      c.CallerAgent,
+		CallerDownstream: c.CallerDownstream,
+		Methods:          make(map[string]bool),
+	}
+
+	for _, uid := range c.CallerUIDs {
+		if uid < 0 || uint64(uid) > uint64(^uint32(0)) {
+			return nil, fmt.Errorf("invalid caller UID %d", uid)
+		}
+		rule.CallerUIDs = append(rule.CallerUIDs, uint32(uid))
+	}
+
+	if c.CallerSPIFFEIDPrefix != "" {
+		prefix, err := parseIDPrefix(c.CallerSPIFFEIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid caller SPIFFE ID prefix: %v", err)
+		}
+		rule.CallerIDPrefix = &prefix
+	}
+
+	if len(c.Methods) == 0 {
+		return nil, errors.New("at least one method is required")
+	}
+	for _, pattern := range c.Methods {
+		matched := matchMethods(pattern, knownMethods)
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("method %q does not match any server API method", pattern)
+		}
+		for _, method := range matched {
+			rule.Methods[method] = true
+		}
+	}
+
+	for _, s := range c.Scope {
+		prefix, err := parseIDPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope: %v", err)
+		}
+		rule.Scope = append(rule.Scope, prefix)
+	}
+	if len(rule.Scope) > 0 {
+		for method := range rule.Methods {
+			if !scopedMethods[method] {
+				return nil, fmt.Errorf("method %q does not support scopes", method)
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+// PolicyAuthorization returns the authorizers for each spire-next server API
+// and legacy registration API method, keyed by full method name, as defined
+// by the policy. The rules
+// that allow a method are evaluated in order and the first rule that
+// matches the caller authorizes the call.
+func PolicyAuthorization(policy *Policy, log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clock) map[string]middleware.Authorizer {
+	entryFetcher := EntryFetcher(ds)
+
+	local := middleware.AuthorizeLocal()
+	admin := middleware.AuthorizeAdmin(entryFetcher)
+	agent := middleware.AuthorizeAgent(AgentAuthorizer(log, ds, clk))
+	downstream := middleware.AuthorizeDownstream(entryFetcher)
+
+	ruleAuthorizers := make([]middleware.Authorizer, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		var attributes []middleware.Authorizer
+		if rule.CallerLocal && len(rule.CallerUIDs) == 0 {
+			attributes = append(attributes, local)
+		}
+		if len(rule.CallerUIDs) > 0 {
+			attributes = append(attributes, middleware.AuthorizeLocalUID(rule.CallerUIDs...))
+		}
+		if rule.CallerIDPrefix != nil {
+			attributes = append(attributes, middleware.AuthorizeIDPrefix(*rule.CallerIDPrefix))
+		}
+		if rule.CallerAdmin {
+			attributes = append(attributes, admin)
+		}
+		if rule.CallerAgent {
+			attributes = append(attributes, agent)
+		}
+		if rule.CallerDownstream {
+			attributes = append(attributes, downstream)
+		}
+
+		var authorizer middleware.Authorizer
+		switch len(attributes) {
+		case 0:
+			authorizer = middleware.AuthorizeAny()
+		case 1:
+			authorizer = attributes[0]
+		default:
+			authorizer = middleware.AuthorizeAllOf(attributes...)
+		}
+		if len(rule.Scope) > 0 {
+			authorizer = middleware.AuthorizeScoped(authorizer, rule.Scope...)
+		}
+		ruleAuthorizers = append(ruleAuthorizers, authorizer)
+	}
+
+	authorizers := make(map[string]middleware.Authorizer)
+	for _, method := range knownMethods() {
+		var allowed []middleware.Authorizer
+		for i, rule := range policy.Rules {
+			if rule.Methods[method] {
+				allowed = append(allowed, ruleAuthorizers[i])
+			}
+		}
+		authorizers[method] = middleware.AuthorizeFirstOf(allowed...)
+	}
+	return authorizers
+}
+
+// knownMethods returns the sorted full names of the spire-next server API
+// and legacy registration API methods.
+func knownMethods() []string {
+	var methods []string
+	for method := range RateLimits(RateLimitConfig{}, telemetry.Blackhole{}) {
+		methods = append(methods, method)
+	}
+	methods = append(methods, registrationMethods...)
+	sort.Strings(methods)
+	return methods
+}
+
+// matchMethods returns the methods that match the pattern. The pattern is
+// either a full method name, with or without the common server API prefix
+// (e.g. "entry.v1.Entry/ListEntries" or
+// "spire.api.registration.Registration/FetchEntries"), a service name followed by "/*" to
+// match all of its methods (e.g. "entry.v1.Entry/*"), or "*" to match every
+// method.
+func matchMethods(pattern string, knownMethods []string) []string {
+	if pattern == "*" {
+		return knownMethods
+	}
+
+	pattern = strings.TrimPrefix(pattern, newAPIPrefix)
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var matched []string
+	for _, method := range knownMethods {
+		name := strings.TrimPrefix(strings.TrimPrefix(method, newAPIPrefix), "/")
+		switch {
+		case name == pattern:
+			matched = append(matched, method)
+		case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")):
+			matched = append(matched, method)
+		}
+	}
+	return matched
+}
+
+// parseIDPrefix parses a SPIFFE ID prefix. A trailing "/*" is accepted for
+// readability, i.e. "spiffe://example.org/team-a/*" is the same prefix as
+// "spiffe://example.org/team-a".
+func parseIDPrefix(s string) (spiffeid.ID, error) {
+	return spiffeid.FromString(strings.TrimSuffix(s, "/*"))
+}
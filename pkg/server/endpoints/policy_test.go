@@ -0,0 +1,375 @@
+package endpoints
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestParsePolicy(t *testing.T) {
+	teamA := spiffeid.Must("example.org", "team-a")
+
+	for _, tt := range []struct {
+		name      string
+		policy    string
+		expectErr string
+		expect    *Policy
+	}{
+		{
+			name: "success",
+			policy: `
+				rule {
+					caller_uids = [0, 1000]
+					methods = ["*"]
+				}
+				rule {
+					caller_admin = true
+					caller_spiffe_id_prefix = "spiffe://example.org/team-a/*"
+					methods = ["entry.v1.Entry/*", "/spire.api.server.agent.v1.Agent/ListAgents"]
+					scope = ["spiffe://example.org/team-a/*"]
+				}
+				rule {
+					methods = ["bundle.v1.Bundle/GetBundle"]
+				}
+			`,
+			expect: &Policy{
+				Rules: []PolicyRule{
+					{
+						CallerUIDs: []uint32{0, 1000},
+						Methods:    allMethods(),
+					},
+					{
+						CallerAdmin:    true,
+						CallerIDPrefix: &teamA,
+						Methods: map[string]bool{
+							"/spire.api.server.entry.v1.Entry/ListEntries":          true,
+							"/spire.api.server.entry.v1.Entry/GetEntry":             true,
+							"/spire.api.server.entry.v1.Entry/BatchCreateEntry":     true,
+							"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":     true,
+							"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":     true,
+							"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries": true,
+							"/spire.api.server.agent.v1.Agent/ListAgents":           true,
+						},
+						Scope: []spiffeid.ID{teamA},
+					},
+					{
+						Methods: map[string]bool{
+							"/spire.api.server.bundle.v1.Bundle/GetBundle": true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "registration API methods",
+			policy: `
+				rule {
+					caller_local = true
+					methods = ["spire.api.registration.Registration/FetchEntries", "/spire.api.registration.Registration/FetchBundle"]
+				}
+			`,
+			expect: &Policy{
+				Rules: []PolicyRule{
+					{
+						CallerLocal: true,
+						Methods: map[string]bool{
+							"/spire.api.registration.Registration/FetchEntries": true,
+							"/spire.api.registration.Registration/FetchBundle":  true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "malformed",
+			policy:    `rule {`,
+			expectErr: "object expected closing RBRACE",
+		},
+		{
+			name:      "no rules",
+			policy:    ``,
+			expectErr: "at least one rule is required",
+		},
+		{
+			name:      "unknown top-level key",
+			policy:    `rules {}`,
+			expectErr: `unknown keys ["rules"]`,
+		},
+		{
+			name: "unknown rule key",
+			policy: `
+				rule {
+					caller_admn = true
+					methods = ["*"]
+				}
+			`,
+			expectErr: `rule 0: unknown keys ["caller_admn"]`,
+		},
+		{
+			name: "no methods",
+			policy: `
+				rule {
+					caller_admin = true
+				}
+			`,
+			expectErr: "rule 0: at least one method is required",
+		},
+		{
+			name: "unknown method",
+			policy: `
+				rule {
+					methods = ["entry.v1.Entry/ListEntry"]
+				}
+			`,
+			expectErr: `rule 0: method "entry.v1.Entry/ListEntry" does not match any server API method`,
+		},
+		{
+			name: "invalid UID",
+			policy: `
+				rule {
+					caller_uids = [-1]
+					methods = ["*"]
+				}
+			`,
+			expectErr: "rule 0: invalid caller UID -1",
+		},
+		{
+			name: "invalid caller SPIFFE ID prefix",
+			policy: `
+				rule {
+					caller_spiffe_id_prefix = "example.org/team-a"
+					methods = ["*"]
+				}
+			`,
+			expectErr: "rule 0: invalid caller SPIFFE ID prefix:",
+		},
+		{
+			name: "invalid scope",
+			policy: `
+				rule {
+					methods = ["entry.v1.Entry/*"]
+					scope = ["team-a"]
+				}
+			`,
+			expectErr: "rule 0: invalid scope:",
+		},
+		{
+			name: "scope not supported by method",
+			policy: `
+				rule {
+					methods = ["bundle.v1.Bundle/GetBundle"]
+					scope = ["spiffe://example.org/team-a"]
+				}
+			`,
+			expectErr: `rule 0: method "/spire.api.server.bundle.v1.Bundle/GetBundle" does not support scopes`,
+		},
+		{
+			name: "scope not supported by registration API",
+			policy: `
+				rule {
+					caller_admin = true
+					methods = ["spire.api.registration.Registration/CreateEntry"]
+					scope = ["spiffe://example.org/team-a"]
+				}
+			`,
+			expectErr: `rule 0: method "/spire.api.registration.Registration/CreateEntry" does not support scopes`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.policy)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				require.Nil(t, policy)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, policy)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-policy-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = LoadPolicy(filepath.Join(dir, "missing.hcl"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to load authorization policy:")
+
+	path := filepath.Join(dir, "policy.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"rule": [{"caller_local": true, "methods": ["*"]}]}`), 0600))
+	policy, err := LoadPolicy(path)
+	require.NoError(t, err)
+	require.Equal(t, &Policy{
+		Rules: []PolicyRule{{CallerLocal: true, Methods: allMethods()}},
+	}, policy)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"rule": [{"caller_local": true}]}`), 0600))
+	_, err = LoadPolicy(path)
+	require.EqualError(t, err, `invalid authorization policy "`+path+`": rule 0: at least one method is required`)
+}
+
+func TestPolicyAuthorization(t *testing.T) {
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	teamAAdminID := spiffeid.Must("example.org", "team-a", "admin")
+	createEntry(t, ds, &common.RegistrationEntry{
+		ParentId:  agentID.String(),
+		SpiffeId:  teamAAdminID.String(),
+		Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+		Admin:     true,
+	})
+
+	policy, err := ParsePolicy(`
+		rule {
+			caller_uids = [0]
+			methods = ["*"]
+		}
+		rule {
+			caller_admin = true
+			caller_spiffe_id_prefix = "spiffe://example.org/team-a"
+			methods = ["entry.v1.Entry/*"]
+			scope = ["spiffe://example.org/team-a/*"]
+		}
+		rule {
+			methods = ["bundle.v1.Bundle/GetBundle"]
+		}
+	`)
+	require.NoError(t, err)
+
+	authorizers := PolicyAuthorization(policy, log, ds, clock.NewMock(t))
+
+	// Every method has an authorizer, even if no rule allows it
+	require.Len(t, authorizers, len(allMethods()))
+
+	localCaller := func(uid uint32) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr:     &net.UnixAddr{Net: "unix"},
+			AuthInfo: peertracker.AuthInfo{Caller: peertracker.CallerInfo{UID: uid}},
+		})
+		return rpccontext.WithLogger(rpccontext.WithLocalCaller(ctx), log)
+	}
+	idCaller := func(id spiffeid.ID) context.Context {
+		ctx := rpccontext.WithCallerID(context.Background(), id)
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	for _, tt := range []struct {
+		name        string
+		ctx         context.Context
+		method      string
+		expectErr   string
+		expectScope []spiffeid.ID
+	}{
+		{
+			name:   "local root caller is allowed anything",
+			ctx:    localCaller(0),
+			method: "/spire.api.server.svid.v1.SVID/MintX509SVID",
+		},
+		{
+			name:      "other local callers are denied",
+			ctx:       localCaller(1000),
+			method:    "/spire.api.server.entry.v1.Entry/ListEntries",
+			expectErr: `caller must be one of ["local-uid" "all-of[spiffe-id-prefix,admin]"]`,
+		},
+		{
+			name:        "team admin is allowed entry methods within its scope",
+			ctx:         idCaller(teamAAdminID),
+			method:      "/spire.api.server.entry.v1.Entry/BatchCreateEntry",
+			expectScope: []spiffeid.ID{spiffeid.Must("example.org", "team-a")},
+		},
+		{
+			name:      "team admin is denied other methods",
+			ctx:       idCaller(teamAAdminID),
+			method:    "/spire.api.server.agent.v1.Agent/ListAgents",
+			expectErr: `caller must be one of ["local-uid"]`,
+		},
+		{
+			name:      "non-admin callers under the prefix are denied",
+			ctx:       idCaller(spiffeid.Must("example.org", "team-a", "workload")),
+			method:    "/spire.api.server.entry.v1.Entry/ListEntries",
+			expectErr: `caller must be one of ["local-uid" "all-of[spiffe-id-prefix,admin]"]`,
+		},
+		{
+			name:   "local root caller is allowed the registration API",
+			ctx:    localCaller(0),
+			method: "/spire.api.registration.Registration/CreateEntry",
+		},
+		{
+			name:      "other local callers are denied the registration API",
+			ctx:       localCaller(1000),
+			method:    "/spire.api.registration.Registration/CreateEntry",
+			expectErr: `caller must be one of ["local-uid"]`,
+		},
+		{
+			name:      "scoped team admin is denied the registration API",
+			ctx:       idCaller(teamAAdminID),
+			method:    "/spire.api.registration.Registration/CreateEntry",
+			expectErr: `caller must be one of ["local-uid"]`,
+		},
+		{
+			name:   "any caller is allowed to get the bundle",
+			ctx:    idCaller(spiffeid.Must("example.org", "workload")),
+			method: "/spire.api.server.bundle.v1.Bundle/GetBundle",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := authorizers[tt.method].AuthorizeCaller(tt.ctx)
+			if tt.expectErr != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			scope, ok := rpccontext.CallerScope(ctx)
+			if tt.expectScope == nil {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Equal(t, tt.expectScope, scope)
+		})
+	}
+}
+
+func TestRegistrationMethods(t *testing.T) {
+	var expected []string
+	server := reflect.TypeOf((*registration.RegistrationServer)(nil)).Elem()
+	for i := 0; i < server.NumMethod(); i++ {
+		expected = append(expected, "/spire.api.registration.Registration/"+server.Method(i).Name)
+	}
+	require.ElementsMatch(t, expected, registrationMethods)
+}
+
+func allMethods() map[string]bool {
+	methods := make(map[string]bool)
+	for method := range RateLimits(RateLimitConfig{}, telemetry.Blackhole{}) {
+		methods[method] = true
+	}
+	for _, method := range registrationMethods {
+		methods[method] = true
+	}
+	return methods
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_registrationapi "github.com/spiffe/spire/pkg/common/telemetry/server/registrationapi"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -99,7 +100,7 @@ func (h *Handler) CreateEntryIfNotExists(ctx context.Context, request *common.Re
 }
 
 //DeleteEntry deletes an entry in the Registration table
-func (h *Handler) DeleteEntry(ctx context.Context, request *registration.RegistrationEntryID) (_ *common.RegistrationEntry, err error) {
+func (h *Handler) DeleteEntry(ctx context.Context, request *registration.DeleteEntryRequest) (_ *common.RegistrationEntry, err error) {
 	counter := telemetry_registrationapi.StartDeleteEntryCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
@@ -107,9 +108,14 @@ func (h *Handler) DeleteEntry(ctx context.Context, request *registration.Registr
 
 	ds := h.getDataStore()
 	req := &datastore.DeleteRegistrationEntryRequest{
-		EntryId: request.Id,
+		EntryId:                request.Id,
+		ExpectedRevisionNumber: request.ExpectedRevisionNumber,
 	}
 	resp, err := ds.DeleteRegistrationEntry(ctx, req)
+	if status.Code(err) == codes.Aborted {
+		log.WithError(err).Error("Registration entry revision conflict")
+		return nil, status.Errorf(codes.Aborted, "error deleting registration entry: %v", status.Convert(err).Message())
+	}
 	if err != nil {
 		log.WithError(err).Error("Error deleting registration entry")
 		return &common.RegistrationEntry{}, status.Error(codes.Internal, err.Error())
@@ -170,16 +176,40 @@ func (h *Handler) UpdateEntry(ctx context.Context, request *registration.UpdateE
 		return nil, status.Error(codes.InvalidArgument, "request is missing entry to update")
 	}
 
+	ds := h.getDataStore()
+
+	// Fields outside of the mask keep their current value, which is needed
+	// to validate the updated entry
+	if request.InputMask != nil && request.Entry.EntryId != "" {
+		fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+			EntryId: request.Entry.EntryId,
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to fetch registration entry")
+			return nil, status.Errorf(codes.Internal, "failed to fetch registration entry: %v", err)
+		}
+		if fetchResp.Entry == nil {
+			log.Error("No such registration entry")
+			return nil, status.Error(codes.NotFound, "no such registration entry")
+		}
+		request.Entry = mergeRegistrationEntry(fetchResp.Entry, request.Entry, request.InputMask)
+	}
+
 	request.Entry, err = h.prepareRegistrationEntry(request.Entry, true)
 	if err != nil {
 		log.WithError(err).Error("Error validating request parameters")
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	ds := h.getDataStore()
 	resp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
-		Entry: request.Entry,
+		Entry:                  request.Entry,
+		ExpectedRevisionNumber: request.ExpectedRevisionNumber,
+		InputMask:              request.InputMask,
 	})
+	if status.Code(err) == codes.Aborted {
+		log.WithError(err).Error("Registration entry revision conflict")
+		return nil, status.Errorf(codes.Aborted, "failed to update registration entry: %v", status.Convert(err).Message())
+	}
 	if err != nil {
 		log.WithError(err).Error("Failed to update registration entry")
 		return nil, status.Errorf(codes.Internal, "failed to update registration entry: %v", err)
@@ -319,12 +349,16 @@ func (h *Handler) ListAllEntriesWithPages(ctx context.Context, request *registra
 		}
 		token = request.Pagination.Token
 	}
-	fetchResponse, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
-		Pagination: &datastore.Pagination{
-			Token:    token,
-			PageSize: pageSize,
-		},
-	})
+	req, err := listEntriesRequestFromFilter(request.Filter)
+	if err != nil {
+		log.WithError(err).Error("Invalid filter")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Pagination = &datastore.Pagination{
+		Token:    token,
+		PageSize: pageSize,
+	}
+	fetchResponse, err := ds.ListRegistrationEntries(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Error trying to fetch entries")
 		return nil, status.Errorf(codes.Internal, "error trying to fetch entries: %v", err)
@@ -589,7 +623,20 @@ func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.Evi
 func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
 	log := h.Log.WithField(telemetry.Method, telemetry.ListAgents)
 	ds := h.Catalog.GetDataStore()
-	req := &datastore.ListAttestedNodesRequest{}
+	req := &datastore.ListAttestedNodesRequest{
+		ByAttestationType: listReq.ByAttestationType,
+		ByBanned:          listReq.ByBanned,
+		ByCanReattest:     listReq.ByCanReattest,
+		FetchSelectors:    true,
+	}
+	if len(listReq.BySelectors) > 0 {
+		bySelectors, err := bySelectorsFromRequest(listReq.BySelectors, listReq.SelectorMatch)
+		if err != nil {
+			log.WithError(err).Error("Invalid selector match")
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		req.BySelectorMatch = bySelectors
+	}
 	resp, err := ds.ListAttestedNodes(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Failed to list attested nodes")
@@ -754,6 +801,76 @@ func (h *Handler) normalizeSPIFFEIDForMinting(spiffeID string) (string, error) {
 	return spiffeID, nil
 }
 
+// listEntriesRequestFromFilter converts a ListAllEntries filter to the
+// equivalent datastore request
+func listEntriesRequestFromFilter(filter *registration.ListAllEntriesRequest_Filter) (*datastore.ListRegistrationEntriesRequest, error) {
+	req := &datastore.ListRegistrationEntriesRequest{}
+	if filter == nil {
+		return req, nil
+	}
+
+	if filter.ByParentId != nil {
+		parentID, err := idutil.NormalizeSpiffeID(filter.ByParentId.Value, idutil.AllowAny())
+		if err != nil {
+			return nil, err
+		}
+		req.ByParentId = &wrappers.StringValue{Value: parentID}
+	}
+	if filter.BySpiffeId != nil {
+		spiffeID, err := idutil.NormalizeSpiffeID(filter.BySpiffeId.Value, idutil.AllowAny())
+		if err != nil {
+			return nil, err
+		}
+		req.BySpiffeId = &wrappers.StringValue{Value: spiffeID}
+	}
+	if len(filter.BySelectors) > 0 {
+		bySelectors, err := bySelectorsFromRequest(filter.BySelectors, filter.SelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+		req.BySelectors = bySelectors
+	}
+	if len(filter.ByFederatesWith) > 0 {
+		req.ByFederatesWith = &datastore.ByFederatesWith{
+			Match: datastore.ByFederatesWith_MATCH_ANY,
+		}
+		for _, trustDomain := range filter.ByFederatesWith {
+			trustDomainID, err := idutil.NormalizeSpiffeID(trustDomain, idutil.AllowAnyTrustDomain())
+			if err != nil {
+				return nil, err
+			}
+			req.ByFederatesWith.TrustDomains = append(req.ByFederatesWith.TrustDomains, trustDomainID)
+		}
+	}
+	req.ByDownstream = filter.ByDownstream
+	req.ByAdmin = filter.ByAdmin
+	req.ByDnsName = filter.ByDnsName
+	req.ByExpiresAfter = filter.ByExpiresAfter
+	req.ByExpiresBefore = filter.ByExpiresBefore
+	return req, nil
+}
+
+// bySelectorsFromRequest converts the selectors and selector match of a
+// request to the equivalent datastore filter
+func bySelectorsFromRequest(selectors []*common.Selector, match registration.SelectorMatch) (*datastore.BySelectors, error) {
+	bySelectors := &datastore.BySelectors{
+		Selectors: selectors,
+	}
+	switch match {
+	case registration.SelectorMatch_MATCH_EXACT:
+		bySelectors.Match = datastore.BySelectors_MATCH_EXACT
+	case registration.SelectorMatch_MATCH_SUBSET:
+		bySelectors.Match = datastore.BySelectors_MATCH_SUBSET
+	case registration.SelectorMatch_MATCH_ANY:
+		bySelectors.Match = datastore.BySelectors_MATCH_ANY
+	case registration.SelectorMatch_MATCH_SUPERSET:
+		bySelectors.Match = datastore.BySelectors_MATCH_SUPERSET
+	default:
+		return nil, fmt.Errorf("unhandled selector match %q", match)
+	}
+	return bySelectors, nil
+}
+
 func (h *Handler) isEntryUnique(ctx context.Context, ds datastore.DataStore, entry *common.RegistrationEntry) (*common.RegistrationEntry, bool, error) {
 	// First we get all the entries that matches the entry's spiffe id.
 	req := &datastore.ListRegistrationEntriesRequest{
@@ -826,6 +943,10 @@ func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forU
 		}
 	}
 
+	if _, err := x509util.ParseSVIDProfile(entry.X509SvidProfile); err != nil {
+		return nil, fmt.Errorf("X509-SVID profile failed validation: %v", err)
+	}
+
 	entry.ParentId, err = idutil.NormalizeSpiffeID(entry.ParentId, idutil.AllowAnyInTrustDomain(h.TrustDomain.Host))
 	if err != nil {
 		return nil, err
@@ -861,6 +982,46 @@ func cloneRegistrationEntry(entry *common.RegistrationEntry) *common.Registratio
 	return proto.Clone(entry).(*common.RegistrationEntry)
 }
 
+// mergeRegistrationEntry returns a copy of dst with the fields enabled in the
+// mask set from src.
+func mergeRegistrationEntry(dst, src *common.RegistrationEntry, mask *common.RegistrationEntryMask) *common.RegistrationEntry {
+	dst = cloneRegistrationEntry(dst)
+	if mask.Selectors {
+		dst.Selectors = src.Selectors
+	}
+	if mask.ParentId {
+		dst.ParentId = src.ParentId
+	}
+	if mask.SpiffeId {
+		dst.SpiffeId = src.SpiffeId
+	}
+	if mask.Ttl {
+		dst.Ttl = src.Ttl
+	}
+	if mask.FederatesWith {
+		dst.FederatesWith = src.FederatesWith
+	}
+	if mask.Admin {
+		dst.Admin = src.Admin
+	}
+	if mask.Downstream {
+		dst.Downstream = src.Downstream
+	}
+	if mask.EntryExpiry {
+		dst.EntryExpiry = src.EntryExpiry
+	}
+	if mask.DnsNames {
+		dst.DnsNames = src.DnsNames
+	}
+	if mask.X509SvidProfile {
+		dst.X509SvidProfile = src.X509SvidProfile
+	}
+	if mask.JwtSvidTtl {
+		dst.JwtSvidTtl = src.JwtSvidTtl
+	}
+	return dst
+}
+
 func convertDeleteBundleMode(in registration.DeleteFederatedBundleRequest_Mode) (datastore.DeleteBundleRequest_Mode, error) {
 	switch in {
 	case registration.DeleteFederatedBundleRequest_RESTRICT:
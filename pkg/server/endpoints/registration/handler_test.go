@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/peertracker"
@@ -336,6 +337,18 @@ func (s *HandlerSuite) TestCreateEntryAndCreateEntryIfNotExists() {
 			},
 			Err: "empty or only whitespace",
 		},
+		{
+			Name: "Bad X509-SVID profile",
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/parent",
+				SpiffeId:  "spiffe://example.org/child",
+				Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+				X509SvidProfile: &common.X509SVIDProfile{
+					ExtKeyUsages: []string{"codeSigning"},
+				},
+			},
+			Err: `X509-SVID profile failed validation: unsupported extended key usage "codeSigning"`,
+		},
 	}
 
 	verifyEntry := func(entry *common.RegistrationEntry) {
@@ -478,9 +491,10 @@ func (s *HandlerSuite) TestUpdateEntry() {
 	})
 
 	testCases := []struct {
-		Name  string
-		Entry *common.RegistrationEntry
-		Err   string
+		Name                   string
+		Entry                  *common.RegistrationEntry
+		ExpectedRevisionNumber *wrappers.Int64Value
+		Err                    string
 	}{
 		{
 			Name: "Missing entry",
@@ -513,14 +527,27 @@ func (s *HandlerSuite) TestUpdateEntry() {
 			Err: "empty or only whitespace",
 		},
 		{
-			Name: "Success",
+			Name: "Revision number mismatch",
 			Entry: &common.RegistrationEntry{
 				EntryId:   entry.EntryId,
 				ParentId:  "spiffe://example.org/parent",
 				SpiffeId:  "spiffe://example.org/child",
 				Selectors: []*common.Selector{{Type: "B", Value: "b"}},
-				DnsNames:  []string{"wxyz.2-a"},
 			},
+			ExpectedRevisionNumber: &wrappers.Int64Value{Value: 1},
+			Err:                    "revision number mismatch: expected 1, current 0",
+		},
+		{
+			Name: "Success",
+			Entry: &common.RegistrationEntry{
+				EntryId:        entry.EntryId,
+				ParentId:       "spiffe://example.org/parent",
+				SpiffeId:       "spiffe://example.org/child",
+				Selectors:      []*common.Selector{{Type: "B", Value: "b"}},
+				DnsNames:       []string{"wxyz.2-a"},
+				RevisionNumber: 1,
+			},
+			ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
 		},
 	}
 
@@ -528,7 +555,8 @@ func (s *HandlerSuite) TestUpdateEntry() {
 		testCase := testCase // alias loop variable as it is used in the closure
 		s.T().Run(testCase.Name, func(t *testing.T) {
 			resp, err := s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
-				Entry: testCase.Entry,
+				Entry:                  testCase.Entry,
+				ExpectedRevisionNumber: testCase.ExpectedRevisionNumber,
 			})
 
 			if testCase.Err != "" {
@@ -542,6 +570,81 @@ func (s *HandlerSuite) TestUpdateEntry() {
 	}
 }
 
+func (s *HandlerSuite) TestUpdateEntryWithMask() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		DnsNames:  []string{"abc"},
+		Ttl:       60,
+	})
+
+	testCases := []struct {
+		Name     string
+		Entry    *common.RegistrationEntry
+		Mask     *common.RegistrationEntryMask
+		Expected *common.RegistrationEntry
+		Err      string
+	}{
+		{
+			Name:  "Registration entry does not exist",
+			Entry: &common.RegistrationEntry{EntryId: "X", Ttl: 120},
+			Mask:  &common.RegistrationEntryMask{Ttl: true},
+			Err:   "no such registration entry",
+		},
+		{
+			Name:  "SPIFFE ID is malformed",
+			Entry: &common.RegistrationEntry{EntryId: entry.EntryId, SpiffeId: "FOO"},
+			Mask:  &common.RegistrationEntryMask{SpiffeId: true},
+			Err:   `"FOO" is not a valid workload SPIFFE ID`,
+		},
+		{
+			Name:  "Only masked fields are updated",
+			Entry: &common.RegistrationEntry{EntryId: entry.EntryId, Ttl: 120},
+			Mask:  &common.RegistrationEntryMask{Ttl: true, DnsNames: true},
+			Expected: &common.RegistrationEntry{
+				EntryId:        entry.EntryId,
+				ParentId:       "spiffe://example.org/foo",
+				SpiffeId:       "spiffe://example.org/bar",
+				Selectors:      []*common.Selector{{Type: "A", Value: "a"}},
+				Ttl:            120,
+				RevisionNumber: 1,
+			},
+		},
+		{
+			Name:  "JWT-SVID TTL is updated",
+			Entry: &common.RegistrationEntry{EntryId: entry.EntryId, Ttl: 180, JwtSvidTtl: 30},
+			Mask:  &common.RegistrationEntryMask{JwtSvidTtl: true},
+			Expected: &common.RegistrationEntry{
+				EntryId:        entry.EntryId,
+				ParentId:       "spiffe://example.org/foo",
+				SpiffeId:       "spiffe://example.org/bar",
+				Selectors:      []*common.Selector{{Type: "A", Value: "a"}},
+				Ttl:            120,
+				JwtSvidTtl:     30,
+				RevisionNumber: 2,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase // alias loop variable as it is used in the closure
+		s.T().Run(testCase.Name, func(t *testing.T) {
+			resp, err := s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+				Entry:     testCase.Entry,
+				InputMask: testCase.Mask,
+			})
+
+			if testCase.Err != "" {
+				requireErrorContains(t, err, testCase.Err)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, testCase.Expected, resp)
+		})
+	}
+}
+
 func (s *HandlerSuite) TestDeleteEntry() {
 	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:  "spiffe://example.org/foo",
@@ -550,13 +653,21 @@ func (s *HandlerSuite) TestDeleteEntry() {
 	})
 
 	testCases := []struct {
-		Name    string
-		EntryID string
-		Err     string
+		Name                   string
+		EntryID                string
+		ExpectedRevisionNumber *wrappers.Int64Value
+		Err                    string
 	}{
 		{
-			Name:    "Success",
-			EntryID: entry.EntryId,
+			Name:                   "Revision number mismatch",
+			EntryID:                entry.EntryId,
+			ExpectedRevisionNumber: &wrappers.Int64Value{Value: 1},
+			Err:                    "revision number mismatch: expected 1, current 0",
+		},
+		{
+			Name:                   "Success",
+			EntryID:                entry.EntryId,
+			ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
 		},
 		{
 			Name: "Registration entry does not exist",
@@ -567,8 +678,9 @@ func (s *HandlerSuite) TestDeleteEntry() {
 	for _, testCase := range testCases {
 		testCase := testCase // alias loop variable as it is used in the closure
 		s.T().Run(testCase.Name, func(t *testing.T) {
-			resp, err := s.handler.DeleteEntry(context.Background(), &registration.RegistrationEntryID{
-				Id: testCase.EntryID,
+			resp, err := s.handler.DeleteEntry(context.Background(), &registration.DeleteEntryRequest{
+				Id:                     testCase.EntryID,
+				ExpectedRevisionNumber: testCase.ExpectedRevisionNumber,
 			})
 
 			if testCase.Err != "" {
@@ -954,6 +1066,121 @@ func (s *HandlerSuite) TestListAllEntriesWithPages() {
 	s.Require().Empty(resp.Pagination.Token)
 }
 
+func (s *HandlerSuite) TestListAllEntriesWithFilter() {
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://otherdomain.test"})
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/foo",
+		SpiffeId:      "spiffe://example.org/bar",
+		Selectors:     []*common.Selector{{Type: "A", Value: "a"}, {Type: "B", Value: "b"}},
+		FederatesWith: []string{"spiffe://otherdomain.test"},
+		DnsNames:      []string{"bar.example.org"},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/foo",
+		SpiffeId:    "spiffe://example.org/baz",
+		Selectors:   []*common.Selector{{Type: "A", Value: "a"}},
+		Downstream:  true,
+		EntryExpiry: 1000,
+	})
+	entry3 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/buz",
+		SpiffeId:    "spiffe://example.org/fuz",
+		Selectors:   []*common.Selector{{Type: "C", Value: "c"}},
+		Admin:       true,
+		EntryExpiry: 2000,
+	})
+
+	for _, tt := range []struct {
+		name         string
+		filter       *registration.ListAllEntriesRequest_Filter
+		expectedList []*common.RegistrationEntry
+		err          string
+	}{
+		{
+			name:         "by parent id",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByParentId: &wrappers.StringValue{Value: "spiffe://example.org/foo"}},
+			expectedList: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name: "by selectors with any match",
+			filter: &registration.ListAllEntriesRequest_Filter{
+				BySelectors:   []*common.Selector{{Type: "B", Value: "b"}, {Type: "C", Value: "c"}},
+				SelectorMatch: registration.SelectorMatch_MATCH_ANY,
+			},
+			expectedList: []*common.RegistrationEntry{entry1, entry3},
+		},
+		{
+			name: "by selectors with superset match",
+			filter: &registration.ListAllEntriesRequest_Filter{
+				BySelectors:   []*common.Selector{{Type: "A", Value: "a"}},
+				SelectorMatch: registration.SelectorMatch_MATCH_SUPERSET,
+			},
+			expectedList: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name:         "by federates with",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByFederatesWith: []string{"spiffe://otherdomain.test", "spiffe://unknown.test"}},
+			expectedList: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name:         "by downstream",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByDownstream: &wrappers.BoolValue{Value: true}},
+			expectedList: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:         "by admin",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByAdmin: &wrappers.BoolValue{Value: false}},
+			expectedList: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name:         "by dns name",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByDnsName: &wrappers.StringValue{Value: "bar.example.org"}},
+			expectedList: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name: "by expiry window",
+			filter: &registration.ListAllEntriesRequest_Filter{
+				ByExpiresAfter:  &wrappers.Int64Value{Value: 500},
+				ByExpiresBefore: &wrappers.Int64Value{Value: 1500},
+			},
+			expectedList: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:         "by expires after",
+			filter:       &registration.ListAllEntriesRequest_Filter{ByExpiresAfter: &wrappers.Int64Value{Value: 1500}},
+			expectedList: []*common.RegistrationEntry{entry1, entry3},
+		},
+		{
+			name: "invalid selector match",
+			filter: &registration.ListAllEntriesRequest_Filter{
+				BySelectors:   []*common.Selector{{Type: "A", Value: "a"}},
+				SelectorMatch: 99,
+			},
+			err: `rpc error: code = InvalidArgument desc = unhandled selector match "99"`,
+		},
+		{
+			name:   "invalid federates with",
+			filter: &registration.ListAllEntriesRequest_Filter{ByFederatesWith: []string{"otherdomain.test"}},
+			err:    "rpc error: code = InvalidArgument",
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.handler.ListAllEntriesWithPages(context.Background(), &registration.ListAllEntriesRequest{
+				Filter: tt.filter,
+			})
+			if tt.err != "" {
+				requireErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			util.SortRegistrationEntries(tt.expectedList)
+			util.SortRegistrationEntries(resp.Entries)
+			require.Equal(t, tt.expectedList, resp.Entries)
+		})
+	}
+}
+
 func (s *HandlerSuite) TestCreateJoinToken() {
 	// No ttl
 	resp, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo"})
@@ -1030,8 +1257,8 @@ func (s *HandlerSuite) TestListAgents() {
 	spiffeID1 := "spiffe://example.org/spire/agent/join_token/token_a"
 	spiffeID2 := "spiffe://example.org/spire/agent/join_token/token_b"
 	expectedNodeList := []*common.AttestedNode{
-		{SpiffeId: spiffeID1},
-		{SpiffeId: spiffeID2},
+		{SpiffeId: spiffeID1, Banned: true},
+		{SpiffeId: spiffeID2, Banned: true},
 	}
 	s.createAttestedNode(spiffeID1)
 	s.createAttestedNode(spiffeID2)
@@ -1043,6 +1270,72 @@ func (s *HandlerSuite) TestListAgents() {
 	s.Equal(listResponse.Nodes, expectedNodeList)
 }
 
+func (s *HandlerSuite) TestListAgentsWithFilters() {
+	ctx := context.Background()
+	x509popID := "spiffe://example.org/spire/agent/x509pop/foo"
+	joinTokenID := "spiffe://example.org/spire/agent/join_token/token_a"
+	x509popNode := &common.AttestedNode{
+		SpiffeId:            x509popID,
+		AttestationDataType: "x509pop",
+		CertSerialNumber:    "1",
+		CanReattest:         true,
+	}
+	joinTokenNode := &common.AttestedNode{
+		SpiffeId:            joinTokenID,
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "2",
+	}
+	for _, node := range []*common.AttestedNode{x509popNode, joinTokenNode} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+	}
+	selectors := []*common.Selector{{Type: "x509pop", Value: "subject:cn:foo"}}
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{SpiffeId: x509popID, Selectors: selectors},
+	})
+	s.Require().NoError(err)
+
+	// Selectors are returned inline
+	x509popNode.Selectors = selectors
+
+	for _, tt := range []struct {
+		name   string
+		req    *registration.ListAgentsRequest
+		expect []*common.AttestedNode
+	}{
+		{
+			name:   "by attestation type",
+			req:    &registration.ListAgentsRequest{ByAttestationType: "join_token"},
+			expect: []*common.AttestedNode{joinTokenNode},
+		},
+		{
+			name: "by selectors",
+			req: &registration.ListAgentsRequest{
+				BySelectors:   selectors,
+				SelectorMatch: registration.SelectorMatch_MATCH_SUBSET,
+			},
+			expect: []*common.AttestedNode{x509popNode},
+		},
+		{
+			name:   "by banned",
+			req:    &registration.ListAgentsRequest{ByBanned: &wrappers.BoolValue{Value: true}},
+			expect: nil,
+		},
+		{
+			name:   "by can reattest",
+			req:    &registration.ListAgentsRequest{ByCanReattest: &wrappers.BoolValue{Value: false}},
+			expect: []*common.AttestedNode{joinTokenNode},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.handler.ListAgents(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expect, resp.Nodes)
+		})
+	}
+}
+
 func (s *HandlerSuite) TestListWithNoAgents() {
 	// Creating attested nodes list
 	ctx := context.Background()
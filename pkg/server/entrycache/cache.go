@@ -0,0 +1,269 @@
+package entrycache
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/eventutil"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// defaultSyncInterval is how often the change events recorded in the
+	// datastore are applied to the cache by default
+	defaultSyncInterval = time.Second
+
+	// defaultReloadInterval is how often the cache is fully reloaded from the
+	// datastore by default
+	defaultReloadInterval = 10 * time.Minute
+
+	// listLimit is the maximum number of change events listed from the
+	// datastore at once
+	listLimit = 1000
+
+	// entryPageSize is the number of registration entries listed from the
+	// datastore at once when the cache is loaded
+	entryPageSize = 10000
+)
+
+// Config is the configuration for the entry cache
+type Config struct {
+	Log       logrus.FieldLogger
+	Metrics   telemetry.Metrics
+	DataStore datastore.DataStore
+	Clock     clock.Clock
+
+	// SyncInterval is how often the change events recorded in the datastore
+	// are applied to the cache. Defaults to one second.
+	SyncInterval time.Duration
+
+	// ReloadInterval is how often the cache is fully reloaded from the
+	// datastore, as a safeguard against change events that were missed.
+	// Defaults to ten minutes.
+	ReloadInterval time.Duration
+}
+
+// Cache is an in-memory index of the registration entries and node selectors
+// in the datastore, used to resolve the entries agents are authorized for
+// without querying the datastore. The cache is loaded with Load and kept up
+// to date by Run, which applies the change events recorded in the datastore
+// and periodically reloads the cache.
+type Cache struct {
+	c Config
+
+	mu  sync.RWMutex
+	idx *index
+
+	// The following are only accessed by Load and Run
+	cursor   *eventutil.Cursor
+	syncedAt time.Time
+}
+
+// New creates a new entry cache. The cache is empty until it is loaded.
+func New(config Config) *Cache {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	if config.SyncInterval <= 0 {
+		config.SyncInterval = defaultSyncInterval
+	}
+	if config.ReloadInterval <= 0 {
+		config.ReloadInterval = defaultReloadInterval
+	}
+	return &Cache{
+		c:   config,
+		idx: newIndex(),
+	}
+}
+
+// FetchAuthorizedEntries returns the registration entries the given SPIFFE
+// ID is authorized for, either directly or through its descendants.
+func (c *Cache) FetchAuthorizedEntries(ctx context.Context, spiffeID string) ([]*common.RegistrationEntry, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.idx.authorizedEntries(spiffeID), nil
+}
+
+// Load fully loads the cache from the datastore.
+func (c *Cache) Load(ctx context.Context) (err error) {
+	counter := telemetry_server.StartEntryCacheReloadCall(c.c.Metrics)
+	defer counter.Done(&err)
+
+	startedAt := c.c.Clock.Now()
+
+	// Changes made while the cache is loaded are applied again from the
+	// change events recorded after the current revision.
+	resp, err := c.c.DataStore.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+		AfterRevision: math.MaxInt64,
+	})
+	if err != nil {
+		return err
+	}
+	revision := resp.LatestRevision
+
+	idx := newIndex()
+	var pagination *datastore.Pagination
+	for {
+		resp, err := c.c.DataStore.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			Pagination: &datastore.Pagination{
+				Token:    pagination.GetToken(),
+				PageSize: entryPageSize,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			idx.setEntry(entry)
+		}
+		pagination = resp.Pagination
+		if len(resp.Entries) < entryPageSize {
+			break
+		}
+	}
+
+	selectorsResp, err := c.c.DataStore.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, nodeSelectors := range selectorsResp.Selectors {
+		idx.setNodeSelectors(nodeSelectors.SpiffeId, nodeSelectors.Selectors)
+	}
+
+	c.mu.Lock()
+	c.idx = idx
+	c.mu.Unlock()
+
+	c.cursor = eventutil.NewCursor(revision)
+	c.syncedAt = startedAt
+	c.emitSizeGauges()
+	return nil
+}
+
+// Run keeps the cache up to date until the context is canceled. The cache
+// must have been loaded first.
+func (c *Cache) Run(ctx context.Context) error {
+	syncTicker := c.c.Clock.Ticker(c.c.SyncInterval)
+	defer syncTicker.Stop()
+	reloadTicker := c.c.Clock.Ticker(c.c.ReloadInterval)
+	defer reloadTicker.Stop()
+
+	for {
+		select {
+		case <-syncTicker.C:
+			// Log an error on failure unless we're shutting down
+			if err := c.sync(ctx); err != nil && ctx.Err() == nil {
+				c.c.Log.WithError(err).Error("Failed to sync entry cache")
+			}
+		case <-reloadTicker.C:
+			if err := c.Load(ctx); err != nil && ctx.Err() == nil {
+				c.c.Log.WithError(err).Error("Failed to reload entry cache")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+		telemetry_server.SetEntryCacheStalenessGauge(c.c.Metrics, c.c.Clock.Now().Sub(c.syncedAt).Seconds())
+	}
+}
+
+// sync applies the change events recorded since the last sync. Events only
+// identify the changed resources, so the current state of each resource is
+// fetched from the datastore. The cursor is only advanced once the changes
+// have been applied to the index.
+func (c *Cache) sync(ctx context.Context) (err error) {
+	counter := telemetry_server.StartEntryCacheSyncCall(c.c.Metrics)
+	defer counter.Done(&err)
+
+	startedAt := c.c.Clock.Now()
+	c.cursor.ExpireGaps(startedAt)
+
+	var events []*datastore.ChangeEvent
+	afterRevision := c.cursor.ResumeRevision()
+	for {
+		resp, err := c.c.DataStore.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+			AfterRevision: afterRevision,
+			Limit:         listLimit,
+		})
+		if err != nil {
+			return err
+		}
+		for _, event := range resp.Events {
+			afterRevision = event.Revision
+			if c.cursor.Pending(event.Revision) {
+				events = append(events, event)
+			}
+		}
+		if len(resp.Events) < listLimit {
+			break
+		}
+	}
+
+	entries := make(map[string]*common.RegistrationEntry)
+	nodeSelectors := make(map[string][]*common.Selector)
+	for _, event := range events {
+		switch event.ResourceType {
+		case datastore.ChangeEvent_REGISTRATION_ENTRY:
+			if _, ok := entries[event.ResourceId]; ok {
+				continue
+			}
+			resp, err := c.c.DataStore.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+				EntryId: event.ResourceId,
+			})
+			if err != nil {
+				return err
+			}
+			entries[event.ResourceId] = resp.Entry
+		case datastore.ChangeEvent_ATTESTED_NODE:
+			if _, ok := nodeSelectors[event.ResourceId]; ok {
+				continue
+			}
+			resp, err := c.c.DataStore.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+				SpiffeId: event.ResourceId,
+			})
+			if err != nil {
+				return err
+			}
+			nodeSelectors[event.ResourceId] = resp.Selectors.GetSelectors()
+		}
+	}
+
+	c.mu.Lock()
+	for entryID, entry := range entries {
+		if entry == nil {
+			c.idx.deleteEntry(entryID)
+			continue
+		}
+		c.idx.setEntry(entry)
+	}
+	for spiffeID, selectors := range nodeSelectors {
+		c.idx.setNodeSelectors(spiffeID, selectors)
+	}
+	c.mu.Unlock()
+
+	// The events are only consumed once they have been applied, so that
+	// they are applied again by the next sync if this one fails.
+	for _, event := range events {
+		c.cursor.Observe(event.Revision, startedAt)
+	}
+
+	c.syncedAt = startedAt
+	if len(events) > 0 {
+		telemetry_server.IncrEntryCacheEventCounter(c.c.Metrics, len(events))
+		c.emitSizeGauges()
+	}
+	return nil
+}
+
+func (c *Cache) emitSizeGauges() {
+	c.mu.RLock()
+	entries, nodes := len(c.idx.entries), len(c.idx.nodeSelectors)
+	c.mu.RUnlock()
+	telemetry_server.SetEntryCacheSizeGauges(c.c.Metrics, entries, nodes)
+}
@@ -0,0 +1,197 @@
+package entrycache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+
+	rootID  = "spiffe://example.org/root"
+	oneID   = "spiffe://example.org/1"
+	twoID   = "spiffe://example.org/2"
+	threeID = "spiffe://example.org/3"
+	fourID  = "spiffe://example.org/4"
+	fiveID  = "spiffe://example.org/5"
+
+	a1 = &common.Selector{Type: "a", Value: "1"}
+	b2 = &common.Selector{Type: "b", Value: "2"}
+	c3 = &common.Selector{Type: "c", Value: "3"}
+)
+
+func TestLoad(t *testing.T) {
+	ds := fakedatastore.New()
+
+	//
+	//        root             4(a1,b2)    (a1,c3)
+	//        /   \           /
+	//       1     2         5
+	//            /
+	//           3
+	//
+	// node resolvers map from 2 to 4
+	//
+	oneEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: oneID})
+	twoEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: twoID})
+	threeEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: twoID, SpiffeId: threeID})
+	fourEntry := createEntry(t, ds, &common.RegistrationEntry{SpiffeId: fourID, Selectors: []*common.Selector{a1, b2}})
+	fiveEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: fourID, SpiffeId: fiveID})
+	createEntry(t, ds, &common.RegistrationEntry{SpiffeId: fiveID, Selectors: []*common.Selector{a1, c3}})
+	setNodeSelectors(t, ds, twoID, a1, b2)
+
+	cache := New(Config{DataStore: ds, Metrics: fakemetrics.New()})
+	requireAuthorizedEntries(t, cache, rootID)
+
+	require.NoError(t, cache.Load(ctx))
+	requireAuthorizedEntries(t, cache, rootID, oneEntry, twoEntry, threeEntry, fourEntry, fiveEntry)
+	requireAuthorizedEntries(t, cache, twoID, threeEntry, fourEntry, fiveEntry)
+	requireAuthorizedEntries(t, cache, oneID)
+}
+
+func TestLoadCycles(t *testing.T) {
+	ds := fakedatastore.New()
+
+	oneEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: oneID})
+	rootEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: oneID, SpiffeId: rootID})
+
+	cache := New(Config{DataStore: ds, Metrics: fakemetrics.New()})
+	require.NoError(t, cache.Load(ctx))
+	requireAuthorizedEntries(t, cache, rootID, oneEntry, rootEntry)
+}
+
+func TestSync(t *testing.T) {
+	ds := fakedatastore.New()
+	oneEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: oneID})
+
+	cache := New(Config{DataStore: ds, Metrics: fakemetrics.New()})
+	require.NoError(t, cache.Load(ctx))
+	requireAuthorizedEntries(t, cache, rootID, oneEntry)
+
+	// Changes are not visible until the cache syncs
+	twoEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: twoID})
+	threeEntry := createEntry(t, ds, &common.RegistrationEntry{SpiffeId: threeID, Selectors: []*common.Selector{a1}})
+	setNodeSelectors(t, ds, rootID, a1)
+	requireAuthorizedEntries(t, cache, rootID, oneEntry)
+
+	require.NoError(t, cache.sync(ctx))
+	requireAuthorizedEntries(t, cache, rootID, oneEntry, twoEntry, threeEntry)
+
+	// Updated and deleted entries and node selectors are applied
+	oneEntry.ParentId = twoID
+	updateResp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: oneEntry})
+	require.NoError(t, err)
+	oneEntry = updateResp.Entry
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: twoEntry.EntryId})
+	require.NoError(t, err)
+	setNodeSelectors(t, ds, rootID)
+	require.NoError(t, cache.sync(ctx))
+	requireAuthorizedEntries(t, cache, rootID)
+	requireAuthorizedEntries(t, cache, twoID, oneEntry)
+}
+
+func TestSyncRetriesFailedChanges(t *testing.T) {
+	ds := &failingDataStore{DataStore: fakedatastore.New()}
+	oneEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: oneID})
+
+	cache := New(Config{DataStore: ds, Metrics: fakemetrics.New()})
+	require.NoError(t, cache.Load(ctx))
+	requireAuthorizedEntries(t, cache, rootID, oneEntry)
+
+	// A deletion that fails to sync is not lost
+	_, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: oneEntry.EntryId})
+	require.NoError(t, err)
+	ds.fetchErr = errors.New("ohno")
+	require.EqualError(t, cache.sync(ctx), "ohno")
+	requireAuthorizedEntries(t, cache, rootID, oneEntry)
+
+	// The next sync applies it
+	require.NoError(t, cache.sync(ctx))
+	requireAuthorizedEntries(t, cache, rootID)
+}
+
+func TestRun(t *testing.T) {
+	ds := fakedatastore.New()
+	clk := clock.NewMock(t)
+	log, _ := test.NewNullLogger()
+	metrics := fakemetrics.New()
+
+	cache := New(Config{
+		Log:       log,
+		Metrics:   metrics,
+		DataStore: ds,
+		Clock:     clk,
+	})
+	require.NoError(t, cache.Load(ctx))
+
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cache.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}()
+	clk.WaitForTickerMulti(time.Minute, 2, "waiting for the sync and reload tickers")
+
+	// Change events are applied on every sync
+	oneEntry := createEntry(t, ds, &common.RegistrationEntry{ParentId: rootID, SpiffeId: oneID})
+	clk.Add(defaultSyncInterval)
+	require.Eventually(t, func() bool {
+		entries, err := cache.FetchAuthorizedEntries(ctx, rootID)
+		return err == nil && len(entries) == 1
+	}, time.Minute, 10*time.Millisecond)
+	requireAuthorizedEntries(t, cache, rootID, oneEntry)
+}
+
+func requireAuthorizedEntries(t *testing.T, cache *Cache, spiffeID string, expected ...*common.RegistrationEntry) {
+	actual, err := cache.FetchAuthorizedEntries(ctx, spiffeID)
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, util.DedupRegistrationEntries(expected), actual)
+}
+
+func createEntry(t *testing.T, ds datastore.DataStore, entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+func setNodeSelectors(t *testing.T, ds datastore.DataStore, spiffeID string, selectors ...*common.Selector) {
+	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: selectors,
+		},
+	})
+	require.NoError(t, err)
+}
+
+// failingDataStore fails the next registration entry fetch with fetchErr, if
+// set.
+type failingDataStore struct {
+	datastore.DataStore
+	fetchErr error
+}
+
+func (ds *failingDataStore) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (*datastore.FetchRegistrationEntryResponse, error) {
+	if err := ds.fetchErr; err != nil {
+		ds.fetchErr = nil
+		return nil, err
+	}
+	return ds.DataStore.FetchRegistrationEntry(ctx, req)
+}
@@ -0,0 +1,154 @@
+package entrycache
+
+import (
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+type selectorKey struct {
+	Type  string
+	Value string
+}
+
+type entryIDSet map[string]struct{}
+
+func (s entryIDSet) add(entryID string) entryIDSet {
+	if s == nil {
+		s = make(entryIDSet)
+	}
+	s[entryID] = struct{}{}
+	return s
+}
+
+// index holds the registration entries and node selectors, indexed the way
+// authorized entries are resolved.
+type index struct {
+	// entries holds the registration entries by entry ID
+	entries map[string]*common.RegistrationEntry
+
+	// byParentID holds the IDs of the entries with a given parent ID
+	byParentID map[string]entryIDSet
+
+	// bySelector holds the IDs of the entries with a given selector
+	bySelector map[selectorKey]entryIDSet
+
+	// nodeSelectors holds the selectors of each node by SPIFFE ID
+	nodeSelectors map[string]map[selectorKey]struct{}
+}
+
+func newIndex() *index {
+	return &index{
+		entries:       make(map[string]*common.RegistrationEntry),
+		byParentID:    make(map[string]entryIDSet),
+		bySelector:    make(map[selectorKey]entryIDSet),
+		nodeSelectors: make(map[string]map[selectorKey]struct{}),
+	}
+}
+
+func (idx *index) setEntry(entry *common.RegistrationEntry) {
+	idx.deleteEntry(entry.EntryId)
+
+	idx.entries[entry.EntryId] = entry
+	idx.byParentID[entry.ParentId] = idx.byParentID[entry.ParentId].add(entry.EntryId)
+	for _, s := range entry.Selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		idx.bySelector[key] = idx.bySelector[key].add(entry.EntryId)
+	}
+}
+
+func (idx *index) deleteEntry(entryID string) {
+	entry, ok := idx.entries[entryID]
+	if !ok {
+		return
+	}
+
+	delete(idx.entries, entryID)
+	delete(idx.byParentID[entry.ParentId], entryID)
+	if len(idx.byParentID[entry.ParentId]) == 0 {
+		delete(idx.byParentID, entry.ParentId)
+	}
+	for _, s := range entry.Selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		delete(idx.bySelector[key], entryID)
+		if len(idx.bySelector[key]) == 0 {
+			delete(idx.bySelector, key)
+		}
+	}
+}
+
+func (idx *index) setNodeSelectors(spiffeID string, selectors []*common.Selector) {
+	if len(selectors) == 0 {
+		delete(idx.nodeSelectors, spiffeID)
+		return
+	}
+
+	set := make(map[selectorKey]struct{}, len(selectors))
+	for _, s := range selectors {
+		set[selectorKey{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+	idx.nodeSelectors[spiffeID] = set
+}
+
+// authorizedEntries returns the registration entries the given SPIFFE ID is
+// authorized for, i.e. its direct entries and, recursively, the direct
+// entries of their SPIFFE IDs. Direct entries are the entries parented to
+// the SPIFFE ID and, for nodes, the entries whose selectors are a subset of
+// the node selectors. The returned entries are copies, sorted and free of
+// duplicates.
+func (idx *index) authorizedEntries(spiffeID string) []*common.RegistrationEntry {
+	var entries []*common.RegistrationEntry
+	visited := map[string]bool{spiffeID: true}
+	pending := []string{spiffeID}
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+
+		for _, entry := range idx.directEntries(id) {
+			entries = append(entries, entry)
+			if !visited[entry.SpiffeId] {
+				visited[entry.SpiffeId] = true
+				pending = append(pending, entry.SpiffeId)
+			}
+		}
+	}
+	return util.DedupRegistrationEntries(entries)
+}
+
+func (idx *index) directEntries(spiffeID string) []*common.RegistrationEntry {
+	var entries []*common.RegistrationEntry
+	for entryID := range idx.byParentID[spiffeID] {
+		entries = append(entries, idx.entries[entryID])
+	}
+
+	nodeSelectors := idx.nodeSelectors[spiffeID]
+	if len(nodeSelectors) == 0 {
+		return entries
+	}
+
+	// Candidates share at least one selector with the node and match if all
+	// of their selectors are node selectors.
+	candidates := make(map[string]bool)
+	for s := range nodeSelectors {
+		for entryID := range idx.bySelector[s] {
+			if _, ok := candidates[entryID]; ok {
+				continue
+			}
+			candidates[entryID] = isSubset(idx.entries[entryID].Selectors, nodeSelectors)
+		}
+	}
+	for entryID, matches := range candidates {
+		if matches {
+			entries = append(entries, idx.entries[entryID])
+		}
+	}
+	return entries
+}
+
+func isSubset(selectors []*common.Selector, set map[selectorKey]struct{}) bool {
+	for _, s := range selectors {
+		if _, ok := set[selectorKey{Type: s.Type, Value: s.Value}]; !ok {
+			return false
+		}
+	}
+	return true
+}
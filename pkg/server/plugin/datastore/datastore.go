@@ -12,73 +12,124 @@ import (
 	"google.golang.org/grpc"
 )
 
-type AppendBundleRequest = datastore.AppendBundleRequest                           //nolint: golint
-type AppendBundleResponse = datastore.AppendBundleResponse                         //nolint: golint
-type BySelectors = datastore.BySelectors                                           //nolint: golint
-type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior               //nolint: golint
-type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest               //nolint: golint
-type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse             //nolint: golint
-type CreateBundleRequest = datastore.CreateBundleRequest                           //nolint: golint
-type CreateBundleResponse = datastore.CreateBundleResponse                         //nolint: golint
-type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                     //nolint: golint
-type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                   //nolint: golint
-type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest     //nolint: golint
-type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse   //nolint: golint
-type DataStoreClient = datastore.DataStoreClient                                   //nolint: golint
-type DataStoreServer = datastore.DataStoreServer                                   //nolint: golint
-type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest               //nolint: golint
-type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse             //nolint: golint
-type DeleteBundleRequest = datastore.DeleteBundleRequest                           //nolint: golint
-type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                 //nolint: golint
-type DeleteBundleResponse = datastore.DeleteBundleResponse                         //nolint: golint
-type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                     //nolint: golint
-type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                   //nolint: golint
-type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest     //nolint: golint
-type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse   //nolint: golint
-type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                 //nolint: golint
-type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse               //nolint: golint
-type FetchBundleRequest = datastore.FetchBundleRequest                             //nolint: golint
-type FetchBundleResponse = datastore.FetchBundleResponse                           //nolint: golint
-type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                       //nolint: golint
-type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                     //nolint: golint
-type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest       //nolint: golint
-type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse     //nolint: golint
-type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                   //nolint: golint
-type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                 //nolint: golint
-type JoinToken = datastore.JoinToken                                               //nolint: golint
-type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                 //nolint: golint
-type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse               //nolint: golint
-type ListBundlesRequest = datastore.ListBundlesRequest                             //nolint: golint
-type ListBundlesResponse = datastore.ListBundlesResponse                           //nolint: golint
-type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest     //nolint: golint
-type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse   //nolint: golint
-type NodeSelectors = datastore.NodeSelectors                                       //nolint: golint
-type Pagination = datastore.Pagination                                             //nolint: golint
-type PruneBundleRequest = datastore.PruneBundleRequest                             //nolint: golint
-type PruneBundleResponse = datastore.PruneBundleResponse                           //nolint: golint
-type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                     //nolint: golint
-type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                   //nolint: golint
-type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest   //nolint: golint
-type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse //nolint: golint
-type SetBundleRequest = datastore.SetBundleRequest                                 //nolint: golint
-type SetBundleResponse = datastore.SetBundleResponse                               //nolint: golint
-type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                   //nolint: golint
-type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                 //nolint: golint
-type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer         //nolint: golint
-type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest               //nolint: golint
-type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse             //nolint: golint
-type UpdateBundleRequest = datastore.UpdateBundleRequest                           //nolint: golint
-type UpdateBundleResponse = datastore.UpdateBundleResponse                         //nolint: golint
-type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest     //nolint: golint
-type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse   //nolint: golint
+type AppendBundleRequest = datastore.AppendBundleRequest                                                   //nolint: golint
+type AttestedNodeMask = datastore.AttestedNodeMask                                                         //nolint: golint
+type AppendBundleResponse = datastore.AppendBundleResponse                                                 //nolint: golint
+type ByFederatesWith = datastore.ByFederatesWith                                                           //nolint: golint
+type ByFederatesWith_MatchBehavior = datastore.ByFederatesWith_MatchBehavior                               //nolint: golint
+type BySelectors = datastore.BySelectors                                                                   //nolint: golint
+type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior                                       //nolint: golint
+type CAJournal = datastore.CAJournal                                                                       //nolint: golint
+type ChangeEvent = datastore.ChangeEvent                                                                   //nolint: golint
+type ChangeEvent_ResourceType = datastore.ChangeEvent_ResourceType                                         //nolint: golint
+type ChangeEvent_Type = datastore.ChangeEvent_Type                                                         //nolint: golint
+type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest                                       //nolint: golint
+type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse                                     //nolint: golint
+type CreateBundleRequest = datastore.CreateBundleRequest                                                   //nolint: golint
+type CreateBundleResponse = datastore.CreateBundleResponse                                                 //nolint: golint
+type CreateFederationRelationshipRequest = datastore.CreateFederationRelationshipRequest                   //nolint: golint
+type CreateFederationRelationshipResponse = datastore.CreateFederationRelationshipResponse                 //nolint: golint
+type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                                             //nolint: golint
+type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                                           //nolint: golint
+type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest                             //nolint: golint
+type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse                           //nolint: golint
+type DataStoreClient = datastore.DataStoreClient                                                           //nolint: golint
+type DataStoreServer = datastore.DataStoreServer                                                           //nolint: golint
+type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest                                       //nolint: golint
+type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse                                     //nolint: golint
+type DeleteBundleRequest = datastore.DeleteBundleRequest                                                   //nolint: golint
+type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                                         //nolint: golint
+type DeleteBundleResponse = datastore.DeleteBundleResponse                                                 //nolint: golint
+type DeleteFederationRelationshipRequest = datastore.DeleteFederationRelationshipRequest                   //nolint: golint
+type DeleteFederationRelationshipResponse = datastore.DeleteFederationRelationshipResponse                 //nolint: golint
+type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                                             //nolint: golint
+type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                                           //nolint: golint
+type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest                             //nolint: golint
+type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse                           //nolint: golint
+type FederationRelationship = datastore.FederationRelationship                                             //nolint: golint
+type FederationRelationship_BundleEndpointProfile = datastore.FederationRelationship_BundleEndpointProfile //nolint: golint
+type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                                         //nolint: golint
+type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse                                       //nolint: golint
+type FetchBundleRequest = datastore.FetchBundleRequest                                                     //nolint: golint
+type FetchBundleResponse = datastore.FetchBundleResponse                                                   //nolint: golint
+type FetchCAJournalRequest = datastore.FetchCAJournalRequest                                               //nolint: golint
+type FetchCAJournalResponse = datastore.FetchCAJournalResponse                                             //nolint: golint
+type FetchFederationRelationshipRequest = datastore.FetchFederationRelationshipRequest                     //nolint: golint
+type FetchFederationRelationshipResponse = datastore.FetchFederationRelationshipResponse                   //nolint: golint
+type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                                               //nolint: golint
+type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                                             //nolint: golint
+type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest                               //nolint: golint
+type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse                             //nolint: golint
+type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                                           //nolint: golint
+type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                                         //nolint: golint
+type JoinToken = datastore.JoinToken                                                                       //nolint: golint
+type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                                         //nolint: golint
+type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse                                       //nolint: golint
+type ListBundlesRequest = datastore.ListBundlesRequest                                                     //nolint: golint
+type ListBundlesResponse = datastore.ListBundlesResponse                                                   //nolint: golint
+type ListFederationRelationshipsRequest = datastore.ListFederationRelationshipsRequest                     //nolint: golint
+type ListFederationRelationshipsResponse = datastore.ListFederationRelationshipsResponse                   //nolint: golint
+type ListChangeEventsRequest = datastore.ListChangeEventsRequest                                           //nolint: golint
+type ListChangeEventsResponse = datastore.ListChangeEventsResponse                                         //nolint: golint
+type ListJoinTokensRequest = datastore.ListJoinTokensRequest                                               //nolint: golint
+type ListJoinTokensResponse = datastore.ListJoinTokensResponse                                             //nolint: golint
+type ListNodeSelectorsRequest = datastore.ListNodeSelectorsRequest                                         //nolint: golint
+type ListNodeSelectorsResponse = datastore.ListNodeSelectorsResponse                                       //nolint: golint
+type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest                             //nolint: golint
+type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse                           //nolint: golint
+type NodeSelectors = datastore.NodeSelectors                                                               //nolint: golint
+type Pagination = datastore.Pagination                                                                     //nolint: golint
+type PruneBundleRequest = datastore.PruneBundleRequest                                                     //nolint: golint
+type PruneBundleResponse = datastore.PruneBundleResponse                                                   //nolint: golint
+type PruneChangeEventsRequest = datastore.PruneChangeEventsRequest                                         //nolint: golint
+type PruneChangeEventsResponse = datastore.PruneChangeEventsResponse                                       //nolint: golint
+type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                                             //nolint: golint
+type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                                           //nolint: golint
+type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest                           //nolint: golint
+type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse                         //nolint: golint
+type RevokeJWTKeyRequest = datastore.RevokeJWTKeyRequest                                                   //nolint: golint
+type RevokeJWTKeyResponse = datastore.RevokeJWTKeyResponse                                                 //nolint: golint
+type RevokeX509CARequest = datastore.RevokeX509CARequest                                                   //nolint: golint
+type RevokeX509CAResponse = datastore.RevokeX509CAResponse                                                 //nolint: golint
+type SetBundleRequest = datastore.SetBundleRequest                                                         //nolint: golint
+type SetBundleResponse = datastore.SetBundleResponse                                                       //nolint: golint
+type SetCAJournalRequest = datastore.SetCAJournalRequest                                                   //nolint: golint
+type SetCAJournalResponse = datastore.SetCAJournalResponse                                                 //nolint: golint
+type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                                           //nolint: golint
+type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                                         //nolint: golint
+type TaintJWTKeyRequest = datastore.TaintJWTKeyRequest                                                     //nolint: golint
+type TaintJWTKeyResponse = datastore.TaintJWTKeyResponse                                                   //nolint: golint
+type TaintX509CARequest = datastore.TaintX509CARequest                                                     //nolint: golint
+type TaintX509CAResponse = datastore.TaintX509CAResponse                                                   //nolint: golint
+type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer                                 //nolint: golint
+type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest                                       //nolint: golint
+type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse                                     //nolint: golint
+type UpdateBundleRequest = datastore.UpdateBundleRequest                                                   //nolint: golint
+type UpdateBundleResponse = datastore.UpdateBundleResponse                                                 //nolint: golint
+type UpdateFederationRelationshipRequest = datastore.UpdateFederationRelationshipRequest                   //nolint: golint
+type UpdateFederationRelationshipResponse = datastore.UpdateFederationRelationshipResponse                 //nolint: golint
+type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest                             //nolint: golint
+type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse                           //nolint: golint
 
 const (
-	Type                           = "DataStore"
-	BySelectors_MATCH_EXACT        = datastore.BySelectors_MATCH_EXACT        //nolint: golint
-	BySelectors_MATCH_SUBSET       = datastore.BySelectors_MATCH_SUBSET       //nolint: golint
-	DeleteBundleRequest_DELETE     = datastore.DeleteBundleRequest_DELETE     //nolint: golint
-	DeleteBundleRequest_DISSOCIATE = datastore.DeleteBundleRequest_DISSOCIATE //nolint: golint
-	DeleteBundleRequest_RESTRICT   = datastore.DeleteBundleRequest_RESTRICT   //nolint: golint
+	Type                                = "DataStore"
+	ByFederatesWith_MATCH_ALL           = datastore.ByFederatesWith_MATCH_ALL           //nolint: golint
+	ByFederatesWith_MATCH_ANY           = datastore.ByFederatesWith_MATCH_ANY           //nolint: golint
+	BySelectors_MATCH_ANY               = datastore.BySelectors_MATCH_ANY               //nolint: golint
+	BySelectors_MATCH_EXACT             = datastore.BySelectors_MATCH_EXACT             //nolint: golint
+	BySelectors_MATCH_SUBSET            = datastore.BySelectors_MATCH_SUBSET            //nolint: golint
+	BySelectors_MATCH_SUPERSET          = datastore.BySelectors_MATCH_SUPERSET          //nolint: golint
+	ChangeEvent_ATTESTED_NODE           = datastore.ChangeEvent_ATTESTED_NODE           //nolint: golint
+	ChangeEvent_BUNDLE                  = datastore.ChangeEvent_BUNDLE                  //nolint: golint
+	ChangeEvent_CREATED                 = datastore.ChangeEvent_CREATED                 //nolint: golint
+	ChangeEvent_DELETED                 = datastore.ChangeEvent_DELETED                 //nolint: golint
+	ChangeEvent_REGISTRATION_ENTRY      = datastore.ChangeEvent_REGISTRATION_ENTRY      //nolint: golint
+	ChangeEvent_UPDATED                 = datastore.ChangeEvent_UPDATED                 //nolint: golint
+	DeleteBundleRequest_DELETE          = datastore.DeleteBundleRequest_DELETE          //nolint: golint
+	DeleteBundleRequest_DISSOCIATE      = datastore.DeleteBundleRequest_DISSOCIATE      //nolint: golint
+	DeleteBundleRequest_RESTRICT        = datastore.DeleteBundleRequest_RESTRICT        //nolint: golint
+	FederationRelationship_HTTPS_SPIFFE = datastore.FederationRelationship_HTTPS_SPIFFE //nolint: golint
+	FederationRelationship_HTTPS_WEB    = datastore.FederationRelationship_HTTPS_WEB    //nolint: golint
 )
 
 // DataStore is the client interface for the service type DataStore interface.
@@ -86,27 +137,42 @@ type DataStore interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	ListChangeEvents(context.Context, *ListChangeEventsRequest) (*ListChangeEventsResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneChangeEvents(context.Context, *PruneChangeEventsRequest) (*PruneChangeEventsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	RevokeJWTKey(context.Context, *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error)
+	RevokeX509CA(context.Context, *RevokeX509CARequest) (*RevokeX509CAResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	TaintJWTKey(context.Context, *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error)
+	TaintX509CA(context.Context, *TaintX509CARequest) (*TaintX509CAResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 }
 
@@ -116,28 +182,43 @@ type Plugin interface {
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	ListChangeEvents(context.Context, *ListChangeEventsRequest) (*ListChangeEventsResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneChangeEvents(context.Context, *PruneChangeEventsRequest) (*PruneChangeEventsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	RevokeJWTKey(context.Context, *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error)
+	RevokeX509CA(context.Context, *RevokeX509CARequest) (*RevokeX509CAResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	TaintJWTKey(context.Context, *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error)
+	TaintX509CA(context.Context, *TaintX509CARequest) (*TaintX509CAResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 }
 
@@ -202,6 +283,10 @@ func (a pluginClientAdapter) CreateBundle(ctx context.Context, in *CreateBundleR
 	return a.client.CreateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return a.client.CreateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return a.client.CreateJoinToken(ctx, in)
 }
@@ -218,6 +303,10 @@ func (a pluginClientAdapter) DeleteBundle(ctx context.Context, in *DeleteBundleR
 	return a.client.DeleteBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return a.client.DeleteFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return a.client.DeleteJoinToken(ctx, in)
 }
@@ -234,6 +323,14 @@ func (a pluginClientAdapter) FetchBundle(ctx context.Context, in *FetchBundleReq
 	return a.client.FetchBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return a.client.FetchCAJournal(ctx, in)
+}
+
+func (a pluginClientAdapter) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return a.client.FetchFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return a.client.FetchJoinToken(ctx, in)
 }
@@ -258,6 +355,22 @@ func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesReq
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return a.client.ListFederationRelationships(ctx, in)
+}
+
+func (a pluginClientAdapter) ListChangeEvents(ctx context.Context, in *ListChangeEventsRequest) (*ListChangeEventsResponse, error) {
+	return a.client.ListChangeEvents(ctx, in)
+}
+
+func (a pluginClientAdapter) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return a.client.ListJoinTokens(ctx, in)
+}
+
+func (a pluginClientAdapter) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
+	return a.client.ListNodeSelectors(ctx, in)
+}
+
 func (a pluginClientAdapter) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
 	return a.client.ListRegistrationEntries(ctx, in)
 }
@@ -266,6 +379,10 @@ func (a pluginClientAdapter) PruneBundle(ctx context.Context, in *PruneBundleReq
 	return a.client.PruneBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneChangeEvents(ctx context.Context, in *PruneChangeEventsRequest) (*PruneChangeEventsResponse, error) {
+	return a.client.PruneChangeEvents(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return a.client.PruneJoinTokens(ctx, in)
 }
@@ -274,14 +391,34 @@ func (a pluginClientAdapter) PruneRegistrationEntries(ctx context.Context, in *P
 	return a.client.PruneRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) RevokeJWTKey(ctx context.Context, in *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error) {
+	return a.client.RevokeJWTKey(ctx, in)
+}
+
+func (a pluginClientAdapter) RevokeX509CA(ctx context.Context, in *RevokeX509CARequest) (*RevokeX509CAResponse, error) {
+	return a.client.RevokeX509CA(ctx, in)
+}
+
 func (a pluginClientAdapter) SetBundle(ctx context.Context, in *SetBundleRequest) (*SetBundleResponse, error) {
 	return a.client.SetBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) SetCAJournal(ctx context.Context, in *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return a.client.SetCAJournal(ctx, in)
+}
+
 func (a pluginClientAdapter) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return a.client.SetNodeSelectors(ctx, in)
 }
 
+func (a pluginClientAdapter) TaintJWTKey(ctx context.Context, in *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error) {
+	return a.client.TaintJWTKey(ctx, in)
+}
+
+func (a pluginClientAdapter) TaintX509CA(ctx context.Context, in *TaintX509CARequest) (*TaintX509CAResponse, error) {
+	return a.client.TaintX509CA(ctx, in)
+}
+
 func (a pluginClientAdapter) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
 	return a.client.UpdateAttestedNode(ctx, in)
 }
@@ -290,6 +427,10 @@ func (a pluginClientAdapter) UpdateBundle(ctx context.Context, in *UpdateBundleR
 	return a.client.UpdateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return a.client.UpdateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return a.client.UpdateRegistrationEntry(ctx, in)
 }
@@ -0,0 +1,487 @@
+package datastoretest
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	testutil "github.com/spiffe/spire/test/util"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *conformanceSuite) TestBundleCRUD() {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}},
+	}
+
+	// fetching, updating or deleting a missing bundle
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Bundle)
+
+	_, err = s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: bundle})
+	s.requireCode(err, codes.NotFound)
+
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.requireCode(err, codes.NotFound)
+
+	// create
+	cresp, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, cresp.Bundle)
+
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().Error(err, "creating a bundle twice must fail")
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, fresp.Bundle)
+
+	// update
+	updated := &common.Bundle{
+		TrustDomainId:  bundle.TrustDomainId,
+		RootCas:        []*common.Certificate{{DerBytes: []byte("bar")}},
+		JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key"), NotAfter: 1000}},
+	}
+	uresp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: updated})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, uresp.Bundle)
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, fresp.Bundle)
+
+	// delete
+	dresp, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, dresp.Bundle)
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Bundle)
+}
+
+func (s *conformanceSuite) TestSetBundle() {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}},
+	}
+
+	// set creates the bundle if it does not exist
+	resp, err := s.ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, resp.Bundle)
+
+	// and replaces its contents otherwise
+	replaced := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("bar")}},
+	}
+	resp, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: replaced})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(replaced, resp.Bundle)
+
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(replaced, fresp.Bundle)
+}
+
+func (s *conformanceSuite) TestAppendBundle() {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}},
+	}
+
+	// append creates the bundle if it does not exist
+	resp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, resp.Bundle)
+
+	// and merges the contents otherwise, without duplicating what the
+	// bundle already holds
+	resp, err = s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  "spiffe://example.org",
+			RootCas:        []*common.Certificate{{DerBytes: []byte("foo")}, {DerBytes: []byte("bar")}},
+			JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key"), NotAfter: 1000}},
+		},
+	})
+	s.Require().NoError(err)
+	expected := &common.Bundle{
+		TrustDomainId:  "spiffe://example.org",
+		RootCas:        []*common.Certificate{{DerBytes: []byte("foo")}, {DerBytes: []byte("bar")}},
+		JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("key"), NotAfter: 1000}},
+	}
+	s.RequireProtoEqual(expected, resp.Bundle)
+
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(expected, fresp.Bundle)
+}
+
+func (s *conformanceSuite) TestListBundles() {
+	resp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Bundles)
+
+	bundles := []*common.Bundle{
+		s.createBundle("spiffe://a.org"),
+		s.createBundle("spiffe://b.org"),
+		s.createBundle("spiffe://c.org"),
+	}
+
+	resp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	requireBundlesEqual(s.T(), bundles, resp.Bundles)
+}
+
+func (s *conformanceSuite) TestListBundlesWithPagination() {
+	bundles := []*common.Bundle{
+		s.createBundle("spiffe://a.org"),
+		s.createBundle("spiffe://b.org"),
+		s.createBundle("spiffe://c.org"),
+	}
+
+	_, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.requireCode(err, codes.InvalidArgument)
+
+	var listed []*common.Bundle
+	var sizes []int
+	pagination := &datastore.Pagination{PageSize: 2}
+	for {
+		req := &datastore.ListBundlesRequest{
+			Pagination: &datastore.Pagination{
+				PageSize: pagination.PageSize,
+				Token:    pagination.Token,
+			},
+		}
+		resp, err := s.ds.ListBundles(ctx, req)
+		s.Require().NoError(err)
+		requirePage(s.T(), req.Pagination, resp.Pagination, len(resp.Bundles))
+		sizes = append(sizes, len(resp.Bundles))
+		if len(resp.Bundles) == 0 {
+			break
+		}
+		listed = append(listed, resp.Bundles...)
+		pagination = resp.Pagination
+	}
+	s.Require().Equal([]int{2, 1, 0}, sizes)
+	requireBundlesEqual(s.T(), bundles, listed)
+}
+
+func (s *conformanceSuite) TestPruneBundle() {
+	now := time.Now().Truncate(time.Second)
+	expired := s.newCACertificate(now.Add(-time.Hour))
+	valid := s.newCACertificate(now.Add(time.Hour))
+
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: expired.Raw},
+			{DerBytes: valid.Raw},
+		},
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "expired", PkixBytes: []byte("expired"), NotAfter: now.Add(-time.Hour).Unix()},
+			{Kid: "valid", PkixBytes: []byte("valid"), NotAfter: now.Add(time.Hour).Unix()},
+		},
+	}
+
+	// pruning a missing bundle is not an error
+	resp, err := s.ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		ExpiresBefore: now.Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().False(resp.BundleChanged)
+
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	// nothing expired before the given time
+	resp, err = s.ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		ExpiresBefore: now.Add(-2 * time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().False(resp.BundleChanged)
+
+	resp, err = s.ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		ExpiresBefore: now.Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().True(resp.BundleChanged)
+
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.Bundle{
+		TrustDomainId:  bundle.TrustDomainId,
+		RootCas:        []*common.Certificate{{DerBytes: valid.Raw}},
+		JwtSigningKeys: []*common.PublicKey{bundle.JwtSigningKeys[1]},
+	}, fresp.Bundle)
+
+	// pruning everything is refused
+	_, err = s.ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		ExpiresBefore: now.Add(2 * time.Hour).Unix(),
+	})
+	s.Require().Error(err)
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.Require().Len(fresp.Bundle.RootCas, 1)
+}
+
+func (s *conformanceSuite) TestTaintAndRevokeX509CA() {
+	now := time.Now().Truncate(time.Second)
+	old := s.newCACertificateWithSubjectKeyID(now.Add(time.Hour), []byte("old"))
+	current := s.newCACertificateWithSubjectKeyID(now.Add(time.Hour), []byte("current"))
+
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: old.Raw},
+			{DerBytes: current.Raw},
+		},
+	}
+
+	// tainting a CA in a missing bundle
+	_, err := s.ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  old.SubjectKeyId,
+	})
+	s.requireCode(err, codes.NotFound)
+
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	// tainting an unknown CA
+	_, err = s.ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  []byte("unknown"),
+	})
+	s.requireCode(err, codes.NotFound)
+
+	// revoking a CA that is not tainted
+	_, err = s.ds.RevokeX509CA(ctx, &datastore.RevokeX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  old.SubjectKeyId,
+	})
+	s.requireCode(err, codes.FailedPrecondition)
+
+	_, err = s.ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  old.SubjectKeyId,
+	})
+	s.Require().NoError(err)
+
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.Bundle{
+		TrustDomainId: bundle.TrustDomainId,
+		RootCas: []*common.Certificate{
+			{DerBytes: old.Raw, TaintedKey: true},
+			{DerBytes: current.Raw},
+		},
+	}, fresp.Bundle)
+
+	// tainting it again
+	_, err = s.ds.TaintX509CA(ctx, &datastore.TaintX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  old.SubjectKeyId,
+	})
+	s.requireCode(err, codes.FailedPrecondition)
+
+	// appending the untainted CA doesn't duplicate it
+	_, err = s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	_, err = s.ds.RevokeX509CA(ctx, &datastore.RevokeX509CARequest{
+		TrustDomainId: bundle.TrustDomainId,
+		SubjectKeyId:  old.SubjectKeyId,
+	})
+	s.Require().NoError(err)
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.Bundle{
+		TrustDomainId: bundle.TrustDomainId,
+		RootCas:       []*common.Certificate{{DerBytes: current.Raw}},
+	}, fresp.Bundle)
+}
+
+func (s *conformanceSuite) TestTaintAndRevokeJWTKey() {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}},
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "old", PkixBytes: []byte("old")},
+			{Kid: "current", PkixBytes: []byte("current")},
+		},
+	}
+
+	// tainting a key in a missing bundle
+	_, err := s.ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "old",
+	})
+	s.requireCode(err, codes.NotFound)
+
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	// tainting an unknown key
+	_, err = s.ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "unknown",
+	})
+	s.requireCode(err, codes.NotFound)
+
+	// revoking a key that is not tainted
+	_, err = s.ds.RevokeJWTKey(ctx, &datastore.RevokeJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "old",
+	})
+	s.requireCode(err, codes.FailedPrecondition)
+
+	_, err = s.ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "old",
+	})
+	s.Require().NoError(err)
+
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.Bundle{
+		TrustDomainId: bundle.TrustDomainId,
+		RootCas:       bundle.RootCas,
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "old", PkixBytes: []byte("old"), TaintedKey: true},
+			{Kid: "current", PkixBytes: []byte("current")},
+		},
+	}, fresp.Bundle)
+
+	// tainting it again
+	_, err = s.ds.TaintJWTKey(ctx, &datastore.TaintJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "old",
+	})
+	s.requireCode(err, codes.FailedPrecondition)
+
+	_, err = s.ds.RevokeJWTKey(ctx, &datastore.RevokeJWTKeyRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		KeyId:         "old",
+	})
+	s.Require().NoError(err)
+
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.Bundle{
+		TrustDomainId:  bundle.TrustDomainId,
+		RootCas:        bundle.RootCas,
+		JwtSigningKeys: []*common.PublicKey{{Kid: "current", PkixBytes: []byte("current")}},
+	}, fresp.Bundle)
+}
+
+func (s *conformanceSuite) TestDeleteBundleRestrictedByDefault() {
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+	})
+
+	_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().Error(err)
+
+	// neither the bundle nor the entry were changed
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://otherdomain.org"})
+	s.Require().NoError(err)
+	s.Require().NotNil(fresp.Bundle)
+	s.RequireProtoEqual(entry, s.fetchRegistrationEntry(entry.EntryId))
+}
+
+func (s *conformanceSuite) TestDeleteBundleDeletesFederatedEntries() {
+	s.createBundle("spiffe://a.org")
+	s.createBundle("spiffe://b.org")
+	federated := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		FederatesWith: []string{"spiffe://a.org", "spiffe://b.org"},
+	})
+	unrelated := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/bar",
+		ParentId:  "spiffe://example.org/parent",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+
+	_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://a.org",
+		Mode:          datastore.DeleteBundleRequest_DELETE,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Nil(s.fetchRegistrationEntry(federated.EntryId))
+	s.RequireProtoEqual(unrelated, s.fetchRegistrationEntry(unrelated.EntryId))
+
+	// the deleted entry no longer restricts the deletion of the other bundle
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://b.org",
+		Mode:          datastore.DeleteBundleRequest_RESTRICT,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *conformanceSuite) TestDeleteBundleDissociatesFederatedEntries() {
+	s.createBundle("spiffe://a.org")
+	s.createBundle("spiffe://b.org")
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		FederatesWith: []string{"spiffe://a.org", "spiffe://b.org"},
+	})
+
+	_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://a.org",
+		Mode:          datastore.DeleteBundleRequest_DISSOCIATE,
+	})
+	s.Require().NoError(err)
+
+	fetched := s.fetchRegistrationEntry(entry.EntryId)
+	s.Require().NotNil(fetched)
+	s.Require().Equal([]string{"spiffe://b.org"}, fetched.FederatesWith)
+
+	// recreating the bundle does not restore the association
+	s.createBundle("spiffe://a.org")
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://a.org",
+		Mode:          datastore.DeleteBundleRequest_RESTRICT,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *conformanceSuite) newCACertificate(notAfter time.Time) *x509.Certificate {
+	return s.newCACertificateWithSubjectKeyID(notAfter, nil)
+}
+
+func (s *conformanceSuite) newCACertificateWithSubjectKeyID(notAfter time.Time, subjectKeyID []byte) *x509.Certificate {
+	template, err := testutil.NewCATemplate(clock.NewMock(s.T()), "example.org")
+	s.Require().NoError(err)
+	template.NotBefore = notAfter.Add(-24 * time.Hour)
+	template.NotAfter = notAfter
+	template.SubjectKeyId = subjectKeyID
+
+	cert, _, err := testutil.SelfSign(template)
+	s.Require().NoError(err)
+	return cert
+}
@@ -0,0 +1,176 @@
+// Package datastoretest provides a conformance test suite for DataStore
+// implementations. The suite exercises every DataStore RPC and asserts the
+// behavior of the built-in sql plugin, so third-party plugins can verify
+// that they can be used in its place.
+//
+// The suite does not depend on implementation details: it treats pagination
+// tokens as opaque, does not assume any ordering for list results and only
+// asserts error codes (not messages) for errors clients are expected to act
+// upon.
+package datastoretest
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx = context.Background()
+)
+
+// Maker returns a new, empty datastore that is ready to use. It is called
+// once for each test in the suite. Resources held by the datastore should be
+// released with t.Cleanup.
+type Maker func(t *testing.T) datastore.DataStore
+
+// Run runs the conformance suite against the datastores returned by the maker
+func Run(t *testing.T, maker Maker) {
+	spiretest.Run(t, &conformanceSuite{maker: maker})
+}
+
+type conformanceSuite struct {
+	spiretest.Suite
+
+	maker Maker
+	ds    datastore.DataStore
+}
+
+func (s *conformanceSuite) SetupTest() {
+	s.ds = s.maker(s.T())
+}
+
+func (s *conformanceSuite) createBundle(trustDomainID string) *common.Bundle {
+	bundle := &common.Bundle{
+		TrustDomainId: trustDomainID,
+		RootCas:       []*common.Certificate{{DerBytes: []byte(trustDomainID)}},
+	}
+	resp, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: bundle,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, resp.Bundle)
+	return resp.Bundle
+}
+
+func (s *conformanceSuite) createAttestedNode(node *common.AttestedNode) *common.AttestedNode {
+	resp, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, resp.Node)
+	return resp.Node
+}
+
+func (s *conformanceSuite) setNodeSelectors(spiffeID string, selectors ...*common.Selector) {
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: selectors,
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *conformanceSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Entry.EntryId)
+	return resp.Entry
+}
+
+func (s *conformanceSuite) fetchRegistrationEntry(entryID string) *common.RegistrationEntry {
+	resp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	s.Require().NoError(err)
+	return resp.Entry
+}
+
+// requireCode asserts that the error is a gRPC status with the given code.
+// Messages are implementation specific and are not asserted.
+func (s *conformanceSuite) requireCode(err error, code codes.Code) {
+	s.T().Helper()
+	s.Require().Error(err)
+	s.Require().Equal(code, status.Code(err), "unexpected status code for error: %v", err)
+}
+
+// requirePage asserts that a page of a paginated listing holds at most
+// the requested number of items and that the returned token is only empty
+// when the page is.
+func requirePage(t *testing.T, req, resp *datastore.Pagination, n int) {
+	require.NotNil(t, resp, "pagination missing from paginated response")
+	require.Equal(t, req.PageSize, resp.PageSize)
+	require.True(t, n <= int(req.PageSize), "page holds %d items; page size is %d", n, req.PageSize)
+	if n == 0 {
+		require.Empty(t, resp.Token, "token must be empty once the listing is exhausted")
+	} else {
+		require.NotEmpty(t, resp.Token, "token must be set on non-empty pages")
+	}
+}
+
+// The following helpers compare lists without regard for their order
+
+func requireBundlesEqual(t *testing.T, expected, actual []*common.Bundle) {
+	sortBundles := func(bundles []*common.Bundle) []*common.Bundle {
+		bundles = append([]*common.Bundle(nil), bundles...)
+		sort.Slice(bundles, func(i, j int) bool {
+			return bundles[i].TrustDomainId < bundles[j].TrustDomainId
+		})
+		return bundles
+	}
+	spiretest.RequireProtoListEqual(t, sortBundles(expected), sortBundles(actual))
+}
+
+func requireAttestedNodesEqual(t *testing.T, expected, actual []*common.AttestedNode) {
+	sortNodes := func(nodes []*common.AttestedNode) []*common.AttestedNode {
+		nodes = append([]*common.AttestedNode(nil), nodes...)
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].SpiffeId < nodes[j].SpiffeId
+		})
+		return nodes
+	}
+	spiretest.RequireProtoListEqual(t, sortNodes(expected), sortNodes(actual))
+}
+
+func requireRegistrationEntriesEqual(t *testing.T, expected, actual []*common.RegistrationEntry) {
+	sortEntries := func(entries []*common.RegistrationEntry) []*common.RegistrationEntry {
+		entries = append([]*common.RegistrationEntry(nil), entries...)
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].EntryId < entries[j].EntryId
+		})
+		return entries
+	}
+	spiretest.RequireProtoListEqual(t, sortEntries(expected), sortEntries(actual))
+}
+
+func requireJoinTokensEqual(t *testing.T, expected, actual []*datastore.JoinToken) {
+	sortTokens := func(tokens []*datastore.JoinToken) []*datastore.JoinToken {
+		tokens = append([]*datastore.JoinToken(nil), tokens...)
+		sort.Slice(tokens, func(i, j int) bool {
+			return tokens[i].Token < tokens[j].Token
+		})
+		return tokens
+	}
+	spiretest.RequireProtoListEqual(t, sortTokens(expected), sortTokens(actual))
+}
+
+func requireFederationRelationshipsEqual(t *testing.T, expected, actual []*datastore.FederationRelationship) {
+	sortRelationships := func(relationships []*datastore.FederationRelationship) []*datastore.FederationRelationship {
+		relationships = append([]*datastore.FederationRelationship(nil), relationships...)
+		sort.Slice(relationships, func(i, j int) bool {
+			return relationships[i].TrustDomainId < relationships[j].TrustDomainId
+		})
+		return relationships
+	}
+	spiretest.RequireProtoListEqual(t, sortRelationships(expected), sortRelationships(actual))
+}
@@ -0,0 +1,498 @@
+package datastoretest
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *conformanceSuite) TestRegistrationEntryCRUD() {
+	s.createBundle("spiffe://otherdomain.org")
+
+	entry := &common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+		Ttl:           60,
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		Admin:         true,
+		Downstream:    true,
+		EntryExpiry:   1000,
+		DnsNames:      []string{"foo", "bar"},
+		X509SvidProfile: &common.X509SVIDProfile{
+			Subject: &common.X509Subject{
+				Organization: []string{"Example"},
+				CommonName:   "foo",
+			},
+			IpAddresses:  []string{"10.0.0.1"},
+			ExtKeyUsages: []string{"serverAuth"},
+		},
+		JwtSvidTtl: 30,
+	}
+
+	// create
+	created := s.createRegistrationEntry(entry)
+	expected := proto.Clone(entry).(*common.RegistrationEntry)
+	expected.EntryId = created.EntryId
+	s.RequireProtoEqual(expected, created)
+
+	// entry IDs are unique
+	other := s.createRegistrationEntry(entry)
+	s.Require().NotEqual(created.EntryId, other.EntryId)
+
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(created.EntryId))
+	s.Require().Nil(s.fetchRegistrationEntry("missing"))
+
+	// update
+	expected.ParentId = "spiffe://example.org/other-parent"
+	expected.Selectors = []*common.Selector{{Type: "c", Value: "3"}}
+	expected.Ttl = 120
+	expected.FederatesWith = nil
+	expected.Admin = false
+	expected.Downstream = false
+	expected.EntryExpiry = 2000
+	expected.DnsNames = []string{"baz"}
+	expected.X509SvidProfile = nil
+	expected.JwtSvidTtl = 90
+	uresp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: expected,
+	})
+	s.Require().NoError(err)
+	expected.RevisionNumber = 1
+	s.RequireProtoEqual(expected, uresp.Entry)
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(created.EntryId))
+
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:   "missing",
+			SpiffeId:  "spiffe://example.org/foo",
+			ParentId:  "spiffe://example.org/parent",
+			Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+		},
+	})
+	s.requireCode(err, codes.NotFound)
+
+	// delete
+	dresp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: created.EntryId,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(expected, dresp.Entry)
+	s.Require().Nil(s.fetchRegistrationEntry(created.EntryId))
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: created.EntryId,
+	})
+	s.requireCode(err, codes.NotFound)
+}
+
+func (s *conformanceSuite) TestUpdateRegistrationEntryWithMask() {
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/parent",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+		Ttl:       60,
+		DnsNames:  []string{"foo"},
+	})
+
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:       entry.EntryId,
+			Ttl:           120,
+			FederatesWith: []string{"spiffe://otherdomain.org"},
+			DnsNames:      []string{"bar"},
+			JwtSvidTtl:    300,
+		},
+		InputMask: &common.RegistrationEntryMask{
+			Ttl:           true,
+			FederatesWith: true,
+		},
+	})
+	s.Require().NoError(err)
+
+	expected := proto.Clone(entry).(*common.RegistrationEntry)
+	expected.Ttl = 120
+	expected.FederatesWith = []string{"spiffe://otherdomain.org"}
+	expected.RevisionNumber = 1
+	s.RequireProtoEqual(expected, resp.Entry)
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(entry.EntryId))
+
+	// federating with an unknown trust domain fails and leaves the entry
+	// untouched
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:       entry.EntryId,
+			FederatesWith: []string{"spiffe://unknown.org"},
+		},
+		InputMask: &common.RegistrationEntryMask{
+			FederatesWith: true,
+		},
+	})
+	s.Require().Error(err)
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(entry.EntryId))
+
+	// the entry still restricts the deletion of the bundle it federates with
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().Error(err)
+}
+
+func (s *conformanceSuite) TestRegistrationEntryRevisionNumber() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/parent",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+	s.Require().Zero(entry.RevisionNumber)
+
+	update := func(ttl int32, expectedRevision *wrappers.Int64Value) (*common.RegistrationEntry, error) {
+		resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+			Entry: &common.RegistrationEntry{
+				EntryId: entry.EntryId,
+				Ttl:     ttl,
+			},
+			InputMask:              &common.RegistrationEntryMask{Ttl: true},
+			ExpectedRevisionNumber: expectedRevision,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Entry, nil
+	}
+
+	// every update bumps the revision
+	updated, err := update(10, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), updated.RevisionNumber)
+
+	updated, err = update(20, &wrappers.Int64Value{Value: 1})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), updated.RevisionNumber)
+
+	// stale revisions are refused
+	_, err = update(30, &wrappers.Int64Value{Value: 1})
+	s.requireCode(err, codes.Aborted)
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                entry.EntryId,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 1},
+	})
+	s.requireCode(err, codes.Aborted)
+
+	fetched := s.fetchRegistrationEntry(entry.EntryId)
+	s.Require().NotNil(fetched)
+	s.Require().Equal(int32(20), fetched.Ttl)
+	s.Require().Equal(int64(2), fetched.RevisionNumber)
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                entry.EntryId,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 2},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *conformanceSuite) TestCreateRegistrationEntryWithUnknownFederatedBundle() {
+	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:      "spiffe://example.org/foo",
+			ParentId:      "spiffe://example.org/parent",
+			Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+			FederatesWith: []string{"spiffe://unknown.org"},
+		},
+	})
+	s.Require().Error(err)
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Entries)
+}
+
+func (s *conformanceSuite) TestListRegistrationEntries() {
+	s.createBundle("spiffe://a.org")
+	s.createBundle("spiffe://b.org")
+
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+	c3 := &common.Selector{Type: "c", Value: "3"}
+
+	entryA1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/a1",
+		ParentId:      "spiffe://example.org/parent1",
+		Selectors:     []*common.Selector{a1},
+		FederatesWith: []string{"spiffe://a.org"},
+		DnsNames:      []string{"a1"},
+	})
+	entryA1B2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/a1b2",
+		ParentId:      "spiffe://example.org/parent1",
+		Selectors:     []*common.Selector{a1, b2},
+		FederatesWith: []string{"spiffe://a.org", "spiffe://b.org"},
+		Admin:         true,
+		EntryExpiry:   100,
+	})
+	entryB2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:    "spiffe://example.org/b2",
+		ParentId:    "spiffe://example.org/parent2",
+		Selectors:   []*common.Selector{b2},
+		Downstream:  true,
+		EntryExpiry: 300,
+		DnsNames:    []string{"b2", "a1"},
+	})
+	entryB2C3 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/b2c3",
+		ParentId:  "spiffe://example.org/parent2",
+		Selectors: []*common.Selector{b2, c3},
+	})
+
+	bySelectors := func(match datastore.BySelectors_MatchBehavior, selectors ...*common.Selector) *datastore.BySelectors {
+		return &datastore.BySelectors{
+			Selectors: selectors,
+			Match:     match,
+		}
+	}
+	byFederatesWith := func(match datastore.ByFederatesWith_MatchBehavior, trustDomains ...string) *datastore.ByFederatesWith {
+		return &datastore.ByFederatesWith{
+			TrustDomains: trustDomains,
+			Match:        match,
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		req    *datastore.ListRegistrationEntriesRequest
+		expect []*common.RegistrationEntry
+		code   codes.Code
+	}{
+		{
+			name:   "all",
+			req:    &datastore.ListRegistrationEntriesRequest{},
+			expect: []*common.RegistrationEntry{entryA1, entryA1B2, entryB2, entryB2C3},
+		},
+		{
+			name:   "by parent ID",
+			req:    &datastore.ListRegistrationEntriesRequest{ByParentId: &wrappers.StringValue{Value: "spiffe://example.org/parent2"}},
+			expect: []*common.RegistrationEntry{entryB2, entryB2C3},
+		},
+		{
+			name:   "by SPIFFE ID",
+			req:    &datastore.ListRegistrationEntriesRequest{BySpiffeId: &wrappers.StringValue{Value: "spiffe://example.org/a1b2"}},
+			expect: []*common.RegistrationEntry{entryA1B2},
+		},
+		{
+			name:   "by admin",
+			req:    &datastore.ListRegistrationEntriesRequest{ByAdmin: &wrappers.BoolValue{Value: true}},
+			expect: []*common.RegistrationEntry{entryA1B2},
+		},
+		{
+			name:   "by downstream",
+			req:    &datastore.ListRegistrationEntriesRequest{ByDownstream: &wrappers.BoolValue{Value: true}},
+			expect: []*common.RegistrationEntry{entryB2},
+		},
+		{
+			name:   "by DNS name",
+			req:    &datastore.ListRegistrationEntriesRequest{ByDnsName: &wrappers.StringValue{Value: "a1"}},
+			expect: []*common.RegistrationEntry{entryA1, entryB2},
+		},
+		{
+			name:   "by expires before",
+			req:    &datastore.ListRegistrationEntriesRequest{ByExpiresBefore: &wrappers.Int64Value{Value: 200}},
+			expect: []*common.RegistrationEntry{entryA1B2},
+		},
+		{
+			// entries without an expiry never expire
+			name:   "by expires after",
+			req:    &datastore.ListRegistrationEntriesRequest{ByExpiresAfter: &wrappers.Int64Value{Value: 200}},
+			expect: []*common.RegistrationEntry{entryA1, entryB2, entryB2C3},
+		},
+		{
+			name:   "by selector match exact",
+			req:    &datastore.ListRegistrationEntriesRequest{BySelectors: bySelectors(datastore.BySelectors_MATCH_EXACT, b2)},
+			expect: []*common.RegistrationEntry{entryB2},
+		},
+		{
+			name:   "by selector match subset",
+			req:    &datastore.ListRegistrationEntriesRequest{BySelectors: bySelectors(datastore.BySelectors_MATCH_SUBSET, a1, b2)},
+			expect: []*common.RegistrationEntry{entryA1, entryA1B2, entryB2},
+		},
+		{
+			name:   "by selector match superset",
+			req:    &datastore.ListRegistrationEntriesRequest{BySelectors: bySelectors(datastore.BySelectors_MATCH_SUPERSET, b2)},
+			expect: []*common.RegistrationEntry{entryA1B2, entryB2, entryB2C3},
+		},
+		{
+			name:   "by selector match any",
+			req:    &datastore.ListRegistrationEntriesRequest{BySelectors: bySelectors(datastore.BySelectors_MATCH_ANY, a1, c3)},
+			expect: []*common.RegistrationEntry{entryA1, entryA1B2, entryB2C3},
+		},
+		{
+			name:   "by federates with all",
+			req:    &datastore.ListRegistrationEntriesRequest{ByFederatesWith: byFederatesWith(datastore.ByFederatesWith_MATCH_ALL, "spiffe://a.org", "spiffe://b.org")},
+			expect: []*common.RegistrationEntry{entryA1B2},
+		},
+		{
+			name:   "by federates with any",
+			req:    &datastore.ListRegistrationEntriesRequest{ByFederatesWith: byFederatesWith(datastore.ByFederatesWith_MATCH_ANY, "spiffe://a.org", "spiffe://b.org")},
+			expect: []*common.RegistrationEntry{entryA1, entryA1B2},
+		},
+		{
+			name: "by combined filters",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId:  &wrappers.StringValue{Value: "spiffe://example.org/parent1"},
+				BySelectors: bySelectors(datastore.BySelectors_MATCH_SUPERSET, b2),
+			},
+			expect: []*common.RegistrationEntry{entryA1B2},
+		},
+		{
+			name: "by empty selector set",
+			req:  &datastore.ListRegistrationEntriesRequest{BySelectors: bySelectors(datastore.BySelectors_MATCH_ANY)},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "by empty federates with set",
+			req:  &datastore.ListRegistrationEntriesRequest{ByFederatesWith: byFederatesWith(datastore.ByFederatesWith_MATCH_ANY)},
+			code: codes.InvalidArgument,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, tt.req)
+			if tt.code != codes.OK {
+				require.Error(t, err)
+				require.Equal(t, tt.code, status.Code(err), "unexpected status code for error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			requireRegistrationEntriesEqual(t, tt.expect, resp.Entries)
+		})
+	}
+}
+
+func (s *conformanceSuite) TestListRegistrationEntriesWithPagination() {
+	var entries []*common.RegistrationEntry
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		parentID := "spiffe://example.org/parent1"
+		if name == "b" || name == "d" {
+			parentID = "spiffe://example.org/parent2"
+		}
+		entries = append(entries, s.createRegistrationEntry(&common.RegistrationEntry{
+			SpiffeId:  "spiffe://example.org/" + name,
+			ParentId:  parentID,
+			Selectors: []*common.Selector{{Type: "name", Value: name}},
+		}))
+	}
+
+	_, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.requireCode(err, codes.InvalidArgument)
+
+	for _, tt := range []struct {
+		name     string
+		byParent string
+		pageSize int32
+		expect   []*common.RegistrationEntry
+		sizes    []int
+	}{
+		{
+			name:     "all",
+			pageSize: 2,
+			expect:   entries,
+			sizes:    []int{2, 2, 1, 0},
+		},
+		{
+			name:     "filtered",
+			byParent: "spiffe://example.org/parent1",
+			pageSize: 1,
+			expect:   []*common.RegistrationEntry{entries[0], entries[2], entries[4]},
+			sizes:    []int{1, 1, 1, 0},
+		},
+		{
+			name:     "page larger than results",
+			byParent: "spiffe://example.org/parent2",
+			pageSize: 10,
+			expect:   []*common.RegistrationEntry{entries[1], entries[3]},
+			sizes:    []int{2, 0},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			var listed []*common.RegistrationEntry
+			var sizes []int
+			var token string
+			for {
+				req := &datastore.ListRegistrationEntriesRequest{
+					Pagination: &datastore.Pagination{
+						PageSize: tt.pageSize,
+						Token:    token,
+					},
+				}
+				if tt.byParent != "" {
+					req.ByParentId = &wrappers.StringValue{Value: tt.byParent}
+				}
+				resp, err := s.ds.ListRegistrationEntries(ctx, req)
+				require.NoError(t, err)
+				requirePage(t, req.Pagination, resp.Pagination, len(resp.Entries))
+				sizes = append(sizes, len(resp.Entries))
+				if len(resp.Entries) == 0 {
+					break
+				}
+				listed = append(listed, resp.Entries...)
+				token = resp.Pagination.Token
+			}
+			require.Equal(t, tt.sizes, sizes)
+			requireRegistrationEntriesEqual(t, tt.expect, listed)
+		})
+	}
+}
+
+func (s *conformanceSuite) TestPruneRegistrationEntries() {
+	s.createBundle("spiffe://otherdomain.org")
+	expired := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:      "spiffe://example.org/expired",
+		ParentId:      "spiffe://example.org/parent",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		EntryExpiry:   100,
+	})
+	valid := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:    "spiffe://example.org/valid",
+		ParentId:    "spiffe://example.org/parent",
+		Selectors:   []*common.Selector{{Type: "a", Value: "1"}},
+		EntryExpiry: 300,
+	})
+	neverExpires := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/never-expires",
+		ParentId:  "spiffe://example.org/parent",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+
+	_, err := s.ds.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{
+		ExpiresBefore: 100,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(s.fetchRegistrationEntry(expired.EntryId))
+
+	_, err = s.ds.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{
+		ExpiresBefore: 200,
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	requireRegistrationEntriesEqual(s.T(), []*common.RegistrationEntry{valid, neverExpires}, resp.Entries)
+
+	// the pruned entry no longer restricts the deletion of the bundle it
+	// federated with
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+}
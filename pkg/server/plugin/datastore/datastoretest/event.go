@@ -0,0 +1,150 @@
+package datastoretest
+
+import (
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *conformanceSuite) TestChangeEvents() {
+	resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Events)
+	s.Require().Zero(resp.LatestRevision)
+
+	s.createBundle("spiffe://otherdomain.org")
+	s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/node",
+		AttestationDataType: "t1",
+		CertSerialNumber:    "1",
+		CertNotAfter:        1000,
+	})
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         "spiffe://example.org/node",
+		CertSerialNumber: "2",
+		CertNotAfter:     2000,
+	})
+	s.Require().NoError(err)
+
+	// agents report in on every sync, so recording when a node was last
+	// seen does not produce an event
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:  "spiffe://example.org/node",
+		LastSeen:  1500,
+		InputMask: &datastore.AttestedNodeMask{LastSeen: true},
+	})
+	s.Require().NoError(err)
+
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/node",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:     &common.RegistrationEntry{EntryId: entry.EntryId, Ttl: 60},
+		InputMask: &common.RegistrationEntryMask{Ttl: true},
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+		SpiffeId: "spiffe://example.org/node",
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+
+	// join tokens and federation relationships are not tracked
+	_, err = s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{Token: "foo", Expiry: 1000},
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:     "spiffe://otherdomain.org",
+			BundleEndpointUrl: "https://otherdomain.org/bundle",
+		},
+	})
+	s.Require().NoError(err)
+
+	type event struct {
+		Type         datastore.ChangeEvent_Type
+		ResourceType datastore.ChangeEvent_ResourceType
+		ResourceID   string
+	}
+	expected := []event{
+		{datastore.ChangeEvent_CREATED, datastore.ChangeEvent_BUNDLE, "spiffe://otherdomain.org"},
+		{datastore.ChangeEvent_CREATED, datastore.ChangeEvent_ATTESTED_NODE, "spiffe://example.org/node"},
+		{datastore.ChangeEvent_UPDATED, datastore.ChangeEvent_ATTESTED_NODE, "spiffe://example.org/node"},
+		{datastore.ChangeEvent_CREATED, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId},
+		{datastore.ChangeEvent_UPDATED, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId},
+		{datastore.ChangeEvent_DELETED, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId},
+		{datastore.ChangeEvent_DELETED, datastore.ChangeEvent_ATTESTED_NODE, "spiffe://example.org/node"},
+		{datastore.ChangeEvent_DELETED, datastore.ChangeEvent_BUNDLE, "spiffe://otherdomain.org"},
+	}
+
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+
+	var actual []event
+	var lastRevision int64
+	for _, e := range resp.Events {
+		s.Require().True(e.Revision > lastRevision, "revisions must increase: %d after %d", e.Revision, lastRevision)
+		lastRevision = e.Revision
+		s.Require().NotZero(e.CreatedAt)
+		actual = append(actual, event{e.Type, e.ResourceType, e.ResourceId})
+	}
+	s.Require().Equal(expected, actual)
+	s.Require().Equal(lastRevision, resp.LatestRevision)
+
+	// resume after a revision, with a limit
+	all := resp.Events
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+		AfterRevision: all[2].Revision,
+		Limit:         2,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(all[3:5], resp.Events)
+	s.Require().Equal(lastRevision, resp.LatestRevision)
+
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+		AfterRevision: lastRevision,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Events)
+	s.Require().Equal(lastRevision, resp.LatestRevision)
+
+	_, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+		Limit: -1,
+	})
+	s.requireCode(err, codes.InvalidArgument)
+}
+
+func (s *conformanceSuite) TestPruneChangeEvents() {
+	s.createBundle("spiffe://otherdomain.org")
+
+	now := time.Now()
+	_, err := s.ds.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{
+		CreatedBefore: now.Add(-time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Events, 1)
+
+	_, err = s.ds.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{
+		CreatedBefore: now.Add(time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Events)
+}
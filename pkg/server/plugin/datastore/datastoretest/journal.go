@@ -0,0 +1,82 @@
+package datastoretest
+
+import (
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *conformanceSuite) TestCAJournal() {
+	// fetch before the journal has been set
+	fresp, err := s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.CaJournal)
+
+	// set for the first time
+	journal := &datastore.CAJournal{
+		TrustDomainId:  "spiffe://example.org",
+		Data:           []byte("journal-1"),
+		LeaseHolder:    "server-a",
+		LeaseExpiresAt: 1000,
+	}
+	sresp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: journal,
+	})
+	s.Require().NoError(err)
+	journal.Revision = 1
+	s.RequireProtoEqual(journal, sresp.CaJournal)
+
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(journal, fresp.CaJournal)
+
+	// journals of other trust domains are not affected
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.CaJournal)
+
+	// set with a stale revision
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: &datastore.CAJournal{
+			TrustDomainId: "spiffe://example.org",
+			Data:          []byte("stale"),
+		},
+	})
+	s.requireCode(err, codes.Aborted)
+
+	// set with the current revision
+	updated := &datastore.CAJournal{
+		TrustDomainId:  "spiffe://example.org",
+		Data:           []byte("journal-2"),
+		Revision:       1,
+		LeaseHolder:    "server-b",
+		LeaseExpiresAt: 2000,
+	}
+	sresp, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: updated,
+	})
+	s.Require().NoError(err)
+	updated.Revision = 2
+	s.RequireProtoEqual(updated, sresp.CaJournal)
+
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, fresp.CaJournal)
+
+	// the previous revision is now stale
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		CaJournal: &datastore.CAJournal{
+			TrustDomainId: "spiffe://example.org",
+			Data:          []byte("stale"),
+			Revision:      1,
+		},
+	})
+	s.requireCode(err, codes.Aborted)
+}
@@ -0,0 +1,418 @@
+package datastoretest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *conformanceSuite) TestAttestedNodeCRUD() {
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/foo",
+		AttestationDataType: "test",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		AgentVersion:        "1.0.0",
+	}
+
+	// fetching, updating or deleting a missing node
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Node)
+
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.requireCode(err, codes.NotFound)
+
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.requireCode(err, codes.NotFound)
+
+	// create
+	s.createAttestedNode(node)
+
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().Error(err, "creating a node twice must fail")
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, fresp.Node)
+
+	// delete
+	dresp, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, dresp.Node)
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Node)
+}
+
+func (s *conformanceSuite) TestUpdateAttestedNode() {
+	notAfter := time.Now().Add(time.Hour).Unix()
+	node := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/foo",
+		AttestationDataType: "test",
+		CertSerialNumber:    "1",
+		CertNotAfter:        notAfter,
+		AgentVersion:        "1.0.0",
+	})
+
+	// without a mask only the certificate fields are updated
+	resp, err := s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:            node.SpiffeId,
+		CertSerialNumber:    "2",
+		CertNotAfter:        notAfter + 1,
+		NewCertSerialNumber: "3",
+		NewCertNotAfter:     notAfter + 2,
+		AgentVersion:        "2.0.0",
+	})
+	s.Require().NoError(err)
+	expected := &common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: "test",
+		CertSerialNumber:    "2",
+		CertNotAfter:        notAfter + 1,
+		NewCertSerialNumber: "3",
+		NewCertNotAfter:     notAfter + 2,
+		AgentVersion:        "1.0.0",
+	}
+	s.RequireProtoEqual(expected, resp.Node)
+
+	// with a mask only the masked fields are updated
+	resp, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         node.SpiffeId,
+		CertSerialNumber: "4",
+		AgentVersion:     "2.0.0",
+		LastSeen:         notAfter - 10,
+		CanReattest:      true,
+		InputMask: &datastore.AttestedNodeMask{
+			AgentVersion: true,
+			LastSeen:     true,
+			CanReattest:  true,
+		},
+	})
+	s.Require().NoError(err)
+	expected.AgentVersion = "2.0.0"
+	expected.LastSeen = notAfter - 10
+	expected.CanReattest = true
+	s.RequireProtoEqual(expected, resp.Node)
+
+	// clearing the serial numbers bans the node
+	resp, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId: node.SpiffeId,
+		InputMask: &datastore.AttestedNodeMask{
+			CertSerialNumber:    true,
+			NewCertSerialNumber: true,
+		},
+	})
+	s.Require().NoError(err)
+	expected.CertSerialNumber = ""
+	expected.NewCertSerialNumber = ""
+	expected.Banned = true
+	s.RequireProtoEqual(expected, resp.Node)
+
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(expected, fresp.Node)
+}
+
+func (s *conformanceSuite) TestListAttestedNodes() {
+	now := time.Now().Unix()
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+	c3 := &common.Selector{Type: "c", Value: "3"}
+
+	expired := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/expired",
+		AttestationDataType: "t1",
+		CertSerialNumber:    "1",
+		CertNotAfter:        now - 60,
+	})
+	s.setNodeSelectors(expired.SpiffeId, a1)
+	banned := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/banned",
+		AttestationDataType: "t2",
+		CertNotAfter:        now + 60,
+		Banned:              true,
+	})
+	s.setNodeSelectors(banned.SpiffeId, a1, b2)
+	reattestable := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/reattestable",
+		AttestationDataType: "t1",
+		CertSerialNumber:    "3",
+		CertNotAfter:        now + 60,
+		CanReattest:         true,
+	})
+	s.setNodeSelectors(reattestable.SpiffeId, b2)
+	noSelectors := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/noselectors",
+		AttestationDataType: "t3",
+		CertSerialNumber:    "4",
+		NewCertSerialNumber: "5",
+		CertNotAfter:        now + 60,
+	})
+
+	bySelectors := func(match datastore.BySelectors_MatchBehavior, selectors ...*common.Selector) *datastore.BySelectors {
+		return &datastore.BySelectors{
+			Selectors: selectors,
+			Match:     match,
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		req    *datastore.ListAttestedNodesRequest
+		expect []*common.AttestedNode
+		code   codes.Code
+	}{
+		{
+			name:   "all",
+			req:    &datastore.ListAttestedNodesRequest{},
+			expect: []*common.AttestedNode{expired, banned, reattestable, noSelectors},
+		},
+		{
+			name:   "by expires before",
+			req:    &datastore.ListAttestedNodesRequest{ByExpiresBefore: &wrappers.Int64Value{Value: now}},
+			expect: []*common.AttestedNode{expired},
+		},
+		{
+			name:   "by banned",
+			req:    &datastore.ListAttestedNodesRequest{ByBanned: &wrappers.BoolValue{Value: true}},
+			expect: []*common.AttestedNode{banned},
+		},
+		{
+			name:   "by not banned",
+			req:    &datastore.ListAttestedNodesRequest{ByBanned: &wrappers.BoolValue{Value: false}},
+			expect: []*common.AttestedNode{expired, reattestable, noSelectors},
+		},
+		{
+			name:   "by attestation type",
+			req:    &datastore.ListAttestedNodesRequest{ByAttestationType: "t1"},
+			expect: []*common.AttestedNode{expired, reattestable},
+		},
+		{
+			name:   "by can reattest",
+			req:    &datastore.ListAttestedNodesRequest{ByCanReattest: &wrappers.BoolValue{Value: true}},
+			expect: []*common.AttestedNode{reattestable},
+		},
+		{
+			name:   "by selector match exact",
+			req:    &datastore.ListAttestedNodesRequest{BySelectorMatch: bySelectors(datastore.BySelectors_MATCH_EXACT, a1)},
+			expect: []*common.AttestedNode{expired},
+		},
+		{
+			name:   "by selector match subset",
+			req:    &datastore.ListAttestedNodesRequest{BySelectorMatch: bySelectors(datastore.BySelectors_MATCH_SUBSET, a1, b2)},
+			expect: []*common.AttestedNode{expired, banned, reattestable},
+		},
+		{
+			name:   "by selector match superset",
+			req:    &datastore.ListAttestedNodesRequest{BySelectorMatch: bySelectors(datastore.BySelectors_MATCH_SUPERSET, b2)},
+			expect: []*common.AttestedNode{banned, reattestable},
+		},
+		{
+			name:   "by selector match any",
+			req:    &datastore.ListAttestedNodesRequest{BySelectorMatch: bySelectors(datastore.BySelectors_MATCH_ANY, a1, c3)},
+			expect: []*common.AttestedNode{expired, banned},
+		},
+		{
+			name: "by combined filters",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: "t1",
+				BySelectorMatch:   bySelectors(datastore.BySelectors_MATCH_SUPERSET, b2),
+			},
+			expect: []*common.AttestedNode{reattestable},
+		},
+		{
+			name: "by empty selector set",
+			req:  &datastore.ListAttestedNodesRequest{BySelectorMatch: bySelectors(datastore.BySelectors_MATCH_ANY)},
+			code: codes.InvalidArgument,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, tt.req)
+			if tt.code != codes.OK {
+				require.Error(t, err)
+				require.Equal(t, tt.code, status.Code(err), "unexpected status code for error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			requireAttestedNodesEqual(t, tt.expect, resp.Nodes)
+		})
+	}
+}
+
+func (s *conformanceSuite) TestListAttestedNodesFetchSelectors() {
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+
+	foo := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:         "spiffe://example.org/spire/agent/foo",
+		CertSerialNumber: "1",
+	})
+	s.setNodeSelectors(foo.SpiffeId, a1, b2)
+	bar := s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:         "spiffe://example.org/spire/agent/bar",
+		CertSerialNumber: "2",
+	})
+
+	// selectors are only returned when requested
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	requireAttestedNodesEqual(s.T(), []*common.AttestedNode{foo, bar}, resp.Nodes)
+
+	resp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+	})
+	s.Require().NoError(err)
+	fooWithSelectors := &common.AttestedNode{
+		SpiffeId:         foo.SpiffeId,
+		CertSerialNumber: foo.CertSerialNumber,
+		Selectors:        []*common.Selector{a1, b2},
+	}
+	requireAttestedNodesEqual(s.T(), []*common.AttestedNode{fooWithSelectors, bar}, resp.Nodes)
+}
+
+func (s *conformanceSuite) TestListAttestedNodesWithPagination() {
+	var nodes []*common.AttestedNode
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		node := &common.AttestedNode{
+			SpiffeId:         "spiffe://example.org/spire/agent/" + name,
+			CertSerialNumber: name,
+		}
+		// every other node is banned so pagination is also exercised with
+		// a filter that skips records
+		if name == "b" || name == "d" {
+			node.CertSerialNumber = ""
+			node.Banned = true
+		}
+		nodes = append(nodes, s.createAttestedNode(node))
+	}
+
+	_, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.requireCode(err, codes.InvalidArgument)
+
+	for _, tt := range []struct {
+		name     string
+		byBanned *wrappers.BoolValue
+		pageSize int32
+		expect   []*common.AttestedNode
+		sizes    []int
+	}{
+		{
+			name:     "all",
+			pageSize: 2,
+			expect:   nodes,
+			sizes:    []int{2, 2, 1, 0},
+		},
+		{
+			name:     "not banned",
+			byBanned: &wrappers.BoolValue{Value: false},
+			pageSize: 1,
+			expect:   []*common.AttestedNode{nodes[0], nodes[2], nodes[4]},
+			sizes:    []int{1, 1, 1, 0},
+		},
+		{
+			name:     "page larger than results",
+			byBanned: &wrappers.BoolValue{Value: true},
+			pageSize: 10,
+			expect:   []*common.AttestedNode{nodes[1], nodes[3]},
+			sizes:    []int{2, 0},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			var listed []*common.AttestedNode
+			var sizes []int
+			var token string
+			for {
+				req := &datastore.ListAttestedNodesRequest{
+					ByBanned: tt.byBanned,
+					Pagination: &datastore.Pagination{
+						PageSize: tt.pageSize,
+						Token:    token,
+					},
+				}
+				resp, err := s.ds.ListAttestedNodes(ctx, req)
+				require.NoError(t, err)
+				requirePage(t, req.Pagination, resp.Pagination, len(resp.Nodes))
+				sizes = append(sizes, len(resp.Nodes))
+				if len(resp.Nodes) == 0 {
+					break
+				}
+				listed = append(listed, resp.Nodes...)
+				token = resp.Pagination.Token
+			}
+			require.Equal(t, tt.sizes, sizes)
+			requireAttestedNodesEqual(t, tt.expect, listed)
+		})
+	}
+}
+
+func (s *conformanceSuite) TestNodeSelectors() {
+	foo := "spiffe://example.org/spire/agent/foo"
+	bar := "spiffe://example.org/spire/agent/bar"
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+
+	// nodes without selectors
+	gresp, err := s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: foo})
+	s.Require().NoError(err)
+	s.Require().Equal(foo, gresp.Selectors.SpiffeId)
+	s.Require().Empty(gresp.Selectors.Selectors)
+
+	lresp, err := s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(lresp.Selectors)
+
+	// set
+	s.setNodeSelectors(foo, a1, b2)
+	s.setNodeSelectors(bar, a1)
+
+	gresp, err = s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: foo})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&datastore.NodeSelectors{
+		SpiffeId:  foo,
+		Selectors: []*common.Selector{a1, b2},
+	}, gresp.Selectors)
+
+	// selectors are replaced, not merged
+	s.setNodeSelectors(foo, b2)
+
+	gresp, err = s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: foo})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&datastore.NodeSelectors{
+		SpiffeId:  foo,
+		Selectors: []*common.Selector{b2},
+	}, gresp.Selectors)
+
+	lresp, err = s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(lresp.Selectors, 2)
+	listed := make(map[string][]*common.Selector)
+	for _, selectors := range lresp.Selectors {
+		listed[selectors.SpiffeId] = selectors.Selectors
+	}
+	s.RequireProtoListEqual([]*common.Selector{b2}, listed[foo])
+	s.RequireProtoListEqual([]*common.Selector{a1}, listed[bar])
+
+	// clearing the selectors removes the node from the listing
+	s.setNodeSelectors(foo)
+
+	gresp, err = s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: foo})
+	s.Require().NoError(err)
+	s.Require().Empty(gresp.Selectors.Selectors)
+
+	lresp, err = s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(lresp.Selectors, 1)
+	s.Require().Equal(bar, lresp.Selectors[0].SpiffeId)
+}
@@ -0,0 +1,143 @@
+package datastoretest
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *conformanceSuite) TestFederationRelationshipCRUD() {
+	relationship := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org/bundle",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	}
+
+	// create
+	cresp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: relationship,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(relationship, cresp.FederationRelationship)
+
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: relationship,
+	})
+	s.Require().Error(err)
+
+	// fetch
+	fresp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(relationship, fresp.FederationRelationship)
+
+	fresp, err = s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://missing.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.FederationRelationship)
+
+	// update
+	updated := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org:8443/bundle",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_SPIFFE,
+		EndpointSpiffeId:      "spiffe://otherdomain.org/bundle-server",
+	}
+	uresp, err := s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: updated,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, uresp.FederationRelationship)
+
+	fresp, err = s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, fresp.FederationRelationship)
+
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:     "spiffe://missing.org",
+			BundleEndpointUrl: "https://missing.org/bundle",
+		},
+	})
+	s.requireCode(err, codes.NotFound)
+
+	// delete
+	dresp, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(updated, dresp.FederationRelationship)
+
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.requireCode(err, codes.NotFound)
+}
+
+func (s *conformanceSuite) TestListFederationRelationships() {
+	var relationships []*datastore.FederationRelationship
+	for _, name := range []string{"a.org", "b.org", "c.org"} {
+		relationship := &datastore.FederationRelationship{
+			TrustDomainId:         "spiffe://" + name,
+			BundleEndpointUrl:     "https://" + name + "/bundle",
+			BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+		}
+		_, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+			FederationRelationship: relationship,
+		})
+		s.Require().NoError(err)
+		relationships = append(relationships, relationship)
+	}
+
+	resp, err := s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.Require().Nil(resp.Pagination)
+	requireFederationRelationshipsEqual(s.T(), relationships, resp.FederationRelationships)
+
+	_, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.requireCode(err, codes.InvalidArgument)
+
+	for _, tt := range []struct {
+		name     string
+		pageSize int32
+		sizes    []int
+	}{
+		{name: "page size 1", pageSize: 1, sizes: []int{1, 1, 1, 0}},
+		{name: "page size 2", pageSize: 2, sizes: []int{2, 1, 0}},
+		{name: "page larger than results", pageSize: 10, sizes: []int{3, 0}},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			var listed []*datastore.FederationRelationship
+			var sizes []int
+			var token string
+			for {
+				req := &datastore.ListFederationRelationshipsRequest{
+					Pagination: &datastore.Pagination{
+						PageSize: tt.pageSize,
+						Token:    token,
+					},
+				}
+				resp, err := s.ds.ListFederationRelationships(ctx, req)
+				require.NoError(t, err)
+				requirePage(t, req.Pagination, resp.Pagination, len(resp.FederationRelationships))
+				sizes = append(sizes, len(resp.FederationRelationships))
+				if len(resp.FederationRelationships) == 0 {
+					break
+				}
+				listed = append(listed, resp.FederationRelationships...)
+				token = resp.Pagination.Token
+			}
+			require.Equal(t, tt.sizes, sizes)
+			requireFederationRelationshipsEqual(t, relationships, listed)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package datastoretest
+
+import (
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *conformanceSuite) TestJoinTokenCRUD() {
+	token := &datastore.JoinToken{
+		Token:  "foo",
+		Expiry: 1000,
+	}
+
+	// create
+	cresp, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: token,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(token, cresp.JoinToken)
+
+	_, err = s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: token,
+	})
+	s.Require().Error(err)
+
+	// fetch
+	fresp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: "foo",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(token, fresp.JoinToken)
+
+	fresp, err = s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: "missing",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.JoinToken)
+
+	// delete
+	dresp, err := s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: "foo",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(token, dresp.JoinToken)
+
+	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: "foo",
+	})
+	s.requireCode(err, codes.NotFound)
+}
+
+func (s *conformanceSuite) TestListAndPruneJoinTokens() {
+	tokens := []*datastore.JoinToken{
+		{Token: "a", Expiry: 100},
+		{Token: "b", Expiry: 200},
+		{Token: "c", Expiry: 300},
+	}
+	for _, token := range tokens {
+		_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: token,
+		})
+		s.Require().NoError(err)
+	}
+
+	resp, err := s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	requireJoinTokensEqual(s.T(), tokens, resp.JoinTokens)
+
+	// tokens expiring exactly at the cutoff are kept
+	_, err = s.ds.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{
+		ExpiresBefore: 200,
+	})
+	s.Require().NoError(err)
+
+	resp, err = s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	requireJoinTokensEqual(s.T(), tokens[1:], resp.JoinTokens)
+}
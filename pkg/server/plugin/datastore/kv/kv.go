@@ -0,0 +1,2271 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	ds_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	pluginInfo = spi.GetPluginInfoResponse{
+		Description: "",
+		DateCreated: "",
+		Version:     "",
+		Author:      "",
+		Company:     "",
+	}
+
+	kvError = errs.Class("datastore-kv")
+
+	errRecordNotFound = errors.New("record not found")
+	errRecordExists   = errors.New("record already exists")
+)
+
+// Records of each kind are stored under their own key prefix
+const (
+	bundlePrefix                 = "bundles/"
+	nodePrefix                   = "nodes/"
+	nodeSelectorsPrefix          = "node_selectors/"
+	entryPrefix                  = "entries/"
+	joinTokenPrefix              = "join_tokens/"
+	federationRelationshipPrefix = "federation_relationships/"
+	changeEventPrefix            = "change_events/"
+	caJournalPrefix              = "ca_journals/"
+
+	// sequencePrefix is the prefix of the keys holding the last ID assigned
+	// to each kind of record
+	sequencePrefix = "sequences/"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin("kv",
+		datastore.PluginServer(p),
+	)
+}
+
+type configuration struct {
+	Path string `hcl:"path" json:"path"`
+}
+
+func (cfg *configuration) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be set")
+	}
+	return nil
+}
+
+// Plugin is a DataStore plugin implemented via an embedded key/value store
+// persisted in a single file. It is meant for single server deployments
+// that do not share the datastore.
+type Plugin struct {
+	mu             sync.Mutex
+	store          *store
+	log            hclog.Logger
+	metricsService hostservices.MetricsService
+}
+
+// New creates a new kv plugin struct. Configure must be called in order to
+// open the store.
+func New() *Plugin {
+	return &Plugin{}
+}
+
+func (ds *Plugin) SetLogger(logger hclog.Logger) {
+	ds.log = logger
+}
+
+func (ds *Plugin) BrokerHostServices(broker catalog.HostServiceBroker) error {
+	has, err := broker.GetHostService(hostservices.MetricsServiceHostServiceClient(&ds.metricsService))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return errors.New("required Metrics host service is not available")
+	}
+	return nil
+}
+
+// CreateBundle stores the given bundle
+func (ds *Plugin) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (resp *datastore.CreateBundleResponse, err error) {
+	callCounter := ds_telemetry.StartCreateBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = createBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateBundle updates an existing bundle with the given CAs. Overwrites any
+// existing certificates.
+func (ds *Plugin) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (resp *datastore.UpdateBundleResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = updateBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetBundle sets bundle contents. If no bundle exists for the trust domain, it is created.
+func (ds *Plugin) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (resp *datastore.SetBundleResponse, err error) {
+	callCounter := ds_telemetry.StartSetBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = setBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AppendBundle append bundle contents to the existing bundle (by trust domain). If no existing one is present, create it.
+func (ds *Plugin) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (resp *datastore.AppendBundleResponse, err error) {
+	callCounter := ds_telemetry.StartAppendBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = appendBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
+func (ds *Plugin) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (resp *datastore.DeleteBundleResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = deleteBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchBundle returns the bundle matching the specified Trust Domain.
+func (ds *Plugin) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (resp *datastore.FetchBundleResponse, err error) {
+	callCounter := ds_telemetry.StartFetchBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchBundle(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListBundles can be used to fetch all existing bundles.
+func (ds *Plugin) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (resp *datastore.ListBundlesResponse, err error) {
+	callCounter := ds_telemetry.StartListBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listBundles(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneBundle removes expired certs and keys from a bundle
+func (ds *Plugin) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (resp *datastore.PruneBundleResponse, err error) {
+	callCounter := ds_telemetry.StartPruneBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = pruneBundle(tx, req, ds.log)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TaintX509CA marks an X.509 CA in a bundle as tainted
+func (ds *Plugin) TaintX509CA(ctx context.Context, req *datastore.TaintX509CARequest) (resp *datastore.TaintX509CAResponse, err error) {
+	callCounter := ds_telemetry.StartTaintX509CACall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = taintX509CA(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RevokeX509CA removes a tainted X.509 CA from a bundle
+func (ds *Plugin) RevokeX509CA(ctx context.Context, req *datastore.RevokeX509CARequest) (resp *datastore.RevokeX509CAResponse, err error) {
+	callCounter := ds_telemetry.StartRevokeX509CACall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = revokeX509CA(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TaintJWTKey marks a JWT signing key in a bundle as tainted
+func (ds *Plugin) TaintJWTKey(ctx context.Context, req *datastore.TaintJWTKeyRequest) (resp *datastore.TaintJWTKeyResponse, err error) {
+	callCounter := ds_telemetry.StartTaintJWTKeyCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = taintJWTKey(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RevokeJWTKey removes a tainted JWT signing key from a bundle
+func (ds *Plugin) RevokeJWTKey(ctx context.Context, req *datastore.RevokeJWTKeyRequest) (resp *datastore.RevokeJWTKeyResponse, err error) {
+	callCounter := ds_telemetry.StartRevokeJWTKeyCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = revokeJWTKey(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateAttestedNode stores the given attested node
+func (ds *Plugin) CreateAttestedNode(ctx context.Context,
+	req *datastore.CreateAttestedNodeRequest) (resp *datastore.CreateAttestedNodeResponse, err error) {
+	callCounter := ds_telemetry.StartCreateNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if req.Node == nil {
+		return nil, kvError.New("invalid request: missing attested node")
+	}
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = createAttestedNode(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchAttestedNode fetches an existing attested node by SPIFFE ID
+func (ds *Plugin) FetchAttestedNode(ctx context.Context,
+	req *datastore.FetchAttestedNodeRequest) (resp *datastore.FetchAttestedNodeResponse, err error) {
+	callCounter := ds_telemetry.StartFetchNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchAttestedNode(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListAttestedNodes lists all attested nodes (pagination available)
+func (ds *Plugin) ListAttestedNodes(ctx context.Context,
+	req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
+	callCounter := ds_telemetry.StartListNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listAttestedNodes(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateAttestedNode updates the given node's cert serial and expiration.
+func (ds *Plugin) UpdateAttestedNode(ctx context.Context,
+	req *datastore.UpdateAttestedNodeRequest) (resp *datastore.UpdateAttestedNodeResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = updateAttestedNode(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteAttestedNode deletes the given attested node
+func (ds *Plugin) DeleteAttestedNode(ctx context.Context,
+	req *datastore.DeleteAttestedNodeRequest) (resp *datastore.DeleteAttestedNodeResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = deleteAttestedNode(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
+func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (resp *datastore.SetNodeSelectorsResponse, err error) {
+	callCounter := ds_telemetry.StartSetNodeSelectorsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if req.Selectors == nil {
+		return nil, errors.New("invalid request: missing selectors")
+	}
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = setNodeSelectors(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNodeSelectors gets node (agent) selectors by SPIFFE ID
+func (ds *Plugin) GetNodeSelectors(ctx context.Context,
+	req *datastore.GetNodeSelectorsRequest) (resp *datastore.GetNodeSelectorsResponse, err error) {
+	callCounter := ds_telemetry.StartGetNodeSelectorsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = getNodeSelectors(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListNodeSelectors gets node (agent) selectors for all nodes
+func (ds *Plugin) ListNodeSelectors(ctx context.Context,
+	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
+	callCounter := ds_telemetry.StartListNodeSelectorsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listNodeSelectors(tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateRegistrationEntry stores the given registration entry
+func (ds *Plugin) CreateRegistrationEntry(ctx context.Context,
+	req *datastore.CreateRegistrationEntryRequest) (resp *datastore.CreateRegistrationEntryResponse, err error) {
+	callCounter := ds_telemetry.StartCreateRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = validateRegistrationEntry(req.Entry); err != nil {
+		return nil, err
+	}
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = createRegistrationEntry(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchRegistrationEntry fetches an existing registration by entry ID
+func (ds *Plugin) FetchRegistrationEntry(ctx context.Context,
+	req *datastore.FetchRegistrationEntryRequest) (resp *datastore.FetchRegistrationEntryResponse, err error) {
+	callCounter := ds_telemetry.StartFetchRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchRegistrationEntry(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListRegistrationEntries lists all registrations (pagination available)
+func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
+	req *datastore.ListRegistrationEntriesRequest) (resp *datastore.ListRegistrationEntriesResponse, err error) {
+	callCounter := ds_telemetry.StartListRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateRegistrationEntry updates an existing registration entry
+func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context,
+	req *datastore.UpdateRegistrationEntryRequest) (resp *datastore.UpdateRegistrationEntryResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = validateRegistrationEntryForUpdate(req.Entry, req.InputMask); err != nil {
+		return nil, err
+	}
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = updateRegistrationEntry(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRegistrationEntry deletes the given registration
+func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context,
+	req *datastore.DeleteRegistrationEntryRequest) (resp *datastore.DeleteRegistrationEntryResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = deleteRegistrationEntry(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntries takes a registration entry message, and deletes all entries which have expired
+// before the date in the message
+func (ds *Plugin) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (resp *datastore.PruneRegistrationEntriesResponse, err error) {
+	callCounter := ds_telemetry.StartPruneRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = pruneRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateJoinToken takes a Token message and stores it
+func (ds *Plugin) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (resp *datastore.CreateJoinTokenResponse, err error) {
+	callCounter := ds_telemetry.StartCreateJoinTokenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if req.JoinToken == nil || req.JoinToken.Token == "" || req.JoinToken.Expiry == 0 {
+		return nil, errors.New("token and expiry are required")
+	}
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = createJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchJoinToken takes a Token message and returns one, populating the fields
+// we have knowledge of
+func (ds *Plugin) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (resp *datastore.FetchJoinTokenResponse, err error) {
+	callCounter := ds_telemetry.StartFetchJoinTokenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteJoinToken deletes the given join token
+func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (resp *datastore.DeleteJoinTokenResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteJoinTokenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = deleteJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListJoinTokens lists all join tokens
+func (ds *Plugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	callCounter := ds_telemetry.StartListJoinTokensCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneJoinTokens takes a Token message, and deletes all tokens which have expired
+// before the date in the message
+func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
+	callCounter := ds_telemetry.StartPruneJoinTokenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = pruneJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateFederationRelationship stores the given federation relationship
+func (ds *Plugin) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (resp *datastore.CreateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartCreateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = createFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchFederationRelationship returns the federation relationship with the
+// specified trust domain
+func (ds *Plugin) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (resp *datastore.FetchFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartFetchFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListFederationRelationships lists all federation relationships
+func (ds *Plugin) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (resp *datastore.ListFederationRelationshipsResponse, err error) {
+	callCounter := ds_telemetry.StartListFederationRelationshipsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listFederationRelationships(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates an existing federation relationship
+func (ds *Plugin) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (resp *datastore.UpdateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = updateFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship with the
+// specified trust domain
+func (ds *Plugin) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (resp *datastore.DeleteFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = deleteFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListChangeEvents lists the change events recorded after the given
+// revision, in revision order
+func (ds *Plugin) ListChangeEvents(ctx context.Context, req *datastore.ListChangeEventsRequest) (resp *datastore.ListChangeEventsResponse, err error) {
+	callCounter := ds_telemetry.StartListChangeEventsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = listChangeEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneChangeEvents deletes all change events recorded before the date in
+// the message
+func (ds *Plugin) PruneChangeEvents(ctx context.Context, req *datastore.PruneChangeEventsRequest) (resp *datastore.PruneChangeEventsResponse, err error) {
+	callCounter := ds_telemetry.StartPruneChangeEventsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = pruneChangeEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchCAJournal fetches the CA journal of the specified trust domain
+func (ds *Plugin) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (resp *datastore.FetchCAJournalResponse, err error) {
+	callCounter := ds_telemetry.StartFetchCAJournalCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(func(tx *txn) (err error) {
+		resp, err = fetchCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetCAJournal sets the CA journal of a trust domain if the revision in the
+// message matches the stored revision
+func (ds *Plugin) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (resp *datastore.SetCAJournalResponse, err error) {
+	callCounter := ds_telemetry.StartSetCAJournalCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(func(tx *txn) (err error) {
+		resp, err = setCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Configure parses HCL config payload into config struct, and opens the
+// store file. Reconfiguring the plugin with a different path closes the
+// previous store.
+func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := &configuration{}
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.store != nil && ds.store.path == config.Path {
+		return &spi.ConfigureResponse{}, nil
+	}
+
+	s, err := openStore(config.Path, ds.log)
+	if err != nil {
+		return nil, kvError.New("unable to open %s: %v", config.Path, err)
+	}
+	ds.log.Info("Opened key/value datastore", "path", config.Path)
+
+	if ds.store != nil {
+		ds.store.Close()
+	}
+	ds.store = s
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (ds *Plugin) closeStore() {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.store != nil {
+		ds.store.Close()
+		ds.store = nil
+	}
+}
+
+// GetPluginInfo returns the kv plugin
+func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &pluginInfo, nil
+}
+
+func (ds *Plugin) withWriteTx(op func(tx *txn) error) error {
+	s, err := ds.getStore()
+	if err != nil {
+		return err
+	}
+	return toGRPCStatus(s.update(op))
+}
+
+func (ds *Plugin) withReadTx(op func(tx *txn) error) error {
+	s, err := ds.getStore()
+	if err != nil {
+		return err
+	}
+	return toGRPCStatus(s.view(op))
+}
+
+func (ds *Plugin) getStore() (*store, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "datastore-kv: not configured")
+	}
+	return ds.store, nil
+}
+
+func (ds *Plugin) prepareMetricsForCall() telemetry.Metrics {
+	return metricsservice.WrapPluginMetrics(ds.metricsService, ds.log)
+}
+
+// toGRPCStatus takes an error, and converts it to a GRPC error. If the
+// error is already a gRPC status, it will be returned unmodified. Missing
+// and duplicate records are mapped to their corresponding codes, and any
+// other error to Unknown.
+func toGRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	unwrapped := errs.Unwrap(err)
+	if _, ok := status.FromError(unwrapped); ok {
+		return unwrapped
+	}
+
+	code := codes.Unknown
+	switch unwrapped {
+	case errRecordNotFound:
+		code = codes.NotFound
+	case errRecordExists:
+		code = codes.AlreadyExists
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func createBundle(tx *txn, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	trustDomainID, err := validateBundle(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	key := bundlePrefix + trustDomainID
+	if _, ok := tx.get(key); ok {
+		return nil, kvError.Wrap(errRecordExists)
+	}
+
+	if err := putRecord(tx, key, nextID(tx, bundlePrefix), req.Bundle); err != nil {
+		return nil, err
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, trustDomainID, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateBundleResponse{
+		Bundle: req.Bundle,
+	}, nil
+}
+
+func updateBundle(tx *txn, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	trustDomainID, err := validateBundle(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	key := bundlePrefix + trustDomainID
+	id, err := mustGetRecord(tx, key, new(common.Bundle))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putRecord(tx, key, id, req.Bundle); err != nil {
+		return nil, err
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, trustDomainID, datastore.ChangeEvent_UPDATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateBundleResponse{
+		Bundle: req.Bundle,
+	}, nil
+}
+
+func setBundle(tx *txn, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	trustDomainID, err := validateBundle(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := tx.get(bundlePrefix + trustDomainID); !ok {
+		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: req.Bundle})
+		if err != nil {
+			return nil, err
+		}
+		return &datastore.SetBundleResponse{
+			Bundle: resp.Bundle,
+		}, nil
+	}
+
+	resp, err := updateBundle(tx, &datastore.UpdateBundleRequest{Bundle: req.Bundle})
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.SetBundleResponse{
+		Bundle: resp.Bundle,
+	}, nil
+}
+
+func appendBundle(tx *txn, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	trustDomainID, err := validateBundle(req.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	key := bundlePrefix + trustDomainID
+	bundle := new(common.Bundle)
+	id, ok, err := getRecord(tx, key, bundle)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: req.Bundle})
+		if err != nil {
+			return nil, err
+		}
+		return &datastore.AppendBundleResponse{
+			Bundle: resp.Bundle,
+		}, nil
+	}
+
+	bundle, changed := bundleutil.MergeBundles(bundle, req.Bundle)
+	if changed {
+		if err := putRecord(tx, key, id, bundle); err != nil {
+			return nil, err
+		}
+		if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, trustDomainID, datastore.ChangeEvent_UPDATED); err != nil {
+			return nil, err
+		}
+	}
+
+	return &datastore.AppendBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+func deleteBundle(tx *txn, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	trustDomainID, err := normalizeTrustDomainID(req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	key := bundlePrefix + trustDomainID
+	bundle := new(common.Bundle)
+	if _, err := mustGetRecord(tx, key, bundle); err != nil {
+		return nil, err
+	}
+
+	entries, err := listEntryRecords(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var federatedEntries []record
+	for _, r := range entries {
+		if containsString(r.msg.(*common.RegistrationEntry).FederatesWith, trustDomainID) {
+			federatedEntries = append(federatedEntries, r)
+		}
+	}
+
+	if len(federatedEntries) > 0 {
+		// The federated entries are either deleted or updated along with the
+		// bundle, so change events are recorded for them as well.
+		for _, r := range federatedEntries {
+			entry := r.msg.(*common.RegistrationEntry)
+			switch req.Mode {
+			case datastore.DeleteBundleRequest_DELETE:
+				tx.delete(r.key)
+				if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_DELETED); err != nil {
+					return nil, err
+				}
+			case datastore.DeleteBundleRequest_DISSOCIATE:
+				entry.FederatesWith = removeString(entry.FederatesWith, trustDomainID)
+				if err := putRecord(tx, r.key, r.id, entry); err != nil {
+					return nil, err
+				}
+				if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_UPDATED); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, kvError.New("cannot delete bundle; federated with %d registration entries", len(federatedEntries))
+			}
+		}
+	}
+
+	tx.delete(key)
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, trustDomainID, datastore.ChangeEvent_DELETED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeleteBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+func fetchBundle(tx *txn, req *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	trustDomainID, err := normalizeTrustDomainID(req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := new(common.Bundle)
+	_, ok, err := getRecord(tx, bundlePrefix+trustDomainID, bundle)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchBundleResponse{}, nil
+	}
+
+	return &datastore.FetchBundleResponse{
+		Bundle: bundle,
+	}, nil
+}
+
+func listBundles(tx *txn, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	records, err := listRecords(tx, bundlePrefix, func() proto.Message { return new(common.Bundle) })
+	if err != nil {
+		return nil, err
+	}
+
+	records, err = paginate(req.Pagination, records)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListBundlesResponse{
+		Pagination: req.Pagination,
+	}
+	for _, r := range records {
+		resp.Bundles = append(resp.Bundles, r.msg.(*common.Bundle))
+	}
+	return resp, nil
+}
+
+func pruneBundle(tx *txn, req *datastore.PruneBundleRequest, log hclog.Logger) (*datastore.PruneBundleResponse, error) {
+	// Get current bundle
+	current, err := fetchBundle(tx, &datastore.FetchBundleRequest{TrustDomainId: req.TrustDomainId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch current bundle: %v", err)
+	}
+
+	if current.Bundle == nil {
+		// No bundle to prune
+		return &datastore.PruneBundleResponse{}, nil
+	}
+
+	// Prune
+	newBundle, changed, err := bundleutil.PruneBundle(current.Bundle, time.Unix(req.ExpiresBefore, 0), log)
+	if err != nil {
+		return nil, fmt.Errorf("prune failed: %v", err)
+	}
+
+	// Update only if bundle was modified
+	if changed {
+		_, err := updateBundle(tx, &datastore.UpdateBundleRequest{
+			Bundle: newBundle,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to write new bundle: %v", err)
+		}
+	}
+
+	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
+}
+
+func taintX509CA(tx *txn, req *datastore.TaintX509CARequest) (*datastore.TaintX509CAResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintX509CAResponse{}, nil
+}
+
+func revokeX509CA(tx *txn, req *datastore.RevokeX509CARequest) (*datastore.RevokeX509CAResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeX509CAResponse{}, nil
+}
+
+func taintJWTKey(tx *txn, req *datastore.TaintJWTKeyRequest) (*datastore.TaintJWTKeyResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintJWTKeyResponse{}, nil
+}
+
+func revokeJWTKey(tx *txn, req *datastore.RevokeJWTKeyRequest) (*datastore.RevokeJWTKeyResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeJWTKeyResponse{}, nil
+}
+
+// modifyBundle applies fn to the bundle of the given trust domain and writes
+// the result back
+func modifyBundle(tx *txn, trustDomainID string, fn func(*common.Bundle) error) error {
+	current, err := fetchBundle(tx, &datastore.FetchBundleRequest{TrustDomainId: trustDomainID})
+	if err != nil {
+		return err
+	}
+	if current.Bundle == nil {
+		return status.Errorf(codes.NotFound, "no bundle for trust domain %q", trustDomainID)
+	}
+
+	if err := fn(current.Bundle); err != nil {
+		return err
+	}
+
+	_, err = updateBundle(tx, &datastore.UpdateBundleRequest{
+		Bundle: current.Bundle,
+	})
+	return err
+}
+
+func createAttestedNode(tx *txn, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	key := nodePrefix + req.Node.SpiffeId
+	if _, ok := tx.get(key); ok {
+		return nil, kvError.Wrap(errRecordExists)
+	}
+
+	// Selectors are stored separately and the banned flag is derived from
+	// the serial numbers
+	node := cloneAttestedNode(req.Node)
+	node.Selectors = nil
+	node.Banned = false
+	if err := putRecord(tx, key, nextID(tx, nodePrefix), node); err != nil {
+		return nil, err
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, node.SpiffeId, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateAttestedNodeResponse{
+		Node: withBannedFlag(node),
+	}, nil
+}
+
+func fetchAttestedNode(tx *txn, req *datastore.FetchAttestedNodeRequest) (*datastore.FetchAttestedNodeResponse, error) {
+	node := new(common.AttestedNode)
+	_, ok, err := getRecord(tx, nodePrefix+req.SpiffeId, node)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchAttestedNodeResponse{}, nil
+	}
+
+	return &datastore.FetchAttestedNodeResponse{
+		Node: withBannedFlag(node),
+	}, nil
+}
+
+func listAttestedNodes(tx *txn, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	if req.Pagination != nil && req.Pagination.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	var matchSelectors func([]*common.Selector) bool
+	if req.BySelectorMatch != nil {
+		var err error
+		matchSelectors, err = selectorMatcher(req.BySelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	records, err := listRecords(tx, nodePrefix, func() proto.Message { return new(common.AttestedNode) })
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := records[:0]
+	for _, r := range records {
+		node := withBannedFlag(r.msg.(*common.AttestedNode))
+		if req.ByExpiresBefore != nil && node.CertNotAfter >= req.ByExpiresBefore.Value {
+			continue
+		}
+		// Banned nodes are those that have had their serial numbers cleared
+		if req.ByBanned != nil && node.Banned != req.ByBanned.Value {
+			continue
+		}
+		if req.ByAttestationType != "" && node.AttestationDataType != req.ByAttestationType {
+			continue
+		}
+		if req.ByCanReattest != nil && node.CanReattest != req.ByCanReattest.Value {
+			continue
+		}
+		if matchSelectors != nil || req.FetchSelectors {
+			selectors, err := getSelectors(tx, node.SpiffeId)
+			if err != nil {
+				return nil, err
+			}
+			if matchSelectors != nil && !matchSelectors(selectors) {
+				continue
+			}
+			if req.FetchSelectors {
+				node.Selectors = selectors
+			}
+		}
+		filtered = append(filtered, r)
+	}
+
+	filtered, err = paginate(req.Pagination, filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListAttestedNodesResponse{
+		Nodes:      make([]*common.AttestedNode, 0, len(filtered)),
+		Pagination: req.Pagination,
+	}
+	for _, r := range filtered {
+		resp.Nodes = append(resp.Nodes, r.msg.(*common.AttestedNode))
+	}
+	return resp, nil
+}
+
+func updateAttestedNode(tx *txn, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	key := nodePrefix + req.SpiffeId
+	node := new(common.AttestedNode)
+	id, err := mustGetRecord(tx, key, node)
+	if err != nil {
+		return nil, err
+	}
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.AttestedNodeMask{
+			CertSerialNumber:    true,
+			CertNotAfter:        true,
+			NewCertSerialNumber: true,
+			NewCertNotAfter:     true,
+		}
+	}
+	if mask.CertSerialNumber {
+		node.CertSerialNumber = req.CertSerialNumber
+	}
+	if mask.CertNotAfter {
+		node.CertNotAfter = req.CertNotAfter
+	}
+	if mask.NewCertSerialNumber {
+		node.NewCertSerialNumber = req.NewCertSerialNumber
+	}
+	if mask.NewCertNotAfter {
+		node.NewCertNotAfter = req.NewCertNotAfter
+	}
+	if mask.AgentVersion {
+		node.AgentVersion = req.AgentVersion
+	}
+	if mask.AgentPlugins {
+		node.AgentPlugins = req.AgentPlugins
+	}
+	if mask.LastSeen {
+		node.LastSeen = req.LastSeen
+	}
+	if mask.CanReattest {
+		node.CanReattest = req.CanReattest
+	}
+
+	if err := putRecord(tx, key, id, node); err != nil {
+		return nil, err
+	}
+
+	// Agents are seen on every sync, so updates that only record the last
+	// seen time are not worth a change event.
+	if !isLastSeenOnlyUpdate(req.InputMask) {
+		if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, node.SpiffeId, datastore.ChangeEvent_UPDATED); err != nil {
+			return nil, err
+		}
+	}
+
+	return &datastore.UpdateAttestedNodeResponse{
+		Node: withBannedFlag(node),
+	}, nil
+}
+
+func deleteAttestedNode(tx *txn, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
+	key := nodePrefix + req.SpiffeId
+	node := new(common.AttestedNode)
+	if _, err := mustGetRecord(tx, key, node); err != nil {
+		return nil, err
+	}
+
+	tx.delete(key)
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, node.SpiffeId, datastore.ChangeEvent_DELETED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeleteAttestedNodeResponse{
+		Node: withBannedFlag(node),
+	}, nil
+}
+
+func setNodeSelectors(tx *txn, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	key := nodeSelectorsPrefix + req.Selectors.SpiffeId
+	if len(req.Selectors.Selectors) == 0 {
+		tx.delete(key)
+	} else if err := putRecord(tx, key, 0, req.Selectors); err != nil {
+		return nil, err
+	}
+
+	// Node selectors are part of the attested node as seen by the change
+	// event consumers, so setting them is recorded as an update.
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, req.Selectors.SpiffeId, datastore.ChangeEvent_UPDATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.SetNodeSelectorsResponse{}, nil
+}
+
+func getNodeSelectors(tx *txn, req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
+	selectors, err := getSelectors(tx, req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.GetNodeSelectorsResponse{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  req.SpiffeId,
+			Selectors: selectors,
+		},
+	}, nil
+}
+
+func listNodeSelectors(tx *txn) (*datastore.ListNodeSelectorsResponse, error) {
+	resp := new(datastore.ListNodeSelectorsResponse)
+	if err := tx.scan(nodeSelectorsPrefix, func(key string, value []byte) error {
+		selectors := new(datastore.NodeSelectors)
+		if _, err := unmarshalRecord(value, selectors); err != nil {
+			return err
+		}
+		resp.Selectors = append(resp.Selectors, selectors)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func getSelectors(tx *txn, spiffeID string) ([]*common.Selector, error) {
+	selectors := new(datastore.NodeSelectors)
+	if _, _, err := getRecord(tx, nodeSelectorsPrefix+spiffeID, selectors); err != nil {
+		return nil, err
+	}
+	return selectors.Selectors, nil
+}
+
+func createRegistrationEntry(tx *txn, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	entryID, err := newRegistrationEntryID()
+	if err != nil {
+		return nil, err
+	}
+
+	federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := cloneRegistrationEntry(req.Entry)
+	entry.EntryId = entryID
+	entry.FederatesWith = federatesWith
+	entry.RevisionNumber = 0
+	if err := putRecord(tx, entryPrefix+entryID, nextID(tx, entryPrefix), entry); err != nil {
+		return nil, err
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entryID, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+func fetchRegistrationEntry(tx *txn, req *datastore.FetchRegistrationEntryRequest) (*datastore.FetchRegistrationEntryResponse, error) {
+	entry := new(common.RegistrationEntry)
+	_, ok, err := getRecord(tx, entryPrefix+req.EntryId, entry)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchRegistrationEntryResponse{}, nil
+	}
+
+	return &datastore.FetchRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+func listRegistrationEntries(tx *txn, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	if req.Pagination != nil && req.Pagination.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+	if req.ByFederatesWith != nil && len(req.ByFederatesWith.TrustDomains) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty federates with set")
+	}
+
+	var matchSelectors func([]*common.Selector) bool
+	if req.BySelectors != nil {
+		var err error
+		matchSelectors, err = selectorMatcher(req.BySelectors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var matchFederatesWith func([]string) bool
+	if req.ByFederatesWith != nil {
+		switch req.ByFederatesWith.Match {
+		case datastore.ByFederatesWith_MATCH_ALL:
+			matchFederatesWith = func(federatesWith []string) bool {
+				for _, trustDomain := range req.ByFederatesWith.TrustDomains {
+					if !containsString(federatesWith, trustDomain) {
+						return false
+					}
+				}
+				return true
+			}
+		case datastore.ByFederatesWith_MATCH_ANY:
+			matchFederatesWith = func(federatesWith []string) bool {
+				for _, trustDomain := range req.ByFederatesWith.TrustDomains {
+					if containsString(federatesWith, trustDomain) {
+						return true
+					}
+				}
+				return false
+			}
+		default:
+			return nil, kvError.New("unhandled federates with match behavior %q", req.ByFederatesWith.Match)
+		}
+	}
+
+	records, err := listEntryRecords(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := records[:0]
+	for _, r := range records {
+		entry := r.msg.(*common.RegistrationEntry)
+		if req.ByParentId != nil && entry.ParentId != req.ByParentId.Value {
+			continue
+		}
+		if req.BySpiffeId != nil && entry.SpiffeId != req.BySpiffeId.Value {
+			continue
+		}
+		if req.ByDownstream != nil && entry.Downstream != req.ByDownstream.Value {
+			continue
+		}
+		if req.ByAdmin != nil && entry.Admin != req.ByAdmin.Value {
+			continue
+		}
+		if req.ByDnsName != nil && !containsString(entry.DnsNames, req.ByDnsName.Value) {
+			continue
+		}
+		// entries without an expiry never expire
+		if req.ByExpiresAfter != nil && entry.EntryExpiry != 0 && entry.EntryExpiry <= req.ByExpiresAfter.Value {
+			continue
+		}
+		if req.ByExpiresBefore != nil && (entry.EntryExpiry == 0 || entry.EntryExpiry >= req.ByExpiresBefore.Value) {
+			continue
+		}
+		if matchSelectors != nil && !matchSelectors(entry.Selectors) {
+			continue
+		}
+		if matchFederatesWith != nil && !matchFederatesWith(entry.FederatesWith) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	filtered, err = paginate(req.Pagination, filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListRegistrationEntriesResponse{
+		Pagination: req.Pagination,
+	}
+	for _, r := range filtered {
+		resp.Entries = append(resp.Entries, r.msg.(*common.RegistrationEntry))
+	}
+	return resp, nil
+}
+
+func updateRegistrationEntry(tx *txn, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	key := entryPrefix + req.Entry.EntryId
+	entry := new(common.RegistrationEntry)
+	id, err := mustGetRecord(tx, key, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bumpRegistrationEntryRevision(entry, req.ExpectedRevisionNumber); err != nil {
+		return nil, err
+	}
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = allRegistrationEntryFieldsMask()
+	}
+
+	if mask.Selectors {
+		entry.Selectors = req.Entry.Selectors
+	}
+	if mask.DnsNames {
+		entry.DnsNames = req.Entry.DnsNames
+	}
+	if mask.SpiffeId {
+		entry.SpiffeId = req.Entry.SpiffeId
+	}
+	if mask.ParentId {
+		entry.ParentId = req.Entry.ParentId
+	}
+	if mask.Ttl {
+		entry.Ttl = req.Entry.Ttl
+	}
+	if mask.Admin {
+		entry.Admin = req.Entry.Admin
+	}
+	if mask.Downstream {
+		entry.Downstream = req.Entry.Downstream
+	}
+	if mask.EntryExpiry {
+		entry.EntryExpiry = req.Entry.EntryExpiry
+	}
+	if mask.X509SvidProfile {
+		entry.X509SvidProfile = req.Entry.X509SvidProfile
+	}
+	if mask.JwtSvidTtl {
+		entry.JwtSvidTtl = req.Entry.JwtSvidTtl
+	}
+	if mask.FederatesWith {
+		federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
+		if err != nil {
+			return nil, err
+		}
+		entry.FederatesWith = federatesWith
+	}
+
+	if err := putRecord(tx, key, id, entry); err != nil {
+		return nil, err
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_UPDATED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+// allRegistrationEntryFieldsMask returns a mask that updates every field of
+// a registration entry.
+func allRegistrationEntryFieldsMask() *common.RegistrationEntryMask {
+	return &common.RegistrationEntryMask{
+		Selectors:       true,
+		ParentId:        true,
+		SpiffeId:        true,
+		Ttl:             true,
+		FederatesWith:   true,
+		Admin:           true,
+		Downstream:      true,
+		EntryExpiry:     true,
+		DnsNames:        true,
+		X509SvidProfile: true,
+		JwtSvidTtl:      true,
+	}
+}
+
+func deleteRegistrationEntry(tx *txn, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	key := entryPrefix + req.EntryId
+	entry := new(common.RegistrationEntry)
+	if _, err := mustGetRecord(tx, key, entry); err != nil {
+		return nil, err
+	}
+
+	// The entry is only deleted if it was not updated since it was read
+	if req.ExpectedRevisionNumber != nil {
+		if err := checkRevisionNumber(entry, req.ExpectedRevisionNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	tx.delete(key)
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_DELETED); err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeleteRegistrationEntryResponse{
+		Entry: entry,
+	}, nil
+}
+
+// bumpRegistrationEntryRevision increments the revision number of the entry.
+// It fails with an Aborted status if the entry does not have the expected
+// revision number, when given. Concurrent updates cannot happen since
+// read-write transactions are serialized.
+func bumpRegistrationEntryRevision(entry *common.RegistrationEntry, expected *wrappers.Int64Value) error {
+	if err := checkRevisionNumber(entry, expected); err != nil {
+		return err
+	}
+	entry.RevisionNumber++
+	return nil
+}
+
+func checkRevisionNumber(entry *common.RegistrationEntry, expected *wrappers.Int64Value) error {
+	if expected != nil && expected.Value != entry.RevisionNumber {
+		return status.Errorf(codes.Aborted, "registration entry revision number mismatch: expected %d, current %d", expected.Value, entry.RevisionNumber)
+	}
+	return nil
+}
+
+func pruneRegistrationEntries(tx *txn, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
+	records, err := listEntryRecords(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		entry := r.msg.(*common.RegistrationEntry)
+		if entry.EntryExpiry == 0 || entry.EntryExpiry >= req.ExpiresBefore {
+			continue
+		}
+		tx.delete(r.key)
+		if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_DELETED); err != nil {
+			return nil, err
+		}
+	}
+
+	return &datastore.PruneRegistrationEntriesResponse{}, nil
+}
+
+func listEntryRecords(tx *txn) ([]record, error) {
+	return listRecords(tx, entryPrefix, func() proto.Message { return new(common.RegistrationEntry) })
+}
+
+// makeFederatesWith returns the trust domains the entry federates with, in
+// the order their bundles were created. All of the bundles must exist.
+func makeFederatesWith(tx *txn, ids []string) ([]string, error) {
+	var bundles []record
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		key := bundlePrefix + id
+		bundleID, ok, err := getRecord(tx, key, new(common.Bundle))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("unable to find federated bundle %q", id)
+		}
+		bundles = append(bundles, record{id: bundleID, key: key})
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].id < bundles[j].id
+	})
+
+	var federatesWith []string
+	for _, bundle := range bundles {
+		federatesWith = append(federatesWith, strings.TrimPrefix(bundle.key, bundlePrefix))
+	}
+	return federatesWith, nil
+}
+
+func createJoinToken(tx *txn, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
+	key := joinTokenPrefix + req.JoinToken.Token
+	if _, ok := tx.get(key); ok {
+		return nil, kvError.Wrap(errRecordExists)
+	}
+
+	if err := putRecord(tx, key, 0, req.JoinToken); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateJoinTokenResponse{
+		JoinToken: req.JoinToken,
+	}, nil
+}
+
+func fetchJoinToken(tx *txn, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
+	token := new(datastore.JoinToken)
+	_, ok, err := getRecord(tx, joinTokenPrefix+req.Token, token)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchJoinTokenResponse{}, nil
+	}
+
+	return &datastore.FetchJoinTokenResponse{
+		JoinToken: token,
+	}, nil
+}
+
+func deleteJoinToken(tx *txn, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
+	key := joinTokenPrefix + req.Token
+	token := new(datastore.JoinToken)
+	if _, err := mustGetRecord(tx, key, token); err != nil {
+		return nil, err
+	}
+
+	tx.delete(key)
+
+	return &datastore.DeleteJoinTokenResponse{
+		JoinToken: token,
+	}, nil
+}
+
+func listJoinTokens(tx *txn, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	resp := &datastore.ListJoinTokensResponse{
+		JoinTokens: []*datastore.JoinToken{},
+	}
+	// Keys are scanned in order, so tokens are sorted
+	if err := tx.scan(joinTokenPrefix, func(key string, value []byte) error {
+		token := new(datastore.JoinToken)
+		if _, err := unmarshalRecord(value, token); err != nil {
+			return err
+		}
+		resp.JoinTokens = append(resp.JoinTokens, token)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func pruneJoinTokens(tx *txn, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
+	resp, err := listJoinTokens(tx, &datastore.ListJoinTokensRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range resp.JoinTokens {
+		if token.Expiry < req.ExpiresBefore {
+			tx.delete(joinTokenPrefix + token.Token)
+		}
+	}
+
+	return &datastore.PruneJoinTokensResponse{}, nil
+}
+
+func createFederationRelationship(tx *txn, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	relationship, err := validateFederationRelationship(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	key := federationRelationshipPrefix + relationship.TrustDomainId
+	if _, ok := tx.get(key); ok {
+		return nil, kvError.Wrap(errRecordExists)
+	}
+
+	if err := putRecord(tx, key, nextID(tx, federationRelationshipPrefix), relationship); err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateFederationRelationshipResponse{
+		FederationRelationship: relationship,
+	}, nil
+}
+
+func fetchFederationRelationship(tx *txn, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	trustDomainID, err := normalizeTrustDomainID(req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	relationship := new(datastore.FederationRelationship)
+	_, ok, err := getRecord(tx, federationRelationshipPrefix+trustDomainID, relationship)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		FederationRelationship: relationship,
+	}, nil
+}
+
+func listFederationRelationships(tx *txn, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	records, err := listRecords(tx, federationRelationshipPrefix, func() proto.Message { return new(datastore.FederationRelationship) })
+	if err != nil {
+		return nil, err
+	}
+
+	records, err = paginate(req.Pagination, records)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListFederationRelationshipsResponse{
+		Pagination: req.Pagination,
+	}
+	for _, r := range records {
+		resp.FederationRelationships = append(resp.FederationRelationships, r.msg.(*datastore.FederationRelationship))
+	}
+	return resp, nil
+}
+
+func updateFederationRelationship(tx *txn, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	relationship, err := validateFederationRelationship(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	key := federationRelationshipPrefix + relationship.TrustDomainId
+	id, err := mustGetRecord(tx, key, new(datastore.FederationRelationship))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putRecord(tx, key, id, relationship); err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		FederationRelationship: relationship,
+	}, nil
+}
+
+func deleteFederationRelationship(tx *txn, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	trustDomainID, err := normalizeTrustDomainID(req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	key := federationRelationshipPrefix + trustDomainID
+	relationship := new(datastore.FederationRelationship)
+	if _, err := mustGetRecord(tx, key, relationship); err != nil {
+		return nil, err
+	}
+
+	tx.delete(key)
+
+	return &datastore.DeleteFederationRelationshipResponse{
+		FederationRelationship: relationship,
+	}, nil
+}
+
+func recordChangeEvent(tx *txn, resourceType datastore.ChangeEvent_ResourceType, resourceID string, eventType datastore.ChangeEvent_Type) error {
+	revision := nextID(tx, changeEventPrefix)
+	return putRecord(tx, changeEventKey(revision), revision, &datastore.ChangeEvent{
+		Revision:     int64(revision),
+		Type:         eventType,
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		CreatedAt:    time.Now().Unix(),
+	})
+}
+
+func listChangeEvents(tx *txn, req *datastore.ListChangeEventsRequest) (*datastore.ListChangeEventsResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list change events with a negative limit")
+	}
+
+	// Event keys sort in revision order
+	resp := new(datastore.ListChangeEventsResponse)
+	if err := tx.scan(changeEventPrefix, func(key string, value []byte) error {
+		event := new(datastore.ChangeEvent)
+		if _, err := unmarshalRecord(value, event); err != nil {
+			return err
+		}
+		resp.LatestRevision = event.Revision
+		if event.Revision > req.AfterRevision && (req.Limit == 0 || len(resp.Events) < int(req.Limit)) {
+			resp.Events = append(resp.Events, event)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func pruneChangeEvents(tx *txn, req *datastore.PruneChangeEventsRequest) (*datastore.PruneChangeEventsResponse, error) {
+	var keys []string
+	if err := tx.scan(changeEventPrefix, func(key string, value []byte) error {
+		event := new(datastore.ChangeEvent)
+		if _, err := unmarshalRecord(value, event); err != nil {
+			return err
+		}
+		if event.CreatedAt < req.CreatedBefore {
+			keys = append(keys, key)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		tx.delete(key)
+	}
+
+	return &datastore.PruneChangeEventsResponse{}, nil
+}
+
+func fetchCAJournal(tx *txn, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	trustDomainID, err := normalizeTrustDomainID(req.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := new(datastore.CAJournal)
+	_, ok, err := getRecord(tx, caJournalPrefix+trustDomainID, journal)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return &datastore.FetchCAJournalResponse{}, nil
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		CaJournal: journal,
+	}, nil
+}
+
+func setCAJournal(tx *txn, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	if req.CaJournal == nil {
+		return nil, kvError.New("invalid request: missing CA journal")
+	}
+	trustDomainID, err := normalizeTrustDomainID(req.CaJournal.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	key := caJournalPrefix + trustDomainID
+	current := new(datastore.CAJournal)
+	id, ok, err := getRecord(tx, key, current)
+	if err != nil {
+		return nil, err
+	}
+	if req.CaJournal.Revision != current.Revision {
+		return nil, status.Errorf(codes.Aborted, "CA journal revision mismatch: expected %d, current %d", req.CaJournal.Revision, current.Revision)
+	}
+	if !ok {
+		id = nextID(tx, caJournalPrefix)
+	}
+
+	journal := proto.Clone(req.CaJournal).(*datastore.CAJournal)
+	journal.TrustDomainId = trustDomainID
+	journal.Revision++
+	if err := putRecord(tx, key, id, journal); err != nil {
+		return nil, err
+	}
+
+	return &datastore.SetCAJournalResponse{
+		CaJournal: journal,
+	}, nil
+}
+
+func changeEventKey(revision uint64) string {
+	return fmt.Sprintf("%s%020d", changeEventPrefix, revision)
+}
+
+// record is a message stored under a key along with the ID it was assigned
+// when it was created. IDs increase monotonically for each kind of record,
+// like auto-incremented primary keys, and are used to list records in
+// creation order and as pagination tokens.
+type record struct {
+	id  uint64
+	key string
+	msg proto.Message
+}
+
+// nextID returns the next ID for the kind of record stored under the prefix
+func nextID(tx *txn, prefix string) uint64 {
+	key := sequencePrefix + prefix
+	var id uint64
+	if value, ok := tx.get(key); ok {
+		id, _ = binary.Uvarint(value)
+	}
+	id++
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	tx.put(key, buf[:binary.PutUvarint(buf, id)])
+	return id
+}
+
+// getRecord unmarshals the record stored under the key into the message and
+// returns its ID. It returns false if there is no such record.
+func getRecord(tx *txn, key string, msg proto.Message) (uint64, bool, error) {
+	value, ok := tx.get(key)
+	if !ok {
+		return 0, false, nil
+	}
+	id, err := unmarshalRecord(value, msg)
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// mustGetRecord is like getRecord but fails if there is no such record
+func mustGetRecord(tx *txn, key string, msg proto.Message) (uint64, error) {
+	id, ok, err := getRecord(tx, key, msg)
+	switch {
+	case err != nil:
+		return 0, err
+	case !ok:
+		return 0, kvError.Wrap(errRecordNotFound)
+	}
+	return id, nil
+}
+
+func putRecord(tx *txn, key string, id uint64, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return kvError.Wrap(err)
+	}
+
+	value := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data))
+	value = append(value[:binary.PutUvarint(value, id)], data...)
+	tx.put(key, value)
+	return nil
+}
+
+func unmarshalRecord(value []byte, msg proto.Message) (uint64, error) {
+	id, n := binary.Uvarint(value)
+	if n <= 0 {
+		return 0, kvError.New("invalid record")
+	}
+	if err := proto.Unmarshal(value[n:], msg); err != nil {
+		return 0, kvError.Wrap(err)
+	}
+	return id, nil
+}
+
+// listRecords returns the records stored under the prefix in ID order
+func listRecords(tx *txn, prefix string, newMsg func() proto.Message) ([]record, error) {
+	var records []record
+	if err := tx.scan(prefix, func(key string, value []byte) error {
+		msg := newMsg()
+		id, err := unmarshalRecord(value, msg)
+		if err != nil {
+			return err
+		}
+		records = append(records, record{id: id, key: key, msg: msg})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].id < records[j].id
+	})
+	return records, nil
+}
+
+// paginate returns the page of the records, which must be in ID order,
+// following the token and updates the token to the ID of the last record
+// in the page.
+func paginate(p *datastore.Pagination, records []record) ([]record, error) {
+	if p == nil {
+		return records, nil
+	}
+	if p.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	if len(p.Token) > 0 {
+		after, err := strconv.ParseUint(p.Token, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "could not parse token '%v'", p.Token)
+		}
+		records = records[sort.Search(len(records), func(i int) bool {
+			return records[i].id > after
+		}):]
+	}
+	if p.PageSize > 0 && len(records) > int(p.PageSize) {
+		records = records[:p.PageSize]
+	}
+
+	p.Token = ""
+	if len(records) > 0 {
+		p.Token = strconv.FormatUint(records[len(records)-1].id, 10)
+	}
+	return records, nil
+}
+
+// selectorMatcher returns a function that matches selectors against the
+// requested ones. Any matching requires at least one of the requested
+// selectors and superset matching requires all of them. Subset and exact
+// matching additionally require no other selectors.
+func selectorMatcher(bySelectors *datastore.BySelectors) (func([]*common.Selector) bool, error) {
+	if len(bySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+
+	requested := selector.NewSetFromRaw(bySelectors.Selectors)
+	switch bySelectors.Match {
+	case datastore.BySelectors_MATCH_ANY:
+		return func(selectors []*common.Selector) bool {
+			return includesAnySelector(requested, selectors)
+		}, nil
+	case datastore.BySelectors_MATCH_SUPERSET:
+		return func(selectors []*common.Selector) bool {
+			return selector.NewSetFromRaw(selectors).IncludesSet(requested)
+		}, nil
+	case datastore.BySelectors_MATCH_SUBSET:
+		return func(selectors []*common.Selector) bool {
+			return len(selectors) > 0 && requested.IncludesSet(selector.NewSetFromRaw(selectors))
+		}, nil
+	case datastore.BySelectors_MATCH_EXACT:
+		return func(selectors []*common.Selector) bool {
+			return requested.Equal(selector.NewSetFromRaw(selectors))
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unhandled match behavior %q", bySelectors.Match)
+	}
+}
+
+func includesAnySelector(set selector.Set, selectors []*common.Selector) bool {
+	for _, s := range selectors {
+		if set.Includes(&selector.Selector{Type: s.Type, Value: s.Value}) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRegistrationEntry(entry *common.RegistrationEntry) error {
+	if entry == nil {
+		return kvError.New("invalid request: missing registered entry")
+	}
+
+	if len(entry.Selectors) == 0 {
+		return kvError.New("invalid registration entry: missing selector list")
+	}
+
+	if len(entry.SpiffeId) == 0 {
+		return kvError.New("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if entry.Ttl < 0 {
+		return kvError.New("invalid registration entry: TTL is not set")
+	}
+
+	if entry.JwtSvidTtl < 0 {
+		return kvError.New("invalid registration entry: JWT-SVID TTL is negative")
+	}
+
+	return nil
+}
+
+// validateRegistrationEntryForUpdate validates the fields of the entry that
+// are updated according to the mask.
+func validateRegistrationEntryForUpdate(entry *common.RegistrationEntry, mask *common.RegistrationEntryMask) error {
+	if entry == nil {
+		return kvError.New("invalid request: missing registered entry")
+	}
+
+	if (mask == nil || mask.Selectors) && len(entry.Selectors) == 0 {
+		return kvError.New("invalid registration entry: missing selector list")
+	}
+
+	if (mask == nil || mask.SpiffeId) && len(entry.SpiffeId) == 0 {
+		return kvError.New("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if (mask == nil || mask.Ttl) && entry.Ttl < 0 {
+		return kvError.New("invalid registration entry: TTL is not set")
+	}
+
+	if (mask == nil || mask.JwtSvidTtl) && entry.JwtSvidTtl < 0 {
+		return kvError.New("invalid registration entry: JWT-SVID TTL is negative")
+	}
+
+	return nil
+}
+
+// validateBundle validates the bundle and returns its normalized trust
+// domain ID
+func validateBundle(bundle *common.Bundle) (string, error) {
+	if bundle == nil {
+		return "", kvError.New("missing bundle in request")
+	}
+	return normalizeTrustDomainID(bundle.TrustDomainId)
+}
+
+// validateFederationRelationship validates the federation relationship and
+// returns a copy with a normalized trust domain ID
+func validateFederationRelationship(relationship *datastore.FederationRelationship) (*datastore.FederationRelationship, error) {
+	if relationship == nil {
+		return nil, kvError.New("missing federation relationship in request")
+	}
+	trustDomainID, err := normalizeTrustDomainID(relationship.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+	if relationship.BundleEndpointUrl == "" {
+		return nil, kvError.New("invalid federation relationship: missing bundle endpoint URL")
+	}
+
+	relationship = proto.Clone(relationship).(*datastore.FederationRelationship)
+	relationship.TrustDomainId = trustDomainID
+	return relationship, nil
+}
+
+func normalizeTrustDomainID(trustDomainID string) (string, error) {
+	id, err := idutil.NormalizeSpiffeID(trustDomainID, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return "", kvError.Wrap(err)
+	}
+	return id, nil
+}
+
+func withBannedFlag(node *common.AttestedNode) *common.AttestedNode {
+	node.Banned = nodeutil.IsAgentBanned(node)
+	return node
+}
+
+func isLastSeenOnlyUpdate(mask *datastore.AttestedNodeMask) bool {
+	return mask != nil && proto.Equal(mask, &datastore.AttestedNodeMask{LastSeen: true})
+}
+
+func newRegistrationEntryID() (string, error) {
+	u, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func cloneAttestedNode(node *common.AttestedNode) *common.AttestedNode {
+	return proto.Clone(node).(*common.AttestedNode)
+}
+
+func cloneRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	return proto.Clone(entry).(*common.RegistrationEntry)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	var out []string
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
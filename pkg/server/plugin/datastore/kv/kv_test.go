@@ -0,0 +1,848 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	proto_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/datastoretest"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+)
+
+const (
+	_notFoundErrMsg = "datastore-kv: record not found"
+)
+
+func TestPlugin(t *testing.T) {
+	spiretest.Run(t, new(PluginSuite))
+}
+
+func TestConformance(t *testing.T) {
+	datastoretest.Run(t, func(t *testing.T) datastore.DataStore {
+		path := filepath.Join(tempDir(t), "datastore.kv")
+
+		p := New()
+		var ds datastore.Plugin
+		metricsService := metricsservice.New(metricsservice.Config{
+			Metrics: fakemetrics.New(),
+		})
+		done := spiretest.LoadPlugin(t, builtin(p), &ds,
+			spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
+		t.Cleanup(func() {
+			p.closeStore()
+			done()
+		})
+
+		_, err := ds.Configure(ctx, &spi.ConfigureRequest{
+			Configuration: fmt.Sprintf("path = %q", path),
+		})
+		require.NoError(t, err)
+		return ds
+	})
+}
+
+type PluginSuite struct {
+	spiretest.Suite
+
+	dir      string
+	nextID   int
+	ds       datastore.Plugin
+	kvPlugin *Plugin
+}
+
+func (s *PluginSuite) SetupTest() {
+	s.dir = s.TempDir()
+	s.ds = s.newPlugin(s.nextPath())
+}
+
+func (s *PluginSuite) TearDownTest() {
+	s.kvPlugin.closeStore()
+}
+
+func (s *PluginSuite) nextPath() string {
+	s.nextID++
+	return filepath.Join(s.dir, fmt.Sprintf("datastore%d.kv", s.nextID))
+}
+
+func (s *PluginSuite) newPlugin(path string) datastore.Plugin {
+	p := New()
+	s.kvPlugin = p
+
+	var ds datastore.Plugin
+	metricsService := metricsservice.New(metricsservice.Config{
+		Metrics: fakemetrics.New(),
+	})
+	s.LoadPlugin(builtin(p), &ds,
+		spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
+
+	_, err := ds.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf("path = %q", path),
+	})
+	s.Require().NoError(err)
+	return ds
+}
+
+// reopen closes the store and loads a new plugin from the same file
+func (s *PluginSuite) reopen() {
+	path := s.kvPlugin.store.path
+	s.kvPlugin.closeStore()
+	s.ds = s.newPlugin(path)
+}
+
+func (s *PluginSuite) TestInvalidPluginConfiguration() {
+	_, err := New().Configure(ctx, &spi.ConfigureRequest{})
+	s.Require().EqualError(err, "path must be set")
+
+	_, err = New().Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf("path = %q", filepath.Join(s.dir, "missing", "datastore.kv")),
+	})
+	s.RequireErrorContains(err, "datastore-kv: unable to open")
+}
+
+func (s *PluginSuite) TestNotConfigured() {
+	var ds datastore.Plugin
+	metricsService := metricsservice.New(metricsservice.Config{
+		Metrics: fakemetrics.New(),
+	})
+	s.LoadPlugin(builtin(New()), &ds,
+		spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
+
+	_, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "datastore-kv: not configured")
+}
+
+func (s *PluginSuite) TestBundleCRUD() {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://foo",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}},
+	}
+
+	// fetch non-existent
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Bundle)
+
+	// update non-existent
+	_, err = s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: bundle})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// delete non-existent
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: "spiffe://foo"})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// create
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	// create duplicate
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.RequireGRPCStatus(err, codes.AlreadyExists, "datastore-kv: record already exists")
+
+	// fetch (with denormalized id)
+	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://fOO"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, fresp.Bundle)
+
+	// append
+	aresp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: "spiffe://foo",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("bar")}},
+		},
+	})
+	s.Require().NoError(err)
+	appended := &common.Bundle{
+		TrustDomainId: "spiffe://foo",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("foo")}, {DerBytes: []byte("bar")}},
+	}
+	s.RequireProtoEqual(appended, aresp.Bundle)
+
+	// set on a new bundle
+	bundle2 := &common.Bundle{
+		TrustDomainId: "spiffe://bar",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("bar")}},
+	}
+	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: bundle2})
+	s.Require().NoError(err)
+
+	// list
+	lresp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Bundle{appended, bundle2}, lresp.Bundles)
+
+	// update
+	_, err = s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	// delete
+	dresp, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: "spiffe://foo"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, dresp.Bundle)
+
+	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Bundle{bundle2}, lresp.Bundles)
+}
+
+func (s *PluginSuite) TestListBundlesWithPagination() {
+	var bundles []*common.Bundle
+	for _, td := range []string{"spiffe://c", "spiffe://a", "spiffe://b"} {
+		bundle := &common.Bundle{TrustDomainId: td}
+		s.createBundle(bundle)
+		bundles = append(bundles, bundle)
+	}
+
+	// Bundles are listed in creation order and the token is the ID of the
+	// last bundle in the page
+	resp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{PageSize: 2},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(bundles[:2], resp.Bundles)
+	s.Require().Equal(&datastore.Pagination{Token: "2", PageSize: 2}, resp.Pagination)
+
+	resp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(bundles[2:], resp.Bundles)
+	s.Require().Equal(&datastore.Pagination{Token: "3", PageSize: 2}, resp.Pagination)
+
+	resp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Bundles)
+	s.Require().Equal(&datastore.Pagination{Token: "", PageSize: 2}, resp.Pagination)
+
+	_, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+
+	_, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{Token: "invalid int", PageSize: 2},
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "could not parse token 'invalid int'")
+}
+
+func (s *PluginSuite) TestDeleteBundleWithFederatedEntries() {
+	for _, tt := range []struct {
+		name             string
+		mode             datastore.DeleteBundleRequest_Mode
+		expectedErr      string
+		expectedEntry    bool
+		expectedFederate []string
+	}{
+		{
+			name:          "restrict",
+			mode:          datastore.DeleteBundleRequest_RESTRICT,
+			expectedErr:   "datastore-kv: cannot delete bundle; federated with 1 registration entries",
+			expectedEntry: true,
+		},
+		{
+			name: "delete",
+			mode: datastore.DeleteBundleRequest_DELETE,
+		},
+		{
+			name:          "dissociate",
+			mode:          datastore.DeleteBundleRequest_DISSOCIATE,
+			expectedEntry: true,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			s.kvPlugin.closeStore()
+			s.ds = s.newPlugin(s.nextPath())
+			s.createBundle(&common.Bundle{TrustDomainId: "spiffe://otherdomain.org"})
+			entry := s.createRegistrationEntry(&common.RegistrationEntry{
+				Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+				SpiffeId:      "spiffe://example.org/foo",
+				FederatesWith: []string{"spiffe://otherdomain.org"},
+			})
+
+			_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+				TrustDomainId: "spiffe://otherdomain.org",
+				Mode:          tt.mode,
+			})
+			if tt.expectedErr != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.Unknown, tt.expectedErr)
+			} else {
+				s.Require().NoError(err)
+			}
+
+			resp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+			s.Require().NoError(err)
+			if !tt.expectedEntry {
+				s.Require().Nil(resp.Entry)
+				return
+			}
+			s.Require().NotNil(resp.Entry)
+			if tt.expectedErr == "" {
+				s.Require().Empty(resp.Entry.FederatesWith)
+			} else {
+				s.Require().Equal([]string{"spiffe://otherdomain.org"}, resp.Entry.FederatesWith)
+			}
+		})
+	}
+}
+
+func (s *PluginSuite) TestAttestedNodeCRUD() {
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	// create
+	cresp, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, cresp.Node)
+
+	// create duplicate
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.RequireGRPCStatus(err, codes.AlreadyExists, "datastore-kv: record already exists")
+
+	// fetch
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, fresp.Node)
+
+	// update with a mask only updates the masked fields
+	uresp, err := s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         node.SpiffeId,
+		CertSerialNumber: "",
+		AgentVersion:     "1.0.0",
+		InputMask: &datastore.AttestedNodeMask{
+			CertSerialNumber: true,
+			AgentVersion:     true,
+		},
+	})
+	s.Require().NoError(err)
+	expected := &common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertNotAfter:        node.CertNotAfter,
+		AgentVersion:        "1.0.0",
+		Banned:              true,
+	}
+	s.RequireProtoEqual(expected, uresp.Node)
+
+	// update non-existent
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{SpiffeId: "spiffe://example.org/spire/agent/bar"})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// delete
+	dresp, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(expected, dresp.Node)
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Node)
+
+	// delete non-existent
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestListAttestedNodes() {
+	now := time.Now().Unix()
+	expired := s.createAttestedNode("expired", "1", now-10, nil)
+	banned := s.createAttestedNode("banned", "", now+10, []*common.Selector{{Type: "a", Value: "1"}})
+	valid := s.createAttestedNode("valid", "2", now+10, []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}})
+
+	for _, tt := range []struct {
+		name     string
+		req      *datastore.ListAttestedNodesRequest
+		expected []*common.AttestedNode
+		err      string
+	}{
+		{
+			name:     "all",
+			req:      &datastore.ListAttestedNodesRequest{},
+			expected: []*common.AttestedNode{expired, banned, valid},
+		},
+		{
+			name:     "by expires before",
+			req:      &datastore.ListAttestedNodesRequest{ByExpiresBefore: &wrappers.Int64Value{Value: now}},
+			expected: []*common.AttestedNode{expired},
+		},
+		{
+			name:     "by banned",
+			req:      &datastore.ListAttestedNodesRequest{ByBanned: &wrappers.BoolValue{Value: true}},
+			expected: []*common.AttestedNode{banned},
+		},
+		{
+			name: "by selector match subset",
+			req: &datastore.ListAttestedNodesRequest{BySelectorMatch: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+				Match:     datastore.BySelectors_MATCH_SUBSET,
+			}},
+			expected: []*common.AttestedNode{banned},
+		},
+		{
+			name: "by selector match superset",
+			req: &datastore.ListAttestedNodesRequest{BySelectorMatch: &datastore.BySelectors{
+				Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+				Match:     datastore.BySelectors_MATCH_SUPERSET,
+			}},
+			expected: []*common.AttestedNode{banned, valid},
+		},
+		{
+			name: "by empty selector match",
+			req: &datastore.ListAttestedNodesRequest{BySelectorMatch: &datastore.BySelectors{
+				Match: datastore.BySelectors_MATCH_ANY,
+			}},
+			err: "cannot list by empty selector set",
+		},
+		{
+			name: "with pagination",
+			req: &datastore.ListAttestedNodesRequest{
+				Pagination: &datastore.Pagination{PageSize: 1, Token: "1"},
+			},
+			expected: []*common.AttestedNode{banned},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, tt.req)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, tt.err)
+				return
+			}
+			s.Require().NoError(err)
+			spiretest.RequireProtoListEqual(t, tt.expected, resp.Nodes)
+		})
+	}
+
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{FetchSelectors: true})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Nodes, 3)
+	s.Require().Empty(resp.Nodes[0].Selectors)
+	s.RequireProtoListEqual([]*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}}, resp.Nodes[2].Selectors)
+}
+
+func (s *PluginSuite) TestNodeSelectors() {
+	selectors := &datastore.NodeSelectors{
+		SpiffeId:  "spiffe://example.org/spire/agent/foo",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	}
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{Selectors: selectors})
+	s.Require().NoError(err)
+
+	gresp, err := s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{SpiffeId: selectors.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(selectors, gresp.Selectors)
+
+	lresp, err := s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.NodeSelectors{selectors}, lresp.Selectors)
+
+	// Clearing the selectors removes the node from the listing
+	_, err = s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{SpiffeId: selectors.SpiffeId},
+	})
+	s.Require().NoError(err)
+
+	lresp, err = s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(lresp.Selectors)
+}
+
+func (s *PluginSuite) TestRegistrationEntryCRUD() {
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://b.org"})
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://a.org"})
+
+	// create with an unknown federated bundle
+	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+			SpiffeId:      "spiffe://example.org/foo",
+			FederatesWith: []string{"spiffe://unknown.org"},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, `unable to find federated bundle "spiffe://unknown.org"`)
+
+	// create with missing selectors
+	_, err = s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/foo"},
+	})
+	s.RequireErrorContains(err, "datastore-kv: invalid registration entry: missing selector list")
+
+	// federated trust domains are returned in the order their bundles were
+	// created
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/parent",
+		Ttl:           60,
+		FederatesWith: []string{"spiffe://a.org", "spiffe://b.org"},
+		DnsNames:      []string{"foo"},
+	})
+	s.Require().NotEmpty(entry.EntryId)
+	s.Require().Equal([]string{"spiffe://b.org", "spiffe://a.org"}, entry.FederatesWith)
+	s.Require().Zero(entry.RevisionNumber)
+
+	fresp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fresp.Entry)
+
+	// update with a mask
+	uresp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId: entry.EntryId,
+			Ttl:     120,
+		},
+		InputMask:              &common.RegistrationEntryMask{Ttl: true},
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.Require().NoError(err)
+	entry.Ttl = 120
+	entry.RevisionNumber = 1
+	s.RequireProtoEqual(entry, uresp.Entry)
+
+	// update with a stale revision
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:                  entry,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, "registration entry revision number mismatch: expected 0, current 1")
+
+	// delete with a stale revision
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                entry.EntryId,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, "registration entry revision number mismatch: expected 0, current 1")
+
+	// delete
+	dresp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, dresp.Entry)
+
+	fresp, err = s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Entry)
+
+	// delete non-existent
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestListRegistrationEntries() {
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://federated.org"})
+	a1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+		SpiffeId:  "spiffe://example.org/a1",
+		ParentId:  "spiffe://example.org/parent",
+	})
+	a1b2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+		SpiffeId:      "spiffe://example.org/a1b2",
+		FederatesWith: []string{"spiffe://federated.org"},
+		EntryExpiry:   100,
+	})
+	b2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "b", Value: "2"}},
+		SpiffeId:  "spiffe://example.org/b2",
+		ParentId:  "spiffe://example.org/parent",
+		Admin:     true,
+	})
+
+	bySelectors := func(match datastore.BySelectors_MatchBehavior, selectors ...*common.Selector) *datastore.ListRegistrationEntriesRequest {
+		return &datastore.ListRegistrationEntriesRequest{
+			BySelectors: &datastore.BySelectors{Selectors: selectors, Match: match},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		req      *datastore.ListRegistrationEntriesRequest
+		expected []*common.RegistrationEntry
+		err      string
+	}{
+		{
+			name:     "all",
+			req:      &datastore.ListRegistrationEntriesRequest{},
+			expected: []*common.RegistrationEntry{a1, a1b2, b2},
+		},
+		{
+			name:     "by parent ID",
+			req:      &datastore.ListRegistrationEntriesRequest{ByParentId: &wrappers.StringValue{Value: "spiffe://example.org/parent"}},
+			expected: []*common.RegistrationEntry{a1, b2},
+		},
+		{
+			name:     "by admin",
+			req:      &datastore.ListRegistrationEntriesRequest{ByAdmin: &wrappers.BoolValue{Value: true}},
+			expected: []*common.RegistrationEntry{b2},
+		},
+		{
+			name:     "by expires before",
+			req:      &datastore.ListRegistrationEntriesRequest{ByExpiresBefore: &wrappers.Int64Value{Value: 200}},
+			expected: []*common.RegistrationEntry{a1b2},
+		},
+		{
+			name:     "by expires after",
+			req:      &datastore.ListRegistrationEntriesRequest{ByExpiresAfter: &wrappers.Int64Value{Value: 200}},
+			expected: []*common.RegistrationEntry{a1, b2},
+		},
+		{
+			name: "by federates with",
+			req: &datastore.ListRegistrationEntriesRequest{ByFederatesWith: &datastore.ByFederatesWith{
+				TrustDomains: []string{"spiffe://federated.org"},
+				Match:        datastore.ByFederatesWith_MATCH_ANY,
+			}},
+			expected: []*common.RegistrationEntry{a1b2},
+		},
+		{
+			name:     "by selectors exact",
+			req:      bySelectors(datastore.BySelectors_MATCH_EXACT, &common.Selector{Type: "a", Value: "1"}),
+			expected: []*common.RegistrationEntry{a1},
+		},
+		{
+			name:     "by selectors subset",
+			req:      bySelectors(datastore.BySelectors_MATCH_SUBSET, &common.Selector{Type: "a", Value: "1"}, &common.Selector{Type: "b", Value: "2"}),
+			expected: []*common.RegistrationEntry{a1, a1b2, b2},
+		},
+		{
+			name:     "by selectors superset",
+			req:      bySelectors(datastore.BySelectors_MATCH_SUPERSET, &common.Selector{Type: "b", Value: "2"}),
+			expected: []*common.RegistrationEntry{a1b2, b2},
+		},
+		{
+			name:     "by selectors any",
+			req:      bySelectors(datastore.BySelectors_MATCH_ANY, &common.Selector{Type: "a", Value: "1"}, &common.Selector{Type: "c", Value: "3"}),
+			expected: []*common.RegistrationEntry{a1, a1b2},
+		},
+		{
+			name: "by empty selectors",
+			req:  bySelectors(datastore.BySelectors_MATCH_ANY),
+			err:  "cannot list by empty selector set",
+		},
+		{
+			name: "by empty federates with",
+			req: &datastore.ListRegistrationEntriesRequest{ByFederatesWith: &datastore.ByFederatesWith{
+				Match: datastore.ByFederatesWith_MATCH_ANY,
+			}},
+			err: "cannot list by empty federates with set",
+		},
+		{
+			name: "with pagination",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId: &wrappers.StringValue{Value: "spiffe://example.org/parent"},
+				Pagination: &datastore.Pagination{PageSize: 1, Token: "1"},
+			},
+			expected: []*common.RegistrationEntry{b2},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, tt.req)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, tt.err)
+				return
+			}
+			s.Require().NoError(err)
+			spiretest.RequireProtoListEqual(t, tt.expected, resp.Entries)
+		})
+	}
+
+	// Expired entries are pruned
+	_, err := s.ds.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{ExpiresBefore: 200})
+	s.Require().NoError(err)
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.RegistrationEntry{a1, b2}, resp.Entries)
+}
+
+func (s *PluginSuite) TestJoinTokens() {
+	for _, token := range []*datastore.JoinToken{
+		{Token: "foo", Expiry: 100},
+		{Token: "bar", Expiry: 200},
+	} {
+		_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: token})
+		s.Require().NoError(err)
+	}
+
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: &datastore.JoinToken{Token: "foo"}})
+	s.RequireGRPCStatus(err, codes.Unknown, "token and expiry are required")
+
+	fresp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "foo"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&datastore.JoinToken{Token: "foo", Expiry: 100}, fresp.JoinToken)
+
+	// tokens are listed in token order
+	lresp, err := s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.JoinToken{
+		{Token: "bar", Expiry: 200},
+		{Token: "foo", Expiry: 100},
+	}, lresp.JoinTokens)
+
+	_, err = s.ds.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{ExpiresBefore: 150})
+	s.Require().NoError(err)
+
+	fresp, err = s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "foo"})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.JoinToken)
+
+	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{Token: "bar"})
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{Token: "bar"})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestFederationRelationshipCRUD() {
+	relationship := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org/bundle",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	}
+
+	_, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{TrustDomainId: "spiffe://otherdomain.org"},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-kv: invalid federation relationship: missing bundle endpoint URL")
+
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{FederationRelationship: relationship})
+	s.Require().NoError(err)
+
+	fresp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{TrustDomainId: "spiffe://OTHERDOMAIN.org"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(relationship, fresp.FederationRelationship)
+
+	relationship.BundleEndpointUrl = "https://otherdomain.org/new"
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{FederationRelationship: relationship})
+	s.Require().NoError(err)
+
+	lresp, err := s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.FederationRelationship{relationship}, lresp.FederationRelationships)
+
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{TrustDomainId: relationship.TrustDomainId})
+	s.Require().NoError(err)
+
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{FederationRelationship: relationship})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestChangeEvents() {
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://otherdomain.org"})
+	s.createAttestedNode("foo", "1", 0, nil)
+
+	resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Events, 2)
+	s.Require().Equal(int64(2), resp.LatestRevision)
+	s.Require().Equal(datastore.ChangeEvent_BUNDLE, resp.Events[0].ResourceType)
+	s.Require().Equal(datastore.ChangeEvent_ATTESTED_NODE, resp.Events[1].ResourceType)
+
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{AfterRevision: 1, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Events, 1)
+	s.Require().Equal(int64(2), resp.Events[0].Revision)
+
+	_, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{Limit: -1})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot list change events with a negative limit")
+
+	// Revisions are never reused once events are pruned
+	_, err = s.ds.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{CreatedBefore: time.Now().Unix() + 10})
+	s.Require().NoError(err)
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://thirddomain.org"})
+
+	resp, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Events, 1)
+	s.Require().Equal(int64(3), resp.Events[0].Revision)
+}
+
+func (s *PluginSuite) TestRecordsPersistAcrossRestarts() {
+	bundle := &common.Bundle{TrustDomainId: "spiffe://otherdomain.org"}
+	s.createBundle(bundle)
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}},
+		SpiffeId:      "spiffe://example.org/foo",
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+	})
+	node := s.createAttestedNode("foo", "1", 0, []*common.Selector{{Type: "a", Value: "1"}})
+
+	s.reopen()
+
+	bresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, bresp.Bundle)
+
+	eresp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, eresp.Entry)
+
+	nresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, nresp.Node)
+
+	// IDs keep increasing after a restart
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://thirddomain.org"})
+	lresp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination: &datastore.Pagination{PageSize: 1, Token: "1"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(lresp.Bundles, 1)
+	s.Require().Equal("spiffe://thirddomain.org", lresp.Bundles[0].TrustDomainId)
+	s.Require().Equal("2", lresp.Pagination.Token)
+
+	cresp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(5), cresp.LatestRevision)
+}
+
+func (s *PluginSuite) createBundle(bundle *common.Bundle) {
+	_, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+}
+
+func (s *PluginSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: entry})
+	s.Require().NoError(err)
+	return resp.Entry
+}
+
+func (s *PluginSuite) createAttestedNode(name, serial string, notAfter int64, selectors []*common.Selector) *common.AttestedNode {
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/" + name,
+		AttestationDataType: "test",
+		CertSerialNumber:    serial,
+		CertNotAfter:        notAfter,
+		Banned:              serial == "",
+	}
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+
+	if len(selectors) > 0 {
+		_, err = s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  node.SpiffeId,
+				Selectors: selectors,
+			},
+		})
+		s.Require().NoError(err)
+	}
+	return node
+}
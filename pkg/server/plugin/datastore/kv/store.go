@@ -0,0 +1,577 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+// The store keeps every key/value pair in memory and persists them in a
+// single append-only log file. The file starts with a magic header and is
+// followed by one record per committed transaction:
+//
+//	length (uint32, little endian) | checksum (uint32, little endian) | payload
+//
+// The checksum is the CRC-32C of the payload, which is a sequence of
+// operations:
+//
+//	op (byte) | key length (uvarint) | key | value length (uvarint) | value
+//
+// where the value is omitted for deletions. Records are synced to disk
+// before a transaction is reported as committed. A final record that was
+// only partially written when the process crashed runs past the end of the
+// log or fails the checksum verification when the log is replayed, and is
+// truncated away, so a transaction is either fully applied or not applied at
+// all. Any other corruption fails the replay.
+//
+// The log is compacted once it grows well past the size of the live data by
+// writing the live data to a temporary file that is atomically renamed over
+// the log.
+//
+// Only one process can use the log at a time. This is enforced with an
+// exclusive lock on a lock file next to the log, since the log file itself is
+// replaced by compactions.
+
+const (
+	opPut    byte = 1
+	opDelete byte = 2
+
+	recordHeaderSize = 8
+
+	// compactBatchSize is the number of keys written per record when the
+	// log is compacted
+	compactBatchSize = 1000
+)
+
+var (
+	logMagic = []byte("SPIREKV1")
+
+	// compactMinSize is the log size below which the log is never compacted
+	compactMinSize int64 = 1 << 20
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptRecord = errors.New("corrupt record")
+
+	// errTornRecord is returned when replaying a final record that was only
+	// partially written
+	errTornRecord = errors.New("torn record")
+)
+
+type store struct {
+	path string
+	log  hclog.Logger
+
+	// lock is the lock file, which is locked while the store is open
+	lock *os.File
+
+	mu   sync.RWMutex
+	f    *os.File
+	data map[string][]byte
+
+	// err is set once the log is left in an unknown state, e.g. a partial
+	// record could not be rolled back. Updates fail from then on so that
+	// committed transactions are not lost.
+	err error
+
+	// size is the size of the log file and live the approximate size the
+	// live data takes in the log
+	size int64
+	live int64
+}
+
+// openStore opens the store backed by the log file at the given path,
+// creating it if it does not exist.
+func openStore(path string, log hclog.Logger) (*store, error) {
+	lock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &store{
+		path: path,
+		log:  log,
+		lock: lock,
+		data: make(map[string][]byte),
+	}
+
+	if err := s.load(); err != nil {
+		lock.Close()
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		lock.Close()
+		return nil, err
+	}
+	s.f = f
+
+	if s.shouldCompact() {
+		if err := s.compact(); err != nil {
+			s.f.Close()
+			lock.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// lockFile opens the lock file at the given path, creating it if it does not
+// exist, and locks it exclusively. It fails if the lock is already held. The
+// lock is released when the file is closed.
+func lockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, kvError.New("%s is in use by another process", path)
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// load replays the log into memory. A new log is created if there is none
+// and a torn final record left by a crash is truncated.
+func (s *store) load() error {
+	f, err := os.OpenFile(s.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < int64(len(logMagic)) {
+		// The log is new, or its creation was interrupted before the magic
+		// header was fully written
+		header := make([]byte, info.Size())
+		if _, err := io.ReadFull(f, header); err != nil {
+			return err
+		}
+		if !bytes.HasPrefix(logMagic, header) {
+			return kvError.New("%s is not a kv datastore file", s.path)
+		}
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.WriteAt(logMagic, 0); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		s.size = int64(len(logMagic))
+		return syncDir(filepath.Dir(s.path))
+	}
+
+	r := bufio.NewReader(f)
+	magic := make([]byte, len(logMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, logMagic) {
+		return kvError.New("%s is not a kv datastore file", s.path)
+	}
+
+	offset := int64(len(logMagic))
+	for {
+		n, err := s.replayRecord(r, info.Size()-offset)
+		if err == io.EOF {
+			break
+		}
+		if err == errTornRecord {
+			s.log.Warn("Truncating torn final record of the datastore log", "path", s.path, "offset", offset, "size", info.Size())
+			if err := f.Truncate(offset); err != nil {
+				return err
+			}
+			if err := f.Sync(); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return kvError.New("%s is corrupt at offset %d: %v", s.path, offset, err)
+		}
+		offset += n
+	}
+	s.size = offset
+	return nil
+}
+
+// replayRecord reads the next record from the log, which has the given
+// number of bytes remaining, and applies it. It returns the size of the
+// record, io.EOF if the log ends cleanly, or errTornRecord if the record is
+// the final one and was only partially written.
+func (s *store) replayRecord(r *bufio.Reader, remaining int64) (int64, error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		switch err {
+		case io.EOF:
+			return 0, io.EOF
+		case io.ErrUnexpectedEOF:
+			return 0, errTornRecord
+		}
+		return 0, err
+	}
+
+	// A record that runs past the end of the log was cut short. The length
+	// is checked before allocating the payload so that a corrupt length
+	// does not cause a huge allocation.
+	length := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	size := int64(recordHeaderSize) + int64(length)
+	if size > remaining {
+		return 0, errTornRecord
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, err
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		// The final record may have been extended before its contents
+		// reached the disk. Anywhere else, the log is corrupt.
+		if size == remaining {
+			return 0, errTornRecord
+		}
+		return 0, errCorruptRecord
+	}
+
+	writes, err := decodeRecord(payload)
+	if err != nil {
+		return 0, err
+	}
+	s.apply(writes)
+	return size, nil
+}
+
+// Close closes the log file and releases the lock
+func (s *store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.f.Close()
+	s.lock.Close()
+	return err
+}
+
+// view runs the function in a read-only transaction. Read-only
+// transactions run concurrently with each other but not with updates.
+func (s *store) view(fn func(tx *txn) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&txn{s: s})
+}
+
+// update runs the function in a read-write transaction. Writes are staged
+// in the transaction and only committed if the function succeeds. Updates
+// are serialized.
+func (s *store) update(fn func(tx *txn) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	tx := &txn{
+		s:      s,
+		writes: make(map[string][]byte),
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	if err := s.appendRecord(tx.writes); err != nil {
+		return err
+	}
+	s.apply(tx.writes)
+
+	if s.shouldCompact() {
+		// The transaction is already durable, so a failed compaction only
+		// leaves a larger log behind.
+		if err := s.compact(); err != nil {
+			s.log.Error("Failed to compact the datastore log", "path", s.path, "error", err)
+		}
+	}
+	return nil
+}
+
+// appendRecord writes the staged writes as a single record and syncs it to
+// disk. If the write fails, the log is truncated back to its previous size
+// so that later records are not appended after a partial one.
+func (s *store) appendRecord(writes map[string][]byte) error {
+	record := encodeRecord(sortedKeys(writes), writes)
+	if _, err := s.f.Write(record); err != nil {
+		s.rollback()
+		return kvError.Wrap(err)
+	}
+	if err := s.f.Sync(); err != nil {
+		s.rollback()
+		return kvError.Wrap(err)
+	}
+	s.size += int64(len(record))
+	return nil
+}
+
+func (s *store) rollback() {
+	if err := s.f.Truncate(s.size); err != nil {
+		s.log.Error("Failed to roll back the datastore log", "path", s.path, "error", err)
+		s.fail(err)
+	}
+}
+
+// fail marks the log as being in an unknown state, so that later updates
+// fail instead of appending records the log may not replay.
+func (s *store) fail(err error) {
+	s.err = kvError.New("datastore log is in an unknown state and must be reopened: %v", err)
+}
+
+func (s *store) apply(writes map[string][]byte) {
+	for key, value := range writes {
+		if old, ok := s.data[key]; ok {
+			s.live -= entrySize(key, old)
+		}
+		if value == nil {
+			delete(s.data, key)
+			continue
+		}
+		s.data[key] = value
+		s.live += entrySize(key, value)
+	}
+}
+
+func (s *store) shouldCompact() bool {
+	return s.size > compactMinSize && s.size > 2*s.live
+}
+
+// compact rewrites the log so that it only holds the live data. The new log
+// is opened for appending before it replaces the old one, so that nothing can
+// fail between the replacement and the switch to the new log.
+func (s *store) compact() error {
+	tmpPath := s.path + ".compact"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	size, err := s.writeLiveData(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		f.Close()
+		return err
+	}
+	s.f.Close()
+	s.f = f
+
+	s.log.Debug("Compacted the datastore log", "path", s.path, "old_size", s.size, "new_size", size)
+	s.size = size
+
+	// Until the rename is durable, a crash may bring back the old log,
+	// which lacks the records appended from now on
+	if err := syncDir(filepath.Dir(s.path)); err != nil {
+		s.fail(err)
+		return err
+	}
+	return nil
+}
+
+func (s *store) writeLiveData(f *os.File) (int64, error) {
+	w := bufio.NewWriter(f)
+	size := int64(len(logMagic))
+	if _, err := w.Write(logMagic); err != nil {
+		return 0, err
+	}
+
+	keys := sortedKeys(s.data)
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > compactBatchSize {
+			batch = batch[:compactBatchSize]
+		}
+		keys = keys[len(batch):]
+
+		record := encodeRecord(batch, s.data)
+		if _, err := w.Write(record); err != nil {
+			return 0, err
+		}
+		size += int64(len(record))
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// txn is a transaction on the store. Reads observe the writes staged by the
+// transaction itself.
+type txn struct {
+	s *store
+
+	// writes holds the staged writes of a read-write transaction. A nil
+	// value stages a deletion.
+	writes map[string][]byte
+}
+
+// get returns the value of the key
+func (tx *txn) get(key string) ([]byte, bool) {
+	if value, ok := tx.writes[key]; ok {
+		return value, value != nil
+	}
+	value, ok := tx.s.data[key]
+	return value, ok
+}
+
+// put stages the value of the key. The value must not be modified
+// afterwards.
+func (tx *txn) put(key string, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	tx.writes[key] = value
+}
+
+// delete stages the deletion of the key
+func (tx *txn) delete(key string) {
+	tx.writes[key] = nil
+}
+
+// scan calls the function for each key with the given prefix, in key
+// order. The function must not modify the transaction.
+func (tx *txn) scan(prefix string, fn func(key string, value []byte) error) error {
+	var keys []string
+	for key := range tx.s.data {
+		if strings.HasPrefix(key, prefix) {
+			if _, staged := tx.writes[key]; !staged {
+				keys = append(keys, key)
+			}
+		}
+	}
+	for key, value := range tx.writes {
+		if value != nil && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, _ := tx.get(key)
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeRecord(keys []string, values map[string][]byte) []byte {
+	var payload []byte
+	var buf [binary.MaxVarintLen64]byte
+	for _, key := range keys {
+		value := values[key]
+		if value == nil {
+			payload = append(payload, opDelete)
+		} else {
+			payload = append(payload, opPut)
+		}
+		payload = append(payload, buf[:binary.PutUvarint(buf[:], uint64(len(key)))]...)
+		payload = append(payload, key...)
+		if value != nil {
+			payload = append(payload, buf[:binary.PutUvarint(buf[:], uint64(len(value)))]...)
+			payload = append(payload, value...)
+		}
+	}
+
+	record := make([]byte, recordHeaderSize, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	return append(record, payload...)
+}
+
+func decodeRecord(payload []byte) (map[string][]byte, error) {
+	writes := make(map[string][]byte)
+	for len(payload) > 0 {
+		op := payload[0]
+		payload = payload[1:]
+
+		key, rest, err := decodeBytes(payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = rest
+
+		switch op {
+		case opPut:
+			value, rest, err := decodeBytes(payload)
+			if err != nil {
+				return nil, err
+			}
+			payload = rest
+			writes[string(key)] = value
+		case opDelete:
+			writes[string(key)] = nil
+		default:
+			return nil, errCorruptRecord
+		}
+	}
+	return writes, nil
+}
+
+func decodeBytes(b []byte) ([]byte, []byte, error) {
+	n, size := binary.Uvarint(b)
+	if size <= 0 || uint64(len(b)-size) < n {
+		return nil, nil, errCorruptRecord
+	}
+	b = b[size:]
+	// The value is copied so it does not pin the whole payload in memory
+	return append([]byte{}, b[:n]...), b[n:], nil
+}
+
+func entrySize(key string, value []byte) int64 {
+	return int64(len(key) + len(value) + 2*binary.MaxVarintLen32 + 1)
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// syncDir syncs the directory so that file creations and renames in it are
+// durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
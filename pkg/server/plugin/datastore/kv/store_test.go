@@ -0,0 +1,289 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreUpdateAndView(t *testing.T) {
+	s, path := openTestStore(t)
+
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("a/1", []byte("one"))
+		tx.put("a/2", []byte("two"))
+		tx.put("b/1", []byte("three"))
+
+		// Staged writes are visible within the transaction
+		value, ok := tx.get("a/1")
+		assert.True(t, ok)
+		assert.Equal(t, "one", string(value))
+		return nil
+	}))
+
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.delete("a/1")
+		tx.put("a/3", nil)
+		assert.Equal(t, []string{"a/2", "a/3"}, scanKeys(t, tx, "a/"))
+		return nil
+	}))
+
+	// A failed transaction discards its writes
+	err := s.update(func(tx *txn) error {
+		tx.put("a/4", []byte("four"))
+		tx.delete("b/1")
+		return errors.New("oh no")
+	})
+	require.EqualError(t, err, "oh no")
+
+	expected := map[string]string{
+		"a/2": "two",
+		"a/3": "",
+		"b/1": "three",
+	}
+	requireStoreData(t, s, expected)
+
+	// The data is replayed from the log
+	require.NoError(t, s.Close())
+	s = reopenTestStore(t, path)
+	requireStoreData(t, s, expected)
+}
+
+func TestStoreTruncatesTornRecord(t *testing.T) {
+	s, path := openTestStore(t)
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("a", []byte("1"))
+		return nil
+	}))
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("b", []byte("2"))
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	// Simulate a crash in the middle of writing the last record
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	s = reopenTestStore(t, path)
+	requireStoreData(t, s, map[string]string{"a": "1"})
+
+	// New records are appended after the last intact record
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("c", []byte("3"))
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	s = reopenTestStore(t, path)
+	requireStoreData(t, s, map[string]string{"a": "1", "c": "3"})
+}
+
+func TestStoreTruncatesCorruptFinalRecord(t *testing.T) {
+	s, path := openTestStore(t)
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("a", []byte("1"))
+		return nil
+	}))
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("b", []byte("2"))
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	// Flip the last byte of the value of the last record so its checksum
+	// no longer matches
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	s = reopenTestStore(t, path)
+	requireStoreData(t, s, map[string]string{"a": "1"})
+}
+
+func TestStoreTruncatesTornRecordHeader(t *testing.T) {
+	s, path := openTestStore(t)
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("a", []byte("1"))
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	// The length of a torn record may claim far more than what is left in
+	// the log, and only part of the header may have been written
+	for _, tail := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		{1, 0, 0},
+	} {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		require.NoError(t, err)
+		_, err = f.Write(tail)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		s = reopenTestStore(t, path)
+		requireStoreData(t, s, map[string]string{"a": "1"})
+		require.NoError(t, s.Close())
+	}
+}
+
+func TestStoreFailsOnCorruptRecord(t *testing.T) {
+	s, path := openTestStore(t)
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("a", []byte("1"))
+		return nil
+	}))
+	size := s.size
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("b", []byte("2"))
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	// Flip the last byte of the value of the first record. The record is
+	// followed by another one, so it was not torn by a crash.
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	data[size-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	_, err = openStore(path, hclog.NewNullLogger())
+	require.EqualError(t, err, fmt.Sprintf("datastore-kv: %s is corrupt at offset %d: corrupt record", path, len(logMagic)))
+
+	// The log is left intact
+	actual, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, actual)
+}
+
+func TestStoreIsLocked(t *testing.T) {
+	s, path := openTestStore(t)
+
+	_, err := openStore(path, hclog.NewNullLogger())
+	require.EqualError(t, err, "datastore-kv: "+path+".lock is in use by another process")
+
+	// The lock is released when the store is closed
+	require.NoError(t, s.Close())
+	reopenTestStore(t, path)
+}
+
+func TestStoreRejectsForeignFile(t *testing.T) {
+	dir := tempDir(t)
+
+	path := filepath.Join(dir, "foreign")
+	require.NoError(t, ioutil.WriteFile(path, []byte("NOT A KV FILE"), 0600))
+	_, err := openStore(path, hclog.NewNullLogger())
+	require.EqualError(t, err, "datastore-kv: "+path+" is not a kv datastore file")
+
+	path = filepath.Join(dir, "short")
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo"), 0600))
+	_, err = openStore(path, hclog.NewNullLogger())
+	require.EqualError(t, err, "datastore-kv: "+path+" is not a kv datastore file")
+}
+
+func TestStoreRecoversPartialHeader(t *testing.T) {
+	path := filepath.Join(tempDir(t), "datastore.kv")
+	require.NoError(t, ioutil.WriteFile(path, logMagic[:3], 0600))
+
+	s := reopenTestStore(t, path)
+	requireStoreData(t, s, map[string]string{})
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, logMagic, data)
+}
+
+func TestStoreCompaction(t *testing.T) {
+	oldCompactMinSize := compactMinSize
+	compactMinSize = 256
+	defer func() {
+		compactMinSize = oldCompactMinSize
+	}()
+
+	s, path := openTestStore(t)
+	value := []byte("0123456789abcdef")
+	for i := 0; i < 64; i++ {
+		require.NoError(t, s.update(func(tx *txn) error {
+			tx.put("key", value)
+			return nil
+		}))
+	}
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.put("other", []byte("value"))
+		return nil
+	}))
+
+	// The log only holds a fraction of the overwritten values
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, info.Size() < 64*int64(len(value)), "log was not compacted: size=%d", info.Size())
+	require.Equal(t, info.Size(), s.size)
+
+	expected := map[string]string{
+		"key":   string(value),
+		"other": "value",
+	}
+	requireStoreData(t, s, expected)
+
+	// Appends after a compaction go to the compacted log
+	require.NoError(t, s.update(func(tx *txn) error {
+		tx.delete("other")
+		return nil
+	}))
+	require.NoError(t, s.Close())
+
+	s = reopenTestStore(t, path)
+	requireStoreData(t, s, map[string]string{"key": string(value)})
+}
+
+func openTestStore(t *testing.T) (*store, string) {
+	path := filepath.Join(tempDir(t), "datastore.kv")
+	return reopenTestStore(t, path), path
+}
+
+func reopenTestStore(t *testing.T, path string) *store {
+	s, err := openStore(path, hclog.NewNullLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		s.Close()
+	})
+	return s
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "kv-store-test-")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+func scanKeys(t *testing.T, tx *txn, prefix string) []string {
+	var keys []string
+	require.NoError(t, tx.scan(prefix, func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	return keys
+}
+
+func requireStoreData(t *testing.T, s *store, expected map[string]string) {
+	actual := make(map[string]string)
+	require.NoError(t, s.view(func(tx *txn) error {
+		return tx.scan("", func(key string, value []byte) error {
+			actual[key] = string(value)
+			return nil
+		})
+	}))
+	require.Equal(t, expected, actual)
+}
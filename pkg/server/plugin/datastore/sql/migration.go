@@ -19,7 +19,7 @@ import (
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 14
+	latestSchemaVersion = 20
 )
 
 var (
@@ -178,6 +178,9 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		&Selector{},
 		&Migration{},
 		&DNSName{},
+		&FederationRelationship{},
+		&ChangeEvent{},
+		&CAJournal{},
 	}
 
 	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
@@ -211,6 +214,32 @@ func tableOptionsForDialect(tx *gorm.DB, dbType string) *gorm.DB {
 	return tx
 }
 
+// migrationDescriptions describes the changes made by each migration step,
+// keyed by the schema version the step migrates to. A description must be
+// added here along with each new migration step.
+var migrationDescriptions = map[int]string{
+	1:  "Delete soft-deleted records",
+	2:  "Create the federated registration entries table",
+	3:  "Normalize the stored SPIFFE IDs",
+	4:  "Store bundles as serialized protobufs and drop the CA certificates table",
+	5:  "Add the admin column to registration entries",
+	6:  "Add the downstream column to registration entries",
+	7:  "Add the expiry column to registration entries",
+	8:  "Create the DNS names table",
+	9:  "Index registration entries by SPIFFE ID and parent ID and recreate the selectors table",
+	10: "Index registration entries by expiry",
+	11: "Index federated registration entries by registration entry ID",
+	12: "Add the code version column to the migrations table",
+	13: "Add the new certificate columns to attested nodes",
+	14: "Add the revision number column to registration entries",
+	15: "Create the federation relationships table",
+	16: "Create the change events table",
+	17: "Add the agent version, plugins, last seen and re-attestation columns to attested nodes",
+	18: "Create the CA journals table",
+	19: "Add the X509-SVID profile column to registration entries",
+	20: "Add the JWT-SVID TTL column to registration entries",
+}
+
 func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut int, err error) {
 	log.Info("migrating version", telemetry.VersionInfo, currVersion)
 
@@ -247,6 +276,18 @@ func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut
 		err = migrateToV13(tx)
 	case 13:
 		err = migrateToV14(tx)
+	case 14:
+		err = migrateToV15(tx)
+	case 15:
+		err = migrateToV16(tx)
+	case 16:
+		err = migrateToV17(tx)
+	case 17:
+		err = migrateToV18(tx)
+	case 18:
+		err = migrateToV19(tx)
+	case 19:
+		err = migrateToV20(tx)
 	default:
 		err = sqlError.New("no migration support for version %d", currVersion)
 	}
@@ -481,6 +522,48 @@ func migrateToV14(tx *gorm.DB) error {
 	return nil
 }
 
+func migrateToV15(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&FederationRelationship{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV16(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&ChangeEvent{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV17(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV18(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&CAJournal{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV19(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV20(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 func addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx *gorm.DB) error {
 	// GORM creates the federated_registration_entries implicitly with a primary
 	// key tuple (bundle_id, registered_entry_id). Unfortunately, MySQL5 does
@@ -0,0 +1,183 @@
+package sql
+
+import (
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/jinzhu/gorm"
+)
+
+// MigrationStep is a step that migrates the schema to the next version
+type MigrationStep struct {
+	// Version is the schema version the step migrates to
+	Version int
+
+	// Description describes the changes made by the step
+	Description string
+}
+
+// MigrationPlan describes the migrations that bring a database schema up to
+// the version supported by this binary
+type MigrationPlan struct {
+	// DatabaseType is the type of the database (e.g. postgres)
+	DatabaseType string
+
+	// NewDatabase is true if the database has not been initialized. The
+	// schema of a new database is created at the target version without
+	// running any migration step.
+	NewDatabase bool
+
+	// SchemaVersion is the current schema version of the database
+	SchemaVersion int
+
+	// CodeVersion is the version of SPIRE that last migrated the database.
+	// It is empty for databases migrated before 0.9.
+	CodeVersion string
+
+	// TargetVersion is the schema version supported by this binary
+	TargetVersion int
+
+	// Steps are the steps that migrate the schema to the target version
+	Steps []MigrationStep
+}
+
+// Migrator migrates the schema of a database outside of the server, so that
+// migrations can be staged and run in a maintenance window.
+type Migrator struct {
+	db     *gorm.DB
+	dbType string
+	log    hclog.Logger
+}
+
+// NewMigrator connects to the database configured by the given sql datastore
+// plugin configuration. Unlike the plugin, it does not migrate the schema
+// when connecting.
+func NewMigrator(pluginConfig string, log hclog.Logger) (*Migrator, error) {
+	config := &configuration{}
+	if err := hcl.Decode(config, pluginConfig); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	db, _, _, err := connectDB(config, false, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:     db,
+		dbType: config.DatabaseType,
+		log:    log,
+	}, nil
+}
+
+// Close closes the connection to the database
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Plan returns the migrations required to bring the schema up to date,
+// without changing the database. It fails if the schema is newer than the
+// version supported by this binary, since migrations cannot be rolled back.
+func (m *Migrator) Plan() (*MigrationPlan, error) {
+	plan := &MigrationPlan{
+		DatabaseType:  m.dbType,
+		TargetVersion: latestSchemaVersion,
+	}
+
+	plan.NewDatabase = !m.db.HasTable(&Bundle{})
+	if err := m.db.Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if plan.NewDatabase {
+		return plan, nil
+	}
+
+	if codeVersion.Major != 0 {
+		return nil, sqlError.New("current migration code not compatible with current release version")
+	}
+
+	// Databases created before version 11 may not have a migrations table,
+	// in which case the schema is at version 0.
+	if m.db.HasTable(&Migration{}) {
+		migration := new(Migration)
+		err := m.db.First(migration).Error
+		switch {
+		case gorm.IsRecordNotFoundError(err):
+		case err != nil:
+			return nil, sqlError.Wrap(err)
+		default:
+			plan.SchemaVersion = migration.Version
+			plan.CodeVersion = migration.CodeVersion
+		}
+	}
+
+	if plan.SchemaVersion > latestSchemaVersion {
+		return nil, sqlError.New("schema version %d is newer than the latest version %d supported by this binary; migrations cannot be rolled back, upgrade SPIRE Server", plan.SchemaVersion, latestSchemaVersion)
+	}
+
+	for version := plan.SchemaVersion + 1; version <= latestSchemaVersion; version++ {
+		plan.Steps = append(plan.Steps, MigrationStep{
+			Version:     version,
+			Description: migrationDescriptions[version],
+		})
+	}
+
+	return plan, nil
+}
+
+// Migrate brings the schema up to date and returns the plan that was
+// applied. All the migration steps run inside a single transaction, so a
+// failed migration leaves the schema untouched. Note that MySQL commits
+// schema changes implicitly, so steps that completed before the failure are
+// kept.
+func (m *Migrator) Migrate() (*MigrationPlan, error) {
+	plan, err := m.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.NewDatabase {
+		if err := initDB(m.db, m.dbType, m.log); err != nil {
+			return nil, err
+		}
+		return plan, nil
+	}
+
+	if len(plan.Steps) == 0 {
+		return plan, nil
+	}
+
+	tx := m.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Older schemas may lack the migrations table, or some of its columns,
+	// which are needed to record the version after each step
+	if err := tx.AutoMigrate(&Migration{}).Error; err != nil {
+		tx.Rollback()
+		return nil, sqlError.Wrap(err)
+	}
+	if err := tx.Assign(Migration{}).FirstOrCreate(new(Migration)).Error; err != nil {
+		tx.Rollback()
+		return nil, sqlError.Wrap(err)
+	}
+
+	schemaVersion := plan.SchemaVersion
+	for schemaVersion < latestSchemaVersion {
+		schemaVersion, err = migrateVersion(tx, schemaVersion, m.log)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,138 @@
+package sql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationDescriptions(t *testing.T) {
+	require.Len(t, migrationDescriptions, latestSchemaVersion)
+	for v := 1; v <= latestSchemaVersion; v++ {
+		require.NotEmpty(t, migrationDescriptions[v], "no description for migration to version %d", v)
+	}
+}
+
+func TestMigratorNewDatabase(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := newTestMigrator(t, filepath.Join(dir, "new.sqlite3"))
+	defer m.Close()
+
+	expected := &MigrationPlan{
+		DatabaseType:  SQLite,
+		NewDatabase:   true,
+		TargetVersion: latestSchemaVersion,
+	}
+
+	plan, err := m.Plan()
+	require.NoError(t, err)
+	require.Equal(t, expected, plan)
+
+	plan, err = m.Migrate()
+	require.NoError(t, err)
+	require.Equal(t, expected, plan)
+
+	plan, err = m.Plan()
+	require.NoError(t, err)
+	require.Equal(t, &MigrationPlan{
+		DatabaseType:  SQLite,
+		SchemaVersion: latestSchemaVersion,
+		TargetVersion: latestSchemaVersion,
+	}, plan)
+}
+
+func TestMigratorOutdatedSchema(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < latestSchemaVersion; i++ {
+		i := i
+		t.Run(fmt.Sprintf("v%d", i), func(t *testing.T) {
+			dbPath := filepath.Join(dir, fmt.Sprintf("v%d.sqlite3", i))
+			require.NoError(t, dumpDB(dbPath, migrationDump(i)))
+
+			m := newTestMigrator(t, dbPath)
+			defer m.Close()
+
+			var expectedSteps []MigrationStep
+			for v := i + 1; v <= latestSchemaVersion; v++ {
+				expectedSteps = append(expectedSteps, MigrationStep{
+					Version:     v,
+					Description: migrationDescriptions[v],
+				})
+			}
+
+			plan, err := m.Plan()
+			require.NoError(t, err)
+			require.False(t, plan.NewDatabase)
+			require.Equal(t, i, plan.SchemaVersion)
+			require.Equal(t, latestSchemaVersion, plan.TargetVersion)
+			require.Equal(t, expectedSteps, plan.Steps)
+
+			applied, err := m.Migrate()
+			require.NoError(t, err)
+			require.Equal(t, plan, applied)
+
+			plan, err = m.Plan()
+			require.NoError(t, err)
+			require.Equal(t, &MigrationPlan{
+				DatabaseType:  SQLite,
+				SchemaVersion: latestSchemaVersion,
+				CodeVersion:   version.Version(),
+				TargetVersion: latestSchemaVersion,
+			}, plan)
+		})
+	}
+}
+
+func TestMigratorNewerSchema(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := newTestMigrator(t, filepath.Join(dir, "newer.sqlite3"))
+	defer m.Close()
+
+	_, err := m.Migrate()
+	require.NoError(t, err)
+	require.NoError(t, m.db.Model(&Migration{}).Update("version", latestSchemaVersion+1).Error)
+
+	expectedErr := fmt.Sprintf("datastore-sql: schema version %d is newer than the latest version %d supported by this binary; migrations cannot be rolled back, upgrade SPIRE Server", latestSchemaVersion+1, latestSchemaVersion)
+
+	_, err = m.Plan()
+	require.EqualError(t, err, expectedErr)
+
+	_, err = m.Migrate()
+	require.EqualError(t, err, expectedErr)
+}
+
+func TestNewMigratorInvalidConfig(t *testing.T) {
+	_, err := NewMigrator(`connection_string = "foo"`, hclog.NewNullLogger())
+	require.EqualError(t, err, "database_type must be set")
+
+	_, err = NewMigrator(`database_type = "foo"
+connection_string = "foo"`, hclog.NewNullLogger())
+	require.EqualError(t, err, "datastore-sql: unsupported database_type: foo")
+}
+
+func newTestMigrator(t *testing.T, dbPath string) *Migrator {
+	m, err := NewMigrator(fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "file://%s"
+	`, dbPath), hclog.NewNullLogger())
+	require.NoError(t, err)
+	return m
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "spire-datastore-sql-migrator-")
+	require.NoError(t, err)
+	return dir
+}
@@ -32,6 +32,10 @@ type AttestedNode struct {
 	ExpiresAt       time.Time
 	NewSerialNumber string
 	NewExpiresAt    *time.Time
+	AgentVersion    string
+	AgentPlugins    []byte
+	LastSeen        *time.Time
+	CanReattest     bool `gorm:"index"`
 }
 
 // TableName gets table name of AttestedNode
@@ -83,6 +87,10 @@ type RegisteredEntry struct {
 	Expiry int64 `gorm:"index"`
 	// (optional) DNS entries
 	DNSList []DNSName
+	// (optional) serialized X509-SVID profile
+	X509SVIDProfile []byte `gorm:"column:x509_svid_profile"`
+	// (optional) time-to-live of the JWT-SVIDs
+	JWTSVIDTTL int32 `gorm:"column:jwt_svid_ttl"`
 
 	// RevisionNumber is a counter that is incremented when the entry is
 	// updated.
@@ -118,6 +126,38 @@ func (DNSName) TableName() string {
 	return "dns_names"
 }
 
+// FederationRelationship holds a relationship with a federated trust domain
+type FederationRelationship struct {
+	Model
+
+	TrustDomain           string `gorm:"not null;unique_index"`
+	BundleEndpointURL     string
+	BundleEndpointProfile string
+	EndpointSPIFFEID      string
+}
+
+// ChangeEvent records a change to a registration entry, attested node or
+// bundle. The ID is used as the revision of the change.
+type ChangeEvent struct {
+	Model
+
+	ResourceType int32
+	ResourceID   string
+	EventType    int32
+}
+
+// CAJournal holds the serialized CA journal of a trust domain, shared by the
+// servers of the trust domain, along with the rotation lease.
+type CAJournal struct {
+	Model
+
+	TrustDomain    string `gorm:"not null;unique_index"`
+	Data           []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB - doesn't affect PostgreSQL/SQLite
+	Revision       int64
+	LeaseHolder    string
+	LeaseExpiresAt int64
+}
+
 // Migration holds database schema version number, and
 // the SPIRE Code version number
 type Migration struct {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/jinzhu/gorm"
@@ -249,6 +251,66 @@ func (ds *Plugin) PruneBundle(ctx context.Context, req *datastore.PruneBundleReq
 	return resp, nil
 }
 
+// TaintX509CA marks an X.509 CA in a bundle as tainted
+func (ds *Plugin) TaintX509CA(ctx context.Context, req *datastore.TaintX509CARequest) (resp *datastore.TaintX509CAResponse, err error) {
+	callCounter := ds_telemetry.StartTaintX509CACall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = taintX509CA(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RevokeX509CA removes a tainted X.509 CA from a bundle
+func (ds *Plugin) RevokeX509CA(ctx context.Context, req *datastore.RevokeX509CARequest) (resp *datastore.RevokeX509CAResponse, err error) {
+	callCounter := ds_telemetry.StartRevokeX509CACall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = revokeX509CA(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// TaintJWTKey marks a JWT signing key in a bundle as tainted
+func (ds *Plugin) TaintJWTKey(ctx context.Context, req *datastore.TaintJWTKeyRequest) (resp *datastore.TaintJWTKeyResponse, err error) {
+	callCounter := ds_telemetry.StartTaintJWTKeyCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = taintJWTKey(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RevokeJWTKey removes a tainted JWT signing key from a bundle
+func (ds *Plugin) RevokeJWTKey(ctx context.Context, req *datastore.RevokeJWTKeyRequest) (resp *datastore.RevokeJWTKeyResponse, err error) {
+	callCounter := ds_telemetry.StartRevokeJWTKeyCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = revokeJWTKey(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 // CreateAttestedNode stores the given attested node
 func (ds *Plugin) CreateAttestedNode(ctx context.Context,
 	req *datastore.CreateAttestedNodeRequest) (resp *datastore.CreateAttestedNodeResponse, err error) {
@@ -358,6 +420,18 @@ func (ds *Plugin) GetNodeSelectors(ctx context.Context,
 	return getNodeSelectors(ctx, ds.db, req)
 }
 
+// ListNodeSelectors gets node (agent) selectors for all nodes
+func (ds *Plugin) ListNodeSelectors(ctx context.Context,
+	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
+	callCounter := ds_telemetry.StartListNodeSelectorsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if req.TolerateStale && ds.roDb != nil {
+		return listNodeSelectors(ctx, ds.roDb)
+	}
+	return listNodeSelectors(ctx, ds.db)
+}
+
 // CreateRegistrationEntry stores the given registration entry
 func (ds *Plugin) CreateRegistrationEntry(ctx context.Context,
 	req *datastore.CreateRegistrationEntryRequest) (resp *datastore.CreateRegistrationEntryResponse, err error) {
@@ -405,7 +479,7 @@ func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context,
 	callCounter := ds_telemetry.StartUpdateRegistrationCall(ds.prepareMetricsForCall())
 	defer callCounter.Done(&err)
 
-	if err = validateRegistrationEntry(req.Entry); err != nil {
+	if err = validateRegistrationEntryForUpdate(req.Entry, req.InputMask); err != nil {
 		return nil, err
 	}
 
@@ -496,6 +570,20 @@ func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoin
 	return resp, nil
 }
 
+// ListJoinTokens lists all join tokens
+func (ds *Plugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	callCounter := ds_telemetry.StartListJoinTokensCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -511,6 +599,137 @@ func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinT
 	return resp, nil
 }
 
+// CreateFederationRelationship stores the given federation relationship
+func (ds *Plugin) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (resp *datastore.CreateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartCreateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchFederationRelationship returns the federation relationship with the
+// specified trust domain
+func (ds *Plugin) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (resp *datastore.FetchFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartFetchFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListFederationRelationships lists all federation relationships
+func (ds *Plugin) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (resp *datastore.ListFederationRelationshipsResponse, err error) {
+	callCounter := ds_telemetry.StartListFederationRelationshipsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listFederationRelationships(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates an existing federation relationship
+func (ds *Plugin) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (resp *datastore.UpdateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship with the
+// specified trust domain
+func (ds *Plugin) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (resp *datastore.DeleteFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListChangeEvents lists the change events recorded after the given
+// revision, in revision order
+func (ds *Plugin) ListChangeEvents(ctx context.Context, req *datastore.ListChangeEventsRequest) (resp *datastore.ListChangeEventsResponse, err error) {
+	callCounter := ds_telemetry.StartListChangeEventsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listChangeEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneChangeEvents deletes all change events recorded before the date in
+// the message
+func (ds *Plugin) PruneChangeEvents(ctx context.Context, req *datastore.PruneChangeEventsRequest) (resp *datastore.PruneChangeEventsResponse, err error) {
+	callCounter := ds_telemetry.StartPruneChangeEventsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneChangeEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchCAJournal fetches the CA journal of the specified trust domain
+func (ds *Plugin) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (resp *datastore.FetchCAJournalResponse, err error) {
+	callCounter := ds_telemetry.StartFetchCAJournalCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetCAJournal sets the CA journal of a trust domain if the revision in the
+// message matches the stored revision
+func (ds *Plugin) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (resp *datastore.SetCAJournalResponse, err error) {
+	callCounter := ds_telemetry.StartSetCAJournalCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = setCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -648,16 +867,31 @@ func (ds *Plugin) withTx(ctx context.Context, op func(tx *gorm.DB) error, readOn
 }
 
 func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string, bool, error) {
+	db, version, supportsCTE, err := connectDB(cfg, isReadOnly, ds.log)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	if err := migrateDB(db, cfg.DatabaseType, cfg.DisableMigration, ds.log); err != nil {
+		db.Close()
+		return nil, "", false, err
+	}
+
+	return db, version, supportsCTE, nil
+}
+
+// connectDB opens the configured database without migrating its schema
+func connectDB(cfg *configuration, isReadOnly bool, log hclog.Logger) (*gorm.DB, string, bool, error) {
 	var db *gorm.DB
 	var version string
 	var supportsCTE bool
 	var err error
 
-	ds.log.Info("Opening SQL database", telemetry.DatabaseType, cfg.DatabaseType)
+	log.Info("Opening SQL database", telemetry.DatabaseType, cfg.DatabaseType)
 	switch cfg.DatabaseType {
 	case SQLite:
 		if isReadOnly {
-			ds.log.Warn("read-only connection is not applicable for %v. Falling back to primary connection.", cfg.DatabaseType)
+			log.Warn("read-only connection is not applicable for %v. Falling back to primary connection.", cfg.DatabaseType)
 		}
 		db, version, supportsCTE, err = sqliteDB{}.connect(cfg)
 	case PostgreSQL:
@@ -671,7 +905,7 @@ func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string,
 		return nil, "", false, err
 	}
 
-	gormLogger := ds.log.Named("gorm")
+	gormLogger := log.Named("gorm")
 	gormLogger.SetLevel(hclog.Debug)
 	db.SetLogger(gormLogger.StandardLogger(&hclog.StandardLoggerOptions{
 		InferLevels: true,
@@ -690,11 +924,6 @@ func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string,
 		db.DB().SetConnMaxLifetime(connMaxLifetime)
 	}
 
-	if err := migrateDB(db, cfg.DatabaseType, cfg.DisableMigration, ds.log); err != nil {
-		db.Close()
-		return nil, "", false, err
-	}
-
 	return db, version, supportsCTE, nil
 }
 
@@ -712,6 +941,10 @@ func createBundle(tx *gorm.DB, req *datastore.CreateBundleRequest) (*datastore.C
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, model.TrustDomain, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
 	return &datastore.CreateBundleResponse{
 		Bundle: req.Bundle,
 	}, nil
@@ -733,6 +966,10 @@ func updateBundle(tx *gorm.DB, req *datastore.UpdateBundleRequest) (*datastore.U
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, model.TrustDomain, datastore.ChangeEvent_UPDATED); err != nil {
+		return nil, err
+	}
+
 	return &datastore.UpdateBundleResponse{
 		Bundle: req.Bundle,
 	}, nil
@@ -805,6 +1042,9 @@ func appendBundle(tx *gorm.DB, req *datastore.AppendBundleRequest) (*datastore.A
 		if err := tx.Save(model).Error; err != nil {
 			return nil, sqlError.Wrap(err)
 		}
+		if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, model.TrustDomain, datastore.ChangeEvent_UPDATED); err != nil {
+			return nil, err
+		}
 	}
 
 	return &datastore.AppendBundleResponse{
@@ -831,8 +1071,23 @@ func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.D
 	}
 
 	if entriesCount > 0 {
+		// The federated entries are either deleted or updated along with the
+		// bundle, so change events are recorded for them as well.
+		var entryIDs []string
+		if err := tx.Model(&RegisteredEntry{}).Where(`id IN (
+				SELECT
+					registered_entry_id
+				FROM
+					federated_registration_entries
+				WHERE
+					bundle_id = ?)`, model.ID).Pluck("entry_id", &entryIDs).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+
+		entryEventType := datastore.ChangeEvent_UPDATED
 		switch req.Mode {
 		case datastore.DeleteBundleRequest_DELETE:
+			entryEventType = datastore.ChangeEvent_DELETED
 			// TODO: figure out how to do this gracefully with GORM.
 			if err := tx.Exec(bindVars(tx, `DELETE FROM registered_entries WHERE id in (
 				SELECT
@@ -850,12 +1105,22 @@ func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.D
 		default:
 			return nil, sqlError.New("cannot delete bundle; federated with %d registration entries", entriesCount)
 		}
+
+		for _, entryID := range entryIDs {
+			if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entryID, entryEventType); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if err := tx.Delete(model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_BUNDLE, model.TrustDomain, datastore.ChangeEvent_DELETED); err != nil {
+		return nil, err
+	}
+
 	bundle, err := modelToBundle(model)
 	if err != nil {
 		return nil, err
@@ -968,7 +1233,69 @@ func pruneBundle(tx *gorm.DB, req *datastore.PruneBundleRequest, log hclog.Logge
 	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
 }
 
+func taintX509CA(tx *gorm.DB, req *datastore.TaintX509CARequest) (*datastore.TaintX509CAResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintX509CAResponse{}, nil
+}
+
+func revokeX509CA(tx *gorm.DB, req *datastore.RevokeX509CARequest) (*datastore.RevokeX509CAResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeX509CAResponse{}, nil
+}
+
+func taintJWTKey(tx *gorm.DB, req *datastore.TaintJWTKeyRequest) (*datastore.TaintJWTKeyResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintJWTKeyResponse{}, nil
+}
+
+func revokeJWTKey(tx *gorm.DB, req *datastore.RevokeJWTKeyRequest) (*datastore.RevokeJWTKeyResponse, error) {
+	if err := modifyBundle(tx, req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeJWTKeyResponse{}, nil
+}
+
+// modifyBundle applies fn to the bundle of the given trust domain and writes
+// the result back
+func modifyBundle(tx *gorm.DB, trustDomainID string, fn func(*common.Bundle) error) error {
+	current, err := fetchBundle(tx, &datastore.FetchBundleRequest{TrustDomainId: trustDomainID})
+	if err != nil {
+		return err
+	}
+	if current.Bundle == nil {
+		return status.Errorf(codes.NotFound, "no bundle for trust domain %q", trustDomainID)
+	}
+
+	if err := fn(current.Bundle); err != nil {
+		return err
+	}
+
+	_, err = updateBundle(tx, &datastore.UpdateBundleRequest{
+		Bundle: current.Bundle,
+	})
+	return err
+}
+
 func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
+	agentPlugins, err := agentPluginsToDB(req.Node.AgentPlugins)
+	if err != nil {
+		return nil, err
+	}
+
 	model := AttestedNode{
 		SpiffeID:        req.Node.SpiffeId,
 		DataType:        req.Node.AttestationDataType,
@@ -976,14 +1303,26 @@ func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (
 		ExpiresAt:       time.Unix(req.Node.CertNotAfter, 0),
 		NewSerialNumber: req.Node.NewCertSerialNumber,
 		NewExpiresAt:    nullableUnixTimeToDBTime(req.Node.NewCertNotAfter),
+		AgentVersion:    req.Node.AgentVersion,
+		AgentPlugins:    agentPlugins,
+		LastSeen:        nullableUnixTimeToDBTime(req.Node.LastSeen),
+		CanReattest:     req.Node.CanReattest,
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, model.SpiffeID, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
+	node, err := modelToAttestedNode(model)
+	if err != nil {
+		return nil, err
+	}
 	return &datastore.CreateAttestedNodeResponse{
-		Node: modelToAttestedNode(model),
+		Node: node,
 	}, nil
 }
 
@@ -996,12 +1335,19 @@ func fetchAttestedNode(tx *gorm.DB, req *datastore.FetchAttestedNodeRequest) (*d
 	case err != nil:
 		return nil, sqlError.Wrap(err)
 	}
+	node, err := modelToAttestedNode(model)
+	if err != nil {
+		return nil, err
+	}
 	return &datastore.FetchAttestedNodeResponse{
-		Node: modelToAttestedNode(model),
+		Node: node,
 	}, nil
 }
 
 func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	// Keep the unfiltered transaction around to fetch the node selectors
+	selectorsTx := tx
+
 	p := req.Pagination
 	var err error
 	if p != nil {
@@ -1015,6 +1361,30 @@ func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*d
 		tx = tx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
 	}
 
+	// Banned nodes are those that have had their serial numbers cleared
+	if req.ByBanned != nil {
+		if req.ByBanned.Value {
+			tx = tx.Where("serial_number = '' AND new_serial_number = ''")
+		} else {
+			tx = tx.Where("serial_number <> '' OR new_serial_number <> ''")
+		}
+	}
+
+	if req.ByAttestationType != "" {
+		tx = tx.Where("data_type = ?", req.ByAttestationType)
+	}
+
+	if req.ByCanReattest != nil {
+		tx = tx.Where("can_reattest = ?", req.ByCanReattest.Value)
+	}
+
+	if req.BySelectorMatch != nil {
+		tx, err = filterNodesBySelectorMatch(tx, req.BySelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var models []AttestedNode
 	if err := tx.Find(&models).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -1034,30 +1404,175 @@ func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*d
 	}
 
 	for _, model := range models {
-		resp.Nodes = append(resp.Nodes, modelToAttestedNode(model))
+		node, err := modelToAttestedNode(model)
+		if err != nil {
+			return nil, err
+		}
+		resp.Nodes = append(resp.Nodes, node)
+	}
+
+	if req.FetchSelectors {
+		if err := fetchNodeSelectors(selectorsTx, resp.Nodes); err != nil {
+			return nil, err
+		}
 	}
 	return resp, nil
 }
 
-func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
-	var model AttestedNode
+// filterNodesBySelectorMatch restricts the attested nodes to those whose
+// selectors match the requested selectors. Any matching requires the node
+// to have at least one of the requested selectors and superset matching
+// requires it to have all of them. Subset and exact matching additionally
+// require the node to have no other selectors.
+func filterNodesBySelectorMatch(tx *gorm.DB, bySelectors *datastore.BySelectors) (*gorm.DB, error) {
+	if len(bySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+
+	type selectorKey struct {
+		Type  string
+		Value string
+	}
+	seen := make(map[selectorKey]bool, len(bySelectors.Selectors))
+	var conds []string
+	var args []interface{}
+	for _, s := range bySelectors.Selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		conds = append(conds, "(type = ? AND value = ?)")
+		args = append(args, s.Type, s.Value)
+	}
+	match := strings.Join(conds, " OR ")
+
+	// Node selectors are unique so counting the matching rows is enough to
+	// make sure all of the requested selectors are present.
+	anyQuery := "spiffe_id IN (SELECT spiffe_id FROM node_resolver_map_entries WHERE " + match + ")"
+	allQuery := "spiffe_id IN (SELECT spiffe_id FROM node_resolver_map_entries WHERE " + match + " GROUP BY spiffe_id HAVING COUNT(*) = ?)"
+	noOthersQuery := "spiffe_id NOT IN (SELECT spiffe_id FROM node_resolver_map_entries WHERE NOT (" + match + "))"
+
+	switch bySelectors.Match {
+	case datastore.BySelectors_MATCH_ANY:
+		tx = tx.Where(anyQuery, args...)
+	case datastore.BySelectors_MATCH_SUPERSET:
+		tx = tx.Where(allQuery, append(args, len(conds))...)
+	case datastore.BySelectors_MATCH_SUBSET:
+		tx = tx.Where(noOthersQuery, args...).Where(anyQuery, args...)
+	case datastore.BySelectors_MATCH_EXACT:
+		tx = tx.Where(noOthersQuery, args...).Where(allQuery, append(args, len(conds))...)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unhandled match behavior %q", bySelectors.Match)
+	}
+	return tx, nil
+}
+
+// fetchNodeSelectors populates the selectors of the given nodes. Selectors
+// are queried in batches to bound the number of query parameters.
+func fetchNodeSelectors(tx *gorm.DB, nodes []*common.AttestedNode) error {
+	const batchSize = 500
+
+	nodesByID := make(map[string]*common.AttestedNode, len(nodes))
+	for _, node := range nodes {
+		nodesByID[node.SpiffeId] = node
+	}
+
+	for len(nodes) > 0 {
+		batch := nodes
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		nodes = nodes[len(batch):]
+
+		ids := make([]string, 0, len(batch))
+		for _, node := range batch {
+			ids = append(ids, node.SpiffeId)
+		}
+
+		var models []NodeSelector
+		if err := tx.Where("spiffe_id IN (?)", ids).Order("id").Find(&models).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+
+		for _, model := range models {
+			node := nodesByID[model.SpiffeID]
+			node.Selectors = append(node.Selectors, &common.Selector{
+				Type:  model.Type,
+				Value: model.Value,
+			})
+		}
+	}
+	return nil
+}
+
+func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	var model AttestedNode
 	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	updates := AttestedNode{
-		SerialNumber:    req.CertSerialNumber,
-		ExpiresAt:       time.Unix(req.CertNotAfter, 0),
-		NewSerialNumber: req.NewCertSerialNumber,
-		NewExpiresAt:    nullableUnixTimeToDBTime(req.NewCertNotAfter),
+	var updates interface{}
+	if mask := req.InputMask; mask != nil {
+		// A map is used so that fields in the mask are updated even when
+		// set to their zero value.
+		fields := make(map[string]interface{})
+		if mask.CertSerialNumber {
+			fields["serial_number"] = req.CertSerialNumber
+		}
+		if mask.CertNotAfter {
+			fields["expires_at"] = time.Unix(req.CertNotAfter, 0)
+		}
+		if mask.NewCertSerialNumber {
+			fields["new_serial_number"] = req.NewCertSerialNumber
+		}
+		if mask.NewCertNotAfter {
+			fields["new_expires_at"] = nullableUnixTimeToDBTime(req.NewCertNotAfter)
+		}
+		if mask.AgentVersion {
+			fields["agent_version"] = req.AgentVersion
+		}
+		if mask.AgentPlugins {
+			agentPlugins, err := agentPluginsToDB(req.AgentPlugins)
+			if err != nil {
+				return nil, err
+			}
+			fields["agent_plugins"] = agentPlugins
+		}
+		if mask.LastSeen {
+			fields["last_seen"] = nullableUnixTimeToDBTime(req.LastSeen)
+		}
+		if mask.CanReattest {
+			fields["can_reattest"] = req.CanReattest
+		}
+		updates = fields
+	} else {
+		updates = AttestedNode{
+			SerialNumber:    req.CertSerialNumber,
+			ExpiresAt:       time.Unix(req.CertNotAfter, 0),
+			NewSerialNumber: req.NewCertSerialNumber,
+			NewExpiresAt:    nullableUnixTimeToDBTime(req.NewCertNotAfter),
+		}
 	}
 
 	if err := tx.Model(&model).Updates(updates).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	// Agents are seen on every sync, so updates that only record the last
+	// seen time are not worth a change event.
+	if !isLastSeenOnlyUpdate(req.InputMask) {
+		if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, model.SpiffeID, datastore.ChangeEvent_UPDATED); err != nil {
+			return nil, err
+		}
+	}
+
+	node, err := modelToAttestedNode(model)
+	if err != nil {
+		return nil, err
+	}
 	return &datastore.UpdateAttestedNodeResponse{
-		Node: modelToAttestedNode(model),
+		Node: node,
 	}, nil
 }
 
@@ -1071,8 +1586,16 @@ func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, model.SpiffeID, datastore.ChangeEvent_DELETED); err != nil {
+		return nil, err
+	}
+
+	node, err := modelToAttestedNode(model)
+	if err != nil {
+		return nil, err
+	}
 	return &datastore.DeleteAttestedNodeResponse{
-		Node: modelToAttestedNode(model),
+		Node: node,
 	}, nil
 }
 
@@ -1109,6 +1632,12 @@ func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*dat
 		}
 	}
 
+	// Node selectors are part of the attested node as seen by the change
+	// event consumers, so setting them is recorded as an update.
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_ATTESTED_NODE, req.Selectors.SpiffeId, datastore.ChangeEvent_UPDATED); err != nil {
+		return nil, err
+	}
+
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
 
@@ -1141,20 +1670,58 @@ func getNodeSelectors(ctx context.Context, db *sqlDB, req *datastore.GetNodeSele
 	}, nil
 }
 
+func listNodeSelectors(ctx context.Context, db *sqlDB) (*datastore.ListNodeSelectorsResponse, error) {
+	rows, err := db.QueryContext(ctx, "SELECT spiffe_id, type, value FROM node_resolver_map_entries ORDER BY spiffe_id, id")
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	defer rows.Close()
+
+	resp := new(datastore.ListNodeSelectorsResponse)
+	var current *datastore.NodeSelectors
+	for rows.Next() {
+		var spiffeID string
+		selector := new(common.Selector)
+		if err := rows.Scan(&spiffeID, &selector.Type, &selector.Value); err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if current == nil || current.SpiffeId != spiffeID {
+			current = &datastore.NodeSelectors{
+				SpiffeId: spiffeID,
+			}
+			resp.Selectors = append(resp.Selectors, current)
+		}
+		current.Selectors = append(current.Selectors, selector)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return resp, nil
+}
+
 func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
 	entryID, err := newRegistrationEntryID()
 	if err != nil {
 		return nil, err
 	}
 
+	x509SVIDProfile, err := marshalX509SVIDProfile(req.Entry.X509SvidProfile)
+	if err != nil {
+		return nil, err
+	}
+
 	newRegisteredEntry := RegisteredEntry{
-		EntryID:    entryID,
-		SpiffeID:   req.Entry.SpiffeId,
-		ParentID:   req.Entry.ParentId,
-		TTL:        req.Entry.Ttl,
-		Admin:      req.Entry.Admin,
-		Downstream: req.Entry.Downstream,
-		Expiry:     req.Entry.EntryExpiry,
+		EntryID:         entryID,
+		SpiffeID:        req.Entry.SpiffeId,
+		ParentID:        req.Entry.ParentId,
+		TTL:             req.Entry.Ttl,
+		Admin:           req.Entry.Admin,
+		Downstream:      req.Entry.Downstream,
+		Expiry:          req.Entry.EntryExpiry,
+		X509SVIDProfile: x509SVIDProfile,
+		JWTSVIDTTL:      req.Entry.JwtSvidTtl,
 	}
 
 	if err := tx.Create(&newRegisteredEntry).Error; err != nil {
@@ -1192,6 +1759,10 @@ func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntry
 		}
 	}
 
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, newRegisteredEntry.EntryID, datastore.ChangeEvent_CREATED); err != nil {
+		return nil, err
+	}
+
 	entry, err := modelToEntry(tx, newRegisteredEntry)
 	if err != nil {
 		return nil, err
@@ -1272,6 +1843,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1285,7 +1859,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1298,7 +1872,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1306,7 +1880,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1330,6 +1904,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1343,7 +1920,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1356,7 +1933,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1364,7 +1941,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1385,6 +1962,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -1421,6 +2001,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1434,7 +2017,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1447,7 +2030,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1455,7 +2038,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1472,19 +2055,23 @@ func listRegistrationEntries(ctx context.Context, db *sqlDB, req *datastore.List
 	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
 	}
+	if req.ByFederatesWith != nil && len(req.ByFederatesWith.TrustDomains) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty federates with set")
+	}
 
-	// Exact/subset selector matching requires filtering out all registration
+	// Exact selector matching requires filtering out all registration
 	// entries returned by the query whose selectors are not fully represented
 	// in the request selectors. For this reason, it's possible that a paged
 	// query returns rows that are completely filtered out. If that happens,
-	// keep querying until a page gets at least one result.
+	// keep querying until a page gets at least one result. Other match
+	// behaviors are fully resolved by the query.
 	for {
 		resp, err := listRegistrationEntriesOnce(ctx, db, req)
 		if err != nil {
 			return nil, err
 		}
 
-		if req.BySelectors == nil || len(resp.Entries) == 0 {
+		if !needsSelectorSetFiltering(req.BySelectors) || len(resp.Entries) == 0 {
 			return resp, nil
 		}
 
@@ -1497,6 +2084,10 @@ func listRegistrationEntries(ctx context.Context, db *sqlDB, req *datastore.List
 	}
 }
 
+func needsSelectorSetFiltering(bySelectors *datastore.BySelectors) bool {
+	return bySelectors != nil && bySelectors.Match == datastore.BySelectors_MATCH_EXACT
+}
+
 func filterEntriesBySelectorSet(entries []*common.RegistrationEntry, selectors []*common.Selector) []*common.RegistrationEntry {
 	type selectorKey struct {
 		Type  string
@@ -1638,6 +2229,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1654,7 +2248,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1669,7 +2263,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -1680,7 +2274,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -1715,6 +2309,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1731,7 +2328,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1746,7 +2343,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -1757,7 +2354,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -1796,6 +2393,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -1849,6 +2449,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1865,7 +2468,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1880,7 +2483,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -1891,7 +2494,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -1905,6 +2508,26 @@ ORDER BY e_id, selector_id, dns_name_id
 	return builder.String(), args, nil
 }
 
+// buildSelectorSubsetQuery builds a query returning the IDs of the entries
+// whose selectors are a subset of the given number of selectors, bound as
+// type/value argument pairs. For each entry with at least one of the
+// selectors, the number of matching selectors is compared to the number of
+// selectors of the entry. Both counts are resolved through the selector
+// indexes, so only true subsets are returned, no matter how many entries
+// share common selectors.
+func buildSelectorSubsetQuery(numSelectors int) string {
+	builder := new(strings.Builder)
+	builder.WriteString("SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE ")
+	for i := 0; i < numSelectors; i++ {
+		if i > 0 {
+			builder.WriteString(" OR ")
+		}
+		builder.WriteString("(type = ? AND value = ?)")
+	}
+	builder.WriteString(" GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)")
+	return builder.String()
+}
+
 type idFilterNode struct {
 	// mutually exclusive with children
 	query string
@@ -2020,30 +2643,51 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 
 	var root idFilterNode
 
-	if req.ByParentId != nil || req.BySpiffeId != nil {
-		subquery := new(strings.Builder)
-		subquery.WriteString("SELECT id FROM registered_entries WHERE ")
-		if req.ByParentId != nil {
-			subquery.WriteString("parent_id = ?")
-			args = append(args, req.ByParentId.Value)
-		}
-		if req.BySpiffeId != nil {
-			if req.ByParentId != nil {
-				subquery.WriteString(" AND ")
-			}
-			subquery.WriteString("spiffe_id = ?")
-			args = append(args, req.BySpiffeId.Value)
-		}
+	// filters on the columns of the registered_entries table are combined
+	// into a single subquery
+	var conds []string
+	if req.ByParentId != nil {
+		conds = append(conds, "parent_id = ?")
+		args = append(args, req.ByParentId.Value)
+	}
+	if req.BySpiffeId != nil {
+		conds = append(conds, "spiffe_id = ?")
+		args = append(args, req.BySpiffeId.Value)
+	}
+	if req.ByDownstream != nil {
+		conds = append(conds, "downstream = ?")
+		args = append(args, req.ByDownstream.Value)
+	}
+	if req.ByAdmin != nil {
+		conds = append(conds, "admin = ?")
+		args = append(args, req.ByAdmin.Value)
+	}
+	if req.ByExpiresAfter != nil {
+		// entries without an expiry never expire
+		conds = append(conds, "(expiry = 0 OR expiry > ?)")
+		args = append(args, req.ByExpiresAfter.Value)
+	}
+	if req.ByExpiresBefore != nil {
+		conds = append(conds, "expiry != 0 AND expiry < ?")
+		args = append(args, req.ByExpiresBefore.Value)
+	}
+	if len(conds) > 0 {
 		root.children = append(root.children, idFilterNode{
-			query: subquery.String(),
+			query: "SELECT id FROM registered_entries WHERE " + strings.Join(conds, " AND "),
 		})
 	}
 
 	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
 		switch req.BySelectors.Match {
 		case datastore.BySelectors_MATCH_SUBSET:
-			// subset needs a union, so we need to group them and add the group
-			// as a child to the root.
+			// subset is resolved by a single query that only returns entries
+			// whose selectors are all requested selectors.
+			root.children = append(root.children, idFilterNode{
+				query: buildSelectorSubsetQuery(len(req.BySelectors.Selectors)),
+			})
+		case datastore.BySelectors_MATCH_ANY:
+			// any needs a union, so we need to group them and add the group as
+			// a child to the root.
 			group := idFilterNode{
 				union: true,
 			}
@@ -2053,9 +2697,11 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 				})
 			}
 			root.children = append(root.children, group)
-		case datastore.BySelectors_MATCH_EXACT:
-			// exact match does uses an intersection, so we can just add these
-			// directly to the root idFilterNode, since it is already an intersection
+		case datastore.BySelectors_MATCH_EXACT, datastore.BySelectors_MATCH_SUPERSET:
+			// exact and superset matches use an intersection, so we can just
+			// add these directly to the root idFilterNode, since it is already
+			// an intersection. Exact matches are further filtered once the
+			// entries are fetched.
 			for range req.BySelectors.Selectors {
 				root.children = append(root.children, idFilterNode{
 					query: "SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?",
@@ -2069,6 +2715,45 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 		}
 	}
 
+	if req.ByFederatesWith != nil {
+		const federatesWithQuery = "SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)"
+		switch req.ByFederatesWith.Match {
+		case datastore.ByFederatesWith_MATCH_ALL:
+			// entries must federate with every trust domain, so each trust
+			// domain is added to the root idFilterNode, which is an
+			// intersection.
+			for range req.ByFederatesWith.TrustDomains {
+				root.children = append(root.children, idFilterNode{
+					query: federatesWithQuery,
+				})
+			}
+		case datastore.ByFederatesWith_MATCH_ANY:
+			// entries must federate with at least one trust domain, so the
+			// trust domains are grouped in a union.
+			group := idFilterNode{
+				union: true,
+			}
+			for range req.ByFederatesWith.TrustDomains {
+				group.children = append(group.children, idFilterNode{
+					query: federatesWithQuery,
+				})
+			}
+			root.children = append(root.children, group)
+		default:
+			return false, nil, errs.New("unhandled federates with match behavior %q", req.ByFederatesWith.Match)
+		}
+		for _, trustDomain := range req.ByFederatesWith.TrustDomains {
+			args = append(args, trustDomain)
+		}
+	}
+
+	if req.ByDnsName != nil {
+		root.children = append(root.children, idFilterNode{
+			query: "SELECT registered_entry_id AS id FROM dns_names WHERE value = ?",
+		})
+		args = append(args, req.ByDnsName.Value)
+	}
+
 	filtered := false
 	filter := func() {
 		if !filtered {
@@ -2120,20 +2805,23 @@ func appendListRegistrationEntriesFilterQuery(filterExp string, builder *strings
 }
 
 type entryRow struct {
-	EId           uint64
-	EntryID       sql.NullString
-	SpiffeID      sql.NullString
-	ParentID      sql.NullString
-	RegTTL        sql.NullInt64
-	Admin         sql.NullBool
-	Downstream    sql.NullBool
-	Expiry        sql.NullInt64
-	SelectorID    sql.NullInt64
-	SelectorType  sql.NullString
-	SelectorValue sql.NullString
-	TrustDomain   sql.NullString
-	DNSNameID     sql.NullInt64
-	DNSName       sql.NullString
+	EId             uint64
+	EntryID         sql.NullString
+	SpiffeID        sql.NullString
+	ParentID        sql.NullString
+	RegTTL          sql.NullInt64
+	Admin           sql.NullBool
+	Downstream      sql.NullBool
+	Expiry          sql.NullInt64
+	RevisionNumber  sql.NullInt64
+	X509SVIDProfile []byte
+	JWTSVIDTTL      sql.NullInt64
+	SelectorID      sql.NullInt64
+	SelectorType    sql.NullString
+	SelectorValue   sql.NullString
+	TrustDomain     sql.NullString
+	DNSNameID       sql.NullInt64
+	DNSName         sql.NullString
 }
 
 func scanEntryRow(rs *sql.Rows, r *entryRow) error {
@@ -2146,6 +2834,9 @@ func scanEntryRow(rs *sql.Rows, r *entryRow) error {
 		&r.Admin,
 		&r.Downstream,
 		&r.Expiry,
+		&r.RevisionNumber,
+		&r.X509SVIDProfile,
+		&r.JWTSVIDTTL,
 		&r.SelectorID,
 		&r.SelectorType,
 		&r.SelectorValue,
@@ -2177,6 +2868,19 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.Expiry.Valid {
 		entry.EntryExpiry = r.Expiry.Int64
 	}
+	if r.RevisionNumber.Valid {
+		entry.RevisionNumber = r.RevisionNumber.Int64
+	}
+	if len(r.X509SVIDProfile) > 0 && entry.X509SvidProfile == nil {
+		profile, err := unmarshalX509SVIDProfile(r.X509SVIDProfile)
+		if err != nil {
+			return err
+		}
+		entry.X509SvidProfile = profile
+	}
+	if r.JWTSVIDTTL.Valid {
+		entry.JwtSvidTtl = int32(r.JWTSVIDTTL.Int64)
+	}
 
 	if r.SelectorType.Valid {
 		if !r.SelectorValue.Valid {
@@ -2223,62 +2927,125 @@ func updateRegistrationEntry(tx *gorm.DB,
 		return nil, sqlError.Wrap(err)
 	}
 
-	// Delete existing selectors - we will write new ones
-	if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+	if err := bumpRegistrationEntryRevision(tx, &entry, req.ExpectedRevisionNumber); err != nil {
+		return nil, err
+	}
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = allRegistrationEntryFieldsMask()
 	}
 
-	selectors := []Selector{}
-	for _, s := range req.Entry.Selectors {
-		selector := Selector{
-			Type:  s.Type,
-			Value: s.Value,
+	if mask.Selectors {
+		// Delete existing selectors - we will write new ones
+		if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+			return nil, sqlError.Wrap(err)
 		}
 
-		selectors = append(selectors, selector)
-	}
+		selectors := []Selector{}
+		for _, s := range req.Entry.Selectors {
+			selector := Selector{
+				Type:  s.Type,
+				Value: s.Value,
+			}
 
-	// Delete existing DNSs - we will write new ones
-	if err := tx.Exec("DELETE FROM dns_names WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+			selectors = append(selectors, selector)
+		}
+		entry.Selectors = selectors
 	}
 
-	dnsList := []DNSName{}
-	for _, d := range req.Entry.DnsNames {
-		dns := DNSName{
-			Value: d,
+	if mask.DnsNames {
+		// Delete existing DNSs - we will write new ones
+		if err := tx.Exec("DELETE FROM dns_names WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+			return nil, sqlError.Wrap(err)
 		}
 
-		dnsList = append(dnsList, dns)
+		dnsList := []DNSName{}
+		for _, d := range req.Entry.DnsNames {
+			dns := DNSName{
+				Value: d,
+			}
+
+			dnsList = append(dnsList, dns)
+		}
+		entry.DNSList = dnsList
 	}
 
-	entry.SpiffeID = req.Entry.SpiffeId
-	entry.ParentID = req.Entry.ParentId
-	entry.TTL = req.Entry.Ttl
-	entry.Selectors = selectors
-	entry.Admin = req.Entry.Admin
-	entry.Downstream = req.Entry.Downstream
-	entry.Expiry = req.Entry.EntryExpiry
-	entry.DNSList = dnsList
+	if mask.SpiffeId {
+		entry.SpiffeID = req.Entry.SpiffeId
+	}
+	if mask.ParentId {
+		entry.ParentID = req.Entry.ParentId
+	}
+	if mask.Ttl {
+		entry.TTL = req.Entry.Ttl
+	}
+	if mask.Admin {
+		entry.Admin = req.Entry.Admin
+	}
+	if mask.Downstream {
+		entry.Downstream = req.Entry.Downstream
+	}
+	if mask.EntryExpiry {
+		entry.Expiry = req.Entry.EntryExpiry
+	}
+	if mask.X509SvidProfile {
+		x509SVIDProfile, err := marshalX509SVIDProfile(req.Entry.X509SvidProfile)
+		if err != nil {
+			return nil, err
+		}
+		entry.X509SVIDProfile = x509SVIDProfile
+	}
+	if mask.JwtSvidTtl {
+		entry.JWTSVIDTTL = req.Entry.JwtSvidTtl
+	}
 	if err := tx.Save(&entry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
-	if err != nil {
+	if mask.FederatesWith {
+		federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.Model(&entry).Association("FederatesWith").Replace(federatesWith).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if err := recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryID, datastore.ChangeEvent_UPDATED); err != nil {
 		return nil, err
 	}
 
-	if err := tx.Model(&entry).Association("FederatesWith").Replace(federatesWith).Error; err != nil {
+	updated, err := modelToEntry(tx, entry)
+	if err != nil {
 		return nil, err
 	}
 
-	req.Entry.EntryId = entry.EntryID
 	return &datastore.UpdateRegistrationEntryResponse{
-		Entry: req.Entry,
+		Entry: updated,
 	}, nil
 }
 
+// allRegistrationEntryFieldsMask returns a mask that updates every field of
+// a registration entry.
+func allRegistrationEntryFieldsMask() *common.RegistrationEntryMask {
+	return &common.RegistrationEntryMask{
+		Selectors:       true,
+		ParentId:        true,
+		SpiffeId:        true,
+		Ttl:             true,
+		FederatesWith:   true,
+		Admin:           true,
+		Downstream:      true,
+		EntryExpiry:     true,
+		DnsNames:        true,
+		X509SvidProfile: true,
+		JwtSvidTtl:      true,
+	}
+}
+
 func deleteRegistrationEntry(tx *gorm.DB, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
 	entry := RegisteredEntry{}
 	if err := tx.Find(&entry, "entry_id = ?", req.EntryId).Error; err != nil {
@@ -2290,6 +3057,14 @@ func deleteRegistrationEntry(tx *gorm.DB, req *datastore.DeleteRegistrationEntry
 		return nil, err
 	}
 
+	// Bumping the revision makes sure the entry is not deleted if it was
+	// updated since it was read
+	if req.ExpectedRevisionNumber != nil {
+		if err := bumpRegistrationEntryRevision(tx, &entry, req.ExpectedRevisionNumber); err != nil {
+			return nil, err
+		}
+	}
+
 	err = deleteRegistrationEntrySupport(tx, entry)
 	if err != nil {
 		return nil, err
@@ -2300,6 +3075,28 @@ func deleteRegistrationEntry(tx *gorm.DB, req *datastore.DeleteRegistrationEntry
 	}, nil
 }
 
+// bumpRegistrationEntryRevision increments the revision number of the entry.
+// It fails with an Aborted status if the entry does not have the expected
+// revision number, when given, or if the entry was updated since it was read.
+func bumpRegistrationEntryRevision(tx *gorm.DB, entry *RegisteredEntry, expected *wrappers.Int64Value) error {
+	if expected != nil && expected.Value != entry.RevisionNumber {
+		return status.Errorf(codes.Aborted, "registration entry revision number mismatch: expected %d, current %d", expected.Value, entry.RevisionNumber)
+	}
+
+	result := tx.Model(&RegisteredEntry{}).
+		Where("id = ? AND revision_number = ?", entry.ID, entry.RevisionNumber).
+		UpdateColumn("revision_number", entry.RevisionNumber+1)
+	if result.Error != nil {
+		return sqlError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return status.Error(codes.Aborted, "registration entry was updated concurrently")
+	}
+
+	entry.RevisionNumber++
+	return nil
+}
+
 func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 	if err := tx.Model(&entry).Association("FederatesWith").Clear().Error; err != nil {
 		return err
@@ -2314,7 +3111,7 @@ func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 		return sqlError.Wrap(err)
 	}
 
-	return nil
+	return recordChangeEvent(tx, datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryID, datastore.ChangeEvent_DELETED)
 }
 
 func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
@@ -2376,6 +3173,21 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 	}, nil
 }
 
+func listJoinTokens(tx *gorm.DB, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	var models []JoinToken
+	if err := tx.Order("token ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListJoinTokensResponse{
+		JoinTokens: make([]*datastore.JoinToken, 0, len(models)),
+	}
+	for _, model := range models {
+		resp.JoinTokens = append(resp.JoinTokens, modelToJoinToken(model))
+	}
+	return resp, nil
+}
+
 func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -2384,6 +3196,249 @@ func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datas
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func createFederationRelationship(tx *gorm.DB, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	model, err := federationRelationshipToModel(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func fetchFederationRelationship(tx *gorm.DB, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederationRelationship)
+	err = tx.Find(model, "trust_domain = ?", trustDomainID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func listFederationRelationships(tx *gorm.DB, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	if req.Pagination != nil && req.Pagination.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var models []FederationRelationship
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListFederationRelationshipsResponse{
+		Pagination: p,
+	}
+	for _, model := range models {
+		model := model // alias the loop variable since we pass it by reference below
+		resp.FederationRelationships = append(resp.FederationRelationships, modelToFederationRelationship(&model))
+	}
+
+	return resp, nil
+}
+
+func updateFederationRelationship(tx *gorm.DB, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	newModel, err := federationRelationshipToModel(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(FederationRelationship)
+	if err := tx.Find(model, "trust_domain = ?", newModel.TrustDomain).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model.BundleEndpointURL = newModel.BundleEndpointURL
+	model.BundleEndpointProfile = newModel.BundleEndpointProfile
+	model.EndpointSPIFFEID = newModel.EndpointSPIFFEID
+	if err := tx.Save(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func deleteFederationRelationship(tx *gorm.DB, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederationRelationship)
+	if err := tx.Find(model, "trust_domain = ?", trustDomainID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.DeleteFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func recordChangeEvent(tx *gorm.DB, resourceType datastore.ChangeEvent_ResourceType, resourceID string, eventType datastore.ChangeEvent_Type) error {
+	model := ChangeEvent{
+		ResourceType: int32(resourceType),
+		ResourceID:   resourceID,
+		EventType:    int32(eventType),
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func listChangeEvents(tx *gorm.DB, req *datastore.ListChangeEventsRequest) (*datastore.ListChangeEventsResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list change events with a negative limit")
+	}
+
+	var latest struct {
+		Revision int64
+	}
+	if err := tx.Model(&ChangeEvent{}).Select("COALESCE(MAX(id), 0) AS revision").Scan(&latest).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	query := tx.Where("id > ?", req.AfterRevision).Order("id")
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+
+	var models []ChangeEvent
+	if err := query.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListChangeEventsResponse{
+		LatestRevision: latest.Revision,
+	}
+	for _, model := range models {
+		resp.Events = append(resp.Events, modelToChangeEvent(model))
+	}
+	return resp, nil
+}
+
+func pruneChangeEvents(tx *gorm.DB, req *datastore.PruneChangeEventsRequest) (*datastore.PruneChangeEventsResponse, error) {
+	if err := tx.Where("created_at < ?", time.Unix(req.CreatedBefore, 0)).Delete(&ChangeEvent{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneChangeEventsResponse{}, nil
+}
+
+func fetchCAJournal(tx *gorm.DB, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(CAJournal)
+	err = tx.Find(model, "trust_domain = ?", trustDomainID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.FetchCAJournalResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		CaJournal: modelToCAJournal(model),
+	}, nil
+}
+
+func setCAJournal(tx *gorm.DB, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	if req.CaJournal == nil {
+		return nil, sqlError.New("invalid request: missing CA journal")
+	}
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.CaJournal.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(CAJournal)
+	err = tx.Find(model, "trust_domain = ?", trustDomainID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		if req.CaJournal.Revision != 0 {
+			return nil, status.Errorf(codes.Aborted, "CA journal revision mismatch: expected %d, current 0", req.CaJournal.Revision)
+		}
+		model = &CAJournal{
+			TrustDomain:    trustDomainID,
+			Data:           req.CaJournal.Data,
+			Revision:       1,
+			LeaseHolder:    req.CaJournal.LeaseHolder,
+			LeaseExpiresAt: req.CaJournal.LeaseExpiresAt,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	default:
+		if req.CaJournal.Revision != model.Revision {
+			return nil, status.Errorf(codes.Aborted, "CA journal revision mismatch: expected %d, current %d", req.CaJournal.Revision, model.Revision)
+		}
+		result := tx.Model(&CAJournal{}).
+			Where("id = ? AND revision = ?", model.ID, model.Revision).
+			Updates(map[string]interface{}{
+				"data":             req.CaJournal.Data,
+				"revision":         model.Revision + 1,
+				"lease_holder":     req.CaJournal.LeaseHolder,
+				"lease_expires_at": req.CaJournal.LeaseExpiresAt,
+			})
+		if result.Error != nil {
+			return nil, sqlError.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, status.Error(codes.Aborted, "CA journal was updated concurrently")
+		}
+		model.Data = req.CaJournal.Data
+		model.Revision++
+		model.LeaseHolder = req.CaJournal.LeaseHolder
+		model.LeaseExpiresAt = req.CaJournal.LeaseExpiresAt
+	}
+
+	return &datastore.SetCAJournalResponse{
+		CaJournal: modelToCAJournal(model),
+	}, nil
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -2412,6 +3467,36 @@ func validateRegistrationEntry(entry *common.RegistrationEntry) error {
 		return sqlError.New("invalid registration entry: TTL is not set")
 	}
 
+	if entry.JwtSvidTtl < 0 {
+		return sqlError.New("invalid registration entry: JWT-SVID TTL is negative")
+	}
+
+	return nil
+}
+
+// validateRegistrationEntryForUpdate validates the fields of the entry that
+// are updated according to the mask.
+func validateRegistrationEntryForUpdate(entry *common.RegistrationEntry, mask *common.RegistrationEntryMask) error {
+	if entry == nil {
+		return sqlError.New("invalid request: missing registered entry")
+	}
+
+	if (mask == nil || mask.Selectors) && len(entry.Selectors) == 0 {
+		return sqlError.New("invalid registration entry: missing selector list")
+	}
+
+	if (mask == nil || mask.SpiffeId) && len(entry.SpiffeId) == 0 {
+		return sqlError.New("invalid registration entry: missing SPIFFE ID")
+	}
+
+	if (mask == nil || mask.Ttl) && entry.Ttl < 0 {
+		return sqlError.New("invalid registration entry: TTL is not set")
+	}
+
+	if (mask == nil || mask.JwtSvidTtl) && entry.JwtSvidTtl < 0 {
+		return sqlError.New("invalid registration entry: JWT-SVID TTL is negative")
+	}
+
 	return nil
 }
 
@@ -2474,20 +3559,52 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		federatesWith = append(federatesWith, bundle.TrustDomain)
 	}
 
+	x509SVIDProfile, err := unmarshalX509SVIDProfile(model.X509SVIDProfile)
+	if err != nil {
+		return nil, err
+	}
+
 	return &common.RegistrationEntry{
-		EntryId:       model.EntryID,
-		Selectors:     selectors,
-		SpiffeId:      model.SpiffeID,
-		ParentId:      model.ParentID,
-		Ttl:           model.TTL,
-		FederatesWith: federatesWith,
-		Admin:         model.Admin,
-		Downstream:    model.Downstream,
-		EntryExpiry:   model.Expiry,
-		DnsNames:      dnsList,
+		EntryId:         model.EntryID,
+		Selectors:       selectors,
+		SpiffeId:        model.SpiffeID,
+		ParentId:        model.ParentID,
+		Ttl:             model.TTL,
+		FederatesWith:   federatesWith,
+		Admin:           model.Admin,
+		Downstream:      model.Downstream,
+		EntryExpiry:     model.Expiry,
+		DnsNames:        dnsList,
+		RevisionNumber:  model.RevisionNumber,
+		X509SvidProfile: x509SVIDProfile,
+		JwtSvidTtl:      model.JWTSVIDTTL,
 	}, nil
 }
 
+// marshalX509SVIDProfile serializes the X509-SVID profile of an entry for
+// storage. Entries without a profile are stored with a NULL column.
+func marshalX509SVIDProfile(profile *common.X509SVIDProfile) ([]byte, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	data, err := proto.Marshal(profile)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func unmarshalX509SVIDProfile(data []byte) (*common.X509SVIDProfile, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	profile := new(common.X509SVIDProfile)
+	if err := proto.Unmarshal(data, profile); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return profile, nil
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -2496,7 +3613,11 @@ func newRegistrationEntryID() (string, error) {
 	return u.String(), nil
 }
 
-func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
+func modelToAttestedNode(model AttestedNode) (*common.AttestedNode, error) {
+	agentPlugins, err := agentPluginsFromDB(model.AgentPlugins)
+	if err != nil {
+		return nil, err
+	}
 	return &common.AttestedNode{
 		SpiffeId:            model.SpiffeID,
 		AttestationDataType: model.DataType,
@@ -2504,7 +3625,39 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 		CertNotAfter:        model.ExpiresAt.Unix(),
 		NewCertSerialNumber: model.NewSerialNumber,
 		NewCertNotAfter:     nullableDBTimeToUnixTime(model.NewExpiresAt),
+		AgentVersion:        model.AgentVersion,
+		AgentPlugins:        agentPlugins,
+		LastSeen:            nullableDBTimeToUnixTime(model.LastSeen),
+		Banned:              model.SerialNumber == "" && model.NewSerialNumber == "",
+		CanReattest:         model.CanReattest,
+	}, nil
+}
+
+// agentPluginsToDB encodes the agent plugins as JSON for storage.
+func agentPluginsToDB(agentPlugins []*common.AgentPlugin) ([]byte, error) {
+	if len(agentPlugins) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(agentPlugins)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func agentPluginsFromDB(data []byte) ([]*common.AgentPlugin, error) {
+	if len(data) == 0 {
+		return nil, nil
 	}
+	var agentPlugins []*common.AgentPlugin
+	if err := json.Unmarshal(data, &agentPlugins); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return agentPlugins, nil
+}
+
+func isLastSeenOnlyUpdate(mask *datastore.AttestedNodeMask) bool {
+	return mask != nil && proto.Equal(mask, &datastore.AttestedNodeMask{LastSeen: true})
 }
 
 func modelToJoinToken(model JoinToken) *datastore.JoinToken {
@@ -2637,3 +3790,60 @@ func nullableUnixTimeToDBTime(unixTime int64) *time.Time {
 	dbTime := time.Unix(unixTime, 0)
 	return &dbTime
 }
+
+// federationRelationshipToModel converts the given Protobuf federation
+// relationship message to a database model, validating the trust domain and
+// the bundle endpoint URL.
+func federationRelationshipToModel(pb *datastore.FederationRelationship) (*FederationRelationship, error) {
+	if pb == nil {
+		return nil, sqlError.New("missing federation relationship in request")
+	}
+	id, err := idutil.NormalizeSpiffeID(pb.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if pb.BundleEndpointUrl == "" {
+		return nil, sqlError.New("invalid federation relationship: missing bundle endpoint URL")
+	}
+
+	return &FederationRelationship{
+		TrustDomain:           id,
+		BundleEndpointURL:     pb.BundleEndpointUrl,
+		BundleEndpointProfile: pb.BundleEndpointProfile.String(),
+		EndpointSPIFFEID:      pb.EndpointSpiffeId,
+	}, nil
+}
+
+func modelToFederationRelationship(model *FederationRelationship) *datastore.FederationRelationship {
+	profile := datastore.FederationRelationship_HTTPS_SPIFFE
+	if model.BundleEndpointProfile == datastore.FederationRelationship_HTTPS_WEB.String() {
+		profile = datastore.FederationRelationship_HTTPS_WEB
+	}
+
+	return &datastore.FederationRelationship{
+		TrustDomainId:         model.TrustDomain,
+		BundleEndpointUrl:     model.BundleEndpointURL,
+		BundleEndpointProfile: profile,
+		EndpointSpiffeId:      model.EndpointSPIFFEID,
+	}
+}
+
+func modelToChangeEvent(model ChangeEvent) *datastore.ChangeEvent {
+	return &datastore.ChangeEvent{
+		Revision:     int64(model.ID),
+		Type:         datastore.ChangeEvent_Type(model.EventType),
+		ResourceType: datastore.ChangeEvent_ResourceType(model.ResourceType),
+		ResourceId:   model.ResourceID,
+		CreatedAt:    model.CreatedAt.Unix(),
+	}
+}
+
+func modelToCAJournal(model *CAJournal) *datastore.CAJournal {
+	return &datastore.CAJournal{
+		TrustDomainId:  model.TrustDomain,
+		Data:           model.Data,
+		Revision:       model.Revision,
+		LeaseHolder:    model.LeaseHolder,
+		LeaseExpiresAt: model.LeaseExpiresAt,
+	}
+}
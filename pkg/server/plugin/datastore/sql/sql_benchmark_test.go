@@ -0,0 +1,113 @@
+package sql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	proto_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkListRegistrationEntriesBySelectorSubset(b *testing.B) {
+	for _, numEntries := range []int{100, 1000, 5000} {
+		for _, selectorsPerEntry := range []int{5, 20, 50} {
+			numEntries, selectorsPerEntry := numEntries, selectorsPerEntry
+			b.Run(fmt.Sprintf("entries=%d/selectors=%d", numEntries, selectorsPerEntry), func(b *testing.B) {
+				benchmarkListEntriesBySelectorSubset(b, numEntries, selectorsPerEntry)
+			})
+		}
+	}
+}
+
+func benchmarkListEntriesBySelectorSubset(b *testing.B, numEntries, selectorsPerEntry int) {
+	p, cleanup := newBenchmarkPlugin(b)
+	defer cleanup()
+
+	// Entries resemble Kubernetes workloads: they all share the namespace
+	// selector and are otherwise distinguished by their pod labels.
+	for i := 0; i < numEntries; i++ {
+		_, err := p.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/node",
+				SpiffeId:  fmt.Sprintf("spiffe://example.org/workload-%d", i),
+				Selectors: podSelectors(i, selectorsPerEntry),
+			},
+		})
+		require.NoError(b, err)
+	}
+
+	// The workload selectors include a few that no entry has
+	selectors := podSelectors(numEntries/2, selectorsPerEntry)
+	selectors = append(selectors,
+		&common.Selector{Type: "k8s", Value: "node-name:node1"},
+		&common.Selector{Type: "k8s", Value: "pod-uid:00000000-0000-0000-0000-000000000000"},
+	)
+	req := &datastore.ListRegistrationEntriesRequest{
+		BySelectors: &datastore.BySelectors{
+			Selectors: selectors,
+			Match:     datastore.BySelectors_MATCH_SUBSET,
+		},
+	}
+
+	resp, err := p.ListRegistrationEntries(ctx, req)
+	require.NoError(b, err)
+	require.Len(b, resp.Entries, 1)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.ListRegistrationEntries(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func newBenchmarkPlugin(b *testing.B) (datastore.Plugin, func()) {
+	dir, err := ioutil.TempDir("", "spire-datastore-sql-bench")
+	require.NoError(b, err)
+
+	p := New()
+	metricsService := metricsservice.New(metricsservice.Config{
+		Metrics: fakemetrics.New(),
+	})
+
+	var ds datastore.Plugin
+	done := spiretest.LoadPlugin(b, builtin(p), &ds,
+		spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
+	_, err = ds.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			database_type = "sqlite3"
+			connection_string = "%s"
+			`, filepath.Join(dir, "bench.sqlite3")),
+	})
+	require.NoError(b, err)
+
+	return ds, func() {
+		p.closeDB()
+		done()
+		os.RemoveAll(dir)
+	}
+}
+
+func podSelectors(id, n int) []*common.Selector {
+	selectors := []*common.Selector{
+		{Type: "k8s", Value: "ns:default"},
+		{Type: "k8s", Value: "sa:default"},
+	}
+	for i := len(selectors); i < n; i++ {
+		selectors = append(selectors, &common.Selector{
+			Type:  "k8s",
+			Value: fmt.Sprintf("pod-label:label%d:pod%d", i, id),
+		})
+	}
+	return selectors
+}
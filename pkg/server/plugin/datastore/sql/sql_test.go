@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync/atomic"
@@ -21,6 +22,7 @@ import (
 	ds_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/datastore"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/datastoretest"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/clock"
@@ -56,6 +58,34 @@ func TestPlugin(t *testing.T) {
 	spiretest.Run(t, new(PluginSuite))
 }
 
+func TestConformance(t *testing.T) {
+	datastoretest.Run(t, func(t *testing.T) datastore.DataStore {
+		dir, err := ioutil.TempDir("", "spire-test-")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			os.RemoveAll(dir)
+		})
+
+		p := New()
+		var ds datastore.Plugin
+		metricsService := metricsservice.New(metricsservice.Config{
+			Metrics: fakemetrics.New(),
+		})
+		done := spiretest.LoadPlugin(t, builtin(p), &ds,
+			spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
+		t.Cleanup(func() {
+			p.closeDB()
+			done()
+		})
+
+		_, err = ds.Configure(ctx, &spi.ConfigureRequest{
+			Configuration: testDataStoreConfig(t, filepath.Join(dir, "db.sqlite3")),
+		})
+		require.NoError(t, err)
+		return ds
+	})
+}
+
 type PluginSuite struct {
 	spiretest.Suite
 
@@ -135,22 +165,14 @@ func (s *PluginSuite) newPlugin() datastore.Plugin {
 	s.LoadPlugin(builtin(p), &ds,
 		spiretest.HostService(proto_services.MetricsServiceHostServiceServer(metricsService)))
 
-	// When the test suite is executed normally, we test against sqlite3 since
-	// it requires no external dependencies. The integration test framework
-	// builds the test harness for a specific dialect and connection string
-	switch TestDialect {
-	case "":
-		s.nextID++
-		dbPath := filepath.Join(s.dir, fmt.Sprintf("db%d.sqlite3", s.nextID))
-		_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
-			Configuration: fmt.Sprintf(`
-				database_type = "sqlite3"
-				log_sql = true
-				connection_string = "%s"
-				`, dbPath),
-		})
-		s.Require().NoError(err)
+	s.nextID++
+	dbPath := filepath.Join(s.dir, fmt.Sprintf("db%d.sqlite3", s.nextID))
+	_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: testDataStoreConfig(s.T(), dbPath),
+	})
+	s.Require().NoError(err)
 
+	if TestDialect == "" {
 		// assert that WAL journal mode is enabled
 		jm := struct {
 			JournalMode string
@@ -164,37 +186,42 @@ func (s *PluginSuite) newPlugin() datastore.Plugin {
 		}{}
 		p.db.Raw("PRAGMA foreign_keys").Scan(&fk)
 		s.Require().Equal(fk.ForeignKeys, "1")
+	}
+
+	return ds
+}
+
+// testDataStoreConfig returns the plugin configuration for the database the
+// tests run against. When the tests are executed normally, they run against
+// a sqlite3 database at the given path since it requires no external
+// dependencies. The integration test framework builds the test harness for a
+// specific dialect and connection string, in which case the database is
+// wiped first.
+func testDataStoreConfig(t *testing.T, dbPath string) string {
+	switch TestDialect {
+	case "":
+		return fmt.Sprintf(`
+			database_type = "sqlite3"
+			log_sql = true
+			connection_string = "%s"
+			`, dbPath)
 	case "mysql":
-		s.T().Logf("CONN STRING: %q", TestConnString)
-		s.Require().NotEmpty(TestConnString, "connection string must be set")
-		wipeMySQL(s.T(), TestConnString)
-		_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
-			Configuration: fmt.Sprintf(`
-				database_type = "mysql"
-				log_sql = true
-				connection_string = "%s"
-				ro_connection_string = "%s"
-				`, TestConnString, TestROConnString),
-		})
-		s.Require().NoError(err)
+		t.Logf("CONN STRING: %q", TestConnString)
+		require.NotEmpty(t, TestConnString, "connection string must be set")
+		wipeMySQL(t, TestConnString)
 	case "postgres":
-		s.T().Logf("CONN STRING: %q", TestConnString)
-		s.Require().NotEmpty(TestConnString, "connection string must be set")
-		wipePostgres(s.T(), TestConnString)
-		_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
-			Configuration: fmt.Sprintf(`
-				database_type = "postgres"
-				log_sql = true
-				connection_string = "%s"
-				ro_connection_string = "%s"
-				`, TestConnString, TestROConnString),
-		})
-		s.Require().NoError(err)
+		t.Logf("CONN STRING: %q", TestConnString)
+		require.NotEmpty(t, TestConnString, "connection string must be set")
+		wipePostgres(t, TestConnString)
 	default:
-		s.Require().FailNowf("Unsupported external test dialect %q", TestDialect)
+		require.FailNowf(t, "Unsupported external test dialect", "dialect %q", TestDialect)
 	}
-
-	return ds
+	return fmt.Sprintf(`
+		database_type = "%s"
+		log_sql = true
+		connection_string = "%s"
+		ro_connection_string = "%s"
+		`, TestDialect, TestConnString, TestROConnString)
 }
 
 func (s *PluginSuite) TestInvalidPluginConfiguration() {
@@ -370,6 +397,140 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestFederationRelationshipCRUD() {
+	relationship := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://foo",
+		BundleEndpointUrl:     "https://foo.test/bundle",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_SPIFFE,
+		EndpointSpiffeId:      "spiffe://foo/bundle-server",
+	}
+
+	// fetch non-existent
+	expectedCallCounter := ds_telemetry.StartFetchFederationRelationshipCall(s.expectedMetrics)
+	fresp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{TrustDomainId: "spiffe://foo"})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(fresp)
+	s.Require().Nil(fresp.FederationRelationship)
+
+	// update non-existent
+	expectedCallCounter = ds_telemetry.StartUpdateFederationRelationshipCall(s.expectedMetrics)
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{FederationRelationship: relationship})
+	expectedErr := status.Error(codes.NotFound, _notFoundErrMsg)
+	expectedCallCounter.Done(&expectedErr)
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// delete non-existent
+	expectedCallCounter = ds_telemetry.StartDeleteFederationRelationshipCall(s.expectedMetrics)
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{TrustDomainId: "spiffe://foo"})
+	expectedErr = status.Error(codes.NotFound, _notFoundErrMsg)
+	expectedCallCounter.Done(&expectedErr)
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// create without bundle endpoint URL
+	expectedCallCounter = ds_telemetry.StartCreateFederationRelationshipCall(s.expectedMetrics)
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{TrustDomainId: "spiffe://foo"},
+	})
+	expectedCallCounter.Done(&err)
+	s.Require().EqualError(err, "datastore-sql: invalid federation relationship: missing bundle endpoint URL")
+
+	// create
+	expectedCallCounter = ds_telemetry.StartCreateFederationRelationshipCall(s.expectedMetrics)
+	cresp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: relationship,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, cresp.FederationRelationship)
+
+	// create duplicate
+	expectedCallCounter = ds_telemetry.StartCreateFederationRelationshipCall(s.expectedMetrics)
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: relationship,
+	})
+	expectedCallCounter.Done(&err)
+	s.Require().Error(err)
+
+	// fetch (with denormalized id)
+	expectedCallCounter = ds_telemetry.StartFetchFederationRelationshipCall(s.expectedMetrics)
+	fresp, err = s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{TrustDomainId: "spiffe://fOO"})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, fresp.FederationRelationship)
+
+	// update
+	relationship2 := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://foo",
+		BundleEndpointUrl:     "https://foo.test/other",
+		BundleEndpointProfile: datastore.FederationRelationship_HTTPS_WEB,
+	}
+	expectedCallCounter = ds_telemetry.StartUpdateFederationRelationshipCall(s.expectedMetrics)
+	uresp, err := s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: relationship2,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship2, uresp.FederationRelationship)
+
+	// list
+	relationship3 := &datastore.FederationRelationship{
+		TrustDomainId:     "spiffe://bar",
+		BundleEndpointUrl: "https://bar.test/bundle",
+	}
+	expectedCallCounter = ds_telemetry.StartCreateFederationRelationshipCall(s.expectedMetrics)
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: relationship3,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	expectedCallCounter = ds_telemetry.StartListFederationRelationshipsCall(s.expectedMetrics)
+	lresp, err := s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Len(lresp.FederationRelationships, 2)
+	s.AssertProtoEqual(relationship2, lresp.FederationRelationships[0])
+	s.AssertProtoEqual(relationship3, lresp.FederationRelationships[1])
+
+	// list with pagination
+	expectedCallCounter = ds_telemetry.StartListFederationRelationshipsCall(s.expectedMetrics)
+	lresp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: &datastore.Pagination{PageSize: 1},
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Len(lresp.FederationRelationships, 1)
+	s.AssertProtoEqual(relationship2, lresp.FederationRelationships[0])
+
+	expectedCallCounter = ds_telemetry.StartListFederationRelationshipsCall(s.expectedMetrics)
+	lresp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: lresp.Pagination,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Len(lresp.FederationRelationships, 1)
+	s.AssertProtoEqual(relationship3, lresp.FederationRelationships[0])
+
+	// delete
+	expectedCallCounter = ds_telemetry.StartDeleteFederationRelationshipCall(s.expectedMetrics)
+	dresp, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship2, dresp.FederationRelationship)
+
+	expectedCallCounter = ds_telemetry.StartListFederationRelationshipsCall(s.expectedMetrics)
+	lresp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Len(lresp.FederationRelationships, 1)
+	s.AssertProtoEqual(relationship3, lresp.FederationRelationships[0])
+
+	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
+}
+
 func (s *PluginSuite) TestListBundlesWithPagination() {
 	bundle1 := bundleutil.BundleProtoFromRootCA("spiffe://example.org", s.cert)
 	_, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
@@ -682,6 +843,234 @@ func (s *PluginSuite) TestFetchStaleNodes() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestFetchBannedNodes() {
+	active := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	banned := &common.AttestedNode{
+		SpiffeId:            "bar",
+		AttestationDataType: "aws-tag",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		Banned:              true,
+	}
+
+	pending := &common.AttestedNode{
+		SpiffeId:            "baz",
+		AttestationDataType: "aws-tag",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		NewCertSerialNumber: "deadbeef",
+		NewCertNotAfter:     time.Now().Add(2 * time.Hour).Unix(),
+	}
+
+	expectedCallCounter := ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: active})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	expectedCallCounter = ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: banned})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	expectedCallCounter = ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: pending})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	expectedCallCounter = ds_telemetry.StartListNodeCall(s.expectedMetrics)
+	sresp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByBanned: &wrappers.BoolValue{Value: true},
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{banned}, sresp.Nodes)
+
+	expectedCallCounter = ds_telemetry.StartListNodeCall(s.expectedMetrics)
+	sresp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByBanned: &wrappers.BoolValue{Value: false},
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{active, pending}, sresp.Nodes)
+
+	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
+}
+
+func (s *PluginSuite) TestListAttestedNodesByAttestationType() {
+	aws := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	gcp := &common.AttestedNode{
+		SpiffeId:            "bar",
+		AttestationDataType: "gcp-iit",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	s.createAttestedNode(aws)
+	s.createAttestedNode(gcp)
+
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByAttestationType: "gcp-iit",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{gcp}, resp.Nodes)
+}
+
+func (s *PluginSuite) TestListAttestedNodesByCanReattest() {
+	reattestable := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "x509pop",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		CanReattest:         true,
+	}
+	other := &common.AttestedNode{
+		SpiffeId:            "bar",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	s.createAttestedNode(reattestable)
+	s.createAttestedNode(other)
+
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByCanReattest: &wrappers.BoolValue{Value: true},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{reattestable}, resp.Nodes)
+
+	resp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByCanReattest: &wrappers.BoolValue{Value: false},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{other}, resp.Nodes)
+}
+
+func (s *PluginSuite) TestListAttestedNodesBySelectorMatch() {
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+	c3 := &common.Selector{Type: "c", Value: "3"}
+
+	nodeA := s.createAttestedNodeWithSelectors("a", a1)
+	nodeAB := s.createAttestedNodeWithSelectors("ab", a1, b2)
+	nodeABC := s.createAttestedNodeWithSelectors("abc", a1, b2, c3)
+	s.createAttestedNodeWithSelectors("none")
+
+	for _, tt := range []struct {
+		name      string
+		selectors []*common.Selector
+		match     datastore.BySelectors_MatchBehavior
+		expected  []*common.AttestedNode
+	}{
+		{
+			name:      "exact single",
+			selectors: []*common.Selector{a1},
+			match:     datastore.BySelectors_MATCH_EXACT,
+			expected:  []*common.AttestedNode{nodeA},
+		},
+		{
+			name:      "exact multiple",
+			selectors: []*common.Selector{b2, a1},
+			match:     datastore.BySelectors_MATCH_EXACT,
+			expected:  []*common.AttestedNode{nodeAB},
+		},
+		{
+			name:      "exact duplicated",
+			selectors: []*common.Selector{a1, b2, a1},
+			match:     datastore.BySelectors_MATCH_EXACT,
+			expected:  []*common.AttestedNode{nodeAB},
+		},
+		{
+			name:      "subset",
+			selectors: []*common.Selector{a1, b2},
+			match:     datastore.BySelectors_MATCH_SUBSET,
+			expected:  []*common.AttestedNode{nodeA, nodeAB},
+		},
+		{
+			name:      "subset superset of all",
+			selectors: []*common.Selector{c3, b2, a1},
+			match:     datastore.BySelectors_MATCH_SUBSET,
+			expected:  []*common.AttestedNode{nodeA, nodeAB, nodeABC},
+		},
+		{
+			name:      "subset without matches",
+			selectors: []*common.Selector{c3},
+			match:     datastore.BySelectors_MATCH_SUBSET,
+		},
+		{
+			name:      "any",
+			selectors: []*common.Selector{c3, b2},
+			match:     datastore.BySelectors_MATCH_ANY,
+			expected:  []*common.AttestedNode{nodeAB, nodeABC},
+		},
+		{
+			name:      "superset",
+			selectors: []*common.Selector{a1, b2},
+			match:     datastore.BySelectors_MATCH_SUPERSET,
+			expected:  []*common.AttestedNode{nodeAB, nodeABC},
+		},
+		{
+			name:      "superset without matches",
+			selectors: []*common.Selector{a1, {Type: "d", Value: "4"}},
+			match:     datastore.BySelectors_MATCH_SUPERSET,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+				BySelectorMatch: &datastore.BySelectors{
+					Selectors: tt.selectors,
+					Match:     tt.match,
+				},
+			})
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expected, resp.Nodes)
+		})
+	}
+
+	_, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		BySelectorMatch: &datastore.BySelectors{},
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot list by empty selector set")
+}
+
+func (s *PluginSuite) TestListAttestedNodesFetchSelectors() {
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+
+	nodeAB := s.createAttestedNodeWithSelectors("ab", a1, b2)
+	nodeNone := s.createAttestedNodeWithSelectors("none")
+
+	// Selectors are not returned unless requested
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{nodeAB, nodeNone}, resp.Nodes)
+
+	resp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+		Pagination: &datastore.Pagination{
+			PageSize: 1,
+		},
+	})
+	s.Require().NoError(err)
+	nodeAB.Selectors = []*common.Selector{a1, b2}
+	s.RequireProtoListEqual([]*common.AttestedNode{nodeAB}, resp.Nodes)
+
+	resp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+		Pagination:     resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{nodeNone}, resp.Nodes)
+}
+
 func (s *PluginSuite) TestFetchAttestedNodesWithPagination() {
 	// Create all necessary nodes
 	aNode1 := &common.AttestedNode{
@@ -924,49 +1313,148 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
-func (s *PluginSuite) TestDeleteAttestedNode() {
-	entry := &common.AttestedNode{
+func (s *PluginSuite) TestUpdateAttestedNodeWithMask() {
+	node := &common.AttestedNode{
 		SpiffeId:            "foo",
 		AttestationDataType: "aws-tag",
 		CertSerialNumber:    "badcafe",
 		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		NewCertSerialNumber: "deadbeef",
+		NewCertNotAfter:     time.Now().Add(2 * time.Hour).Unix(),
 	}
 
-	// delete it before it exists
-	expectedCallCounter := ds_telemetry.StartDeleteNodeCall(s.expectedMetrics)
-	_, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: entry.SpiffeId})
-	expectedError := status.Error(codes.NotFound, _notFoundErrMsg)
-	expectedCallCounter.Done(&expectedError)
-	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
-
-	expectedCallCounter = ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
-	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: entry})
+	expectedCallCounter := ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
 
-	expectedCallCounter = ds_telemetry.StartDeleteNodeCall(s.expectedMetrics)
-	dresp, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: entry.SpiffeId})
+	// Only the masked fields are updated, even when set to the zero value
+	expectedCallCounter = ds_telemetry.StartUpdateNodeCall(s.expectedMetrics)
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId: node.SpiffeId,
+		InputMask: &datastore.AttestedNodeMask{
+			CertSerialNumber:    true,
+			NewCertSerialNumber: true,
+			NewCertNotAfter:     true,
+		},
+	})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	s.AssertProtoEqual(entry, dresp.Node)
 
 	expectedCallCounter = ds_telemetry.StartFetchNodeCall(s.expectedMetrics)
-	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: entry.SpiffeId})
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	s.Nil(fresp.Node)
+	s.RequireProtoEqual(&common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertNotAfter:        node.CertNotAfter,
+		Banned:              true,
+	}, fresp.Node)
 
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
-func (s *PluginSuite) TestNodeSelectors() {
-	foo1 := []*common.Selector{
-		{Type: "FOO1", Value: "1"},
-	}
-	foo2 := []*common.Selector{
-		{Type: "FOO2", Value: "1"},
+func (s *PluginSuite) TestUpdateAttestedNodeAgentInfo() {
+	node := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		AgentVersion:        "0.10.0",
+		AgentPlugins: []*common.AgentPlugin{
+			{Type: "KeyManager", Name: "disk"},
+			{Type: "NodeAttestor", Name: "aws_iid"},
+		},
+		LastSeen:    time.Now().Add(-time.Hour).Unix(),
+		CanReattest: true,
 	}
-	bar := []*common.Selector{
+	s.createAttestedNode(node)
+
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(node, fresp.Node)
+
+	// Updates that only record the last seen time do not record change events
+	start := s.listChangeEvents(0, 0).LatestRevision
+	lastSeen := time.Now().Unix()
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:  node.SpiffeId,
+		LastSeen:  lastSeen,
+		InputMask: &datastore.AttestedNodeMask{LastSeen: true},
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(s.listChangeEvents(start, 0).Events)
+
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:     node.SpiffeId,
+		AgentVersion: "0.11.0",
+		AgentPlugins: []*common.AgentPlugin{{Type: "KeyManager", Name: "memory"}},
+		InputMask: &datastore.AttestedNodeMask{
+			AgentVersion: true,
+			AgentPlugins: true,
+			CanReattest:  true,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(s.listChangeEvents(start, 0).Events, 1)
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(&common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertSerialNumber:    node.CertSerialNumber,
+		CertNotAfter:        node.CertNotAfter,
+		AgentVersion:        "0.11.0",
+		AgentPlugins:        []*common.AgentPlugin{{Type: "KeyManager", Name: "memory"}},
+		LastSeen:            lastSeen,
+	}, fresp.Node)
+}
+
+func (s *PluginSuite) TestDeleteAttestedNode() {
+	entry := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	// delete it before it exists
+	expectedCallCounter := ds_telemetry.StartDeleteNodeCall(s.expectedMetrics)
+	_, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: entry.SpiffeId})
+	expectedError := status.Error(codes.NotFound, _notFoundErrMsg)
+	expectedCallCounter.Done(&expectedError)
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	expectedCallCounter = ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: entry})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	expectedCallCounter = ds_telemetry.StartDeleteNodeCall(s.expectedMetrics)
+	dresp, err := s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: entry.SpiffeId})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(entry, dresp.Node)
+
+	expectedCallCounter = ds_telemetry.StartFetchNodeCall(s.expectedMetrics)
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: entry.SpiffeId})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Nil(fresp.Node)
+
+	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
+}
+
+func (s *PluginSuite) TestNodeSelectors() {
+	foo1 := []*common.Selector{
+		{Type: "FOO1", Value: "1"},
+	}
+	foo2 := []*common.Selector{
+		{Type: "FOO2", Value: "1"},
+	}
+	bar := []*common.Selector{
 		{Type: "BAR", Value: "FIGHT"},
 	}
 
@@ -1010,6 +1498,44 @@ func (s *PluginSuite) TestNodeSelectors() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestListNodeSelectors() {
+	// assert there are no selectors
+	s.Require().Empty(s.listNodeSelectors(true))
+	s.Require().Empty(s.listNodeSelectors(false))
+
+	s.setNodeSelectors("foo", []*common.Selector{
+		{Type: "FOO", Value: "2"},
+		{Type: "FOO", Value: "1"},
+	})
+	s.setNodeSelectors("bar", []*common.Selector{
+		{Type: "BAR", Value: "FIGHT"},
+	})
+	s.setNodeSelectors("baz", []*common.Selector{
+		{Type: "BAZ", Value: "1"},
+	})
+	s.setNodeSelectors("baz", nil)
+
+	// selectors are grouped by node, ordered by SPIFFE ID, and keep the
+	// order in which they were set
+	expected := []*datastore.NodeSelectors{
+		{
+			SpiffeId:  "bar",
+			Selectors: []*common.Selector{{Type: "BAR", Value: "FIGHT"}},
+		},
+		{
+			SpiffeId: "foo",
+			Selectors: []*common.Selector{
+				{Type: "FOO", Value: "2"},
+				{Type: "FOO", Value: "1"},
+			},
+		},
+	}
+	s.RequireProtoListEqual(expected, s.listNodeSelectors(true))
+	s.RequireProtoListEqual(expected, s.listNodeSelectors(false))
+
+	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
+}
+
 func (s *PluginSuite) TestSetNodeSelectorsUnderLoad() {
 	selectors := []*common.Selector{
 		{Type: "TYPE", Value: "VALUE"},
@@ -1492,6 +2018,259 @@ func (s *PluginSuite) TestListRegistrationEntriesAgainstMultipleCriteria() {
 	s.RequireProtoListEqual([]*common.RegistrationEntry{entry}, resp.Entries)
 }
 
+func (s *PluginSuite) TestListRegistrationEntriesByFederatesWith() {
+	s.createBundle("spiffe://td1.org")
+	s.createBundle("spiffe://td2.org")
+
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/P1",
+		SpiffeId:      "spiffe://example.org/S1",
+		Selectors:     []*common.Selector{{Type: "T1", Value: "V1"}},
+		FederatesWith: []string{"spiffe://td1.org"},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/P1",
+		SpiffeId:      "spiffe://example.org/S2",
+		Selectors:     []*common.Selector{{Type: "T1", Value: "V1"}},
+		FederatesWith: []string{"spiffe://td1.org", "spiffe://td2.org"},
+	})
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/P1",
+		SpiffeId:  "spiffe://example.org/S3",
+		Selectors: []*common.Selector{{Type: "T1", Value: "V1"}},
+	})
+
+	for _, tt := range []struct {
+		name          string
+		trustDomains  []string
+		match         datastore.ByFederatesWith_MatchBehavior
+		pagination    *datastore.Pagination
+		expectEntries []*common.RegistrationEntry
+	}{
+		{
+			name:          "one trust domain",
+			trustDomains:  []string{"spiffe://td1.org"},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name:          "any trust domain",
+			trustDomains:  []string{"spiffe://td2.org", "spiffe://td3.org"},
+			match:         datastore.ByFederatesWith_MATCH_ANY,
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:          "any trust domain with many matches",
+			trustDomains:  []string{"spiffe://td1.org", "spiffe://td2.org"},
+			match:         datastore.ByFederatesWith_MATCH_ANY,
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name:          "all trust domains",
+			trustDomains:  []string{"spiffe://td1.org", "spiffe://td2.org"},
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:         "paginated",
+			trustDomains: []string{"spiffe://td2.org"},
+			pagination: &datastore.Pagination{
+				PageSize: 1,
+			},
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:         "unknown trust domain",
+			trustDomains: []string{"spiffe://td3.org"},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: &datastore.ByFederatesWith{
+					TrustDomains: tt.trustDomains,
+					Match:        tt.match,
+				},
+				Pagination: tt.pagination,
+			})
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectEntries, resp.Entries)
+		})
+	}
+
+	_, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		ByFederatesWith: &datastore.ByFederatesWith{},
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot list by empty federates with set")
+}
+
+func (s *PluginSuite) TestListRegistrationEntriesByAttributes() {
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/P1",
+		SpiffeId:  "spiffe://example.org/S1",
+		Selectors: []*common.Selector{{Type: "T1", Value: "V1"}},
+		DnsNames:  []string{"s1.example.org", "s.example.org"},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/P1",
+		SpiffeId:    "spiffe://example.org/S2",
+		Selectors:   []*common.Selector{{Type: "T1", Value: "V1"}},
+		Downstream:  true,
+		EntryExpiry: 1000,
+		DnsNames:    []string{"s.example.org"},
+	})
+	entry3 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/P2",
+		SpiffeId:    "spiffe://example.org/S3",
+		Selectors:   []*common.Selector{{Type: "T1", Value: "V1"}},
+		Admin:       true,
+		EntryExpiry: 2000,
+	})
+
+	for _, tt := range []struct {
+		name          string
+		req           *datastore.ListRegistrationEntriesRequest
+		expectEntries []*common.RegistrationEntry
+	}{
+		{
+			name:          "downstream",
+			req:           &datastore.ListRegistrationEntriesRequest{ByDownstream: &wrappers.BoolValue{Value: true}},
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name:          "not downstream",
+			req:           &datastore.ListRegistrationEntriesRequest{ByDownstream: &wrappers.BoolValue{Value: false}},
+			expectEntries: []*common.RegistrationEntry{entry1, entry3},
+		},
+		{
+			name:          "admin",
+			req:           &datastore.ListRegistrationEntriesRequest{ByAdmin: &wrappers.BoolValue{Value: true}},
+			expectEntries: []*common.RegistrationEntry{entry3},
+		},
+		{
+			name:          "dns name",
+			req:           &datastore.ListRegistrationEntriesRequest{ByDnsName: &wrappers.StringValue{Value: "s.example.org"}},
+			expectEntries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name:          "unknown dns name",
+			req:           &datastore.ListRegistrationEntriesRequest{ByDnsName: &wrappers.StringValue{Value: "unknown.example.org"}},
+			expectEntries: nil,
+		},
+		{
+			name:          "expires after",
+			req:           &datastore.ListRegistrationEntriesRequest{ByExpiresAfter: &wrappers.Int64Value{Value: 1000}},
+			expectEntries: []*common.RegistrationEntry{entry1, entry3},
+		},
+		{
+			name:          "expires before",
+			req:           &datastore.ListRegistrationEntriesRequest{ByExpiresBefore: &wrappers.Int64Value{Value: 2000}},
+			expectEntries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "expiry window",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByExpiresAfter:  &wrappers.Int64Value{Value: 1500},
+				ByExpiresBefore: &wrappers.Int64Value{Value: 2500},
+			},
+			expectEntries: []*common.RegistrationEntry{entry3},
+		},
+		{
+			name: "combined with parent id",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId:   &wrappers.StringValue{Value: "spiffe://example.org/P1"},
+				ByDownstream: &wrappers.BoolValue{Value: false},
+				ByDnsName:    &wrappers.StringValue{Value: "s.example.org"},
+			},
+			expectEntries: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name: "paginated",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByDnsName:  &wrappers.StringValue{Value: "s.example.org"},
+				Pagination: &datastore.Pagination{PageSize: 1},
+			},
+			expectEntries: []*common.RegistrationEntry{entry1},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectEntries, resp.Entries)
+		})
+	}
+}
+
+func (s *PluginSuite) TestListEntriesBySelectorAnyAndSuperset() {
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+	c3 := &common.Selector{Type: "c", Value: "3"}
+
+	entryA := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/P1",
+		SpiffeId:  "spiffe://example.org/A",
+		Selectors: []*common.Selector{a1},
+	})
+	entryAB := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/P1",
+		SpiffeId:  "spiffe://example.org/AB",
+		Selectors: []*common.Selector{a1, b2},
+	})
+	entryBC := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/P1",
+		SpiffeId:  "spiffe://example.org/BC",
+		Selectors: []*common.Selector{b2, c3},
+	})
+
+	for _, tt := range []struct {
+		name          string
+		selectors     []*common.Selector
+		match         datastore.BySelectors_MatchBehavior
+		expectEntries []*common.RegistrationEntry
+	}{
+		{
+			name:          "any single",
+			selectors:     []*common.Selector{a1},
+			match:         datastore.BySelectors_MATCH_ANY,
+			expectEntries: []*common.RegistrationEntry{entryA, entryAB},
+		},
+		{
+			name:          "any multiple",
+			selectors:     []*common.Selector{a1, c3},
+			match:         datastore.BySelectors_MATCH_ANY,
+			expectEntries: []*common.RegistrationEntry{entryA, entryAB, entryBC},
+		},
+		{
+			name:          "superset single",
+			selectors:     []*common.Selector{b2},
+			match:         datastore.BySelectors_MATCH_SUPERSET,
+			expectEntries: []*common.RegistrationEntry{entryAB, entryBC},
+		},
+		{
+			name:          "superset multiple",
+			selectors:     []*common.Selector{a1, b2},
+			match:         datastore.BySelectors_MATCH_SUPERSET,
+			expectEntries: []*common.RegistrationEntry{entryAB},
+		},
+		{
+			name:      "superset without matches",
+			selectors: []*common.Selector{a1, c3},
+			match:     datastore.BySelectors_MATCH_SUPERSET,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: tt.selectors,
+					Match:     tt.match,
+				},
+			})
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectEntries, resp.Entries)
+		})
+	}
+}
+
 func (s *PluginSuite) TestListRegistrationEntriesWhenCruftRowsExist() {
 	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
 		Entry: &common.RegistrationEntry{
@@ -1546,6 +2325,8 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(updateRegistrationEntryResponse)
+	s.Require().Equal(int64(1), updateRegistrationEntryResponse.Entry.RevisionNumber)
+	entry.RevisionNumber = 1
 
 	expectedCallCounter = ds_telemetry.StartFetchRegistrationCall(s.expectedMetrics)
 	fetchRegistrationEntryResponse, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
@@ -1567,6 +2348,130 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{
+			{Type: "Type1", Value: "Value1"},
+			{Type: "Type2", Value: "Value2"},
+		},
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/bar",
+		Ttl:           1,
+		Admin:         true,
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		DnsNames:      []string{"abcd.efg"},
+	})
+
+	// Fields outside of the mask are ignored, even if set to a zero value
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:    entry.EntryId,
+			Ttl:        2,
+			Downstream: true,
+		},
+		InputMask: &common.RegistrationEntryMask{Ttl: true, Downstream: true},
+	})
+	s.Require().NoError(err)
+	entry.Ttl = 2
+	entry.Downstream = true
+	entry.RevisionNumber = 1
+	s.RequireProtoEqual(entry, resp.Entry)
+	s.RequireProtoEqual(entry, s.fetchRegistrationEntry(entry.EntryId))
+
+	// Fields in the mask are updated, even if set to a zero value
+	resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:   entry.EntryId,
+			Selectors: []*common.Selector{{Type: "Type3", Value: "Value3"}},
+		},
+		InputMask: &common.RegistrationEntryMask{
+			Selectors:     true,
+			Admin:         true,
+			FederatesWith: true,
+			DnsNames:      true,
+		},
+	})
+	s.Require().NoError(err)
+	entry.Selectors = []*common.Selector{{Type: "Type3", Value: "Value3"}}
+	entry.Admin = false
+	entry.FederatesWith = nil
+	entry.DnsNames = nil
+	entry.RevisionNumber = 2
+	s.RequireProtoEqual(entry, resp.Entry)
+	s.RequireProtoEqual(entry, s.fetchRegistrationEntry(entry.EntryId))
+
+	// Masked fields are still validated
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:     &common.RegistrationEntry{EntryId: entry.EntryId},
+		InputMask: &common.RegistrationEntryMask{Selectors: true},
+	})
+	s.Require().EqualError(err, "datastore-sql: invalid registration entry: missing selector list")
+}
+
+func (s *PluginSuite) TestRegistrationEntryRevisionNumber() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	})
+	s.Require().Zero(entry.RevisionNumber)
+
+	// Updates without an expected revision number always succeed
+	entry.Ttl = 10
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), resp.Entry.RevisionNumber)
+
+	// Updates with a stale revision number are rejected
+	entry.Ttl = 20
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:                  entry,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, "registration entry revision number mismatch: expected 0, current 1")
+	s.Require().Equal(int32(10), s.fetchRegistrationEntry(entry.EntryId).Ttl)
+
+	resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:                  entry,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 1},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), resp.Entry.RevisionNumber)
+
+	fetched := s.fetchRegistrationEntry(entry.EntryId)
+	s.Require().Equal(int32(20), fetched.Ttl)
+	s.Require().Equal(int64(2), fetched.RevisionNumber)
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Entries, 1)
+	s.Require().Equal(int64(2), listResp.Entries[0].RevisionNumber)
+
+	// Deletes with a stale revision number are rejected
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                entry.EntryId,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 1},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, "registration entry revision number mismatch: expected 1, current 2")
+	s.fetchRegistrationEntry(entry.EntryId)
+
+	delResp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:                entry.EntryId,
+		ExpectedRevisionNumber: &wrappers.Int64Value{Value: 2},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(fetched, delResp.Entry)
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fetchResp.Entry)
+}
+
 func (s *PluginSuite) TestDeleteRegistrationEntry() {
 	// delete non-existing
 	expectedCallCounter := ds_telemetry.StartDeleteRegistrationCall(s.expectedMetrics)
@@ -1774,6 +2679,75 @@ func (s *PluginSuite) TestListEntriesBySelectorSubset() {
 	}
 }
 
+func (s *PluginSuite) TestListEntriesBySelectorSubsetWithSharedSelectors() {
+	ns := &common.Selector{Type: "k8s", Value: "ns:default"}
+	sa := &common.Selector{Type: "k8s", Value: "sa:default"}
+	podLabels := func(app string) []*common.Selector {
+		return []*common.Selector{
+			{Type: "k8s", Value: "pod-label:app:" + app},
+			{Type: "k8s", Value: "pod-label:tier:backend"},
+			{Type: "k8s", Value: "container-name:" + app},
+		}
+	}
+
+	// Every entry shares the namespace selector, which must not be enough
+	// to match entries that have selectors outside of the requested set.
+	nsEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/node",
+		SpiffeId:  "spiffe://example.org/ns",
+		Selectors: []*common.Selector{ns},
+	})
+	fooEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/node",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: append([]*common.Selector{ns, sa}, podLabels("foo")...),
+	})
+	fooPartialEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/node",
+		SpiffeId:  "spiffe://example.org/foo-partial",
+		Selectors: append([]*common.Selector{ns}, podLabels("foo")[:1]...),
+	})
+	for i := 0; i < 10; i++ {
+		s.createRegistrationEntry(&common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/node",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/bar%d", i),
+			Selectors: append([]*common.Selector{ns, sa}, podLabels(fmt.Sprintf("bar%d", i))...),
+		})
+	}
+
+	fooSelectors := append([]*common.Selector{ns, sa}, podLabels("foo")...)
+	fooSelectors = append(fooSelectors, &common.Selector{Type: "k8s", Value: "node-name:node1"})
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySelectors: &datastore.BySelectors{
+			Selectors: fooSelectors,
+			Match:     datastore.BySelectors_MATCH_SUBSET,
+		},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.RegistrationEntry{nsEntry, fooEntry, fooPartialEntry}, resp.Entries)
+
+	// Subset matching pages through the true subsets only
+	var entries []*common.RegistrationEntry
+	pagination := &datastore.Pagination{PageSize: 2}
+	for {
+		resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySelectors: &datastore.BySelectors{
+				Selectors: fooSelectors,
+				Match:     datastore.BySelectors_MATCH_SUBSET,
+			},
+			Pagination: pagination,
+		})
+		s.Require().NoError(err)
+		if len(resp.Entries) == 0 {
+			break
+		}
+		entries = append(entries, resp.Entries...)
+		pagination = resp.Pagination
+	}
+	s.RequireProtoListEqual([]*common.RegistrationEntry{nsEntry, fooEntry, fooPartialEntry}, entries)
+}
+
 func (s *PluginSuite) TestRegistrationEntriesFederatesWithAgainstMissingBundle() {
 	// cannot federate with a trust bundle that does not exist
 	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
@@ -1854,16 +2828,137 @@ func (s *PluginSuite) TestDeleteBundleDissociateRegistrationEntries() {
 	s.Require().Empty(entry.FederatesWith)
 }
 
-func (s *PluginSuite) TestCreateJoinToken() {
-	now := time.Now().Unix()
-	req := &datastore.CreateJoinTokenRequest{
-		JoinToken: &datastore.JoinToken{
-			Token:  "foobar",
-			Expiry: now,
-		},
-	}
-	expectedCallCounter := ds_telemetry.StartCreateJoinTokenCall(s.expectedMetrics)
-	_, err := s.ds.CreateJoinToken(ctx, req)
+func (s *PluginSuite) TestChangeEvents() {
+	start := s.listChangeEvents(0, 0).LatestRevision
+
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	s.createAttestedNodeWithSelectors("spiffe://example.org/host", &common.Selector{Type: "TYPE", Value: "VALUE"})
+
+	entry.Ttl = 60
+	_, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: entry})
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		Mode:          datastore.DeleteBundleRequest_DISSOCIATE,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: "spiffe://example.org/host"})
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+
+	resp := s.listChangeEvents(start, 0)
+	s.Require().Len(resp.Events, 9)
+	s.Require().Equal(resp.Events[len(resp.Events)-1].Revision, resp.LatestRevision)
+
+	type event struct {
+		Type         datastore.ChangeEvent_Type
+		ResourceType datastore.ChangeEvent_ResourceType
+		ResourceID   string
+	}
+	var events []event
+	revision := start
+	for _, e := range resp.Events {
+		s.Require().Greater(e.Revision, revision, "revisions must increase")
+		s.Require().NotZero(e.CreatedAt)
+		revision = e.Revision
+		events = append(events, event{Type: e.Type, ResourceType: e.ResourceType, ResourceID: e.ResourceId})
+	}
+	s.Require().Equal([]event{
+		{Type: datastore.ChangeEvent_CREATED, ResourceType: datastore.ChangeEvent_BUNDLE, ResourceID: "spiffe://otherdomain.org"},
+		{Type: datastore.ChangeEvent_CREATED, ResourceType: datastore.ChangeEvent_REGISTRATION_ENTRY, ResourceID: entry.EntryId},
+		{Type: datastore.ChangeEvent_CREATED, ResourceType: datastore.ChangeEvent_ATTESTED_NODE, ResourceID: "spiffe://example.org/host"},
+		{Type: datastore.ChangeEvent_UPDATED, ResourceType: datastore.ChangeEvent_ATTESTED_NODE, ResourceID: "spiffe://example.org/host"},
+		{Type: datastore.ChangeEvent_UPDATED, ResourceType: datastore.ChangeEvent_REGISTRATION_ENTRY, ResourceID: entry.EntryId},
+		{Type: datastore.ChangeEvent_UPDATED, ResourceType: datastore.ChangeEvent_REGISTRATION_ENTRY, ResourceID: entry.EntryId},
+		{Type: datastore.ChangeEvent_DELETED, ResourceType: datastore.ChangeEvent_BUNDLE, ResourceID: "spiffe://otherdomain.org"},
+		{Type: datastore.ChangeEvent_DELETED, ResourceType: datastore.ChangeEvent_ATTESTED_NODE, ResourceID: "spiffe://example.org/host"},
+		{Type: datastore.ChangeEvent_DELETED, ResourceType: datastore.ChangeEvent_REGISTRATION_ENTRY, ResourceID: entry.EntryId},
+	}, events)
+
+	// Resume after the third event and only list two events
+	limited := s.listChangeEvents(resp.Events[2].Revision, 2)
+	s.RequireProtoListEqual(resp.Events[3:5], limited.Events)
+	s.Require().Equal(resp.LatestRevision, limited.LatestRevision)
+
+	// Nothing is listed after the latest revision
+	latest := s.listChangeEvents(resp.LatestRevision, 0)
+	s.Require().Empty(latest.Events)
+	s.Require().Equal(resp.LatestRevision, latest.LatestRevision)
+
+	// Negative limits are rejected
+	_, err = s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{Limit: -1})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot list change events with a negative limit")
+}
+
+func (s *PluginSuite) TestDeleteBundleDeleteRegistrationEntriesRecordsChangeEvents() {
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	start := s.listChangeEvents(0, 0).LatestRevision
+
+	_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		Mode:          datastore.DeleteBundleRequest_DELETE,
+	})
+	s.Require().NoError(err)
+
+	resp := s.listChangeEvents(start, 0)
+	s.Require().Len(resp.Events, 2)
+	s.Require().Equal(datastore.ChangeEvent_DELETED, resp.Events[0].Type)
+	s.Require().Equal(datastore.ChangeEvent_REGISTRATION_ENTRY, resp.Events[0].ResourceType)
+	s.Require().Equal(entry.EntryId, resp.Events[0].ResourceId)
+	s.Require().Equal(datastore.ChangeEvent_DELETED, resp.Events[1].Type)
+	s.Require().Equal(datastore.ChangeEvent_BUNDLE, resp.Events[1].ResourceType)
+	s.Require().Equal("spiffe://otherdomain.org", resp.Events[1].ResourceId)
+}
+
+func (s *PluginSuite) TestPruneChangeEvents() {
+	s.createBundle("spiffe://otherdomain.org")
+	resp := s.listChangeEvents(0, 0)
+	s.Require().NotEmpty(resp.Events)
+	createdAt := resp.Events[len(resp.Events)-1].CreatedAt
+
+	// Events created at or after the timestamp are not pruned
+	expectedCallCounter := ds_telemetry.StartPruneChangeEventsCall(s.expectedMetrics)
+	_, err := s.ds.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{
+		CreatedBefore: createdAt - 10,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Len(s.listChangeEvents(0, 0).Events, len(resp.Events))
+
+	// Older events are pruned. Revisions are never reused, so cursors
+	// remain valid after pruning.
+	expectedCallCounter = ds_telemetry.StartPruneChangeEventsCall(s.expectedMetrics)
+	_, err = s.ds.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{
+		CreatedBefore: createdAt + 10,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	pruned := s.listChangeEvents(0, 0)
+	s.Require().Empty(pruned.Events)
+
+	s.createBundle("spiffe://thirddomain.org")
+	after := s.listChangeEvents(resp.LatestRevision, 0)
+	s.Require().Len(after.Events, 1)
+	s.Require().Greater(after.Events[0].Revision, resp.LatestRevision)
+	s.Require().Equal("spiffe://thirddomain.org", after.Events[0].ResourceId)
+}
+
+func (s *PluginSuite) TestCreateJoinToken() {
+	now := time.Now().Unix()
+	req := &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  "foobar",
+			Expiry: now,
+		},
+	}
+	expectedCallCounter := ds_telemetry.StartCreateJoinTokenCall(s.expectedMetrics)
+	_, err := s.ds.CreateJoinToken(ctx, req)
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
 
@@ -1956,6 +3051,41 @@ func (s *PluginSuite) TestDeleteJoinToken() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestListJoinTokens() {
+	expectedCallCounter := ds_telemetry.StartListJoinTokensCall(s.expectedMetrics)
+	resp, err := s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Empty(resp.JoinTokens)
+
+	now := time.Now().Unix()
+	joinToken1 := &datastore.JoinToken{
+		Token:  "foobar",
+		Expiry: now,
+	}
+	joinToken2 := &datastore.JoinToken{
+		Token:  "batbaz",
+		Expiry: now + 60,
+	}
+	for _, joinToken := range []*datastore.JoinToken{joinToken1, joinToken2} {
+		expectedCallCounter = ds_telemetry.StartCreateJoinTokenCall(s.expectedMetrics)
+		_, err = s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: joinToken,
+		})
+		expectedCallCounter.Done(nil)
+		s.Require().NoError(err)
+	}
+
+	// Tokens are sorted by value
+	expectedCallCounter = ds_telemetry.StartListJoinTokensCall(s.expectedMetrics)
+	resp, err = s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.JoinToken{joinToken2, joinToken1}, resp.JoinTokens)
+
+	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
+}
+
 func (s *PluginSuite) TestPruneJoinTokens() {
 	now := time.Now().Unix()
 	joinToken := &datastore.JoinToken{
@@ -2254,6 +3384,22 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().Empty(resp.Node.NewCertNotAfter)
 		case 13:
 			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "revision_number"))
+		case 14:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("federation_relationships"))
+		case 15:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("change_events"))
+		case 16:
+			db := s.sqlPlugin.db
+			s.Require().True(db.Dialect().HasColumn("attested_node_entries", "agent_version"))
+			s.Require().True(db.Dialect().HasColumn("attested_node_entries", "agent_plugins"))
+			s.Require().True(db.Dialect().HasColumn("attested_node_entries", "last_seen"))
+			s.Require().True(db.Dialect().HasColumn("attested_node_entries", "can_reattest"))
+		case 17:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("ca_journals"))
+		case 18:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "x509_svid_profile"))
+		case 19:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "jwt_svid_ttl"))
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -2314,6 +3460,47 @@ func (s *PluginSuite) createBundle(trustDomainID string) {
 	s.Require().NoError(err)
 }
 
+func (s *PluginSuite) listChangeEvents(afterRevision int64, limit int32) *datastore.ListChangeEventsResponse {
+	expectedCallCounter := ds_telemetry.StartListChangeEventsCall(s.expectedMetrics)
+	resp, err := s.ds.ListChangeEvents(ctx, &datastore.ListChangeEventsRequest{
+		AfterRevision: afterRevision,
+		Limit:         limit,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *PluginSuite) createAttestedNode(node *common.AttestedNode) {
+	expectedCallCounter := ds_telemetry.StartCreateNodeCall(s.expectedMetrics)
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+}
+
+func (s *PluginSuite) createAttestedNodeWithSelectors(spiffeID string, selectors ...*common.Selector) *common.AttestedNode {
+	node := &common.AttestedNode{
+		SpiffeId:            spiffeID,
+		AttestationDataType: "test",
+		CertSerialNumber:    spiffeID,
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	s.createAttestedNode(node)
+
+	expectedCallCounter := ds_telemetry.StartSetNodeSelectorsCall(s.expectedMetrics)
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: selectors,
+		},
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	return node
+}
+
 func (s *PluginSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	expectedCallCounter := ds_telemetry.StartCreateRegistrationCall(s.expectedMetrics)
 	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
@@ -2360,6 +3547,17 @@ func (s *PluginSuite) getNodeSelectors(spiffeID string, tolerateStale bool) []*c
 	return resp.Selectors.Selectors
 }
 
+func (s *PluginSuite) listNodeSelectors(tolerateStale bool) []*datastore.NodeSelectors {
+	callCounter := ds_telemetry.StartListNodeSelectorsCall(s.expectedMetrics)
+	defer callCounter.Done(nil)
+	resp, err := s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+		TolerateStale: tolerateStale,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp.Selectors
+}
+
 func (s *PluginSuite) setNodeSelectors(spiffeID string, selectors []*common.Selector) {
 	callCounter := ds_telemetry.StartSetNodeSelectorsCall(s.expectedMetrics)
 	resp, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
@@ -2473,6 +3671,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2485,7 +3686,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2496,14 +3697,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -2526,6 +3727,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2539,7 +3743,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2552,7 +3756,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2560,7 +3764,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2584,6 +3788,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2597,7 +3804,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2610,7 +3817,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2618,7 +3825,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2642,6 +3849,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2655,7 +3865,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2668,7 +3878,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2676,7 +3886,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2689,7 +3899,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			by:      []string{"selector-subset-one"},
 			query: `
 WITH listing AS (
-	SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -2700,6 +3910,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2713,7 +3926,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2726,7 +3939,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2734,7 +3947,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2747,11 +3960,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			by:      []string{"selector-subset-many"},
 			query: `
 WITH listing AS (
-	SELECT id FROM (
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		UNION
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-	) s_0
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -2762,6 +3971,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2775,7 +3987,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2788,7 +4000,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2796,7 +4008,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2820,6 +4032,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2833,7 +4048,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2846,7 +4061,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2854,7 +4069,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2882,6 +4097,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2895,7 +4113,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2908,7 +4126,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2916,7 +4134,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2932,7 +4150,7 @@ WITH listing AS (
 	SELECT id FROM (
 		SELECT id FROM registered_entries WHERE parent_id = ?
 		INTERSECT
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
+		SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 	) s_0
 )
 SELECT
@@ -2944,6 +4162,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2957,7 +4178,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2970,7 +4191,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2978,7 +4199,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2994,11 +4215,7 @@ WITH listing AS (
 	SELECT id FROM (
 		SELECT id FROM registered_entries WHERE parent_id = ?
 		INTERSECT
-		SELECT id FROM (
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-			UNION
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		) s_1
+		SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 	) s_0
 )
 SELECT
@@ -3010,6 +4227,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3023,7 +4243,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3036,7 +4256,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3044,7 +4264,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3072,6 +4292,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3085,7 +4308,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3098,7 +4321,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3106,7 +4329,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3136,6 +4359,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3149,7 +4375,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3162,7 +4388,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3170,7 +4396,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3194,6 +4420,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3207,7 +4436,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3220,7 +4449,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3228,7 +4457,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3252,6 +4481,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3265,7 +4497,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3278,7 +4510,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3286,7 +4518,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3311,6 +4543,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3324,7 +4559,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3337,7 +4572,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3345,7 +4580,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3374,6 +4609,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3387,7 +4625,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3400,7 +4638,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3408,7 +4646,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3428,6 +4666,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3440,7 +4681,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3451,14 +4692,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -3481,6 +4722,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3494,7 +4738,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3507,7 +4751,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3515,7 +4759,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3539,6 +4783,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3552,7 +4799,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3565,7 +4812,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3573,7 +4820,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3597,6 +4844,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3610,7 +4860,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3623,7 +4873,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3631,7 +4881,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3644,7 +4894,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			by:      []string{"selector-subset-one"},
 			query: `
 WITH listing AS (
-	SELECT registered_entry_id AS id FROM selectors WHERE type = $1 AND value = $2
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = $1 AND value = $2) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -3655,6 +4905,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3668,7 +4921,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3681,7 +4934,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3689,7 +4942,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3702,11 +4955,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			by:      []string{"selector-subset-many"},
 			query: `
 WITH listing AS (
-	SELECT id FROM (
-		SELECT registered_entry_id AS id FROM selectors WHERE type = $1 AND value = $2
-		UNION
-		SELECT registered_entry_id AS id FROM selectors WHERE type = $3 AND value = $4
-	) s_0
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = $1 AND value = $2) OR (type = $3 AND value = $4) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -3717,6 +4966,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3730,7 +4982,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3743,7 +4995,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3751,7 +5003,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3775,6 +5027,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3788,7 +5043,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3801,7 +5056,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3809,7 +5064,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3837,6 +5092,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3850,7 +5108,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3863,7 +5121,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3871,7 +5129,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3887,7 +5145,7 @@ WITH listing AS (
 	SELECT id FROM (
 		SELECT id FROM registered_entries WHERE parent_id = $1
 		INTERSECT
-		SELECT registered_entry_id AS id FROM selectors WHERE type = $2 AND value = $3
+		SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = $2 AND value = $3) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 	) s_0
 )
 SELECT
@@ -3899,6 +5157,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3912,7 +5173,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3925,7 +5186,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3933,7 +5194,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3949,11 +5210,7 @@ WITH listing AS (
 	SELECT id FROM (
 		SELECT id FROM registered_entries WHERE parent_id = $1
 		INTERSECT
-		SELECT id FROM (
-			SELECT registered_entry_id AS id FROM selectors WHERE type = $2 AND value = $3
-			UNION
-			SELECT registered_entry_id AS id FROM selectors WHERE type = $4 AND value = $5
-		) s_1
+		SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = $2 AND value = $3) OR (type = $4 AND value = $5) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 	) s_0
 )
 SELECT
@@ -3965,6 +5222,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3978,7 +5238,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3991,7 +5251,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3999,7 +5259,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4027,6 +5287,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4040,7 +5303,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4053,7 +5316,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4061,7 +5324,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4091,6 +5354,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4104,7 +5370,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4117,7 +5383,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4125,7 +5391,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4149,6 +5415,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4162,7 +5431,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4175,7 +5444,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4183,7 +5452,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4207,6 +5476,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4220,7 +5492,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4233,7 +5505,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4241,7 +5513,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4266,6 +5538,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4279,7 +5554,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4292,7 +5567,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4300,7 +5575,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4329,6 +5604,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4342,7 +5620,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4355,7 +5633,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4363,7 +5641,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4383,6 +5661,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4416,6 +5697,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4451,6 +5735,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4486,6 +5773,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4521,6 +5811,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4538,7 +5831,7 @@ LEFT JOIN
 LEFT JOIN
 	(federated_registration_entries F INNER JOIN bundles B ON F.bundle_id=B.id) ON joinItem=3 AND E.id=F.registered_entry_id
 WHERE E.id IN (
-	SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 ORDER BY e_id, selector_id, dns_name_id
 ;`,
@@ -4556,6 +5849,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4573,11 +5869,7 @@ LEFT JOIN
 LEFT JOIN
 	(federated_registration_entries F INNER JOIN bundles B ON F.bundle_id=B.id) ON joinItem=3 AND E.id=F.registered_entry_id
 WHERE E.id IN (
-	SELECT id FROM (
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		UNION
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-	) s_0
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 ORDER BY e_id, selector_id, dns_name_id
 ;`,
@@ -4595,6 +5887,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4630,6 +5925,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4670,6 +5968,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4690,7 +5991,7 @@ WHERE E.id IN (
 	SELECT DISTINCT id FROM (
 		(SELECT id FROM registered_entries WHERE parent_id = ?) c_0
 		INNER JOIN
-		(SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?) c_1
+		(SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)) c_1
 		USING(id)
 	)
 )
@@ -4710,6 +6011,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4730,11 +6034,7 @@ WHERE E.id IN (
 	SELECT DISTINCT id FROM (
 		(SELECT id FROM registered_entries WHERE parent_id = ?) c_0
 		INNER JOIN
-		(SELECT id FROM (
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-			UNION
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		) s_1) c_1
+		(SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)) c_1
 		USING(id)
 	)
 )
@@ -4754,6 +6054,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4794,6 +6097,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4837,6 +6143,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4874,6 +6183,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4912,6 +6224,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4950,6 +6265,9 @@ SELECT
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4992,6 +6310,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5004,7 +6325,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5015,14 +6336,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -5046,6 +6367,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5059,7 +6383,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5072,7 +6396,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5080,7 +6404,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5105,6 +6429,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5118,7 +6445,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5131,7 +6458,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5139,7 +6466,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5164,6 +6491,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5177,7 +6507,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5190,7 +6520,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5198,7 +6528,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5212,7 +6542,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			supportsCTE: true,
 			query: `
 WITH listing AS (
-	SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -5223,6 +6553,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5236,7 +6569,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5249,7 +6582,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5257,7 +6590,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5271,11 +6604,7 @@ ORDER BY e_id, selector_id, dns_name_id
 			supportsCTE: true,
 			query: `
 WITH listing AS (
-	SELECT id FROM (
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		UNION
-		SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-	) s_0
+	SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)
 )
 SELECT
 	id as e_id,
@@ -5286,6 +6615,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5299,7 +6631,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5312,7 +6644,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5320,7 +6652,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5345,6 +6677,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5358,7 +6693,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5371,7 +6706,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5379,7 +6714,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5409,6 +6744,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5422,7 +6760,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5435,7 +6773,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5443,7 +6781,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5460,7 +6798,7 @@ WITH listing AS (
 	SELECT DISTINCT id FROM (
 		(SELECT id FROM registered_entries WHERE parent_id = ?) c_0
 		INNER JOIN
-		(SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?) c_1
+		(SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)) c_1
 		USING(id)
 	)
 )
@@ -5473,6 +6811,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5486,7 +6827,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5499,7 +6840,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5507,7 +6848,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5524,11 +6865,7 @@ WITH listing AS (
 	SELECT DISTINCT id FROM (
 		(SELECT id FROM registered_entries WHERE parent_id = ?) c_0
 		INNER JOIN
-		(SELECT id FROM (
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-			UNION
-			SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?
-		) s_1) c_1
+		(SELECT id FROM (SELECT registered_entry_id AS id, COUNT(*) AS matched FROM selectors WHERE (type = ? AND value = ?) OR (type = ? AND value = ?) GROUP BY registered_entry_id) m WHERE matched = (SELECT COUNT(*) FROM selectors WHERE registered_entry_id = m.id)) c_1
 		USING(id)
 	)
 )
@@ -5541,6 +6878,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5554,7 +6894,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5567,7 +6907,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5575,7 +6915,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5605,6 +6945,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5618,7 +6961,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5631,7 +6974,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5639,7 +6982,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5672,6 +7015,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5685,7 +7031,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5698,7 +7044,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5706,7 +7052,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5733,6 +7079,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5746,7 +7095,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5759,7 +7108,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5767,7 +7116,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5794,6 +7143,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5807,7 +7159,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5820,7 +7172,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5828,7 +7180,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5856,6 +7208,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5869,7 +7224,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5882,7 +7237,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5890,7 +7245,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5923,6 +7278,9 @@ SELECT
 	admin,
 	downstream,
 	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5936,7 +7294,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5949,7 +7307,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5957,7 +7315,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5965,69 +7323,679 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 ORDER BY e_id, selector_id, dns_name_id
 ;`,
 		},
-	}
+		{
+			dialect: "sqlite3",
+			by:      []string{"federates-with-one"},
+			query: `
+WITH listing AS (
+	SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
 
-	for _, testCase := range testCases {
-		testCase := testCase
-		name := testCase.dialect + "-list-"
-		if len(testCase.by) == 0 {
-			name += "all"
-		} else {
-			name += "by-" + strings.Join(testCase.by, "-")
-		}
-		if testCase.paged != "" {
-			name += "-paged-" + testCase.paged
-		}
-		if testCase.supportsCTE {
-			name += "-cte"
-		}
-		t.Run(name, func(t *testing.T) {
-			req := new(datastore.ListRegistrationEntriesRequest)
-			switch testCase.paged {
-			case "":
-			case "no-token":
-				req.Pagination = &datastore.Pagination{
-					PageSize: 1,
-				}
-			case "with-token":
-				req.Pagination = &datastore.Pagination{
-					PageSize: 1,
-					Token:    "2",
-				}
-			default:
-				require.FailNow(t, "unsupported page case: %q", testCase.paged)
-			}
+UNION
 
-			for _, by := range testCase.by {
-				switch by {
-				case "parent-id":
-					req.ByParentId = &wrappers.StringValue{
-						Value: "spiffe://parent",
-					}
-				case "spiffe-id":
-					req.BySpiffeId = &wrappers.StringValue{
-						Value: "spiffe://id",
-					}
-				case "selector-subset-one":
-					req.BySelectors = &datastore.BySelectors{
-						Selectors: []*common.Selector{{Type: "a", Value: "1"}},
-						Match:     datastore.BySelectors_MATCH_SUBSET,
-					}
-				case "selector-subset-many":
-					req.BySelectors = &datastore.BySelectors{
-						Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
-						Match:     datastore.BySelectors_MATCH_SUBSET,
-					}
-				case "selector-exact-one":
-					req.BySelectors = &datastore.BySelectors{
-						Selectors: []*common.Selector{{Type: "a", Value: "1"}},
-						Match:     datastore.BySelectors_MATCH_EXACT,
-					}
-				case "selector-exact-many":
-					req.BySelectors = &datastore.BySelectors{
-						Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
-						Match:     datastore.BySelectors_MATCH_EXACT,
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "sqlite3",
+			by:      []string{"parent-id", "federates-with-many"},
+			query: `
+WITH listing AS (
+	SELECT id FROM (
+		SELECT id FROM registered_entries WHERE parent_id = ?
+		INTERSECT
+		SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)
+		INTERSECT
+		SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)
+	) s_0
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "sqlite3",
+			paged:   "with-token",
+			by:      []string{"federates-with-one"},
+			query: `
+WITH listing AS (
+	SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?) AND id > ? ORDER BY id ASC LIMIT 1
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "postgres",
+			by:      []string{"federates-with-many"},
+			query: `
+WITH listing AS (
+	SELECT id FROM (
+		SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = $1)
+		INTERSECT
+		SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = $2)
+	) s_0
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL ::integer AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL ::integer AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "postgres",
+			paged:   "with-token",
+			by:      []string{"federates-with-one"},
+			query: `
+WITH listing AS (
+	SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = $1) AND id > $2 ORDER BY id ASC LIMIT 1
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL ::integer AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL ::integer AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "mysql",
+			by:      []string{"federates-with-many"},
+			query: `
+SELECT
+	E.id AS e_id,
+	E.entry_id AS entry_id,
+	E.spiffe_id,
+	E.parent_id,
+	E.ttl AS reg_ttl,
+	E.admin,
+	E.downstream,
+	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
+	S.id AS selector_id,
+	S.type AS selector_type,
+	S.value AS selector_value,
+	B.trust_domain,
+	D.id AS dns_name_id,
+	D.value AS dns_name
+FROM
+	registered_entries E
+LEFT JOIN
+	(SELECT 1 AS joinItem UNION SELECT 2 UNION SELECT 3) AS joinItems ON TRUE
+LEFT JOIN
+	selectors S ON joinItem=1 AND E.id=S.registered_entry_id
+LEFT JOIN
+	dns_names D ON joinItem=2 AND E.id=D.registered_entry_id
+LEFT JOIN
+	(federated_registration_entries F INNER JOIN bundles B ON F.bundle_id=B.id) ON joinItem=3 AND E.id=F.registered_entry_id
+WHERE E.id IN (
+	SELECT DISTINCT id FROM (
+		(SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)) c_0
+		INNER JOIN
+		(SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)) c_1
+		USING(id)
+	)
+)
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "mysql",
+			paged:   "with-token",
+			by:      []string{"federates-with-one"},
+			query: `
+SELECT
+	E.id AS e_id,
+	E.entry_id AS entry_id,
+	E.spiffe_id,
+	E.parent_id,
+	E.ttl AS reg_ttl,
+	E.admin,
+	E.downstream,
+	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
+	S.id AS selector_id,
+	S.type AS selector_type,
+	S.value AS selector_value,
+	B.trust_domain,
+	D.id AS dns_name_id,
+	D.value AS dns_name
+FROM
+	registered_entries E
+LEFT JOIN
+	(SELECT 1 AS joinItem UNION SELECT 2 UNION SELECT 3) AS joinItems ON TRUE
+LEFT JOIN
+	selectors S ON joinItem=1 AND E.id=S.registered_entry_id
+LEFT JOIN
+	dns_names D ON joinItem=2 AND E.id=D.registered_entry_id
+LEFT JOIN
+	(federated_registration_entries F INNER JOIN bundles B ON F.bundle_id=B.id) ON joinItem=3 AND E.id=F.registered_entry_id
+WHERE E.id IN (
+	SELECT id FROM (
+		SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?) AND id > ? ORDER BY id ASC LIMIT 1
+	) workaround_for_mysql_subquery_limit
+)
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect:     "mysql",
+			by:          []string{"selector-exact-one", "federates-with-one"},
+			supportsCTE: true,
+			query: `
+WITH listing AS (
+	SELECT DISTINCT id FROM (
+		(SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?) c_0
+		INNER JOIN
+		(SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)) c_1
+		USING(id)
+	)
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "sqlite3",
+			by:      []string{"entry-attributes", "federates-with-any-many", "dns-name"},
+			query: `
+WITH listing AS (
+	SELECT id FROM (
+		SELECT id FROM registered_entries WHERE downstream = ? AND admin = ? AND (expiry = 0 OR expiry > ?) AND expiry != 0 AND expiry < ?
+		INTERSECT
+		SELECT id FROM (
+			SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)
+			UNION
+			SELECT id FROM registered_entries WHERE id IN (SELECT F.registered_entry_id FROM federated_registration_entries F INNER JOIN bundles B ON F.bundle_id = B.id WHERE B.trust_domain = ?)
+		) s_1
+		INTERSECT
+		SELECT registered_entry_id AS id FROM dns_names WHERE value = ?
+	) s_0
+)
+SELECT
+	id as e_id,
+	entry_id,
+	spiffe_id,
+	parent_id,
+	ttl AS reg_ttl,
+	admin,
+	downstream,
+	expiry,
+	revision_number,
+	x509_svid_profile,
+	jwt_svid_ttl,
+	NULL AS selector_id,
+	NULL AS selector_type,
+	NULL AS selector_value,
+	NULL AS trust_domain,
+	NULL AS dns_name_id,
+	NULL AS dns_name
+FROM
+	registered_entries
+WHERE id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+FROM
+	bundles B
+INNER JOIN
+	federated_registration_entries F
+ON
+	B.id = F.bundle_id
+WHERE
+	F.registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+FROM
+	dns_names
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+UNION
+
+SELECT
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+FROM
+	selectors
+WHERE registered_entry_id IN (SELECT id FROM listing)
+
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+		{
+			dialect: "mysql",
+			by:      []string{"selector-superset-many", "dns-name"},
+			query: `
+SELECT
+	E.id AS e_id,
+	E.entry_id AS entry_id,
+	E.spiffe_id,
+	E.parent_id,
+	E.ttl AS reg_ttl,
+	E.admin,
+	E.downstream,
+	E.expiry,
+	E.revision_number,
+	E.x509_svid_profile,
+	E.jwt_svid_ttl,
+	S.id AS selector_id,
+	S.type AS selector_type,
+	S.value AS selector_value,
+	B.trust_domain,
+	D.id AS dns_name_id,
+	D.value AS dns_name
+FROM
+	registered_entries E
+LEFT JOIN
+	(SELECT 1 AS joinItem UNION SELECT 2 UNION SELECT 3) AS joinItems ON TRUE
+LEFT JOIN
+	selectors S ON joinItem=1 AND E.id=S.registered_entry_id
+LEFT JOIN
+	dns_names D ON joinItem=2 AND E.id=D.registered_entry_id
+LEFT JOIN
+	(federated_registration_entries F INNER JOIN bundles B ON F.bundle_id=B.id) ON joinItem=3 AND E.id=F.registered_entry_id
+WHERE E.id IN (
+	SELECT DISTINCT id FROM (
+		(SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?) c_0
+		INNER JOIN
+		(SELECT registered_entry_id AS id FROM selectors WHERE type = ? AND value = ?) c_1
+		USING(id)
+		INNER JOIN
+		(SELECT registered_entry_id AS id FROM dns_names WHERE value = ?) c_2
+		USING(id)
+	)
+)
+ORDER BY e_id, selector_id, dns_name_id
+;`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		name := testCase.dialect + "-list-"
+		if len(testCase.by) == 0 {
+			name += "all"
+		} else {
+			name += "by-" + strings.Join(testCase.by, "-")
+		}
+		if testCase.paged != "" {
+			name += "-paged-" + testCase.paged
+		}
+		if testCase.supportsCTE {
+			name += "-cte"
+		}
+		t.Run(name, func(t *testing.T) {
+			req := new(datastore.ListRegistrationEntriesRequest)
+			switch testCase.paged {
+			case "":
+			case "no-token":
+				req.Pagination = &datastore.Pagination{
+					PageSize: 1,
+				}
+			case "with-token":
+				req.Pagination = &datastore.Pagination{
+					PageSize: 1,
+					Token:    "2",
+				}
+			default:
+				require.FailNow(t, "unsupported page case: %q", testCase.paged)
+			}
+
+			for _, by := range testCase.by {
+				switch by {
+				case "parent-id":
+					req.ByParentId = &wrappers.StringValue{
+						Value: "spiffe://parent",
+					}
+				case "spiffe-id":
+					req.BySpiffeId = &wrappers.StringValue{
+						Value: "spiffe://id",
+					}
+				case "selector-subset-one":
+					req.BySelectors = &datastore.BySelectors{
+						Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+						Match:     datastore.BySelectors_MATCH_SUBSET,
+					}
+				case "selector-subset-many":
+					req.BySelectors = &datastore.BySelectors{
+						Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+						Match:     datastore.BySelectors_MATCH_SUBSET,
+					}
+				case "selector-exact-one":
+					req.BySelectors = &datastore.BySelectors{
+						Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+						Match:     datastore.BySelectors_MATCH_EXACT,
+					}
+				case "selector-exact-many":
+					req.BySelectors = &datastore.BySelectors{
+						Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+						Match:     datastore.BySelectors_MATCH_EXACT,
+					}
+				case "federates-with-one":
+					req.ByFederatesWith = &datastore.ByFederatesWith{
+						TrustDomains: []string{"spiffe://td1"},
+					}
+				case "federates-with-many":
+					req.ByFederatesWith = &datastore.ByFederatesWith{
+						TrustDomains: []string{"spiffe://td1", "spiffe://td2"},
+					}
+				case "federates-with-any-many":
+					req.ByFederatesWith = &datastore.ByFederatesWith{
+						TrustDomains: []string{"spiffe://td1", "spiffe://td2"},
+						Match:        datastore.ByFederatesWith_MATCH_ANY,
+					}
+				case "selector-superset-many":
+					req.BySelectors = &datastore.BySelectors{
+						Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+						Match:     datastore.BySelectors_MATCH_SUPERSET,
 					}
+				case "entry-attributes":
+					req.ByDownstream = &wrappers.BoolValue{Value: true}
+					req.ByAdmin = &wrappers.BoolValue{Value: false}
+					req.ByExpiresAfter = &wrappers.Int64Value{Value: 1}
+					req.ByExpiresBefore = &wrappers.Int64Value{Value: 2}
+				case "dns-name":
+					req.ByDnsName = &wrappers.StringValue{Value: "example.org"}
 				default:
 					require.FailNow(t, "unsupported by case: %q", by)
 				}
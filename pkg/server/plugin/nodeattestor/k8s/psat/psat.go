@@ -196,8 +196,9 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 	}
 
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId:   k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, nodeUID),
-		Selectors: selectors,
+		AgentId:     k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, nodeUID),
+		Selectors:   selectors,
+		CanReattest: true,
 	})
 }
 
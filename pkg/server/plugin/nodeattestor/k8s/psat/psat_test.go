@@ -320,6 +320,7 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	s.Require().NotNil(resp)
 	s.Require().Equal(resp.AgentId, "spiffe://example.org/spire/agent/k8s_psat/FOO/NODEUID-1")
 	s.Require().Nil(resp.Challenge)
+	s.Require().True(resp.CanReattest)
 	s.Require().Equal([]*common.Selector{
 		{Type: "k8s_psat", Value: "cluster:FOO"},
 		{Type: "k8s_psat", Value: "agent_ns:NS1"},
@@ -72,7 +72,8 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 	}
 
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId: agentID,
+		AgentId:     agentID,
+		CanReattest: true,
 	})
 }
 
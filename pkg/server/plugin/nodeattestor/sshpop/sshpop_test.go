@@ -106,6 +106,7 @@ func (s *Suite) TestAttestSuccess() {
 	require.NoError(err)
 	require.Equal("spiffe://example.org/spire/agent/sshpop/21Aic_muK032oJMhLfU1_CMNcGmfAnvESeuH5zyFw_g", resp.AgentId)
 	require.Nil(resp.Challenge)
+	require.True(resp.CanReattest)
 	require.Len(resp.Selectors, 0)
 }
 
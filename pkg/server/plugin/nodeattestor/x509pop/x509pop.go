@@ -137,8 +137,9 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 	}
 
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId:   spiffeid,
-		Selectors: buildSelectors(leaf, chains),
+		AgentId:     spiffeid,
+		Selectors:   buildSelectors(leaf, chains),
+		CanReattest: true,
 	})
 }
 
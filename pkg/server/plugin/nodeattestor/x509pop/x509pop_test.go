@@ -117,6 +117,7 @@ func (s *Suite) TestAttestSuccess() {
 			require.NoError(err)
 			require.Equal(tt.expectAgentID, resp.AgentId)
 			require.Nil(resp.Challenge)
+			require.True(resp.CanReattest)
 			require.Len(resp.Selectors, 3)
 			require.EqualValues([]*common.Selector{
 				{Type: "x509pop", Value: "subject:cn:some common name"},
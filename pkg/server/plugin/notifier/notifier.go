@@ -12,11 +12,13 @@ import (
 	"google.golang.org/grpc"
 )
 
+type AgentPruning = notifier.AgentPruning                                               //nolint: golint
 type BundleLoaded = notifier.BundleLoaded                                               //nolint: golint
 type BundleUpdated = notifier.BundleUpdated                                             //nolint: golint
 type NotifierClient = notifier.NotifierClient                                           //nolint: golint
 type NotifierServer = notifier.NotifierServer                                           //nolint: golint
 type NotifyAndAdviseRequest = notifier.NotifyAndAdviseRequest                           //nolint: golint
+type NotifyAndAdviseRequest_AgentPruning = notifier.NotifyAndAdviseRequest_AgentPruning //nolint: golint
 type NotifyAndAdviseRequest_BundleLoaded = notifier.NotifyAndAdviseRequest_BundleLoaded //nolint: golint
 type NotifyAndAdviseResponse = notifier.NotifyAndAdviseResponse                         //nolint: golint
 type NotifyRequest = notifier.NotifyRequest                                             //nolint: golint
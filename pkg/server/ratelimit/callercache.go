@@ -0,0 +1,97 @@
+// Package ratelimit holds the pieces shared by the per-caller rate limiters
+// of the server APIs.
+package ratelimit
+
+import (
+	"time"
+)
+
+const (
+	// GCInterval is the interval at which per-caller limiters are garbage
+	// collected.
+	GCInterval = time.Minute
+
+	// The classes of callers distinguished by per-caller limiters
+	CallerClassAgent      = "agent"
+	CallerClassDownstream = "downstream"
+	CallerClassIP         = "ip"
+)
+
+// CallerCache holds per-caller values, such as rate limiters, and evicts the
+// values of the callers that go unused for a GC interval. The values are kept
+// in two generations. A GC evicts the previous generation, i.e. the values
+// that have not been used since the last GC, and makes the current generation
+// the previous one. Values of the previous generation move back to the
+// current generation when they are used.
+//
+// CallerCache is not safe for concurrent use.
+type CallerCache struct {
+	interval time.Duration
+
+	// previous holds all of the values that were current at the last GC
+	previous map[string]interface{}
+
+	// current holds all of the values that have been set or moved from the
+	// previous values since the last GC
+	current map[string]interface{}
+
+	// lastGC is the last GC
+	lastGC time.Time
+}
+
+// NewCallerCache returns a new cache that is garbage collected at the given
+// interval, starting from now.
+func NewCallerCache(interval time.Duration, now time.Time) *CallerCache {
+	return &CallerCache{
+		interval: interval,
+		current:  make(map[string]interface{}),
+		lastGC:   now,
+	}
+}
+
+// Peek returns the value of the caller if it has been used since the last
+// GC. Unlike Get, it does not modify the cache, so it can be used under a
+// read lock.
+func (c *CallerCache) Peek(key string) (interface{}, bool) {
+	value, ok := c.current[key]
+	return value, ok
+}
+
+// Get returns the value of the caller, moving it to the current generation
+// if it has not been used since the last GC.
+func (c *CallerCache) Get(key string) (interface{}, bool) {
+	if value, ok := c.current[key]; ok {
+		return value, true
+	}
+	if value, ok := c.previous[key]; ok {
+		c.current[key] = value
+		delete(c.previous, key)
+		return value, true
+	}
+	return nil, false
+}
+
+// Set sets the value of the caller in the current generation.
+func (c *CallerCache) Set(key string, value interface{}) {
+	delete(c.previous, key)
+	c.current[key] = value
+}
+
+// GC evicts the values that have not been used since the last GC, if a GC
+// interval has elapsed since then. It returns the number of evicted values
+// and whether a GC happened.
+func (c *CallerCache) GC(now time.Time) (int, bool) {
+	if now.Sub(c.lastGC) < c.interval {
+		return 0, false
+	}
+	evicted := len(c.previous)
+	c.previous = c.current
+	c.current = make(map[string]interface{})
+	c.lastGC = now
+	return evicted, true
+}
+
+// Len returns the number of values in the cache.
+func (c *CallerCache) Len() int {
+	return len(c.previous) + len(c.current)
+}
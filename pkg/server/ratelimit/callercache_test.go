@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerCache(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := NewCallerCache(GCInterval, now)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	require.Equal(t, 2, c.Len())
+
+	// No GC happens before the interval has elapsed
+	evicted, ok := c.GC(now.Add(GCInterval - time.Second))
+	require.False(t, ok)
+	require.Equal(t, 0, evicted)
+
+	// After a GC, the values move to the previous generation. They can only
+	// be peeked at once they have been used again.
+	now = now.Add(GCInterval)
+	evicted, ok = c.GC(now)
+	require.True(t, ok)
+	require.Equal(t, 0, evicted)
+	_, ok = c.Peek("a")
+	require.False(t, ok)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	value, ok = c.Peek("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	// The values that go unused for a GC interval are evicted
+	now = now.Add(GCInterval)
+	evicted, ok = c.GC(now)
+	require.True(t, ok)
+	require.Equal(t, 1, evicted)
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	value, ok = c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	require.Equal(t, 1, c.Len())
+
+	// Setting a value of the previous generation replaces it
+	now = now.Add(GCInterval)
+	_, ok = c.GC(now)
+	require.True(t, ok)
+	c.Set("a", 3)
+	require.Equal(t, 1, c.Len())
+	value, ok = c.Peek("a")
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+}
@@ -5,20 +5,44 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
 )
 
 const (
 	_pruningCandence = 5 * time.Minute
+
+	// _changeEventRetention is how long change events are kept for event
+	// streams to resume from
+	_changeEventRetention = 24 * time.Hour
+
+	// _agentPruningPageSize is how many expired agents are listed from the
+	// datastore at a time
+	_agentPruningPageSize = 1000
 )
 
 // ManagerConfig is the config for the registration manager
 type ManagerConfig struct {
 	DataStore datastore.DataStore
 
+	// PruneAgentsExpiredFor, if non-zero, enables pruning of agents whose
+	// SVID expired more than the given duration ago. Agents that can
+	// reattest and banned agents are never pruned.
+	PruneAgentsExpiredFor time.Duration
+
+	// NotifyAgentPruning, if true, notifies the notifiers before each agent
+	// is pruned. An agent is not pruned if any notifier returns an error.
+	NotifyAgentPruning bool
+
+	Notifiers []catalog.Notifier
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
@@ -61,6 +85,17 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if err := m.pruneChangeEvents(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning change events")
+			}
+			if err := m.pruneJoinTokens(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning join tokens")
+			}
+			if m.c.PruneAgentsExpiredFor > 0 {
+				if err := m.pruneAgents(ctx); err != nil && ctx.Err() == nil {
+					m.log.WithError(err).Error("Failed pruning expired agents")
+				}
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -76,3 +111,124 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	})
 	return err
 }
+
+func (m *Manager) pruneChangeEvents(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneChangeEventsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneChangeEvents(ctx, &datastore.PruneChangeEventsRequest{
+		CreatedBefore: m.c.Clock.Now().Add(-_changeEventRetention).Unix(),
+	})
+	return err
+}
+
+func (m *Manager) pruneJoinTokens(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneJoinTokensCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneJoinTokens(ctx, &datastore.PruneJoinTokensRequest{
+		ExpiresBefore: m.c.Clock.Now().Unix(),
+	})
+	return err
+}
+
+func (m *Manager) pruneAgents(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneAgentsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	// Agents that can reattest will come back on their own and banned agents
+	// must be kept around so they stay banned.
+	req := &datastore.ListAttestedNodesRequest{
+		ByExpiresBefore: &wrappers.Int64Value{
+			Value: m.c.Clock.Now().Add(-m.c.PruneAgentsExpiredFor).Unix(),
+		},
+		ByBanned:      &wrappers.BoolValue{Value: false},
+		ByCanReattest: &wrappers.BoolValue{Value: false},
+		Pagination: &datastore.Pagination{
+			PageSize: _agentPruningPageSize,
+		},
+	}
+
+	// Gather the agents up front so the deletions don't disturb the paging
+	var nodes []*common.AttestedNode
+	for {
+		resp, err := m.c.DataStore.ListAttestedNodes(ctx, req)
+		if err != nil {
+			return err
+		}
+		if len(resp.Nodes) == 0 {
+			break
+		}
+		nodes = append(nodes, resp.Nodes...)
+		req.Pagination.Token = resp.Pagination.Token
+	}
+
+	var allErrs errs.Group
+	for _, node := range nodes {
+		if err := m.pruneAgent(ctx, node); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			allErrs.Add(err)
+		}
+	}
+	return allErrs.Err()
+}
+
+func (m *Manager) pruneAgent(ctx context.Context, node *common.AttestedNode) error {
+	log := m.log.WithFields(logrus.Fields{
+		telemetry.SPIFFEID:   node.SpiffeId,
+		telemetry.Expiration: time.Unix(node.CertNotAfter, 0),
+	})
+
+	if m.c.NotifyAgentPruning {
+		if err := m.notifyAgentPruning(ctx, node); err != nil {
+			log.WithError(err).Warn("Not pruning expired agent")
+			return nil
+		}
+	}
+
+	// Clear the selectors first so they aren't left behind if the agent
+	// can't be deleted
+	if _, err := m.c.DataStore.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId: node.SpiffeId,
+		},
+	}); err != nil {
+		return errs.New("unable to clear selectors of agent %q: %v", node.SpiffeId, err)
+	}
+
+	if _, err := m.c.DataStore.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+		SpiffeId: node.SpiffeId,
+	}); err != nil {
+		return errs.New("unable to delete agent %q: %v", node.SpiffeId, err)
+	}
+
+	telemetry_server.IncrRegistrationManagerPrunedAgentCounter(m.c.Metrics)
+	log.Info("Pruned expired agent")
+	return nil
+}
+
+func (m *Manager) notifyAgentPruning(ctx context.Context, node *common.AttestedNode) error {
+	var allErrs errs.Group
+	for _, n := range m.c.Notifiers {
+		_, err := n.NotifyAndAdvise(ctx, &notifier.NotifyAndAdviseRequest{
+			Event: &notifier.NotifyAndAdviseRequest_AgentPruning{
+				AgentPruning: &notifier.AgentPruning{
+					Node: node,
+				},
+			},
+		})
+		if err != nil {
+			m.log.WithFields(logrus.Fields{
+				telemetry.Notifier: n.Name(),
+				telemetry.Event:    "agent pruning",
+			}).WithError(err).Error("Notifier failed to handle event")
+			allErrs.Add(err)
+		}
+	}
+	if err := allErrs.Err(); err != nil {
+		return errs.New("one or more notifiers returned an error: %v", err)
+	}
+	return nil
+}
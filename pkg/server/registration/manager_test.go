@@ -2,16 +2,22 @@ package registration
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/fakes/fakenotifier"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/spiffe/spire/test/spiretest"
 )
 
@@ -132,13 +138,188 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
-func (s *ManagerSuite) setupAndRunManager() func() {
-	s.m = NewManager(ManagerConfig{
+func (s *ManagerSuite) TestPruningChangeEvents() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	_, err := s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{TrustDomainId: "spiffe://test.test"},
+	})
+	s.Require().NoError(err)
+
+	// The fake datastore records the events with the current time, so the
+	// events are only pruned once the clock is past the retention period
+	s.clock.Set(time.Now())
+	s.NoError(s.m.pruneChangeEvents(context.Background()))
+	listResp, err := s.ds.ListChangeEvents(context.Background(), &datastore.ListChangeEventsRequest{})
+	s.NoError(err)
+	s.Len(listResp.Events, 1)
+
+	s.clock.Add(_changeEventRetention + time.Minute)
+	s.NoError(s.m.pruneChangeEvents(context.Background()))
+	listResp, err = s.ds.ListChangeEvents(context.Background(), &datastore.ListChangeEventsRequest{})
+	s.NoError(err)
+	s.Empty(listResp.Events)
+}
+
+func (s *ManagerSuite) TestPruningJoinTokens() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	now := s.clock.Now()
+	for _, token := range []*datastore.JoinToken{
+		{Token: "expired", Expiry: now.Add(-time.Second).Unix()},
+		{Token: "valid", Expiry: now.Add(time.Minute).Unix()},
+	} {
+		_, err := s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+			JoinToken: token,
+		})
+		s.Require().NoError(err)
+	}
+
+	s.NoError(s.m.pruneJoinTokens(context.Background()))
+	listResp, err := s.ds.ListJoinTokens(context.Background(), &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.JoinTokens, 1)
+	s.Equal("valid", listResp.JoinTokens[0].Token)
+}
+
+func (s *ManagerSuite) TestPruningAgents() {
+	// the manager is not run so the pruning only happens when called below
+	s.newManager(func(c *ManagerConfig) {
+		c.PruneAgentsExpiredFor = time.Hour
+	})
+
+	now := s.clock.Now()
+	s.createAgent("spiffe://test.test/spire/agent/pruned", now.Add(-2*time.Hour), false, false)
+	s.createAgent("spiffe://test.test/spire/agent/recently-expired", now.Add(-time.Minute), false, false)
+	s.createAgent("spiffe://test.test/spire/agent/can-reattest", now.Add(-2*time.Hour), true, false)
+	s.createAgent("spiffe://test.test/spire/agent/banned", now.Add(-2*time.Hour), false, true)
+
+	s.NoError(s.m.pruneAgents(context.Background()))
+	s.requireAgents(
+		"spiffe://test.test/spire/agent/banned",
+		"spiffe://test.test/spire/agent/can-reattest",
+		"spiffe://test.test/spire/agent/recently-expired",
+	)
+	s.requireNoSelectors("spiffe://test.test/spire/agent/pruned")
+	s.Equal(1, s.countPrunedAgents())
+
+	// the recently expired agent is pruned once the grace period elapses
+	s.clock.Add(time.Hour)
+	s.NoError(s.m.pruneAgents(context.Background()))
+	s.requireAgents(
+		"spiffe://test.test/spire/agent/banned",
+		"spiffe://test.test/spire/agent/can-reattest",
+	)
+	s.requireNoSelectors("spiffe://test.test/spire/agent/recently-expired")
+	s.Equal(2, s.countPrunedAgents())
+}
+
+func (s *ManagerSuite) TestPruningAgentsNotifiesNotifiers() {
+	var notified []string
+	n := fakenotifier.New(fakenotifier.Config{
+		OnNotifyAndAdvise: func(req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+			event, ok := req.Event.(*notifier.NotifyAndAdviseRequest_AgentPruning)
+			if !ok {
+				return nil, errors.New("unexpected event")
+			}
+			spiffeID := event.AgentPruning.Node.SpiffeId
+			notified = append(notified, spiffeID)
+			if spiffeID == "spiffe://test.test/spire/agent/vetoed" {
+				return nil, errors.New("ohno")
+			}
+			return &notifier.NotifyAndAdviseResponse{}, nil
+		},
+	})
+
+	s.newManager(func(c *ManagerConfig) {
+		c.PruneAgentsExpiredFor = time.Hour
+		c.NotifyAgentPruning = true
+		c.Notifiers = []catalog.Notifier{fakeservercatalog.Notifier("fake", n)}
+	})
+
+	now := s.clock.Now()
+	s.createAgent("spiffe://test.test/spire/agent/pruned", now.Add(-2*time.Hour), false, false)
+	s.createAgent("spiffe://test.test/spire/agent/vetoed", now.Add(-2*time.Hour), false, false)
+
+	s.NoError(s.m.pruneAgents(context.Background()))
+	s.ElementsMatch([]string{
+		"spiffe://test.test/spire/agent/pruned",
+		"spiffe://test.test/spire/agent/vetoed",
+	}, notified)
+	s.requireAgents("spiffe://test.test/spire/agent/vetoed")
+	s.Equal(1, s.countPrunedAgents())
+}
+
+func (s *ManagerSuite) createAgent(spiffeID string, expiresAt time.Time, canReattest, banned bool) {
+	node := &common.AttestedNode{
+		SpiffeId:            spiffeID,
+		AttestationDataType: "test",
+		CertNotAfter:        expiresAt.Unix(),
+		CanReattest:         canReattest,
+	}
+	if !banned {
+		node.CertSerialNumber = "1234"
+	}
+	_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ManagerSuite) requireAgents(spiffeIDs ...string) {
+	resp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+
+	var actual []string
+	for _, node := range resp.Nodes {
+		actual = append(actual, node.SpiffeId)
+	}
+	s.Require().ElementsMatch(spiffeIDs, actual)
+}
+
+func (s *ManagerSuite) requireNoSelectors(spiffeID string) {
+	resp, err := s.ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+		SpiffeId: spiffeID,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Selectors.Selectors)
+}
+
+func (s *ManagerSuite) countPrunedAgents() int {
+	count := 0
+	for _, metric := range s.metrics.AllMetrics() {
+		if metric.Type == fakemetrics.IncrCounterType && strings.Join(metric.Key, ".") == "node.manager.pruned" {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *ManagerSuite) newManager(configure ...func(*ManagerConfig)) {
+	config := ManagerConfig{
 		Clock:     s.clock,
 		DataStore: s.ds,
 		Log:       s.log,
 		Metrics:   s.metrics,
-	})
+	}
+	for _, fn := range configure {
+		fn(&config)
+	}
+	s.m = NewManager(config)
+}
+
+func (s *ManagerSuite) setupAndRunManager() func() {
+	s.newManager()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
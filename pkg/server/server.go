@@ -247,6 +247,7 @@ func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
 		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
 		Metrics:     metrics,
 		X509SVIDTTL: s.config.SVIDTTL,
+		JWTSVIDTTL:  s.config.JWTSVIDTTL,
 		JWTIssuer:   s.config.JWTIssuer,
 		TrustDomain: s.config.TrustDomain,
 		CASubject:   s.config.CASubject,
@@ -265,7 +266,10 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 		CASubject:      s.config.CASubject,
 		Dir:            s.config.DataDir,
 		X509CAKeyType:  s.config.CAKeyType,
-		JWTKeyType:     s.config.CAKeyType,
+		JWTKeyType:     s.config.JWTKeyType,
+
+		JournalInDataStore: s.config.JournalInDataStore,
+		CoordinateRotation: s.config.CoordinateRotation,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -275,9 +279,12 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:             cat.GetDataStore(),
+		PruneAgentsExpiredFor: s.config.PruneAgentsExpiredFor,
+		NotifyAgentPruning:    s.config.NotifyAgentPruning,
+		Notifiers:             cat.GetNotifiers(),
+		Log:                   s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:               metrics,
 	})
 	return registrationManager
 }
@@ -305,6 +312,9 @@ func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.O
 		ServerCA:                    serverCA,
 		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:                     metrics,
+		AuditLog:                    s.config.AuditLog,
+		AuthorizationPolicy:         s.config.AuthorizationPolicy,
+		RateLimits:                  s.config.RateLimits,
 		Manager:                     caManager,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
 	}
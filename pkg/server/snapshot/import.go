@@ -0,0 +1,613 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	kindBundle                 = "bundle"
+	kindFederationRelationship = "federation relationship"
+	kindAttestedNode           = "attested node"
+	kindNodeSelectors          = "node selectors"
+	kindJoinToken              = "join token"
+	kindRegistrationEntry      = "registration entry"
+)
+
+// Mode controls how a snapshot is imported
+type Mode int
+
+const (
+	// Merge creates and updates the records in the snapshot. The other
+	// records in the datastore are left intact.
+	Merge Mode = iota
+
+	// Replace creates and updates the records in the snapshot and deletes
+	// the records in the datastore that are not in the snapshot.
+	Replace
+)
+
+// ParseMode parses the name of an import mode
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "merge":
+		return Merge, nil
+	case "replace":
+		return Replace, nil
+	default:
+		return 0, fmt.Errorf("unknown import mode %q", s)
+	}
+}
+
+// Action is the action taken on a record
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Change is a change made to the datastore when importing a snapshot
+type Change struct {
+	Action Action
+
+	// Kind is the kind of record changed (e.g. "registration entry")
+	Kind string
+
+	// ID identifies the record changed. Registration entries are identified
+	// by their entry ID when they exist in the datastore and by their SPIFFE
+	// ID otherwise, since entry IDs are assigned by the datastore.
+	ID string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s", c.Action, c.Kind, c.ID)
+}
+
+// Import imports the snapshot into the datastore of the server for the given
+// trust domain and returns the changes made. Records are matched with the
+// records in the datastore by trust domain, SPIFFE ID or token, except for
+// registration entries, which are matched by parent ID, SPIFFE ID and
+// selectors. Entry IDs are generated by the datastore, so the entries created
+// by the import get new entry IDs. The bundle of the local trust domain is
+// merged with the one in the datastore instead of replacing it, so the keys
+// the server added since the snapshot was taken are kept, and it is never
+// deleted.
+//
+// The snapshot is validated before any change is made. Changes are not made
+// in a transaction though, so if the datastore fails a change, the changes
+// made before it are kept. If dryRun is true, the changes are returned but not
+// made.
+func Import(ctx context.Context, ds datastore.DataStore, trustDomainID string, snapshot *Snapshot, mode Mode, dryRun bool) ([]Change, error) {
+	current, err := Export(ctx, ds)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		ds:            ds,
+		trustDomainID: trustDomainID,
+		deletes:       mode == Replace,
+	}
+	if err := p.planBundles(current.Bundles, snapshot.Bundles); err != nil {
+		return nil, err
+	}
+	if err := p.planFederationRelationships(current.FederationRelationships, snapshot.FederationRelationships); err != nil {
+		return nil, err
+	}
+	if err := p.planAttestedNodes(current.AttestedNodes, snapshot.AttestedNodes); err != nil {
+		return nil, err
+	}
+	if err := p.planNodeSelectors(current.NodeSelectors, snapshot.NodeSelectors); err != nil {
+		return nil, err
+	}
+	if err := p.planJoinTokens(current.JoinTokens, snapshot.JoinTokens); err != nil {
+		return nil, err
+	}
+	if err := p.planRegistrationEntries(current.RegistrationEntries, snapshot.RegistrationEntries); err != nil {
+		return nil, err
+	}
+
+	if err := validate(trustDomainID, current, snapshot, mode); err != nil {
+		return nil, err
+	}
+
+	// Records are deleted after every other change, in the reverse order,
+	// so that registration entries no longer reference deleted bundles.
+	ops := append(p.upsertOps, p.deleteOps...)
+
+	var changes []Change
+	for _, op := range ops {
+		if !dryRun {
+			if err := op.apply(ctx); err != nil {
+				return changes, fmt.Errorf("failed to %s: %v", op.change, err)
+			}
+		}
+		changes = append(changes, op.change)
+	}
+	return changes, nil
+}
+
+type operation struct {
+	change Change
+	apply  func(ctx context.Context) error
+}
+
+type planner struct {
+	ds            datastore.DataStore
+	trustDomainID string
+	deletes       bool
+
+	upsertOps []operation
+	deleteOps []operation
+}
+
+func (p *planner) upsert(action Action, kind, id string, apply func(ctx context.Context) error) {
+	p.upsertOps = append(p.upsertOps, operation{
+		change: Change{Action: action, Kind: kind, ID: id},
+		apply:  apply,
+	})
+}
+
+// delete plans the deletion of the given records, ahead of the deletions of
+// the kinds planned before.
+func (p *planner) delete(kind string, ids []string, apply func(ctx context.Context, i int) error) {
+	if !p.deletes {
+		return
+	}
+	ops := make([]operation, 0, len(ids))
+	for i, id := range ids {
+		i := i
+		ops = append(ops, operation{
+			change: Change{Action: Delete, Kind: kind, ID: id},
+			apply: func(ctx context.Context) error {
+				return apply(ctx, i)
+			},
+		})
+	}
+	p.deleteOps = append(ops, p.deleteOps...)
+}
+
+func (p *planner) planBundles(current, desired []*common.Bundle) error {
+	keyOf := func(b *common.Bundle) string { return b.TrustDomainId }
+	pairs, unmatched, err := match(kindBundle, len(current), len(desired),
+		func(i int) string { return keyOf(current[i]) },
+		func(i int) string { return keyOf(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := desired[pair.desired]
+		switch {
+		case pair.current < 0:
+			p.upsert(Create, kindBundle, keyOf(want), func(ctx context.Context) error {
+				_, err := p.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: want})
+				return err
+			})
+		case keyOf(want) == p.trustDomainID:
+			// The local bundle holds the keys of the server, which may have
+			// been rotated since the snapshot was taken
+			if merged, changed := bundleutil.MergeBundles(current[pair.current], want); changed {
+				p.upsert(Update, kindBundle, keyOf(want), func(ctx context.Context) error {
+					_, err := p.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: merged})
+					return err
+				})
+			}
+		case !proto.Equal(current[pair.current], want):
+			p.upsert(Update, kindBundle, keyOf(want), func(ctx context.Context) error {
+				_, err := p.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{Bundle: want})
+				return err
+			})
+		}
+	}
+
+	var deleted []int
+	for _, i := range unmatched {
+		if keyOf(current[i]) != p.trustDomainID {
+			deleted = append(deleted, i)
+		}
+	}
+	p.delete(kindBundle, keys(deleted, func(i int) string { return keyOf(current[i]) }), func(ctx context.Context, i int) error {
+		_, err := p.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+			TrustDomainId: keyOf(current[deleted[i]]),
+		})
+		return err
+	})
+	return nil
+}
+
+func (p *planner) planFederationRelationships(current, desired []*datastore.FederationRelationship) error {
+	keyOf := func(r *datastore.FederationRelationship) string { return r.TrustDomainId }
+	pairs, unmatched, err := match(kindFederationRelationship, len(current), len(desired),
+		func(i int) string { return keyOf(current[i]) },
+		func(i int) string { return keyOf(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := desired[pair.desired]
+		switch {
+		case pair.current < 0:
+			p.upsert(Create, kindFederationRelationship, keyOf(want), func(ctx context.Context) error {
+				_, err := p.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+					FederationRelationship: want,
+				})
+				return err
+			})
+		case !proto.Equal(current[pair.current], want):
+			p.upsert(Update, kindFederationRelationship, keyOf(want), func(ctx context.Context) error {
+				_, err := p.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+					FederationRelationship: want,
+				})
+				return err
+			})
+		}
+	}
+
+	p.delete(kindFederationRelationship, keys(unmatched, func(i int) string { return keyOf(current[i]) }), func(ctx context.Context, i int) error {
+		_, err := p.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+			TrustDomainId: keyOf(current[unmatched[i]]),
+		})
+		return err
+	})
+	return nil
+}
+
+func (p *planner) planAttestedNodes(current, desired []*common.AttestedNode) error {
+	keyOf := func(n *common.AttestedNode) string { return n.SpiffeId }
+	pairs, unmatched, err := match(kindAttestedNode, len(current), len(desired),
+		func(i int) string { return keyOf(current[i]) },
+		func(i int) string { return keyOf(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := normalizeAttestedNode(desired[pair.desired])
+		create := func(ctx context.Context) error {
+			_, err := p.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: want})
+			return err
+		}
+		if pair.current < 0 {
+			p.upsert(Create, kindAttestedNode, keyOf(want), create)
+			continue
+		}
+
+		have := normalizeAttestedNode(current[pair.current])
+		switch {
+		case proto.Equal(have, want):
+		case have.AttestationDataType != want.AttestationDataType:
+			// The attestation data type cannot be updated
+			p.upsert(Update, kindAttestedNode, keyOf(want), func(ctx context.Context) error {
+				if _, err := p.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: want.SpiffeId}); err != nil {
+					return err
+				}
+				return create(ctx)
+			})
+		default:
+			p.upsert(Update, kindAttestedNode, keyOf(want), func(ctx context.Context) error {
+				_, err := p.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+					SpiffeId:            want.SpiffeId,
+					CertSerialNumber:    want.CertSerialNumber,
+					CertNotAfter:        want.CertNotAfter,
+					NewCertSerialNumber: want.NewCertSerialNumber,
+					NewCertNotAfter:     want.NewCertNotAfter,
+					AgentVersion:        want.AgentVersion,
+					AgentPlugins:        want.AgentPlugins,
+					LastSeen:            want.LastSeen,
+					CanReattest:         want.CanReattest,
+					InputMask: &datastore.AttestedNodeMask{
+						CertSerialNumber:    true,
+						CertNotAfter:        true,
+						NewCertSerialNumber: true,
+						NewCertNotAfter:     true,
+						AgentVersion:        true,
+						AgentPlugins:        true,
+						LastSeen:            true,
+						CanReattest:         true,
+					},
+				})
+				return err
+			})
+		}
+	}
+
+	p.delete(kindAttestedNode, keys(unmatched, func(i int) string { return keyOf(current[i]) }), func(ctx context.Context, i int) error {
+		_, err := p.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+			SpiffeId: keyOf(current[unmatched[i]]),
+		})
+		return err
+	})
+	return nil
+}
+
+func (p *planner) planNodeSelectors(current, desired []*datastore.NodeSelectors) error {
+	keyOf := func(s *datastore.NodeSelectors) string { return s.SpiffeId }
+	pairs, unmatched, err := match(kindNodeSelectors, len(current), len(desired),
+		func(i int) string { return keyOf(current[i]) },
+		func(i int) string { return keyOf(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	setNodeSelectors := func(ctx context.Context, selectors *datastore.NodeSelectors) error {
+		_, err := p.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{Selectors: selectors})
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := normalizeNodeSelectors(desired[pair.desired])
+		switch {
+		case pair.current < 0:
+			p.upsert(Create, kindNodeSelectors, keyOf(want), func(ctx context.Context) error {
+				return setNodeSelectors(ctx, want)
+			})
+		case !proto.Equal(normalizeNodeSelectors(current[pair.current]), want):
+			p.upsert(Update, kindNodeSelectors, keyOf(want), func(ctx context.Context) error {
+				return setNodeSelectors(ctx, want)
+			})
+		}
+	}
+
+	p.delete(kindNodeSelectors, keys(unmatched, func(i int) string { return keyOf(current[i]) }), func(ctx context.Context, i int) error {
+		return setNodeSelectors(ctx, &datastore.NodeSelectors{
+			SpiffeId: keyOf(current[unmatched[i]]),
+		})
+	})
+	return nil
+}
+
+func (p *planner) planJoinTokens(current, desired []*datastore.JoinToken) error {
+	keyOf := func(t *datastore.JoinToken) string { return t.Token }
+	pairs, unmatched, err := match(kindJoinToken, len(current), len(desired),
+		func(i int) string { return keyOf(current[i]) },
+		func(i int) string { return keyOf(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := desired[pair.desired]
+		create := func(ctx context.Context) error {
+			_, err := p.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: want})
+			return err
+		}
+		switch {
+		case pair.current < 0:
+			p.upsert(Create, kindJoinToken, keyOf(want), create)
+		case !proto.Equal(current[pair.current], want):
+			// Join tokens cannot be updated
+			p.upsert(Update, kindJoinToken, keyOf(want), func(ctx context.Context) error {
+				if _, err := p.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{Token: want.Token}); err != nil {
+					return err
+				}
+				return create(ctx)
+			})
+		}
+	}
+
+	p.delete(kindJoinToken, keys(unmatched, func(i int) string { return keyOf(current[i]) }), func(ctx context.Context, i int) error {
+		_, err := p.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+			Token: keyOf(current[unmatched[i]]),
+		})
+		return err
+	})
+	return nil
+}
+
+func (p *planner) planRegistrationEntries(current, desired []*common.RegistrationEntry) error {
+	pairs, unmatched, err := match(kindRegistrationEntry, len(current), len(desired),
+		func(i int) string { return entryKey(current[i]) },
+		func(i int) string { return entryKey(desired[i]) })
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		want := normalizeEntry(desired[pair.desired])
+		if pair.current < 0 {
+			p.upsert(Create, kindRegistrationEntry, want.SpiffeId, func(ctx context.Context) error {
+				_, err := p.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: want})
+				return err
+			})
+			continue
+		}
+
+		entryID := current[pair.current].EntryId
+		if !proto.Equal(normalizeEntry(current[pair.current]), want) {
+			p.upsert(Update, kindRegistrationEntry, entryID, func(ctx context.Context) error {
+				entry := proto.Clone(want).(*common.RegistrationEntry)
+				entry.EntryId = entryID
+				_, err := p.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: entry})
+				return err
+			})
+		}
+	}
+
+	p.delete(kindRegistrationEntry, keys(unmatched, func(i int) string { return current[i].EntryId }), func(ctx context.Context, i int) error {
+		_, err := p.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+			EntryId: current[unmatched[i]].EntryId,
+		})
+		return err
+	})
+	return nil
+}
+
+// validate checks the records of the snapshot against the constraints the
+// datastore enforces, so that an invalid snapshot is rejected before any
+// change is made.
+func validate(trustDomainID string, current, snapshot *Snapshot, mode Mode) error {
+	// Registration entries can only federate with the trust domains that
+	// have a bundle once the snapshot is imported
+	bundles := make(map[string]bool)
+	for _, bundle := range current.Bundles {
+		if mode == Merge || bundle.TrustDomainId == trustDomainID {
+			bundles[bundle.TrustDomainId] = true
+		}
+	}
+
+	for _, bundle := range snapshot.Bundles {
+		if err := idutil.ValidateSpiffeID(bundle.TrustDomainId, idutil.AllowAnyTrustDomain()); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", kindBundle, bundle.TrustDomainId, err)
+		}
+		bundles[bundle.TrustDomainId] = true
+	}
+	for _, relationship := range snapshot.FederationRelationships {
+		if err := idutil.ValidateSpiffeID(relationship.TrustDomainId, idutil.AllowAnyTrustDomain()); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", kindFederationRelationship, relationship.TrustDomainId, err)
+		}
+		if relationship.BundleEndpointUrl == "" {
+			return fmt.Errorf("invalid %s %q: missing bundle endpoint URL", kindFederationRelationship, relationship.TrustDomainId)
+		}
+	}
+	for _, node := range snapshot.AttestedNodes {
+		if err := idutil.ValidateSpiffeID(node.SpiffeId, idutil.AllowAnyTrustDomainAgent()); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", kindAttestedNode, node.SpiffeId, err)
+		}
+	}
+	for _, selectors := range snapshot.NodeSelectors {
+		if err := idutil.ValidateSpiffeID(selectors.SpiffeId, idutil.AllowAnyTrustDomainAgent()); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", kindNodeSelectors, selectors.SpiffeId, err)
+		}
+	}
+	for _, token := range snapshot.JoinTokens {
+		if token.Token == "" {
+			return fmt.Errorf("invalid %s: missing token", kindJoinToken)
+		}
+	}
+	for _, entry := range snapshot.RegistrationEntries {
+		if err := validateEntry(entry, bundles); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", kindRegistrationEntry, entryKey(entry), err)
+		}
+	}
+	return nil
+}
+
+func validateEntry(entry *common.RegistrationEntry, bundles map[string]bool) error {
+	if err := idutil.ValidateSpiffeID(entry.SpiffeId, idutil.AllowAny()); err != nil {
+		return err
+	}
+	if err := idutil.ValidateSpiffeID(entry.ParentId, idutil.AllowAny()); err != nil {
+		return fmt.Errorf("invalid parent ID: %v", err)
+	}
+	if len(entry.Selectors) == 0 {
+		return errors.New("missing selector list")
+	}
+	if entry.Ttl < 0 {
+		return errors.New("TTL is negative")
+	}
+	if entry.JwtSvidTtl < 0 {
+		return errors.New("JWT-SVID TTL is negative")
+	}
+	for _, trustDomainID := range entry.FederatesWith {
+		if !bundles[trustDomainID] {
+			return fmt.Errorf("no bundle for federated trust domain %q", trustDomainID)
+		}
+	}
+	return nil
+}
+
+type pair struct {
+	// current is the index of the current record, or -1 if there is none
+	current int
+
+	// desired is the index of the desired record
+	desired int
+}
+
+// match pairs up the current and desired records by key. It returns a pair
+// for each desired record, in order, and the indices of the current records
+// without a desired counterpart.
+func match(kind string, numCurrent, numDesired int, currentKey, desiredKey func(i int) string) ([]pair, []int, error) {
+	currentByKey := make(map[string]int, numCurrent)
+	for i := 0; i < numCurrent; i++ {
+		if _, ok := currentByKey[currentKey(i)]; !ok {
+			currentByKey[currentKey(i)] = i
+		}
+	}
+
+	pairs := make([]pair, 0, numDesired)
+	matched := make(map[int]bool, numDesired)
+	seen := make(map[string]bool, numDesired)
+	for i := 0; i < numDesired; i++ {
+		key := desiredKey(i)
+		if seen[key] {
+			return nil, nil, fmt.Errorf("duplicate %s %q in snapshot", kind, key)
+		}
+		seen[key] = true
+
+		current, ok := currentByKey[key]
+		if ok {
+			matched[current] = true
+		} else {
+			current = -1
+		}
+		pairs = append(pairs, pair{current: current, desired: i})
+	}
+
+	var unmatched []int
+	for i := 0; i < numCurrent; i++ {
+		if !matched[i] {
+			unmatched = append(unmatched, i)
+		}
+	}
+	return pairs, unmatched, nil
+}
+
+func keys(indices []int, keyOf func(i int) string) []string {
+	keys := make([]string, 0, len(indices))
+	for _, i := range indices {
+		keys = append(keys, keyOf(i))
+	}
+	return keys
+}
+
+// entryKey returns the key registration entries are matched by. Entries
+// with the same key are considered similar and cannot coexist.
+func entryKey(entry *common.RegistrationEntry) string {
+	selectors := make([]string, 0, len(entry.Selectors))
+	for _, s := range entry.Selectors {
+		selectors = append(selectors, s.Type+":"+s.Value)
+	}
+	sort.Strings(selectors)
+	return fmt.Sprintf("%s (parent %s, selectors %s)", entry.SpiffeId, entry.ParentId, strings.Join(selectors, ","))
+}
+
+// normalizeEntry returns a copy of the entry without the fields assigned by
+// the datastore and with its lists sorted, so entries can be compared.
+func normalizeEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	entry = proto.Clone(entry).(*common.RegistrationEntry)
+	entry.EntryId = ""
+	entry.RevisionNumber = 0
+	util.SortSelectors(entry.Selectors)
+	sort.Strings(entry.FederatesWith)
+	return entry
+}
+
+// normalizeAttestedNode returns a copy of the node without the fields that
+// are not stored with the node.
+func normalizeAttestedNode(node *common.AttestedNode) *common.AttestedNode {
+	node = proto.Clone(node).(*common.AttestedNode)
+	node.Selectors = nil
+	node.Banned = false
+	return node
+}
+
+func normalizeNodeSelectors(selectors *datastore.NodeSelectors) *datastore.NodeSelectors {
+	selectors = proto.Clone(selectors).(*datastore.NodeSelectors)
+	util.SortSelectors(selectors.Selectors)
+	return selectors
+}
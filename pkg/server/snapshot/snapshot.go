@@ -0,0 +1,120 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// Version is the version of the snapshot format
+	Version = 1
+
+	// pageSize is the number of registration entries and attested nodes
+	// listed from the datastore at once
+	pageSize = 1000
+)
+
+// Snapshot is the logical state of a datastore. It does not depend on the
+// datastore implementation, so it can be imported into any datastore.
+type Snapshot struct {
+	Version                 int                                 `json:"version"`
+	Bundles                 []*common.Bundle                    `json:"bundles"`
+	FederationRelationships []*datastore.FederationRelationship `json:"federation_relationships"`
+	RegistrationEntries     []*common.RegistrationEntry         `json:"registration_entries"`
+	AttestedNodes           []*common.AttestedNode              `json:"attested_nodes"`
+	NodeSelectors           []*datastore.NodeSelectors          `json:"node_selectors"`
+	JoinTokens              []*datastore.JoinToken              `json:"join_tokens"`
+}
+
+// Export takes a snapshot of the given datastore.
+func Export(ctx context.Context, ds datastore.DataStore) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version: Version,
+	}
+
+	bundlesResp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundles: %v", err)
+	}
+	snapshot.Bundles = bundlesResp.Bundles
+
+	relationshipsResp, err := ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federation relationships: %v", err)
+	}
+	snapshot.FederationRelationships = relationshipsResp.FederationRelationships
+
+	var pagination *datastore.Pagination
+	for {
+		resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			Pagination: &datastore.Pagination{
+				Token:    pagination.GetToken(),
+				PageSize: pageSize,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list registration entries: %v", err)
+		}
+		snapshot.RegistrationEntries = append(snapshot.RegistrationEntries, resp.Entries...)
+		pagination = resp.Pagination
+		if pagination == nil || len(resp.Entries) < pageSize {
+			break
+		}
+	}
+
+	pagination = nil
+	for {
+		resp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+			Pagination: &datastore.Pagination{
+				Token:    pagination.GetToken(),
+				PageSize: pageSize,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attested nodes: %v", err)
+		}
+		snapshot.AttestedNodes = append(snapshot.AttestedNodes, resp.Nodes...)
+		pagination = resp.Pagination
+		if pagination == nil || len(resp.Nodes) < pageSize {
+			break
+		}
+	}
+
+	selectorsResp, err := ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node selectors: %v", err)
+	}
+	snapshot.NodeSelectors = selectorsResp.Selectors
+
+	tokensResp, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join tokens: %v", err)
+	}
+	snapshot.JoinTokens = tokensResp.JoinTokens
+
+	return snapshot, nil
+}
+
+// Write writes the snapshot as JSON.
+func Write(w io.Writer, snapshot *Snapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// Read reads a snapshot written by Write.
+func Read(r io.Reader) (*Snapshot, error) {
+	snapshot := new(Snapshot)
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("unable to decode snapshot: %v", err)
+	}
+	if snapshot.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	return snapshot, nil
+}
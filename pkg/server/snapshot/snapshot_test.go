@@ -0,0 +1,377 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const trustDomainID = "spiffe://example.org"
+
+var (
+	ctx = context.Background()
+
+	localBundle = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("local")}},
+	}
+	federatedBundle = &common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("federated")}},
+	}
+	relationship = &datastore.FederationRelationship{
+		TrustDomainId:     "spiffe://otherdomain.test",
+		BundleEndpointUrl: "https://otherdomain.test",
+	}
+	node = &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/join_token/foo",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        1000,
+		AgentVersion:        "0.11.0",
+	}
+	nodeSelectors = &datastore.NodeSelectors{
+		SpiffeId:  "spiffe://example.org/spire/agent/join_token/foo",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	}
+	joinToken = &datastore.JoinToken{
+		Token:  "bar",
+		Expiry: 1000,
+	}
+	entry = &common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/spire/agent/join_token/foo",
+		SpiffeId:      "spiffe://example.org/workload",
+		Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}, {Type: "unix", Value: "gid:1000"}},
+		Ttl:           60,
+		FederatesWith: []string{"spiffe://otherdomain.test"},
+	}
+)
+
+func TestExportAndRead(t *testing.T) {
+	ds := newPopulatedDataStore(t)
+
+	exported, err := Export(ctx, ds)
+	require.NoError(t, err)
+	require.Equal(t, Version, exported.Version)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{localBundle, federatedBundle}, exported.Bundles)
+	spiretest.RequireProtoListEqual(t, []*datastore.FederationRelationship{relationship}, exported.FederationRelationships)
+	spiretest.RequireProtoListEqual(t, []*common.AttestedNode{node}, exported.AttestedNodes)
+	spiretest.RequireProtoListEqual(t, []*datastore.NodeSelectors{nodeSelectors}, exported.NodeSelectors)
+	spiretest.RequireProtoListEqual(t, []*datastore.JoinToken{joinToken}, exported.JoinTokens)
+	require.Len(t, exported.RegistrationEntries, 1)
+	require.Equal(t, entryKey(entry), entryKey(exported.RegistrationEntries[0]))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, Write(buf, exported))
+	read, err := Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, exported, read)
+}
+
+func TestReadUnsupportedVersion(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"version": 2}`))
+	require.EqualError(t, err, "unsupported snapshot version 2")
+
+	_, err = Read(strings.NewReader(`{`))
+	require.EqualError(t, err, "unable to decode snapshot: unexpected EOF")
+}
+
+func TestImportIntoEmptyDataStore(t *testing.T) {
+	exported, err := Export(ctx, newPopulatedDataStore(t))
+	require.NoError(t, err)
+
+	ds := fakedatastore.New()
+
+	// A dry run reports the changes without making them
+	changes, err := Import(ctx, ds, trustDomainID, exported, Merge, true)
+	require.NoError(t, err)
+	expectedChanges := []string{
+		"create bundle spiffe://example.org",
+		"create bundle spiffe://otherdomain.test",
+		"create federation relationship spiffe://otherdomain.test",
+		"create attested node spiffe://example.org/spire/agent/join_token/foo",
+		"create node selectors spiffe://example.org/spire/agent/join_token/foo",
+		"create join token bar",
+		"create registration entry spiffe://example.org/workload",
+	}
+	requireChanges(t, expectedChanges, changes)
+	empty, err := Export(ctx, ds)
+	require.NoError(t, err)
+	require.Empty(t, empty.Bundles)
+	require.Empty(t, empty.FederationRelationships)
+	require.Empty(t, empty.AttestedNodes)
+	require.Empty(t, empty.NodeSelectors)
+	require.Empty(t, empty.JoinTokens)
+	require.Empty(t, empty.RegistrationEntries)
+
+	changes, err = Import(ctx, ds, trustDomainID, exported, Merge, false)
+	require.NoError(t, err)
+	requireChanges(t, expectedChanges, changes)
+
+	// The datastore now holds the same records, so there is nothing left to
+	// import
+	changes, err = Import(ctx, ds, trustDomainID, exported, Replace, false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestImportModes(t *testing.T) {
+	ds := newPopulatedDataStore(t)
+
+	updatedBundle := cloneBundle(localBundle)
+	updatedBundle.RootCas = append(updatedBundle.RootCas, &common.Certificate{DerBytes: []byte("new")})
+	updatedNode := cloneAttestedNode(node)
+	updatedNode.CertSerialNumber = "5678"
+	updatedEntry := cloneEntry(entry)
+	updatedEntry.Ttl = 120
+	updatedEntry.FederatesWith = nil
+	newEntry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/agent/join_token/foo",
+		SpiffeId:  "spiffe://example.org/other",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:2000"}},
+	}
+	s := &Snapshot{
+		Version:             Version,
+		Bundles:             []*common.Bundle{updatedBundle},
+		AttestedNodes:       []*common.AttestedNode{updatedNode},
+		JoinTokens:          []*datastore.JoinToken{{Token: "bar", Expiry: 2000}},
+		RegistrationEntries: []*common.RegistrationEntry{updatedEntry, newEntry},
+	}
+
+	entryID := listEntries(t, ds)[0].EntryId
+	changes, err := Import(ctx, ds, trustDomainID, s, Merge, false)
+	require.NoError(t, err)
+	requireChanges(t, []string{
+		"update bundle spiffe://example.org",
+		"update attested node spiffe://example.org/spire/agent/join_token/foo",
+		"update join token bar",
+		"update registration entry " + entryID,
+		"create registration entry spiffe://example.org/other",
+	}, changes)
+
+	// Records that are not in the snapshot are kept when merging and
+	// deleted, dependents first, when replacing
+	changes, err = Import(ctx, ds, trustDomainID, s, Replace, false)
+	require.NoError(t, err)
+	requireChanges(t, []string{
+		"delete node selectors spiffe://example.org/spire/agent/join_token/foo",
+		"delete federation relationship spiffe://otherdomain.test",
+		"delete bundle spiffe://otherdomain.test",
+	}, changes)
+
+	exported, err := Export(ctx, ds)
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, s.Bundles, exported.Bundles)
+	require.Empty(t, exported.FederationRelationships)
+	spiretest.RequireProtoListEqual(t, s.AttestedNodes, exported.AttestedNodes)
+	require.Empty(t, exported.NodeSelectors)
+	spiretest.RequireProtoListEqual(t, s.JoinTokens, exported.JoinTokens)
+	require.Len(t, exported.RegistrationEntries, 2)
+}
+
+func TestImportChangesAttestationDataType(t *testing.T) {
+	ds := newPopulatedDataStore(t)
+
+	updatedNode := cloneAttestedNode(node)
+	updatedNode.AttestationDataType = "x509pop"
+	changes, err := Import(ctx, ds, trustDomainID, &Snapshot{
+		Version:       Version,
+		AttestedNodes: []*common.AttestedNode{updatedNode},
+	}, Merge, false)
+	require.NoError(t, err)
+	requireChanges(t, []string{"update attested node spiffe://example.org/spire/agent/join_token/foo"}, changes)
+
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, updatedNode, resp.Node)
+}
+
+func TestImportDuplicates(t *testing.T) {
+	_, err := Import(ctx, fakedatastore.New(), trustDomainID, &Snapshot{
+		Version:    Version,
+		JoinTokens: []*datastore.JoinToken{joinToken, joinToken},
+	}, Merge, false)
+	require.EqualError(t, err, `duplicate join token "bar" in snapshot`)
+
+	// Entries with the same selectors in a different order are similar
+	reordered := cloneEntry(entry)
+	reordered.Selectors = []*common.Selector{entry.Selectors[1], entry.Selectors[0]}
+	_, err = Import(ctx, fakedatastore.New(), trustDomainID, &Snapshot{
+		Version:             Version,
+		RegistrationEntries: []*common.RegistrationEntry{entry, reordered},
+	}, Merge, false)
+	require.EqualError(t, err, `duplicate registration entry "spiffe://example.org/workload (parent spiffe://example.org/spire/agent/join_token/foo, selectors unix:gid:1000,unix:uid:1000)" in snapshot`)
+}
+
+func TestImportLocalBundle(t *testing.T) {
+	ds := newPopulatedDataStore(t)
+
+	// The local bundle is merged with the one in the datastore, which holds
+	// the current keys of the server
+	oldBundle := &common.Bundle{
+		TrustDomainId: localBundle.TrustDomainId,
+		RootCas:       []*common.Certificate{{DerBytes: []byte("old")}},
+	}
+	changes, err := Import(ctx, ds, trustDomainID, &Snapshot{
+		Version: Version,
+		Bundles: []*common.Bundle{oldBundle, federatedBundle},
+	}, Merge, false)
+	require.NoError(t, err)
+	requireChanges(t, []string{"update bundle spiffe://example.org"}, changes)
+	mergedBundle := cloneBundle(localBundle)
+	mergedBundle.RootCas = append(mergedBundle.RootCas, oldBundle.RootCas...)
+	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: trustDomainID})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, mergedBundle, resp.Bundle)
+
+	// Importing the same keys again changes nothing
+	changes, err = Import(ctx, ds, trustDomainID, &Snapshot{
+		Version: Version,
+		Bundles: []*common.Bundle{localBundle},
+	}, Merge, false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// The local bundle is not deleted when replacing
+	changes, err = Import(ctx, ds, trustDomainID, &Snapshot{Version: Version}, Replace, true)
+	require.NoError(t, err)
+	for _, change := range changes {
+		require.NotEqual(t, "delete bundle spiffe://example.org", change.String())
+	}
+	require.Contains(t, changes, Change{Action: Delete, Kind: kindBundle, ID: federatedBundle.TrustDomainId})
+}
+
+func TestImportValidatesSnapshotFirst(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		snapshot    *Snapshot
+		mode        Mode
+		expectedErr string
+	}{
+		{
+			name: "invalid bundle",
+			snapshot: &Snapshot{
+				Bundles: []*common.Bundle{{TrustDomainId: "example.org"}},
+			},
+			expectedErr: `invalid bundle "example.org": `,
+		},
+		{
+			name: "federation relationship without bundle endpoint URL",
+			snapshot: &Snapshot{
+				FederationRelationships: []*datastore.FederationRelationship{{TrustDomainId: "spiffe://otherdomain.test"}},
+			},
+			expectedErr: `invalid federation relationship "spiffe://otherdomain.test": missing bundle endpoint URL`,
+		},
+		{
+			name: "attested node without agent ID",
+			snapshot: &Snapshot{
+				AttestedNodes: []*common.AttestedNode{{SpiffeId: "spiffe://example.org/workload"}},
+			},
+			expectedErr: `invalid attested node "spiffe://example.org/workload": `,
+		},
+		{
+			name: "entry without selectors",
+			snapshot: &Snapshot{
+				RegistrationEntries: []*common.RegistrationEntry{{
+					ParentId: entry.ParentId,
+					SpiffeId: entry.SpiffeId,
+				}},
+			},
+			expectedErr: `invalid registration entry "spiffe://example.org/workload (parent spiffe://example.org/spire/agent/join_token/foo, selectors )": missing selector list`,
+		},
+		{
+			name: "entry federating with an unknown trust domain",
+			snapshot: &Snapshot{
+				Bundles:             []*common.Bundle{localBundle},
+				RegistrationEntries: []*common.RegistrationEntry{entry},
+			},
+			mode:        Replace,
+			expectedErr: `invalid registration entry "spiffe://example.org/workload (parent spiffe://example.org/spire/agent/join_token/foo, selectors unix:gid:1000,unix:uid:1000)": no bundle for federated trust domain "spiffe://otherdomain.test"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := newPopulatedDataStore(t)
+			before, err := Export(ctx, ds)
+			require.NoError(t, err)
+
+			// Valid records precede the invalid one but are not imported
+			tt.snapshot.Version = Version
+			tt.snapshot.JoinTokens = []*datastore.JoinToken{{Token: "baz", Expiry: 1000}}
+			changes, err := Import(ctx, ds, trustDomainID, tt.snapshot, tt.mode, false)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErr)
+			require.Empty(t, changes)
+
+			after, err := Export(ctx, ds)
+			require.NoError(t, err)
+			require.Equal(t, before, after)
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("merge")
+	require.NoError(t, err)
+	require.Equal(t, Merge, mode)
+
+	mode, err = ParseMode("replace")
+	require.NoError(t, err)
+	require.Equal(t, Replace, mode)
+
+	_, err = ParseMode("foo")
+	require.EqualError(t, err, `unknown import mode "foo"`)
+}
+
+func newPopulatedDataStore(t *testing.T) *fakedatastore.DataStore {
+	ds := fakedatastore.New()
+	for _, bundle := range []*common.Bundle{localBundle, federatedBundle} {
+		_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+		require.NoError(t, err)
+	}
+	_, err := ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{FederationRelationship: relationship})
+	require.NoError(t, err)
+	_, err = ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	require.NoError(t, err)
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{Selectors: nodeSelectors})
+	require.NoError(t, err)
+	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{JoinToken: joinToken})
+	require.NoError(t, err)
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: entry})
+	require.NoError(t, err)
+	return ds
+}
+
+func listEntries(t *testing.T, ds datastore.DataStore) []*common.RegistrationEntry {
+	resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	return resp.Entries
+}
+
+func requireChanges(t *testing.T, expected []string, changes []Change) {
+	actual := make([]string, 0, len(changes))
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	require.Equal(t, expected, actual)
+}
+
+func cloneBundle(bundle *common.Bundle) *common.Bundle {
+	return proto.Clone(bundle).(*common.Bundle)
+}
+
+func cloneAttestedNode(node *common.AttestedNode) *common.AttestedNode {
+	return proto.Clone(node).(*common.AttestedNode)
+}
+
+func cloneEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	return proto.Clone(entry).(*common.RegistrationEntry)
+}
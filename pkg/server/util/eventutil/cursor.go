@@ -0,0 +1,88 @@
+package eventutil
+
+import (
+	"time"
+)
+
+const (
+	// gapTimeout is how long a skipped revision is waited on before it is
+	// given up on
+	gapTimeout = time.Minute
+
+	// maxGaps bounds the number of skipped revisions waited on at once
+	maxGaps = 1000
+)
+
+// Cursor tracks the change events consumed from the datastore. Revisions are
+// allocated when an event is recorded but the event only becomes visible
+// when its transaction commits, so an event can show up after events with
+// higher revisions. The revisions skipped over are therefore watched for
+// gapTimeout before they are given up on (e.g. because the transaction was
+// rolled back).
+type Cursor struct {
+	// revision is the highest revision observed
+	revision int64
+
+	// gaps holds the skipped revisions still being watched, along with the
+	// time they were first skipped
+	gaps map[int64]time.Time
+}
+
+// NewCursor returns a cursor positioned after the given revision.
+func NewCursor(revision int64) *Cursor {
+	return &Cursor{
+		revision: revision,
+		gaps:     make(map[int64]time.Time),
+	}
+}
+
+// Observe records that the event with the given revision was listed and
+// returns whether it still has to be consumed.
+func (c *Cursor) Observe(revision int64, now time.Time) bool {
+	if revision <= c.revision {
+		if _, ok := c.gaps[revision]; !ok {
+			return false
+		}
+		delete(c.gaps, revision)
+		return true
+	}
+
+	for skipped := c.revision + 1; skipped < revision && len(c.gaps) < maxGaps; skipped++ {
+		c.gaps[skipped] = now
+	}
+	c.revision = revision
+	return true
+}
+
+// Pending returns whether the event with the given revision still has to be
+// consumed, like Observe, but without recording it. It lets callers hold off
+// advancing the cursor until the event has actually been consumed.
+func (c *Cursor) Pending(revision int64) bool {
+	if revision <= c.revision {
+		_, ok := c.gaps[revision]
+		return ok
+	}
+	return true
+}
+
+// ExpireGaps gives up on the skipped revisions watched for at least
+// gapTimeout.
+func (c *Cursor) ExpireGaps(now time.Time) {
+	for revision, skippedAt := range c.gaps {
+		if now.Sub(skippedAt) >= gapTimeout {
+			delete(c.gaps, revision)
+		}
+	}
+}
+
+// ResumeRevision returns the revision to list events after without missing
+// either the skipped revisions or the new events.
+func (c *Cursor) ResumeRevision() int64 {
+	after := c.revision
+	for revision := range c.gaps {
+		if revision-1 < after {
+			after = revision - 1
+		}
+	}
+	return after
+}
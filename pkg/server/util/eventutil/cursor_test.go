@@ -0,0 +1,56 @@
+package eventutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	now := time.Now()
+	cursor := NewCursor(10)
+	require.Equal(t, int64(10), cursor.ResumeRevision())
+
+	// Revisions at or before the starting revision are not consumed
+	require.False(t, cursor.Pending(10))
+	require.False(t, cursor.Observe(10, now))
+
+	// Pending revisions are not recorded until they are observed
+	require.True(t, cursor.Pending(11))
+	require.Equal(t, int64(10), cursor.ResumeRevision())
+
+	// Contiguous revisions are consumed and advance the cursor
+	require.True(t, cursor.Observe(11, now))
+	require.Equal(t, int64(11), cursor.ResumeRevision())
+
+	// Skipped revisions hold back the resume revision
+	require.True(t, cursor.Observe(14, now))
+	require.Equal(t, int64(11), cursor.ResumeRevision())
+
+	// Events already consumed are not consumed again
+	require.False(t, cursor.Observe(14, now))
+
+	// Skipped revisions are consumed once they show up
+	require.True(t, cursor.Pending(12))
+	require.True(t, cursor.Observe(12, now))
+	require.False(t, cursor.Pending(12))
+	require.False(t, cursor.Observe(12, now))
+	require.Equal(t, int64(12), cursor.ResumeRevision())
+
+	// Skipped revisions are given up on after the gap timeout
+	cursor.ExpireGaps(now.Add(gapTimeout - time.Second))
+	require.Equal(t, int64(12), cursor.ResumeRevision())
+	cursor.ExpireGaps(now.Add(gapTimeout))
+	require.Equal(t, int64(14), cursor.ResumeRevision())
+	require.False(t, cursor.Observe(13, now))
+}
+
+func TestCursorBoundsGaps(t *testing.T) {
+	now := time.Now()
+	cursor := NewCursor(0)
+
+	require.True(t, cursor.Observe(maxGaps*2, now))
+	require.Len(t, cursor.gaps, maxGaps)
+	require.Equal(t, int64(0), cursor.ResumeRevision())
+}
@@ -0,0 +1,355 @@
+package nodeattestutil
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"path"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/errorutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JoinTokenType is the attestation data type used by agents attesting with
+// a join token.
+const JoinTokenType = "join_token"
+
+// ChallengeFunc relays a challenge issued by the node attestor to the agent
+// and returns the agent's response.
+type ChallengeFunc func(challenge []byte) ([]byte, error)
+
+type Config struct {
+	Catalog     catalog.Catalog
+	TrustDomain spiffeid.TrustDomain
+	Clock       clock.Clock
+}
+
+// Attestor implements the server side of node attestation shared by the
+// node and agent APIs. Failures are logged with the provided logger and
+// returned as gRPC status errors ready to be sent to the caller.
+type Attestor struct {
+	c Config
+}
+
+func New(config Config) *Attestor {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	return &Attestor{
+		c: config,
+	}
+}
+
+type AttestRequest struct {
+	// AttestationData is the attestation data sent by the agent
+	AttestationData *common.AttestationData
+
+	// CSRSpiffeID is the agent ID in the CSR sent by the agent. Only legacy
+	// agents provide one.
+	CSRSpiffeID string
+
+	// Challenge relays node attestor challenges to the agent
+	Challenge ChallengeFunc
+}
+
+// AgentInfo describes the agent software, as reported by the agent when it
+// attests. The zero value means the agent did not report any information.
+type AgentInfo struct {
+	// Version is the version of the agent
+	Version string
+
+	// Plugins are the plugins loaded by the agent
+	Plugins []*common.AgentPlugin
+}
+
+// Attest attests the agent, either using a join token or the node attestor
+// plugin for the attestation data type, and makes sure the attested agent
+// has not been banned.
+func (a *Attestor) Attest(ctx context.Context, log logrus.FieldLogger, req AttestRequest) (*nodeattestor.AttestResponse, error) {
+	var attestResponse *nodeattestor.AttestResponse
+	var err error
+	if req.AttestationData.Type == JoinTokenType {
+		attestResponse, err = a.attestToken(ctx, log, req.AttestationData)
+		if err != nil {
+			log.WithError(err).Error("Failed to attest")
+			return nil, errorutil.WrapError(err, "failed to attest")
+		}
+	} else {
+		attestResponse, err = a.attestChallengeResponse(ctx, log, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	agentID := attestResponse.AgentId
+	log = log.WithField(telemetry.SPIFFEID, agentID)
+
+	if req.CSRSpiffeID != "" && agentID != req.CSRSpiffeID {
+		log.WithField(telemetry.CsrSpiffeID, req.CSRSpiffeID).Error("Attested SPIFFE ID does not match CSR")
+		return nil, status.Error(codes.NotFound, "attestor returned unexpected response")
+	}
+
+	node, err := a.fetchAttestedNode(ctx, agentID)
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to determine if agent is banned")
+		return nil, status.Error(codes.Internal, "failed to determine if agent is banned")
+	case node != nil && nodeutil.IsAgentBanned(node):
+		log.Error("Agent is banned")
+		return nil, status.Error(codes.PermissionDenied, "failed to attest: agent is banned")
+	}
+
+	return attestResponse, nil
+}
+
+// UpdateNode stores the selectors for the attested agent and creates or
+// updates its attested node with the newly signed agent SVID and the agent
+// information. The agent information already recorded for the node is kept if
+// the agent did not report any.
+func (a *Attestor) UpdateNode(ctx context.Context, log logrus.FieldLogger, attestationType string, attestResponse *nodeattestor.AttestResponse, svid *x509.Certificate, info AgentInfo) error {
+	agentID := attestResponse.AgentId
+	log = log.WithField(telemetry.SPIFFEID, agentID)
+
+	if err := a.updateNodeSelectors(ctx, log, agentID, attestResponse, attestationType); err != nil {
+		log.WithError(err).Error("Failed to update node selectors")
+		return status.Error(codes.Internal, "failed to update node selectors")
+	}
+
+	ds := a.c.Catalog.GetDataStore()
+	lastSeen := a.c.Clock.Now().Unix()
+	hasInfo := info.Version != "" || len(info.Plugins) > 0
+	node, err := a.fetchAttestedNode(ctx, agentID)
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to determine if agent has already attested")
+		return status.Error(codes.Internal, "failed to determine if agent has already attested")
+	case node != nil:
+		if _, err := ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+			SpiffeId:         agentID,
+			CertNotAfter:     svid.NotAfter.Unix(),
+			CertSerialNumber: svid.SerialNumber.String(),
+			AgentVersion:     info.Version,
+			AgentPlugins:     info.Plugins,
+			LastSeen:         lastSeen,
+			CanReattest:      attestResponse.CanReattest,
+			InputMask: &datastore.AttestedNodeMask{
+				CertSerialNumber:    true,
+				CertNotAfter:        true,
+				NewCertSerialNumber: true,
+				NewCertNotAfter:     true,
+				AgentVersion:        hasInfo,
+				AgentPlugins:        hasInfo,
+				LastSeen:            true,
+				CanReattest:         true,
+			},
+		}); err != nil {
+			log.WithError(err).Error("Failed to update attestation entry")
+			return status.Error(codes.Internal, "failed to update attestation entry")
+		}
+	default:
+		if _, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				AttestationDataType: attestationType,
+				SpiffeId:            agentID,
+				CertNotAfter:        svid.NotAfter.Unix(),
+				CertSerialNumber:    svid.SerialNumber.String(),
+				AgentVersion:        info.Version,
+				AgentPlugins:        info.Plugins,
+				LastSeen:            lastSeen,
+				CanReattest:         attestResponse.CanReattest,
+			},
+		}); err != nil {
+			log.WithError(err).Error("Failed to create attestation entry")
+			return status.Error(codes.Internal, "failed to create attestation entry")
+		}
+	}
+
+	return nil
+}
+
+func (a *Attestor) attestChallengeResponse(ctx context.Context, log logrus.FieldLogger, req AttestRequest) (*nodeattestor.AttestResponse, error) {
+	// New attestor plugins don't provide a SPIFFE ID to the agent so the
+	// CSR will not have one. If we have a SPIFFE ID in the CSR then we're
+	// working with a legacy plugin and need to provide deprecated
+	// "attested before" information to the server side plugin so it can
+	// make re-attestation decisions.
+	//
+	// If the CSR does not provide a SPIFFE ID then we tell the plugin
+	// that the agent has already attested to prevent old plugins from
+	// re-attesting unsafely.
+	//
+	// TODO: remove in SPIRE 0.10
+	attestedBefore := true
+	if req.CSRSpiffeID != "" {
+		node, err := a.fetchAttestedNode(ctx, req.CSRSpiffeID)
+		if err != nil {
+			log.WithError(err).Error("Failed to determine if agent has already attested")
+			return nil, status.Error(codes.Internal, "failed to determine if agent has already attested")
+		}
+		attestedBefore = node != nil
+	}
+
+	nodeAttestorType := req.AttestationData.Type
+	nodeAttestor, ok := a.c.Catalog.GetNodeAttestorNamed(nodeAttestorType)
+	if !ok {
+		log.WithField(telemetry.NodeAttestorType, nodeAttestorType).Error("Could not find node attestor type")
+		return nil, status.Errorf(codes.Unimplemented, "could not find node attestor type %q", nodeAttestorType)
+	}
+
+	// make sure node attestor stream will be cancelled if things go awry
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	attestStream, err := nodeAttestor.Attest(ctx)
+	if err != nil {
+		log.WithError(err).Error("Unable to open attest stream")
+		return nil, errorutil.WrapError(err, "unable to open attest stream")
+	}
+
+	attestRequest := &nodeattestor.AttestRequest{
+		AttestationData:          req.AttestationData,
+		DEPRECATEDAttestedBefore: attestedBefore,
+	}
+
+	// challenge/response loop
+	var attestResponse *nodeattestor.AttestResponse
+	for {
+		if err := attestStream.Send(attestRequest); err != nil {
+			log.WithError(err).Error("Failed to attest")
+			return nil, errorutil.WrapError(err, "failed to attest")
+		}
+
+		attestResponse, err = attestStream.Recv()
+		if err != nil {
+			log.WithError(err).Error("Failed to attest")
+			return nil, errorutil.WrapError(err, "failed to attest")
+		}
+		if attestResponse.Challenge == nil {
+			break
+		}
+
+		response, err := req.Challenge(attestResponse.Challenge)
+		if err != nil {
+			log.WithError(err).Error("Failed to relay challenge")
+			return nil, err
+		}
+
+		attestRequest = &nodeattestor.AttestRequest{
+			Response:                 response,
+			DEPRECATEDAttestedBefore: attestedBefore,
+		}
+	}
+
+	if err := attestStream.CloseSend(); err != nil {
+		log.WithError(err).Error("Failed to close send stream")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if _, err := attestStream.Recv(); err != io.EOF {
+		log.WithError(err).Warn("expected EOF on attestation stream")
+	}
+
+	return attestResponse, nil
+}
+
+func (a *Attestor) attestToken(ctx context.Context, log logrus.FieldLogger, attestationData *common.AttestationData) (*nodeattestor.AttestResponse, error) {
+	tokenValue := string(attestationData.Data)
+
+	agentID := a.c.TrustDomain.NewID(path.Join("spire", "agent", JoinTokenType, tokenValue)).String()
+
+	node, err := a.fetchAttestedNode(ctx, agentID)
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to determine if agent has already attested")
+		return nil, errorutil.WrapError(err, "failed to determine if agent has already attested")
+	case node != nil:
+		return nil, errors.New("join token has already been used")
+	}
+
+	ds := a.c.Catalog.GetDataStore()
+	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: tokenValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JoinToken == nil {
+		return nil, errors.New("no such token")
+	}
+	t := resp.JoinToken
+
+	if t.Token == "" {
+		return nil, errors.New("invalid join token")
+	}
+
+	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: tokenValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Unix(t.Expiry, 0).Before(a.c.Clock.Now()) {
+		return nil, errors.New("join token expired")
+	}
+
+	// If we're here, the token is valid
+	return &nodeattestor.AttestResponse{
+		AgentId: agentID,
+	}, nil
+}
+
+func (a *Attestor) updateNodeSelectors(ctx context.Context, log logrus.FieldLogger, agentID string, attestResponse *nodeattestor.AttestResponse, attestationType string) error {
+	var selectors []*common.Selector
+
+	// Select node resolver based on request attestation type
+	nodeResolver, ok := a.c.Catalog.GetNodeResolverNamed(attestationType)
+	if ok {
+		//Call node resolver plugin to get a map of spiffeID=>Selector
+		response, err := nodeResolver.Resolve(ctx, &noderesolver.ResolveRequest{
+			BaseSpiffeIdList: []string{agentID},
+		})
+		if err != nil {
+			return err
+		}
+
+		if resolved := response.Map[agentID]; resolved != nil {
+			selectors = append(selectors, resolved.Entries...)
+		}
+	} else {
+		log.WithField(telemetry.Attestor, attestationType).Debug("could not find node resolver")
+	}
+
+	selectors = append(selectors, attestResponse.Selectors...)
+
+	ds := a.c.Catalog.GetDataStore()
+	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  agentID,
+			Selectors: selectors,
+		},
+	})
+	return err
+}
+
+func (a *Attestor) fetchAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
+	resp, err := a.c.Catalog.GetDataStore().FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Node, nil
+}
@@ -0,0 +1,215 @@
+package nodeattestutil
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/fakes/fakeservernodeattestor"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const agentID = "spiffe://example.org/spire/agent/test/id"
+
+func TestAttestTellsLegacyPluginsAgentAttestedBefore(t *testing.T) {
+	a, ds, done := setupAttestor(t, true)
+	defer done()
+
+	// Without an agent ID in the CSR the plugin is told the agent attested
+	// before so it does not allow re-attestation unsafely.
+	_, err := a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: "test", Data: []byte("data")},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reattestation is not permitted")
+
+	// With an agent ID in the CSR the plugin is told whether the agent is
+	// attested or not.
+	resp, err := a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: "test", Data: []byte("data")},
+		CSRSpiffeID:     agentID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, agentID, resp.AgentId)
+
+	createAttestedNode(t, ds, &common.AttestedNode{SpiffeId: agentID, CertSerialNumber: "1"})
+	_, err = a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: "test", Data: []byte("data")},
+		CSRSpiffeID:     agentID,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reattestation is not permitted")
+}
+
+func TestAttestBannedAgent(t *testing.T) {
+	a, ds, done := setupAttestor(t, false)
+	defer done()
+
+	createAttestedNode(t, ds, &common.AttestedNode{SpiffeId: agentID})
+
+	_, err := a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: "test", Data: []byte("data")},
+		CSRSpiffeID:     agentID,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "failed to attest: agent is banned")
+}
+
+func TestAttestWithChallenges(t *testing.T) {
+	a, _, done := setupAttestor(t, false)
+	defer done()
+
+	var challenges []string
+	resp, err := a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: "test", Data: []byte("challenge")},
+		Challenge: func(challenge []byte) ([]byte, error) {
+			challenges = append(challenges, string(challenge))
+			return challenge, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/test/challenged", resp.AgentId)
+	require.Equal(t, []string{"one", "two"}, challenges)
+}
+
+func TestAttestWithJoinToken(t *testing.T) {
+	a, ds, done := setupAttestor(t, false)
+	defer done()
+
+	_, err := ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{Token: "TOKEN", Expiry: time.Now().Add(time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+
+	resp, err := a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: JoinTokenType, Data: []byte("TOKEN")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/join_token/TOKEN", resp.AgentId)
+
+	_, err = a.Attest(context.Background(), logger(), AttestRequest{
+		AttestationData: &common.AttestationData{Type: JoinTokenType, Data: []byte("TOKEN")},
+	})
+	require.EqualError(t, err, "failed to attest: no such token")
+}
+
+func TestUpdateNode(t *testing.T) {
+	a, ds, done := setupAttestor(t, false)
+	defer done()
+
+	attestResp := &nodeattestor.AttestResponse{
+		AgentId:     agentID,
+		Selectors:   []*common.Selector{{Type: "test", Value: "a"}},
+		CanReattest: true,
+	}
+	info := AgentInfo{
+		Version: "1.0.0",
+		Plugins: []*common.AgentPlugin{{Type: "NodeAttestor", Name: "test"}},
+	}
+	now := a.c.Clock.Now().Unix()
+
+	svid := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Unix(1000, 0)}
+	require.NoError(t, a.UpdateNode(context.Background(), logger(), "test", attestResp, svid, info))
+	spiretest.AssertProtoEqual(t, &common.AttestedNode{
+		SpiffeId:            agentID,
+		AttestationDataType: "test",
+		CertSerialNumber:    "1",
+		CertNotAfter:        1000,
+		AgentVersion:        "1.0.0",
+		AgentPlugins:        info.Plugins,
+		LastSeen:            now,
+		CanReattest:         true,
+	}, fetchAttestedNode(t, ds))
+
+	// Re-attestation replaces the agent information
+	attestResp.CanReattest = false
+	info.Version = "1.1.0"
+	svid = &x509.Certificate{SerialNumber: big.NewInt(2), NotAfter: time.Unix(2000, 0)}
+	require.NoError(t, a.UpdateNode(context.Background(), logger(), "test", attestResp, svid, info))
+	spiretest.AssertProtoEqual(t, &common.AttestedNode{
+		SpiffeId:            agentID,
+		AttestationDataType: "test",
+		CertSerialNumber:    "2",
+		CertNotAfter:        2000,
+		AgentVersion:        "1.1.0",
+		AgentPlugins:        info.Plugins,
+		LastSeen:            now,
+	}, fetchAttestedNode(t, ds))
+
+	// Re-attestation without agent information keeps the recorded one
+	svid = &x509.Certificate{SerialNumber: big.NewInt(3), NotAfter: time.Unix(3000, 0)}
+	require.NoError(t, a.UpdateNode(context.Background(), logger(), "test", attestResp, svid, AgentInfo{}))
+	spiretest.AssertProtoEqual(t, &common.AttestedNode{
+		SpiffeId:            agentID,
+		AttestationDataType: "test",
+		CertSerialNumber:    "3",
+		CertNotAfter:        3000,
+		AgentVersion:        "1.1.0",
+		AgentPlugins:        info.Plugins,
+		LastSeen:            now,
+	}, fetchAttestedNode(t, ds))
+
+	selectors, err := ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+		SpiffeId: agentID,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoListEqual(t, attestResp.Selectors, selectors.Selectors.Selectors)
+}
+
+func setupAttestor(t *testing.T, disallowReattestation bool) (*Attestor, *fakedatastore.DataStore, func()) {
+	ds := fakedatastore.New()
+	cat := fakeservercatalog.New()
+	cat.SetDataStore(ds)
+
+	var p nodeattestor.NodeAttestor
+	done := spiretest.LoadPlugin(t, catalog.MakePlugin("test", nodeattestor.PluginServer(fakeservernodeattestor.New("test", fakeservernodeattestor.Config{
+		DisallowReattestation: disallowReattestation,
+		Data: map[string]string{
+			"data":      "id",
+			"challenge": "challenged",
+		},
+		Challenges: map[string][]string{
+			"challenged": {"one", "two"},
+		},
+	}))), &p)
+	cat.AddNodeAttestorNamed("test", p)
+
+	return New(Config{
+		Catalog:     cat,
+		TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		Clock:       clock.NewMock(t),
+	}), ds, done
+}
+
+func createAttestedNode(t *testing.T, ds datastore.DataStore, node *common.AttestedNode) {
+	_, err := ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	require.NoError(t, err)
+}
+
+func fetchAttestedNode(t *testing.T, ds datastore.DataStore) *common.AttestedNode {
+	resp, err := ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	require.NoError(t, err)
+	return resp.Node
+}
+
+func logger() logrus.FieldLogger {
+	log, _ := test.NewNullLogger()
+	return log
+}
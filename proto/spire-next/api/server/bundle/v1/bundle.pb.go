@@ -25,6 +25,42 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Mode controls the delete behavior if there are registration entries
+// federating with the bundle.
+type BatchDeleteFederatedBundleRequest_Mode int32
+
+const (
+	// RESTRICT prevents the bundle from being deleted if there are
+	// registration entries federating with it.
+	BatchDeleteFederatedBundleRequest_RESTRICT BatchDeleteFederatedBundleRequest_Mode = 0
+	// DELETE deletes the bundle along with the registration entries
+	// federating with it.
+	BatchDeleteFederatedBundleRequest_DELETE BatchDeleteFederatedBundleRequest_Mode = 1
+	// DISSOCIATE deletes the bundle and removes it from the registration
+	// entries federating with it.
+	BatchDeleteFederatedBundleRequest_DISSOCIATE BatchDeleteFederatedBundleRequest_Mode = 2
+)
+
+var BatchDeleteFederatedBundleRequest_Mode_name = map[int32]string{
+	0: "RESTRICT",
+	1: "DELETE",
+	2: "DISSOCIATE",
+}
+
+var BatchDeleteFederatedBundleRequest_Mode_value = map[string]int32{
+	"RESTRICT":   0,
+	"DELETE":     1,
+	"DISSOCIATE": 2,
+}
+
+func (x BatchDeleteFederatedBundleRequest_Mode) String() string {
+	return proto.EnumName(BatchDeleteFederatedBundleRequest_Mode_name, int32(x))
+}
+
+func (BatchDeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cf01a1817f9fc5c2, []int{13, 0}
+}
+
 type GetBundleRequest struct {
 	// An output mask indicating which bundle fields are set in the response.
 	OutputMask           *types.BundleMask `protobuf:"bytes,1,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
@@ -794,10 +830,12 @@ func (m *BatchSetFederatedBundleResponse_Result) GetBundle() *types.Bundle {
 
 type BatchDeleteFederatedBundleRequest struct {
 	// The trust domain names (e.g., "example.org") of the bundles to delete.
-	TrustDomains         []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TrustDomains []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	// The delete mode. Defaults to RESTRICT.
+	Mode                 BatchDeleteFederatedBundleRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest_Mode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
 }
 
 func (m *BatchDeleteFederatedBundleRequest) Reset()         { *m = BatchDeleteFederatedBundleRequest{} }
@@ -832,6 +870,13 @@ func (m *BatchDeleteFederatedBundleRequest) GetTrustDomains() []string {
 	return nil
 }
 
+func (m *BatchDeleteFederatedBundleRequest) GetMode() BatchDeleteFederatedBundleRequest_Mode {
+	if m != nil {
+		return m.Mode
+	}
+	return BatchDeleteFederatedBundleRequest_RESTRICT
+}
+
 type BatchDeleteFederatedBundleResponse struct {
 	// Result for each bundle in the request.
 	Results              []*BatchDeleteFederatedBundleResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
@@ -925,6 +970,7 @@ func (m *BatchDeleteFederatedBundleResponse_Result) GetTrustDomain() string {
 }
 
 func init() {
+	proto.RegisterEnum("spire.api.server.bundle.v1.BatchDeleteFederatedBundleRequest_Mode", BatchDeleteFederatedBundleRequest_Mode_name, BatchDeleteFederatedBundleRequest_Mode_value)
 	proto.RegisterType((*GetBundleRequest)(nil), "spire.api.server.bundle.v1.GetBundleRequest")
 	proto.RegisterType((*AppendBundleRequest)(nil), "spire.api.server.bundle.v1.AppendBundleRequest")
 	proto.RegisterType((*PublishJWTAuthorityRequest)(nil), "spire.api.server.bundle.v1.PublishJWTAuthorityRequest")
@@ -949,58 +995,62 @@ func init() {
 func init() { proto.RegisterFile("bundle.proto", fileDescriptor_cf01a1817f9fc5c2) }
 
 var fileDescriptor_cf01a1817f9fc5c2 = []byte{
-	// 804 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x6d, 0x4f, 0x13, 0x4b,
-	0x14, 0xce, 0x96, 0xdc, 0x72, 0x7b, 0x28, 0x97, 0x9b, 0xe1, 0x26, 0x70, 0x17, 0x51, 0x58, 0x13,
-	0x63, 0xa2, 0xec, 0x06, 0x8c, 0x48, 0x44, 0x4d, 0xa8, 0x08, 0x06, 0xd1, 0x90, 0xa5, 0x8a, 0x51,
-	0x93, 0xba, 0xa5, 0x07, 0x3a, 0x50, 0xba, 0xeb, 0xce, 0x2c, 0x6f, 0x3f, 0x40, 0xa3, 0x1f, 0xd5,
-	0x6f, 0xfe, 0x0d, 0x7f, 0x81, 0x7f, 0xc4, 0x0f, 0x7e, 0xf2, 0x5f, 0x98, 0xce, 0xec, 0x96, 0xb5,
-	0xcc, 0x6e, 0x4b, 0x31, 0xf0, 0x6d, 0x33, 0x2f, 0xcf, 0x79, 0xe6, 0x39, 0x73, 0x9e, 0x39, 0x0b,
-	0xf9, 0x72, 0x50, 0xaf, 0xd4, 0xd0, 0xf4, 0x7c, 0x97, 0xbb, 0x44, 0x67, 0x1e, 0xf5, 0xd1, 0x74,
-	0x3c, 0x6a, 0x32, 0xf4, 0x77, 0xd1, 0x37, 0xc3, 0xe9, 0xdd, 0x49, 0x7d, 0x54, 0xcc, 0x4d, 0xd4,
-	0x71, 0x9f, 0x5b, 0xfc, 0xc0, 0x43, 0x66, 0xc5, 0xb7, 0x2a, 0xa6, 0x19, 0x77, 0x78, 0xc0, 0xe4,
-	0xb4, 0xb1, 0x0c, 0xff, 0x2e, 0x22, 0x2f, 0x88, 0x1d, 0x36, 0xbe, 0x09, 0x90, 0x71, 0x32, 0x03,
-	0x7d, 0x6e, 0xc0, 0xbd, 0x80, 0x97, 0x76, 0x1c, 0xb6, 0x3d, 0xac, 0x8d, 0x69, 0x57, 0xfb, 0xa6,
-	0x86, 0x4c, 0xc9, 0x41, 0x60, 0x98, 0x72, 0xc3, 0x63, 0x87, 0x6d, 0xdb, 0x20, 0xd7, 0x36, 0xbe,
-	0x8d, 0xaf, 0x1a, 0x0c, 0xce, 0x79, 0x1e, 0xd6, 0x2b, 0xbf, 0x23, 0x5e, 0x83, 0xac, 0x24, 0x15,
-	0x82, 0x0d, 0x2a, 0xc0, 0xec, 0x70, 0x09, 0x99, 0x06, 0xa0, 0xf5, 0x66, 0xf4, 0x4c, 0x7a, 0xf4,
-	0x9c, 0x58, 0xda, 0xf8, 0x6c, 0xa5, 0xdd, 0xd3, 0x39, 0xed, 0x67, 0xa0, 0xaf, 0x04, 0xe5, 0x1a,
-	0x65, 0xd5, 0xa5, 0xb5, 0xe2, 0x5c, 0xc0, 0xab, 0xae, 0x4f, 0xf9, 0xc1, 0x91, 0x1c, 0xfd, 0x5b,
-	0x7b, 0xbc, 0xe4, 0x44, 0xe3, 0xca, 0x33, 0x2c, 0xad, 0x15, 0x1f, 0xe1, 0x81, 0x9d, 0xdf, 0xda,
-	0xe3, 0x4d, 0x00, 0xe3, 0x25, 0x8c, 0x28, 0x71, 0x99, 0xe7, 0xd6, 0x19, 0x92, 0x3b, 0x30, 0x10,
-	0x07, 0xa6, 0xc8, 0x86, 0xb5, 0xb1, 0x9e, 0x24, 0xe8, 0x7f, 0x62, 0xd0, 0x14, 0x99, 0xf1, 0x49,
-	0x83, 0x91, 0x65, 0xca, 0xf8, 0x02, 0x56, 0xd0, 0x77, 0x38, 0x86, 0x92, 0xb3, 0x53, 0x67, 0x91,
-	0x8c, 0x40, 0xce, 0x73, 0x36, 0xb1, 0xc4, 0xe8, 0x21, 0x0a, 0xfd, 0xff, 0xb2, 0xff, 0x6e, 0x0c,
-	0xac, 0xd2, 0x43, 0x24, 0xa3, 0x00, 0x62, 0x92, 0xbb, 0xdb, 0x58, 0x17, 0x22, 0xe7, 0x6c, 0xb1,
-	0xbc, 0xd8, 0x18, 0x30, 0x02, 0xb8, 0xa0, 0x26, 0x15, 0x9e, 0x79, 0x02, 0x7a, 0x65, 0x9a, 0xd5,
-	0x67, 0x0d, 0xaf, 0x42, 0xb4, 0x86, 0x5c, 0x81, 0x81, 0xc6, 0xcd, 0x2d, 0xc5, 0x42, 0x66, 0x44,
-	0xc8, 0xfe, 0xc6, 0xf0, 0x4a, 0x33, 0xec, 0x3e, 0xfc, 0xbf, 0x88, 0xad, 0x51, 0x23, 0x25, 0xc6,
-	0x21, 0xcf, 0xfd, 0x80, 0xf1, 0x52, 0xc5, 0xdd, 0x71, 0x68, 0x5d, 0x48, 0x91, 0xb3, 0xfb, 0xc4,
-	0xd8, 0xbc, 0x18, 0x6a, 0x15, 0x2b, 0xd3, 0xf9, 0xdd, 0xf9, 0xa0, 0xc1, 0x78, 0xc1, 0xe1, 0xeb,
-	0xd5, 0xfb, 0x3e, 0x3a, 0x1c, 0x13, 0x28, 0xc4, 0x0b, 0xa0, 0xa7, 0x5d, 0x01, 0x74, 0x4f, 0xe6,
-	0xa7, 0x06, 0x46, 0x1a, 0x99, 0x30, 0x09, 0x25, 0xe8, 0xf5, 0x91, 0x05, 0x35, 0x1e, 0x25, 0xe1,
-	0x81, 0x99, 0x6c, 0x30, 0x66, 0x7b, 0x40, 0xd3, 0x16, 0x68, 0x76, 0x84, 0xaa, 0x97, 0x21, 0x2b,
-	0x87, 0x1a, 0x07, 0x97, 0x7e, 0xa3, 0xac, 0x9a, 0x55, 0x31, 0x65, 0x87, 0x4b, 0x62, 0x2a, 0x65,
-	0xda, 0xda, 0x84, 0xf1, 0x2d, 0x12, 0xfe, 0xa9, 0x57, 0xf9, 0x43, 0xc2, 0x9f, 0xbd, 0xf3, 0x34,
-	0x13, 0x96, 0x70, 0x88, 0xee, 0x13, 0x96, 0x0a, 0x78, 0x2e, 0x09, 0x7b, 0xa7, 0xc1, 0x45, 0x41,
-	0x6d, 0x35, 0xb1, 0x52, 0xcf, 0xa8, 0x4c, 0x7e, 0x68, 0x70, 0x29, 0x91, 0x49, 0x28, 0xf9, 0xab,
-	0x56, 0xc9, 0x0b, 0x6d, 0x25, 0x4f, 0x46, 0x3b, 0x17, 0xbd, 0x1f, 0x86, 0xf5, 0x31, 0x8f, 0x35,
-	0x4c, 0xac, 0x8f, 0xcb, 0xd0, 0x1f, 0xf7, 0x46, 0x79, 0xd8, 0x9c, 0x9d, 0x8f, 0x99, 0x23, 0x33,
-	0xbe, 0x47, 0xb7, 0x34, 0x01, 0xaa, 0xfb, 0x5b, 0x9a, 0x0a, 0x78, 0x4c, 0xb5, 0xe7, 0xdd, 0xa9,
-	0xd6, 0xea, 0xff, 0x99, 0x63, 0xfe, 0x3f, 0xf5, 0x39, 0x07, 0x59, 0x19, 0x9c, 0x3c, 0x81, 0x5c,
-	0xb3, 0x23, 0x22, 0xd7, 0xd3, 0x4e, 0xd0, 0xda, 0x38, 0xe9, 0xaa, 0x74, 0x90, 0x22, 0xe4, 0xe3,
-	0x2d, 0x11, 0xb1, 0xd2, 0x20, 0x15, 0xcd, 0x93, 0x1a, 0xf5, 0xad, 0x06, 0x83, 0x8a, 0xde, 0x82,
-	0x4c, 0xa7, 0xa1, 0x27, 0x37, 0x39, 0xfa, 0xad, 0x13, 0xef, 0x0b, 0x93, 0xfe, 0x5e, 0x83, 0xff,
-	0x54, 0x2f, 0x3e, 0x49, 0x45, 0x4c, 0x69, 0x5c, 0xf4, 0x99, 0x93, 0x6f, 0x0c, 0xb9, 0xbc, 0x06,
-	0x72, 0xbc, 0x0b, 0x20, 0x37, 0xdb, 0xe4, 0x50, 0x5d, 0x19, 0x6a, 0xd9, 0xbf, 0x68, 0xa0, 0x27,
-	0xbf, 0x87, 0xe4, 0x6e, 0xb7, 0xef, 0xa8, 0x0c, 0x79, 0xef, 0x74, 0xcf, 0xf0, 0x11, 0x3b, 0xa5,
-	0xf9, 0x77, 0xc0, 0x2e, 0xed, 0x29, 0xed, 0x80, 0x5d, 0xfa, 0x23, 0xf6, 0x51, 0x83, 0xa1, 0x04,
-	0x9f, 0x24, 0xb7, 0xbb, 0x32, 0x57, 0xc9, 0x6b, 0xf6, 0x14, 0xc6, 0x7c, 0x24, 0x99, 0xd2, 0x89,
-	0x3a, 0x90, 0x2c, 0xcd, 0x5d, 0x3b, 0x90, 0x2c, 0xd5, 0x00, 0x0b, 0x0b, 0x2f, 0xe6, 0x37, 0x29,
-	0xaf, 0x06, 0x65, 0x73, 0xdd, 0xdd, 0xb1, 0x98, 0x47, 0x37, 0x36, 0xd0, 0x12, 0x90, 0x96, 0xf8,
-	0x7d, 0xb3, 0x62, 0x3f, 0x77, 0x8e, 0x47, 0x2d, 0x19, 0x23, 0xfc, 0x01, 0xb4, 0x76, 0x27, 0x67,
-	0xe5, 0x57, 0x39, 0x2b, 0x56, 0xdf, 0xf8, 0x15, 0x00, 0x00, 0xff, 0xff, 0x58, 0xd2, 0x9b, 0x1c,
-	0x56, 0x0e, 0x00, 0x00,
+	// 869 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x52, 0xd3, 0x5a,
+	0x14, 0x3e, 0x29, 0x9c, 0x42, 0x17, 0x05, 0x3a, 0x9b, 0x33, 0x03, 0x27, 0x1c, 0x8e, 0x10, 0x67,
+	0x1c, 0x67, 0x94, 0x44, 0x70, 0x44, 0x46, 0xd4, 0x19, 0x4a, 0x0b, 0x03, 0x82, 0x32, 0x69, 0x04,
+	0x47, 0x9d, 0xa9, 0x29, 0xdd, 0xd0, 0x40, 0xdb, 0xc4, 0xec, 0x1d, 0xfe, 0x1e, 0x40, 0x47, 0x2f,
+	0xd5, 0x3b, 0x5f, 0xc3, 0x27, 0xf0, 0x11, 0x7c, 0x01, 0x2f, 0xbc, 0xf2, 0x2d, 0x9c, 0xec, 0x24,
+	0x25, 0x96, 0x9d, 0xb4, 0x14, 0x07, 0xee, 0xda, 0xfd, 0xf3, 0xad, 0x6f, 0x7d, 0x6b, 0xaf, 0x9f,
+	0x40, 0xba, 0xe4, 0xd4, 0xcb, 0x55, 0x2c, 0x5b, 0xb6, 0x49, 0x4d, 0x24, 0x12, 0xcb, 0xb0, 0xb1,
+	0xac, 0x5b, 0x86, 0x4c, 0xb0, 0xbd, 0x8f, 0x6d, 0xd9, 0xdf, 0xde, 0x9f, 0x12, 0xc7, 0xd8, 0xde,
+	0x64, 0x1d, 0x1f, 0x52, 0x85, 0x1e, 0x59, 0x98, 0x28, 0xe1, 0xab, 0x9c, 0x6d, 0x42, 0x75, 0xea,
+	0x10, 0x6f, 0x5b, 0x5a, 0x85, 0xcc, 0x12, 0xa6, 0x59, 0x76, 0x43, 0xc5, 0xaf, 0x1d, 0x4c, 0x28,
+	0x9a, 0x85, 0x3e, 0xd3, 0xa1, 0x96, 0x43, 0x8b, 0x35, 0x9d, 0xec, 0x8d, 0x08, 0xe3, 0xc2, 0xf5,
+	0xbe, 0xe9, 0x61, 0xd9, 0xe3, 0xc0, 0x30, 0x64, 0xef, 0xc2, 0x9a, 0x4e, 0xf6, 0x54, 0xf0, 0xce,
+	0xba, 0xbf, 0xa5, 0x2f, 0x02, 0x0c, 0xcd, 0x5b, 0x16, 0xae, 0x97, 0x7f, 0x47, 0xbc, 0x01, 0x49,
+	0x8f, 0x94, 0x0f, 0x36, 0xc4, 0x01, 0x53, 0xfd, 0x23, 0x68, 0x06, 0xc0, 0xa8, 0x37, 0xac, 0x27,
+	0xe2, 0xad, 0xa7, 0xd8, 0x51, 0xf7, 0x67, 0x33, 0xed, 0xae, 0xf6, 0x69, 0x6f, 0x80, 0xb8, 0xee,
+	0x94, 0xaa, 0x06, 0xa9, 0xac, 0x6c, 0x6a, 0xf3, 0x0e, 0xad, 0x98, 0xb6, 0x41, 0x8f, 0x4e, 0xe4,
+	0xe8, 0xdf, 0x3d, 0xa0, 0x45, 0x3d, 0x58, 0xe7, 0xfa, 0xb0, 0xb2, 0xa9, 0x3d, 0xc2, 0x47, 0x6a,
+	0x7a, 0xf7, 0x80, 0x36, 0x00, 0xa4, 0x17, 0x30, 0xca, 0xc5, 0x25, 0x96, 0x59, 0x27, 0x18, 0xdd,
+	0x87, 0xc1, 0x30, 0xb0, 0x81, 0xc9, 0x88, 0x30, 0xde, 0x15, 0x05, 0x3d, 0x10, 0x82, 0x36, 0x30,
+	0x91, 0x3e, 0x0a, 0x30, 0xba, 0x6a, 0x10, 0xba, 0x88, 0xcb, 0xd8, 0xd6, 0x29, 0xf6, 0x25, 0x27,
+	0xe7, 0x8e, 0x22, 0x1a, 0x85, 0x94, 0xa5, 0xef, 0xe0, 0x22, 0x31, 0x8e, 0x31, 0xd3, 0xff, 0x6f,
+	0xb5, 0xd7, 0x5d, 0x28, 0x18, 0xc7, 0x18, 0x8d, 0x01, 0xb0, 0x4d, 0x6a, 0xee, 0xe1, 0x3a, 0x13,
+	0x39, 0xa5, 0xb2, 0xe3, 0x9a, 0xbb, 0x20, 0x39, 0xf0, 0x1f, 0x9f, 0x94, 0xef, 0xf3, 0x24, 0xf4,
+	0x78, 0x61, 0xe6, 0xfb, 0xea, 0x3f, 0x85, 0xe0, 0x0c, 0xba, 0x06, 0x83, 0xee, 0xcb, 0x2d, 0x86,
+	0x4c, 0x26, 0x98, 0xc9, 0x7e, 0x77, 0x79, 0xbd, 0x61, 0xf6, 0x10, 0xfe, 0x5d, 0xc2, 0xcd, 0x56,
+	0x03, 0x25, 0x26, 0x20, 0x4d, 0x6d, 0x87, 0xd0, 0x62, 0xd9, 0xac, 0xe9, 0x46, 0x9d, 0x49, 0x91,
+	0x52, 0xfb, 0xd8, 0x5a, 0x8e, 0x2d, 0x35, 0x8b, 0x95, 0x68, 0xff, 0xed, 0xbc, 0x17, 0x60, 0x22,
+	0xab, 0xd3, 0xad, 0xca, 0x82, 0x8d, 0x75, 0x8a, 0x23, 0x28, 0x84, 0x13, 0xa0, 0xab, 0x55, 0x02,
+	0x74, 0x4e, 0xe6, 0xa7, 0x00, 0x52, 0x1c, 0x19, 0x3f, 0x08, 0x45, 0xe8, 0xb1, 0x31, 0x71, 0xaa,
+	0x34, 0x08, 0x42, 0x5e, 0x8e, 0x2e, 0x30, 0x72, 0x6b, 0x40, 0x59, 0x65, 0x68, 0x6a, 0x80, 0x2a,
+	0x96, 0x20, 0xe9, 0x2d, 0xb9, 0x8e, 0x7b, 0xf5, 0x86, 0x9b, 0x35, 0x05, 0xb6, 0xa5, 0xfa, 0x47,
+	0x42, 0x2a, 0x25, 0x5a, 0x96, 0x09, 0xe9, 0x6b, 0x20, 0xfc, 0x53, 0xab, 0xfc, 0x87, 0x84, 0xbf,
+	0xf8, 0xca, 0xd3, 0x08, 0x58, 0x84, 0x13, 0x9d, 0x07, 0x2c, 0x16, 0xf0, 0x52, 0x02, 0xf6, 0x56,
+	0x80, 0xff, 0x19, 0xb5, 0x42, 0x64, 0xa6, 0x5e, 0x50, 0x9a, 0xfc, 0x10, 0xe0, 0x4a, 0x24, 0x13,
+	0x5f, 0xf2, 0x97, 0xcd, 0x92, 0x67, 0x5b, 0x4a, 0x1e, 0x8d, 0x76, 0x29, 0x7a, 0x7f, 0x0b, 0x12,
+	0x24, 0x87, 0xab, 0x38, 0x32, 0x41, 0xae, 0x42, 0x7f, 0xb8, 0x38, 0x7a, 0xde, 0xa6, 0xd4, 0x74,
+	0xa8, 0x3a, 0x12, 0xb4, 0x01, 0xdd, 0x35, 0xb3, 0xec, 0x59, 0x1d, 0x68, 0x43, 0x89, 0x38, 0x8b,
+	0xf2, 0x9a, 0x59, 0xc6, 0x2a, 0xc3, 0x93, 0x6e, 0x41, 0xb7, 0xfb, 0x0f, 0xa5, 0xa1, 0x57, 0xcd,
+	0x17, 0x34, 0x75, 0x79, 0x41, 0xcb, 0xfc, 0x85, 0x00, 0x92, 0xb9, 0xfc, 0x6a, 0x5e, 0xcb, 0x67,
+	0x04, 0x34, 0x00, 0x90, 0x5b, 0x2e, 0x14, 0x9e, 0x2c, 0x2c, 0xcf, 0x6b, 0xf9, 0x4c, 0x42, 0xfa,
+	0x1e, 0x24, 0x4c, 0x84, 0x89, 0xce, 0x13, 0x26, 0x16, 0xf0, 0x54, 0x00, 0x9f, 0x75, 0x16, 0xc0,
+	0xe6, 0x56, 0x94, 0x38, 0xd5, 0x8a, 0xa6, 0x3f, 0xa5, 0x20, 0xe9, 0x19, 0x47, 0x8f, 0x21, 0xd5,
+	0x18, 0xce, 0xd0, 0xcd, 0x38, 0x0f, 0x9a, 0x67, 0x38, 0x91, 0xf7, 0x32, 0x90, 0x06, 0xe9, 0xf0,
+	0x74, 0x86, 0x94, 0x38, 0x48, 0xce, 0x1c, 0xc7, 0x47, 0x7d, 0x23, 0xc0, 0x10, 0x67, 0xcc, 0x41,
+	0x33, 0x71, 0xe8, 0xd1, 0xf3, 0x96, 0x78, 0xf7, 0xcc, 0xf7, 0xfc, 0xa0, 0xbf, 0x13, 0xe0, 0x1f,
+	0xde, 0xf0, 0x81, 0x62, 0x11, 0x63, 0x66, 0x28, 0x71, 0xf6, 0xec, 0x17, 0x7d, 0x2e, 0xaf, 0x00,
+	0x9d, 0x1e, 0x48, 0xd0, 0x9d, 0x16, 0x31, 0xe4, 0x67, 0x0c, 0x5f, 0xf6, 0xcf, 0x02, 0x88, 0xd1,
+	0xad, 0x19, 0x3d, 0xe8, 0xb4, 0xa5, 0x7b, 0x26, 0x1f, 0x9e, 0x6f, 0x22, 0x38, 0x61, 0xc7, 0xed,
+	0x43, 0x6d, 0xb0, 0x8b, 0xeb, 0xea, 0x6d, 0xb0, 0x8b, 0xef, 0xa7, 0x1f, 0x04, 0x18, 0x8e, 0x28,
+	0xd9, 0xe8, 0x5e, 0x47, 0x75, 0xde, 0xe3, 0x35, 0x77, 0x8e, 0x1e, 0x71, 0x22, 0x19, 0xb7, 0x12,
+	0xb5, 0x21, 0x59, 0x5c, 0xd5, 0x6d, 0x43, 0xb2, 0xd8, 0x02, 0x98, 0x5d, 0x7c, 0x9e, 0xdb, 0x31,
+	0x68, 0xc5, 0x29, 0xc9, 0x5b, 0x66, 0x4d, 0x21, 0x96, 0xb1, 0xbd, 0x8d, 0x15, 0x06, 0xa9, 0xb0,
+	0x2f, 0x49, 0x25, 0xf4, 0x9d, 0xa9, 0x5b, 0x86, 0xe2, 0xd9, 0xf0, 0xbf, 0x45, 0x95, 0xfd, 0xa9,
+	0x39, 0xef, 0x57, 0x29, 0xc9, 0x4e, 0xdf, 0xfe, 0x15, 0x00, 0x00, 0xff, 0xff, 0x57, 0xae, 0x68,
+	0x95, 0xe1, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -94,12 +94,15 @@ func (m *ListEntriesRequest) GetPageToken() string {
 }
 
 type ListEntriesRequest_Filter struct {
-	BySpiffeId           *types.SPIFFEID      `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
-	ByParentId           *types.SPIFFEID      `protobuf:"bytes,2,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
-	BySelectors          *types.SelectorMatch `protobuf:"bytes,3,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	BySpiffeId  *types.SPIFFEID      `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	ByParentId  *types.SPIFFEID      `protobuf:"bytes,2,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
+	BySelectors *types.SelectorMatch `protobuf:"bytes,3,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	// Matches entries that federate with all of the given trust domain
+	// names.
+	ByFederatesWith      []string `protobuf:"bytes,4,rep,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListEntriesRequest_Filter) Reset()         { *m = ListEntriesRequest_Filter{} }
@@ -148,6 +151,13 @@ func (m *ListEntriesRequest_Filter) GetBySelectors() *types.SelectorMatch {
 	return nil
 }
 
+func (m *ListEntriesRequest_Filter) GetByFederatesWith() []string {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
+}
+
 type ListEntriesResponse struct {
 	// The list of entries.
 	Entries []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -543,10 +553,14 @@ func (m *BatchUpdateEntryResponse_Result) GetEntry() *types.Entry {
 
 type BatchDeleteEntryRequest struct {
 	// IDs of the entries to delete.
-	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// The revision numbers the entries are expected to have, by entry ID.
+	// Entries with an expected revision number are not deleted unless they
+	// have that revision number.
+	ExpectedRevisionNumbers map[string]int64 `protobuf:"bytes,2,rep,name=expected_revision_numbers,json=expectedRevisionNumbers,proto3" json:"expected_revision_numbers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral    struct{}         `json:"-"`
+	XXX_unrecognized        []byte           `json:"-"`
+	XXX_sizecache           int32            `json:"-"`
 }
 
 func (m *BatchDeleteEntryRequest) Reset()         { *m = BatchDeleteEntryRequest{} }
@@ -581,6 +595,13 @@ func (m *BatchDeleteEntryRequest) GetIds() []string {
 	return nil
 }
 
+func (m *BatchDeleteEntryRequest) GetExpectedRevisionNumbers() map[string]int64 {
+	if m != nil {
+		return m.ExpectedRevisionNumbers
+	}
+	return nil
+}
+
 type BatchDeleteEntryResponse struct {
 	// Result for each entry in the request.
 	Results              []*BatchDeleteEntryResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
@@ -762,6 +783,7 @@ func init() {
 	proto.RegisterType((*BatchUpdateEntryResponse)(nil), "spire.api.server.entry.v1.BatchUpdateEntryResponse")
 	proto.RegisterType((*BatchUpdateEntryResponse_Result)(nil), "spire.api.server.entry.v1.BatchUpdateEntryResponse.Result")
 	proto.RegisterType((*BatchDeleteEntryRequest)(nil), "spire.api.server.entry.v1.BatchDeleteEntryRequest")
+	proto.RegisterMapType((map[string]int64)(nil), "spire.api.server.entry.v1.BatchDeleteEntryRequest.ExpectedRevisionNumbersEntry")
 	proto.RegisterType((*BatchDeleteEntryResponse)(nil), "spire.api.server.entry.v1.BatchDeleteEntryResponse")
 	proto.RegisterType((*BatchDeleteEntryResponse_Result)(nil), "spire.api.server.entry.v1.BatchDeleteEntryResponse.Result")
 	proto.RegisterType((*GetAuthorizedEntriesRequest)(nil), "spire.api.server.entry.v1.GetAuthorizedEntriesRequest")
@@ -771,54 +793,61 @@ func init() {
 func init() { proto.RegisterFile("entry.proto", fileDescriptor_daa6c5b6c627940f) }
 
 var fileDescriptor_daa6c5b6c627940f = []byte{
-	// 739 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xcf, 0x4e, 0xdb, 0x4c,
-	0x10, 0x97, 0x93, 0x2f, 0x81, 0x4c, 0xbe, 0xef, 0x03, 0x2d, 0xfd, 0xe3, 0x1a, 0x50, 0xa3, 0x1c,
-	0xaa, 0xa8, 0x14, 0x5b, 0x84, 0xb6, 0x48, 0xad, 0x7a, 0x28, 0x85, 0xa0, 0x54, 0x20, 0x21, 0x43,
-	0x7b, 0xe0, 0x12, 0x39, 0x78, 0x80, 0x55, 0x82, 0xed, 0x7a, 0xd7, 0xa8, 0xa1, 0x97, 0xde, 0xfa,
-	0x00, 0x7d, 0x98, 0xaa, 0xd7, 0xbe, 0x41, 0xdf, 0xa8, 0xf2, 0xee, 0xba, 0x4a, 0x62, 0x87, 0x10,
-	0x57, 0xea, 0xcd, 0xda, 0xf9, 0xcd, 0x6f, 0x66, 0x7e, 0x33, 0x3b, 0x5e, 0xa8, 0xa2, 0xc7, 0xc3,
-	0x81, 0x19, 0x84, 0x3e, 0xf7, 0xc9, 0x03, 0x16, 0xd0, 0x10, 0x4d, 0x27, 0xa0, 0x26, 0xc3, 0xf0,
-	0x0a, 0x43, 0x53, 0x5a, 0xaf, 0x36, 0x8c, 0x15, 0x61, 0x5a, 0xf7, 0xf0, 0x23, 0xb7, 0xf8, 0x20,
-	0x40, 0x66, 0x0d, 0x39, 0x1a, 0x0f, 0x53, 0x56, 0x86, 0x7d, 0x3c, 0xe5, 0x7e, 0x38, 0x19, 0x10,
-	0xd0, 0xb3, 0x33, 0xa4, 0xae, 0x02, 0xac, 0xa6, 0x01, 0xdc, 0xe1, 0x11, 0x93, 0xe6, 0xfa, 0xd7,
-	0x22, 0x90, 0x7d, 0xca, 0xf8, 0xae, 0xc7, 0x43, 0x8a, 0xcc, 0xc6, 0x0f, 0x11, 0x32, 0x4e, 0xf6,
-	0xa1, 0x7c, 0x46, 0xfb, 0x1c, 0x43, 0x5d, 0xab, 0x69, 0x8d, 0x6a, 0xf3, 0xa9, 0x39, 0xb1, 0x02,
-	0x33, 0xed, 0x6e, 0xb6, 0x84, 0xaf, 0xad, 0x38, 0xc8, 0x16, 0x54, 0xfd, 0x88, 0x07, 0x11, 0xef,
-	0x5c, 0x3a, 0xac, 0xa7, 0x17, 0x04, 0xe5, 0x3d, 0x45, 0x29, 0x92, 0x32, 0x63, 0x82, 0xc1, 0x81,
-	0xc3, 0x7a, 0x36, 0x48, 0x68, 0xfc, 0x4d, 0x96, 0xa1, 0x12, 0x38, 0xe7, 0xd8, 0x61, 0xf4, 0x1a,
-	0xf5, 0x62, 0x4d, 0x6b, 0x94, 0xec, 0xf9, 0xf8, 0xe0, 0x88, 0x5e, 0x23, 0x59, 0x05, 0x10, 0x46,
-	0xee, 0xf7, 0xd0, 0xd3, 0xff, 0xa9, 0x69, 0x8d, 0x8a, 0x2d, 0xe0, 0xc7, 0xf1, 0x81, 0xf1, 0x5d,
-	0x83, 0x72, 0x2b, 0x89, 0xff, 0x6f, 0x77, 0xd0, 0x91, 0xc2, 0x74, 0xa8, 0xab, 0x6a, 0xba, 0x3b,
-	0x92, 0xc0, 0xd1, 0x61, 0xbb, 0xd5, 0xda, 0x6d, 0xef, 0xd8, 0xd0, 0x1d, 0x1c, 0x09, 0x64, 0xdb,
-	0x55, 0x8e, 0x81, 0x13, 0xa2, 0xc7, 0x63, 0xc7, 0xc2, 0x14, 0xc7, 0x43, 0x81, 0x6c, 0xbb, 0xe4,
-	0x95, 0x8c, 0xa8, 0x7a, 0xc5, 0x44, 0xee, 0xd5, 0xa6, 0x31, 0xea, 0xa8, 0xac, 0x07, 0x0e, 0x3f,
-	0xbd, 0xb0, 0xab, 0xdd, 0x41, 0x72, 0xc0, 0xea, 0x3d, 0x58, 0x1a, 0x51, 0x95, 0x05, 0xbe, 0xc7,
-	0x90, 0x3c, 0x81, 0x39, 0x94, 0x47, 0xba, 0x56, 0x2b, 0x36, 0xaa, 0x4d, 0x92, 0xd6, 0xd0, 0x4e,
-	0x20, 0xe4, 0x11, 0x2c, 0xc4, 0x5d, 0xef, 0x0c, 0x89, 0x54, 0x10, 0x22, 0xfd, 0x17, 0x1f, 0x1f,
-	0x26, 0x42, 0xd5, 0x4f, 0x60, 0x61, 0x0f, 0xb9, 0x74, 0x56, 0xed, 0xff, 0x1f, 0x0a, 0x4a, 0xa6,
-	0x8a, 0x5d, 0xa0, 0x6e, 0xee, 0x06, 0xd6, 0x3f, 0x6b, 0x70, 0x7f, 0x3b, 0xae, 0xef, 0x4d, 0x88,
-	0x0e, 0xc7, 0x91, 0x20, 0xb3, 0x55, 0x93, 0x3b, 0x85, 0x9f, 0x1a, 0xe8, 0xe9, 0x14, 0x94, 0xa2,
-	0xc7, 0x30, 0x17, 0x22, 0x8b, 0xfa, 0x3c, 0xc9, 0xe1, 0xc5, 0x0d, 0x83, 0x3e, 0x89, 0xc5, 0xb4,
-	0x05, 0x85, 0x9d, 0x50, 0x19, 0x1d, 0x28, 0xcb, 0x23, 0xb2, 0x06, 0x65, 0x79, 0xdd, 0xd4, 0xcc,
-	0x2d, 0x8d, 0x4e, 0x80, 0x30, 0xd9, 0x0a, 0x42, 0x1a, 0x50, 0x12, 0xb1, 0x54, 0x71, 0x59, 0x72,
-	0x48, 0x40, 0xfd, 0x5b, 0x22, 0xeb, 0xbb, 0xc0, 0xfd, 0x33, 0x59, 0x9f, 0x01, 0x50, 0xef, 0x96,
-	0xaa, 0x56, 0x04, 0x52, 0x5c, 0xcc, 0xb1, 0x6e, 0x14, 0x67, 0xef, 0xc6, 0x48, 0xe6, 0xb9, 0xbb,
-	0x91, 0xc1, 0xf2, 0xf7, 0xbb, 0xb1, 0xa6, 0x9a, 0xb1, 0x83, 0x7d, 0x1c, 0x6b, 0xc6, 0x22, 0x14,
-	0xa9, 0x2b, 0xab, 0xa9, 0xd8, 0xf1, 0x67, 0xdc, 0x3a, 0x3d, 0x8d, 0xce, 0x2d, 0x40, 0x06, 0x4b,
-	0x4a, 0x80, 0xdd, 0x7c, 0x02, 0xc8, 0x25, 0x50, 0x48, 0x96, 0x40, 0xfd, 0x3d, 0x2c, 0xef, 0x21,
-	0x7f, 0x1d, 0xf1, 0x0b, 0x3f, 0xa4, 0xd7, 0xe8, 0x8e, 0xfd, 0x32, 0xc6, 0x46, 0x42, 0xbb, 0xf5,
-	0x48, 0xec, 0xc3, 0x4a, 0x36, 0x6f, 0x9e, 0xad, 0xd7, 0xfc, 0x51, 0x82, 0x92, 0x38, 0x22, 0x7d,
-	0xa8, 0x0e, 0x2d, 0x51, 0xb2, 0x3e, 0xd3, 0x2f, 0xcc, 0x30, 0x6f, 0x0b, 0x57, 0x59, 0xbe, 0x85,
-	0xf9, 0x64, 0x8b, 0x92, 0xc7, 0x37, 0xf8, 0x8e, 0xad, 0x5a, 0x23, 0xa3, 0x18, 0xf2, 0x09, 0x16,
-	0xc7, 0x77, 0x0d, 0x69, 0xce, 0xb4, 0x98, 0x24, 0xf7, 0x66, 0x8e, 0x65, 0xf6, 0x3b, 0xf8, 0xd0,
-	0xd5, 0x9a, 0x1e, 0x3c, 0xbd, 0x87, 0xa6, 0x07, 0xcf, 0xda, 0x00, 0x49, 0xf0, 0xa1, 0xb1, 0x9e,
-	0x1e, 0x3c, 0x7d, 0xef, 0xa6, 0x07, 0xcf, 0xba, 0x7d, 0x5f, 0x34, 0xb8, 0x93, 0x35, 0x89, 0xe4,
-	0xf9, 0xcd, 0xfd, 0x9c, 0x74, 0x25, 0x8c, 0xad, 0x99, 0xfd, 0x64, 0x26, 0xdb, 0x3b, 0x27, 0xdb,
-	0xe7, 0x94, 0x5f, 0x44, 0x5d, 0xf3, 0xd4, 0xbf, 0x54, 0x2f, 0x3a, 0x4b, 0x70, 0x59, 0xe2, 0xd9,
-	0x66, 0x0d, 0x3d, 0xea, 0x9c, 0x80, 0x5a, 0x92, 0x5c, 0xbe, 0x1c, 0xad, 0xab, 0x8d, 0x97, 0xe2,
-	0xa3, 0x5b, 0x16, 0xd8, 0xcd, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7a, 0x06, 0xb3, 0x3f, 0x8b,
-	0x0a, 0x00, 0x00,
+	// 851 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x97, 0x9d, 0x26, 0x6d, 0x5e, 0x80, 0x2d, 0xd3, 0x42, 0x5d, 0x77, 0x2b, 0xa2, 0x1c, 0x50,
+	0x54, 0xa8, 0xa3, 0xa6, 0x40, 0x51, 0x11, 0x07, 0x96, 0xdd, 0x54, 0x5b, 0x6d, 0x61, 0x35, 0x5b,
+	0x40, 0xea, 0xc5, 0xb2, 0xe3, 0x97, 0x66, 0x94, 0xac, 0x6d, 0x3c, 0xe3, 0xb0, 0x5e, 0x2e, 0x5c,
+	0x10, 0x07, 0x3e, 0x10, 0x47, 0x24, 0xbe, 0x01, 0x1f, 0x82, 0xef, 0x81, 0x3c, 0x33, 0x46, 0x49,
+	0xec, 0x24, 0x9b, 0x20, 0xf5, 0x66, 0xbf, 0x3f, 0xbf, 0xf7, 0xe7, 0xf7, 0xe6, 0xcd, 0x40, 0x0b,
+	0x43, 0x91, 0x64, 0x4e, 0x9c, 0x44, 0x22, 0x22, 0x77, 0x79, 0xcc, 0x12, 0x74, 0xbc, 0x98, 0x39,
+	0x1c, 0x93, 0x19, 0x26, 0x8e, 0xd2, 0xce, 0x1e, 0xd9, 0xfb, 0x52, 0xf5, 0x30, 0xc4, 0x0b, 0xd1,
+	0x13, 0x59, 0x8c, 0xbc, 0x37, 0xe7, 0x68, 0x7f, 0x50, 0xd2, 0x72, 0x9c, 0xe2, 0x50, 0x44, 0xc9,
+	0x6a, 0x83, 0x98, 0x8d, 0x46, 0xc8, 0x02, 0x6d, 0x70, 0xbf, 0x6c, 0x20, 0x3c, 0x91, 0x72, 0xa5,
+	0xee, 0xfc, 0x59, 0x03, 0x72, 0xc2, 0xb8, 0x38, 0x0a, 0x45, 0xc2, 0x90, 0x53, 0xfc, 0x31, 0x45,
+	0x2e, 0xc8, 0x09, 0x34, 0x46, 0x6c, 0x2a, 0x30, 0xb1, 0x8c, 0xb6, 0xd1, 0x6d, 0xf5, 0x3f, 0x71,
+	0x56, 0x56, 0xe0, 0x94, 0xdd, 0x9d, 0x81, 0xf4, 0xa5, 0x1a, 0x83, 0x3c, 0x81, 0x56, 0x94, 0x8a,
+	0x38, 0x15, 0xee, 0xb9, 0xc7, 0x27, 0x96, 0x29, 0x21, 0xdf, 0xd7, 0x90, 0x32, 0x29, 0x27, 0x07,
+	0xc8, 0x5e, 0x78, 0x7c, 0x42, 0x41, 0x99, 0xe6, 0xdf, 0xe4, 0x1e, 0x34, 0x63, 0xef, 0x35, 0xba,
+	0x9c, 0x5d, 0xa2, 0x55, 0x6b, 0x1b, 0xdd, 0x3a, 0xbd, 0x91, 0x0b, 0xce, 0xd8, 0x25, 0x92, 0xfb,
+	0x00, 0x52, 0x29, 0xa2, 0x09, 0x86, 0xd6, 0xb5, 0xb6, 0xd1, 0x6d, 0x52, 0x69, 0xfe, 0x32, 0x17,
+	0xd8, 0xff, 0x18, 0xd0, 0x18, 0x14, 0xf1, 0xdf, 0xf2, 0x33, 0x57, 0x35, 0xc6, 0x65, 0x81, 0xae,
+	0xe9, 0xbd, 0x85, 0x04, 0xce, 0x4e, 0x8f, 0x07, 0x83, 0xa3, 0xe3, 0x43, 0x0a, 0x7e, 0x76, 0x26,
+	0x2d, 0x8f, 0x03, 0xed, 0x18, 0x7b, 0x09, 0x86, 0x22, 0x77, 0x34, 0x37, 0x38, 0x9e, 0x4a, 0xcb,
+	0xe3, 0x80, 0x7c, 0xa9, 0x22, 0x6a, 0xae, 0xb8, 0xcc, 0xbd, 0xd5, 0xb7, 0x17, 0x1d, 0xb5, 0xf6,
+	0x85, 0x27, 0x86, 0x63, 0xda, 0xf2, 0xb3, 0x42, 0xc0, 0xc9, 0x03, 0x78, 0xd7, 0xcf, 0xdc, 0x11,
+	0x06, 0x98, 0x78, 0x02, 0xb9, 0xfb, 0x13, 0x13, 0x63, 0xeb, 0x5a, 0xbb, 0xd6, 0x6d, 0xd2, 0x3d,
+	0x3f, 0x1b, 0x14, 0xf2, 0x1f, 0x98, 0x18, 0x77, 0x26, 0x70, 0x6b, 0x81, 0x01, 0x1e, 0x47, 0x21,
+	0x47, 0xf2, 0x31, 0x5c, 0x47, 0x25, 0xb2, 0x8c, 0x76, 0xad, 0xdb, 0xea, 0x93, 0x72, 0xbf, 0x69,
+	0x61, 0x42, 0x3e, 0x84, 0xbd, 0x7c, 0x42, 0xdc, 0xb9, 0x86, 0x9a, 0xb2, 0xa1, 0x6f, 0xe7, 0xe2,
+	0xd3, 0xa2, 0xa9, 0x9d, 0x57, 0xb0, 0xf7, 0x0c, 0x85, 0x72, 0xd6, 0xa3, 0xf2, 0x0e, 0x98, 0xba,
+	0xa5, 0x4d, 0x6a, 0xb2, 0x60, 0x67, 0xb2, 0x3b, 0xbf, 0x18, 0x70, 0xe7, 0x20, 0xef, 0xc5, 0xd7,
+	0x09, 0x7a, 0x02, 0x17, 0x82, 0x6c, 0x57, 0xcd, 0xce, 0x29, 0xfc, 0x6d, 0x80, 0x55, 0x4e, 0x41,
+	0x77, 0xf4, 0x25, 0x5c, 0x4f, 0x90, 0xa7, 0x53, 0x51, 0xe4, 0xf0, 0x74, 0xcd, 0xa1, 0x58, 0x85,
+	0xe2, 0x50, 0x09, 0x41, 0x0b, 0x28, 0xdb, 0x85, 0x86, 0x12, 0x91, 0x8f, 0xa0, 0xa1, 0x8e, 0xa6,
+	0x9e, 0xcf, 0x5b, 0x8b, 0xd3, 0x22, 0x55, 0x54, 0x9b, 0x90, 0x2e, 0xd4, 0x65, 0x2c, 0x5d, 0x5c,
+	0x55, 0x3b, 0x94, 0x41, 0xe7, 0x8f, 0xa2, 0xad, 0xdf, 0xc5, 0xc1, 0xff, 0x6b, 0xeb, 0xa7, 0x00,
+	0x2c, 0xbc, 0x62, 0x57, 0x9b, 0xd2, 0x52, 0x1e, 0xe2, 0x25, 0x36, 0x6a, 0xdb, 0xb3, 0xb1, 0x90,
+	0xf9, 0xce, 0x6c, 0x54, 0xa0, 0xbc, 0x79, 0x36, 0x7e, 0x35, 0x35, 0x1b, 0x87, 0x38, 0xc5, 0x25,
+	0x36, 0x6e, 0x42, 0x8d, 0x05, 0xaa, 0x9c, 0x26, 0xcd, 0x3f, 0xc9, 0xef, 0x06, 0xdc, 0xc5, 0x8b,
+	0x18, 0x87, 0x02, 0x03, 0x37, 0xc1, 0x19, 0xe3, 0x2c, 0x0a, 0xdd, 0x30, 0x3d, 0xf7, 0x31, 0xe1,
+	0x96, 0x29, 0xeb, 0xfe, 0x76, 0x53, 0xdd, 0xe5, 0x48, 0xce, 0x91, 0xc6, 0xa4, 0x1a, 0xf2, 0x1b,
+	0x85, 0xa8, 0x6c, 0xee, 0x60, 0xb5, 0xd6, 0x7e, 0x0e, 0xfb, 0xeb, 0x1c, 0xf3, 0xfc, 0x27, 0x98,
+	0xe9, 0x55, 0x90, 0x7f, 0x92, 0xdb, 0x50, 0x9f, 0x79, 0xd3, 0x14, 0x65, 0x5f, 0x6a, 0x54, 0xfd,
+	0x3c, 0x35, 0x3f, 0x37, 0xf2, 0xa9, 0xb4, 0xca, 0xd9, 0xed, 0xcc, 0x6d, 0x05, 0x4a, 0x89, 0xdb,
+	0xa3, 0xdd, 0xb8, 0x55, 0xfb, 0xcd, 0x2c, 0xf6, 0x5b, 0xe7, 0x7b, 0xb8, 0xf7, 0x0c, 0xc5, 0x57,
+	0xa9, 0x18, 0x47, 0x09, 0xbb, 0xc4, 0x60, 0xe9, 0xe6, 0x5c, 0x9a, 0x76, 0xe3, 0xca, 0xd3, 0x7e,
+	0x02, 0xfb, 0xd5, 0xb8, 0xbb, 0x2c, 0xf4, 0xfe, 0x5f, 0x75, 0xa8, 0x2b, 0x56, 0xa6, 0xd0, 0x9a,
+	0xbb, 0x1f, 0xc8, 0xc3, 0xad, 0x6e, 0x72, 0xdb, 0xb9, 0xaa, 0xb9, 0xce, 0xf2, 0x39, 0xdc, 0x28,
+	0x2e, 0x08, 0xf2, 0x60, 0x8d, 0xef, 0xd2, 0x2d, 0x62, 0x57, 0x14, 0x43, 0x7e, 0x86, 0x9b, 0xcb,
+	0x6b, 0x94, 0xf4, 0xb7, 0xda, 0xb9, 0x0a, 0xfb, 0xf1, 0x0e, 0x7b, 0xfa, 0xbf, 0xe0, 0x73, 0x5b,
+	0x63, 0x73, 0xf0, 0xf2, 0x8a, 0xdd, 0x1c, 0xbc, 0x6a, 0xb9, 0x15, 0xc1, 0xe7, 0xc6, 0x7a, 0x73,
+	0xf0, 0xf2, 0x39, 0xdf, 0x1c, 0xbc, 0xea, 0xf4, 0xfd, 0x66, 0xc0, 0xed, 0xaa, 0x49, 0x24, 0x9f,
+	0xad, 0xe7, 0x73, 0xd5, 0x91, 0xb0, 0x9f, 0x6c, 0xed, 0xa7, 0x32, 0x39, 0x38, 0x7c, 0x75, 0xf0,
+	0x9a, 0x89, 0x71, 0xea, 0x3b, 0xc3, 0xe8, 0x5c, 0x3f, 0x6c, 0x7b, 0x12, 0xab, 0x27, 0x5f, 0xaf,
+	0xbd, 0xb9, 0xb7, 0xad, 0x17, 0xb3, 0x9e, 0x02, 0x57, 0x0f, 0xe8, 0xde, 0xec, 0xd1, 0x17, 0xf2,
+	0xc3, 0x6f, 0x48, 0xdb, 0xc7, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x75, 0x35, 0x63, 0x92,
+	0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
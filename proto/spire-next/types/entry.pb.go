@@ -52,7 +52,16 @@ type Entry struct {
 	// When the entry expires (seconds since Unix epoch).
 	ExpiresAt int64 `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	// A list of DNS names associated with the identity described by this entry.
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// The revision number of the entry, incremented each time the entry is
+	// updated.
+	RevisionNumber int64 `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	// Customization of the X.509-SVIDs issued for this entry. The default
+	// subject, SANs and extended key usages are used if unset.
+	X509SvidProfile *X509SVIDProfile `protobuf:"bytes,12,opt,name=x509_svid_profile,json=x509SvidProfile,proto3" json:"x509_svid_profile,omitempty"`
+	// The time to live for JWT-SVIDs issued for this entry (in seconds). The
+	// server default is used if unset.
+	JwtSvidTtl           int32    `protobuf:"varint,13,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -153,6 +162,160 @@ func (m *Entry) GetDnsNames() []string {
 	return nil
 }
 
+func (m *Entry) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
+func (m *Entry) GetX509SvidProfile() *X509SVIDProfile {
+	if m != nil {
+		return m.X509SvidProfile
+	}
+	return nil
+}
+
+func (m *Entry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+// Customizes the X.509-SVIDs issued for an entry.
+type X509SVIDProfile struct {
+	// The subject of the X.509-SVIDs. The default subject is used if unset.
+	Subject *X509Subject `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// IP address SANs.
+	IpAddresses []string `protobuf:"bytes,2,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	// URI SANs, added after the SPIFFE ID.
+	Uris []string `protobuf:"bytes,3,rep,name=uris,proto3" json:"uris,omitempty"`
+	// The extended key usages, among "serverAuth" and "clientAuth". Both are
+	// used if unset.
+	ExtKeyUsages         []string `protobuf:"bytes,4,rep,name=ext_key_usages,json=extKeyUsages,proto3" json:"ext_key_usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDProfile) Reset()         { *m = X509SVIDProfile{} }
+func (m *X509SVIDProfile) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDProfile) ProtoMessage()    {}
+func (*X509SVIDProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daa6c5b6c627940f, []int{1}
+}
+
+func (m *X509SVIDProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDProfile.Unmarshal(m, b)
+}
+func (m *X509SVIDProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDProfile.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDProfile.Merge(m, src)
+}
+func (m *X509SVIDProfile) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDProfile.Size(m)
+}
+func (m *X509SVIDProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDProfile proto.InternalMessageInfo
+
+func (m *X509SVIDProfile) GetSubject() *X509Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetUris() []string {
+	if m != nil {
+		return m.Uris
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetExtKeyUsages() []string {
+	if m != nil {
+		return m.ExtKeyUsages
+	}
+	return nil
+}
+
+// The subject of an X.509 certificate.
+type X509Subject struct {
+	Country              []string `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+	Organization         []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	OrganizationalUnit   []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	CommonName           string   `protobuf:"bytes,4,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509Subject) Reset()         { *m = X509Subject{} }
+func (m *X509Subject) String() string { return proto.CompactTextString(m) }
+func (*X509Subject) ProtoMessage()    {}
+func (*X509Subject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daa6c5b6c627940f, []int{2}
+}
+
+func (m *X509Subject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509Subject.Unmarshal(m, b)
+}
+func (m *X509Subject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509Subject.Marshal(b, m, deterministic)
+}
+func (m *X509Subject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509Subject.Merge(m, src)
+}
+func (m *X509Subject) XXX_Size() int {
+	return xxx_messageInfo_X509Subject.Size(m)
+}
+func (m *X509Subject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509Subject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509Subject proto.InternalMessageInfo
+
+func (m *X509Subject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509Subject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
 // Field mask for Entry fields
 type EntryMask struct {
 	// id field mask
@@ -174,7 +337,15 @@ type EntryMask struct {
 	// expires_at field mask
 	ExpiresAt bool `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	// dns_names field mask
-	DnsNames             bool     `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames bool `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// revision_number field mask. The revision number cannot be updated; when
+	// set in an update input mask, the update fails unless the entry has the
+	// given revision number.
+	RevisionNumber bool `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	// x509_svid_profile field mask
+	X509SvidProfile bool `protobuf:"varint,12,opt,name=x509_svid_profile,json=x509SvidProfile,proto3" json:"x509_svid_profile,omitempty"`
+	// jwt_svid_ttl field mask
+	JwtSvidTtl           bool     `protobuf:"varint,13,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -184,7 +355,7 @@ func (m *EntryMask) Reset()         { *m = EntryMask{} }
 func (m *EntryMask) String() string { return proto.CompactTextString(m) }
 func (*EntryMask) ProtoMessage()    {}
 func (*EntryMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_daa6c5b6c627940f, []int{1}
+	return fileDescriptor_daa6c5b6c627940f, []int{3}
 }
 
 func (m *EntryMask) XXX_Unmarshal(b []byte) error {
@@ -275,37 +446,76 @@ func (m *EntryMask) GetDnsNames() bool {
 	return false
 }
 
+func (m *EntryMask) GetRevisionNumber() bool {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return false
+}
+
+func (m *EntryMask) GetX509SvidProfile() bool {
+	if m != nil {
+		return m.X509SvidProfile
+	}
+	return false
+}
+
+func (m *EntryMask) GetJwtSvidTtl() bool {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "spire.types.Entry")
+	proto.RegisterType((*X509SVIDProfile)(nil), "spire.types.X509SVIDProfile")
+	proto.RegisterType((*X509Subject)(nil), "spire.types.X509Subject")
 	proto.RegisterType((*EntryMask)(nil), "spire.types.EntryMask")
 }
 
 func init() { proto.RegisterFile("entry.proto", fileDescriptor_daa6c5b6c627940f) }
 
 var fileDescriptor_daa6c5b6c627940f = []byte{
-	// 378 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0x5f, 0x4b, 0xeb, 0x30,
-	0x18, 0xc6, 0x69, 0x7b, 0xba, 0x93, 0x64, 0x9c, 0x71, 0x08, 0xe7, 0x40, 0x70, 0xfe, 0x29, 0x03,
-	0xa1, 0x37, 0xb6, 0xb2, 0x7d, 0x02, 0xc5, 0x0d, 0x7a, 0xa1, 0x48, 0xbd, 0x10, 0xbc, 0x29, 0xdd,
-	0x92, 0xb9, 0xe0, 0x9a, 0x96, 0xe6, 0x95, 0x6d, 0x5f, 0xc6, 0xaf, 0xe6, 0x57, 0x91, 0xa6, 0x9b,
-	0xd6, 0x39, 0x77, 0xe7, 0x5d, 0xdf, 0xe7, 0x7d, 0x9e, 0x26, 0xf9, 0xf1, 0x90, 0xb6, 0x50, 0x50,
-	0xae, 0x82, 0xa2, 0xcc, 0x21, 0xa7, 0x6d, 0x5d, 0xc8, 0x52, 0x04, 0xb0, 0x2a, 0x84, 0x3e, 0x38,
-	0x31, 0xc3, 0x99, 0x12, 0x4b, 0x08, 0x8d, 0x12, 0x6a, 0x31, 0x17, 0x13, 0xc8, 0xcb, 0xda, 0xbd,
-	0xcb, 0x50, 0xc8, 0xe9, 0x54, 0x48, 0x5e, 0x1b, 0x7a, 0xaf, 0x36, 0x71, 0x87, 0xd5, 0xef, 0x69,
-	0x87, 0xd8, 0x92, 0x33, 0xcb, 0xb3, 0x7c, 0x1c, 0xdb, 0x92, 0xd3, 0x3e, 0xc1, 0xb5, 0x37, 0x91,
-	0x9c, 0xd9, 0x9e, 0xe5, 0xb7, 0xfb, 0xff, 0x83, 0xc6, 0xe1, 0xc1, 0xdd, 0x6d, 0x34, 0x1a, 0x0d,
-	0xa3, 0xab, 0x18, 0xd5, 0xbe, 0xc8, 0x64, 0x8a, 0xb4, 0x14, 0x0a, 0xaa, 0x8c, 0xb3, 0x37, 0x53,
-	0xfb, 0x22, 0x4e, 0x07, 0x04, 0x6f, 0x2e, 0xad, 0xd9, 0x2f, 0xcf, 0xf9, 0x9a, 0x59, 0x6f, 0xe3,
-	0x0f, 0x1f, 0xfd, 0x4b, 0x1c, 0x80, 0x39, 0x73, 0x3d, 0xcb, 0x77, 0xe3, 0xea, 0x93, 0x9e, 0x92,
-	0xce, 0x54, 0x70, 0x51, 0xa6, 0x20, 0x74, 0xb2, 0x90, 0x30, 0x63, 0x2d, 0xcf, 0xf1, 0x71, 0xfc,
-	0xe7, 0x5d, 0xbd, 0x97, 0x30, 0xa3, 0xff, 0x88, 0x9b, 0xf2, 0x4c, 0x2a, 0xf6, 0xdb, 0xb3, 0x7c,
-	0x14, 0xd7, 0x03, 0x3d, 0x26, 0x84, 0xe7, 0x0b, 0xa5, 0xa1, 0x14, 0x69, 0xc6, 0x90, 0x59, 0x35,
-	0x14, 0x7a, 0x44, 0x88, 0x58, 0x56, 0x57, 0xd2, 0x49, 0x0a, 0x0c, 0x7b, 0x96, 0xef, 0xc4, 0x78,
-	0xad, 0x5c, 0x00, 0xed, 0x12, 0xcc, 0x95, 0x4e, 0x54, 0x9a, 0x09, 0xcd, 0x88, 0x39, 0x16, 0x71,
-	0xa5, 0x6f, 0xaa, 0xb9, 0xf7, 0x62, 0x13, 0x6c, 0x08, 0x5f, 0xa7, 0xfa, 0xa9, 0x41, 0x19, 0x19,
-	0xca, 0xdd, 0x6d, 0xca, 0xa8, 0x81, 0xb3, 0xbb, 0x8d, 0x13, 0x35, 0xb8, 0x1d, 0x7e, 0xe6, 0x56,
-	0x2d, 0x77, 0x03, 0x42, 0xdf, 0x03, 0xaa, 0x96, 0x3f, 0x04, 0x08, 0xed, 0x01, 0x64, 0x1e, 0xb2,
-	0x01, 0x74, 0x79, 0xfe, 0x10, 0x3c, 0x4a, 0x98, 0x3d, 0x8f, 0x83, 0x49, 0x9e, 0xad, 0xfb, 0x19,
-	0x9a, 0x02, 0x84, 0xa6, 0xa3, 0xe1, 0x76, 0x87, 0xc7, 0x2d, 0xa3, 0x0f, 0xde, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x4f, 0xbb, 0xcc, 0xb0, 0x19, 0x03, 0x00, 0x00,
+	// 640 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0xe3, 0xa6, 0xb5, 0xc7, 0x69, 0x0a, 0x0b, 0x48, 0x2b, 0x5a, 0xa8, 0x89, 0x40, 0x44,
+	0x48, 0x24, 0x55, 0xaa, 0x1e, 0x7a, 0x2c, 0x6a, 0x2b, 0x22, 0x44, 0x55, 0xb9, 0x14, 0x10, 0x17,
+	0xcb, 0xc9, 0x4e, 0xda, 0x6d, 0xe3, 0xb5, 0xe5, 0x5d, 0x37, 0x09, 0xff, 0x83, 0x23, 0x17, 0x7e,
+	0x23, 0x3f, 0x00, 0xed, 0x3a, 0x01, 0x27, 0xfd, 0x80, 0x0b, 0x37, 0xcf, 0x9b, 0x37, 0x3b, 0xe3,
+	0xd9, 0xf7, 0x16, 0x3c, 0x14, 0x2a, 0x9b, 0xb4, 0xd2, 0x2c, 0x51, 0x09, 0xf1, 0x64, 0xca, 0x33,
+	0x6c, 0xa9, 0x49, 0x8a, 0xf2, 0xf1, 0xa6, 0x09, 0x5e, 0x0b, 0x1c, 0xab, 0xb6, 0x41, 0xda, 0x12,
+	0x87, 0xd8, 0x57, 0x49, 0x56, 0xb0, 0x6f, 0x22, 0xa4, 0x7c, 0x30, 0x40, 0xce, 0x0a, 0x42, 0xe3,
+	0xa7, 0x0d, 0xd5, 0x03, 0x7d, 0x3c, 0xa9, 0x43, 0x85, 0x33, 0x6a, 0xf9, 0x56, 0xd3, 0x0d, 0x2a,
+	0x9c, 0x91, 0x0e, 0xb8, 0x05, 0x37, 0xe4, 0x8c, 0x56, 0x7c, 0xab, 0xe9, 0x75, 0x1e, 0xb5, 0x4a,
+	0xcd, 0x5b, 0x27, 0xc7, 0xdd, 0xc3, 0xc3, 0x83, 0xee, 0x7e, 0xe0, 0x14, 0xbc, 0xae, 0xa9, 0x49,
+	0xa3, 0x0c, 0x85, 0xd2, 0x35, 0xf6, 0x9d, 0x35, 0x05, 0xaf, 0xcb, 0xc8, 0x36, 0xb8, 0xb3, 0xa1,
+	0x25, 0x5d, 0xf2, 0xed, 0xeb, 0x35, 0xd3, 0x6c, 0xf0, 0x87, 0x47, 0xee, 0x81, 0xad, 0xd4, 0x90,
+	0x56, 0x7d, 0xab, 0x59, 0x0d, 0xf4, 0x27, 0x79, 0x01, 0xf5, 0x01, 0x32, 0xcc, 0x22, 0x85, 0x32,
+	0x1c, 0x71, 0x75, 0x4e, 0x97, 0x7d, 0xbb, 0xe9, 0x06, 0xab, 0xbf, 0xd1, 0x4f, 0x5c, 0x9d, 0x93,
+	0x87, 0x50, 0x8d, 0x58, 0xcc, 0x05, 0x5d, 0xf1, 0xad, 0xa6, 0x13, 0x14, 0x01, 0x79, 0x0a, 0xc0,
+	0x92, 0x91, 0x90, 0x2a, 0xc3, 0x28, 0xa6, 0x8e, 0x49, 0x95, 0x10, 0xf2, 0x04, 0x00, 0xc7, 0x7a,
+	0x24, 0x19, 0x46, 0x8a, 0xba, 0xbe, 0xd5, 0xb4, 0x03, 0x77, 0x8a, 0xec, 0x29, 0xb2, 0x0e, 0x2e,
+	0x13, 0x32, 0x14, 0x51, 0x8c, 0x92, 0x82, 0x69, 0xeb, 0x30, 0x21, 0x8f, 0x74, 0x4c, 0x5e, 0xc2,
+	0x5a, 0x86, 0x57, 0x5c, 0xf2, 0x44, 0x84, 0x22, 0x8f, 0x7b, 0x98, 0x51, 0xcf, 0x1c, 0x50, 0x9f,
+	0xc1, 0x47, 0x06, 0x25, 0x6f, 0xe1, 0xfe, 0x78, 0x67, 0x6b, 0x37, 0x94, 0x57, 0x9c, 0x85, 0x69,
+	0x96, 0x0c, 0xf8, 0x10, 0x69, 0xcd, 0x2c, 0x71, 0x63, 0x6e, 0x21, 0x9f, 0x77, 0xb6, 0x76, 0x4f,
+	0x3e, 0x76, 0xf7, 0x8f, 0x0b, 0x4e, 0xb0, 0xa6, 0xcb, 0x4e, 0xae, 0x38, 0x9b, 0x02, 0xc4, 0x87,
+	0xda, 0xc5, 0x48, 0x15, 0x07, 0xe9, 0x35, 0xad, 0x9a, 0x35, 0xc1, 0xc5, 0x48, 0x69, 0xd6, 0x07,
+	0x35, 0x6c, 0xfc, 0xb0, 0x60, 0x6d, 0xe1, 0x18, 0xd2, 0x81, 0x15, 0x99, 0xf7, 0x2e, 0xb0, 0xaf,
+	0x8c, 0x0a, 0xbc, 0x0e, 0xbd, 0xde, 0xb5, 0xc8, 0x07, 0x33, 0x22, 0x79, 0x06, 0x35, 0x9e, 0x86,
+	0x11, 0x63, 0x19, 0x4a, 0x89, 0x92, 0x56, 0xcc, 0xcf, 0x7b, 0x3c, 0xdd, 0x9b, 0x41, 0x84, 0xc0,
+	0x52, 0x9e, 0x71, 0x49, 0x6d, 0x93, 0x32, 0xdf, 0xe4, 0x39, 0xd4, 0x71, 0xac, 0xc2, 0x4b, 0x9c,
+	0x84, 0xb9, 0x8c, 0xce, 0xb0, 0xb8, 0x78, 0x37, 0xa8, 0xe1, 0x58, 0xbd, 0xc3, 0xc9, 0xa9, 0xc1,
+	0x1a, 0xdf, 0x2d, 0xf0, 0x4a, 0x5d, 0x09, 0x85, 0x95, 0x7e, 0x92, 0x6b, 0xb1, 0x52, 0xcb, 0xd0,
+	0x67, 0x21, 0x69, 0x40, 0x2d, 0xc9, 0xce, 0x22, 0xc1, 0xbf, 0x46, 0x8a, 0x27, 0x62, 0x3a, 0xc6,
+	0x1c, 0x46, 0xda, 0xf0, 0xa0, 0x1c, 0x47, 0xc3, 0x30, 0x17, 0x5c, 0x4d, 0xc7, 0x22, 0xf3, 0xa9,
+	0x53, 0xc1, 0x15, 0xd9, 0x04, 0xaf, 0x9f, 0xc4, 0xb1, 0xbe, 0xb6, 0x28, 0x46, 0xba, 0x64, 0x9c,
+	0x01, 0x05, 0xa4, 0xaf, 0xb6, 0xf1, 0xcd, 0x06, 0xd7, 0x78, 0xe7, 0x7d, 0x24, 0x2f, 0x4b, 0xfe,
+	0x71, 0x8c, 0x7f, 0xd6, 0x17, 0xfd, 0xe3, 0x94, 0x8c, 0xb2, 0xbe, 0x68, 0x14, 0xa7, 0xe4, 0x88,
+	0x8d, 0x79, 0x47, 0xe8, 0xe4, 0xcd, 0xd2, 0x77, 0x6e, 0x97, 0xbe, 0x4e, 0xfe, 0x27, 0xe9, 0x3b,
+	0x77, 0x48, 0xdf, 0xfc, 0xc8, 0xdf, 0xa4, 0xef, 0x5c, 0x93, 0xfe, 0xab, 0xdb, 0xa4, 0xef, 0xfc,
+	0x9b, 0xb8, 0x9d, 0xb2, 0xb8, 0xdf, 0x6c, 0x7d, 0x69, 0x9d, 0x71, 0x75, 0x9e, 0xf7, 0x5a, 0xfd,
+	0x24, 0x9e, 0x3e, 0x78, 0x6d, 0x23, 0xe5, 0xb6, 0x79, 0xf4, 0xda, 0x8b, 0x8f, 0x62, 0x6f, 0xd9,
+	0xe0, 0xdb, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa5, 0x00, 0xae, 0xfe, 0x6a, 0x05, 0x00, 0x00,
 }
@@ -29,16 +29,26 @@ const (
 	// Indicates that the selectors in this match are a subset of the
 	// candidate selectors.
 	SelectorMatch_MATCH_SUBSET SelectorMatch_MatchBehavior = 1
+	// Indicates that the candidate selectors include at least one of the
+	// selectors in this match.
+	SelectorMatch_MATCH_ANY SelectorMatch_MatchBehavior = 2
+	// Indicates that the candidate selectors include all of the selectors
+	// in this match.
+	SelectorMatch_MATCH_SUPERSET SelectorMatch_MatchBehavior = 3
 )
 
 var SelectorMatch_MatchBehavior_name = map[int32]string{
 	0: "MATCH_EXACT",
 	1: "MATCH_SUBSET",
+	2: "MATCH_ANY",
+	3: "MATCH_SUPERSET",
 }
 
 var SelectorMatch_MatchBehavior_value = map[string]int32{
-	"MATCH_EXACT":  0,
-	"MATCH_SUBSET": 1,
+	"MATCH_EXACT":    0,
+	"MATCH_SUBSET":   1,
+	"MATCH_ANY":      2,
+	"MATCH_SUPERSET": 3,
 }
 
 func (x SelectorMatch_MatchBehavior) String() string {
@@ -157,20 +167,22 @@ func init() {
 func init() { proto.RegisterFile("selector.proto", fileDescriptor_e4729c7385e2dd96) }
 
 var fileDescriptor_e4729c7385e2dd96 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
+	// 258 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2b, 0x4e, 0xcd, 0x49,
 	0x4d, 0x2e, 0xc9, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x2e, 0x2e, 0xc8, 0x2c,
 	0x4a, 0xd5, 0x2b, 0xa9, 0x2c, 0x48, 0x2d, 0x56, 0x32, 0xe1, 0xe2, 0x08, 0x86, 0x4a, 0x0b, 0x09,
 	0x71, 0xb1, 0x80, 0x04, 0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0x21, 0x11, 0x2e,
-	0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x26, 0xb0, 0x20, 0x84, 0xa3, 0xb4, 0x83, 0x91, 0x8b,
+	0xd6, 0xb2, 0xc4, 0x9c, 0xd2, 0x54, 0x09, 0x26, 0xb0, 0x20, 0x84, 0xa3, 0x74, 0x9b, 0x91, 0x8b,
 	0x17, 0xa6, 0xcd, 0x37, 0xb1, 0x24, 0x39, 0x43, 0xc8, 0x98, 0x8b, 0x13, 0x66, 0x4d, 0xb1, 0x04,
 	0xa3, 0x02, 0xb3, 0x06, 0xb7, 0x91, 0xa8, 0x1e, 0x92, 0x45, 0x7a, 0x30, 0xe5, 0x41, 0x08, 0x75,
 	0x42, 0x76, 0x5c, 0xac, 0xb9, 0x20, 0xdd, 0x60, 0xc3, 0xf9, 0x8c, 0x34, 0xb0, 0x6a, 0x00, 0x9b,
-	0xaf, 0x07, 0x26, 0x9d, 0x52, 0x33, 0x12, 0xcb, 0x32, 0xf3, 0x8b, 0x82, 0x20, 0xda, 0x94, 0x8c,
-	0xb8, 0x78, 0x51, 0xc4, 0x85, 0xf8, 0xb9, 0xb8, 0x7d, 0x1d, 0x43, 0x9c, 0x3d, 0xe2, 0x5d, 0x23,
+	0xaf, 0x07, 0x26, 0x9d, 0x52, 0x33, 0x12, 0xcb, 0x32, 0xf3, 0x8b, 0x82, 0x20, 0xda, 0x94, 0x42,
+	0xb9, 0x78, 0x51, 0xc4, 0x85, 0xf8, 0xb9, 0xb8, 0x7d, 0x1d, 0x43, 0x9c, 0x3d, 0xe2, 0x5d, 0x23,
 	0x1c, 0x9d, 0x43, 0x04, 0x18, 0x84, 0x04, 0xb8, 0x78, 0x20, 0x02, 0xc1, 0xa1, 0x4e, 0xc1, 0xae,
-	0x21, 0x02, 0x8c, 0x4e, 0x06, 0x51, 0x7a, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9,
-	0xb9, 0xfa, 0xc5, 0x05, 0x99, 0x69, 0x69, 0xa9, 0xfa, 0x60, 0x7b, 0xf5, 0xc1, 0xc1, 0x03, 0x61,
-	0xeb, 0xe6, 0xa5, 0x56, 0x94, 0xe8, 0x83, 0x1d, 0x92, 0xc4, 0x06, 0x16, 0x37, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x5c, 0x3b, 0x0c, 0x90, 0x48, 0x01, 0x00, 0x00,
+	0x21, 0x02, 0x8c, 0x42, 0xbc, 0x5c, 0x9c, 0x10, 0x11, 0x47, 0xbf, 0x48, 0x01, 0x26, 0x21, 0x21,
+	0x2e, 0x3e, 0x98, 0x82, 0x00, 0xd7, 0x20, 0x90, 0x12, 0x66, 0x27, 0x83, 0x28, 0xbd, 0xf4, 0xcc,
+	0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c, 0xfd, 0xe2, 0x82, 0xcc, 0xb4, 0xb4, 0x54, 0x7d,
+	0xb0, 0xd3, 0xf4, 0xc1, 0x21, 0x08, 0x61, 0xeb, 0xe6, 0xa5, 0x56, 0x94, 0xe8, 0x83, 0xdd, 0x9a,
+	0xc4, 0x06, 0x16, 0x37, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x11, 0x5e, 0xc9, 0x9e, 0x6b, 0x01,
+	0x00, 0x00,
 }
@@ -25,8 +25,48 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Represents an empty request
+//* Types of key pairs the key manager can generate
+type KeyType int32
+
+const (
+	KeyType_UNSPECIFIED_KEY_TYPE KeyType = 0
+	KeyType_EC_P256              KeyType = 1
+	KeyType_EC_P384              KeyType = 2
+	KeyType_RSA_1024             KeyType = 3
+	KeyType_RSA_2048             KeyType = 4
+	KeyType_RSA_4096             KeyType = 5
+)
+
+var KeyType_name = map[int32]string{
+	0: "UNSPECIFIED_KEY_TYPE",
+	1: "EC_P256",
+	2: "EC_P384",
+	3: "RSA_1024",
+	4: "RSA_2048",
+	5: "RSA_4096",
+}
+
+var KeyType_value = map[string]int32{
+	"UNSPECIFIED_KEY_TYPE": 0,
+	"EC_P256":              1,
+	"EC_P384":              2,
+	"RSA_1024":             3,
+	"RSA_2048":             4,
+	"RSA_4096":             5,
+}
+
+func (x KeyType) String() string {
+	return proto.EnumName(KeyType_name, int32(x))
+}
+
+func (KeyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_217d89b504f0b25a, []int{0}
+}
+
+//* Represents a request to generate a key pair
 type GenerateKeyPairRequest struct {
+	//* Type of the key pair. An EC_P256 key pair is generated if unspecified
+	KeyType              KeyType  `protobuf:"varint,1,opt,name=keyType,proto3,enum=spire.agent.keymanager.KeyType" json:"keyType,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -57,11 +97,18 @@ func (m *GenerateKeyPairRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GenerateKeyPairRequest proto.InternalMessageInfo
 
+func (m *GenerateKeyPairRequest) GetKeyType() KeyType {
+	if m != nil {
+		return m.KeyType
+	}
+	return KeyType_UNSPECIFIED_KEY_TYPE
+}
+
 //* Represents a public and private key pair
 type GenerateKeyPairResponse struct {
-	//* Public key
+	//* Public key, in PKIX ASN.1 DER form
 	PublicKey []byte `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
-	//* Private key
+	//* Private key, in PKCS #8 ASN.1 DER form
 	PrivateKey           []byte   `protobuf:"bytes,2,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -109,7 +156,7 @@ func (m *GenerateKeyPairResponse) GetPrivateKey() []byte {
 
 //* Represents a private key
 type StorePrivateKeyRequest struct {
-	//* Private key
+	//* Private key, in PKCS #8 ASN.1 DER form
 	PrivateKey           []byte   `protobuf:"bytes,1,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -214,7 +261,8 @@ var xxx_messageInfo_FetchPrivateKeyRequest proto.InternalMessageInfo
 
 //* Represents a private key
 type FetchPrivateKeyResponse struct {
-	//* Private key
+	//* Private key, in PKCS #8 ASN.1 DER form. Keys stored by previous
+	//versions may be EC private keys in SEC 1 ASN.1 DER form
 	PrivateKey           []byte   `protobuf:"bytes,1,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -254,6 +302,7 @@ func (m *FetchPrivateKeyResponse) GetPrivateKey() []byte {
 }
 
 func init() {
+	proto.RegisterEnum("spire.agent.keymanager.KeyType", KeyType_name, KeyType_value)
 	proto.RegisterType((*GenerateKeyPairRequest)(nil), "spire.agent.keymanager.GenerateKeyPairRequest")
 	proto.RegisterType((*GenerateKeyPairResponse)(nil), "spire.agent.keymanager.GenerateKeyPairResponse")
 	proto.RegisterType((*StorePrivateKeyRequest)(nil), "spire.agent.keymanager.StorePrivateKeyRequest")
@@ -265,29 +314,36 @@ func init() {
 func init() { proto.RegisterFile("keymanager.proto", fileDescriptor_217d89b504f0b25a) }
 
 var fileDescriptor_217d89b504f0b25a = []byte{
-	// 346 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0x99, 0x88, 0xb0, 0x87, 0x32, 0xc9, 0x61, 0x9b, 0x43, 0x64, 0x14, 0x14, 0xf5, 0x90,
-	0x82, 0x8a, 0xe8, 0x55, 0xc1, 0x21, 0x43, 0x18, 0xf3, 0x20, 0xec, 0xd6, 0x8d, 0xd7, 0x2e, 0xb8,
-	0x25, 0x31, 0x4d, 0x85, 0xfe, 0x4f, 0xfe, 0x91, 0xb2, 0x24, 0x5b, 0xb5, 0x6d, 0xd8, 0x4e, 0x2d,
-	0xef, 0x7b, 0x5f, 0xbe, 0xbc, 0x5f, 0x12, 0x38, 0xfe, 0xc4, 0x7c, 0x19, 0xf1, 0x28, 0x41, 0x45,
-	0xa5, 0x12, 0x5a, 0x90, 0x76, 0x2a, 0x99, 0x42, 0x1a, 0x25, 0xc8, 0x35, 0x2d, 0xd4, 0x5e, 0xdf,
-	0xd4, 0xc3, 0x99, 0x58, 0x2e, 0x05, 0x0f, 0xe5, 0x22, 0x4b, 0xd8, 0xfa, 0x63, 0x9d, 0x41, 0x17,
-	0xda, 0x03, 0xe4, 0xa8, 0x22, 0x8d, 0x43, 0xcc, 0x47, 0x11, 0x53, 0x63, 0xfc, 0xca, 0x30, 0xd5,
-	0xc1, 0x07, 0x74, 0x2a, 0x4a, 0x2a, 0x05, 0x4f, 0x91, 0x9c, 0x42, 0x53, 0x66, 0xd3, 0x05, 0x9b,
-	0x0d, 0x31, 0xef, 0x36, 0xfa, 0x8d, 0xcb, 0xc3, 0x71, 0x51, 0x20, 0x67, 0x00, 0x52, 0xb1, 0x6f,
-	0xeb, 0xeb, 0xee, 0x19, 0xf9, 0x4f, 0x25, 0x78, 0x80, 0xf6, 0xbb, 0x16, 0x0a, 0x47, 0x9b, 0x92,
-	0x8b, 0x2c, 0x39, 0x1b, 0x15, 0xe7, 0x09, 0x74, 0x2a, 0x4e, 0xbb, 0xa5, 0xd5, 0x1c, 0x2f, 0xa8,
-	0x67, 0xf3, 0xca, 0xa2, 0xc1, 0x23, 0x74, 0x2a, 0x8a, 0x9b, 0x63, 0x4b, 0xde, 0xcd, 0xcf, 0x3e,
-	0xc0, 0x10, 0xf3, 0x37, 0x4b, 0x93, 0x28, 0x68, 0x95, 0x88, 0x10, 0x4a, 0xeb, 0xc9, 0xd3, 0x7a,
-	0xa8, 0xbd, 0x70, 0xe7, 0x7e, 0xb7, 0x45, 0x05, 0xad, 0xd2, 0xc8, 0xfe, 0xcc, 0x7a, 0xaa, 0xfe,
-	0x4c, 0x0f, 0xcb, 0x55, 0x66, 0x89, 0x98, 0x3f, 0xb3, 0x1e, 0xba, 0x3f, 0xd3, 0x77, 0x14, 0x13,
-	0x68, 0x3e, 0x0b, 0x1e, 0xb3, 0x24, 0x53, 0x48, 0xce, 0x9d, 0xdb, 0xde, 0x5b, 0xea, 0x2e, 0xec,
-	0x46, 0x5f, 0x87, 0x5c, 0x6c, 0x6b, 0x73, 0x6b, 0xc7, 0x70, 0x34, 0x40, 0x3d, 0x32, 0xf2, 0x2b,
-	0x8f, 0x05, 0xb9, 0xaa, 0x35, 0xfe, 0xeb, 0x59, 0x67, 0x5c, 0xef, 0xd2, 0x6a, 0x73, 0x9e, 0xee,
-	0x27, 0x77, 0x09, 0xd3, 0xf3, 0x6c, 0xba, 0xea, 0x0e, 0x53, 0xc9, 0xe2, 0x18, 0x43, 0xfb, 0x02,
-	0xcd, 0x63, 0x73, 0xff, 0x86, 0x49, 0x58, 0x30, 0x99, 0x1e, 0x18, 0xf5, 0xf6, 0x37, 0x00, 0x00,
-	0xff, 0xff, 0x02, 0xae, 0x23, 0x7c, 0xd8, 0x03, 0x00, 0x00,
+	// 452 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x61, 0x6f, 0xd3, 0x30,
+	0x10, 0xa5, 0x63, 0x50, 0x76, 0x0c, 0x16, 0x59, 0xa8, 0x2d, 0x13, 0x82, 0x29, 0x12, 0x08, 0xf6,
+	0x21, 0x29, 0x59, 0xa8, 0xda, 0x8f, 0x50, 0xb2, 0xa9, 0x8a, 0x40, 0x51, 0x3b, 0x84, 0xb6, 0x2f,
+	0x55, 0x5a, 0x5d, 0x32, 0x6b, 0x6b, 0x6c, 0x1c, 0x07, 0x29, 0xff, 0x89, 0x1f, 0x89, 0x16, 0xc7,
+	0xeb, 0xd6, 0x24, 0xda, 0x3e, 0xb5, 0x77, 0xf7, 0xde, 0x3d, 0xe7, 0x3d, 0xd9, 0x60, 0x5c, 0x62,
+	0xbe, 0x0a, 0x93, 0x30, 0x46, 0x61, 0x71, 0xc1, 0x24, 0x23, 0x9d, 0x94, 0x53, 0x81, 0x56, 0x18,
+	0x63, 0x22, 0xad, 0xf5, 0x74, 0xff, 0xa0, 0xe8, 0xdb, 0x4b, 0xb6, 0x5a, 0xb1, 0xc4, 0xe6, 0x57,
+	0x59, 0x4c, 0xf5, 0x8f, 0x62, 0x9a, 0x33, 0xe8, 0x9c, 0x60, 0x82, 0x22, 0x94, 0xe8, 0x63, 0x1e,
+	0x84, 0x54, 0x4c, 0xf1, 0x4f, 0x86, 0xa9, 0x24, 0x23, 0x68, 0x5f, 0x62, 0x7e, 0x9a, 0x73, 0xec,
+	0xb5, 0x0e, 0x5a, 0x1f, 0x5f, 0x3a, 0xef, 0xac, 0x7a, 0x15, 0xcb, 0x57, 0xb0, 0xa9, 0xc6, 0x9b,
+	0xbf, 0xa1, 0x5b, 0x59, 0x9a, 0x72, 0x96, 0xa4, 0x48, 0xde, 0xc0, 0x0e, 0xcf, 0x16, 0x57, 0x74,
+	0xe9, 0x63, 0x5e, 0xec, 0xdd, 0x9d, 0xae, 0x1b, 0xe4, 0x2d, 0x00, 0x17, 0xf4, 0xaf, 0xe2, 0xf5,
+	0xb6, 0x8a, 0xf1, 0xad, 0x8e, 0x39, 0x84, 0xce, 0x4c, 0x32, 0x81, 0xc1, 0x4d, 0x4b, 0x9f, 0xf6,
+	0x2e, 0xb3, 0x55, 0x61, 0xbe, 0x86, 0x6e, 0x85, 0xa9, 0x8e, 0x64, 0xf6, 0xa0, 0x73, 0x8c, 0x72,
+	0x79, 0x51, 0x59, 0x6a, 0x8e, 0xa0, 0x5b, 0x99, 0x94, 0xdf, 0x71, 0x8f, 0xde, 0x61, 0x0c, 0xed,
+	0xd2, 0x16, 0xd2, 0x83, 0x57, 0xbf, 0x7e, 0xce, 0x02, 0x6f, 0x3c, 0x39, 0x9e, 0x78, 0xdf, 0xe7,
+	0xbe, 0x77, 0x36, 0x3f, 0x3d, 0x0b, 0x3c, 0xe3, 0x11, 0x79, 0x0e, 0x6d, 0x6f, 0x3c, 0x0f, 0x9c,
+	0x2f, 0x03, 0xa3, 0xa5, 0x8b, 0xa3, 0xa1, 0x6b, 0x6c, 0x91, 0x5d, 0x78, 0x36, 0x9d, 0x7d, 0x9d,
+	0x7f, 0xee, 0x3b, 0xae, 0xf1, 0x58, 0x57, 0x4e, 0xdf, 0x1d, 0x1a, 0xdb, 0xba, 0x72, 0xfb, 0xa3,
+	0x81, 0xf1, 0xc4, 0xf9, 0xb7, 0x0d, 0xe0, 0x63, 0xfe, 0x43, 0x65, 0x41, 0x04, 0xec, 0x6d, 0x58,
+	0x4f, 0xac, 0xa6, 0xdc, 0xea, 0x83, 0xdf, 0xb7, 0x1f, 0x8c, 0x2f, 0xbd, 0x10, 0xb0, 0xb7, 0xe1,
+	0x6d, 0xb3, 0x66, 0x7d, 0x7c, 0xcd, 0x9a, 0x0d, 0xa1, 0x5d, 0x6b, 0x6e, 0x44, 0xd3, 0xac, 0x59,
+	0x9f, 0x6e, 0xb3, 0x66, 0x53, 0xe6, 0xe7, 0xb0, 0x33, 0x66, 0x49, 0x44, 0xe3, 0x4c, 0x20, 0x79,
+	0x5f, 0xb2, 0xd5, 0xdd, 0xb2, 0xca, 0x4b, 0x75, 0x33, 0xd7, 0x22, 0x1f, 0xee, 0x83, 0x95, 0xbb,
+	0x23, 0x78, 0x71, 0x82, 0x32, 0x28, 0xc6, 0x93, 0x24, 0x62, 0xe4, 0x53, 0x2d, 0xf1, 0x0e, 0x46,
+	0x6b, 0x1c, 0x3e, 0x04, 0xaa, 0x74, 0xbe, 0x0d, 0xce, 0xdd, 0x98, 0xca, 0x8b, 0x6c, 0x71, 0x8d,
+	0xb6, 0x53, 0x4e, 0xa3, 0x08, 0x6d, 0xf5, 0x4a, 0x14, 0x0f, 0x42, 0xf9, 0xbf, 0xf0, 0xc4, 0x5e,
+	0x7b, 0xb2, 0x78, 0x5a, 0x4c, 0x8f, 0xfe, 0x07, 0x00, 0x00, 0xff, 0xff, 0x9e, 0x52, 0x66, 0x2b,
+	0x7c, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -319,10 +319,14 @@ type AttestRequest struct {
 	// Certificate signing request.
 	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
 	// Attestation challenge response
-	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Response []byte `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	// Version of the agent software
+	AgentVersion string `protobuf:"bytes,4,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	// Plugins loaded by the agent
+	AgentPlugins         []*common.AgentPlugin `protobuf:"bytes,5,rep,name=agent_plugins,json=agentPlugins,proto3" json:"agent_plugins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *AttestRequest) Reset()         { *m = AttestRequest{} }
@@ -371,6 +375,20 @@ func (m *AttestRequest) GetResponse() []byte {
 	return nil
 }
 
+func (m *AttestRequest) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *AttestRequest) GetAgentPlugins() []*common.AgentPlugin {
+	if m != nil {
+		return m.AgentPlugins
+	}
+	return nil
+}
+
 // Represents a response that contains  map of signed SVIDs and an array of
 // all current Registration Entries which are relevant to the caller SPIFFE ID
 type AttestResponse struct {
@@ -859,66 +877,68 @@ func init() {
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 933 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5d, 0x6f, 0x1a, 0x47,
-	0x14, 0xd5, 0x7a, 0x0d, 0x86, 0x0b, 0xc1, 0xd6, 0x98, 0x36, 0x78, 0x5b, 0xa7, 0xd6, 0x26, 0x69,
-	0x5c, 0xc7, 0x5a, 0x5b, 0x8e, 0xaa, 0x7e, 0xa8, 0x52, 0x84, 0x31, 0x55, 0x62, 0xd4, 0x0a, 0x0d,
-	0x49, 0x9a, 0xb6, 0x0f, 0x74, 0xd8, 0x9d, 0xe0, 0xb1, 0xf1, 0x2e, 0xdd, 0x99, 0x8d, 0xcb, 0x2f,
-	0xe8, 0x7b, 0xff, 0x4d, 0xa5, 0xfe, 0xb8, 0x6a, 0x3e, 0x16, 0x76, 0x31, 0x98, 0x3c, 0xe4, 0x89,
-	0x9d, 0x3b, 0xe7, 0x9e, 0x7b, 0xef, 0xd9, 0x33, 0xc3, 0x02, 0x84, 0x51, 0x40, 0xbd, 0x71, 0x1c,
-	0x89, 0x08, 0xd5, 0xf8, 0x98, 0xc5, 0xd4, 0x23, 0x63, 0xe6, 0xc9, 0xa8, 0xb3, 0xa3, 0xd6, 0x47,
-	0x7e, 0x74, 0x7d, 0x1d, 0x85, 0xe6, 0x47, 0x43, 0xdd, 0x67, 0x50, 0x3c, 0x4d, 0xc2, 0x60, 0x44,
-	0x51, 0x0d, 0xd6, 0x58, 0xd0, 0xb0, 0xf6, 0xac, 0xfd, 0x32, 0x5e, 0x63, 0x01, 0xda, 0x81, 0x92,
-	0x4f, 0xfa, 0x3e, 0x8d, 0x05, 0x6f, 0xac, 0xed, 0x59, 0xfb, 0x55, 0xbc, 0xe1, 0x93, 0x96, 0x5c,
-	0xba, 0x2f, 0xa0, 0xf4, 0xf6, 0xeb, 0xe3, 0xef, 0x7a, 0x6f, 0x5e, 0x9e, 0xa1, 0x5d, 0x00, 0x89,
-	0xe9, 0xfb, 0x17, 0x84, 0x85, 0x0d, 0x5b, 0x01, 0xcb, 0x32, 0xd2, 0x92, 0x01, 0xb9, 0x4d, 0xff,
-	0x92, 0xd5, 0x79, 0x9f, 0x08, 0xc5, 0x63, 0xe3, 0xb2, 0x89, 0x34, 0x85, 0xfb, 0x8f, 0x0d, 0xb5,
-	0x94, 0xea, 0xf5, 0x38, 0x20, 0x82, 0xa2, 0xe7, 0x50, 0xe0, 0xef, 0x59, 0xc0, 0x1b, 0xd6, 0x9e,
-	0xbd, 0x5f, 0x39, 0xf9, 0xca, 0xcb, 0x0f, 0xe3, 0xe5, 0xe1, 0x5e, 0x4f, 0x62, 0xdb, 0xa1, 0x88,
-	0x27, 0x58, 0xe7, 0x21, 0x0c, 0xf5, 0x98, 0x0e, 0x19, 0x17, 0x31, 0x11, 0x2c, 0x0a, 0xfb, 0x34,
-	0x14, 0x31, 0xa3, 0xbc, 0x61, 0x2b, 0xbe, 0x2f, 0x0c, 0x9f, 0x51, 0x01, 0x67, 0x90, 0x9a, 0x65,
-	0x3b, 0x9e, 0x0b, 0x31, 0xca, 0x51, 0x1b, 0x36, 0x06, 0x4a, 0x26, 0xde, 0x28, 0x28, 0x9a, 0xa7,
-	0x2b, 0xda, 0xd2, 0xa2, 0x9a, 0xc6, 0xd2, 0x5c, 0x07, 0x03, 0xcc, 0xfa, 0x45, 0x5b, 0x60, 0x5f,
-	0xd1, 0x89, 0x91, 0x5c, 0x3e, 0x22, 0x0f, 0x0a, 0xef, 0xc9, 0x28, 0xa1, 0x4a, 0xa8, 0xca, 0x49,
-	0x63, 0x59, 0x11, 0xac, 0x61, 0xdf, 0xaf, 0x7d, 0x6b, 0x39, 0x5d, 0xa8, 0x66, 0x8b, 0x2d, 0x60,
-	0x3d, 0xc8, 0xb3, 0xd6, 0xf3, 0x0a, 0xe8, 0xe4, 0x0c, 0xa3, 0xdb, 0x05, 0xfb, 0xbc, 0x87, 0xd1,
-	0x67, 0x50, 0xe6, 0x63, 0xf6, 0xee, 0x1d, 0xed, 0x4f, 0x7d, 0x51, 0xd2, 0x81, 0x97, 0x01, 0x72,
-	0xa0, 0x44, 0x92, 0x80, 0xd1, 0xd0, 0x97, 0xb4, 0xb6, 0xdc, 0x4b, 0xd7, 0xb2, 0x03, 0x21, 0x46,
-	0xca, 0x0b, 0x05, 0x2c, 0x1f, 0xdd, 0xdf, 0x61, 0xe3, 0xfc, 0x97, 0x57, 0xca, 0x2f, 0x75, 0x28,
-	0x88, 0xe8, 0x8a, 0x86, 0x86, 0x51, 0x2f, 0x56, 0xd8, 0x44, 0xb6, 0xc2, 0x38, 0x4f, 0x68, 0x20,
-	0x77, 0x6d, 0xb5, 0x5b, 0xd2, 0x81, 0xa6, 0x70, 0xff, 0xb6, 0xe0, 0x5e, 0x53, 0x08, 0xca, 0x05,
-	0xa6, 0x7f, 0x26, 0x94, 0x0b, 0xf4, 0x02, 0xb6, 0x88, 0x0a, 0x68, 0x03, 0x04, 0x44, 0x10, 0x55,
-	0xae, 0x72, 0xb2, 0x9b, 0x9f, 0xbd, 0x39, 0x43, 0x9d, 0x11, 0x41, 0xf0, 0x26, 0xc9, 0x07, 0xe4,
-	0x28, 0x3e, 0x8f, 0x8d, 0xff, 0xe5, 0xa3, 0x1c, 0x3c, 0xa6, 0x7c, 0x1c, 0x85, 0x9c, 0x1a, 0xb7,
-	0x4f, 0xd7, 0x6e, 0x04, 0xb5, 0xb4, 0x11, 0x1d, 0x41, 0xcf, 0xa1, 0x22, 0x4d, 0xd9, 0x4f, 0x94,
-	0x2b, 0x4c, 0x13, 0x0f, 0xee, 0xf6, 0x0e, 0x06, 0x99, 0x62, 0x4e, 0xc3, 0xe7, 0x50, 0xf6, 0x2f,
-	0xc8, 0x68, 0x44, 0xc3, 0x21, 0x35, 0x6d, 0xcc, 0x02, 0xee, 0x7f, 0x16, 0xd4, 0x7f, 0xa4, 0xc2,
-	0xbf, 0x98, 0x1a, 0xc3, 0x28, 0xf0, 0x04, 0x36, 0xcf, 0xda, 0x5d, 0xdc, 0x6e, 0x35, 0x5f, 0xb5,
-	0xcf, 0xfa, 0x3e, 0x8f, 0xb9, 0x7a, 0x4b, 0x55, 0x5c, 0x9b, 0x85, 0x5b, 0x3c, 0xe6, 0xe8, 0x14,
-	0xd6, 0xd5, 0xae, 0x3e, 0x1c, 0xde, 0x7c, 0x67, 0x8b, 0xc8, 0x3d, 0x99, 0xa8, 0x8d, 0xad, 0x72,
-	0x9d, 0x6f, 0xa0, 0x3c, 0x0d, 0x2d, 0xb0, 0x5f, 0x3d, 0x6b, 0xbf, 0x6a, 0xd6, 0x68, 0x6f, 0xe1,
-	0x93, 0xb9, 0x02, 0x1f, 0x49, 0x36, 0xf7, 0x07, 0xd8, 0x56, 0xcc, 0xc6, 0x75, 0xa9, 0x2c, 0x8f,
-	0xc1, 0xbe, 0xe4, 0xb1, 0xe1, 0xdb, 0x9e, 0xe7, 0x3b, 0xef, 0x61, 0x2c, 0xf7, 0xdd, 0x96, 0x51,
-	0x75, 0x9a, 0x6d, 0xda, 0x7a, 0x0a, 0xeb, 0xb2, 0x86, 0xc9, 0xbf, 0x7f, 0x2b, 0xdf, 0xc0, 0x15,
-	0xc8, 0x3d, 0x80, 0x4f, 0xa7, 0xc3, 0xb5, 0x9a, 0xd9, 0x2e, 0x8c, 0xa9, 0xac, 0xa9, 0xa9, 0xdc,
-	0x04, 0xee, 0xdf, 0xc2, 0x9a, 0x9a, 0x87, 0xb9, 0x9a, 0xcb, 0x6f, 0x04, 0x85, 0x42, 0x87, 0x50,
-	0xd4, 0x77, 0xcd, 0x9d, 0x67, 0xdd, 0x60, 0xdc, 0x9f, 0x60, 0xa7, 0x9b, 0x70, 0x39, 0x66, 0x87,
-	0x4e, 0x5e, 0x8f, 0xb9, 0x88, 0x29, 0xb9, 0x4e, 0xbb, 0x3c, 0x86, 0x8d, 0xcb, 0x1b, 0xd1, 0x4f,
-	0x5f, 0xe6, 0x6c, 0x5e, 0xc3, 0xd5, 0x4d, 0x06, 0x23, 0xe6, 0x77, 0xe8, 0x04, 0x17, 0x2f, 0x6f,
-	0x44, 0x87, 0x4e, 0xdc, 0x3e, 0x38, 0x8b, 0xe8, 0xcc, 0x20, 0x4d, 0xd8, 0x92, 0x7c, 0x9c, 0x0d,
-	0x43, 0x16, 0x0e, 0x25, 0x6f, 0x7a, 0xc5, 0x2f, 0x25, 0xae, 0x5d, 0xde, 0x88, 0x9e, 0xc6, 0x77,
-	0xe8, 0x84, 0xbb, 0x75, 0x40, 0x4a, 0x26, 0x33, 0x86, 0x6e, 0xd4, 0x6d, 0x99, 0x77, 0x9d, 0x46,
-	0xa7, 0xc2, 0xa5, 0x52, 0x58, 0xab, 0xa5, 0x38, 0xf9, 0x77, 0x1d, 0xd6, 0x7f, 0x8e, 0x02, 0x8a,
-	0x3a, 0x50, 0xd4, 0x67, 0x18, 0xed, 0xce, 0x6b, 0x9d, 0xbb, 0x64, 0x9c, 0x07, 0xcb, 0xb6, 0x75,
-	0xfd, 0x7d, 0xeb, 0xd8, 0x42, 0x7f, 0xc0, 0xbd, 0x9c, 0xc1, 0xd1, 0xa3, 0x0f, 0x39, 0x60, 0xce,
-	0xe3, 0x15, 0xa8, 0x4c, 0x85, 0x5f, 0xa1, 0x9a, 0xb5, 0x2a, 0x7a, 0xb8, 0x30, 0x35, 0x7f, 0x0c,
-	0x9c, 0x47, 0x77, 0x83, 0x8c, 0x80, 0x03, 0xd8, 0x9c, 0x33, 0x25, 0xfa, 0x72, 0x69, 0x63, 0x39,
-	0x87, 0x3b, 0x4f, 0x56, 0xe2, 0x4c, 0x8d, 0x2b, 0x40, 0xb7, 0x2d, 0x83, 0x6e, 0xfd, 0xe7, 0x2f,
-	0x75, 0xa9, 0x73, 0xf0, 0x21, 0x50, 0x53, 0xec, 0x0d, 0x54, 0x32, 0x46, 0x41, 0xee, 0xc2, 0x26,
-	0x73, 0xde, 0x72, 0x1e, 0xde, 0x89, 0xd1, 0xbc, 0xa7, 0xde, 0x6f, 0x87, 0x43, 0x26, 0x2e, 0x92,
-	0x81, 0xf4, 0xd6, 0x91, 0xfe, 0x8b, 0x3c, 0xd2, 0x9f, 0x5c, 0xea, 0x23, 0xcb, 0x3c, 0x93, 0x31,
-	0x3b, 0x92, 0x1c, 0x83, 0xa2, 0x8a, 0x3e, 0xfb, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x22, 0xfc, 0x6f,
-	0xa7, 0xb3, 0x09, 0x00, 0x00,
+	// 976 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xed, 0x4e, 0xe3, 0x46,
+	0x14, 0x95, 0x31, 0x09, 0xc9, 0x4d, 0x08, 0x68, 0x48, 0xbb, 0xc1, 0x2d, 0x5b, 0x64, 0x76, 0xbb,
+	0x94, 0x45, 0x06, 0xb1, 0xaa, 0xfa, 0xa1, 0xaa, 0xab, 0x10, 0x52, 0xed, 0x12, 0xb5, 0x8a, 0x9c,
+	0x5d, 0xba, 0x6d, 0x7f, 0xb8, 0x13, 0x7b, 0x36, 0x0c, 0x04, 0x3b, 0xf5, 0x8c, 0xa1, 0x79, 0x8d,
+	0xbe, 0x4d, 0xa5, 0x3e, 0x52, 0x1f, 0xa2, 0x9a, 0x0f, 0x27, 0x76, 0x48, 0xc8, 0xfe, 0xe8, 0xaf,
+	0x78, 0xee, 0x9c, 0x7b, 0xee, 0xbd, 0x27, 0x67, 0xc6, 0x06, 0x08, 0xa3, 0x80, 0x38, 0xa3, 0x38,
+	0xe2, 0x11, 0xaa, 0xb1, 0x11, 0x8d, 0x89, 0x83, 0x47, 0xd4, 0x11, 0x51, 0x6b, 0x5b, 0xae, 0x8f,
+	0xfc, 0xe8, 0xe6, 0x26, 0x0a, 0xf5, 0x8f, 0x82, 0xda, 0x2f, 0xa0, 0x78, 0x9a, 0x84, 0xc1, 0x90,
+	0xa0, 0x1a, 0xac, 0xd0, 0xa0, 0x61, 0xec, 0x1a, 0xfb, 0x65, 0x77, 0x85, 0x06, 0x68, 0x1b, 0x4a,
+	0x3e, 0xf6, 0x7c, 0x12, 0x73, 0xd6, 0x58, 0xd9, 0x35, 0xf6, 0xab, 0xee, 0x9a, 0x8f, 0x5b, 0x62,
+	0x69, 0xbf, 0x82, 0xd2, 0xbb, 0x2f, 0x8f, 0xbf, 0xe9, 0x5d, 0xbc, 0x3e, 0x43, 0x3b, 0x00, 0x02,
+	0xe3, 0xf9, 0x97, 0x98, 0x86, 0x0d, 0x53, 0x02, 0xcb, 0x22, 0xd2, 0x12, 0x01, 0xb1, 0x4d, 0xfe,
+	0x14, 0xd5, 0x99, 0x87, 0xb9, 0xe4, 0x31, 0xdd, 0xb2, 0x8e, 0x34, 0xb9, 0xfd, 0x97, 0x09, 0xb5,
+	0x94, 0xea, 0xed, 0x28, 0xc0, 0x9c, 0xa0, 0x97, 0x50, 0x60, 0xb7, 0x34, 0x60, 0x0d, 0x63, 0xd7,
+	0xdc, 0xaf, 0x9c, 0x7c, 0xe1, 0xe4, 0x87, 0x71, 0xf2, 0x70, 0xa7, 0x27, 0xb0, 0xed, 0x90, 0xc7,
+	0x63, 0x57, 0xe5, 0x21, 0x17, 0xea, 0x31, 0x19, 0x50, 0xc6, 0x63, 0xcc, 0x69, 0x14, 0x7a, 0x24,
+	0xe4, 0x31, 0x25, 0xac, 0x61, 0x4a, 0xbe, 0xcf, 0x34, 0x9f, 0x56, 0xc1, 0xcd, 0x20, 0x15, 0xcb,
+	0x56, 0x3c, 0x13, 0xa2, 0x84, 0xa1, 0x36, 0xac, 0xf5, 0xa5, 0x4c, 0xac, 0x51, 0x90, 0x34, 0xcf,
+	0x97, 0xb4, 0xa5, 0x44, 0xd5, 0x8d, 0xa5, 0xb9, 0x96, 0x0b, 0x30, 0xed, 0x17, 0x6d, 0x82, 0x79,
+	0x4d, 0xc6, 0x5a, 0x72, 0xf1, 0x88, 0x1c, 0x28, 0xdc, 0xe2, 0x61, 0x42, 0xa4, 0x50, 0x95, 0x93,
+	0xc6, 0xa2, 0x22, 0xae, 0x82, 0x7d, 0xbb, 0xf2, 0xb5, 0x61, 0x75, 0xa1, 0x9a, 0x2d, 0x36, 0x87,
+	0xf5, 0x20, 0xcf, 0x5a, 0xcf, 0x2b, 0xa0, 0x92, 0x33, 0x8c, 0x76, 0x17, 0xcc, 0xf3, 0x9e, 0x8b,
+	0x3e, 0x81, 0x32, 0x1b, 0xd1, 0xf7, 0xef, 0x89, 0x37, 0xf1, 0x45, 0x49, 0x05, 0x5e, 0x07, 0xc8,
+	0x82, 0x12, 0x4e, 0x02, 0x4a, 0x42, 0x5f, 0xd0, 0x9a, 0x62, 0x2f, 0x5d, 0x8b, 0x0e, 0x38, 0x1f,
+	0x4a, 0x2f, 0x14, 0x5c, 0xf1, 0x68, 0xff, 0x06, 0x6b, 0xe7, 0x3f, 0xbf, 0x91, 0x7e, 0xa9, 0x43,
+	0x81, 0x47, 0xd7, 0x24, 0xd4, 0x8c, 0x6a, 0xb1, 0xc4, 0x26, 0xa2, 0x15, 0xca, 0x58, 0x42, 0x02,
+	0xb1, 0x6b, 0xca, 0xdd, 0x92, 0x0a, 0x34, 0xb9, 0xfd, 0xaf, 0x01, 0xeb, 0x4d, 0xce, 0x09, 0xe3,
+	0x2e, 0xf9, 0x23, 0x21, 0x8c, 0xa3, 0x57, 0xb0, 0x89, 0x65, 0x40, 0x19, 0x20, 0xc0, 0x1c, 0xcb,
+	0x72, 0x95, 0x93, 0x9d, 0xfc, 0xec, 0xcd, 0x29, 0xea, 0x0c, 0x73, 0xec, 0x6e, 0xe0, 0x7c, 0x40,
+	0x8c, 0xe2, 0xb3, 0x58, 0xfb, 0x5f, 0x3c, 0x8a, 0xc1, 0x63, 0xc2, 0x46, 0x51, 0xc8, 0x88, 0x76,
+	0xfb, 0x64, 0x8d, 0xf6, 0x60, 0x1d, 0x0f, 0x48, 0xc8, 0xbd, 0x5b, 0x12, 0x33, 0x1a, 0x85, 0x8d,
+	0x55, 0x39, 0x63, 0x55, 0x06, 0x2f, 0x54, 0x0c, 0x7d, 0x9f, 0x82, 0x46, 0xc3, 0x64, 0x40, 0xc3,
+	0xd4, 0x50, 0xdb, 0x33, 0x9d, 0x09, 0x48, 0x57, 0x22, 0x74, 0xbe, 0x5a, 0x30, 0x3b, 0x82, 0x5a,
+	0x3a, 0xad, 0x2e, 0xfb, 0x12, 0x2a, 0xc2, 0xf9, 0x5e, 0x22, 0xad, 0xa7, 0x27, 0x7d, 0xfc, 0xb0,
+	0x41, 0x5d, 0x10, 0x29, 0xfa, 0xc8, 0x7d, 0x0a, 0x65, 0xff, 0x12, 0x0f, 0x87, 0x24, 0x1c, 0x10,
+	0x3d, 0xeb, 0x34, 0x60, 0xff, 0x63, 0x40, 0xfd, 0x07, 0xc2, 0xfd, 0xcb, 0x89, 0xfb, 0xb4, 0xcc,
+	0xcf, 0x60, 0xe3, 0xac, 0xdd, 0x75, 0xdb, 0xad, 0xe6, 0x9b, 0xf6, 0x99, 0xe7, 0xb3, 0x98, 0x49,
+	0x2b, 0x54, 0xdd, 0xda, 0x34, 0xdc, 0x62, 0x31, 0x43, 0xa7, 0xb0, 0x2a, 0x77, 0xd5, 0x09, 0x74,
+	0x66, 0x3b, 0x9b, 0x47, 0xee, 0x88, 0x44, 0x75, 0x7a, 0x64, 0xae, 0xf5, 0x15, 0x94, 0x27, 0xa1,
+	0x39, 0x1e, 0xaf, 0x67, 0x3d, 0x5e, 0xcd, 0xba, 0xf9, 0x1d, 0x7c, 0x34, 0x53, 0xe0, 0x7f, 0x92,
+	0xcd, 0xfe, 0x0e, 0xb6, 0x24, 0xb3, 0xb6, 0x76, 0x2a, 0xcb, 0x53, 0x30, 0xaf, 0x58, 0xac, 0xf9,
+	0xb6, 0x66, 0xf9, 0xce, 0x7b, 0xae, 0x2b, 0xf6, 0xed, 0x96, 0x56, 0x75, 0x92, 0xad, 0xdb, 0x7a,
+	0x0e, 0xab, 0xa2, 0x86, 0xce, 0x7f, 0x74, 0x2f, 0x5f, 0xc3, 0x25, 0xc8, 0x3e, 0x80, 0x8f, 0x27,
+	0xc3, 0xb5, 0x9a, 0xd9, 0x2e, 0xb4, 0x73, 0x8d, 0x89, 0x73, 0xed, 0x04, 0x1e, 0xdd, 0xc3, 0xea,
+	0x9a, 0x87, 0xb9, 0x9a, 0x8b, 0xaf, 0x1d, 0x89, 0x42, 0x87, 0x50, 0x54, 0x17, 0xda, 0x83, 0x17,
+	0x8a, 0xc6, 0xd8, 0x3f, 0xc2, 0x76, 0x37, 0x61, 0x62, 0xcc, 0x0e, 0x19, 0xbf, 0x1d, 0x31, 0x1e,
+	0x13, 0x7c, 0x93, 0x76, 0x79, 0x0c, 0x6b, 0x57, 0x77, 0xdc, 0x4b, 0xff, 0xcc, 0xe9, 0xbc, 0x9a,
+	0xab, 0x9b, 0xf4, 0x87, 0xd4, 0xef, 0x90, 0xb1, 0x5b, 0xbc, 0xba, 0xe3, 0x1d, 0x32, 0xb6, 0x3d,
+	0xb0, 0xe6, 0xd1, 0xe9, 0x41, 0x9a, 0xb0, 0x29, 0xf8, 0x18, 0x1d, 0x84, 0x34, 0x1c, 0x08, 0xde,
+	0xf4, 0x3d, 0xb2, 0x90, 0xb8, 0x76, 0x75, 0xc7, 0x7b, 0x0a, 0xdf, 0x21, 0x63, 0x66, 0xd7, 0x01,
+	0x49, 0x99, 0xf4, 0x18, 0xaa, 0x51, 0xbb, 0xa5, 0xff, 0xeb, 0x34, 0x3a, 0x11, 0x2e, 0x95, 0xc2,
+	0x58, 0x2e, 0xc5, 0xc9, 0xdf, 0xab, 0xb0, 0xfa, 0x53, 0x14, 0x10, 0xd4, 0x81, 0xa2, 0x3a, 0xc3,
+	0x68, 0x67, 0x56, 0xeb, 0xdc, 0x4d, 0x66, 0x3d, 0x5e, 0xb4, 0xad, 0xea, 0xef, 0x1b, 0xc7, 0x06,
+	0xfa, 0x1d, 0xd6, 0x73, 0x06, 0x47, 0x4f, 0x3e, 0xe4, 0x80, 0x59, 0x4f, 0x97, 0xa0, 0x32, 0x15,
+	0x7e, 0x81, 0x6a, 0xd6, 0xaa, 0x68, 0x6f, 0x6e, 0x6a, 0xfe, 0x18, 0x58, 0x4f, 0x1e, 0x06, 0x69,
+	0x01, 0xfb, 0xb0, 0x31, 0x63, 0x4a, 0xf4, 0xf9, 0xc2, 0xc6, 0x72, 0x0e, 0xb7, 0x9e, 0x2d, 0xc5,
+	0xe9, 0x1a, 0xd7, 0x80, 0xee, 0x5b, 0x06, 0xdd, 0xfb, 0xb0, 0x58, 0xe8, 0x52, 0xeb, 0xe0, 0x43,
+	0xa0, 0xba, 0xd8, 0x05, 0x54, 0x32, 0x46, 0x41, 0xf6, 0xdc, 0x26, 0x73, 0xde, 0xb2, 0xf6, 0x1e,
+	0xc4, 0x28, 0xde, 0x53, 0xe7, 0xd7, 0xc3, 0x01, 0xe5, 0x97, 0x49, 0x5f, 0x78, 0xeb, 0x48, 0xbd,
+	0x87, 0x8f, 0xd4, 0x77, 0x9d, 0xfc, 0x92, 0xd3, 0xcf, 0x78, 0x44, 0x8f, 0x04, 0x47, 0xbf, 0x28,
+	0xa3, 0x2f, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x1e, 0x71, 0x8e, 0x9e, 0x18, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	common "github.com/spiffe/spire/proto/spire/common"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -25,6 +26,43 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// How the selectors of an entry or agent are matched against the requested
+// selectors
+type SelectorMatch int32
+
+const (
+	// Selectors must be exactly the requested selectors
+	SelectorMatch_MATCH_EXACT SelectorMatch = 0
+	// Selectors must be a subset of the requested selectors
+	SelectorMatch_MATCH_SUBSET SelectorMatch = 1
+	// Selectors must include at least one of the requested selectors
+	SelectorMatch_MATCH_ANY SelectorMatch = 2
+	// Selectors must be a superset of the requested selectors
+	SelectorMatch_MATCH_SUPERSET SelectorMatch = 3
+)
+
+var SelectorMatch_name = map[int32]string{
+	0: "MATCH_EXACT",
+	1: "MATCH_SUBSET",
+	2: "MATCH_ANY",
+	3: "MATCH_SUPERSET",
+}
+
+var SelectorMatch_value = map[string]int32{
+	"MATCH_EXACT":    0,
+	"MATCH_SUBSET":   1,
+	"MATCH_ANY":      2,
+	"MATCH_SUPERSET": 3,
+}
+
+func (x SelectorMatch) String() string {
+	return proto.EnumName(SelectorMatch_name, int32(x))
+}
+
+func (SelectorMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{0}
+}
+
 // Mode controls the delete behavior if there are other records
 // associated with the bundle (e.g. registration entries).
 type DeleteFederatedBundleRequest_Mode int32
@@ -55,7 +93,7 @@ func (x DeleteFederatedBundleRequest_Mode) String() string {
 }
 
 func (DeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{10, 0}
+	return fileDescriptor_199f7aef77c18626, []int{11, 0}
 }
 
 // A type that represents the id of an entry.
@@ -234,10 +272,16 @@ func (m *CreateEntryIfNotExistsResponse) GetPreexisting() bool {
 // A type used to update registration entries
 type UpdateEntryRequest struct {
 	// Registration entry to update
-	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// If set, the update fails with an Aborted status unless the entry has
+	// the expected revision number.
+	ExpectedRevisionNumber *wrappers.Int64Value `protobuf:"bytes,2,opt,name=expected_revision_number,json=expectedRevisionNumber,proto3" json:"expected_revision_number,omitempty"`
+	// If set, only the fields in the mask are updated, including fields set
+	// to their zero value. Otherwise every field is updated.
+	InputMask            *common.RegistrationEntryMask `protobuf:"bytes,3,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *UpdateEntryRequest) Reset()         { *m = UpdateEntryRequest{} }
@@ -272,6 +316,71 @@ func (m *UpdateEntryRequest) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
+func (m *UpdateEntryRequest) GetExpectedRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.ExpectedRevisionNumber
+	}
+	return nil
+}
+
+func (m *UpdateEntryRequest) GetInputMask() *common.RegistrationEntryMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+// A type used to delete registration entries
+type DeleteEntryRequest struct {
+	// ID of the registration entry to delete
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// If set, the delete fails with an Aborted status unless the entry has
+	// the expected revision number.
+	ExpectedRevisionNumber *wrappers.Int64Value `protobuf:"bytes,2,opt,name=expected_revision_number,json=expectedRevisionNumber,proto3" json:"expected_revision_number,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}             `json:"-"`
+	XXX_unrecognized       []byte               `json:"-"`
+	XXX_sizecache          int32                `json:"-"`
+}
+
+func (m *DeleteEntryRequest) Reset()         { *m = DeleteEntryRequest{} }
+func (m *DeleteEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteEntryRequest) ProtoMessage()    {}
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{5}
+}
+
+func (m *DeleteEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEntryRequest.Unmarshal(m, b)
+}
+func (m *DeleteEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEntryRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEntryRequest.Merge(m, src)
+}
+func (m *DeleteEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteEntryRequest.Size(m)
+}
+func (m *DeleteEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEntryRequest proto.InternalMessageInfo
+
+func (m *DeleteEntryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteEntryRequest) GetExpectedRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.ExpectedRevisionNumber
+	}
+	return nil
+}
+
 // A type that represents pagination for list responses
 type Pagination struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -285,7 +394,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{5}
+	return fileDescriptor_199f7aef77c18626, []int{6}
 }
 
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
@@ -322,17 +431,18 @@ func (m *Pagination) GetPageSize() int32 {
 
 //
 type ListAllEntriesRequest struct {
-	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Pagination           *Pagination                   `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Filter               *ListAllEntriesRequest_Filter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *ListAllEntriesRequest) Reset()         { *m = ListAllEntriesRequest{} }
 func (m *ListAllEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAllEntriesRequest) ProtoMessage()    {}
 func (*ListAllEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{6}
+	return fileDescriptor_199f7aef77c18626, []int{7}
 }
 
 func (m *ListAllEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -360,6 +470,138 @@ func (m *ListAllEntriesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListAllEntriesRequest) GetFilter() *ListAllEntriesRequest_Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// Filters the listed entries. Entries must match every filter that is set.
+type ListAllEntriesRequest_Filter struct {
+	// If set, only entries with this parent ID are listed
+	ByParentId *wrappers.StringValue `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
+	// If set, only entries with this SPIFFE ID are listed
+	BySpiffeId *wrappers.StringValue `protobuf:"bytes,2,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	// If set, only entries whose selectors match these selectors are listed
+	BySelectors []*common.Selector `protobuf:"bytes,3,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	// How entry selectors are matched against by_selectors
+	SelectorMatch SelectorMatch `protobuf:"varint,4,opt,name=selector_match,json=selectorMatch,proto3,enum=spire.api.registration.SelectorMatch" json:"selector_match,omitempty"`
+	// If set, only entries that federate with at least one of these trust
+	// domains are listed
+	ByFederatesWith []string `protobuf:"bytes,5,rep,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	// If set, only entries that are (or are not) downstream are listed
+	ByDownstream *wrappers.BoolValue `protobuf:"bytes,6,opt,name=by_downstream,json=byDownstream,proto3" json:"by_downstream,omitempty"`
+	// If set, only entries that are (or are not) admin are listed
+	ByAdmin *wrappers.BoolValue `protobuf:"bytes,7,opt,name=by_admin,json=byAdmin,proto3" json:"by_admin,omitempty"`
+	// If set, only entries with this DNS name are listed
+	ByDnsName *wrappers.StringValue `protobuf:"bytes,8,opt,name=by_dns_name,json=byDnsName,proto3" json:"by_dns_name,omitempty"`
+	// If set, only entries expiring after this time (seconds since Unix
+	// epoch) are listed. Entries without an expiry are included.
+	ByExpiresAfter *wrappers.Int64Value `protobuf:"bytes,9,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	// If set, only entries expiring before this time (seconds since Unix
+	// epoch) are listed. Entries without an expiry are excluded.
+	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,10,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListAllEntriesRequest_Filter) Reset()         { *m = ListAllEntriesRequest_Filter{} }
+func (m *ListAllEntriesRequest_Filter) String() string { return proto.CompactTextString(m) }
+func (*ListAllEntriesRequest_Filter) ProtoMessage()    {}
+func (*ListAllEntriesRequest_Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{7, 0}
+}
+
+func (m *ListAllEntriesRequest_Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAllEntriesRequest_Filter.Unmarshal(m, b)
+}
+func (m *ListAllEntriesRequest_Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAllEntriesRequest_Filter.Marshal(b, m, deterministic)
+}
+func (m *ListAllEntriesRequest_Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAllEntriesRequest_Filter.Merge(m, src)
+}
+func (m *ListAllEntriesRequest_Filter) XXX_Size() int {
+	return xxx_messageInfo_ListAllEntriesRequest_Filter.Size(m)
+}
+func (m *ListAllEntriesRequest_Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAllEntriesRequest_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAllEntriesRequest_Filter proto.InternalMessageInfo
+
+func (m *ListAllEntriesRequest_Filter) GetByParentId() *wrappers.StringValue {
+	if m != nil {
+		return m.ByParentId
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetBySpiffeId() *wrappers.StringValue {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetSelectorMatch() SelectorMatch {
+	if m != nil {
+		return m.SelectorMatch
+	}
+	return SelectorMatch_MATCH_EXACT
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByFederatesWith() []string {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByDownstream() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByDownstream
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByAdmin() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByAdmin
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByDnsName() *wrappers.StringValue {
+	if m != nil {
+		return m.ByDnsName
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
+func (m *ListAllEntriesRequest_Filter) GetByExpiresBefore() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return nil
+}
+
 // It is used to list all registration entries with pagination
 type ListAllEntriesResponse struct {
 	Entries              []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -373,7 +615,7 @@ func (m *ListAllEntriesResponse) Reset()         { *m = ListAllEntriesResponse{}
 func (m *ListAllEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAllEntriesResponse) ProtoMessage()    {}
 func (*ListAllEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{7}
+	return fileDescriptor_199f7aef77c18626, []int{8}
 }
 
 func (m *ListAllEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -421,7 +663,7 @@ func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
 func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundle) ProtoMessage()    {}
 func (*FederatedBundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{8}
+	return fileDescriptor_199f7aef77c18626, []int{9}
 }
 
 func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
@@ -462,7 +704,7 @@ func (m *FederatedBundleID) Reset()         { *m = FederatedBundleID{} }
 func (m *FederatedBundleID) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundleID) ProtoMessage()    {}
 func (*FederatedBundleID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{9}
+	return fileDescriptor_199f7aef77c18626, []int{10}
 }
 
 func (m *FederatedBundleID) XXX_Unmarshal(b []byte) error {
@@ -502,7 +744,7 @@ func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBun
 func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
 func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{10}
+	return fileDescriptor_199f7aef77c18626, []int{11}
 }
 
 func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -552,7 +794,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{11}
+	return fileDescriptor_199f7aef77c18626, []int{12}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -600,7 +842,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{12}
+	return fileDescriptor_199f7aef77c18626, []int{13}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -630,16 +872,26 @@ func (m *Bundle) GetBundle() *common.Bundle {
 
 // Represents a ListAgents request
 type ListAgentsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// If set, only agents attested with this attestation type are listed
+	ByAttestationType string `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// If set, only agents whose selectors match these selectors are listed
+	BySelectors []*common.Selector `protobuf:"bytes,2,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	// How agent selectors are matched against by_selectors
+	SelectorMatch SelectorMatch `protobuf:"varint,3,opt,name=selector_match,json=selectorMatch,proto3,enum=spire.api.registration.SelectorMatch" json:"selector_match,omitempty"`
+	// If set, only agents that are (or are not) banned are listed
+	ByBanned *wrappers.BoolValue `protobuf:"bytes,4,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// If set, only agents that can (or cannot) re-attest are listed
+	ByCanReattest        *wrappers.BoolValue `protobuf:"bytes,5,opt,name=by_can_reattest,json=byCanReattest,proto3" json:"by_can_reattest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
 func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsRequest) ProtoMessage()    {}
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{13}
+	return fileDescriptor_199f7aef77c18626, []int{14}
 }
 
 func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
@@ -660,6 +912,41 @@ func (m *ListAgentsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
 
+func (m *ListAgentsRequest) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *ListAgentsRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *ListAgentsRequest) GetSelectorMatch() SelectorMatch {
+	if m != nil {
+		return m.SelectorMatch
+	}
+	return SelectorMatch_MATCH_EXACT
+}
+
+func (m *ListAgentsRequest) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *ListAgentsRequest) GetByCanReattest() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByCanReattest
+	}
+	return nil
+}
+
 // Represents a ListAgents response
 type ListAgentsResponse struct {
 	// List of all attested agents
@@ -673,7 +960,7 @@ func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
 func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsResponse) ProtoMessage()    {}
 func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{14}
+	return fileDescriptor_199f7aef77c18626, []int{15}
 }
 
 func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
@@ -715,7 +1002,7 @@ func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
 func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentRequest) ProtoMessage()    {}
 func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{15}
+	return fileDescriptor_199f7aef77c18626, []int{16}
 }
 
 func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -756,7 +1043,7 @@ func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
 func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentResponse) ProtoMessage()    {}
 func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{16}
+	return fileDescriptor_199f7aef77c18626, []int{17}
 }
 
 func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
@@ -807,7 +1094,7 @@ func (m *MintX509SVIDRequest) Reset()         { *m = MintX509SVIDRequest{} }
 func (m *MintX509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDRequest) ProtoMessage()    {}
 func (*MintX509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{17}
+	return fileDescriptor_199f7aef77c18626, []int{18}
 }
 
 func (m *MintX509SVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -871,7 +1158,7 @@ func (m *MintX509SVIDResponse) Reset()         { *m = MintX509SVIDResponse{} }
 func (m *MintX509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDResponse) ProtoMessage()    {}
 func (*MintX509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{18}
+	return fileDescriptor_199f7aef77c18626, []int{19}
 }
 
 func (m *MintX509SVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -926,7 +1213,7 @@ func (m *MintJWTSVIDRequest) Reset()         { *m = MintJWTSVIDRequest{} }
 func (m *MintJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDRequest) ProtoMessage()    {}
 func (*MintJWTSVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{19}
+	return fileDescriptor_199f7aef77c18626, []int{20}
 }
 
 func (m *MintJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -980,7 +1267,7 @@ func (m *MintJWTSVIDResponse) Reset()         { *m = MintJWTSVIDResponse{} }
 func (m *MintJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDResponse) ProtoMessage()    {}
 func (*MintJWTSVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{20}
+	return fileDescriptor_199f7aef77c18626, []int{21}
 }
 
 func (m *MintJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -1022,7 +1309,7 @@ func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
 func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
 func (*NodeSelectors) ProtoMessage()    {}
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{21}
+	return fileDescriptor_199f7aef77c18626, []int{22}
 }
 
 func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
@@ -1069,7 +1356,7 @@ func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest
 func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsRequest) ProtoMessage()    {}
 func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{22}
+	return fileDescriptor_199f7aef77c18626, []int{23}
 }
 
 func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
@@ -1109,7 +1396,7 @@ func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsRespon
 func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsResponse) ProtoMessage()    {}
 func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{23}
+	return fileDescriptor_199f7aef77c18626, []int{24}
 }
 
 func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
@@ -1138,14 +1425,17 @@ func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
 }
 
 func init() {
+	proto.RegisterEnum("spire.api.registration.SelectorMatch", SelectorMatch_name, SelectorMatch_value)
 	proto.RegisterEnum("spire.api.registration.DeleteFederatedBundleRequest_Mode", DeleteFederatedBundleRequest_Mode_name, DeleteFederatedBundleRequest_Mode_value)
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
 	proto.RegisterType((*ParentID)(nil), "spire.api.registration.ParentID")
 	proto.RegisterType((*SpiffeID)(nil), "spire.api.registration.SpiffeID")
 	proto.RegisterType((*CreateEntryIfNotExistsResponse)(nil), "spire.api.registration.CreateEntryIfNotExistsResponse")
 	proto.RegisterType((*UpdateEntryRequest)(nil), "spire.api.registration.UpdateEntryRequest")
+	proto.RegisterType((*DeleteEntryRequest)(nil), "spire.api.registration.DeleteEntryRequest")
 	proto.RegisterType((*Pagination)(nil), "spire.api.registration.Pagination")
 	proto.RegisterType((*ListAllEntriesRequest)(nil), "spire.api.registration.ListAllEntriesRequest")
+	proto.RegisterType((*ListAllEntriesRequest_Filter)(nil), "spire.api.registration.ListAllEntriesRequest.Filter")
 	proto.RegisterType((*ListAllEntriesResponse)(nil), "spire.api.registration.ListAllEntriesResponse")
 	proto.RegisterType((*FederatedBundle)(nil), "spire.api.registration.FederatedBundle")
 	proto.RegisterType((*FederatedBundleID)(nil), "spire.api.registration.FederatedBundleID")
@@ -1168,78 +1458,109 @@ func init() {
 func init() { proto.RegisterFile("registration.proto", fileDescriptor_199f7aef77c18626) }
 
 var fileDescriptor_199f7aef77c18626 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x53, 0x1b, 0x37,
-	0x10, 0xae, 0xcd, 0x4b, 0xec, 0xb5, 0x4b, 0x40, 0xbc, 0x39, 0x97, 0x36, 0x75, 0xd4, 0xc9, 0x34,
-	0x0d, 0xa9, 0xcd, 0x10, 0xc2, 0x0c, 0x9f, 0x32, 0x60, 0x9b, 0x8e, 0x93, 0x40, 0x99, 0xb3, 0x09,
-	0x1d, 0xf8, 0xe0, 0x39, 0x7c, 0xc2, 0xa8, 0x35, 0x77, 0x97, 0x93, 0xc8, 0x84, 0xfc, 0x80, 0xfe,
-	0x85, 0xfe, 0x8c, 0xfe, 0xc5, 0x8e, 0x4e, 0xba, 0xf3, 0xf9, 0x7c, 0xc2, 0x17, 0xa6, 0xfd, 0xe4,
-	0x93, 0xb4, 0xfb, 0xec, 0xb3, 0xab, 0x95, 0xf4, 0x18, 0x90, 0x4f, 0x06, 0x94, 0x71, 0xdf, 0xe2,
-	0xd4, 0x75, 0x6a, 0x9e, 0xef, 0x72, 0x17, 0xad, 0x31, 0x8f, 0xfa, 0xa4, 0x66, 0x79, 0xb4, 0x16,
-	0x5f, 0x35, 0x1e, 0x05, 0xf3, 0xf5, 0xbe, 0x7b, 0x7d, 0xed, 0x3a, 0xea, 0x47, 0xba, 0xe0, 0x67,
-	0xb0, 0x6c, 0xc6, 0x4c, 0x5b, 0x0e, 0xf7, 0x6f, 0xdb, 0x4d, 0xb4, 0x00, 0x79, 0x6a, 0x57, 0x72,
-	0xd5, 0xdc, 0xf3, 0xa2, 0x99, 0xa7, 0x36, 0x36, 0xa0, 0x70, 0x6c, 0xf9, 0xc4, 0xe1, 0xe9, 0x6b,
-	0x1d, 0x8f, 0x5e, 0x5e, 0x92, 0x94, 0xb5, 0x5b, 0x78, 0xd2, 0xf0, 0x89, 0xc5, 0x89, 0x04, 0xbe,
-	0x3c, 0x72, 0x79, 0xeb, 0x33, 0x65, 0x9c, 0x99, 0x84, 0x79, 0xae, 0xc3, 0x08, 0x7a, 0x0d, 0x73,
-	0x44, 0xac, 0x05, 0x4e, 0xa5, 0xad, 0x1f, 0x6a, 0x32, 0x07, 0x45, 0x72, 0x82, 0x9b, 0x29, 0xad,
-	0x51, 0x15, 0x4a, 0x9e, 0x4f, 0x88, 0xc0, 0xa2, 0xce, 0xa0, 0x92, 0xaf, 0xe6, 0x9e, 0x17, 0xcc,
-	0xf8, 0x14, 0x7e, 0x07, 0xe8, 0xc4, 0xb3, 0xc3, 0xd0, 0x26, 0xf9, 0x78, 0x43, 0x18, 0xbf, 0x67,
-	0x38, 0xfc, 0x06, 0xe0, 0xd8, 0x1a, 0x50, 0x27, 0x58, 0x41, 0x2b, 0x30, 0xc7, 0xdd, 0x3f, 0x89,
-	0xa3, 0x12, 0x95, 0x03, 0xf4, 0x18, 0x8a, 0x9e, 0x35, 0x20, 0x3d, 0x46, 0xbf, 0x90, 0x80, 0xd0,
-	0x9c, 0x59, 0x10, 0x13, 0x1d, 0xfa, 0x85, 0xe0, 0x73, 0x58, 0x7d, 0x4f, 0x19, 0xdf, 0x1b, 0x0e,
-	0x05, 0x2e, 0x25, 0x2c, 0x24, 0xb4, 0x0f, 0xe0, 0x45, 0xc8, 0x8a, 0x15, 0xae, 0xa5, 0x6f, 0x64,
-	0x6d, 0xc4, 0xc1, 0x8c, 0x79, 0xe1, 0xbf, 0x73, 0xb0, 0x96, 0x44, 0x57, 0xe5, 0xdd, 0x85, 0x07,
-	0x44, 0x4e, 0x55, 0x72, 0xd5, 0x99, 0x2c, 0x19, 0x87, 0xf6, 0x09, 0x66, 0xf9, 0x7b, 0x31, 0x7b,
-	0x03, 0x0f, 0x0f, 0x88, 0x4d, 0x7c, 0x8b, 0x13, 0x7b, 0xff, 0xc6, 0xb1, 0x87, 0x04, 0xbd, 0x84,
-	0xf9, 0x8b, 0xe0, 0xab, 0x32, 0x13, 0x40, 0xae, 0x8c, 0x13, 0x92, 0x56, 0xa6, 0xb2, 0xc1, 0x3f,
-	0xc2, 0x52, 0x02, 0x20, 0xa5, 0xcb, 0xfe, 0xc9, 0xc1, 0x77, 0x4d, 0x32, 0x24, 0x9c, 0x24, 0x6c,
-	0xc3, 0x22, 0x27, 0x1c, 0xd0, 0x21, 0xcc, 0x5e, 0xbb, 0xb6, 0xdc, 0xa5, 0x85, 0xad, 0x5d, 0x5d,
-	0x52, 0x77, 0x61, 0xd6, 0x0e, 0x5d, 0x9b, 0x98, 0x01, 0x0c, 0xde, 0x84, 0x59, 0x31, 0x42, 0x65,
-	0x28, 0x98, 0xad, 0x4e, 0xd7, 0x6c, 0x37, 0xba, 0x8b, 0xdf, 0x20, 0x80, 0xf9, 0x66, 0xeb, 0x7d,
-	0xab, 0xdb, 0x5a, 0xcc, 0xa1, 0x05, 0x80, 0x66, 0xbb, 0xd3, 0xf9, 0xad, 0xd1, 0xde, 0xeb, 0xb6,
-	0x16, 0xf3, 0xf8, 0x15, 0x14, 0xdf, 0xba, 0xd4, 0xe9, 0x06, 0x8d, 0x93, 0xde, 0x4e, 0x8b, 0x30,
-	0xc3, 0xf9, 0x50, 0x35, 0x92, 0xf8, 0xc4, 0x3b, 0x30, 0x3f, 0x51, 0xc3, 0x7c, 0x86, 0x1a, 0x2e,
-	0xc3, 0x52, 0xd0, 0x1d, 0x03, 0xe2, 0xf0, 0xb0, 0xef, 0xf0, 0x01, 0xa0, 0xf8, 0xa4, 0x6a, 0x97,
-	0x4d, 0x98, 0x73, 0x5c, 0x3b, 0x6a, 0x16, 0x63, 0x1c, 0x77, 0x8f, 0x73, 0xc2, 0x38, 0xb1, 0x8f,
-	0x44, 0xea, 0xd2, 0x10, 0xd7, 0x61, 0xa9, 0xf5, 0x89, 0xf6, 0x25, 0x50, 0x58, 0x6f, 0x03, 0x0a,
-	0x4c, 0x5d, 0x09, 0x2a, 0xa9, 0x68, 0x8c, 0x9b, 0x80, 0xe2, 0x0e, 0x2a, 0x70, 0x0d, 0x66, 0x05,
-	0x9e, 0x3a, 0x00, 0x77, 0xc5, 0x0d, 0xec, 0x30, 0x83, 0xe5, 0x43, 0xea, 0xf0, 0xdf, 0x5f, 0x6f,
-	0xee, 0x76, 0x3e, 0xb4, 0x9b, 0x61, 0xe0, 0xc7, 0x50, 0x94, 0x81, 0x7a, 0xd1, 0x7e, 0x87, 0x91,
-	0x6d, 0x51, 0xd1, 0x3e, 0xf3, 0x83, 0x92, 0x95, 0x4d, 0xf1, 0x19, 0xd6, 0x78, 0x26, 0xaa, 0xb1,
-	0x00, 0xb0, 0x1d, 0xd6, 0x73, 0xac, 0x6b, 0xc2, 0x2a, 0xb3, 0xd5, 0x19, 0x01, 0x60, 0x3b, 0xec,
-	0x48, 0x8c, 0xf1, 0x31, 0xac, 0x8c, 0x07, 0x55, 0xe4, 0xbf, 0x07, 0x60, 0x9f, 0xa8, 0xdd, 0xeb,
-	0x5f, 0x59, 0xd4, 0x09, 0x4a, 0x57, 0x36, 0x8b, 0x62, 0xa6, 0x21, 0x26, 0xd0, 0x23, 0x28, 0xf8,
-	0xae, 0xcb, 0x7b, 0x7d, 0x8b, 0x55, 0xf2, 0xc1, 0xe2, 0x03, 0x31, 0x6e, 0x58, 0x0c, 0xf7, 0x00,
-	0x09, 0xc4, 0xb7, 0xa7, 0xdd, 0xaf, 0xc9, 0x62, 0xbc, 0x2f, 0x44, 0xb5, 0xad, 0x1b, 0x9b, 0x12,
-	0xa7, 0x2f, 0xce, 0x54, 0x40, 0x39, 0x1c, 0xe3, 0x0d, 0x59, 0xa7, 0x28, 0x80, 0x62, 0x9c, 0xda,
-	0x72, 0xf8, 0x02, 0xbe, 0x15, 0x25, 0xee, 0x90, 0x21, 0xe9, 0x73, 0xd7, 0x67, 0x77, 0x13, 0xd9,
-	0x86, 0x22, 0x0b, 0x2d, 0x83, 0xbc, 0x4a, 0x5b, 0x6b, 0xe3, 0xfb, 0x16, 0x02, 0x99, 0x23, 0x43,
-	0xbc, 0x03, 0xeb, 0xbf, 0x12, 0x3e, 0x16, 0x26, 0x4b, 0xda, 0xb8, 0x07, 0x95, 0x49, 0x3f, 0x95,
-	0x4d, 0x23, 0xce, 0x44, 0x76, 0xd0, 0x33, 0xdd, 0x99, 0x1e, 0x47, 0x18, 0xf9, 0x6d, 0xfd, 0xb5,
-	0x04, 0xe5, 0xf8, 0x6d, 0x88, 0xce, 0xa1, 0x14, 0x7b, 0xbb, 0xd0, 0xb4, 0x8b, 0xd3, 0xd8, 0xd0,
-	0x85, 0x4c, 0x7b, 0x60, 0x3f, 0xc2, 0x5a, 0xfa, 0xc3, 0x38, 0x3d, 0xce, 0x8e, 0x2e, 0xce, 0x94,
-	0x97, 0xf6, 0x1c, 0x4a, 0xf2, 0x42, 0x93, 0xf9, 0x7c, 0x0d, 0x5d, 0x63, 0x1a, 0x29, 0x74, 0x06,
-	0x70, 0x40, 0x78, 0xff, 0xea, 0xff, 0xc0, 0x3e, 0x80, 0x72, 0x84, 0x2d, 0x1e, 0xa6, 0xe5, 0x71,
-	0x87, 0xd6, 0xb5, 0xc7, 0x6f, 0x8d, 0xa7, 0x77, 0xa3, 0x08, 0xbf, 0x33, 0x28, 0xc5, 0x14, 0x01,
-	0x7a, 0xa1, 0x23, 0x39, 0x29, 0x1b, 0xa6, 0x73, 0x3c, 0x81, 0x05, 0x71, 0x9d, 0xee, 0xdf, 0x46,
-	0x32, 0xa9, 0xaa, 0x7f, 0x2a, 0xa5, 0x45, 0x16, 0xca, 0xef, 0x42, 0xd8, 0xb0, 0x65, 0x91, 0xe6,
-	0x88, 0x65, 0x01, 0x3b, 0x84, 0x87, 0xe3, 0x60, 0x0c, 0xad, 0xa7, 0xa3, 0xb1, 0x2c, 0x70, 0x51,
-	0xca, 0x91, 0xfa, 0xd3, 0xa6, 0x1c, 0x5a, 0x64, 0x81, 0xfd, 0x0c, 0xeb, 0xe3, 0x5a, 0xe6, 0x94,
-	0xf2, 0xab, 0x63, 0x6b, 0x40, 0x18, 0xfa, 0x45, 0x87, 0x9f, 0x2a, 0xad, 0x8c, 0x5a, 0x56, 0x73,
-	0x75, 0x40, 0x4e, 0x60, 0x55, 0x1e, 0xa1, 0xa4, 0x64, 0xf9, 0x49, 0x07, 0x94, 0x30, 0x34, 0xd2,
-	0x3a, 0x13, 0xfd, 0x01, 0x2b, 0x41, 0xfb, 0x26, 0x51, 0x7f, 0xce, 0x88, 0xda, 0x6e, 0x1a, 0x59,
-	0x09, 0xa0, 0x0f, 0xb0, 0x22, 0x92, 0x4b, 0x4c, 0x6b, 0x8e, 0x4c, 0x56, 0xd4, 0xcd, 0x9c, 0x28,
-	0x8d, 0x3c, 0x15, 0xff, 0x6d, 0x69, 0x2e, 0x60, 0x35, 0x55, 0x63, 0xa1, 0xed, 0xfb, 0x48, 0xb2,
-	0xf4, 0x18, 0xa7, 0xf0, 0x50, 0xee, 0xea, 0x48, 0x70, 0x3d, 0xd5, 0xa1, 0x47, 0x26, 0xc6, 0x74,
-	0x13, 0xb4, 0x0f, 0xa5, 0x60, 0x5f, 0x15, 0xe5, 0xd4, 0x12, 0x3f, 0xd1, 0xc1, 0x28, 0xa7, 0x3e,
-	0xc0, 0x48, 0x0c, 0xe9, 0x3b, 0x62, 0x42, 0x61, 0x19, 0x2f, 0xb2, 0x98, 0xaa, 0xbe, 0xee, 0x03,
-	0x8c, 0xa4, 0x9e, 0x3e, 0xc8, 0x84, 0x46, 0xd4, 0x07, 0x49, 0x51, 0x8e, 0x14, 0xca, 0x71, 0x6d,
-	0xa4, 0x7f, 0x02, 0x52, 0x64, 0x9b, 0xf1, 0x32, 0x9b, 0xb1, 0x0a, 0x75, 0x09, 0xa5, 0x98, 0xa6,
-	0xd1, 0xdf, 0xe3, 0x93, 0xca, 0xca, 0xd8, 0xc8, 0x64, 0xab, 0xe2, 0xdc, 0xc0, 0x62, 0x52, 0x72,
-	0xa0, 0xba, 0x0e, 0x40, 0x23, 0x6a, 0x8c, 0xcd, 0xec, 0x0e, 0x32, 0xec, 0xfe, 0xce, 0xd9, 0xf6,
-	0x80, 0xf2, 0xab, 0x9b, 0x0b, 0xd1, 0x4a, 0x75, 0x29, 0x80, 0xea, 0xf2, 0x1f, 0x7c, 0xf0, 0x9f,
-	0x5d, 0x7d, 0x5b, 0x1e, 0xad, 0xc7, 0x01, 0x2f, 0xe6, 0x83, 0xd5, 0x57, 0xff, 0x06, 0x00, 0x00,
-	0xff, 0xff, 0x1c, 0xa6, 0x33, 0xe8, 0x1a, 0x10, 0x00, 0x00,
+	// 1631 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xdb, 0xc6,
+	0x15, 0x2e, 0x49, 0x89, 0x26, 0x0f, 0x29, 0x8a, 0x5a, 0xcb, 0x32, 0x03, 0xa7, 0xae, 0x82, 0x4c,
+	0xa6, 0xaa, 0x9d, 0x92, 0x1a, 0x45, 0x56, 0x47, 0x33, 0x9d, 0x7a, 0xf8, 0xa7, 0x54, 0xa9, 0xa4,
+	0x6a, 0x40, 0xca, 0x4e, 0xd5, 0x0b, 0x0c, 0x40, 0x2c, 0x29, 0xd4, 0x24, 0x80, 0x60, 0x97, 0xb6,
+	0x90, 0x5e, 0x76, 0xfa, 0x0c, 0x7d, 0x8c, 0x3c, 0x47, 0x5f, 0xa4, 0xcf, 0xd1, 0xd9, 0x1f, 0x90,
+	0x20, 0x09, 0x88, 0xb0, 0xd3, 0x5e, 0x89, 0xd8, 0x3d, 0xdf, 0x77, 0x7e, 0xf6, 0x9c, 0xb3, 0x67,
+	0x05, 0xc8, 0xc7, 0x23, 0x9b, 0x50, 0xdf, 0xa0, 0xb6, 0xeb, 0xd4, 0x3d, 0xdf, 0xa5, 0x2e, 0xda,
+	0x23, 0x9e, 0xed, 0xe3, 0xba, 0xe1, 0xd9, 0xf5, 0xe8, 0xae, 0xf2, 0x7c, 0xe4, 0xba, 0xa3, 0x31,
+	0x6e, 0x70, 0x29, 0x73, 0x3a, 0x6c, 0x7c, 0xf0, 0x0d, 0xcf, 0xc3, 0x3e, 0x11, 0x38, 0xe5, 0x33,
+	0x8e, 0x6b, 0x0c, 0xdc, 0xc9, 0xc4, 0x75, 0xe4, 0x1f, 0xb1, 0xa5, 0x7e, 0x05, 0x8f, 0xb5, 0x08,
+	0x55, 0xd7, 0xa1, 0x7e, 0x70, 0xde, 0x41, 0x15, 0xc8, 0xda, 0x56, 0x2d, 0xb3, 0x9f, 0x39, 0x28,
+	0x6a, 0x59, 0xdb, 0x52, 0x15, 0x28, 0x5c, 0x1b, 0x3e, 0x76, 0x68, 0xfc, 0x5e, 0xcf, 0xb3, 0x87,
+	0x43, 0x1c, 0xb3, 0x17, 0xc0, 0xf3, 0xb6, 0x8f, 0x0d, 0x8a, 0x05, 0xf1, 0xf0, 0xca, 0xa5, 0xdd,
+	0x7b, 0x9b, 0x50, 0xa2, 0x61, 0xe2, 0xb9, 0x0e, 0xc1, 0xe8, 0x15, 0x6c, 0x62, 0xb6, 0xc7, 0x41,
+	0xa5, 0xa3, 0x5f, 0xd5, 0x85, 0x8f, 0xd2, 0xc8, 0x15, 0xdb, 0x34, 0x21, 0x8d, 0xf6, 0xa1, 0xe4,
+	0xf9, 0x18, 0x33, 0x2e, 0xdb, 0x19, 0xd5, 0xb2, 0xfb, 0x99, 0x83, 0x82, 0x16, 0x5d, 0x52, 0xff,
+	0x93, 0x01, 0x74, 0xe3, 0x59, 0xa1, 0x6e, 0x0d, 0xff, 0x30, 0xc5, 0x84, 0x7e, 0xaa, 0xbe, 0x1b,
+	0xa8, 0xe1, 0x7b, 0x0f, 0x0f, 0x28, 0xb6, 0x74, 0x1f, 0xbf, 0xb7, 0x89, 0xed, 0x3a, 0xba, 0x33,
+	0x9d, 0x98, 0xd8, 0xe7, 0xca, 0x4b, 0x47, 0xcf, 0xea, 0xe2, 0x14, 0xea, 0xe1, 0x29, 0xd4, 0xcf,
+	0x1d, 0x7a, 0x72, 0xfc, 0xc6, 0x18, 0x4f, 0xb1, 0xb6, 0x17, 0x82, 0x35, 0x89, 0xbd, 0xe2, 0x50,
+	0xd4, 0x02, 0xb0, 0x1d, 0x6f, 0x4a, 0xf5, 0x89, 0x41, 0xde, 0xd5, 0x72, 0x9c, 0xe8, 0xcb, 0x35,
+	0x26, 0x5d, 0x1a, 0xe4, 0x9d, 0x56, 0xe4, 0x30, 0xf6, 0x53, 0xfd, 0x3b, 0xa0, 0x0e, 0x1e, 0xe3,
+	0x25, 0x3f, 0x97, 0x4e, 0xe2, 0xff, 0xe4, 0x80, 0xfa, 0x1a, 0xe0, 0xda, 0x18, 0xd9, 0x0e, 0x37,
+	0x0f, 0xed, 0xc2, 0x26, 0x75, 0xdf, 0x61, 0x47, 0xea, 0x15, 0x1f, 0xe8, 0x19, 0x14, 0x3d, 0x63,
+	0x84, 0x75, 0x62, 0xff, 0x88, 0xb9, 0xae, 0x4d, 0xad, 0xc0, 0x16, 0x7a, 0xf6, 0x8f, 0x58, 0xfd,
+	0x29, 0x0f, 0x4f, 0x2e, 0x6c, 0x42, 0x9b, 0xe3, 0x31, 0xb3, 0xdf, 0xc6, 0x24, 0xf4, 0xa0, 0x05,
+	0xe0, 0xcd, 0xa8, 0xe5, 0x71, 0xa9, 0xf5, 0xf8, 0x12, 0xa8, 0xcf, 0x8d, 0xd0, 0x22, 0x28, 0x74,
+	0x01, 0xf9, 0xa1, 0x3d, 0xa6, 0x33, 0x1f, 0x8f, 0x93, 0xf0, 0xb1, 0x26, 0xd4, 0xcf, 0x38, 0x56,
+	0x93, 0x1c, 0xca, 0x3f, 0x36, 0x21, 0x2f, 0x96, 0xd0, 0x1f, 0xa0, 0x6c, 0x06, 0xba, 0xc7, 0x6b,
+	0x42, 0x97, 0x81, 0x2e, 0x1d, 0x7d, 0xbe, 0x12, 0xc2, 0x1e, 0xf5, 0x6d, 0x67, 0x24, 0x62, 0x08,
+	0x66, 0x20, 0x8b, 0xc8, 0x92, 0x78, 0xc2, 0xeb, 0x86, 0xe1, 0xb3, 0xe9, 0xf0, 0xb2, 0xd0, 0x2c,
+	0x74, 0x2a, 0xf0, 0x78, 0x8c, 0x07, 0xd4, 0xf5, 0x49, 0x2d, 0xb7, 0x9f, 0x3b, 0x28, 0x1d, 0xed,
+	0x2d, 0xa6, 0x4e, 0x4f, 0x6e, 0x6b, 0x25, 0x33, 0x08, 0x7f, 0x13, 0x74, 0x01, 0x95, 0x10, 0xa7,
+	0x4f, 0x0c, 0x3a, 0xb8, 0xab, 0x6d, 0xec, 0x67, 0x0e, 0x2a, 0x47, 0x5f, 0x25, 0xc5, 0x26, 0x84,
+	0x5e, 0x32, 0x61, 0x6d, 0x8b, 0x44, 0x3f, 0xd1, 0x0b, 0xd8, 0x31, 0x03, 0x7d, 0x88, 0x2d, 0xec,
+	0x1b, 0x14, 0x13, 0xfd, 0x83, 0x4d, 0xef, 0x6a, 0x9b, 0xfb, 0xb9, 0x83, 0xa2, 0xb6, 0x6d, 0x06,
+	0x67, 0xe1, 0xfa, 0x5b, 0x9b, 0xde, 0xa1, 0xd7, 0xb0, 0x65, 0x06, 0xba, 0xe5, 0x7e, 0x70, 0x08,
+	0xf5, 0xb1, 0x31, 0xa9, 0xe5, 0xb9, 0xd7, 0xca, 0x8a, 0xd7, 0x2d, 0xd7, 0x1d, 0x0b, 0x9f, 0xcb,
+	0x66, 0xd0, 0x99, 0xc9, 0xa3, 0x57, 0x50, 0x30, 0x03, 0xdd, 0xb0, 0x26, 0xb6, 0x53, 0x7b, 0xb4,
+	0x16, 0xfb, 0xc8, 0x0c, 0x9a, 0x4c, 0x14, 0xfd, 0x1e, 0x4a, 0x4c, 0xaf, 0x43, 0x74, 0xc7, 0x98,
+	0xe0, 0x5a, 0x21, 0x45, 0xac, 0x8b, 0x66, 0xd0, 0x71, 0xc8, 0x95, 0x31, 0xc1, 0xa8, 0x0b, 0x55,
+	0x33, 0xd0, 0xf1, 0x3d, 0x0b, 0x0e, 0xd1, 0x8d, 0x21, 0xcb, 0xa6, 0xe2, 0xfa, 0x8a, 0xa9, 0x98,
+	0x41, 0x57, 0x60, 0x9a, 0x0c, 0x82, 0xbe, 0xe5, 0x81, 0x0a, 0x69, 0x4c, 0x3c, 0x74, 0x7d, 0x5c,
+	0x83, 0xf5, 0x3c, 0xdb, 0x33, 0x9e, 0x16, 0xc7, 0xa8, 0xff, 0xca, 0xc0, 0xde, 0x72, 0xba, 0xca,
+	0x66, 0x7a, 0x0a, 0x8f, 0xb0, 0x58, 0xaa, 0x65, 0x78, 0x42, 0xac, 0x6d, 0x6f, 0xa1, 0xfc, 0x52,
+	0xb5, 0x65, 0x3f, 0xa5, 0xda, 0xd4, 0xd7, 0xb0, 0x1d, 0x1e, 0xb8, 0xd5, 0x9a, 0x3a, 0xd6, 0x18,
+	0xa3, 0xaf, 0x21, 0x6f, 0xf2, 0x5f, 0xb2, 0xb9, 0xed, 0x2e, 0x1a, 0x24, 0xa4, 0x34, 0x29, 0xa3,
+	0x7e, 0x09, 0x3b, 0x4b, 0x04, 0x31, 0x77, 0xca, 0x4f, 0x19, 0xf8, 0x5c, 0x34, 0xbc, 0x25, 0xd9,
+	0xa4, 0xd6, 0x77, 0x09, 0x1b, 0x13, 0xd7, 0x12, 0xad, 0xa7, 0x72, 0x74, 0x9a, 0xe4, 0xd4, 0x43,
+	0x9c, 0xf5, 0x4b, 0xd7, 0xc2, 0x1a, 0xa7, 0x51, 0x0f, 0x61, 0x83, 0x7d, 0xa1, 0x32, 0x14, 0xb4,
+	0x6e, 0xaf, 0xaf, 0x9d, 0xb7, 0xfb, 0xd5, 0x5f, 0x20, 0x80, 0x7c, 0xa7, 0x7b, 0xd1, 0xed, 0x77,
+	0xab, 0x19, 0x54, 0x01, 0xe8, 0x9c, 0xf7, 0x7a, 0x7f, 0x6e, 0x9f, 0x37, 0xfb, 0xdd, 0x6a, 0x56,
+	0xfd, 0x06, 0x8a, 0xdf, 0xb9, 0xb6, 0xd3, 0xe7, 0xdd, 0x30, 0xbe, 0x47, 0x56, 0x21, 0x47, 0xe9,
+	0x58, 0x76, 0x47, 0xf6, 0x53, 0x3d, 0x81, 0xfc, 0x4a, 0x0c, 0xb3, 0x29, 0x62, 0xf8, 0xef, 0x2c,
+	0xec, 0xf0, 0xf4, 0x18, 0x61, 0x87, 0xce, 0x9a, 0x69, 0x1d, 0x1e, 0xb3, 0xca, 0xa1, 0x14, 0x13,
+	0xca, 0xdd, 0xd5, 0x69, 0xe0, 0x61, 0x69, 0xc3, 0x8e, 0x19, 0x34, 0xe7, 0x3b, 0xfd, 0xc0, 0xc3,
+	0x2b, 0xfd, 0x25, 0xfb, 0x73, 0xfa, 0x4b, 0xee, 0x67, 0xf4, 0x97, 0xdf, 0x41, 0xd1, 0x0c, 0x74,
+	0xd3, 0x70, 0x1c, 0x6c, 0xf1, 0x46, 0xf5, 0x70, 0xcd, 0x17, 0xcc, 0xa0, 0xc5, 0x65, 0x51, 0x0b,
+	0xb6, 0xcd, 0x40, 0x1f, 0x18, 0x8e, 0xce, 0x06, 0x10, 0xe6, 0x5d, 0x6d, 0x73, 0x2d, 0x7c, 0xcb,
+	0x0c, 0xda, 0x86, 0xa3, 0x49, 0x80, 0x7a, 0x06, 0x28, 0x1a, 0x4a, 0x59, 0x65, 0x87, 0xb0, 0xe9,
+	0xb8, 0xd6, 0xac, 0xc6, 0x94, 0xc5, 0xa0, 0x88, 0x48, 0x62, 0xeb, 0x8a, 0x65, 0x8c, 0x10, 0x54,
+	0x1b, 0xb0, 0xd3, 0x7d, 0x6f, 0x0f, 0x04, 0x51, 0x78, 0x24, 0x0a, 0x14, 0x88, 0x9c, 0x9b, 0xe4,
+	0x39, 0xcc, 0xbe, 0xd5, 0x0e, 0xa0, 0x28, 0x40, 0x2a, 0xae, 0xc3, 0x06, 0xe3, 0x93, 0x97, 0xcd,
+	0x43, 0x7a, 0xb9, 0x9c, 0x4a, 0xe0, 0xf1, 0xa5, 0xed, 0xd0, 0xef, 0x5f, 0x1d, 0x9e, 0xf6, 0xde,
+	0x9c, 0x77, 0x42, 0xc5, 0xcf, 0xa0, 0x38, 0xbf, 0x78, 0x16, 0x35, 0x5b, 0x2c, 0x11, 0x07, 0x44,
+	0x5c, 0x97, 0x65, 0x8d, 0xfd, 0x0c, 0x53, 0x33, 0x37, 0x4b, 0x4d, 0x46, 0x10, 0x36, 0x53, 0x52,
+	0xdb, 0xe0, 0xbd, 0xbe, 0x60, 0x89, 0x6e, 0x49, 0xd4, 0x6b, 0xd8, 0x5d, 0x54, 0x2a, 0x8d, 0xff,
+	0x25, 0x00, 0x79, 0x6f, 0x5b, 0xfa, 0xe0, 0xce, 0xb0, 0x1d, 0x1e, 0xba, 0xb2, 0x56, 0x64, 0x2b,
+	0x6d, 0xb6, 0x80, 0x3e, 0x83, 0x82, 0xef, 0xba, 0x54, 0x1f, 0x18, 0x22, 0xd9, 0xca, 0xda, 0x23,
+	0xf6, 0xdd, 0x36, 0x88, 0xaa, 0x03, 0x62, 0x8c, 0xdf, 0xbd, 0xed, 0x7f, 0x8c, 0x17, 0x8b, 0xe5,
+	0xc4, 0xa2, 0x6d, 0x4c, 0x2d, 0x1b, 0x3b, 0x03, 0xcc, 0x2f, 0xcb, 0xa2, 0x36, 0xfb, 0x56, 0x5f,
+	0x8a, 0x38, 0xcd, 0x14, 0x48, 0x8b, 0x63, 0x2b, 0x55, 0x35, 0x61, 0x8b, 0x85, 0x78, 0x9e, 0xef,
+	0x0f, 0x1a, 0x72, 0x0c, 0xc5, 0xb4, 0x45, 0x34, 0x17, 0x54, 0x4f, 0xe0, 0xe9, 0xb7, 0x98, 0x2e,
+	0xa8, 0x49, 0xe3, 0xb6, 0xaa, 0x43, 0x6d, 0x15, 0x27, 0xbd, 0x69, 0x47, 0x2d, 0x11, 0x19, 0x94,
+	0x58, 0x91, 0x8b, 0x0c, 0x73, 0xdc, 0x8b, 0x1b, 0xd8, 0x5a, 0xa8, 0x56, 0xb4, 0x0d, 0xa5, 0xcb,
+	0x66, 0xbf, 0xfd, 0x47, 0xbd, 0xfb, 0x7d, 0x93, 0xf7, 0xc1, 0x2a, 0x94, 0xc5, 0x42, 0xef, 0xa6,
+	0xd5, 0xeb, 0xf6, 0xab, 0x19, 0xb4, 0x05, 0x45, 0xb1, 0xd2, 0xbc, 0xfa, 0x4b, 0x35, 0x8b, 0x10,
+	0x54, 0x42, 0x81, 0xeb, 0xae, 0xc6, 0x44, 0x72, 0x47, 0xff, 0xdc, 0x81, 0x72, 0xf4, 0x6e, 0x42,
+	0x7f, 0x85, 0x52, 0xe4, 0xdd, 0x80, 0xd6, 0x5d, 0x63, 0xca, 0xcb, 0x24, 0x4f, 0xe2, 0x1e, 0x37,
+	0x3f, 0xc0, 0x5e, 0xfc, 0xa3, 0x64, 0xbd, 0x9e, 0x93, 0x24, 0x3d, 0x6b, 0x5e, 0x39, 0xb7, 0x50,
+	0x8a, 0xcc, 0xe8, 0xe8, 0xc5, 0xc3, 0x77, 0x50, 0x74, 0x90, 0x57, 0xd6, 0xd9, 0x84, 0x6e, 0x01,
+	0xce, 0x30, 0x1d, 0xdc, 0x89, 0xaf, 0x8f, 0x89, 0xc4, 0x7a, 0xee, 0x33, 0x28, 0xcf, 0xb8, 0xd9,
+	0x94, 0xf0, 0x78, 0x11, 0xd0, 0x9d, 0x78, 0x34, 0x50, 0xbe, 0x78, 0x98, 0x85, 0xe1, 0x6e, 0xa1,
+	0x14, 0x79, 0x8b, 0x25, 0xfb, 0xbf, 0xfa, 0x60, 0x5b, 0x6f, 0xe3, 0x0d, 0x54, 0x58, 0x93, 0x6e,
+	0x05, 0xb3, 0x17, 0xea, 0x7e, 0xf2, 0xdc, 0x22, 0x24, 0xd2, 0x98, 0xfc, 0xa7, 0x90, 0x36, 0x4c,
+	0x78, 0x94, 0x50, 0xb8, 0x69, 0xc8, 0x2e, 0x61, 0x7b, 0x91, 0x8c, 0xa0, 0xa7, 0xf1, 0x6c, 0x24,
+	0x0d, 0xdd, 0xcc, 0xe5, 0xd9, 0xc3, 0x3b, 0xd1, 0xe5, 0x50, 0x22, 0x0d, 0xed, 0x3d, 0x3c, 0x5d,
+	0x1c, 0x2c, 0xd9, 0xd4, 0x7e, 0x6d, 0x8c, 0x30, 0x41, 0xbf, 0xfd, 0xa8, 0x87, 0x93, 0x52, 0x4f,
+	0x2b, 0x2e, 0xeb, 0xe3, 0x06, 0x9e, 0x88, 0x0a, 0x5a, 0x9e, 0x1f, 0x7f, 0x9d, 0x44, 0xb4, 0x24,
+	0xa8, 0xc4, 0x65, 0x26, 0xfa, 0x1b, 0xec, 0xf2, 0xf4, 0x5d, 0x66, 0xfd, 0x4d, 0x4a, 0xd6, 0xf3,
+	0x8e, 0x92, 0xd6, 0x00, 0xf4, 0x06, 0x76, 0x99, 0x73, 0x4b, 0xcb, 0x09, 0x25, 0x93, 0x96, 0xf5,
+	0x30, 0xc3, 0x42, 0x23, 0xaa, 0xe2, 0x7f, 0x1b, 0x1a, 0x13, 0x9e, 0xc4, 0x0e, 0xbc, 0xe8, 0xf8,
+	0x53, 0xe6, 0xe3, 0x78, 0x1d, 0x6f, 0x61, 0x5b, 0x9c, 0xea, 0x7c, 0xfa, 0xfd, 0x22, 0x89, 0x7d,
+	0x26, 0xa2, 0xac, 0x17, 0x41, 0x2d, 0x28, 0xf1, 0x73, 0x95, 0x26, 0xc7, 0x86, 0xf8, 0x79, 0x12,
+	0x8d, 0x04, 0x0d, 0x00, 0xe6, 0x23, 0x56, 0x72, 0x46, 0xac, 0xcc, 0x6d, 0xca, 0x8b, 0x34, 0xa2,
+	0x32, 0xaf, 0x07, 0x00, 0xf3, 0x01, 0x32, 0x59, 0xc9, 0xca, 0xbc, 0x9e, 0xac, 0x24, 0x66, 0x1e,
+	0xb5, 0xa1, 0x1c, 0x9d, 0xb8, 0x92, 0xaf, 0x80, 0x98, 0x61, 0x50, 0xf9, 0x3a, 0x9d, 0xb0, 0x54,
+	0x35, 0x84, 0x52, 0x64, 0x52, 0x4a, 0xee, 0xe3, 0xab, 0xf3, 0x9a, 0xf2, 0x32, 0x95, 0xac, 0xd4,
+	0x33, 0x85, 0xea, 0xf2, 0x20, 0x83, 0x1a, 0x49, 0x04, 0x09, 0xa3, 0x92, 0x72, 0x98, 0x1e, 0x20,
+	0xd4, 0xb6, 0x4e, 0x6e, 0x8f, 0x47, 0x36, 0xbd, 0x9b, 0x9a, 0x2c, 0x95, 0x1a, 0x62, 0xac, 0x6a,
+	0x88, 0x7f, 0x9e, 0xf2, 0xc7, 0x82, 0xfc, 0x6d, 0x78, 0x76, 0x23, 0x4a, 0x68, 0xe6, 0xf9, 0xee,
+	0x37, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xd0, 0x49, 0xef, 0x17, 0xb5, 0x15, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1259,7 +1580,7 @@ type RegistrationClient interface {
 	// Creates an entry in the Registration table if one like it doesn't already exist
 	CreateEntryIfNotExists(ctx context.Context, in *common.RegistrationEntry, opts ...grpc.CallOption) (*CreateEntryIfNotExistsResponse, error)
 	// Deletes an entry and returns the deleted entry.
-	DeleteEntry(ctx context.Context, in *RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*common.RegistrationEntry, error)
 	// Retrieve a specific registered entry.
 	FetchEntry(ctx context.Context, in *RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error)
 	// Retrieve all registered entries.
@@ -1328,7 +1649,7 @@ func (c *registrationClient) CreateEntryIfNotExists(ctx context.Context, in *com
 	return out, nil
 }
 
-func (c *registrationClient) DeleteEntry(ctx context.Context, in *RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
+func (c *registrationClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	out := new(common.RegistrationEntry)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/DeleteEntry", in, out, opts...)
 	if err != nil {
@@ -1547,7 +1868,7 @@ type RegistrationServer interface {
 	// Creates an entry in the Registration table if one like it doesn't already exist
 	CreateEntryIfNotExists(context.Context, *common.RegistrationEntry) (*CreateEntryIfNotExistsResponse, error)
 	// Deletes an entry and returns the deleted entry.
-	DeleteEntry(context.Context, *RegistrationEntryID) (*common.RegistrationEntry, error)
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*common.RegistrationEntry, error)
 	// Retrieve a specific registered entry.
 	FetchEntry(context.Context, *RegistrationEntryID) (*common.RegistrationEntry, error)
 	// Retrieve all registered entries.
@@ -1600,7 +1921,7 @@ func (*UnimplementedRegistrationServer) CreateEntry(ctx context.Context, req *co
 func (*UnimplementedRegistrationServer) CreateEntryIfNotExists(ctx context.Context, req *common.RegistrationEntry) (*CreateEntryIfNotExistsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEntryIfNotExists not implemented")
 }
-func (*UnimplementedRegistrationServer) DeleteEntry(ctx context.Context, req *RegistrationEntryID) (*common.RegistrationEntry, error) {
+func (*UnimplementedRegistrationServer) DeleteEntry(ctx context.Context, req *DeleteEntryRequest) (*common.RegistrationEntry, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntry not implemented")
 }
 func (*UnimplementedRegistrationServer) FetchEntry(ctx context.Context, req *RegistrationEntryID) (*common.RegistrationEntry, error) {
@@ -1705,7 +2026,7 @@ func _Registration_CreateEntryIfNotExists_Handler(srv interface{}, ctx context.C
 }
 
 func _Registration_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegistrationEntryID)
+	in := new(DeleteEntryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1717,7 +2038,7 @@ func _Registration_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/spire.api.registration.Registration/DeleteEntry",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).DeleteEntry(ctx, req.(*RegistrationEntryID))
+		return srv.(RegistrationServer).DeleteEntry(ctx, req.(*DeleteEntryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
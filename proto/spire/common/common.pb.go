@@ -208,7 +208,20 @@ type AttestedNode struct {
 	// Node certificate serial number
 	NewCertSerialNumber string `protobuf:"bytes,5,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
 	// Node certificate not_after (seconds since unix epoch)
-	NewCertNotAfter      int64    `protobuf:"varint,6,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	NewCertNotAfter int64 `protobuf:"varint,6,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	// Node selectors. Only populated when explicitly requested.
+	Selectors []*Selector `protobuf:"bytes,7,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Version of the agent software, as reported at attestation
+	AgentVersion string `protobuf:"bytes,8,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	// Plugins loaded by the agent, as reported at attestation
+	AgentPlugins []*AgentPlugin `protobuf:"bytes,9,rep,name=agent_plugins,json=agentPlugins,proto3" json:"agent_plugins,omitempty"`
+	// Last time the agent was seen by the server (seconds since unix epoch)
+	LastSeen int64 `protobuf:"varint,10,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Whether the node is banned. Output only; a node is banned when both of
+	// its certificate serial numbers are cleared.
+	Banned bool `protobuf:"varint,11,opt,name=banned,proto3" json:"banned,omitempty"`
+	// Whether the node can re-attest using its attestation data
+	CanReattest          bool     `protobuf:"varint,12,opt,name=can_reattest,json=canReattest,proto3" json:"can_reattest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -281,6 +294,98 @@ func (m *AttestedNode) GetNewCertNotAfter() int64 {
 	return 0
 }
 
+func (m *AttestedNode) GetSelectors() []*Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *AttestedNode) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *AttestedNode) GetAgentPlugins() []*AgentPlugin {
+	if m != nil {
+		return m.AgentPlugins
+	}
+	return nil
+}
+
+func (m *AttestedNode) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *AttestedNode) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+func (m *AttestedNode) GetCanReattest() bool {
+	if m != nil {
+		return m.CanReattest
+	}
+	return false
+}
+
+// A plugin loaded by an agent
+type AgentPlugin struct {
+	// Plugin type (e.g. "NodeAttestor")
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Plugin name (e.g. "join_token")
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AgentPlugin) Reset()         { *m = AgentPlugin{} }
+func (m *AgentPlugin) String() string { return proto.CompactTextString(m) }
+func (*AgentPlugin) ProtoMessage()    {}
+func (*AgentPlugin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{5}
+}
+
+func (m *AgentPlugin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentPlugin.Unmarshal(m, b)
+}
+func (m *AgentPlugin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentPlugin.Marshal(b, m, deterministic)
+}
+func (m *AgentPlugin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentPlugin.Merge(m, src)
+}
+func (m *AgentPlugin) XXX_Size() int {
+	return xxx_messageInfo_AgentPlugin.Size(m)
+}
+func (m *AgentPlugin) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentPlugin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentPlugin proto.InternalMessageInfo
+
+func (m *AgentPlugin) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AgentPlugin) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 //* This is a curated record that the Server uses to set up and
 //manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
@@ -308,7 +413,15 @@ type RegistrationEntry struct {
 	//* Expiration of this entry, in seconds from epoch
 	EntryExpiry int64 `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
 	//* DNS entries
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	//* Revision number of the entry, incremented each time the entry is
+	//updated
+	RevisionNumber int64 `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	//* Customization of the X509-SVIDs issued for the entry
+	X509SvidProfile *X509SVIDProfile `protobuf:"bytes,12,opt,name=x509_svid_profile,json=x509SvidProfile,proto3" json:"x509_svid_profile,omitempty"`
+	//* Time to live of the JWT-SVIDs issued for the entry, in seconds. The
+	//server default is used if unset.
+	JwtSvidTtl           int32    `protobuf:"varint,13,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -318,7 +431,7 @@ func (m *RegistrationEntry) Reset()         { *m = RegistrationEntry{} }
 func (m *RegistrationEntry) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntry) ProtoMessage()    {}
 func (*RegistrationEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{5}
+	return fileDescriptor_555bd8c177793206, []int{6}
 }
 
 func (m *RegistrationEntry) XXX_Unmarshal(b []byte) error {
@@ -409,6 +522,295 @@ func (m *RegistrationEntry) GetDnsNames() []string {
 	return nil
 }
 
+func (m *RegistrationEntry) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
+func (m *RegistrationEntry) GetX509SvidProfile() *X509SVIDProfile {
+	if m != nil {
+		return m.X509SvidProfile
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+//* Customizes the X509-SVIDs issued for a registration entry.
+type X509SVIDProfile struct {
+	//* Subject of the X509-SVIDs. The default subject is used if unset.
+	Subject *X509Subject `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	//* IP address SANs
+	IpAddresses []string `protobuf:"bytes,2,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	//* URI SANs, added after the SPIFFE ID
+	Uris []string `protobuf:"bytes,3,rep,name=uris,proto3" json:"uris,omitempty"`
+	//* Extended key usages, among "serverAuth" and "clientAuth". Both are
+	//used if unset.
+	ExtKeyUsages         []string `protobuf:"bytes,4,rep,name=ext_key_usages,json=extKeyUsages,proto3" json:"ext_key_usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDProfile) Reset()         { *m = X509SVIDProfile{} }
+func (m *X509SVIDProfile) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDProfile) ProtoMessage()    {}
+func (*X509SVIDProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{7}
+}
+
+func (m *X509SVIDProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDProfile.Unmarshal(m, b)
+}
+func (m *X509SVIDProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDProfile.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDProfile.Merge(m, src)
+}
+func (m *X509SVIDProfile) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDProfile.Size(m)
+}
+func (m *X509SVIDProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDProfile proto.InternalMessageInfo
+
+func (m *X509SVIDProfile) GetSubject() *X509Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetUris() []string {
+	if m != nil {
+		return m.Uris
+	}
+	return nil
+}
+
+func (m *X509SVIDProfile) GetExtKeyUsages() []string {
+	if m != nil {
+		return m.ExtKeyUsages
+	}
+	return nil
+}
+
+//* The subject of an X509 certificate.
+type X509Subject struct {
+	//* Country values
+	Country []string `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+	//* Organization values
+	Organization []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	//* OrganizationalUnit values
+	OrganizationalUnit []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	//* CommonName value
+	CommonName           string   `protobuf:"bytes,4,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509Subject) Reset()         { *m = X509Subject{} }
+func (m *X509Subject) String() string { return proto.CompactTextString(m) }
+func (*X509Subject) ProtoMessage()    {}
+func (*X509Subject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{8}
+}
+
+func (m *X509Subject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509Subject.Unmarshal(m, b)
+}
+func (m *X509Subject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509Subject.Marshal(b, m, deterministic)
+}
+func (m *X509Subject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509Subject.Merge(m, src)
+}
+func (m *X509Subject) XXX_Size() int {
+	return xxx_messageInfo_X509Subject.Size(m)
+}
+func (m *X509Subject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509Subject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509Subject proto.InternalMessageInfo
+
+func (m *X509Subject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509Subject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+//* Field mask for RegistrationEntry fields
+type RegistrationEntryMask struct {
+	//* selectors field mask
+	Selectors bool `protobuf:"varint,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	//* parent_id field mask
+	ParentId bool `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	//* spiffe_id field mask
+	SpiffeId bool `protobuf:"varint,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	//* ttl field mask
+	Ttl bool `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	//* federates_with field mask
+	FederatesWith bool `protobuf:"varint,5,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	//* admin field mask
+	Admin bool `protobuf:"varint,6,opt,name=admin,proto3" json:"admin,omitempty"`
+	//* downstream field mask
+	Downstream bool `protobuf:"varint,7,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	//* entryExpiry field mask
+	EntryExpiry bool `protobuf:"varint,8,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
+	//* dns_names field mask
+	DnsNames bool `protobuf:"varint,9,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	//* x509_svid_profile field mask
+	X509SvidProfile bool `protobuf:"varint,10,opt,name=x509_svid_profile,json=x509SvidProfile,proto3" json:"x509_svid_profile,omitempty"`
+	//* jwt_svid_ttl field mask
+	JwtSvidTtl           bool     `protobuf:"varint,11,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegistrationEntryMask) Reset()         { *m = RegistrationEntryMask{} }
+func (m *RegistrationEntryMask) String() string { return proto.CompactTextString(m) }
+func (*RegistrationEntryMask) ProtoMessage()    {}
+func (*RegistrationEntryMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{9}
+}
+
+func (m *RegistrationEntryMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegistrationEntryMask.Unmarshal(m, b)
+}
+func (m *RegistrationEntryMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegistrationEntryMask.Marshal(b, m, deterministic)
+}
+func (m *RegistrationEntryMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegistrationEntryMask.Merge(m, src)
+}
+func (m *RegistrationEntryMask) XXX_Size() int {
+	return xxx_messageInfo_RegistrationEntryMask.Size(m)
+}
+func (m *RegistrationEntryMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegistrationEntryMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegistrationEntryMask proto.InternalMessageInfo
+
+func (m *RegistrationEntryMask) GetSelectors() bool {
+	if m != nil {
+		return m.Selectors
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetParentId() bool {
+	if m != nil {
+		return m.ParentId
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetSpiffeId() bool {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetTtl() bool {
+	if m != nil {
+		return m.Ttl
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetFederatesWith() bool {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetDownstream() bool {
+	if m != nil {
+		return m.Downstream
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetEntryExpiry() bool {
+	if m != nil {
+		return m.EntryExpiry
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetDnsNames() bool {
+	if m != nil {
+		return m.DnsNames
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetX509SvidProfile() bool {
+	if m != nil {
+		return m.X509SvidProfile
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetJwtSvidTtl() bool {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return false
+}
+
 //* A list of registration entries.
 type RegistrationEntries struct {
 	//* A list of RegistrationEntry.
@@ -422,7 +824,7 @@ func (m *RegistrationEntries) Reset()         { *m = RegistrationEntries{} }
 func (m *RegistrationEntries) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntries) ProtoMessage()    {}
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{6}
+	return fileDescriptor_555bd8c177793206, []int{10}
 }
 
 func (m *RegistrationEntries) XXX_Unmarshal(b []byte) error {
@@ -452,7 +854,10 @@ func (m *RegistrationEntries) GetEntries() []*RegistrationEntry {
 
 //* Certificate represents a ASN.1/DER encoded X509 certificate
 type Certificate struct {
-	DerBytes             []byte   `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	DerBytes []byte `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	//* whether the key of the certificate has been tainted. SVIDs issued by
+	//a tainted authority are rotated by the agents.
+	TaintedKey           bool     `protobuf:"varint,2,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -462,7 +867,7 @@ func (m *Certificate) Reset()         { *m = Certificate{} }
 func (m *Certificate) String() string { return proto.CompactTextString(m) }
 func (*Certificate) ProtoMessage()    {}
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{7}
+	return fileDescriptor_555bd8c177793206, []int{11}
 }
 
 func (m *Certificate) XXX_Unmarshal(b []byte) error {
@@ -490,6 +895,13 @@ func (m *Certificate) GetDerBytes() []byte {
 	return nil
 }
 
+func (m *Certificate) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
 //* PublicKey represents a PKIX encoded public key
 type PublicKey struct {
 	//* PKIX encoded key data
@@ -497,7 +909,10 @@ type PublicKey struct {
 	//* key identifier
 	Kid string `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
 	//* not after (seconds since unix epoch, 0 means "never expires")
-	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	//* whether the key has been tainted. JWT-SVIDs signed by a tainted key
+	//are renewed by the agents.
+	TaintedKey           bool     `protobuf:"varint,4,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -507,7 +922,7 @@ func (m *PublicKey) Reset()         { *m = PublicKey{} }
 func (m *PublicKey) String() string { return proto.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()    {}
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{8}
+	return fileDescriptor_555bd8c177793206, []int{12}
 }
 
 func (m *PublicKey) XXX_Unmarshal(b []byte) error {
@@ -549,6 +964,13 @@ func (m *PublicKey) GetNotAfter() int64 {
 	return 0
 }
 
+func (m *PublicKey) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
 type Bundle struct {
 	//* the SPIFFE ID of the trust domain the bundle belongs to
 	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
@@ -568,7 +990,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{9}
+	return fileDescriptor_555bd8c177793206, []int{13}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -623,7 +1045,11 @@ func init() {
 	proto.RegisterType((*Selector)(nil), "spire.common.Selector")
 	proto.RegisterType((*Selectors)(nil), "spire.common.Selectors")
 	proto.RegisterType((*AttestedNode)(nil), "spire.common.AttestedNode")
+	proto.RegisterType((*AgentPlugin)(nil), "spire.common.AgentPlugin")
 	proto.RegisterType((*RegistrationEntry)(nil), "spire.common.RegistrationEntry")
+	proto.RegisterType((*X509SVIDProfile)(nil), "spire.common.X509SVIDProfile")
+	proto.RegisterType((*X509Subject)(nil), "spire.common.X509Subject")
+	proto.RegisterType((*RegistrationEntryMask)(nil), "spire.common.RegistrationEntryMask")
 	proto.RegisterType((*RegistrationEntries)(nil), "spire.common.RegistrationEntries")
 	proto.RegisterType((*Certificate)(nil), "spire.common.Certificate")
 	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
@@ -633,48 +1059,75 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 687 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdd, 0x6e, 0xf3, 0x44,
-	0x10, 0x55, 0xe2, 0x26, 0xb1, 0x27, 0xe9, 0x0f, 0x5b, 0x28, 0xae, 0x10, 0x10, 0x2c, 0x40, 0x51,
-	0xa9, 0x52, 0xd4, 0xf6, 0xa6, 0x17, 0x5c, 0xf4, 0x4f, 0x22, 0xaa, 0x14, 0x55, 0x2e, 0x12, 0x82,
-	0x1b, 0x6b, 0x93, 0x9d, 0x24, 0xdb, 0xc6, 0x6b, 0x6b, 0x77, 0x42, 0xea, 0x07, 0xe1, 0xad, 0x78,
-	0x28, 0xb4, 0xeb, 0xfc, 0x7f, 0x95, 0xbe, 0xbb, 0xd9, 0xe3, 0x99, 0xb3, 0x73, 0xce, 0xcc, 0x1a,
-	0x5a, 0xc3, 0x2c, 0x4d, 0x33, 0xd5, 0xcd, 0x75, 0x46, 0x19, 0x6b, 0x99, 0x5c, 0x6a, 0xec, 0x96,
-	0x58, 0xd4, 0x80, 0xda, 0x63, 0x9a, 0x53, 0x11, 0xdd, 0xc0, 0xe1, 0x2d, 0x11, 0x1a, 0xe2, 0x24,
-	0x33, 0xf5, 0xc0, 0x89, 0x33, 0x06, 0x7b, 0x54, 0xe4, 0x18, 0x56, 0xda, 0x95, 0x4e, 0x10, 0xbb,
-	0xd8, 0x62, 0x82, 0x13, 0x0f, 0xab, 0xed, 0x4a, 0xa7, 0x15, 0xbb, 0x38, 0xba, 0x06, 0xff, 0x05,
-	0xa7, 0x38, 0xa4, 0x4c, 0x7f, 0x58, 0xf3, 0x25, 0xd4, 0xfe, 0xe1, 0xd3, 0x19, 0xba, 0xa2, 0x20,
-	0x2e, 0x0f, 0xd1, 0x6f, 0x10, 0x2c, 0xab, 0x0c, 0xfb, 0x15, 0x1a, 0xa8, 0x48, 0x4b, 0x34, 0x61,
-	0xa5, 0xed, 0x75, 0x9a, 0x97, 0x27, 0xdd, 0xcd, 0x36, 0xbb, 0xcb, 0xcc, 0x78, 0x99, 0x16, 0xfd,
-	0x5b, 0x85, 0x56, 0xd9, 0x30, 0x8a, 0x7e, 0x26, 0x90, 0x7d, 0x03, 0x81, 0xc9, 0xe5, 0x68, 0x84,
-	0x89, 0x14, 0x8b, 0xeb, 0xfd, 0x12, 0xe8, 0x09, 0x76, 0x09, 0x5f, 0xf1, 0xb5, 0xba, 0xc4, 0xb6,
-	0x9d, 0xb8, 0x3e, 0xcb, 0x96, 0x8e, 0xf9, 0xb6, 0xf4, 0x3f, 0x6c, 0xdb, 0xe7, 0xc0, 0x86, 0xa8,
-	0x29, 0x31, 0xa8, 0x25, 0x9f, 0x26, 0x6a, 0x96, 0x0e, 0x50, 0x87, 0x9e, 0x2b, 0x38, 0xb2, 0x5f,
-	0x5e, 0xdc, 0x87, 0xbe, 0xc3, 0xd9, 0x8f, 0x70, 0xe0, 0xb2, 0x55, 0x46, 0x09, 0x1f, 0x11, 0xea,
-	0x70, 0xaf, 0x5d, 0xe9, 0x78, 0x71, 0xcb, 0xa2, 0xfd, 0x8c, 0x6e, 0x2d, 0xc6, 0xae, 0xe0, 0x44,
-	0xe1, 0x3c, 0xf9, 0x80, 0xb7, 0x56, 0x36, 0xa2, 0x70, 0x7e, 0xbf, 0x4b, 0xfd, 0x0b, 0xb0, 0x55,
-	0xd1, 0x9a, 0xbe, 0xee, 0xe8, 0x0f, 0x17, 0x05, 0xcb, 0x1b, 0xa2, 0xff, 0xaa, 0xf0, 0x45, 0x8c,
-	0x63, 0x69, 0x48, 0x3b, 0x39, 0x8f, 0x8a, 0x74, 0xc1, 0xae, 0x21, 0x30, 0x4b, 0xb3, 0x3f, 0xe3,
-	0xf0, 0x3a, 0xd1, 0x5a, 0x9a, 0x73, 0x8d, 0x8a, 0xac, 0xa5, 0xa5, 0x53, 0x7e, 0x09, 0xf4, 0xc4,
-	0xb6, 0xdf, 0xde, 0x8e, 0xdf, 0x47, 0xe0, 0x11, 0x4d, 0x9d, 0x05, 0xb5, 0xd8, 0x86, 0xec, 0x27,
-	0x38, 0x18, 0xa1, 0x40, 0xcd, 0x09, 0x4d, 0x32, 0x97, 0x34, 0x09, 0x6b, 0x6d, 0xaf, 0x13, 0xc4,
-	0xfb, 0x2b, 0xf4, 0x4f, 0x49, 0x13, 0x76, 0x0a, 0xbe, 0x9d, 0x70, 0x61, 0x49, 0xeb, 0x8e, 0xd4,
-	0x4d, 0xbc, 0xe8, 0x09, 0xbb, 0x46, 0x5c, 0xa4, 0x52, 0x85, 0x8d, 0x76, 0xa5, 0xe3, 0xc7, 0xe5,
-	0x81, 0x7d, 0x07, 0x20, 0xb2, 0xb9, 0x32, 0xa4, 0x91, 0xa7, 0xa1, 0xef, 0x3e, 0x6d, 0x20, 0xac,
-	0x0d, 0x4d, 0x47, 0xf0, 0xf8, 0x9e, 0x4b, 0x5d, 0x84, 0x81, 0x73, 0x6d, 0x13, 0xb2, 0x42, 0x84,
-	0x32, 0x89, 0xe2, 0x29, 0x9a, 0x10, 0x5c, 0x53, 0xbe, 0x50, 0xa6, 0x6f, 0xcf, 0xd1, 0x33, 0x1c,
-	0xef, 0xba, 0x29, 0xd1, 0xb0, 0x9b, 0xdd, 0x7d, 0xfd, 0x7e, 0xdb, 0xcd, 0x4f, 0x26, 0xb0, 0x5e,
-	0xdc, 0x33, 0x68, 0xda, 0x81, 0xc9, 0x91, 0x1c, 0x72, 0x72, 0x6b, 0x2b, 0x50, 0x27, 0x83, 0x82,
-	0x1c, 0x97, 0x7d, 0x55, 0xbe, 0x40, 0x7d, 0x67, 0xcf, 0xd1, 0x5f, 0x10, 0x3c, 0xcf, 0x06, 0x53,
-	0x39, 0x7c, 0xc2, 0x82, 0x7d, 0x0b, 0x90, 0xbf, 0xc9, 0xf7, 0xad, 0xd4, 0xc0, 0x22, 0x2e, 0xd7,
-	0x5a, 0xfe, 0xb6, 0x1a, 0x93, 0x0d, 0x2d, 0xf5, 0x7a, 0x5d, 0x3c, 0x27, 0xdc, 0x57, 0xab, 0x3d,
-	0xa9, 0x40, 0xfd, 0x6e, 0xa6, 0xc4, 0x14, 0xd9, 0xcf, 0x70, 0x48, 0x7a, 0x66, 0x28, 0x11, 0x59,
-	0xca, 0xa5, 0x5a, 0xbf, 0x9f, 0x7d, 0x07, 0x3f, 0x38, 0xb4, 0x27, 0xd8, 0x35, 0xf8, 0x3a, 0xcb,
-	0x28, 0x19, 0x72, 0x13, 0x56, 0x9d, 0xea, 0xd3, 0x6d, 0xd5, 0x1b, 0xba, 0xe2, 0x86, 0x4d, 0xbd,
-	0xe7, 0x86, 0xdd, 0xc2, 0xd1, 0xeb, 0x9c, 0x12, 0x23, 0xc7, 0x4a, 0xaa, 0x71, 0xf2, 0x86, 0x85,
-	0x09, 0x3d, 0x57, 0xfd, 0xf5, 0x76, 0xf5, 0x4a, 0x69, 0x7c, 0xf0, 0x3a, 0xa7, 0x97, 0x32, 0xff,
-	0x09, 0x0b, 0xc3, 0x7e, 0x80, 0x96, 0xc6, 0x91, 0x46, 0x33, 0x49, 0x26, 0x52, 0xd1, 0xe2, 0x65,
-	0x35, 0x17, 0xd8, 0xef, 0x52, 0xd1, 0xdd, 0xf9, 0xdf, 0x67, 0x63, 0x49, 0x93, 0xd9, 0xc0, 0xb2,
-	0x5d, 0x94, 0x7b, 0x78, 0xe1, 0xe8, 0x2f, 0xdc, 0x6f, 0x6f, 0x11, 0x97, 0x57, 0x0d, 0xea, 0x0e,
-	0xbb, 0xfa, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xec, 0x32, 0xf4, 0x74, 0x1a, 0x05, 0x00, 0x00,
+	// 1119 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0xdc, 0x36,
+	0x10, 0xc5, 0x66, 0x6d, 0xaf, 0x34, 0x92, 0xbd, 0x0e, 0xd3, 0xa4, 0x0a, 0xd2, 0x34, 0x5b, 0xf5,
+	0xb6, 0x48, 0x03, 0x3b, 0x70, 0x92, 0x07, 0x3f, 0xb4, 0x80, 0x73, 0x01, 0xba, 0x30, 0x6a, 0x18,
+	0x72, 0x92, 0x16, 0x7d, 0x11, 0xb8, 0xcb, 0xd9, 0x35, 0xe3, 0x5d, 0x4a, 0x20, 0x29, 0xaf, 0x95,
+	0x2f, 0xe8, 0x4f, 0xf4, 0xa9, 0xbf, 0xd3, 0xaf, 0xe8, 0x6b, 0x7f, 0xa2, 0x20, 0x29, 0xed, 0xcd,
+	0x97, 0xf4, 0x8d, 0x3c, 0x9c, 0x39, 0x33, 0x9c, 0x39, 0x23, 0x0a, 0xc2, 0x41, 0x36, 0x99, 0x64,
+	0x62, 0x27, 0x97, 0x99, 0xce, 0x48, 0xa8, 0x72, 0x2e, 0x71, 0xc7, 0x61, 0x71, 0x0b, 0xd6, 0xdf,
+	0x4c, 0x72, 0x5d, 0xc6, 0xfb, 0xd0, 0x3e, 0xd0, 0x1a, 0x95, 0xa6, 0x9a, 0x67, 0xe2, 0x35, 0xd5,
+	0x94, 0x10, 0x58, 0xd3, 0x65, 0x8e, 0x51, 0xa3, 0xd3, 0xe8, 0xfa, 0x89, 0x5d, 0x1b, 0x8c, 0x51,
+	0x4d, 0xa3, 0x5b, 0x9d, 0x46, 0x37, 0x4c, 0xec, 0x3a, 0x7e, 0x0e, 0xde, 0x09, 0x8e, 0x71, 0xa0,
+	0x33, 0x79, 0xa5, 0xcf, 0x67, 0xb0, 0x7e, 0x4e, 0xc7, 0x05, 0x5a, 0x27, 0x3f, 0x71, 0x9b, 0xf8,
+	0x47, 0xf0, 0x6b, 0x2f, 0x45, 0x9e, 0x42, 0x0b, 0x85, 0x96, 0x1c, 0x55, 0xd4, 0xe8, 0x34, 0xbb,
+	0xc1, 0xde, 0xbd, 0x9d, 0xc5, 0x34, 0x77, 0x6a, 0xcb, 0xa4, 0x36, 0x8b, 0xff, 0x58, 0x83, 0xd0,
+	0x25, 0x8c, 0xec, 0x28, 0x63, 0x48, 0x1e, 0x80, 0xaf, 0x72, 0x3e, 0x1c, 0x62, 0xca, 0x59, 0x15,
+	0xde, 0x73, 0x40, 0x8f, 0x91, 0x3d, 0xb8, 0x4b, 0xe7, 0xb7, 0x4b, 0x4d, 0xda, 0xa9, 0xcd, 0xd3,
+	0xa5, 0x74, 0x87, 0x2e, 0x5f, 0xfd, 0xad, 0x49, 0xfb, 0x09, 0x90, 0x01, 0x4a, 0x9d, 0x2a, 0x94,
+	0x9c, 0x8e, 0x53, 0x51, 0x4c, 0xfa, 0x28, 0xa3, 0xa6, 0x75, 0xd8, 0x36, 0x27, 0x27, 0xf6, 0xe0,
+	0xc8, 0xe2, 0xe4, 0x1b, 0xd8, 0xb2, 0xd6, 0x22, 0xd3, 0x29, 0x1d, 0x6a, 0x94, 0xd1, 0x5a, 0xa7,
+	0xd1, 0x6d, 0x26, 0xa1, 0x41, 0x8f, 0x32, 0x7d, 0x60, 0x30, 0xf2, 0x0c, 0xee, 0x09, 0x9c, 0xa6,
+	0x57, 0xf0, 0xae, 0xbb, 0x44, 0x04, 0x4e, 0x5f, 0xad, 0x52, 0xff, 0x00, 0x64, 0xe6, 0x34, 0xa7,
+	0xdf, 0xb0, 0xf4, 0xed, 0xca, 0x61, 0x16, 0xe1, 0x39, 0xf8, 0xaa, 0x2e, 0x6b, 0xd4, 0xba, 0xb1,
+	0x96, 0x73, 0x43, 0xf2, 0x35, 0x6c, 0xd2, 0x11, 0x0a, 0x9d, 0x9e, 0xa3, 0x54, 0x3c, 0x13, 0x91,
+	0x67, 0xd3, 0x09, 0x2d, 0xf8, 0xde, 0x61, 0xe4, 0xa7, 0xda, 0x28, 0x1f, 0x17, 0x23, 0x2e, 0x54,
+	0xe4, 0x5b, 0xfa, 0xfb, 0xcb, 0xf4, 0x07, 0xc6, 0xe4, 0xd8, 0x5a, 0x54, 0xfe, 0x6e, 0xa3, 0x4c,
+	0x87, 0xc6, 0x54, 0x99, 0x8b, 0xa3, 0x88, 0xc0, 0xa6, 0xef, 0x19, 0xe0, 0x04, 0x51, 0x90, 0x7b,
+	0xb0, 0xd1, 0xa7, 0x42, 0x20, 0x8b, 0x82, 0x4e, 0xa3, 0xeb, 0x25, 0xd5, 0x8e, 0x7c, 0x05, 0xe1,
+	0x80, 0x8a, 0x54, 0xa2, 0x6b, 0x51, 0x14, 0xda, 0xd3, 0x60, 0x40, 0x45, 0x52, 0x41, 0xf1, 0x0b,
+	0x08, 0x16, 0x82, 0x5e, 0x27, 0x5b, 0x41, 0x27, 0x75, 0xbb, 0xed, 0x3a, 0xfe, 0xa7, 0x09, 0xb7,
+	0x13, 0x1c, 0x71, 0xa5, 0xa5, 0x6d, 0xfc, 0x1b, 0xa1, 0x65, 0xb9, 0x5c, 0xbf, 0xc6, 0xff, 0xad,
+	0xdf, 0x03, 0xf0, 0x73, 0x2a, 0x4d, 0x6d, 0x38, 0xab, 0x82, 0x78, 0x0e, 0xe8, 0xb1, 0x65, 0x65,
+	0x36, 0x57, 0x94, 0xb9, 0x0d, 0x4d, 0xad, 0xc7, 0x56, 0x2c, 0xeb, 0x89, 0x59, 0x92, 0x6f, 0x61,
+	0x6b, 0x88, 0x0c, 0x25, 0xd5, 0xa8, 0xd2, 0x29, 0xd7, 0xa7, 0xd1, 0x7a, 0xa7, 0xd9, 0xf5, 0x93,
+	0xcd, 0x19, 0xfa, 0x2b, 0xd7, 0xa7, 0xe4, 0x3e, 0x78, 0x66, 0x16, 0x4a, 0x43, 0xba, 0x61, 0x49,
+	0xed, 0x6c, 0x94, 0x3d, 0x66, 0x06, 0x8e, 0xb2, 0x09, 0x17, 0x51, 0xcb, 0x16, 0xcb, 0x6d, 0xc8,
+	0x97, 0x00, 0x2c, 0x9b, 0x0a, 0xa5, 0x25, 0xd2, 0x89, 0x6d, 0xb0, 0x97, 0x2c, 0x20, 0xa4, 0x03,
+	0x81, 0x25, 0x78, 0x73, 0x91, 0x73, 0x59, 0x46, 0xbe, 0x6d, 0xd0, 0x22, 0x64, 0x2e, 0xc2, 0x84,
+	0x4a, 0x4d, 0xf5, 0x54, 0x04, 0x36, 0x29, 0x8f, 0x09, 0x75, 0x64, 0xf6, 0xe4, 0x7b, 0x68, 0x4b,
+	0x3c, 0xe7, 0x46, 0x29, 0xb5, 0xa6, 0x03, 0x4b, 0xb1, 0x55, 0xc3, 0x95, 0x9c, 0x7b, 0x70, 0xfb,
+	0xe2, 0xc5, 0xd3, 0xfd, 0x54, 0x9d, 0x73, 0x96, 0xe6, 0x32, 0x1b, 0xf2, 0x31, 0xda, 0xb6, 0x06,
+	0x7b, 0x0f, 0x97, 0x2b, 0xfd, 0xdb, 0x8b, 0xa7, 0xfb, 0x27, 0xef, 0x7b, 0xaf, 0x8f, 0x9d, 0x51,
+	0xd2, 0x36, 0x7e, 0x27, 0xe7, 0x9c, 0x55, 0x00, 0xe9, 0x40, 0xf8, 0x61, 0xaa, 0x1d, 0x93, 0xa9,
+	0xe2, 0xa6, 0xad, 0x22, 0x7c, 0x98, 0x6a, 0x63, 0xf5, 0x56, 0x8f, 0xe3, 0xbf, 0x1a, 0xd0, 0x5e,
+	0xa1, 0x21, 0xcf, 0xa0, 0xa5, 0x8a, 0xfe, 0x07, 0x1c, 0x68, 0xab, 0x91, 0x4b, 0x0a, 0xb6, 0xf6,
+	0xce, 0x20, 0xa9, 0x2d, 0x8d, 0x0e, 0x79, 0x9e, 0x52, 0xc6, 0x24, 0x2a, 0x85, 0x2a, 0xba, 0x65,
+	0xaf, 0x1f, 0xf0, 0xfc, 0xa0, 0x86, 0x8c, 0xc8, 0x0a, 0xc9, 0x55, 0xd4, 0xb4, 0x47, 0x76, 0x6d,
+	0x3e, 0x0b, 0x78, 0xa1, 0xd3, 0x33, 0x2c, 0xd3, 0x42, 0xd1, 0x11, 0xaa, 0x68, 0xcd, 0x9e, 0x86,
+	0x78, 0xa1, 0x0f, 0xb1, 0x7c, 0x67, 0xb1, 0xf8, 0xcf, 0x06, 0x04, 0x0b, 0x51, 0x49, 0x04, 0xad,
+	0x41, 0x56, 0x98, 0xca, 0x5b, 0x09, 0xfa, 0x49, 0xbd, 0x25, 0x31, 0x84, 0x99, 0x1c, 0x51, 0xc1,
+	0x3f, 0x5a, 0xcd, 0x56, 0x69, 0x2c, 0x61, 0x64, 0x17, 0xee, 0x2c, 0xee, 0xe9, 0x38, 0x2d, 0x04,
+	0xd7, 0x55, 0x5a, 0x64, 0xf9, 0xe8, 0x9d, 0xe0, 0x9a, 0x3c, 0x82, 0xc0, 0x5d, 0xdd, 0xb6, 0xd6,
+	0x6a, 0xd1, 0x4f, 0xc0, 0x41, 0xa6, 0xb9, 0xf1, 0xbf, 0xb7, 0xe0, 0xee, 0xa5, 0x51, 0xf9, 0x85,
+	0xaa, 0x33, 0xf2, 0xc5, 0xf2, 0xb8, 0x18, 0x4d, 0xdd, 0x34, 0x16, 0xde, 0x4d, 0x63, 0xe1, 0x5d,
+	0x3d, 0x16, 0xde, 0xf5, 0x63, 0x61, 0x0e, 0x57, 0xc6, 0x62, 0xa6, 0xfd, 0x8d, 0xeb, 0xb5, 0xdf,
+	0xfa, 0x94, 0xf6, 0xdd, 0x70, 0x5c, 0xaf, 0x7d, 0xdf, 0x65, 0x3b, 0xd3, 0xfe, 0xe3, 0xab, 0x24,
+	0x0d, 0xd6, 0xe8, 0x93, 0x9a, 0x75, 0x9f, 0xbb, 0x45, 0xcd, 0x1e, 0xc3, 0x9d, 0xd5, 0x62, 0x73,
+	0x54, 0x64, 0x7f, 0xf5, 0x8d, 0x7c, 0xb4, 0x2c, 0xdb, 0x4b, 0x0d, 0x9a, 0x3f, 0x96, 0x87, 0x10,
+	0x98, 0x47, 0x82, 0x0f, 0xf9, 0x80, 0x6a, 0xfb, 0x54, 0x32, 0x94, 0x69, 0xbf, 0xd4, 0xe8, 0x9a,
+	0x16, 0x26, 0x1e, 0x43, 0xf9, 0xd2, 0xec, 0x8d, 0x18, 0x34, 0xe5, 0x42, 0x23, 0x33, 0xaa, 0xad,
+	0xba, 0x06, 0x15, 0x74, 0x88, 0x65, 0xfc, 0x11, 0xfc, 0xe3, 0xa2, 0x3f, 0xe6, 0x83, 0x43, 0x2c,
+	0xc9, 0x43, 0x80, 0xfc, 0x8c, 0x5f, 0x2c, 0x71, 0xf9, 0x06, 0x71, 0x64, 0xdb, 0xd0, 0x3c, 0x9b,
+	0x7d, 0x11, 0xcd, 0xd2, 0xc4, 0x9e, 0xbf, 0x61, 0x4d, 0xf7, 0x08, 0x88, 0xfa, 0xf1, 0x5a, 0x89,
+	0xbd, 0x76, 0x29, 0xf6, 0xdf, 0x0d, 0xd8, 0x78, 0x59, 0x08, 0x36, 0x46, 0xf2, 0x1d, 0xb4, 0xb5,
+	0x2c, 0x94, 0x4e, 0x59, 0x36, 0xa1, 0x5c, 0xcc, 0x5f, 0xfd, 0x4d, 0x0b, 0xbf, 0xb6, 0x68, 0x8f,
+	0x91, 0xe7, 0xe0, 0xc9, 0x2c, 0xd3, 0xe9, 0x80, 0xba, 0xa1, 0xbd, 0x34, 0xee, 0x0b, 0x95, 0x49,
+	0x5a, 0xc6, 0xf4, 0x15, 0x55, 0xe4, 0x00, 0xb6, 0x6d, 0x97, 0xf8, 0x48, 0x70, 0x31, 0x32, 0xd9,
+	0xb8, 0xb9, 0x0e, 0xf6, 0x3e, 0x5f, 0xf6, 0x9e, 0x95, 0x22, 0xd9, 0x32, 0x2d, 0x74, 0xf6, 0x87,
+	0x58, 0x2a, 0xf3, 0xc5, 0x90, 0x38, 0x94, 0xa8, 0x4e, 0xd3, 0x53, 0x2e, 0x74, 0xf5, 0x3f, 0x10,
+	0x54, 0xd8, 0xcf, 0x5c, 0xe8, 0x97, 0x4f, 0x7e, 0x7f, 0x3c, 0xe2, 0xfa, 0xb4, 0xe8, 0x1b, 0xb6,
+	0x5d, 0x27, 0xfe, 0x5d, 0x4b, 0xbf, 0x6b, 0x7f, 0xd6, 0xaa, 0xb5, 0x0b, 0xd5, 0xdf, 0xb0, 0xd8,
+	0xb3, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x06, 0x3a, 0xa8, 0xa8, 0xd0, 0x09, 0x00, 0x00,
 }
@@ -63,18 +63,28 @@ func (DeleteBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
 type BySelectors_MatchBehavior int32
 
 const (
-	BySelectors_MATCH_EXACT  BySelectors_MatchBehavior = 0
+	// Selectors must be exactly the requested selectors
+	BySelectors_MATCH_EXACT BySelectors_MatchBehavior = 0
+	// Selectors must be a subset of the requested selectors
 	BySelectors_MATCH_SUBSET BySelectors_MatchBehavior = 1
+	// Selectors must include at least one of the requested selectors
+	BySelectors_MATCH_ANY BySelectors_MatchBehavior = 2
+	// Selectors must be a superset of the requested selectors
+	BySelectors_MATCH_SUPERSET BySelectors_MatchBehavior = 3
 )
 
 var BySelectors_MatchBehavior_name = map[int32]string{
 	0: "MATCH_EXACT",
 	1: "MATCH_SUBSET",
+	2: "MATCH_ANY",
+	3: "MATCH_SUPERSET",
 }
 
 var BySelectors_MatchBehavior_value = map[string]int32{
-	"MATCH_EXACT":  0,
-	"MATCH_SUBSET": 1,
+	"MATCH_EXACT":    0,
+	"MATCH_SUBSET":   1,
+	"MATCH_ANY":      2,
+	"MATCH_SUPERSET": 3,
 }
 
 func (x BySelectors_MatchBehavior) String() string {
@@ -82,7 +92,127 @@ func (x BySelectors_MatchBehavior) String() string {
 }
 
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35, 0}
+	return fileDescriptor_d08157cfd31fc929, []int{46, 0}
+}
+
+type ByFederatesWith_MatchBehavior int32
+
+const (
+	// Entries must federate with all of the trust domains
+	ByFederatesWith_MATCH_ALL ByFederatesWith_MatchBehavior = 0
+	// Entries must federate with at least one of the trust domains
+	ByFederatesWith_MATCH_ANY ByFederatesWith_MatchBehavior = 1
+)
+
+var ByFederatesWith_MatchBehavior_name = map[int32]string{
+	0: "MATCH_ALL",
+	1: "MATCH_ANY",
+}
+
+var ByFederatesWith_MatchBehavior_value = map[string]int32{
+	"MATCH_ALL": 0,
+	"MATCH_ANY": 1,
+}
+
+func (x ByFederatesWith_MatchBehavior) String() string {
+	return proto.EnumName(ByFederatesWith_MatchBehavior_name, int32(x))
+}
+
+func (ByFederatesWith_MatchBehavior) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{47, 0}
+}
+
+// BundleEndpointProfile controls how the bundle endpoint is
+// authenticated.
+type FederationRelationship_BundleEndpointProfile int32
+
+const (
+	// HTTPS_SPIFFE authenticates the endpoint using SPIFFE authentication
+	FederationRelationship_HTTPS_SPIFFE FederationRelationship_BundleEndpointProfile = 0
+	// HTTPS_WEB authenticates the endpoint using Web PKI
+	FederationRelationship_HTTPS_WEB FederationRelationship_BundleEndpointProfile = 1
+)
+
+var FederationRelationship_BundleEndpointProfile_name = map[int32]string{
+	0: "HTTPS_SPIFFE",
+	1: "HTTPS_WEB",
+}
+
+var FederationRelationship_BundleEndpointProfile_value = map[string]int32{
+	"HTTPS_SPIFFE": 0,
+	"HTTPS_WEB":    1,
+}
+
+func (x FederationRelationship_BundleEndpointProfile) String() string {
+	return proto.EnumName(FederationRelationship_BundleEndpointProfile_name, int32(x))
+}
+
+func (FederationRelationship_BundleEndpointProfile) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{68, 0}
+}
+
+// Type is the type of change
+type ChangeEvent_Type int32
+
+const (
+	// CREATED is recorded when a resource is created
+	ChangeEvent_CREATED ChangeEvent_Type = 0
+	// UPDATED is recorded when a resource is updated
+	ChangeEvent_UPDATED ChangeEvent_Type = 1
+	// DELETED is recorded when a resource is deleted
+	ChangeEvent_DELETED ChangeEvent_Type = 2
+)
+
+var ChangeEvent_Type_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "DELETED",
+}
+
+var ChangeEvent_Type_value = map[string]int32{
+	"CREATED": 0,
+	"UPDATED": 1,
+	"DELETED": 2,
+}
+
+func (x ChangeEvent_Type) String() string {
+	return proto.EnumName(ChangeEvent_Type_name, int32(x))
+}
+
+func (ChangeEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{79, 0}
+}
+
+// ResourceType is the type of the changed resource
+type ChangeEvent_ResourceType int32
+
+const (
+	// REGISTRATION_ENTRY resources are identified by entry ID
+	ChangeEvent_REGISTRATION_ENTRY ChangeEvent_ResourceType = 0
+	// ATTESTED_NODE resources are identified by agent SPIFFE ID
+	ChangeEvent_ATTESTED_NODE ChangeEvent_ResourceType = 1
+	// BUNDLE resources are identified by trust domain ID
+	ChangeEvent_BUNDLE ChangeEvent_ResourceType = 2
+)
+
+var ChangeEvent_ResourceType_name = map[int32]string{
+	0: "REGISTRATION_ENTRY",
+	1: "ATTESTED_NODE",
+	2: "BUNDLE",
+}
+
+var ChangeEvent_ResourceType_value = map[string]int32{
+	"REGISTRATION_ENTRY": 0,
+	"ATTESTED_NODE":      1,
+	"BUNDLE":             2,
+}
+
+func (x ChangeEvent_ResourceType) String() string {
+	return proto.EnumName(ChangeEvent_ResourceType_name, int32(x))
+}
+
+func (ChangeEvent_ResourceType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{79, 1}
 }
 
 type CreateBundleRequest struct {
@@ -735,6 +865,326 @@ func (m *PruneBundleResponse) GetBundleChanged() bool {
 	return false
 }
 
+type TaintX509CARequest struct {
+	// Trust domain of the bundle
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Subject key ID of the root CA to taint
+	SubjectKeyId         []byte   `protobuf:"bytes,2,opt,name=subject_key_id,json=subjectKeyId,proto3" json:"subject_key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintX509CARequest) Reset()         { *m = TaintX509CARequest{} }
+func (m *TaintX509CARequest) String() string { return proto.CompactTextString(m) }
+func (*TaintX509CARequest) ProtoMessage()    {}
+func (*TaintX509CARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{16}
+}
+
+func (m *TaintX509CARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509CARequest.Unmarshal(m, b)
+}
+func (m *TaintX509CARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509CARequest.Marshal(b, m, deterministic)
+}
+func (m *TaintX509CARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509CARequest.Merge(m, src)
+}
+func (m *TaintX509CARequest) XXX_Size() int {
+	return xxx_messageInfo_TaintX509CARequest.Size(m)
+}
+func (m *TaintX509CARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509CARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509CARequest proto.InternalMessageInfo
+
+func (m *TaintX509CARequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *TaintX509CARequest) GetSubjectKeyId() []byte {
+	if m != nil {
+		return m.SubjectKeyId
+	}
+	return nil
+}
+
+type TaintX509CAResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintX509CAResponse) Reset()         { *m = TaintX509CAResponse{} }
+func (m *TaintX509CAResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintX509CAResponse) ProtoMessage()    {}
+func (*TaintX509CAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{17}
+}
+
+func (m *TaintX509CAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509CAResponse.Unmarshal(m, b)
+}
+func (m *TaintX509CAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509CAResponse.Marshal(b, m, deterministic)
+}
+func (m *TaintX509CAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509CAResponse.Merge(m, src)
+}
+func (m *TaintX509CAResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintX509CAResponse.Size(m)
+}
+func (m *TaintX509CAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509CAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509CAResponse proto.InternalMessageInfo
+
+type RevokeX509CARequest struct {
+	// Trust domain of the bundle
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Subject key ID of the tainted root CA to remove
+	SubjectKeyId         []byte   `protobuf:"bytes,2,opt,name=subject_key_id,json=subjectKeyId,proto3" json:"subject_key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeX509CARequest) Reset()         { *m = RevokeX509CARequest{} }
+func (m *RevokeX509CARequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeX509CARequest) ProtoMessage()    {}
+func (*RevokeX509CARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{18}
+}
+
+func (m *RevokeX509CARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeX509CARequest.Unmarshal(m, b)
+}
+func (m *RevokeX509CARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeX509CARequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeX509CARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeX509CARequest.Merge(m, src)
+}
+func (m *RevokeX509CARequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeX509CARequest.Size(m)
+}
+func (m *RevokeX509CARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeX509CARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeX509CARequest proto.InternalMessageInfo
+
+func (m *RevokeX509CARequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *RevokeX509CARequest) GetSubjectKeyId() []byte {
+	if m != nil {
+		return m.SubjectKeyId
+	}
+	return nil
+}
+
+type RevokeX509CAResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeX509CAResponse) Reset()         { *m = RevokeX509CAResponse{} }
+func (m *RevokeX509CAResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeX509CAResponse) ProtoMessage()    {}
+func (*RevokeX509CAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{19}
+}
+
+func (m *RevokeX509CAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeX509CAResponse.Unmarshal(m, b)
+}
+func (m *RevokeX509CAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeX509CAResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeX509CAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeX509CAResponse.Merge(m, src)
+}
+func (m *RevokeX509CAResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeX509CAResponse.Size(m)
+}
+func (m *RevokeX509CAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeX509CAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeX509CAResponse proto.InternalMessageInfo
+
+type TaintJWTKeyRequest struct {
+	// Trust domain of the bundle
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Key ID of the JWT signing key to taint
+	KeyId                string   `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintJWTKeyRequest) Reset()         { *m = TaintJWTKeyRequest{} }
+func (m *TaintJWTKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*TaintJWTKeyRequest) ProtoMessage()    {}
+func (*TaintJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{20}
+}
+
+func (m *TaintJWTKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintJWTKeyRequest.Unmarshal(m, b)
+}
+func (m *TaintJWTKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintJWTKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *TaintJWTKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintJWTKeyRequest.Merge(m, src)
+}
+func (m *TaintJWTKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_TaintJWTKeyRequest.Size(m)
+}
+func (m *TaintJWTKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintJWTKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintJWTKeyRequest proto.InternalMessageInfo
+
+func (m *TaintJWTKeyRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *TaintJWTKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type TaintJWTKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintJWTKeyResponse) Reset()         { *m = TaintJWTKeyResponse{} }
+func (m *TaintJWTKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintJWTKeyResponse) ProtoMessage()    {}
+func (*TaintJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{21}
+}
+
+func (m *TaintJWTKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintJWTKeyResponse.Unmarshal(m, b)
+}
+func (m *TaintJWTKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintJWTKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *TaintJWTKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintJWTKeyResponse.Merge(m, src)
+}
+func (m *TaintJWTKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintJWTKeyResponse.Size(m)
+}
+func (m *TaintJWTKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintJWTKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintJWTKeyResponse proto.InternalMessageInfo
+
+type RevokeJWTKeyRequest struct {
+	// Trust domain of the bundle
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Key ID of the tainted JWT signing key to remove
+	KeyId                string   `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeJWTKeyRequest) Reset()         { *m = RevokeJWTKeyRequest{} }
+func (m *RevokeJWTKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeJWTKeyRequest) ProtoMessage()    {}
+func (*RevokeJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{22}
+}
+
+func (m *RevokeJWTKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJWTKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeJWTKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJWTKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeJWTKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJWTKeyRequest.Merge(m, src)
+}
+func (m *RevokeJWTKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeJWTKeyRequest.Size(m)
+}
+func (m *RevokeJWTKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJWTKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJWTKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeJWTKeyRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *RevokeJWTKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type RevokeJWTKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeJWTKeyResponse) Reset()         { *m = RevokeJWTKeyResponse{} }
+func (m *RevokeJWTKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeJWTKeyResponse) ProtoMessage()    {}
+func (*RevokeJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{23}
+}
+
+func (m *RevokeJWTKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJWTKeyResponse.Unmarshal(m, b)
+}
+func (m *RevokeJWTKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJWTKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeJWTKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJWTKeyResponse.Merge(m, src)
+}
+func (m *RevokeJWTKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeJWTKeyResponse.Size(m)
+}
+func (m *RevokeJWTKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJWTKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJWTKeyResponse proto.InternalMessageInfo
+
 type NodeSelectors struct {
 	// Node SPIFFE ID
 	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
@@ -749,7 +1199,7 @@ func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
 func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
 func (*NodeSelectors) ProtoMessage()    {}
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{16}
+	return fileDescriptor_d08157cfd31fc929, []int{24}
 }
 
 func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
@@ -795,7 +1245,7 @@ func (m *SetNodeSelectorsRequest) Reset()         { *m = SetNodeSelectorsRequest
 func (m *SetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*SetNodeSelectorsRequest) ProtoMessage()    {}
 func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{17}
+	return fileDescriptor_d08157cfd31fc929, []int{25}
 }
 
 func (m *SetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
@@ -833,7 +1283,7 @@ func (m *SetNodeSelectorsResponse) Reset()         { *m = SetNodeSelectorsRespon
 func (m *SetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*SetNodeSelectorsResponse) ProtoMessage()    {}
 func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{18}
+	return fileDescriptor_d08157cfd31fc929, []int{26}
 }
 
 func (m *SetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
@@ -867,7 +1317,7 @@ func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest
 func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsRequest) ProtoMessage()    {}
 func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{19}
+	return fileDescriptor_d08157cfd31fc929, []int{27}
 }
 
 func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
@@ -913,7 +1363,7 @@ func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsRespon
 func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsResponse) ProtoMessage()    {}
 func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{20}
+	return fileDescriptor_d08157cfd31fc929, []int{28}
 }
 
 func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
@@ -941,43 +1391,122 @@ func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
 	return nil
 }
 
-type CreateAttestedNodeRequest struct {
-	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type ListNodeSelectorsRequest struct {
+	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
+	TolerateStale        bool     `protobuf:"varint,1,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeRequest{} }
-func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateAttestedNodeRequest) ProtoMessage()    {}
-func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{21}
+func (m *ListNodeSelectorsRequest) Reset()         { *m = ListNodeSelectorsRequest{} }
+func (m *ListNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNodeSelectorsRequest) ProtoMessage()    {}
+func (*ListNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{29}
 }
 
-func (m *CreateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateAttestedNodeRequest.Unmarshal(m, b)
+func (m *ListNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Unmarshal(m, b)
 }
-func (m *CreateAttestedNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateAttestedNodeRequest.Marshal(b, m, deterministic)
+func (m *ListNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateAttestedNodeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateAttestedNodeRequest.Merge(m, src)
+func (m *ListNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNodeSelectorsRequest.Merge(m, src)
 }
-func (m *CreateAttestedNodeRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateAttestedNodeRequest.Size(m)
+func (m *ListNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Size(m)
 }
-func (m *CreateAttestedNodeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateAttestedNodeRequest.DiscardUnknown(m)
+func (m *ListNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNodeSelectorsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateAttestedNodeRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListNodeSelectorsRequest proto.InternalMessageInfo
 
-func (m *CreateAttestedNodeRequest) GetNode() *common.AttestedNode {
+func (m *ListNodeSelectorsRequest) GetTolerateStale() bool {
 	if m != nil {
-		return m.Node
+		return m.TolerateStale
 	}
-	return nil
+	return false
+}
+
+type ListNodeSelectorsResponse struct {
+	Selectors            []*NodeSelectors `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListNodeSelectorsResponse) Reset()         { *m = ListNodeSelectorsResponse{} }
+func (m *ListNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNodeSelectorsResponse) ProtoMessage()    {}
+func (*ListNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{30}
+}
+
+func (m *ListNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *ListNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNodeSelectorsResponse.Merge(m, src)
+}
+func (m *ListNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Size(m)
+}
+func (m *ListNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNodeSelectorsResponse proto.InternalMessageInfo
+
+func (m *ListNodeSelectorsResponse) GetSelectors() []*NodeSelectors {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type CreateAttestedNodeRequest struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeRequest{} }
+func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAttestedNodeRequest) ProtoMessage()    {}
+func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{31}
+}
+
+func (m *CreateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAttestedNodeRequest.Unmarshal(m, b)
+}
+func (m *CreateAttestedNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAttestedNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAttestedNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAttestedNodeRequest.Merge(m, src)
+}
+func (m *CreateAttestedNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAttestedNodeRequest.Size(m)
+}
+func (m *CreateAttestedNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAttestedNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAttestedNodeRequest proto.InternalMessageInfo
+
+func (m *CreateAttestedNodeRequest) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
 }
 
 type CreateAttestedNodeResponse struct {
@@ -991,7 +1520,7 @@ func (m *CreateAttestedNodeResponse) Reset()         { *m = CreateAttestedNodeRe
 func (m *CreateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeResponse) ProtoMessage()    {}
 func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{22}
+	return fileDescriptor_d08157cfd31fc929, []int{32}
 }
 
 func (m *CreateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1030,7 +1559,7 @@ func (m *FetchAttestedNodeRequest) Reset()         { *m = FetchAttestedNodeReque
 func (m *FetchAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeRequest) ProtoMessage()    {}
 func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{23}
+	return fileDescriptor_d08157cfd31fc929, []int{33}
 }
 
 func (m *FetchAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1069,7 +1598,7 @@ func (m *FetchAttestedNodeResponse) Reset()         { *m = FetchAttestedNodeResp
 func (m *FetchAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeResponse) ProtoMessage()    {}
 func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{24}
+	return fileDescriptor_d08157cfd31fc929, []int{34}
 }
 
 func (m *FetchAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1098,18 +1627,24 @@ func (m *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
 }
 
 type ListAttestedNodesRequest struct {
-	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
-	Pagination           *Pagination          `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	ByExpiresBefore   *wrappers.Int64Value `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	Pagination        *Pagination          `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	ByBanned          *wrappers.BoolValue  `protobuf:"bytes,3,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	ByAttestationType string               `protobuf:"bytes,4,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	BySelectorMatch   *BySelectors         `protobuf:"bytes,5,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
+	// If true, the selectors of each node are returned with the node
+	FetchSelectors       bool                `protobuf:"varint,6,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	ByCanReattest        *wrappers.BoolValue `protobuf:"bytes,7,opt,name=by_can_reattest,json=byCanReattest,proto3" json:"by_can_reattest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ListAttestedNodesRequest) Reset()         { *m = ListAttestedNodesRequest{} }
 func (m *ListAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesRequest) ProtoMessage()    {}
 func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{25}
+	return fileDescriptor_d08157cfd31fc929, []int{35}
 }
 
 func (m *ListAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
@@ -1144,6 +1679,41 @@ func (m *ListAttestedNodesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListAttestedNodesRequest) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *ListAttestedNodesRequest) GetBySelectorMatch() *BySelectors {
+	if m != nil {
+		return m.BySelectorMatch
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetFetchSelectors() bool {
+	if m != nil {
+		return m.FetchSelectors
+	}
+	return false
+}
+
+func (m *ListAttestedNodesRequest) GetByCanReattest() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByCanReattest
+	}
+	return nil
+}
+
 type ListAttestedNodesResponse struct {
 	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	Pagination           *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1156,7 +1726,7 @@ func (m *ListAttestedNodesResponse) Reset()         { *m = ListAttestedNodesResp
 func (m *ListAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesResponse) ProtoMessage()    {}
 func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{26}
+	return fileDescriptor_d08157cfd31fc929, []int{36}
 }
 
 func (m *ListAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
@@ -1192,21 +1762,28 @@ func (m *ListAttestedNodesResponse) GetPagination() *Pagination {
 }
 
 type UpdateAttestedNodeRequest struct {
-	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	CertSerialNumber     string   `protobuf:"bytes,2,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
-	CertNotAfter         int64    `protobuf:"varint,3,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
-	NewCertSerialNumber  string   `protobuf:"bytes,4,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
-	NewCertNotAfter      int64    `protobuf:"varint,5,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SpiffeId            string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	CertSerialNumber    string `protobuf:"bytes,2,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
+	CertNotAfter        int64  `protobuf:"varint,3,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	NewCertSerialNumber string `protobuf:"bytes,4,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
+	NewCertNotAfter     int64  `protobuf:"varint,5,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	// If set, only the fields in the mask are updated, including fields set
+	// to their zero value. Otherwise only the certificate fields are updated.
+	InputMask            *AttestedNodeMask     `protobuf:"bytes,6,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	AgentVersion         string                `protobuf:"bytes,7,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	AgentPlugins         []*common.AgentPlugin `protobuf:"bytes,8,rep,name=agent_plugins,json=agentPlugins,proto3" json:"agent_plugins,omitempty"`
+	LastSeen             int64                 `protobuf:"varint,9,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	CanReattest          bool                  `protobuf:"varint,10,opt,name=can_reattest,json=canReattest,proto3" json:"can_reattest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *UpdateAttestedNodeRequest) Reset()         { *m = UpdateAttestedNodeRequest{} }
 func (m *UpdateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeRequest) ProtoMessage()    {}
 func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{27}
+	return fileDescriptor_d08157cfd31fc929, []int{37}
 }
 
 func (m *UpdateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1262,6 +1839,136 @@ func (m *UpdateAttestedNodeRequest) GetNewCertNotAfter() int64 {
 	return 0
 }
 
+func (m *UpdateAttestedNodeRequest) GetInputMask() *AttestedNodeMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+func (m *UpdateAttestedNodeRequest) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *UpdateAttestedNodeRequest) GetAgentPlugins() []*common.AgentPlugin {
+	if m != nil {
+		return m.AgentPlugins
+	}
+	return nil
+}
+
+func (m *UpdateAttestedNodeRequest) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *UpdateAttestedNodeRequest) GetCanReattest() bool {
+	if m != nil {
+		return m.CanReattest
+	}
+	return false
+}
+
+type AttestedNodeMask struct {
+	CertSerialNumber     bool     `protobuf:"varint,1,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
+	CertNotAfter         bool     `protobuf:"varint,2,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	NewCertSerialNumber  bool     `protobuf:"varint,3,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
+	NewCertNotAfter      bool     `protobuf:"varint,4,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	AgentVersion         bool     `protobuf:"varint,5,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	AgentPlugins         bool     `protobuf:"varint,6,opt,name=agent_plugins,json=agentPlugins,proto3" json:"agent_plugins,omitempty"`
+	LastSeen             bool     `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	CanReattest          bool     `protobuf:"varint,8,opt,name=can_reattest,json=canReattest,proto3" json:"can_reattest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttestedNodeMask) Reset()         { *m = AttestedNodeMask{} }
+func (m *AttestedNodeMask) String() string { return proto.CompactTextString(m) }
+func (*AttestedNodeMask) ProtoMessage()    {}
+func (*AttestedNodeMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{38}
+}
+
+func (m *AttestedNodeMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttestedNodeMask.Unmarshal(m, b)
+}
+func (m *AttestedNodeMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttestedNodeMask.Marshal(b, m, deterministic)
+}
+func (m *AttestedNodeMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttestedNodeMask.Merge(m, src)
+}
+func (m *AttestedNodeMask) XXX_Size() int {
+	return xxx_messageInfo_AttestedNodeMask.Size(m)
+}
+func (m *AttestedNodeMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttestedNodeMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttestedNodeMask proto.InternalMessageInfo
+
+func (m *AttestedNodeMask) GetCertSerialNumber() bool {
+	if m != nil {
+		return m.CertSerialNumber
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetCertNotAfter() bool {
+	if m != nil {
+		return m.CertNotAfter
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetNewCertSerialNumber() bool {
+	if m != nil {
+		return m.NewCertSerialNumber
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetNewCertNotAfter() bool {
+	if m != nil {
+		return m.NewCertNotAfter
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetAgentVersion() bool {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetAgentPlugins() bool {
+	if m != nil {
+		return m.AgentPlugins
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetLastSeen() bool {
+	if m != nil {
+		return m.LastSeen
+	}
+	return false
+}
+
+func (m *AttestedNodeMask) GetCanReattest() bool {
+	if m != nil {
+		return m.CanReattest
+	}
+	return false
+}
+
 type UpdateAttestedNodeResponse struct {
 	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -1273,7 +1980,7 @@ func (m *UpdateAttestedNodeResponse) Reset()         { *m = UpdateAttestedNodeRe
 func (m *UpdateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeResponse) ProtoMessage()    {}
 func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{28}
+	return fileDescriptor_d08157cfd31fc929, []int{39}
 }
 
 func (m *UpdateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1312,7 +2019,7 @@ func (m *DeleteAttestedNodeRequest) Reset()         { *m = DeleteAttestedNodeReq
 func (m *DeleteAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{29}
+	return fileDescriptor_d08157cfd31fc929, []int{40}
 }
 
 func (m *DeleteAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1351,7 +2058,7 @@ func (m *DeleteAttestedNodeResponse) Reset()         { *m = DeleteAttestedNodeRe
 func (m *DeleteAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{30}
+	return fileDescriptor_d08157cfd31fc929, []int{41}
 }
 
 func (m *DeleteAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1390,7 +2097,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{31}
+	return fileDescriptor_d08157cfd31fc929, []int{42}
 }
 
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1429,7 +2136,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{32}
+	return fileDescriptor_d08157cfd31fc929, []int{43}
 }
 
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1468,7 +2175,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{33}
+	return fileDescriptor_d08157cfd31fc929, []int{44}
 }
 
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1507,7 +2214,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{34}
+	return fileDescriptor_d08157cfd31fc929, []int{45}
 }
 
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1547,7 +2254,7 @@ func (m *BySelectors) Reset()         { *m = BySelectors{} }
 func (m *BySelectors) String() string { return proto.CompactTextString(m) }
 func (*BySelectors) ProtoMessage()    {}
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35}
+	return fileDescriptor_d08157cfd31fc929, []int{46}
 }
 
 func (m *BySelectors) XXX_Unmarshal(b []byte) error {
@@ -1582,6 +2289,54 @@ func (m *BySelectors) GetMatch() BySelectors_MatchBehavior {
 	return BySelectors_MATCH_EXACT
 }
 
+type ByFederatesWith struct {
+	// Trust domain IDs (i.e. spiffe://example.org) the entries federate with
+	TrustDomains         []string                      `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	Match                ByFederatesWith_MatchBehavior `protobuf:"varint,2,opt,name=match,proto3,enum=spire.server.datastore.ByFederatesWith_MatchBehavior" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ByFederatesWith) Reset()         { *m = ByFederatesWith{} }
+func (m *ByFederatesWith) String() string { return proto.CompactTextString(m) }
+func (*ByFederatesWith) ProtoMessage()    {}
+func (*ByFederatesWith) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{47}
+}
+
+func (m *ByFederatesWith) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ByFederatesWith.Unmarshal(m, b)
+}
+func (m *ByFederatesWith) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ByFederatesWith.Marshal(b, m, deterministic)
+}
+func (m *ByFederatesWith) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ByFederatesWith.Merge(m, src)
+}
+func (m *ByFederatesWith) XXX_Size() int {
+	return xxx_messageInfo_ByFederatesWith.Size(m)
+}
+func (m *ByFederatesWith) XXX_DiscardUnknown() {
+	xxx_messageInfo_ByFederatesWith.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ByFederatesWith proto.InternalMessageInfo
+
+func (m *ByFederatesWith) GetTrustDomains() []string {
+	if m != nil {
+		return m.TrustDomains
+	}
+	return nil
+}
+
+func (m *ByFederatesWith) GetMatch() ByFederatesWith_MatchBehavior {
+	if m != nil {
+		return m.Match
+	}
+	return ByFederatesWith_MATCH_ALL
+}
+
 type Pagination struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	PageSize             int32    `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
@@ -1594,7 +2349,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{36}
+	return fileDescriptor_d08157cfd31fc929, []int{48}
 }
 
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
@@ -1635,17 +2390,25 @@ type ListRegistrationEntriesRequest struct {
 	BySpiffeId  *wrappers.StringValue `protobuf:"bytes,3,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
 	Pagination  *Pagination           `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
-	TolerateStale        bool     `protobuf:"varint,5,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TolerateStale   bool                  `protobuf:"varint,5,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
+	ByFederatesWith *ByFederatesWith      `protobuf:"bytes,6,opt,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	ByDownstream    *wrappers.BoolValue   `protobuf:"bytes,7,opt,name=by_downstream,json=byDownstream,proto3" json:"by_downstream,omitempty"`
+	ByAdmin         *wrappers.BoolValue   `protobuf:"bytes,8,opt,name=by_admin,json=byAdmin,proto3" json:"by_admin,omitempty"`
+	ByDnsName       *wrappers.StringValue `protobuf:"bytes,9,opt,name=by_dns_name,json=byDnsName,proto3" json:"by_dns_name,omitempty"`
+	// Entries without an expiry never expire, so they are listed by
+	// by_expires_after but not by by_expires_before.
+	ByExpiresAfter       *wrappers.Int64Value `protobuf:"bytes,10,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,11,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ListRegistrationEntriesRequest) Reset()         { *m = ListRegistrationEntriesRequest{} }
 func (m *ListRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesRequest) ProtoMessage()    {}
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{37}
+	return fileDescriptor_d08157cfd31fc929, []int{49}
 }
 
 func (m *ListRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1701,23 +2464,65 @@ func (m *ListRegistrationEntriesRequest) GetTolerateStale() bool {
 	return false
 }
 
-type ListRegistrationEntriesResponse struct {
-	Entries              []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
-	Pagination           *Pagination                 `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+func (m *ListRegistrationEntriesRequest) GetByFederatesWith() *ByFederatesWith {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
 }
 
-func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistrationEntriesResponse{} }
-func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
-func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
-func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{38}
+func (m *ListRegistrationEntriesRequest) GetByDownstream() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByDownstream
+	}
+	return nil
 }
 
-func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListRegistrationEntriesResponse.Unmarshal(m, b)
+func (m *ListRegistrationEntriesRequest) GetByAdmin() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByAdmin
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByDnsName() *wrappers.StringValue {
+	if m != nil {
+		return m.ByDnsName
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByExpiresBefore() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return nil
+}
+
+type ListRegistrationEntriesResponse struct {
+	Entries              []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Pagination           *Pagination                 `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistrationEntriesResponse{} }
+func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
+func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{50}
+}
+
+func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntriesResponse.Unmarshal(m, b)
 }
 func (m *ListRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_ListRegistrationEntriesResponse.Marshal(b, m, deterministic)
@@ -1749,17 +2554,23 @@ func (m *ListRegistrationEntriesResponse) GetPagination() *Pagination {
 }
 
 type UpdateRegistrationEntryRequest struct {
-	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// If set, the update fails with an Aborted status unless the entry has
+	// the expected revision number.
+	ExpectedRevisionNumber *wrappers.Int64Value `protobuf:"bytes,2,opt,name=expected_revision_number,json=expectedRevisionNumber,proto3" json:"expected_revision_number,omitempty"`
+	// If set, only the fields in the mask are updated, including fields set
+	// to their zero value. Otherwise every field is updated.
+	InputMask            *common.RegistrationEntryMask `protobuf:"bytes,3,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrationEntryRequest{} }
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{39}
+	return fileDescriptor_d08157cfd31fc929, []int{51}
 }
 
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1787,6 +2598,20 @@ func (m *UpdateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
+func (m *UpdateRegistrationEntryRequest) GetExpectedRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.ExpectedRevisionNumber
+	}
+	return nil
+}
+
+func (m *UpdateRegistrationEntryRequest) GetInputMask() *common.RegistrationEntryMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
 type UpdateRegistrationEntryResponse struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1798,7 +2623,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{40}
+	return fileDescriptor_d08157cfd31fc929, []int{52}
 }
 
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1827,17 +2652,20 @@ func (m *UpdateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
 }
 
 type DeleteRegistrationEntryRequest struct {
-	EntryId              string   `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// If set, the delete fails with an Aborted status unless the entry has
+	// the expected revision number.
+	ExpectedRevisionNumber *wrappers.Int64Value `protobuf:"bytes,2,opt,name=expected_revision_number,json=expectedRevisionNumber,proto3" json:"expected_revision_number,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}             `json:"-"`
+	XXX_unrecognized       []byte               `json:"-"`
+	XXX_sizecache          int32                `json:"-"`
 }
 
 func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrationEntryRequest{} }
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{41}
+	return fileDescriptor_d08157cfd31fc929, []int{53}
 }
 
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1865,6 +2693,13 @@ func (m *DeleteRegistrationEntryRequest) GetEntryId() string {
 	return ""
 }
 
+func (m *DeleteRegistrationEntryRequest) GetExpectedRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.ExpectedRevisionNumber
+	}
+	return nil
+}
+
 type DeleteRegistrationEntryResponse struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1876,7 +2711,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{42}
+	return fileDescriptor_d08157cfd31fc929, []int{54}
 }
 
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1915,7 +2750,7 @@ func (m *PruneRegistrationEntriesRequest) Reset()         { *m = PruneRegistrati
 func (m *PruneRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesRequest) ProtoMessage()    {}
 func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{43}
+	return fileDescriptor_d08157cfd31fc929, []int{55}
 }
 
 func (m *PruneRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1953,7 +2788,7 @@ func (m *PruneRegistrationEntriesResponse) Reset()         { *m = PruneRegistrat
 func (m *PruneRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesResponse) ProtoMessage()    {}
 func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{44}
+	return fileDescriptor_d08157cfd31fc929, []int{56}
 }
 
 func (m *PruneRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -1988,7 +2823,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{45}
+	return fileDescriptor_d08157cfd31fc929, []int{57}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -2034,7 +2869,7 @@ func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{}
 func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenRequest) ProtoMessage()    {}
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{46}
+	return fileDescriptor_d08157cfd31fc929, []int{58}
 }
 
 func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2073,7 +2908,7 @@ func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse
 func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenResponse) ProtoMessage()    {}
 func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{47}
+	return fileDescriptor_d08157cfd31fc929, []int{59}
 }
 
 func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2112,7 +2947,7 @@ func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
 func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenRequest) ProtoMessage()    {}
 func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{48}
+	return fileDescriptor_d08157cfd31fc929, []int{60}
 }
 
 func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2151,7 +2986,7 @@ func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{}
 func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenResponse) ProtoMessage()    {}
 func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{49}
+	return fileDescriptor_d08157cfd31fc929, []int{61}
 }
 
 func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2190,7 +3025,7 @@ func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{}
 func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenRequest) ProtoMessage()    {}
 func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{50}
+	return fileDescriptor_d08157cfd31fc929, []int{62}
 }
 
 func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2229,7 +3064,7 @@ func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse
 func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenResponse) ProtoMessage()    {}
 func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{51}
+	return fileDescriptor_d08157cfd31fc929, []int{63}
 }
 
 func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2257,6 +3092,76 @@ func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
+type ListJoinTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJoinTokensRequest) Reset()         { *m = ListJoinTokensRequest{} }
+func (m *ListJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensRequest) ProtoMessage()    {}
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{64}
+}
+
+func (m *ListJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *ListJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensRequest.Merge(m, src)
+}
+func (m *ListJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensRequest.Size(m)
+}
+func (m *ListJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensRequest proto.InternalMessageInfo
+
+type ListJoinTokensResponse struct {
+	JoinTokens           []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJoinTokensResponse) Reset()         { *m = ListJoinTokensResponse{} }
+func (m *ListJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensResponse) ProtoMessage()    {}
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{65}
+}
+
+func (m *ListJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *ListJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensResponse.Merge(m, src)
+}
+func (m *ListJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensResponse.Size(m)
+}
+func (m *ListJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensResponse proto.InternalMessageInfo
+
+func (m *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if m != nil {
+		return m.JoinTokens
+	}
+	return nil
+}
+
 type PruneJoinTokensRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2268,7 +3173,7 @@ func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{}
 func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensRequest) ProtoMessage()    {}
 func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{52}
+	return fileDescriptor_d08157cfd31fc929, []int{66}
 }
 
 func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -2306,7 +3211,7 @@ func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse
 func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensResponse) ProtoMessage()    {}
 func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{53}
+	return fileDescriptor_d08157cfd31fc929, []int{67}
 }
 
 func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
@@ -2327,259 +3232,1374 @@ func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
-	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
-	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
-	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
-	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
-	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
-	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
-	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
-	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
-	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
-	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
-	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
-	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
-	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
-	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
-	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
-	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
-	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
-	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
-	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
-	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
-	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
-	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
-	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
-	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
-	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
-	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
-	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
-	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
-	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
-	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
-	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
-	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
-	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
-	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
-	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
-	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
-	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
-	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
-	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
-	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
-	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
-	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
-	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
-	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
-	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
-	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
-	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
-	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
-	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
-	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
-	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
-	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
-	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
-	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
-	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+type FederationRelationship struct {
+	// Trust domain ID (i.e. spiffe://example.org) of the federated trust
+	// domain
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// URL of the bundle endpoint (i.e. https://example.org:8443)
+	BundleEndpointUrl string `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	// Profile used to authenticate the bundle endpoint
+	BundleEndpointProfile FederationRelationship_BundleEndpointProfile `protobuf:"varint,3,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3,enum=spire.server.datastore.FederationRelationship_BundleEndpointProfile" json:"bundle_endpoint_profile,omitempty"`
+	// Expected SPIFFE ID of the bundle endpoint server. Only used with the
+	// HTTPS_SPIFFE profile. If unset, it defaults to the SPIRE server ID
+	// within the federated trust domain.
+	EndpointSpiffeId     string   `protobuf:"bytes,4,opt,name=endpoint_spiffe_id,json=endpointSpiffeId,proto3" json:"endpoint_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("datastore.proto", fileDescriptor_d08157cfd31fc929) }
-
-var fileDescriptor_d08157cfd31fc929 = []byte{
-	// 1765 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xdd, 0x76, 0xd3, 0xc6,
-	0x13, 0xff, 0x2b, 0x5f, 0xc4, 0xe3, 0x7c, 0xb1, 0xe1, 0x9f, 0xd8, 0xa6, 0x4d, 0x52, 0xb5, 0xe1,
-	0xf0, 0x29, 0x87, 0x10, 0x02, 0xb4, 0x9c, 0x42, 0xe2, 0x98, 0xe0, 0x36, 0xd0, 0x1c, 0x39, 0x94,
-	0x1c, 0x38, 0xad, 0x2a, 0x47, 0x6b, 0x47, 0xd4, 0x91, 0x5c, 0x69, 0x0d, 0x04, 0x1e, 0xa0, 0x87,
-	0x9e, 0xd3, 0x8b, 0xbe, 0x41, 0xef, 0xfa, 0x04, 0xbd, 0xef, 0x3b, 0xf4, 0x3d, 0xfa, 0x0c, 0x3d,
-	0xda, 0x95, 0x2c, 0xc9, 0xda, 0x55, 0x6c, 0x27, 0xbd, 0x8a, 0x35, 0x3b, 0x1f, 0xbf, 0x99, 0xdd,
-	0xd9, 0x9d, 0x19, 0x80, 0x69, 0x43, 0x27, 0xba, 0x4b, 0x6c, 0x07, 0x2b, 0x2d, 0xc7, 0x26, 0x36,
-	0x9a, 0x73, 0x5b, 0xa6, 0x83, 0x15, 0x17, 0x3b, 0xaf, 0xb1, 0xa3, 0x74, 0x56, 0x0b, 0x0b, 0x0d,
-	0xdb, 0x6e, 0x34, 0x71, 0x91, 0x72, 0xd5, 0xda, 0xf5, 0xe2, 0x1b, 0x47, 0x6f, 0xb5, 0xb0, 0xe3,
-	0x32, 0xb9, 0xc2, 0x12, 0x95, 0x2b, 0x1e, 0xd8, 0x47, 0x47, 0xb6, 0x55, 0x6c, 0x35, 0xdb, 0x0d,
-	0x33, 0xf8, 0xe3, 0x73, 0xe4, 0x63, 0x1c, 0xec, 0x0f, 0x5b, 0x92, 0x4b, 0x30, 0x5b, 0x72, 0xb0,
-	0x4e, 0xf0, 0x66, 0xdb, 0x32, 0x9a, 0x58, 0xc5, 0x3f, 0xb5, 0xb1, 0x4b, 0xd0, 0x75, 0x18, 0xab,
-	0x51, 0x42, 0x4e, 0x5a, 0x92, 0x2e, 0x67, 0x57, 0x2f, 0x28, 0x0c, 0x9c, 0x2f, 0xeb, 0x33, 0xfb,
-	0x3c, 0xf2, 0x16, 0x5c, 0x88, 0x2b, 0x71, 0x5b, 0xb6, 0xe5, 0xe2, 0x3e, 0xb5, 0xdc, 0x07, 0xf4,
-	0x08, 0x93, 0x83, 0xc3, 0x38, 0x92, 0x4b, 0x30, 0x4d, 0x9c, 0xb6, 0x4b, 0x34, 0xc3, 0x3e, 0xd2,
-	0x4d, 0x4b, 0x33, 0x0d, 0xaa, 0x2c, 0xa3, 0x4e, 0x52, 0xf2, 0x16, 0xa5, 0x56, 0x0c, 0xcf, 0x91,
-	0x98, 0xf4, 0x40, 0x10, 0xf6, 0x01, 0xed, 0x98, 0x2e, 0x61, 0x54, 0x37, 0x80, 0xb0, 0x09, 0xd0,
-	0xd2, 0x1b, 0xa6, 0xa5, 0x13, 0xd3, 0xb6, 0x7c, 0x3d, 0xb2, 0xc2, 0xdf, 0x2d, 0x65, 0xb7, 0xc3,
-	0xa9, 0x46, 0xa4, 0xe4, 0x0f, 0x12, 0xcc, 0xc6, 0x54, 0xfb, 0xf8, 0x14, 0x38, 0xc7, 0x6c, 0xbb,
-	0x39, 0x69, 0x69, 0x58, 0x08, 0x30, 0x60, 0xea, 0xc2, 0x32, 0x34, 0x10, 0x96, 0x12, 0xcc, 0x3e,
-	0x6b, 0x19, 0xa7, 0xdf, 0xf3, 0xb8, 0x92, 0x81, 0x02, 0xfe, 0x10, 0x66, 0xaa, 0x98, 0x9c, 0x06,
-	0xc7, 0x06, 0x9c, 0x8f, 0x68, 0x18, 0x08, 0x44, 0x09, 0x66, 0x37, 0x5a, 0x2d, 0x6c, 0x19, 0xa7,
-	0x8c, 0x47, 0x5c, 0xc9, 0x40, 0x50, 0xfe, 0x94, 0x60, 0x76, 0x0b, 0x37, 0x71, 0xf7, 0xde, 0xf4,
-	0x98, 0x05, 0x68, 0x0b, 0x46, 0x8e, 0x6c, 0x03, 0xd3, 0x83, 0x31, 0xb5, 0xba, 0x22, 0x3a, 0x18,
-	0x1c, 0x13, 0xca, 0x13, 0xdb, 0xc0, 0x2a, 0x95, 0x96, 0x57, 0x60, 0xc4, 0xfb, 0x42, 0x13, 0x30,
-	0xae, 0x96, 0xab, 0x7b, 0x6a, 0xa5, 0xb4, 0x37, 0xf3, 0x3f, 0x04, 0x30, 0xb6, 0x55, 0xde, 0x29,
-	0xef, 0x95, 0x67, 0x24, 0x34, 0x05, 0xb0, 0x55, 0xa9, 0x56, 0xbf, 0x29, 0x55, 0x36, 0xf6, 0xca,
-	0x33, 0x43, 0x9e, 0xf7, 0x71, 0x9d, 0x03, 0x79, 0x7f, 0x00, 0x68, 0xd7, 0x69, 0x5b, 0x03, 0xfa,
-	0xbe, 0x0c, 0x53, 0xf8, 0xad, 0xa7, 0xdd, 0xd5, 0x6a, 0xb8, 0x6e, 0x3b, 0x2c, 0x0a, 0xc3, 0xea,
-	0xa4, 0x4f, 0xdd, 0xa4, 0x44, 0xf9, 0x3e, 0xcc, 0xc6, 0x8c, 0xf8, 0x48, 0x97, 0x61, 0x8a, 0xa1,
-	0xd0, 0x0e, 0x0e, 0x75, 0xab, 0x81, 0x99, 0x91, 0x71, 0x75, 0x92, 0x51, 0x4b, 0x8c, 0x28, 0xd7,
-	0x60, 0xf2, 0xa9, 0x6d, 0xe0, 0x2a, 0x6e, 0xe2, 0x03, 0x62, 0x3b, 0x2e, 0xba, 0x08, 0x19, 0xb7,
-	0x65, 0xd6, 0xeb, 0x38, 0xc4, 0x35, 0xce, 0x08, 0x15, 0x03, 0xad, 0x41, 0xc6, 0x0d, 0x38, 0x73,
-	0x43, 0x34, 0xbf, 0xe7, 0xe2, 0x11, 0x08, 0x14, 0xa9, 0x21, 0xa3, 0xfc, 0x3d, 0xcc, 0x57, 0x31,
-	0x89, 0x99, 0x09, 0x62, 0x51, 0x8a, 0x2a, 0x64, 0x21, 0x5d, 0x16, 0x6d, 0x72, 0x5c, 0x41, 0x44,
-	0x7f, 0x01, 0x72, 0x49, 0xfd, 0x2c, 0x0c, 0xf2, 0x77, 0x30, 0xbf, 0x2d, 0xb0, 0x9d, 0xea, 0xe9,
-	0x32, 0x4c, 0x11, 0xbb, 0x89, 0x1d, 0x9d, 0x60, 0xcd, 0x25, 0x7a, 0x93, 0x05, 0x7f, 0x5c, 0x9d,
-	0x0c, 0xa8, 0x55, 0x8f, 0x28, 0x6b, 0x90, 0xdb, 0x16, 0x98, 0x3e, 0x1b, 0xdf, 0xbe, 0x86, 0x3c,
-	0x7b, 0x8a, 0x36, 0x08, 0xc1, 0x2e, 0xc1, 0x86, 0xc7, 0x19, 0x78, 0xa0, 0xc0, 0x88, 0xe5, 0x65,
-	0x07, 0x53, 0x5e, 0x88, 0xef, 0x44, 0x4c, 0x80, 0xf2, 0xc9, 0x3b, 0x50, 0xe0, 0x29, 0xeb, 0x5c,
-	0xdd, 0xfd, 0x69, 0xbb, 0x03, 0x39, 0xfa, 0x42, 0xf1, 0x90, 0xa5, 0xc5, 0xd6, 0xf3, 0x89, 0x23,
-	0x38, 0x20, 0x8a, 0x3f, 0x24, 0xc8, 0x79, 0x0f, 0x51, 0x74, 0xa9, 0xb3, 0xc5, 0xdb, 0x70, 0xbe,
-	0x76, 0xac, 0x75, 0x65, 0x11, 0xd3, 0x7c, 0x51, 0x61, 0x65, 0x88, 0x12, 0x94, 0x21, 0x4a, 0xc5,
-	0x22, 0xeb, 0x6b, 0xdf, 0xea, 0xcd, 0x36, 0x56, 0xa7, 0x6b, 0xc7, 0xe5, 0x68, 0x92, 0x9d, 0xc9,
-	0x33, 0xf5, 0x9b, 0x04, 0x79, 0x0e, 0x52, 0xdf, 0xef, 0x15, 0x18, 0xf5, 0xfc, 0x09, 0x9e, 0xcd,
-	0x34, 0xc7, 0x19, 0xe3, 0x99, 0x60, 0xfa, 0x47, 0x82, 0x3c, 0x7b, 0xf6, 0xfa, 0xdd, 0x45, 0x74,
-	0x1d, 0xd0, 0x01, 0x76, 0x88, 0xe6, 0x62, 0xc7, 0xd4, 0x9b, 0x9a, 0xd5, 0x3e, 0xaa, 0x61, 0x87,
-	0xc2, 0xc8, 0xa8, 0x33, 0xde, 0x4a, 0x95, 0x2e, 0x3c, 0xa5, 0x74, 0xf4, 0x19, 0x4c, 0x51, 0x6e,
-	0xcb, 0x26, 0x9a, 0x5e, 0x27, 0xd8, 0xc9, 0x0d, 0xd3, 0xcb, 0x6c, 0xc2, 0xa3, 0x3e, 0xb5, 0xc9,
-	0x86, 0x47, 0x43, 0xb7, 0x60, 0xce, 0xc2, 0x6f, 0x34, 0x8e, 0xde, 0x11, 0xaa, 0x77, 0xd6, 0xc2,
-	0x6f, 0x4a, 0xdd, 0xaa, 0xaf, 0x01, 0xea, 0x08, 0x85, 0xea, 0x47, 0xa9, 0xfa, 0x69, 0x5f, 0x20,
-	0xb0, 0xe0, 0xa5, 0x00, 0xcf, 0xdf, 0x01, 0x0f, 0xdf, 0x5d, 0xc8, 0xb3, 0x67, 0xa2, 0xef, 0x1c,
-	0xd8, 0x81, 0x02, 0x4f, 0x72, 0x40, 0x1c, 0xcf, 0x61, 0x81, 0x25, 0xb6, 0x8a, 0x1b, 0xa6, 0x4b,
-	0x1c, 0xba, 0xb9, 0x65, 0x8b, 0x38, 0xc7, 0x01, 0x98, 0xdb, 0x30, 0x8a, 0xbd, 0x6f, 0x5f, 0xe5,
-	0x62, 0x5c, 0x65, 0x52, 0x8c, 0x71, 0xcb, 0xfb, 0xb0, 0x28, 0x54, 0xec, 0x63, 0x1d, 0x50, 0xf3,
-	0xe7, 0xf0, 0x31, 0xbd, 0x04, 0x84, 0x88, 0xf3, 0x30, 0x4e, 0x39, 0xc3, 0xe8, 0x9d, 0xa3, 0xdf,
-	0x15, 0xc3, 0x73, 0x57, 0x24, 0x7b, 0x3a, 0x50, 0x7f, 0x49, 0x90, 0xdd, 0x3c, 0x0e, 0x1f, 0xc3,
-	0xb5, 0xf8, 0x15, 0xde, 0xdb, 0x7b, 0x87, 0xb6, 0x61, 0xf4, 0x48, 0x27, 0x07, 0x87, 0x7e, 0xd5,
-	0x72, 0x53, 0x94, 0x93, 0x11, 0x4b, 0xca, 0x13, 0x4f, 0x60, 0x13, 0x1f, 0xea, 0xaf, 0x4d, 0xdb,
-	0x51, 0x99, 0xbc, 0xbc, 0x0a, 0x93, 0x31, 0x3a, 0x9a, 0x86, 0xec, 0x93, 0x8d, 0xbd, 0xd2, 0x63,
-	0xad, 0xbc, 0xbf, 0x41, 0x6b, 0x98, 0x19, 0x98, 0x60, 0x84, 0xea, 0xb3, 0xcd, 0x6a, 0x79, 0x6f,
-	0x46, 0x92, 0x1f, 0x00, 0x84, 0xb9, 0x8e, 0x2e, 0xc0, 0x28, 0xb1, 0x7f, 0xc4, 0x96, 0x1f, 0x41,
-	0xf6, 0xe1, 0x9d, 0xcc, 0x96, 0xde, 0xc0, 0x9a, 0x6b, 0xbe, 0x63, 0xef, 0xda, 0xa8, 0x3a, 0xee,
-	0x11, 0xaa, 0xe6, 0x3b, 0x2c, 0xff, 0x3d, 0x04, 0x0b, 0xde, 0x35, 0xd5, 0x1d, 0x24, 0x33, 0xbc,
-	0x56, 0xbf, 0x84, 0x89, 0xda, 0xb1, 0xd6, 0xd2, 0x1d, 0x6c, 0x91, 0x60, 0x7b, 0xb2, 0xab, 0x1f,
-	0x25, 0x6e, 0xd4, 0x2a, 0x71, 0x4c, 0xab, 0xc1, 0xae, 0x54, 0xa8, 0x1d, 0xef, 0x52, 0x81, 0x8a,
-	0x81, 0x1e, 0x51, 0xf9, 0x68, 0x25, 0xe1, 0xc9, 0x7f, 0xda, 0x43, 0x9c, 0xd4, 0x6c, 0x2d, 0xb2,
-	0x3d, 0x0c, 0x47, 0x98, 0x64, 0xc3, 0xbd, 0xe1, 0xa8, 0x06, 0x57, 0x58, 0xfc, 0x06, 0x1d, 0x19,
-	0xe4, 0x06, 0xe5, 0x14, 0x0a, 0xa3, 0xbc, 0x42, 0xe1, 0x77, 0x09, 0x16, 0x85, 0x51, 0xf5, 0x0f,
-	0xed, 0x3d, 0xa0, 0x27, 0xdc, 0xec, 0x3c, 0x02, 0x27, 0x1e, 0xdb, 0x80, 0xff, 0x4c, 0xde, 0x82,
-	0xe7, 0xb0, 0xc0, 0xae, 0xc6, 0xff, 0xe0, 0x12, 0x11, 0x2a, 0x3e, 0x5d, 0xbe, 0x7e, 0x01, 0x0b,
-	0xec, 0x16, 0x1d, 0xe4, 0x16, 0xd9, 0x87, 0x45, 0xa1, 0xf0, 0xe9, 0x60, 0x3d, 0x86, 0x45, 0x5a,
-	0x92, 0xa7, 0xa4, 0x50, 0xb2, 0xb8, 0x97, 0x78, 0xc5, 0xbd, 0x0c, 0x4b, 0x62, 0x4d, 0x7e, 0x89,
-	0x7b, 0x0f, 0x32, 0x5f, 0xd9, 0xa6, 0xb5, 0x47, 0x53, 0x9b, 0x9f, 0xf0, 0x73, 0x30, 0x46, 0xf5,
-	0x1e, 0xfb, 0x2d, 0x84, 0xff, 0x25, 0xbf, 0x80, 0x39, 0x76, 0xbd, 0x77, 0x14, 0x04, 0xf8, 0x1e,
-	0x02, 0xbc, 0xb2, 0x4d, 0x4b, 0x0b, 0x95, 0x65, 0x57, 0x3f, 0x11, 0x1d, 0xa8, 0x50, 0x3a, 0xf3,
-	0x2a, 0xf8, 0x29, 0xbf, 0x84, 0xf9, 0x84, 0x6e, 0x3f, 0xac, 0xa7, 0x57, 0x7e, 0x03, 0xfe, 0x4f,
-	0x5f, 0x80, 0x04, 0x6e, 0xae, 0xff, 0x9e, 0x9f, 0xdd, 0xec, 0x67, 0x06, 0x45, 0x81, 0x39, 0x76,
-	0x8c, 0x7a, 0xc4, 0xf2, 0x12, 0xe6, 0x13, 0xfc, 0x67, 0x06, 0xe6, 0x01, 0xcc, 0xd1, 0xf3, 0xd2,
-	0x59, 0xec, 0xf7, 0xc0, 0xe5, 0x61, 0x3e, 0xa1, 0x80, 0xa1, 0x5b, 0xfd, 0x90, 0x87, 0xcc, 0x96,
-	0x4e, 0xf4, 0xaa, 0x67, 0x1e, 0x99, 0x30, 0x11, 0x9d, 0x91, 0xa1, 0x6b, 0x22, 0x9c, 0x9c, 0x71,
-	0x5c, 0xe1, 0x7a, 0x6f, 0xcc, 0x7e, 0x58, 0xea, 0x90, 0x8d, 0x8c, 0xc2, 0xd0, 0x55, 0x91, 0x70,
-	0x72, 0xda, 0x56, 0xb8, 0xd6, 0x13, 0x6f, 0x68, 0x27, 0x32, 0xd2, 0x12, 0xdb, 0x49, 0x8e, 0xd4,
-	0xc4, 0x76, 0x78, 0x33, 0x32, 0x13, 0x26, 0xa2, 0xa3, 0x26, 0x71, 0xe8, 0x38, 0x53, 0x2d, 0x71,
-	0xe8, 0xb8, 0xd3, 0xab, 0x1f, 0x20, 0xd3, 0x99, 0x26, 0xa1, 0xcb, 0x22, 0xd1, 0xee, 0x91, 0x55,
-	0xe1, 0x4a, 0x0f, 0x9c, 0xa1, 0x33, 0xd1, 0x39, 0x91, 0xd8, 0x19, 0xce, 0x48, 0x4a, 0xec, 0x0c,
-	0x77, 0xf4, 0x64, 0xc2, 0x44, 0x74, 0x28, 0x23, 0x36, 0xc5, 0x19, 0x07, 0x89, 0x4d, 0x71, 0xe7,
-	0x3c, 0x75, 0xc8, 0x46, 0x86, 0x2a, 0xe2, 0xa3, 0x90, 0x1c, 0xef, 0x88, 0x8f, 0x02, 0x6f, 0x4a,
-	0xf3, 0x1e, 0x50, 0xb2, 0x23, 0x47, 0x37, 0xd3, 0xd3, 0x83, 0xd3, 0x6c, 0x14, 0x56, 0xfb, 0x11,
-	0xf1, 0x8d, 0xbf, 0x85, 0xf3, 0x89, 0x3e, 0x1c, 0xad, 0xa4, 0x66, 0x0c, 0xcf, 0xf4, 0xcd, 0x3e,
-	0x24, 0x42, 0xcb, 0x89, 0x4e, 0x58, 0x6c, 0x59, 0xd4, 0xde, 0x8b, 0x2d, 0x8b, 0xdb, 0xec, 0xf7,
-	0x80, 0x92, 0xfd, 0x9f, 0x38, 0xe0, 0xc2, 0xde, 0x58, 0x1c, 0xf0, 0x94, 0xf6, 0xf2, 0x3d, 0xa0,
-	0x64, 0xd3, 0x27, 0x36, 0x2e, 0x6c, 0x2d, 0xc5, 0xc6, 0x53, 0x7a, 0xca, 0x36, 0x1d, 0x4d, 0xc7,
-	0x87, 0x7d, 0xc5, 0x94, 0x3c, 0xe7, 0xcd, 0xcc, 0x0a, 0x2b, 0xbd, 0x0b, 0x84, 0x66, 0xb7, 0x7b,
-	0x36, 0xbb, 0xdd, 0xaf, 0x59, 0xe1, 0xf0, 0xed, 0x17, 0x29, 0x28, 0x3f, 0x12, 0x55, 0x1a, 0x5a,
-	0x4f, 0xcf, 0x15, 0x51, 0x2d, 0x59, 0xb8, 0xd3, 0xb7, 0x9c, 0x0f, 0xe6, 0x67, 0xc9, 0xaf, 0x3f,
-	0x92, 0x58, 0x6e, 0xa7, 0x26, 0x8f, 0x10, 0xca, 0x7a, 0xbf, 0x62, 0x91, 0xb0, 0x08, 0xda, 0x10,
-	0x71, 0x58, 0xd2, 0xbb, 0x41, 0x71, 0x58, 0x4e, 0xea, 0x77, 0x3c, 0x30, 0x82, 0xc6, 0x40, 0x0c,
-	0x26, 0xbd, 0x45, 0x11, 0x83, 0x39, 0xa9, 0x03, 0xf1, 0xc0, 0x08, 0xda, 0x01, 0x31, 0x98, 0xf4,
-	0xe6, 0x43, 0x0c, 0xe6, 0xa4, 0xbe, 0xe3, 0x57, 0x09, 0x72, 0xa2, 0xba, 0x1f, 0xdd, 0x49, 0x7d,
-	0x60, 0x52, 0x36, 0xea, 0x6e, 0xff, 0x82, 0x3e, 0x1e, 0x07, 0xa6, 0xbb, 0x6a, 0x79, 0xa4, 0xa4,
-	0x27, 0x43, 0x77, 0x31, 0x5c, 0x28, 0xf6, 0xcc, 0xef, 0xdb, 0xb4, 0x61, 0x2a, 0x5e, 0xb3, 0xa3,
-	0x1b, 0xa9, 0x87, 0x3e, 0x61, 0x51, 0xe9, 0x95, 0x3d, 0x74, 0xb2, 0xab, 0x30, 0x17, 0x3b, 0xc9,
-	0xaf, 0xf8, 0xc5, 0x4e, 0x8a, 0x2a, 0x7e, 0x07, 0xa6, 0xbb, 0xca, 0x6d, 0xb1, 0x4d, 0x7e, 0x61,
-	0x2f, 0xb6, 0x29, 0xa8, 0xe3, 0xd1, 0x0b, 0xc8, 0x94, 0x6c, 0xab, 0x6e, 0x36, 0xda, 0x0e, 0x46,
-	0xcb, 0xf1, 0x96, 0xd6, 0xff, 0x67, 0xf6, 0xce, 0x7a, 0x60, 0xe4, 0xd2, 0x49, 0x6c, 0x9d, 0xba,
-	0x69, 0x72, 0x1b, 0x93, 0x5d, 0xba, 0x5c, 0xb1, 0xea, 0x36, 0xba, 0xc2, 0x15, 0x8c, 0xf1, 0x04,
-	0x36, 0xae, 0xf6, 0xc2, 0xca, 0xec, 0x6c, 0xae, 0xbf, 0x58, 0x6b, 0x98, 0xe4, 0xb0, 0x5d, 0xf3,
-	0xb8, 0x8b, 0x6c, 0x02, 0x54, 0x64, 0xff, 0x2b, 0x80, 0x4e, 0x7d, 0xfc, 0xdf, 0x2c, 0x26, 0xc5,
-	0x4e, 0x4c, 0x6a, 0x63, 0x74, 0xf5, 0xd6, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb7, 0x3c, 0xc6,
-	0xb9, 0xad, 0x20, 0x00, 0x00,
+func (m *FederationRelationship) Reset()         { *m = FederationRelationship{} }
+func (m *FederationRelationship) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationship) ProtoMessage()    {}
+func (*FederationRelationship) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{68}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *FederationRelationship) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationship.Unmarshal(m, b)
+}
+func (m *FederationRelationship) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationship.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationship) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationship.Merge(m, src)
+}
+func (m *FederationRelationship) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationship.Size(m)
+}
+func (m *FederationRelationship) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationship.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_FederationRelationship proto.InternalMessageInfo
 
-// DataStoreClient is the client API for DataStore service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DataStoreClient interface {
-	// Creates a bundle
-	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
-	// Fetches a specific bundle
-	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
-	// Lists bundles (optionally filtered)
-	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
-	// Updates a specific bundle
-	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
-	// Sets bundle contents (creates if it does not exist)
-	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
-	// Appends contents from a specific bundle (creates if it does not exist)
-	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
-	// Deletes a specific bundle
-	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
-	// Prunes all expired certificates and JWT signing keys from a bundle
-	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
-	// Creates an attested node
-	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
-	// Fetches a specific attested node
-	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
-	// Lists attested nodes (optionally filtered)
-	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
-	// Updates a specific attested node
-	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
-	// Deletes a specific attested node
-	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
-	// Sets the set of selectors for a specific node id
-	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
-	// Gets the set of node selectors for a specific node id
-	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
-	// Creates a registration entry
-	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
-	// Fetches a specific registration entry
-	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
-	// Lists registration entries (optionally filtered)
-	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
-	// Updates a specific registration entry
-	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
-	// Deletes a specific registration entry
-	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
-	// Prunes all registration entries that expire before the specified timestamp
-	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
-	// Creates a join token
-	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
-	// Fetches a specific join token
-	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
-	// Delete a specific join token
-	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
-	// Prunes all join tokens that expire before the specified timestamp
-	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
-	// Applies the plugin configuration
-	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
-	// Returns the version and related metadata of the installed plugin
-	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+func (m *FederationRelationship) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
 }
 
-type dataStoreClient struct {
-	cc *grpc.ClientConn
+func (m *FederationRelationship) GetBundleEndpointUrl() string {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return ""
 }
 
-func NewDataStoreClient(cc *grpc.ClientConn) DataStoreClient {
-	return &dataStoreClient{cc}
+func (m *FederationRelationship) GetBundleEndpointProfile() FederationRelationship_BundleEndpointProfile {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return FederationRelationship_HTTPS_SPIFFE
 }
 
-func (c *dataStoreClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
+func (m *FederationRelationship) GetEndpointSpiffeId() string {
+	if m != nil {
+		return m.EndpointSpiffeId
+	}
+	return ""
+}
+
+type CreateFederationRelationshipRequest struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *CreateFederationRelationshipRequest) Reset()         { *m = CreateFederationRelationshipRequest{} }
+func (m *CreateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipRequest) ProtoMessage()    {}
+func (*CreateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{69}
+}
+
+func (m *CreateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Size(m)
+}
+func (m *CreateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type CreateFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *CreateFederationRelationshipResponse) Reset()         { *m = CreateFederationRelationshipResponse{} }
+func (m *CreateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipResponse) ProtoMessage()    {}
+func (*CreateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{70}
+}
+
+func (m *CreateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Size(m)
+}
+func (m *CreateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type FetchFederationRelationshipRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchFederationRelationshipRequest) Reset()         { *m = FetchFederationRelationshipRequest{} }
+func (m *FetchFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipRequest) ProtoMessage()    {}
+func (*FetchFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{71}
+}
+
+func (m *FetchFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipRequest.Merge(m, src)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Size(m)
+}
+func (m *FetchFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type FetchFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *FetchFederationRelationshipResponse) Reset()         { *m = FetchFederationRelationshipResponse{} }
+func (m *FetchFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipResponse) ProtoMessage()    {}
+func (*FetchFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{72}
+}
+
+func (m *FetchFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipResponse.Merge(m, src)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Size(m)
+}
+func (m *FetchFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type ListFederationRelationshipsRequest struct {
+	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListFederationRelationshipsRequest) Reset()         { *m = ListFederationRelationshipsRequest{} }
+func (m *ListFederationRelationshipsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsRequest) ProtoMessage()    {}
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{73}
+}
+
+func (m *ListFederationRelationshipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsRequest.Merge(m, src)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Size(m)
+}
+func (m *ListFederationRelationshipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsRequest proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListFederationRelationshipsResponse struct {
+	FederationRelationships []*FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	Pagination              *Pagination               `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                  `json:"-"`
+	XXX_unrecognized        []byte                    `json:"-"`
+	XXX_sizecache           int32                     `json:"-"`
+}
+
+func (m *ListFederationRelationshipsResponse) Reset()         { *m = ListFederationRelationshipsResponse{} }
+func (m *ListFederationRelationshipsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsResponse) ProtoMessage()    {}
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{74}
+}
+
+func (m *ListFederationRelationshipsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsResponse.Merge(m, src)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Size(m)
+}
+func (m *ListFederationRelationshipsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsResponse proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsResponse) GetFederationRelationships() []*FederationRelationship {
+	if m != nil {
+		return m.FederationRelationships
+	}
+	return nil
+}
+
+func (m *ListFederationRelationshipsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipRequest struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipRequest) Reset()         { *m = UpdateFederationRelationshipRequest{} }
+func (m *UpdateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipRequest) ProtoMessage()    {}
+func (*UpdateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{75}
+}
+
+func (m *UpdateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Size(m)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipResponse) Reset()         { *m = UpdateFederationRelationshipResponse{} }
+func (m *UpdateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipResponse) ProtoMessage()    {}
+func (*UpdateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{76}
+}
+
+func (m *UpdateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Size(m)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type DeleteFederationRelationshipRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipRequest) Reset()         { *m = DeleteFederationRelationshipRequest{} }
+func (m *DeleteFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipRequest) ProtoMessage()    {}
+func (*DeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{77}
+}
+
+func (m *DeleteFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Size(m)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type DeleteFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipResponse) Reset()         { *m = DeleteFederationRelationshipResponse{} }
+func (m *DeleteFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipResponse) ProtoMessage()    {}
+func (*DeleteFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{78}
+}
+
+func (m *DeleteFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Size(m)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type ChangeEvent struct {
+	// Revision of the event. Revisions increase with every recorded event.
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Type of change
+	Type ChangeEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=spire.server.datastore.ChangeEvent_Type" json:"type,omitempty"`
+	// Type of the changed resource
+	ResourceType ChangeEvent_ResourceType `protobuf:"varint,3,opt,name=resource_type,json=resourceType,proto3,enum=spire.server.datastore.ChangeEvent_ResourceType" json:"resource_type,omitempty"`
+	// ID of the changed resource
+	ResourceId string `protobuf:"bytes,4,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// Time at which the event was recorded, in seconds since the Unix epoch
+	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeEvent) Reset()         { *m = ChangeEvent{} }
+func (m *ChangeEvent) String() string { return proto.CompactTextString(m) }
+func (*ChangeEvent) ProtoMessage()    {}
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{79}
+}
+
+func (m *ChangeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeEvent.Unmarshal(m, b)
+}
+func (m *ChangeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeEvent.Marshal(b, m, deterministic)
+}
+func (m *ChangeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeEvent.Merge(m, src)
+}
+func (m *ChangeEvent) XXX_Size() int {
+	return xxx_messageInfo_ChangeEvent.Size(m)
+}
+func (m *ChangeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeEvent proto.InternalMessageInfo
+
+func (m *ChangeEvent) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *ChangeEvent) GetType() ChangeEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ChangeEvent_CREATED
+}
+
+func (m *ChangeEvent) GetResourceType() ChangeEvent_ResourceType {
+	if m != nil {
+		return m.ResourceType
+	}
+	return ChangeEvent_REGISTRATION_ENTRY
+}
+
+func (m *ChangeEvent) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *ChangeEvent) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type ListChangeEventsRequest struct {
+	// Only events with a revision greater than after_revision are returned
+	AfterRevision int64 `protobuf:"varint,1,opt,name=after_revision,json=afterRevision,proto3" json:"after_revision,omitempty"`
+	// Maximum number of events to return. If zero, every event is returned.
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListChangeEventsRequest) Reset()         { *m = ListChangeEventsRequest{} }
+func (m *ListChangeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListChangeEventsRequest) ProtoMessage()    {}
+func (*ListChangeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{80}
+}
+
+func (m *ListChangeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListChangeEventsRequest.Unmarshal(m, b)
+}
+func (m *ListChangeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListChangeEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListChangeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChangeEventsRequest.Merge(m, src)
+}
+func (m *ListChangeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListChangeEventsRequest.Size(m)
+}
+func (m *ListChangeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChangeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChangeEventsRequest proto.InternalMessageInfo
+
+func (m *ListChangeEventsRequest) GetAfterRevision() int64 {
+	if m != nil {
+		return m.AfterRevision
+	}
+	return 0
+}
+
+func (m *ListChangeEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListChangeEventsResponse struct {
+	// Events in revision order
+	Events []*ChangeEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Revision of the latest recorded event, or zero if there are no events
+	LatestRevision       int64    `protobuf:"varint,2,opt,name=latest_revision,json=latestRevision,proto3" json:"latest_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListChangeEventsResponse) Reset()         { *m = ListChangeEventsResponse{} }
+func (m *ListChangeEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListChangeEventsResponse) ProtoMessage()    {}
+func (*ListChangeEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{81}
+}
+
+func (m *ListChangeEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListChangeEventsResponse.Unmarshal(m, b)
+}
+func (m *ListChangeEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListChangeEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListChangeEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListChangeEventsResponse.Merge(m, src)
+}
+func (m *ListChangeEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListChangeEventsResponse.Size(m)
+}
+func (m *ListChangeEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListChangeEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListChangeEventsResponse proto.InternalMessageInfo
+
+func (m *ListChangeEventsResponse) GetEvents() []*ChangeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListChangeEventsResponse) GetLatestRevision() int64 {
+	if m != nil {
+		return m.LatestRevision
+	}
+	return 0
+}
+
+type PruneChangeEventsRequest struct {
+	CreatedBefore        int64    `protobuf:"varint,1,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneChangeEventsRequest) Reset()         { *m = PruneChangeEventsRequest{} }
+func (m *PruneChangeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneChangeEventsRequest) ProtoMessage()    {}
+func (*PruneChangeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{82}
+}
+
+func (m *PruneChangeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneChangeEventsRequest.Unmarshal(m, b)
+}
+func (m *PruneChangeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneChangeEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneChangeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneChangeEventsRequest.Merge(m, src)
+}
+func (m *PruneChangeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneChangeEventsRequest.Size(m)
+}
+func (m *PruneChangeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneChangeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneChangeEventsRequest proto.InternalMessageInfo
+
+func (m *PruneChangeEventsRequest) GetCreatedBefore() int64 {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return 0
+}
+
+type PruneChangeEventsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneChangeEventsResponse) Reset()         { *m = PruneChangeEventsResponse{} }
+func (m *PruneChangeEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneChangeEventsResponse) ProtoMessage()    {}
+func (*PruneChangeEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{83}
+}
+
+func (m *PruneChangeEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneChangeEventsResponse.Unmarshal(m, b)
+}
+func (m *PruneChangeEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneChangeEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneChangeEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneChangeEventsResponse.Merge(m, src)
+}
+func (m *PruneChangeEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneChangeEventsResponse.Size(m)
+}
+func (m *PruneChangeEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneChangeEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneChangeEventsResponse proto.InternalMessageInfo
+
+type CAJournal struct {
+	// SPIFFE ID of the trust domain the journal belongs to
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Serialized journal entries. The datastore does not interpret them.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Revision of the journal, incremented every time the journal is set.
+	// Zero if the journal has never been set.
+	Revision int64 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// ID of the server holding the rotation lease, if any
+	LeaseHolder string `protobuf:"bytes,4,opt,name=lease_holder,json=leaseHolder,proto3" json:"lease_holder,omitempty"`
+	// Expiration of the rotation lease (seconds since unix epoch)
+	LeaseExpiresAt       int64    `protobuf:"varint,5,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CAJournal) Reset()         { *m = CAJournal{} }
+func (m *CAJournal) String() string { return proto.CompactTextString(m) }
+func (*CAJournal) ProtoMessage()    {}
+func (*CAJournal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{84}
+}
+
+func (m *CAJournal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAJournal.Unmarshal(m, b)
+}
+func (m *CAJournal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAJournal.Marshal(b, m, deterministic)
+}
+func (m *CAJournal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAJournal.Merge(m, src)
+}
+func (m *CAJournal) XXX_Size() int {
+	return xxx_messageInfo_CAJournal.Size(m)
+}
+func (m *CAJournal) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAJournal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAJournal proto.InternalMessageInfo
+
+func (m *CAJournal) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *CAJournal) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CAJournal) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *CAJournal) GetLeaseHolder() string {
+	if m != nil {
+		return m.LeaseHolder
+	}
+	return ""
+}
+
+func (m *CAJournal) GetLeaseExpiresAt() int64 {
+	if m != nil {
+		return m.LeaseExpiresAt
+	}
+	return 0
+}
+
+type FetchCAJournalRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchCAJournalRequest) Reset()         { *m = FetchCAJournalRequest{} }
+func (m *FetchCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalRequest) ProtoMessage()    {}
+func (*FetchCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{85}
+}
+
+func (m *FetchCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalRequest.Unmarshal(m, b)
+}
+func (m *FetchCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalRequest.Merge(m, src)
+}
+func (m *FetchCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalRequest.Size(m)
+}
+func (m *FetchCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalRequest proto.InternalMessageInfo
+
+func (m *FetchCAJournalRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type FetchCAJournalResponse struct {
+	// The journal, or unset if it has never been set
+	CaJournal            *CAJournal `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FetchCAJournalResponse) Reset()         { *m = FetchCAJournalResponse{} }
+func (m *FetchCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalResponse) ProtoMessage()    {}
+func (*FetchCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{86}
+}
+
+func (m *FetchCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalResponse.Unmarshal(m, b)
+}
+func (m *FetchCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalResponse.Merge(m, src)
+}
+func (m *FetchCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalResponse.Size(m)
+}
+func (m *FetchCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalResponse proto.InternalMessageInfo
+
+func (m *FetchCAJournalResponse) GetCaJournal() *CAJournal {
+	if m != nil {
+		return m.CaJournal
+	}
+	return nil
+}
+
+type SetCAJournalRequest struct {
+	// The journal to set. Its revision must match the revision of the stored
+	// journal (zero if there is none) or the request fails with ABORTED.
+	CaJournal            *CAJournal `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalRequest) Reset()         { *m = SetCAJournalRequest{} }
+func (m *SetCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalRequest) ProtoMessage()    {}
+func (*SetCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{87}
+}
+
+func (m *SetCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalRequest.Unmarshal(m, b)
+}
+func (m *SetCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalRequest.Merge(m, src)
+}
+func (m *SetCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalRequest.Size(m)
+}
+func (m *SetCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalRequest proto.InternalMessageInfo
+
+func (m *SetCAJournalRequest) GetCaJournal() *CAJournal {
+	if m != nil {
+		return m.CaJournal
+	}
+	return nil
+}
+
+type SetCAJournalResponse struct {
+	// The stored journal, with its new revision
+	CaJournal            *CAJournal `protobuf:"bytes,1,opt,name=ca_journal,json=caJournal,proto3" json:"ca_journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalResponse) Reset()         { *m = SetCAJournalResponse{} }
+func (m *SetCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalResponse) ProtoMessage()    {}
+func (*SetCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{88}
+}
+
+func (m *SetCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalResponse.Unmarshal(m, b)
+}
+func (m *SetCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (m *SetCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalResponse.Merge(m, src)
+}
+func (m *SetCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalResponse.Size(m)
+}
+func (m *SetCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalResponse proto.InternalMessageInfo
+
+func (m *SetCAJournalResponse) GetCaJournal() *CAJournal {
+	if m != nil {
+		return m.CaJournal
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
+	proto.RegisterEnum("spire.server.datastore.ByFederatesWith_MatchBehavior", ByFederatesWith_MatchBehavior_name, ByFederatesWith_MatchBehavior_value)
+	proto.RegisterEnum("spire.server.datastore.FederationRelationship_BundleEndpointProfile", FederationRelationship_BundleEndpointProfile_name, FederationRelationship_BundleEndpointProfile_value)
+	proto.RegisterEnum("spire.server.datastore.ChangeEvent_Type", ChangeEvent_Type_name, ChangeEvent_Type_value)
+	proto.RegisterEnum("spire.server.datastore.ChangeEvent_ResourceType", ChangeEvent_ResourceType_name, ChangeEvent_ResourceType_value)
+	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
+	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
+	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
+	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
+	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
+	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
+	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
+	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
+	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
+	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
+	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
+	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
+	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
+	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
+	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
+	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
+	proto.RegisterType((*TaintX509CARequest)(nil), "spire.server.datastore.TaintX509CARequest")
+	proto.RegisterType((*TaintX509CAResponse)(nil), "spire.server.datastore.TaintX509CAResponse")
+	proto.RegisterType((*RevokeX509CARequest)(nil), "spire.server.datastore.RevokeX509CARequest")
+	proto.RegisterType((*RevokeX509CAResponse)(nil), "spire.server.datastore.RevokeX509CAResponse")
+	proto.RegisterType((*TaintJWTKeyRequest)(nil), "spire.server.datastore.TaintJWTKeyRequest")
+	proto.RegisterType((*TaintJWTKeyResponse)(nil), "spire.server.datastore.TaintJWTKeyResponse")
+	proto.RegisterType((*RevokeJWTKeyRequest)(nil), "spire.server.datastore.RevokeJWTKeyRequest")
+	proto.RegisterType((*RevokeJWTKeyResponse)(nil), "spire.server.datastore.RevokeJWTKeyResponse")
+	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
+	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
+	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
+	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
+	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
+	proto.RegisterType((*ListNodeSelectorsRequest)(nil), "spire.server.datastore.ListNodeSelectorsRequest")
+	proto.RegisterType((*ListNodeSelectorsResponse)(nil), "spire.server.datastore.ListNodeSelectorsResponse")
+	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
+	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
+	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
+	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
+	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
+	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
+	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
+	proto.RegisterType((*AttestedNodeMask)(nil), "spire.server.datastore.AttestedNodeMask")
+	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
+	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
+	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
+	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
+	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
+	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
+	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
+	proto.RegisterType((*ByFederatesWith)(nil), "spire.server.datastore.ByFederatesWith")
+	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
+	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
+	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
+	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
+	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
+	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
+	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
+	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
+	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
+	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
+	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
+	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
+	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
+	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
+	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
+	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*ListJoinTokensRequest)(nil), "spire.server.datastore.ListJoinTokensRequest")
+	proto.RegisterType((*ListJoinTokensResponse)(nil), "spire.server.datastore.ListJoinTokensResponse")
+	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
+	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+	proto.RegisterType((*FederationRelationship)(nil), "spire.server.datastore.FederationRelationship")
+	proto.RegisterType((*CreateFederationRelationshipRequest)(nil), "spire.server.datastore.CreateFederationRelationshipRequest")
+	proto.RegisterType((*CreateFederationRelationshipResponse)(nil), "spire.server.datastore.CreateFederationRelationshipResponse")
+	proto.RegisterType((*FetchFederationRelationshipRequest)(nil), "spire.server.datastore.FetchFederationRelationshipRequest")
+	proto.RegisterType((*FetchFederationRelationshipResponse)(nil), "spire.server.datastore.FetchFederationRelationshipResponse")
+	proto.RegisterType((*ListFederationRelationshipsRequest)(nil), "spire.server.datastore.ListFederationRelationshipsRequest")
+	proto.RegisterType((*ListFederationRelationshipsResponse)(nil), "spire.server.datastore.ListFederationRelationshipsResponse")
+	proto.RegisterType((*UpdateFederationRelationshipRequest)(nil), "spire.server.datastore.UpdateFederationRelationshipRequest")
+	proto.RegisterType((*UpdateFederationRelationshipResponse)(nil), "spire.server.datastore.UpdateFederationRelationshipResponse")
+	proto.RegisterType((*DeleteFederationRelationshipRequest)(nil), "spire.server.datastore.DeleteFederationRelationshipRequest")
+	proto.RegisterType((*DeleteFederationRelationshipResponse)(nil), "spire.server.datastore.DeleteFederationRelationshipResponse")
+	proto.RegisterType((*ChangeEvent)(nil), "spire.server.datastore.ChangeEvent")
+	proto.RegisterType((*ListChangeEventsRequest)(nil), "spire.server.datastore.ListChangeEventsRequest")
+	proto.RegisterType((*ListChangeEventsResponse)(nil), "spire.server.datastore.ListChangeEventsResponse")
+	proto.RegisterType((*PruneChangeEventsRequest)(nil), "spire.server.datastore.PruneChangeEventsRequest")
+	proto.RegisterType((*PruneChangeEventsResponse)(nil), "spire.server.datastore.PruneChangeEventsResponse")
+	proto.RegisterType((*CAJournal)(nil), "spire.server.datastore.CAJournal")
+	proto.RegisterType((*FetchCAJournalRequest)(nil), "spire.server.datastore.FetchCAJournalRequest")
+	proto.RegisterType((*FetchCAJournalResponse)(nil), "spire.server.datastore.FetchCAJournalResponse")
+	proto.RegisterType((*SetCAJournalRequest)(nil), "spire.server.datastore.SetCAJournalRequest")
+	proto.RegisterType((*SetCAJournalResponse)(nil), "spire.server.datastore.SetCAJournalResponse")
+}
+
+func init() { proto.RegisterFile("datastore.proto", fileDescriptor_d08157cfd31fc929) }
+
+var fileDescriptor_d08157cfd31fc929 = []byte{
+	// 3314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5b, 0xcb, 0x73, 0xdb, 0xd6,
+	0xd5, 0x37, 0xf4, 0xb2, 0x78, 0x48, 0x49, 0xf4, 0x95, 0x2d, 0x51, 0x74, 0x62, 0x3b, 0x70, 0x1c,
+	0xe7, 0x61, 0x53, 0xb6, 0x12, 0xdb, 0x79, 0x78, 0xe2, 0xf0, 0x65, 0x99, 0xb1, 0x2c, 0x6b, 0x40,
+	0x2a, 0x76, 0x92, 0xef, 0xfb, 0x10, 0x90, 0xbc, 0x94, 0x60, 0x53, 0x00, 0x3f, 0x00, 0xb4, 0xc3,
+	0xa4, 0x8b, 0xee, 0x3a, 0xed, 0x4c, 0xdb, 0x69, 0x17, 0x9d, 0xe9, 0xae, 0x7f, 0x42, 0xa7, 0x33,
+	0xfd, 0x37, 0xba, 0xe8, 0x74, 0xba, 0xea, 0xae, 0xeb, 0x76, 0xd1, 0xe9, 0x1f, 0xd0, 0xb9, 0x0f,
+	0x80, 0x00, 0x81, 0x0b, 0x82, 0x94, 0xe2, 0xe9, 0x4a, 0xc4, 0xc5, 0x79, 0xfc, 0xce, 0xbd, 0x07,
+	0xf7, 0x9e, 0x7b, 0xce, 0x11, 0xac, 0xb4, 0x35, 0x47, 0xb3, 0x1d, 0xd3, 0xc2, 0x85, 0x9e, 0x65,
+	0x3a, 0x26, 0x5a, 0xb3, 0x7b, 0xba, 0x85, 0x0b, 0x36, 0xb6, 0x5e, 0x60, 0xab, 0xe0, 0xbd, 0xcd,
+	0x5f, 0x38, 0x30, 0xcd, 0x83, 0x2e, 0xde, 0xa4, 0x54, 0xcd, 0x7e, 0x67, 0xf3, 0xa5, 0xa5, 0xf5,
+	0x7a, 0xd8, 0xb2, 0x19, 0x5f, 0xfe, 0x12, 0xe5, 0xdb, 0x6c, 0x99, 0x47, 0x47, 0xa6, 0xb1, 0xd9,
+	0xeb, 0xf6, 0x0f, 0x74, 0xf7, 0x0f, 0xa7, 0xd8, 0x08, 0x50, 0xb0, 0x3f, 0xec, 0x95, 0x5c, 0x86,
+	0xd5, 0xb2, 0x85, 0x35, 0x07, 0x97, 0xfa, 0x46, 0xbb, 0x8b, 0x15, 0xfc, 0xff, 0x7d, 0x6c, 0x3b,
+	0xe8, 0x1a, 0x2c, 0x34, 0xe9, 0x40, 0x4e, 0xba, 0x24, 0xbd, 0x9d, 0xde, 0x3a, 0x5b, 0x60, 0xe0,
+	0x38, 0x2f, 0x27, 0xe6, 0x34, 0x72, 0x05, 0xce, 0x06, 0x85, 0xd8, 0x3d, 0xd3, 0xb0, 0xf1, 0x84,
+	0x52, 0xee, 0x02, 0xba, 0x8f, 0x9d, 0xd6, 0x61, 0x10, 0xc9, 0x5b, 0xb0, 0xe2, 0x58, 0x7d, 0xdb,
+	0x51, 0xdb, 0xe6, 0x91, 0xa6, 0x1b, 0xaa, 0xde, 0xa6, 0xc2, 0x52, 0xca, 0x12, 0x1d, 0xae, 0xd0,
+	0xd1, 0x5a, 0x9b, 0x18, 0x12, 0xe0, 0x9e, 0x0a, 0xc2, 0x53, 0x40, 0x3b, 0xba, 0xed, 0xb0, 0x51,
+	0xdb, 0x85, 0x50, 0x02, 0xe8, 0x69, 0x07, 0xba, 0xa1, 0x39, 0xba, 0x69, 0x70, 0x39, 0x72, 0x21,
+	0x7a, 0xb5, 0x0a, 0x7b, 0x1e, 0xa5, 0xe2, 0xe3, 0x92, 0x7f, 0x2a, 0xc1, 0x6a, 0x40, 0x34, 0xc7,
+	0x57, 0x80, 0xd3, 0x4c, 0xb7, 0x9d, 0x93, 0x2e, 0xcd, 0x0a, 0x01, 0xba, 0x44, 0x23, 0x58, 0x66,
+	0xa6, 0xc2, 0x52, 0x86, 0xd5, 0xfd, 0x5e, 0xfb, 0xf8, 0x6b, 0x1e, 0x14, 0x32, 0xd5, 0x84, 0x7f,
+	0x06, 0xd9, 0x3a, 0x76, 0x8e, 0x83, 0xa3, 0x08, 0x67, 0x7c, 0x12, 0xa6, 0x02, 0x51, 0x86, 0xd5,
+	0x62, 0xaf, 0x87, 0x8d, 0xf6, 0x31, 0xe7, 0x23, 0x28, 0x64, 0x2a, 0x28, 0x7f, 0x94, 0x60, 0xb5,
+	0x82, 0xbb, 0x78, 0x74, 0x6d, 0x12, 0x7e, 0x05, 0xa8, 0x02, 0x73, 0x47, 0x66, 0x1b, 0x53, 0xc7,
+	0x58, 0xde, 0xba, 0x21, 0x72, 0x8c, 0x08, 0x15, 0x85, 0x47, 0x66, 0x1b, 0x2b, 0x94, 0x5b, 0xbe,
+	0x01, 0x73, 0xe4, 0x09, 0x65, 0x60, 0x51, 0xa9, 0xd6, 0x1b, 0x4a, 0xad, 0xdc, 0xc8, 0x9e, 0x42,
+	0x00, 0x0b, 0x95, 0xea, 0x4e, 0xb5, 0x51, 0xcd, 0x4a, 0x68, 0x19, 0xa0, 0x52, 0xab, 0xd7, 0x1f,
+	0x97, 0x6b, 0xc5, 0x46, 0x35, 0x3b, 0x43, 0xac, 0x0f, 0xca, 0x9c, 0xca, 0xfa, 0x16, 0xa0, 0x3d,
+	0xab, 0x6f, 0x4c, 0x69, 0xfb, 0x15, 0x58, 0xc6, 0xdf, 0x12, 0xe9, 0xb6, 0xda, 0xc4, 0x1d, 0xd3,
+	0x62, 0xb3, 0x30, 0xab, 0x2c, 0xf1, 0xd1, 0x12, 0x1d, 0x94, 0xef, 0xc2, 0x6a, 0x40, 0x09, 0x47,
+	0x7a, 0x05, 0x96, 0x19, 0x0a, 0xb5, 0x75, 0xa8, 0x19, 0x07, 0x98, 0x29, 0x59, 0x54, 0x96, 0xd8,
+	0x68, 0x99, 0x0d, 0xca, 0x4d, 0x40, 0x0d, 0x4d, 0x37, 0x9c, 0xa7, 0xb7, 0x6e, 0x7c, 0x54, 0x2e,
+	0x4e, 0x0a, 0xf1, 0x4d, 0x58, 0xb6, 0xfb, 0xcd, 0x67, 0xb8, 0xe5, 0xa8, 0xcf, 0xf1, 0x80, 0x90,
+	0x11, 0x88, 0x19, 0x25, 0xc3, 0x47, 0x1f, 0xe2, 0x41, 0xad, 0x2d, 0x9f, 0x83, 0xd5, 0x80, 0x0e,
+	0x86, 0x50, 0x6e, 0xc1, 0xaa, 0x82, 0x5f, 0x98, 0xcf, 0xf1, 0x0f, 0xa9, 0x7b, 0x0d, 0xce, 0x06,
+	0x95, 0x70, 0xe5, 0x75, 0x6e, 0xf7, 0xe7, 0x4f, 0x1a, 0x0f, 0xf1, 0x60, 0x52, 0xdd, 0xe7, 0x60,
+	0xc1, 0xa7, 0x33, 0xa5, 0xcc, 0x3f, 0x0f, 0x18, 0xea, 0x0a, 0xe5, 0xba, 0x1a, 0xae, 0xa1, 0x27,
+	0xaa, 0xcc, 0xb3, 0x6c, 0x44, 0x5b, 0x13, 0x96, 0x76, 0xcd, 0x36, 0xae, 0xe3, 0x2e, 0x6e, 0x39,
+	0xa6, 0x65, 0xa3, 0xf3, 0x90, 0xb2, 0x7b, 0x7a, 0xa7, 0x83, 0x87, 0x1a, 0x16, 0xd9, 0x40, 0xad,
+	0x8d, 0x3e, 0x80, 0x94, 0xed, 0x52, 0xe6, 0x66, 0xe8, 0x8e, 0xbd, 0x16, 0xf4, 0x69, 0x57, 0x90,
+	0x32, 0x24, 0x94, 0xff, 0x0f, 0xd6, 0xeb, 0xd8, 0x09, 0xa8, 0x71, 0xad, 0x2a, 0xfb, 0x05, 0xb2,
+	0x8f, 0xe4, 0x8a, 0xe8, 0xb3, 0x0d, 0x0a, 0xf0, 0xc9, 0xcf, 0x43, 0x2e, 0x2c, 0x9f, 0xdb, 0xf7,
+	0xbf, 0xb0, 0xbe, 0x2d, 0xd0, 0x1d, 0x6b, 0xe9, 0x15, 0x58, 0x76, 0xcc, 0x2e, 0xb6, 0x34, 0x07,
+	0xab, 0xb6, 0xa3, 0x75, 0xd9, 0xe7, 0xb4, 0xa8, 0x2c, 0xb9, 0xa3, 0x75, 0x32, 0x28, 0xab, 0x90,
+	0xdb, 0x16, 0xa8, 0x3e, 0x19, 0xdb, 0x8a, 0x90, 0x23, 0x07, 0x67, 0xa4, 0x01, 0x61, 0x8c, 0x52,
+	0x14, 0xc6, 0x6f, 0x60, 0x23, 0x42, 0x44, 0x34, 0xc8, 0xd9, 0xa9, 0x40, 0x3e, 0x84, 0x0d, 0x16,
+	0x01, 0x15, 0x1d, 0x07, 0xdb, 0x0e, 0x6e, 0x13, 0x4a, 0x17, 0x65, 0x01, 0xe6, 0x0c, 0xb2, 0x29,
+	0xb3, 0x19, 0xc8, 0x07, 0xdd, 0x25, 0xc0, 0x40, 0xe9, 0xe4, 0x1d, 0xc8, 0x47, 0x09, 0xf3, 0x22,
+	0x86, 0xc9, 0xa4, 0xdd, 0x81, 0x1c, 0x0d, 0x8c, 0xa2, 0x90, 0xc5, 0x39, 0x00, 0xb1, 0x29, 0x82,
+	0x71, 0x4a, 0x14, 0x7f, 0x9a, 0x65, 0xcb, 0xe8, 0x7f, 0xe5, 0x2d, 0xe3, 0x36, 0x9c, 0x69, 0x0e,
+	0xd4, 0x91, 0xcd, 0x9b, 0x49, 0x3e, 0x5f, 0x60, 0xd1, 0x6f, 0xc1, 0x8d, 0x7e, 0x0b, 0x35, 0xc3,
+	0xb9, 0xfd, 0xc1, 0x17, 0x5a, 0xb7, 0x8f, 0x95, 0x95, 0xe6, 0xa0, 0xea, 0xdf, 0xdb, 0x4f, 0x22,
+	0x3a, 0x42, 0x77, 0x20, 0xd5, 0x1c, 0xa8, 0x4d, 0xcd, 0x30, 0x70, 0x3b, 0x37, 0xcb, 0xcd, 0x1b,
+	0x05, 0x51, 0x32, 0xcd, 0x2e, 0xc3, 0xb0, 0xd8, 0x1c, 0x94, 0x28, 0x2d, 0x2a, 0xc0, 0x6a, 0x73,
+	0xa0, 0x6a, 0xd4, 0x40, 0x2a, 0x4a, 0x75, 0x06, 0x3d, 0x9c, 0x9b, 0xa3, 0xd3, 0x7a, 0xa6, 0x39,
+	0x28, 0x0e, 0xdf, 0x34, 0x06, 0x3d, 0x8c, 0x1e, 0x53, 0xab, 0x5d, 0x1f, 0x52, 0x8f, 0x34, 0xa7,
+	0x75, 0x98, 0x9b, 0xa7, 0x0a, 0x2f, 0x8b, 0x30, 0x97, 0x06, 0x43, 0xf7, 0x5b, 0x69, 0x7a, 0x0f,
+	0x8f, 0x08, 0x2f, 0xba, 0x0a, 0x2b, 0x1d, 0xb2, 0x60, 0xea, 0xd0, 0x9f, 0x17, 0xe8, 0xe7, 0xb0,
+	0x4c, 0x87, 0x87, 0x3b, 0x5c, 0x09, 0x56, 0x9a, 0x03, 0xb5, 0xa5, 0x19, 0x2a, 0x71, 0x33, 0x82,
+	0x2a, 0x77, 0x7a, 0xac, 0xa1, 0x4b, 0xcd, 0x41, 0x59, 0x33, 0x14, 0xce, 0x20, 0xff, 0x4a, 0x62,
+	0x1f, 0xd5, 0xc8, 0x82, 0x72, 0xf7, 0xb8, 0x01, 0xf3, 0x64, 0xd9, 0xdd, 0x0f, 0x2a, 0xce, 0x3f,
+	0x18, 0xe1, 0x89, 0x04, 0xb6, 0x7f, 0x9b, 0x85, 0x0d, 0x16, 0x94, 0x4e, 0xea, 0xec, 0xe8, 0x1a,
+	0xa0, 0x16, 0xb6, 0x1c, 0xd5, 0xc6, 0x96, 0xae, 0x75, 0x55, 0xa3, 0x7f, 0xd4, 0xc4, 0x16, 0x3f,
+	0x40, 0xb2, 0xe4, 0x4d, 0x9d, 0xbe, 0xd8, 0xa5, 0xe3, 0xe4, 0x2c, 0xa5, 0xd4, 0x86, 0xe9, 0xa8,
+	0x5a, 0xc7, 0xc1, 0x16, 0x75, 0x94, 0x59, 0x25, 0x43, 0x46, 0x77, 0x4d, 0xa7, 0x48, 0xc6, 0xd0,
+	0xfb, 0xb0, 0x66, 0xe0, 0x97, 0x6a, 0x84, 0x5c, 0xe6, 0x13, 0xab, 0x06, 0x7e, 0x59, 0x1e, 0x15,
+	0xfd, 0x1e, 0x20, 0x8f, 0x69, 0x28, 0x7e, 0x9e, 0x8a, 0x5f, 0xe1, 0x0c, 0x9e, 0x86, 0x6d, 0x00,
+	0xdd, 0xe8, 0xf5, 0x1d, 0xf5, 0x48, 0xb3, 0x9f, 0xd3, 0xc5, 0x4e, 0x6f, 0xbd, 0x2d, 0x9a, 0x34,
+	0xff, 0x9c, 0x3c, 0xd2, 0xec, 0xe7, 0x4a, 0x8a, 0xf2, 0x92, 0x9f, 0xe8, 0x32, 0x2c, 0x69, 0x07,
+	0xd8, 0x70, 0xd4, 0x17, 0xd8, 0xb2, 0xc9, 0x02, 0x9c, 0xa6, 0x08, 0x33, 0x74, 0xf0, 0x0b, 0x36,
+	0x86, 0x3e, 0x75, 0x89, 0xd8, 0xe5, 0xd2, 0xce, 0x2d, 0xd2, 0xc5, 0xdd, 0x18, 0x59, 0x5c, 0x42,
+	0xb2, 0x47, 0x29, 0x38, 0x3f, 0x7b, 0xa0, 0x07, 0x6b, 0x57, 0xb3, 0xc9, 0x5c, 0x60, 0x23, 0x97,
+	0xa2, 0x16, 0x2d, 0x92, 0x81, 0x3a, 0xc6, 0x06, 0x7a, 0x03, 0x32, 0x01, 0x87, 0x04, 0xea, 0xb9,
+	0xe9, 0x96, 0xcf, 0xe5, 0xfe, 0x3c, 0x03, 0xd9, 0x51, 0x23, 0x04, 0x0b, 0xc7, 0x8e, 0x81, 0x24,
+	0x0b, 0xc7, 0x0e, 0xb5, 0xa4, 0x0b, 0x37, 0x4b, 0xa9, 0x27, 0x58, 0xb8, 0x39, 0xca, 0x10, 0x5a,
+	0xb8, 0xd0, 0x7c, 0xcf, 0x33, 0x18, 0x81, 0xf9, 0xbe, 0x3c, 0x3a, 0xdf, 0x0b, 0x3e, 0xa2, 0xc8,
+	0x49, 0x3d, 0x4d, 0x09, 0xc4, 0x93, 0xba, 0x18, 0x9e, 0xd4, 0x1d, 0xc8, 0x47, 0x7d, 0x32, 0x53,
+	0x6e, 0xf3, 0x1f, 0xc2, 0x06, 0xbb, 0x07, 0x4c, 0x7c, 0xda, 0xec, 0x40, 0x3e, 0x8a, 0x73, 0x4a,
+	0x1c, 0x4f, 0xe0, 0x02, 0x3b, 0x42, 0x15, 0x7c, 0xa0, 0xdb, 0x8e, 0x45, 0xf7, 0x87, 0xaa, 0xe1,
+	0x58, 0x5e, 0x34, 0x79, 0x0b, 0xe6, 0x31, 0x79, 0xe6, 0x22, 0x2f, 0x06, 0x45, 0x86, 0xd9, 0x18,
+	0xb5, 0xfc, 0x14, 0x2e, 0x0a, 0x05, 0x73, 0xac, 0x53, 0x4a, 0xfe, 0x18, 0x5e, 0xa7, 0xc7, 0xad,
+	0x10, 0xf1, 0x06, 0x2c, 0x52, 0xca, 0xe1, 0xec, 0x9d, 0xa6, 0xcf, 0xb5, 0x36, 0x31, 0x57, 0xc4,
+	0x7b, 0x3c, 0x50, 0x7f, 0x97, 0x20, 0xed, 0x3b, 0x73, 0x82, 0xe1, 0xaf, 0x94, 0x30, 0xfc, 0x45,
+	0xdb, 0x30, 0xcf, 0x4e, 0x37, 0x76, 0x2d, 0xbd, 0x99, 0xe0, 0x74, 0x2b, 0xd0, 0x23, 0xad, 0x84,
+	0x0f, 0xb5, 0x17, 0xba, 0x69, 0x29, 0x8c, 0x5f, 0xde, 0x87, 0xa5, 0xc0, 0x38, 0x5a, 0x81, 0xf4,
+	0xa3, 0x62, 0xa3, 0xfc, 0x40, 0xad, 0x3e, 0x2d, 0xd2, 0x4b, 0x6a, 0x16, 0x32, 0x6c, 0xa0, 0xbe,
+	0x5f, 0xaa, 0x57, 0x1b, 0x59, 0x09, 0x2d, 0x41, 0x8a, 0x8d, 0x14, 0x77, 0xbf, 0xcc, 0xce, 0x20,
+	0x04, 0xcb, 0x2e, 0xc1, 0x5e, 0x55, 0x21, 0x24, 0xb3, 0xf2, 0x1f, 0x24, 0x58, 0x29, 0x0d, 0xee,
+	0xe3, 0x36, 0x8d, 0x19, 0xed, 0x27, 0xba, 0x73, 0x48, 0x3e, 0x3e, 0xff, 0x6d, 0x83, 0x59, 0x9b,
+	0x52, 0x32, 0xbe, 0xbb, 0x86, 0x8d, 0x1e, 0x06, 0x0d, 0xbb, 0x25, 0x36, 0x2c, 0x20, 0x3c, 0xda,
+	0xb8, 0xeb, 0xa3, 0xc6, 0x0d, 0x91, 0xef, 0xec, 0x64, 0x4f, 0x05, 0x0d, 0x91, 0xe4, 0x7b, 0x00,
+	0xc3, 0x63, 0x10, 0x9d, 0x85, 0x79, 0xc7, 0x7c, 0x8e, 0x0d, 0xee, 0x19, 0xec, 0x81, 0x7c, 0x71,
+	0x3d, 0xed, 0x00, 0xab, 0xb6, 0xfe, 0x1d, 0x0b, 0xdf, 0xe7, 0x95, 0x45, 0x32, 0x50, 0xd7, 0xbf,
+	0xc3, 0xf2, 0x3f, 0xe7, 0xe1, 0x02, 0x39, 0xc1, 0x47, 0x17, 0x5f, 0x1f, 0x06, 0x66, 0x9f, 0x42,
+	0xa6, 0x39, 0x50, 0x7b, 0x9a, 0x45, 0x76, 0x21, 0xee, 0x76, 0xe9, 0xad, 0xd7, 0x42, 0x51, 0x42,
+	0xdd, 0xb1, 0x74, 0xe3, 0x80, 0xc5, 0x09, 0xd0, 0x1c, 0xec, 0x51, 0x86, 0x5a, 0x1b, 0xdd, 0xa7,
+	0xfc, 0xfe, 0x0b, 0x53, 0xe2, 0xe8, 0x26, 0xdd, 0xf4, 0xb9, 0x1d, 0xc3, 0x31, 0xdc, 0x3c, 0x66,
+	0x93, 0xe1, 0xa8, 0xbb, 0xa7, 0x7b, 0x30, 0xb8, 0x98, 0x9b, 0x2a, 0x2e, 0x0c, 0xdf, 0x35, 0xe6,
+	0x23, 0xee, 0x1a, 0xa8, 0x4e, 0xa3, 0xba, 0x8e, 0xbb, 0xdc, 0xea, 0x4b, 0xdd, 0x39, 0xe4, 0x27,
+	0xf3, 0xd5, 0x84, 0xee, 0x41, 0x22, 0xbb, 0xa0, 0x33, 0xde, 0x83, 0xa5, 0xe6, 0x40, 0x6d, 0x9b,
+	0x2f, 0x0d, 0xdb, 0xb1, 0xb0, 0x76, 0x94, 0x20, 0x5c, 0xcb, 0x34, 0x07, 0x15, 0x8f, 0x1e, 0xdd,
+	0x82, 0x45, 0x12, 0x9b, 0xb6, 0x8f, 0x74, 0x83, 0x1e, 0x02, 0xf1, 0xbc, 0xa7, 0x9b, 0x83, 0x22,
+	0x21, 0x45, 0x77, 0x21, 0x4d, 0xf4, 0x1a, 0xb6, 0x6a, 0x68, 0x47, 0x98, 0x9e, 0xd9, 0xe3, 0xa6,
+	0x3d, 0xd5, 0x1c, 0x54, 0x0c, 0x7b, 0x57, 0x3b, 0xc2, 0xa8, 0x0a, 0x59, 0x5f, 0x58, 0xcf, 0xce,
+	0x43, 0x18, 0x1f, 0xd5, 0x2f, 0x7b, 0x51, 0xbd, 0x1b, 0xe4, 0x44, 0xdc, 0x0e, 0xd2, 0x93, 0xdf,
+	0x0e, 0xe4, 0xdf, 0x49, 0x70, 0x51, 0xe8, 0xf0, 0x7c, 0x9f, 0xfc, 0x08, 0xe8, 0xa6, 0xaa, 0x7b,
+	0xa1, 0xeb, 0xd8, 0x9d, 0xd2, 0xa5, 0x3f, 0x91, 0x08, 0xf6, 0x5f, 0x12, 0x5c, 0x60, 0xc7, 0xf1,
+	0x09, 0x1f, 0x5c, 0x68, 0x1f, 0x72, 0xf8, 0xdb, 0x1e, 0x6e, 0x39, 0xb8, 0xad, 0x5a, 0xf8, 0x85,
+	0x4e, 0x22, 0x0c, 0x7f, 0x98, 0x3b, 0x66, 0x32, 0xd7, 0x5c, 0x66, 0x85, 0xf3, 0xf2, 0xa8, 0xa7,
+	0x14, 0x88, 0x40, 0x67, 0x03, 0xdf, 0xb7, 0x08, 0xd2, 0x48, 0xf0, 0x49, 0xce, 0x54, 0xa1, 0xcd,
+	0xc7, 0x3b, 0xbe, 0x7e, 0x2d, 0xc1, 0x05, 0x16, 0x55, 0x4c, 0x71, 0xaa, 0xfe, 0x40, 0x53, 0x46,
+	0xcc, 0x15, 0x62, 0x3a, 0x9e, 0xb9, 0x0f, 0xe0, 0x22, 0x4d, 0x6d, 0xc6, 0xec, 0xe8, 0xe1, 0x24,
+	0xa9, 0x14, 0x95, 0x24, 0x95, 0xe1, 0x92, 0x58, 0x12, 0x4f, 0x2c, 0x7d, 0x04, 0xa9, 0xcf, 0x4d,
+	0xdd, 0x68, 0xd0, 0x93, 0x26, 0xfa, 0xfc, 0x59, 0x83, 0x05, 0x2a, 0x77, 0xc0, 0x53, 0xb1, 0xfc,
+	0x49, 0xfe, 0x0a, 0xd6, 0x58, 0x14, 0xe5, 0x09, 0x70, 0xf1, 0x7d, 0x06, 0xf0, 0xcc, 0xd4, 0x0d,
+	0x75, 0x28, 0x2c, 0xbd, 0xf5, 0x86, 0xe8, 0x23, 0x1a, 0x72, 0xa7, 0x9e, 0xb9, 0x3f, 0xe5, 0xaf,
+	0x61, 0x3d, 0x24, 0x9b, 0x4f, 0xeb, 0xf1, 0x85, 0x5f, 0x87, 0x73, 0x34, 0xd0, 0x0a, 0xe1, 0x8e,
+	0xb4, 0x9f, 0xd8, 0x39, 0x4a, 0x7e, 0x62, 0x50, 0x0a, 0xb0, 0xc6, 0xdc, 0x28, 0x21, 0x96, 0xaf,
+	0x61, 0x3d, 0x44, 0x7f, 0x62, 0x60, 0xd6, 0xe1, 0x1c, 0xd9, 0x59, 0xbd, 0x77, 0xae, 0xbf, 0xc9,
+	0xff, 0x03, 0x6b, 0xa3, 0x2f, 0xb8, 0xd2, 0x12, 0xa4, 0x87, 0x4a, 0xdd, 0xdd, 0x36, 0x81, 0x56,
+	0xf0, 0xb4, 0xda, 0xf2, 0x3d, 0x58, 0xa3, 0x6e, 0x1a, 0xd2, 0x9b, 0xd4, 0xcf, 0x37, 0x60, 0x3d,
+	0x24, 0x80, 0xbb, 0xf7, 0x5f, 0x67, 0xc8, 0xe2, 0xd1, 0x53, 0x98, 0xec, 0xd2, 0xb8, 0x4b, 0xff,
+	0xda, 0x87, 0x7a, 0x2f, 0x71, 0x26, 0xba, 0x00, 0xab, 0xbc, 0xa6, 0x80, 0x8d, 0x76, 0xcf, 0xd4,
+	0x0d, 0x47, 0xed, 0x5b, 0x5d, 0x9e, 0x55, 0x38, 0xc3, 0x5e, 0x55, 0xf9, 0x9b, 0x7d, 0xab, 0x8b,
+	0x7e, 0x04, 0xeb, 0xa3, 0xf4, 0x3d, 0xcb, 0xec, 0xe8, 0x5d, 0x4c, 0x77, 0xd6, 0xe5, 0xad, 0x8a,
+	0x68, 0x7a, 0xa2, 0x81, 0xf2, 0xfa, 0x8a, 0xab, 0x62, 0x8f, 0xc9, 0x52, 0xce, 0x35, 0xa3, 0x86,
+	0xc9, 0x4d, 0xda, 0x53, 0x3b, 0x0c, 0xb5, 0x58, 0xaa, 0x22, 0xeb, 0xbe, 0x71, 0x43, 0x2a, 0xf9,
+	0x43, 0x38, 0x17, 0x29, 0x9d, 0x44, 0xe0, 0x0f, 0x1a, 0x8d, 0xbd, 0xba, 0x5a, 0xdf, 0xab, 0xdd,
+	0xbf, 0x5f, 0x65, 0x81, 0x2b, 0x1b, 0x79, 0x52, 0x2d, 0x65, 0x25, 0xf9, 0x17, 0x12, 0x5c, 0x66,
+	0x5f, 0x68, 0x34, 0x6a, 0x77, 0x09, 0x0f, 0x60, 0xbd, 0xe3, 0x11, 0xa8, 0x96, 0x8f, 0x82, 0xbb,
+	0x68, 0x61, 0xb2, 0xd9, 0x50, 0xd6, 0x3a, 0x91, 0xe3, 0xf2, 0x2f, 0x25, 0x78, 0x33, 0x1e, 0x10,
+	0x77, 0xd9, 0x57, 0x86, 0x68, 0x07, 0x64, 0xba, 0x6f, 0xc4, 0x4f, 0x50, 0xd2, 0xd2, 0x38, 0x99,
+	0xf0, 0x58, 0x71, 0xaf, 0xda, 0xbc, 0x43, 0x90, 0xc9, 0xa6, 0x10, 0xcd, 0x75, 0xa2, 0x65, 0xf7,
+	0xbf, 0x48, 0x70, 0x39, 0x56, 0x15, 0x37, 0x5d, 0x87, 0x9c, 0xc0, 0x74, 0x77, 0x67, 0x9a, 0xd4,
+	0xf6, 0xf5, 0x68, 0xdb, 0x4f, 0x26, 0x4c, 0x24, 0x2b, 0xca, 0x42, 0xa6, 0xff, 0xa2, 0x4f, 0x28,
+	0x1e, 0xd0, 0xab, 0xf6, 0xb1, 0x47, 0x70, 0x99, 0x1d, 0x77, 0x27, 0xf3, 0x0d, 0x11, 0x03, 0xe3,
+	0xe5, 0xbd, 0x6a, 0x03, 0xff, 0x3d, 0x03, 0x69, 0x56, 0x95, 0xae, 0xbe, 0xc0, 0x86, 0x83, 0xf2,
+	0xb0, 0xe8, 0x06, 0xa9, 0xfc, 0xac, 0xf3, 0x9e, 0xd1, 0x5d, 0x98, 0xa3, 0xb5, 0x08, 0x96, 0xa6,
+	0x10, 0x66, 0x88, 0x7d, 0xe2, 0x0a, 0x8d, 0x41, 0x0f, 0x2b, 0x94, 0x0b, 0xed, 0xc3, 0x92, 0x85,
+	0x6d, 0xb3, 0x6f, 0xb5, 0x30, 0x2b, 0x69, 0xcc, 0xc6, 0x77, 0x17, 0xf8, 0xc5, 0x28, 0x9c, 0x91,
+	0x8a, 0xcb, 0x58, 0xbe, 0x27, 0x74, 0x11, 0xd2, 0x9e, 0x58, 0xef, 0xa0, 0x01, 0x77, 0xa8, 0xd6,
+	0x46, 0xaf, 0x03, 0xb4, 0xe8, 0xb6, 0xdc, 0x56, 0x35, 0x87, 0xa7, 0xc0, 0x53, 0x7c, 0xa4, 0xe8,
+	0xc8, 0xd7, 0x61, 0x8e, 0xca, 0x49, 0xc3, 0xe9, 0xb2, 0x52, 0x2d, 0x36, 0xaa, 0x95, 0xec, 0x29,
+	0xf2, 0xb0, 0xbf, 0x57, 0xa1, 0x0f, 0x12, 0x79, 0x60, 0x1d, 0x0b, 0x95, 0xec, 0x8c, 0x5c, 0x85,
+	0x8c, 0x1f, 0x0c, 0x5a, 0x03, 0xa4, 0x54, 0xb7, 0x6b, 0xf5, 0x86, 0x52, 0x6c, 0xd4, 0x1e, 0xef,
+	0xaa, 0xd5, 0xdd, 0x86, 0xf2, 0x65, 0xf6, 0x14, 0x3a, 0x03, 0x4b, 0xc5, 0x46, 0xa3, 0x5a, 0x6f,
+	0x54, 0x2b, 0xea, 0xee, 0xe3, 0x4a, 0x35, 0x2b, 0x21, 0x80, 0x85, 0xd2, 0xfe, 0x6e, 0x65, 0xa7,
+	0x9a, 0x9d, 0x91, 0xbf, 0x80, 0x75, 0xb2, 0xa1, 0xf8, 0x6c, 0xf4, 0xc7, 0x1c, 0xf4, 0x92, 0xab,
+	0x8e, 0xac, 0xc3, 0x12, 0x1d, 0x75, 0x6f, 0x01, 0x24, 0x3c, 0xeb, 0xea, 0x47, 0xba, 0xc3, 0x13,
+	0x32, 0xec, 0x41, 0xfe, 0xb1, 0xc4, 0x0a, 0x64, 0x41, 0xc1, 0xdc, 0xa9, 0x3e, 0x81, 0x05, 0x4c,
+	0x47, 0xf8, 0x66, 0x74, 0x39, 0xc1, 0xd4, 0x2b, 0x9c, 0x05, 0x5d, 0x85, 0x95, 0xae, 0xe6, 0x60,
+	0xdb, 0x19, 0xe2, 0x62, 0xd1, 0xf8, 0x32, 0x1b, 0x76, 0x81, 0xc9, 0x45, 0xc8, 0xd1, 0x60, 0x48,
+	0x60, 0x9b, 0xbb, 0x16, 0xc1, 0x78, 0x8a, 0x8f, 0xf2, 0x78, 0xea, 0x3c, 0x6c, 0x44, 0x88, 0xe0,
+	0x11, 0xd5, 0xef, 0x25, 0x48, 0x95, 0x8b, 0x9f, 0x9b, 0x7d, 0xcb, 0xd0, 0xba, 0x89, 0x83, 0x28,
+	0x04, 0x73, 0xc4, 0x3e, 0xde, 0xad, 0x40, 0x7f, 0x07, 0x7c, 0x7d, 0x76, 0xc4, 0xd7, 0xdf, 0x80,
+	0x4c, 0x17, 0x6b, 0x36, 0x56, 0x0f, 0xcd, 0x6e, 0xdb, 0xab, 0xb5, 0xa4, 0xe9, 0xd8, 0x03, 0x3a,
+	0x84, 0xde, 0x86, 0x2c, 0x23, 0xf1, 0x72, 0x13, 0xae, 0x7b, 0x2d, 0xd3, 0x71, 0x37, 0xfd, 0xe0,
+	0xc8, 0xf7, 0x78, 0xbc, 0xef, 0xc1, 0x9e, 0x74, 0xdf, 0x70, 0x6f, 0x00, 0x3e, 0x01, 0xc3, 0xa0,
+	0xbb, 0xa5, 0xa9, 0xcf, 0xd8, 0xe8, 0xb8, 0xa0, 0x7b, 0xc8, 0x9e, 0x6a, 0x69, 0xfc, 0xa7, 0xfc,
+	0x04, 0x56, 0xeb, 0xd8, 0x09, 0x41, 0x3b, 0xbe, 0xe0, 0xa7, 0x70, 0x36, 0x28, 0xf8, 0xa4, 0x20,
+	0x6f, 0xfd, 0xe3, 0x2a, 0xa4, 0x2a, 0x9a, 0xa3, 0xd5, 0x09, 0x09, 0xd2, 0x21, 0xe3, 0xef, 0x1b,
+	0x44, 0xef, 0x09, 0x65, 0x85, 0x5b, 0x14, 0xf3, 0xd7, 0x92, 0x11, 0x73, 0xe8, 0x1d, 0x48, 0xfb,
+	0xda, 0x03, 0xd1, 0xbb, 0xe2, 0x4d, 0x78, 0xb4, 0x03, 0x31, 0xff, 0x5e, 0x22, 0xda, 0xa1, 0x1e,
+	0x5f, 0x9b, 0x9f, 0x58, 0x4f, 0xb8, 0xcd, 0x50, 0xac, 0x27, 0xaa, 0x6f, 0x50, 0x87, 0x8c, 0xbf,
+	0xfd, 0x4e, 0x3c, 0x75, 0x11, 0x9d, 0x7e, 0xe2, 0xa9, 0x8b, 0xec, 0xe8, 0xfb, 0x06, 0x52, 0x5e,
+	0x87, 0x1d, 0x12, 0x9e, 0x1d, 0xa3, 0x6d, 0x7c, 0xf9, 0x77, 0x12, 0x50, 0x0e, 0x8d, 0xf1, 0xf7,
+	0xce, 0x89, 0x8d, 0x89, 0x68, 0xd3, 0x13, 0x1b, 0x13, 0xd9, 0x8e, 0xa7, 0x43, 0xc6, 0xdf, 0xa8,
+	0x26, 0x56, 0x15, 0xd1, 0x22, 0x27, 0x56, 0x15, 0xd9, 0xfb, 0xd6, 0x81, 0xb4, 0xaf, 0xd1, 0x4c,
+	0xec, 0x0a, 0xe1, 0x96, 0x37, 0xb1, 0x2b, 0x44, 0x75, 0xae, 0x75, 0x20, 0xed, 0x6b, 0x17, 0x13,
+	0xeb, 0x09, 0xf7, 0xad, 0x89, 0xf5, 0x44, 0xf4, 0x9f, 0x91, 0xa9, 0xf3, 0xb7, 0x86, 0x89, 0xa7,
+	0x2e, 0xa2, 0x4b, 0x4d, 0x3c, 0x75, 0x51, 0xdd, 0x66, 0x9e, 0x49, 0xac, 0x55, 0x6b, 0x8c, 0x49,
+	0x81, 0x2e, 0xb1, 0x31, 0x26, 0x05, 0x7b, 0xbf, 0x86, 0x26, 0x71, 0x45, 0x63, 0x4c, 0x0a, 0x6a,
+	0xba, 0x96, 0x8c, 0x98, 0xab, 0xfa, 0x1e, 0x50, 0xb8, 0xa9, 0x07, 0xdd, 0x8c, 0xdf, 0xc4, 0x22,
+	0xaa, 0xa8, 0xf9, 0xad, 0x49, 0x58, 0xb8, 0xf2, 0x6f, 0xe1, 0x4c, 0xa8, 0x95, 0x07, 0xdd, 0x88,
+	0xdd, 0xd7, 0xa2, 0x54, 0xdf, 0x9c, 0x80, 0x63, 0xa8, 0x39, 0xd4, 0x25, 0x22, 0xd6, 0x2c, 0xea,
+	0x10, 0x12, 0x6b, 0x16, 0xb7, 0xa0, 0x7c, 0x0f, 0x28, 0x5c, 0xd8, 0x16, 0x4f, 0xb8, 0xb0, 0x6f,
+	0x44, 0x3c, 0xe1, 0x31, 0x75, 0xf3, 0xef, 0x01, 0x85, 0xab, 0xd9, 0x62, 0xe5, 0xc2, 0x9a, 0xb9,
+	0x58, 0x79, 0x4c, 0xb1, 0xbc, 0x4f, 0x9b, 0xaa, 0x83, 0x4d, 0x8d, 0x9b, 0x31, 0xbb, 0x71, 0x54,
+	0x6b, 0x5d, 0xfe, 0x46, 0x72, 0x86, 0xa1, 0xda, 0xed, 0xc4, 0x6a, 0xb7, 0x27, 0x55, 0x2b, 0x6c,
+	0x32, 0xe4, 0x1e, 0x16, 0xd4, 0x1b, 0xeb, 0x61, 0x91, 0x8a, 0x6f, 0x4e, 0xc0, 0xc1, 0x35, 0xff,
+	0x4c, 0x72, 0x53, 0xcd, 0xa1, 0x8c, 0x3c, 0xba, 0x1d, 0xff, 0x95, 0x8a, 0xca, 0x11, 0xf9, 0x3b,
+	0x13, 0xf3, 0x71, 0x30, 0x3f, 0x91, 0x78, 0xa4, 0x19, 0xc6, 0x72, 0x2b, 0xf6, 0xb3, 0x15, 0x42,
+	0xb9, 0x3d, 0x29, 0x9b, 0x6f, 0x5a, 0x04, 0x65, 0x36, 0xf1, 0xb4, 0xc4, 0x17, 0xa2, 0xc5, 0xd3,
+	0x32, 0xae, 0x9e, 0x47, 0xc0, 0x08, 0x8a, 0x4b, 0x62, 0x30, 0xf1, 0x15, 0x38, 0x31, 0x98, 0x71,
+	0x55, 0x2c, 0x02, 0x46, 0x50, 0xfa, 0x11, 0x83, 0x89, 0xaf, 0x5f, 0x89, 0xc1, 0x8c, 0xab, 0x31,
+	0xfd, 0x5c, 0xe2, 0xd7, 0xbd, 0xa8, 0x75, 0xba, 0x13, 0x1b, 0x80, 0xc4, 0x2c, 0xd4, 0x87, 0x93,
+	0x33, 0x72, 0x3c, 0x16, 0xac, 0x8c, 0xd4, 0x6d, 0x50, 0x21, 0xfe, 0x63, 0x18, 0x2d, 0x7c, 0xe4,
+	0x37, 0x13, 0xd3, 0x73, 0x9d, 0x26, 0x2c, 0x07, 0xeb, 0x33, 0xe8, 0x7a, 0xac, 0xd3, 0x87, 0x34,
+	0x16, 0x92, 0x92, 0x0f, 0x8d, 0x1c, 0x29, 0xc2, 0x88, 0x8d, 0x8c, 0xae, 0xee, 0x88, 0x8d, 0x14,
+	0x55, 0x77, 0x4c, 0x58, 0x0e, 0x96, 0x60, 0xc4, 0x46, 0x46, 0xd6, 0x70, 0xc4, 0x46, 0x0a, 0x2a,
+	0x3b, 0x16, 0xac, 0x8c, 0x14, 0x55, 0xc4, 0x46, 0x46, 0x97, 0x6f, 0xc4, 0x46, 0x0a, 0xaa, 0x35,
+	0xe8, 0xb7, 0x12, 0xbc, 0x16, 0x97, 0xc3, 0x47, 0x9f, 0xc4, 0xfb, 0x46, 0x6c, 0x96, 0x30, 0x7f,
+	0x77, 0x3a, 0x66, 0x8e, 0xed, 0x37, 0x12, 0x9c, 0x8f, 0xc9, 0xbf, 0xa3, 0x8f, 0x63, 0x9d, 0x28,
+	0x1e, 0xd9, 0x27, 0x53, 0xf1, 0xfa, 0x80, 0xc5, 0x64, 0xc7, 0xc5, 0xc0, 0xc6, 0x67, 0xef, 0xc5,
+	0xc0, 0x92, 0xa4, 0xe3, 0xc9, 0x6a, 0xc6, 0xa5, 0x93, 0xc5, 0xab, 0x99, 0x20, 0x2b, 0x2e, 0x5e,
+	0xcd, 0x44, 0x19, 0x6c, 0x82, 0x2d, 0x2e, 0x13, 0x2c, 0xc6, 0x96, 0x20, 0x1f, 0x2d, 0xc6, 0x96,
+	0x28, 0xf9, 0xdc, 0x87, 0xec, 0x68, 0x0e, 0x51, 0x1c, 0x82, 0x09, 0xd2, 0x98, 0xf9, 0x1b, 0xc9,
+	0x19, 0x86, 0x21, 0x58, 0x28, 0xeb, 0x27, 0x0e, 0xc1, 0x44, 0x39, 0x46, 0x71, 0x08, 0x26, 0x4c,
+	0x29, 0x7a, 0x1b, 0xf8, 0x30, 0xad, 0x18, 0xbf, 0x81, 0x8f, 0x26, 0xcb, 0xc6, 0x6c, 0xe0, 0xe1,
+	0x14, 0x98, 0x0e, 0x19, 0x7f, 0x6a, 0x4c, 0x7c, 0x63, 0x8c, 0xc8, 0xcc, 0x89, 0x6f, 0x8c, 0x91,
+	0xd9, 0xb6, 0xaf, 0x20, 0x55, 0x36, 0x8d, 0x8e, 0x7e, 0xd0, 0xb7, 0x30, 0xba, 0x12, 0x6c, 0x01,
+	0xe1, 0xff, 0xde, 0xeb, 0xbd, 0x77, 0x35, 0xbc, 0x35, 0x8e, 0xcc, 0xbb, 0x60, 0x2f, 0x6d, 0x63,
+	0xde, 0x43, 0x5c, 0x33, 0x3a, 0x26, 0x7a, 0x27, 0x92, 0x31, 0x40, 0xe3, 0xea, 0x78, 0x37, 0x09,
+	0x29, 0xd3, 0x53, 0xba, 0xfd, 0xd5, 0x07, 0x07, 0xba, 0x73, 0xd8, 0x6f, 0x12, 0xea, 0x4d, 0x56,
+	0x55, 0xde, 0x64, 0xff, 0x8d, 0x4c, 0x5b, 0x66, 0xf8, 0x6f, 0x36, 0x21, 0x9b, 0xde, 0x84, 0x34,
+	0x17, 0xe8, 0xdb, 0xf7, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0x69, 0x17, 0x80, 0x25, 0x25, 0x3d,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DataStoreClient is the client API for DataStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DataStoreClient interface {
+	// Creates a bundle
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
+	// Fetches a specific bundle
+	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	// Lists bundles (optionally filtered)
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	// Updates a specific bundle
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
+	// Sets bundle contents (creates if it does not exist)
+	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
+	// Appends contents from a specific bundle (creates if it does not exist)
+	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
+	// Deletes a specific bundle
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
+	// Prunes all expired certificates and JWT signing keys from a bundle
+	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
+	// Marks a root CA of a bundle as tainted
+	TaintX509CA(ctx context.Context, in *TaintX509CARequest, opts ...grpc.CallOption) (*TaintX509CAResponse, error)
+	// Removes a tainted root CA from a bundle
+	RevokeX509CA(ctx context.Context, in *RevokeX509CARequest, opts ...grpc.CallOption) (*RevokeX509CAResponse, error)
+	// Marks a JWT signing key of a bundle as tainted
+	TaintJWTKey(ctx context.Context, in *TaintJWTKeyRequest, opts ...grpc.CallOption) (*TaintJWTKeyResponse, error)
+	// Removes a tainted JWT signing key from a bundle
+	RevokeJWTKey(ctx context.Context, in *RevokeJWTKeyRequest, opts ...grpc.CallOption) (*RevokeJWTKeyResponse, error)
+	// Creates an attested node
+	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
+	// Fetches a specific attested node
+	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
+	// Lists attested nodes (optionally filtered)
+	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
+	// Updates a specific attested node
+	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Deletes a specific attested node
+	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	// Sets the set of selectors for a specific node id
+	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
+	// Gets the set of node selectors for a specific node id
+	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
+	// Lists the sets of node selectors for all nodes
+	ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error)
+	// Creates a registration entry
+	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
+	// Fetches a specific registration entry
+	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
+	// Lists registration entries (optionally filtered)
+	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
+	// Updates a specific registration entry
+	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
+	// Deletes a specific registration entry
+	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
+	// Prunes all registration entries that expire before the specified timestamp
+	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
+	// Creates a join token
+	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
+	// Fetches a specific join token
+	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
+	// Delete a specific join token
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// Prunes all join tokens that expire before the specified timestamp
+	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error)
+	// Lists the change events recorded after a given revision
+	ListChangeEvents(ctx context.Context, in *ListChangeEventsRequest, opts ...grpc.CallOption) (*ListChangeEventsResponse, error)
+	// Prunes all change events recorded before the specified timestamp
+	PruneChangeEvents(ctx context.Context, in *PruneChangeEventsRequest, opts ...grpc.CallOption) (*PruneChangeEventsResponse, error)
+	// Fetches the CA journal of a trust domain
+	FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error)
+	// Sets the CA journal of a trust domain if its revision has not changed
+	SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error)
+	// Applies the plugin configuration
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	// Returns the version and related metadata of the installed plugin
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type dataStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDataStoreClient(cc *grpc.ClientConn) DataStoreClient {
+	return &dataStoreClient{cc}
+}
+
+func (c *dataStoreClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
 	out := new(CreateBundleResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateBundle", in, out, opts...)
 	if err != nil {
@@ -2651,6 +4671,42 @@ func (c *dataStoreClient) PruneBundle(ctx context.Context, in *PruneBundleReques
 	return out, nil
 }
 
+func (c *dataStoreClient) TaintX509CA(ctx context.Context, in *TaintX509CARequest, opts ...grpc.CallOption) (*TaintX509CAResponse, error) {
+	out := new(TaintX509CAResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/TaintX509CA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeX509CA(ctx context.Context, in *RevokeX509CARequest, opts ...grpc.CallOption) (*RevokeX509CAResponse, error) {
+	out := new(RevokeX509CAResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/RevokeX509CA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) TaintJWTKey(ctx context.Context, in *TaintJWTKeyRequest, opts ...grpc.CallOption) (*TaintJWTKeyResponse, error) {
+	out := new(TaintJWTKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/TaintJWTKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeJWTKey(ctx context.Context, in *RevokeJWTKeyRequest, opts ...grpc.CallOption) (*RevokeJWTKeyResponse, error) {
+	out := new(RevokeJWTKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/RevokeJWTKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error) {
 	out := new(CreateAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateAttestedNode", in, out, opts...)
@@ -2714,6 +4770,15 @@ func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelec
 	return out, nil
 }
 
+func (c *dataStoreClient) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error) {
+	out := new(ListNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
 	out := new(CreateRegistrationEntryResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateRegistrationEntry", in, out, opts...)
@@ -2759,45 +4824,135 @@ func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *Delet
 	return out, nil
 }
 
-func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
-	out := new(PruneRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
+func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
+	out := new(PruneRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
+	out := new(CreateJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
+	out := new(FetchJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
+	out := new(PruneJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error) {
+	out := new(CreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error) {
+	out := new(FetchFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListFederationRelationships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error) {
+	out := new(UpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error) {
+	out := new(DeleteFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
-	out := new(CreateJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
+func (c *dataStoreClient) ListChangeEvents(ctx context.Context, in *ListChangeEventsRequest, opts ...grpc.CallOption) (*ListChangeEventsResponse, error) {
+	out := new(ListChangeEventsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListChangeEvents", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
-	out := new(FetchJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
+func (c *dataStoreClient) PruneChangeEvents(ctx context.Context, in *PruneChangeEventsRequest, opts ...grpc.CallOption) (*PruneChangeEventsResponse, error) {
+	out := new(PruneChangeEventsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneChangeEvents", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
-	out := new(DeleteJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
+func (c *dataStoreClient) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error) {
+	out := new(FetchCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchCAJournal", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
-	out := new(PruneJoinTokensResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
+func (c *dataStoreClient) SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error) {
+	out := new(SetCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetCAJournal", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2840,6 +4995,14 @@ type DataStoreServer interface {
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
 	// Prunes all expired certificates and JWT signing keys from a bundle
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	// Marks a root CA of a bundle as tainted
+	TaintX509CA(context.Context, *TaintX509CARequest) (*TaintX509CAResponse, error)
+	// Removes a tainted root CA from a bundle
+	RevokeX509CA(context.Context, *RevokeX509CARequest) (*RevokeX509CAResponse, error)
+	// Marks a JWT signing key of a bundle as tainted
+	TaintJWTKey(context.Context, *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error)
+	// Removes a tainted JWT signing key from a bundle
+	RevokeJWTKey(context.Context, *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error)
 	// Creates an attested node
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	// Fetches a specific attested node
@@ -2854,6 +5017,8 @@ type DataStoreServer interface {
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
+	// Lists the sets of node selectors for all nodes
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	// Creates a registration entry
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	// Fetches a specific registration entry
@@ -2872,8 +5037,28 @@ type DataStoreServer interface {
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
+	// Lists the change events recorded after a given revision
+	ListChangeEvents(context.Context, *ListChangeEventsRequest) (*ListChangeEventsResponse, error)
+	// Prunes all change events recorded before the specified timestamp
+	PruneChangeEvents(context.Context, *PruneChangeEventsRequest) (*PruneChangeEventsResponse, error)
+	// Fetches the CA journal of a trust domain
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	// Sets the CA journal of a trust domain if its revision has not changed
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -2908,6 +5093,18 @@ func (*UnimplementedDataStoreServer) DeleteBundle(ctx context.Context, req *Dele
 func (*UnimplementedDataStoreServer) PruneBundle(ctx context.Context, req *PruneBundleRequest) (*PruneBundleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneBundle not implemented")
 }
+func (*UnimplementedDataStoreServer) TaintX509CA(ctx context.Context, req *TaintX509CARequest) (*TaintX509CAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintX509CA not implemented")
+}
+func (*UnimplementedDataStoreServer) RevokeX509CA(ctx context.Context, req *RevokeX509CARequest) (*RevokeX509CAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeX509CA not implemented")
+}
+func (*UnimplementedDataStoreServer) TaintJWTKey(ctx context.Context, req *TaintJWTKeyRequest) (*TaintJWTKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintJWTKey not implemented")
+}
+func (*UnimplementedDataStoreServer) RevokeJWTKey(ctx context.Context, req *RevokeJWTKeyRequest) (*RevokeJWTKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeJWTKey not implemented")
+}
 func (*UnimplementedDataStoreServer) CreateAttestedNode(ctx context.Context, req *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateAttestedNode not implemented")
 }
@@ -2929,6 +5126,9 @@ func (*UnimplementedDataStoreServer) SetNodeSelectors(ctx context.Context, req *
 func (*UnimplementedDataStoreServer) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeSelectors not implemented")
 }
+func (*UnimplementedDataStoreServer) ListNodeSelectors(ctx context.Context, req *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNodeSelectors not implemented")
+}
 func (*UnimplementedDataStoreServer) CreateRegistrationEntry(ctx context.Context, req *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRegistrationEntry not implemented")
 }
@@ -2956,9 +5156,39 @@ func (*UnimplementedDataStoreServer) FetchJoinToken(ctx context.Context, req *Fe
 func (*UnimplementedDataStoreServer) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
 }
+func (*UnimplementedDataStoreServer) ListJoinTokens(ctx context.Context, req *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinTokens not implemented")
+}
 func (*UnimplementedDataStoreServer) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
 }
+func (*UnimplementedDataStoreServer) CreateFederationRelationship(ctx context.Context, req *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) FetchFederationRelationship(ctx context.Context, req *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (*UnimplementedDataStoreServer) UpdateFederationRelationship(ctx context.Context, req *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) DeleteFederationRelationship(ctx context.Context, req *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) ListChangeEvents(ctx context.Context, req *ListChangeEventsRequest) (*ListChangeEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChangeEvents not implemented")
+}
+func (*UnimplementedDataStoreServer) PruneChangeEvents(ctx context.Context, req *PruneChangeEventsRequest) (*PruneChangeEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneChangeEvents not implemented")
+}
+func (*UnimplementedDataStoreServer) FetchCAJournal(ctx context.Context, req *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchCAJournal not implemented")
+}
+func (*UnimplementedDataStoreServer) SetCAJournal(ctx context.Context, req *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCAJournal not implemented")
+}
 func (*UnimplementedDataStoreServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
@@ -3114,6 +5344,78 @@ func _DataStore_PruneBundle_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_TaintX509CA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintX509CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).TaintX509CA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/TaintX509CA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).TaintX509CA(ctx, req.(*TaintX509CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_RevokeX509CA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeX509CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RevokeX509CA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/RevokeX509CA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RevokeX509CA(ctx, req.(*RevokeX509CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_TaintJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).TaintJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/TaintJWTKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).TaintJWTKey(ctx, req.(*TaintJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_RevokeJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RevokeJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/RevokeJWTKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RevokeJWTKey(ctx, req.(*RevokeJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_CreateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -3240,6 +5542,24 @@ func _DataStore_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListNodeSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, req.(*ListNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_CreateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRegistrationEntryRequest)
 	if err := dec(in); err != nil {
@@ -3402,6 +5722,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -3420,6 +5758,168 @@ func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, req.(*CreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, req.(*FetchFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListFederationRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, req.(*UpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, req.(*DeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListChangeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListChangeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListChangeEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListChangeEvents(ctx, req.(*ListChangeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneChangeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneChangeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneChangeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneChangeEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneChangeEvents(ctx, req.(*PruneChangeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchCAJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, req.(*FetchCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/SetCAJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetCAJournal(ctx, req.(*SetCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -3492,6 +5992,22 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PruneBundle",
 			Handler:    _DataStore_PruneBundle_Handler,
 		},
+		{
+			MethodName: "TaintX509CA",
+			Handler:    _DataStore_TaintX509CA_Handler,
+		},
+		{
+			MethodName: "RevokeX509CA",
+			Handler:    _DataStore_RevokeX509CA_Handler,
+		},
+		{
+			MethodName: "TaintJWTKey",
+			Handler:    _DataStore_TaintJWTKey_Handler,
+		},
+		{
+			MethodName: "RevokeJWTKey",
+			Handler:    _DataStore_RevokeJWTKey_Handler,
+		},
 		{
 			MethodName: "CreateAttestedNode",
 			Handler:    _DataStore_CreateAttestedNode_Handler,
@@ -3520,6 +6036,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodeSelectors",
 			Handler:    _DataStore_GetNodeSelectors_Handler,
 		},
+		{
+			MethodName: "ListNodeSelectors",
+			Handler:    _DataStore_ListNodeSelectors_Handler,
+		},
 		{
 			MethodName: "CreateRegistrationEntry",
 			Handler:    _DataStore_CreateRegistrationEntry_Handler,
@@ -3556,10 +6076,50 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _DataStore_ListJoinTokens_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "CreateFederationRelationship",
+			Handler:    _DataStore_CreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "FetchFederationRelationship",
+			Handler:    _DataStore_FetchFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _DataStore_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "UpdateFederationRelationship",
+			Handler:    _DataStore_UpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteFederationRelationship",
+			Handler:    _DataStore_DeleteFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListChangeEvents",
+			Handler:    _DataStore_ListChangeEvents_Handler,
+		},
+		{
+			MethodName: "PruneChangeEvents",
+			Handler:    _DataStore_PruneChangeEvents_Handler,
+		},
+		{
+			MethodName: "FetchCAJournal",
+			Handler:    _DataStore_FetchCAJournal_Handler,
+		},
+		{
+			MethodName: "SetCAJournal",
+			Handler:    _DataStore_SetCAJournal_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
@@ -94,10 +94,12 @@ type AttestResponse struct {
 	//* Challenge required for attestation
 	Challenge []byte `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`
 	//* Optional list of selectors
-	Selectors            []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	//* True if the node can re-attest using its attestation data
+	CanReattest          bool     `protobuf:"varint,5,opt,name=can_reattest,json=canReattest,proto3" json:"can_reattest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AttestResponse) Reset()         { *m = AttestResponse{} }
@@ -153,6 +155,13 @@ func (m *AttestResponse) GetSelectors() []*common.Selector {
 	return nil
 }
 
+func (m *AttestResponse) GetCanReattest() bool {
+	if m != nil {
+		return m.CanReattest
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*AttestRequest)(nil), "spire.agent.nodeattestor.AttestRequest")
 	proto.RegisterType((*AttestResponse)(nil), "spire.agent.nodeattestor.AttestResponse")
@@ -161,33 +170,34 @@ func init() {
 func init() { proto.RegisterFile("nodeattestor.proto", fileDescriptor_9e3b2582c38c076c) }
 
 var fileDescriptor_9e3b2582c38c076c = []byte{
-	// 414 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0xe5, 0x0d, 0x8d, 0xc4, 0xdb, 0xd8, 0xe4, 0x03, 0xca, 0x22, 0x90, 0xa2, 0x49, 0x40,
-	0xc6, 0x21, 0x41, 0x05, 0x09, 0x21, 0x71, 0xc9, 0xd6, 0x0a, 0x76, 0x41, 0x93, 0x41, 0x1c, 0x76,
-	0x89, 0xdc, 0xe6, 0x25, 0xb5, 0x94, 0xda, 0xc1, 0x76, 0xfa, 0xa1, 0xb8, 0xf6, 0x0b, 0xa2, 0xda,
-	0x49, 0xd3, 0x48, 0xa0, 0x72, 0xb2, 0xfd, 0xfe, 0x3f, 0xbf, 0xf7, 0xf4, 0x7f, 0x0f, 0x13, 0x21,
-	0x0b, 0x60, 0xc6, 0x80, 0x36, 0x52, 0x25, 0x8d, 0x92, 0x46, 0x92, 0x40, 0x37, 0x5c, 0x41, 0xc2,
-	0x2a, 0x10, 0x26, 0xd9, 0xd7, 0xc3, 0xc8, 0x2a, 0xe9, 0x42, 0xae, 0x56, 0x52, 0xa4, 0x4d, 0xdd,
-	0x56, 0xbc, 0x3f, 0xdc, 0xdf, 0xf0, 0x6a, 0x44, 0xb8, 0xc3, 0x49, 0xd7, 0x1b, 0x84, 0xcf, 0x33,
-	0x9b, 0x89, 0xc2, 0xaf, 0x16, 0xb4, 0x21, 0x5f, 0xf1, 0xa5, 0x4b, 0xcd, 0x0c, 0x97, 0x22, 0x2f,
-	0x98, 0x61, 0x01, 0x8a, 0x50, 0x7c, 0x3a, 0x79, 0x99, 0xb8, 0x1e, 0xba, 0x04, 0xd9, 0x40, 0x4d,
-	0x99, 0x61, 0xf4, 0x82, 0x8d, 0x03, 0xe4, 0x33, 0x0e, 0xa7, 0xb3, 0x07, 0x3a, 0xbb, 0xcb, 0x7e,
-	0xcc, 0xa6, 0xb9, 0x53, 0xa1, 0xc8, 0xe7, 0x50, 0x4a, 0x05, 0xc1, 0x51, 0x84, 0x62, 0x8f, 0x06,
-	0x03, 0x91, 0x75, 0xc0, 0xad, 0xd5, 0x49, 0x88, 0x3d, 0x05, 0xba, 0x91, 0x42, 0x43, 0x70, 0x1c,
-	0xa1, 0xf8, 0x8c, 0xee, 0xde, 0xd7, 0xbf, 0x11, 0x7e, 0xd6, 0x77, 0xed, 0x42, 0xe4, 0x06, 0x5f,
-	0xee, 0x15, 0x5b, 0xb3, 0x9a, 0x17, 0xb6, 0x6d, 0x8f, 0x5e, 0x0c, 0xf1, 0x9f, 0xdb, 0x30, 0xb9,
-	0xc2, 0x9e, 0xb5, 0x31, 0xe7, 0x85, 0xed, 0xc2, 0xa7, 0x4f, 0xed, 0xfb, 0xbe, 0x20, 0x2f, 0xb0,
-	0xbf, 0x58, 0xb2, 0xba, 0x06, 0x51, 0xf5, 0x55, 0x87, 0x00, 0xf9, 0x80, 0x7d, 0x0d, 0x35, 0x2c,
-	0x8c, 0x54, 0x3a, 0x78, 0x12, 0x1d, 0xc7, 0xa7, 0x93, 0xe7, 0x63, 0x4f, 0xbe, 0x77, 0x32, 0x1d,
-	0xc0, 0xc9, 0xe6, 0x08, 0x9f, 0x7d, 0x93, 0x05, 0x64, 0xdd, 0xc0, 0x48, 0x8e, 0x4f, 0xdc, 0x9d,
-	0xbc, 0x49, 0xfe, 0x35, 0xd5, 0x64, 0x34, 0x94, 0x30, 0x3e, 0x0c, 0x3a, 0x1f, 0x62, 0xf4, 0x0e,
-	0x91, 0x47, 0xec, 0xdf, 0x49, 0x51, 0xf2, 0xaa, 0x55, 0x40, 0x5e, 0x8d, 0x3b, 0xec, 0x16, 0x63,
-	0xa7, 0xf7, 0x15, 0x5e, 0x1f, 0xc2, 0x3a, 0x9f, 0x4b, 0x7c, 0xfe, 0x05, 0xcc, 0x83, 0x95, 0xef,
-	0x45, 0x29, 0xc9, 0xcd, 0x5f, 0x3f, 0x8e, 0x98, 0xbe, 0xc6, 0xdb, 0xff, 0x41, 0x5d, 0x9d, 0xdb,
-	0x4f, 0x8f, 0x1f, 0x2b, 0x6e, 0x96, 0xed, 0x7c, 0x4b, 0xa7, 0xba, 0xe1, 0x65, 0x09, 0xa9, 0xdb,
-	0x63, 0xbb, 0xb9, 0xdd, 0x5d, 0x83, 0x5a, 0x83, 0x4a, 0xf7, 0x1d, 0x99, 0x9f, 0x58, 0xe0, 0xfd,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xfd, 0xfb, 0x31, 0xb6, 0x47, 0x03, 0x00, 0x00,
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xc1, 0x6e, 0xd4, 0x30,
+	0x10, 0x86, 0xe5, 0x16, 0xca, 0xc6, 0xbb, 0xa5, 0x95, 0x0f, 0x28, 0x8d, 0x40, 0x0a, 0x95, 0x80,
+	0x94, 0x43, 0x82, 0x16, 0x24, 0x84, 0xc4, 0x25, 0xed, 0xae, 0xa0, 0x17, 0x54, 0x19, 0xc4, 0xa1,
+	0x97, 0xc8, 0x9b, 0x4c, 0x52, 0x4b, 0xa9, 0x1d, 0x6c, 0xa7, 0x2f, 0xd6, 0xe7, 0xe0, 0x9d, 0x50,
+	0x6d, 0xa7, 0xd9, 0x48, 0xa0, 0x72, 0x8a, 0xfd, 0xff, 0x9f, 0x67, 0x26, 0x33, 0x83, 0x89, 0x90,
+	0x15, 0x30, 0x63, 0x40, 0x1b, 0xa9, 0xd2, 0x4e, 0x49, 0x23, 0x49, 0xa8, 0x3b, 0xae, 0x20, 0x65,
+	0x0d, 0x08, 0x93, 0x6e, 0xfb, 0x51, 0x6c, 0x9d, 0xac, 0x94, 0xd7, 0xd7, 0x52, 0x64, 0x5d, 0xdb,
+	0x37, 0x7c, 0xf8, 0xb8, 0xb7, 0xd1, 0xd1, 0x84, 0x70, 0x1f, 0x67, 0x1d, 0xdf, 0x22, 0xbc, 0x9f,
+	0xdb, 0x48, 0x14, 0x7e, 0xf5, 0xa0, 0x0d, 0xf9, 0x8a, 0x0f, 0x5d, 0x68, 0x66, 0xb8, 0x14, 0x45,
+	0xc5, 0x0c, 0x0b, 0x51, 0x8c, 0x92, 0xf9, 0xf2, 0x45, 0xea, 0x6a, 0xf0, 0x01, 0xf2, 0x91, 0x5a,
+	0x31, 0xc3, 0xe8, 0x01, 0x9b, 0x0a, 0xe4, 0x33, 0x8e, 0x56, 0xeb, 0x0b, 0xba, 0x3e, 0xcb, 0x7f,
+	0xac, 0x57, 0x85, 0x73, 0xa1, 0x2a, 0x36, 0x50, 0x4b, 0x05, 0xe1, 0x4e, 0x8c, 0x92, 0x19, 0x0d,
+	0x47, 0x22, 0xf7, 0xc0, 0xa9, 0xf5, 0x49, 0x84, 0x67, 0x0a, 0x74, 0x27, 0x85, 0x86, 0x70, 0x37,
+	0x46, 0xc9, 0x82, 0xde, 0xdf, 0x8f, 0x7f, 0x23, 0xfc, 0x74, 0xa8, 0xda, 0x49, 0xe4, 0x04, 0x1f,
+	0x6e, 0x25, 0xbb, 0x61, 0x2d, 0xaf, 0x6c, 0xd9, 0x33, 0x7a, 0x30, 0xea, 0x3f, 0xef, 0x64, 0x72,
+	0x84, 0x67, 0xb6, 0x8d, 0x05, 0xaf, 0x6c, 0x15, 0x01, 0x7d, 0x62, 0xef, 0xe7, 0x15, 0x79, 0x8e,
+	0x83, 0xf2, 0x8a, 0xb5, 0x2d, 0x88, 0x66, 0xc8, 0x3a, 0x0a, 0xe4, 0x03, 0x0e, 0x34, 0xb4, 0x50,
+	0x1a, 0xa9, 0x74, 0xf8, 0x28, 0xde, 0x4d, 0xe6, 0xcb, 0x67, 0xd3, 0x9e, 0x7c, 0xf7, 0x36, 0x1d,
+	0x41, 0xf2, 0x12, 0x2f, 0x4a, 0x26, 0x0a, 0xe5, 0x27, 0x16, 0x3e, 0xb6, 0x55, 0xcd, 0x4b, 0x26,
+	0xa8, 0x97, 0x96, 0xb7, 0x3b, 0x78, 0xf1, 0x4d, 0x56, 0x90, 0xfb, 0x99, 0x92, 0x02, 0xef, 0xb9,
+	0x33, 0x79, 0x93, 0xfe, 0x6b, 0xf0, 0xe9, 0x64, 0x6e, 0x51, 0xf2, 0x30, 0xe8, 0x5a, 0x95, 0xa0,
+	0x77, 0x88, 0x5c, 0xe2, 0xe0, 0x4c, 0x8a, 0x9a, 0x37, 0xbd, 0x02, 0xf2, 0x6a, 0xfa, 0x13, 0x7e,
+	0x77, 0xee, 0xfd, 0x21, 0xc3, 0xeb, 0x87, 0x30, 0x3f, 0x8a, 0x1a, 0xef, 0x7f, 0x01, 0x73, 0x61,
+	0xed, 0x73, 0x51, 0x4b, 0x72, 0xf2, 0xd7, 0x87, 0x13, 0x66, 0xc8, 0xf1, 0xf6, 0x7f, 0x50, 0x97,
+	0xe7, 0xf4, 0xd3, 0xe5, 0xc7, 0x86, 0x9b, 0xab, 0x7e, 0x73, 0x47, 0x67, 0xba, 0xe3, 0x75, 0x0d,
+	0x99, 0x5b, 0x75, 0xbb, 0xdc, 0xfe, 0xac, 0x41, 0xdd, 0x80, 0xca, 0xb6, 0x3b, 0xb2, 0xd9, 0xb3,
+	0xc0, 0xfb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x53, 0x60, 0x80, 0x20, 0x6a, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
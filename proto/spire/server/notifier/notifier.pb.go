@@ -26,6 +26,45 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type AgentPruning struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AgentPruning) Reset()         { *m = AgentPruning{} }
+func (m *AgentPruning) String() string { return proto.CompactTextString(m) }
+func (*AgentPruning) ProtoMessage()    {}
+func (*AgentPruning) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c0fc606bc4470de, []int{0}
+}
+
+func (m *AgentPruning) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentPruning.Unmarshal(m, b)
+}
+func (m *AgentPruning) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentPruning.Marshal(b, m, deterministic)
+}
+func (m *AgentPruning) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentPruning.Merge(m, src)
+}
+func (m *AgentPruning) XXX_Size() int {
+	return xxx_messageInfo_AgentPruning.Size(m)
+}
+func (m *AgentPruning) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentPruning.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentPruning proto.InternalMessageInfo
+
+func (m *AgentPruning) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type BundleLoaded struct {
 	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -37,7 +76,7 @@ func (m *BundleLoaded) Reset()         { *m = BundleLoaded{} }
 func (m *BundleLoaded) String() string { return proto.CompactTextString(m) }
 func (*BundleLoaded) ProtoMessage()    {}
 func (*BundleLoaded) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{0}
+	return fileDescriptor_1c0fc606bc4470de, []int{1}
 }
 
 func (m *BundleLoaded) XXX_Unmarshal(b []byte) error {
@@ -76,7 +115,7 @@ func (m *BundleUpdated) Reset()         { *m = BundleUpdated{} }
 func (m *BundleUpdated) String() string { return proto.CompactTextString(m) }
 func (*BundleUpdated) ProtoMessage()    {}
 func (*BundleUpdated) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{1}
+	return fileDescriptor_1c0fc606bc4470de, []int{2}
 }
 
 func (m *BundleUpdated) XXX_Unmarshal(b []byte) error {
@@ -117,7 +156,7 @@ func (m *NotifyRequest) Reset()         { *m = NotifyRequest{} }
 func (m *NotifyRequest) String() string { return proto.CompactTextString(m) }
 func (*NotifyRequest) ProtoMessage()    {}
 func (*NotifyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{2}
+	return fileDescriptor_1c0fc606bc4470de, []int{3}
 }
 
 func (m *NotifyRequest) XXX_Unmarshal(b []byte) error {
@@ -179,7 +218,7 @@ func (m *NotifyResponse) Reset()         { *m = NotifyResponse{} }
 func (m *NotifyResponse) String() string { return proto.CompactTextString(m) }
 func (*NotifyResponse) ProtoMessage()    {}
 func (*NotifyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{3}
+	return fileDescriptor_1c0fc606bc4470de, []int{4}
 }
 
 func (m *NotifyResponse) XXX_Unmarshal(b []byte) error {
@@ -203,6 +242,7 @@ var xxx_messageInfo_NotifyResponse proto.InternalMessageInfo
 type NotifyAndAdviseRequest struct {
 	// Types that are valid to be assigned to Event:
 	//	*NotifyAndAdviseRequest_BundleLoaded
+	//	*NotifyAndAdviseRequest_AgentPruning
 	Event                isNotifyAndAdviseRequest_Event `protobuf_oneof:"event"`
 	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
 	XXX_unrecognized     []byte                         `json:"-"`
@@ -213,7 +253,7 @@ func (m *NotifyAndAdviseRequest) Reset()         { *m = NotifyAndAdviseRequest{}
 func (m *NotifyAndAdviseRequest) String() string { return proto.CompactTextString(m) }
 func (*NotifyAndAdviseRequest) ProtoMessage()    {}
 func (*NotifyAndAdviseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{4}
+	return fileDescriptor_1c0fc606bc4470de, []int{5}
 }
 
 func (m *NotifyAndAdviseRequest) XXX_Unmarshal(b []byte) error {
@@ -242,8 +282,14 @@ type NotifyAndAdviseRequest_BundleLoaded struct {
 	BundleLoaded *BundleLoaded `protobuf:"bytes,1,opt,name=bundle_loaded,json=bundleLoaded,proto3,oneof"`
 }
 
+type NotifyAndAdviseRequest_AgentPruning struct {
+	AgentPruning *AgentPruning `protobuf:"bytes,2,opt,name=agent_pruning,json=agentPruning,proto3,oneof"`
+}
+
 func (*NotifyAndAdviseRequest_BundleLoaded) isNotifyAndAdviseRequest_Event() {}
 
+func (*NotifyAndAdviseRequest_AgentPruning) isNotifyAndAdviseRequest_Event() {}
+
 func (m *NotifyAndAdviseRequest) GetEvent() isNotifyAndAdviseRequest_Event {
 	if m != nil {
 		return m.Event
@@ -258,10 +304,18 @@ func (m *NotifyAndAdviseRequest) GetBundleLoaded() *BundleLoaded {
 	return nil
 }
 
+func (m *NotifyAndAdviseRequest) GetAgentPruning() *AgentPruning {
+	if x, ok := m.GetEvent().(*NotifyAndAdviseRequest_AgentPruning); ok {
+		return x.AgentPruning
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*NotifyAndAdviseRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*NotifyAndAdviseRequest_BundleLoaded)(nil),
+		(*NotifyAndAdviseRequest_AgentPruning)(nil),
 	}
 }
 
@@ -275,7 +329,7 @@ func (m *NotifyAndAdviseResponse) Reset()         { *m = NotifyAndAdviseResponse
 func (m *NotifyAndAdviseResponse) String() string { return proto.CompactTextString(m) }
 func (*NotifyAndAdviseResponse) ProtoMessage()    {}
 func (*NotifyAndAdviseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1c0fc606bc4470de, []int{5}
+	return fileDescriptor_1c0fc606bc4470de, []int{6}
 }
 
 func (m *NotifyAndAdviseResponse) XXX_Unmarshal(b []byte) error {
@@ -297,6 +351,7 @@ func (m *NotifyAndAdviseResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_NotifyAndAdviseResponse proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterType((*AgentPruning)(nil), "spire.server.notifier.AgentPruning")
 	proto.RegisterType((*BundleLoaded)(nil), "spire.server.notifier.BundleLoaded")
 	proto.RegisterType((*BundleUpdated)(nil), "spire.server.notifier.BundleUpdated")
 	proto.RegisterType((*NotifyRequest)(nil), "spire.server.notifier.NotifyRequest")
@@ -308,31 +363,35 @@ func init() {
 func init() { proto.RegisterFile("notifier.proto", fileDescriptor_1c0fc606bc4470de) }
 
 var fileDescriptor_1c0fc606bc4470de = []byte{
-	// 377 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x4f, 0xea, 0x40,
-	0x14, 0x85, 0xbc, 0x3c, 0xde, 0xf3, 0x4a, 0xd1, 0x4c, 0xfc, 0xa2, 0x2b, 0x52, 0xc5, 0xa8, 0xd1,
-	0x36, 0x81, 0xb8, 0xd3, 0x05, 0xb8, 0x10, 0x8d, 0x12, 0xd3, 0x84, 0x0d, 0x1b, 0x42, 0xed, 0x6d,
-	0x9d, 0x04, 0x66, 0x6a, 0x3f, 0x48, 0xfc, 0x25, 0xfe, 0x5d, 0x43, 0xef, 0x54, 0x29, 0x22, 0xe0,
-	0xaa, 0x9d, 0x7b, 0xce, 0x3d, 0xe7, 0xde, 0x93, 0x19, 0xa8, 0x08, 0x19, 0x73, 0x8f, 0x63, 0x68,
-	0x06, 0xa1, 0x8c, 0x25, 0xdb, 0x8d, 0x02, 0x1e, 0xa2, 0x19, 0x61, 0x38, 0xc1, 0xd0, 0xcc, 0x40,
-	0xbd, 0x9a, 0x96, 0xad, 0x67, 0x39, 0x1e, 0x4b, 0xa1, 0x3e, 0xd4, 0xa1, 0xd7, 0x72, 0x50, 0x30,
-	0x4a, 0x7c, 0x9e, 0x7d, 0x88, 0x61, 0x5c, 0x41, 0xb9, 0x9d, 0x08, 0x77, 0x84, 0x0f, 0x72, 0xe8,
-	0xa2, 0xcb, 0xce, 0xa1, 0xe4, 0xa4, 0xe7, 0x83, 0x62, 0xad, 0x78, 0xb2, 0xd9, 0xd8, 0x31, 0xc9,
-	0x54, 0xc9, 0x12, 0xd7, 0x56, 0x1c, 0xe3, 0x1a, 0x34, 0xaa, 0xf4, 0x02, 0x77, 0x18, 0xff, 0xba,
-	0xdd, 0x07, 0xad, 0x3b, 0xdd, 0xe2, 0xcd, 0xc6, 0xd7, 0x04, 0xa3, 0x98, 0x3d, 0x42, 0x85, 0xa0,
-	0x41, 0x42, 0x82, 0x4a, 0xe6, 0xc8, 0x5c, 0xb8, 0xba, 0x99, 0x33, 0xef, 0x14, 0x6c, 0xcd, 0x99,
-	0x2d, 0xb4, 0xff, 0xc1, 0x5f, 0x9c, 0xa0, 0x88, 0x8d, 0x6d, 0xa8, 0x64, 0x46, 0x51, 0x20, 0x45,
-	0x84, 0xc6, 0x18, 0xf6, 0xa8, 0xd2, 0x12, 0x6e, 0xcb, 0x9d, 0xf0, 0x08, 0xb3, 0x19, 0xee, 0x41,
-	0xa9, 0x0c, 0x46, 0x69, 0x24, 0x6a, 0x84, 0xc3, 0xa5, 0x23, 0x50, 0x7a, 0x9d, 0x82, 0x5d, 0x76,
-	0x66, 0xce, 0x5f, 0x03, 0x54, 0x61, 0xff, 0x9b, 0x1d, 0x4d, 0xd2, 0x78, 0xff, 0x03, 0xff, 0xbb,
-	0x4a, 0x8d, 0xf5, 0xa0, 0x44, 0x3c, 0xf6, 0xd3, 0xca, 0xb9, 0xc0, 0xf4, 0xfa, 0x0a, 0x16, 0x79,
-	0xb0, 0x00, 0xb6, 0xe6, 0xec, 0xd9, 0xc5, 0xd2, 0xce, 0xf9, 0x54, 0x74, 0x73, 0x5d, 0xba, 0x72,
-	0xec, 0xc3, 0xc6, 0x8d, 0x14, 0x1e, 0xf7, 0x93, 0x10, 0x59, 0x3d, 0x7f, 0x0b, 0xd4, 0x05, 0xfc,
-	0xc4, 0x33, 0x8f, 0xe3, 0x55, 0x34, 0xa5, 0xed, 0x81, 0x76, 0x8b, 0xf1, 0x53, 0x0a, 0xdf, 0x09,
-	0x4f, 0xb2, 0xd3, 0x85, 0x8d, 0x39, 0x4e, 0xe6, 0x71, 0xb6, 0x0e, 0x95, 0x7c, 0xda, 0x97, 0xfd,
-	0xa6, 0xcf, 0xe3, 0x97, 0xc4, 0x99, 0xb2, 0xad, 0x28, 0xe0, 0x9e, 0x87, 0x16, 0xbd, 0xa8, 0xf4,
-	0xf1, 0xa8, 0x7f, 0x8a, 0xc4, 0xca, 0x22, 0x71, 0x4a, 0x29, 0xd8, 0xfc, 0x08, 0x00, 0x00, 0xff,
-	0xff, 0x04, 0x9c, 0x9c, 0x51, 0xbf, 0x03, 0x00, 0x00,
+	// 436 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xd4, 0x30,
+	0x10, 0xed, 0xf2, 0xb1, 0xc0, 0xb0, 0x59, 0x90, 0xc5, 0x47, 0x9b, 0x53, 0x15, 0x28, 0x02, 0x04,
+	0x8e, 0xd4, 0x8a, 0x1b, 0x20, 0x65, 0x39, 0x50, 0x2a, 0x58, 0x55, 0x91, 0x7a, 0xe9, 0x65, 0x95,
+	0xe0, 0x49, 0xb0, 0xb4, 0xb5, 0x4d, 0xec, 0xac, 0xc4, 0x2f, 0xe1, 0xb7, 0xf0, 0xef, 0x50, 0x32,
+	0x0e, 0x24, 0xa5, 0x6c, 0xb7, 0x27, 0xc7, 0xf3, 0xde, 0xbc, 0x37, 0x7e, 0x8e, 0x61, 0xaa, 0xb4,
+	0x93, 0x85, 0xc4, 0x8a, 0x9b, 0x4a, 0x3b, 0xcd, 0x1e, 0x5a, 0x23, 0x2b, 0xe4, 0x16, 0xab, 0x15,
+	0x56, 0xbc, 0x03, 0xc3, 0x9d, 0xb6, 0x1c, 0x7f, 0xd5, 0x67, 0x67, 0x5a, 0xf9, 0x85, 0x3a, 0xc2,
+	0xdd, 0x01, 0x64, 0x96, 0x75, 0x29, 0xbb, 0x85, 0x18, 0xd1, 0x7b, 0x98, 0x24, 0x25, 0x2a, 0x77,
+	0x5c, 0xd5, 0x4a, 0xaa, 0x92, 0x71, 0xb8, 0xa1, 0xb4, 0xc0, 0xed, 0xd1, 0xee, 0xe8, 0xf9, 0xdd,
+	0xfd, 0x90, 0x93, 0xa5, 0x17, 0x4d, 0x9c, 0x43, 0xeb, 0x50, 0xcc, 0xb5, 0xc0, 0xb4, 0xe5, 0x45,
+	0x6f, 0x61, 0x32, 0xab, 0x95, 0x58, 0xe2, 0x67, 0x9d, 0x09, 0x14, 0xec, 0x15, 0x8c, 0xf3, 0x76,
+	0xef, 0x15, 0x1e, 0x0c, 0x15, 0x88, 0x9b, 0x7a, 0x4e, 0xf4, 0x0e, 0x02, 0xaa, 0x9c, 0x18, 0x91,
+	0xb9, 0x2b, 0xb7, 0x97, 0x10, 0xcc, 0x9b, 0x14, 0x7e, 0xa4, 0xf8, 0xbd, 0x46, 0xeb, 0xd8, 0x17,
+	0x98, 0x12, 0xb4, 0xa8, 0x49, 0xd0, 0xcb, 0x3c, 0xe5, 0x17, 0x46, 0xc7, 0x07, 0xe6, 0x87, 0x5b,
+	0x69, 0x90, 0xf7, 0x0b, 0xb3, 0x5b, 0x70, 0x13, 0x57, 0xa8, 0x5c, 0x74, 0x1f, 0xa6, 0x9d, 0x91,
+	0x35, 0x5a, 0x59, 0x8c, 0x7e, 0x8d, 0xe0, 0x11, 0x95, 0x12, 0x25, 0x12, 0xb1, 0x92, 0x16, 0xbb,
+	0x21, 0x8e, 0xc0, 0xcb, 0x2c, 0x96, 0x6d, 0x26, 0x7e, 0x86, 0x27, 0x6b, 0x67, 0xa0, 0xf8, 0x0e,
+	0xb7, 0xd2, 0x49, 0xde, 0x8f, 0xf3, 0x08, 0x82, 0xac, 0xb9, 0x9e, 0x85, 0xa1, 0xfb, 0xd9, 0xbe,
+	0xb6, 0x56, 0xab, 0x7f, 0x95, 0x8d, 0x56, 0xd6, 0xdb, 0xff, 0x3d, 0xcd, 0x0e, 0x3c, 0xfe, 0x67,
+	0x74, 0x3a, 0xd6, 0xfe, 0xcf, 0xeb, 0x70, 0x7b, 0xee, 0xd5, 0xd8, 0x09, 0x8c, 0x89, 0xc7, 0xfe,
+	0x97, 0xdf, 0x20, 0xfd, 0x70, 0xef, 0x12, 0x16, 0x79, 0x30, 0x03, 0xf7, 0xce, 0xd9, 0xb3, 0xd7,
+	0x6b, 0x3b, 0xcf, 0x27, 0x1c, 0xf2, 0x4d, 0xe9, 0xde, 0xf1, 0x14, 0xee, 0x7c, 0xd0, 0xaa, 0x90,
+	0x65, 0x5d, 0x21, 0xdb, 0x1b, 0xfe, 0x52, 0xfe, 0x35, 0xfc, 0xc1, 0x3b, 0x8f, 0x67, 0x97, 0xd1,
+	0xbc, 0x76, 0x01, 0xc1, 0x47, 0x74, 0xc7, 0x2d, 0xfc, 0x49, 0x15, 0x9a, 0xbd, 0xb8, 0xb0, 0x71,
+	0xc0, 0xe9, 0x3c, 0x5e, 0x6e, 0x42, 0x25, 0x9f, 0xd9, 0x9b, 0xd3, 0x83, 0x52, 0xba, 0x6f, 0x75,
+	0xde, 0xb0, 0x63, 0x6b, 0x64, 0x51, 0x60, 0x4c, 0xcf, 0xbb, 0x7d, 0xc9, 0xfe, 0x9b, 0x22, 0x89,
+	0xbb, 0x48, 0xf2, 0x71, 0x0b, 0x1e, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x02, 0x50, 0xd2, 0x1f,
+	0x4c, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
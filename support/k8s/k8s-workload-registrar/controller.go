@@ -171,7 +171,7 @@ func (c *Controller) deletePodEntry(ctx context.Context, namespace, name string)
 
 	var errGroup errs.Group
 	for _, entry := range entries.Entries {
-		_, err := c.c.R.DeleteEntry(ctx, &registration.RegistrationEntryID{
+		_, err := c.c.R.DeleteEntry(ctx, &registration.DeleteEntryRequest{
 			Id: entry.EntryId,
 		})
 		if err != nil {
@@ -475,7 +475,7 @@ func (c *fakeRegistrationClient) CreateEntry(ctx context.Context, entry *common.
 	return &registration.RegistrationEntryID{Id: entry.EntryId}, nil
 }
 
-func (c *fakeRegistrationClient) DeleteEntry(ctx context.Context, id *registration.RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
+func (c *fakeRegistrationClient) DeleteEntry(ctx context.Context, id *registration.DeleteEntryRequest, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -12,7 +12,9 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -29,10 +31,14 @@ var (
 	ErrAttestedNodeAlreadyExists = errors.New("attested node entry already exists")
 	ErrTokenAlreadyExists        = errors.New("token already exists")
 
+	ErrFederationRelationshipAlreadyExists = errors.New("federation relationship already exists")
+
 	ErrNoSuchBundle            = status.Error(codes.NotFound, "no such bundle")
 	ErrNoSuchAttestedNode      = status.Error(codes.NotFound, "no such attested node entry")
 	ErrNoSuchRegistrationEntry = status.Error(codes.NotFound, "no such registration entry")
 	ErrNoSuchToken             = status.Error(codes.NotFound, "no such token")
+
+	ErrNoSuchFederationRelationship = status.Error(codes.NotFound, "no such federation relationship")
 )
 
 type DataStore struct {
@@ -43,9 +49,15 @@ type DataStore struct {
 	nodeSelectors       map[string][]*common.Selector
 	registrationEntries map[string]*common.RegistrationEntry
 	tokens              map[string]*datastore.JoinToken
+	relationships       map[string]*datastore.FederationRelationship
+	caJournals          map[string]*datastore.CAJournal
 
 	// relates bundles with entries that federate with them
 	bundleEntries map[string]map[string]bool
+
+	// change events, in revision order
+	events   []*datastore.ChangeEvent
+	revision int64
 }
 
 var _ datastore.DataStore = (*DataStore)(nil)
@@ -57,6 +69,8 @@ func New() *DataStore {
 		nodeSelectors:       make(map[string][]*common.Selector),
 		registrationEntries: make(map[string]*common.RegistrationEntry),
 		tokens:              make(map[string]*datastore.JoinToken),
+		relationships:       make(map[string]*datastore.FederationRelationship),
+		caJournals:          make(map[string]*datastore.CAJournal),
 		bundleEntries:       make(map[string]map[string]bool),
 	}
 }
@@ -73,6 +87,7 @@ func (s *DataStore) CreateBundle(ctx context.Context, req *datastore.CreateBundl
 	}
 
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, bundle.TrustDomainId, datastore.ChangeEvent_CREATED)
 
 	return &datastore.CreateBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -92,6 +107,7 @@ func (s *DataStore) UpdateBundle(ctx context.Context, req *datastore.UpdateBundl
 	}
 
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, bundle.TrustDomainId, datastore.ChangeEvent_UPDATED)
 
 	return &datastore.UpdateBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -105,7 +121,12 @@ func (s *DataStore) SetBundle(ctx context.Context, req *datastore.SetBundleReque
 
 	bundle := req.Bundle
 
+	eventType := datastore.ChangeEvent_UPDATED
+	if _, ok := s.bundles[bundle.TrustDomainId]; !ok {
+		eventType = datastore.ChangeEvent_CREATED
+	}
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, bundle.TrustDomainId, eventType)
 
 	return &datastore.SetBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -121,7 +142,13 @@ func (s *DataStore) AppendBundle(ctx context.Context, req *datastore.AppendBundl
 	bundle := req.Bundle
 
 	if existingBundle, ok := s.bundles[bundle.TrustDomainId]; ok {
-		bundle, _ = bundleutil.MergeBundles(existingBundle, bundle)
+		var changed bool
+		bundle, changed = bundleutil.MergeBundles(existingBundle, bundle)
+		if changed {
+			s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, bundle.TrustDomainId, datastore.ChangeEvent_UPDATED)
+		}
+	} else {
+		s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, bundle.TrustDomainId, datastore.ChangeEvent_CREATED)
 	}
 
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
@@ -142,15 +169,26 @@ func (s *DataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundl
 	}
 
 	if bundleEntries := s.bundleEntries[req.TrustDomainId]; len(bundleEntries) > 0 {
+		entryIDs := make([]string, 0, len(bundleEntries))
+		for entryID := range bundleEntries {
+			entryIDs = append(entryIDs, entryID)
+		}
+		sort.Strings(entryIDs)
+
 		switch req.Mode {
 		case datastore.DeleteBundleRequest_DELETE:
-			for entryID := range bundleEntries {
+			for _, entryID := range entryIDs {
+				if entry := s.registrationEntries[entryID]; entry != nil {
+					s.removeBundleLinks(entryID, entry.FederatesWith)
+				}
 				delete(s.registrationEntries, entryID)
+				s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, entryID, datastore.ChangeEvent_DELETED)
 			}
 		case datastore.DeleteBundleRequest_DISSOCIATE:
-			for entryID := range bundleEntries {
+			for _, entryID := range entryIDs {
 				if entry := s.registrationEntries[entryID]; entry != nil {
 					entry.FederatesWith = removeString(entry.FederatesWith, req.TrustDomainId)
+					s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, entryID, datastore.ChangeEvent_UPDATED)
 				}
 			}
 		default:
@@ -158,6 +196,8 @@ func (s *DataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundl
 		}
 	}
 	delete(s.bundles, req.TrustDomainId)
+	delete(s.bundleEntries, req.TrustDomainId)
+	s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, req.TrustDomainId, datastore.ChangeEvent_DELETED)
 
 	return &datastore.DeleteBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -191,38 +231,14 @@ func (s *DataStore) ListBundles(ctx context.Context, req *datastore.ListBundlesR
 	}
 	sort.Strings(keys)
 
-	resp := new(datastore.ListBundlesResponse)
-
-	p := req.Pagination
-	// in case pagination is defined and page size greater than zero apply pagination
-	if p != nil && p.PageSize > 0 {
-		// as default start in first position
-		init := 0
-
-		// If token is defined set index of initial element
-		if p.Token != "" {
-			init = sort.StringSlice(keys).Search(p.Token) + 1
-		}
-
-		// set end as initial element + page size, if end is greater to entries size use length as end
-		length := len(keys)
-		end := init + int(p.PageSize)
-		if end > length {
-			end = length
-		}
-
-		// create a new array with paged bundles
-		keys = keys[init:end]
-		if len(keys) > 0 {
-			lastIndex := len(keys) - 1
-			// change token to latests bundle key
-			resp.Pagination = &datastore.Pagination{
-				PageSize: p.PageSize,
-				Token:    keys[lastIndex],
-			}
-		}
+	keys, pagination, err := paginateKeys(keys, req.Pagination)
+	if err != nil {
+		return nil, err
 	}
 
+	resp := &datastore.ListBundlesResponse{
+		Pagination: pagination,
+	}
 	for _, key := range keys {
 		resp.Bundles = append(resp.Bundles, cloneBundle(s.bundles[key]))
 	}
@@ -249,11 +265,71 @@ func (s *DataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleR
 	// If any cert was pruned, then update the bundle
 	if changed {
 		s.bundles[req.TrustDomainId] = newBundle
+		s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, req.TrustDomainId, datastore.ChangeEvent_UPDATED)
 	}
 
 	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
 }
 
+// TaintX509CA marks an X.509 CA in a bundle as tainted
+func (s *DataStore) TaintX509CA(ctx context.Context, req *datastore.TaintX509CARequest) (*datastore.TaintX509CAResponse, error) {
+	if err := s.modifyBundle(req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintX509CAResponse{}, nil
+}
+
+// RevokeX509CA removes a tainted X.509 CA from a bundle
+func (s *DataStore) RevokeX509CA(ctx context.Context, req *datastore.RevokeX509CARequest) (*datastore.RevokeX509CAResponse, error) {
+	if err := s.modifyBundle(req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeX509CA(bundle, req.SubjectKeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeX509CAResponse{}, nil
+}
+
+// TaintJWTKey marks a JWT signing key in a bundle as tainted
+func (s *DataStore) TaintJWTKey(ctx context.Context, req *datastore.TaintJWTKeyRequest) (*datastore.TaintJWTKeyResponse, error) {
+	if err := s.modifyBundle(req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.TaintJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.TaintJWTKeyResponse{}, nil
+}
+
+// RevokeJWTKey removes a tainted JWT signing key from a bundle
+func (s *DataStore) RevokeJWTKey(ctx context.Context, req *datastore.RevokeJWTKeyRequest) (*datastore.RevokeJWTKeyResponse, error) {
+	if err := s.modifyBundle(req.TrustDomainId, func(bundle *common.Bundle) error {
+		return bundleutil.RevokeJWTKey(bundle, req.KeyId)
+	}); err != nil {
+		return nil, err
+	}
+	return &datastore.RevokeJWTKeyResponse{}, nil
+}
+
+func (s *DataStore) modifyBundle(trustDomainID string, fn func(*common.Bundle) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldBundle, ok := s.bundles[trustDomainID]
+	if !ok {
+		return ErrNoSuchBundle
+	}
+
+	newBundle := cloneBundle(oldBundle)
+	if err := fn(newBundle); err != nil {
+		return err
+	}
+
+	s.bundles[trustDomainID] = newBundle
+	s.recordChangeEvent(datastore.ChangeEvent_BUNDLE, trustDomainID, datastore.ChangeEvent_UPDATED)
+	return nil
+}
+
 func (s *DataStore) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -265,6 +341,8 @@ func (s *DataStore) CreateAttestedNode(ctx context.Context, req *datastore.Creat
 	}
 
 	s.attestedNodes[node.SpiffeId] = cloneAttestedNode(node)
+	s.recordChangeEvent(datastore.ChangeEvent_ATTESTED_NODE, node.SpiffeId, datastore.ChangeEvent_CREATED)
+
 	return &datastore.CreateAttestedNodeResponse{
 		Node: cloneAttestedNode(node),
 	}, nil
@@ -295,7 +373,21 @@ func (s *DataStore) ListAttestedNodes(ctx context.Context, req *datastore.ListAt
 	}
 	sort.Strings(keys)
 
-	resp := new(datastore.ListAttestedNodesResponse)
+	var bySelectors selector.Set
+	if req.BySelectorMatch != nil {
+		if len(req.BySelectorMatch.Selectors) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+		}
+		switch req.BySelectorMatch.Match {
+		case datastore.BySelectors_MATCH_EXACT, datastore.BySelectors_MATCH_SUBSET,
+			datastore.BySelectors_MATCH_ANY, datastore.BySelectors_MATCH_SUPERSET:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unhandled match behavior %q", req.BySelectorMatch.Match)
+		}
+		bySelectors = selector.NewSetFromRaw(req.BySelectorMatch.Selectors)
+	}
+
+	var matched []string
 	for _, key := range keys {
 		attestedNodeEntry := s.attestedNodes[key]
 		if req.ByExpiresBefore != nil {
@@ -303,7 +395,56 @@ func (s *DataStore) ListAttestedNodes(ctx context.Context, req *datastore.ListAt
 				continue
 			}
 		}
-		resp.Nodes = append(resp.Nodes, cloneAttestedNode(attestedNodeEntry))
+		if req.ByBanned != nil {
+			if req.ByBanned.Value != nodeutil.IsAgentBanned(attestedNodeEntry) {
+				continue
+			}
+		}
+		if req.ByAttestationType != "" && attestedNodeEntry.AttestationDataType != req.ByAttestationType {
+			continue
+		}
+		if req.ByCanReattest != nil && attestedNodeEntry.CanReattest != req.ByCanReattest.Value {
+			continue
+		}
+		if bySelectors != nil {
+			nodeSelectors := selector.NewSetFromRaw(s.nodeSelectors[key])
+			switch req.BySelectorMatch.Match {
+			case datastore.BySelectors_MATCH_EXACT:
+				if !bySelectors.Equal(nodeSelectors) {
+					continue
+				}
+			case datastore.BySelectors_MATCH_SUBSET:
+				if nodeSelectors.Size() == 0 || !bySelectors.IncludesSet(nodeSelectors) {
+					continue
+				}
+			case datastore.BySelectors_MATCH_ANY:
+				if !includesAnySelector(nodeSelectors, req.BySelectorMatch.Selectors) {
+					continue
+				}
+			case datastore.BySelectors_MATCH_SUPERSET:
+				if !nodeSelectors.IncludesSet(bySelectors) {
+					continue
+				}
+			}
+		}
+
+		matched = append(matched, key)
+	}
+
+	matched, pagination, err := paginateKeys(matched, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListAttestedNodesResponse{
+		Pagination: pagination,
+	}
+	for _, key := range matched {
+		node := cloneAttestedNode(s.attestedNodes[key])
+		if req.FetchSelectors {
+			node.Selectors = cloneSelectors(s.nodeSelectors[key])
+		}
+		resp.Nodes = append(resp.Nodes, node)
 	}
 
 	return resp, nil
@@ -317,10 +458,42 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context, req *datastore.Updat
 	if !ok {
 		return nil, ErrNoSuchAttestedNode
 	}
-	node.CertSerialNumber = req.CertSerialNumber
-	node.CertNotAfter = req.CertNotAfter
-	node.NewCertSerialNumber = req.NewCertSerialNumber
-	node.NewCertNotAfter = req.NewCertNotAfter
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.AttestedNodeMask{
+			CertSerialNumber:    true,
+			CertNotAfter:        true,
+			NewCertSerialNumber: true,
+			NewCertNotAfter:     true,
+		}
+	}
+	if mask.CertSerialNumber {
+		node.CertSerialNumber = req.CertSerialNumber
+	}
+	if mask.CertNotAfter {
+		node.CertNotAfter = req.CertNotAfter
+	}
+	if mask.NewCertSerialNumber {
+		node.NewCertSerialNumber = req.NewCertSerialNumber
+	}
+	if mask.NewCertNotAfter {
+		node.NewCertNotAfter = req.NewCertNotAfter
+	}
+	if mask.AgentVersion {
+		node.AgentVersion = req.AgentVersion
+	}
+	if mask.AgentPlugins {
+		node.AgentPlugins = cloneAgentPlugins(req.AgentPlugins)
+	}
+	if mask.LastSeen {
+		node.LastSeen = req.LastSeen
+	}
+	if mask.CanReattest {
+		node.CanReattest = req.CanReattest
+	}
+	if !proto.Equal(mask, &datastore.AttestedNodeMask{LastSeen: true}) {
+		s.recordChangeEvent(datastore.ChangeEvent_ATTESTED_NODE, req.SpiffeId, datastore.ChangeEvent_UPDATED)
+	}
 
 	return &datastore.UpdateAttestedNodeResponse{
 		Node: cloneAttestedNode(node),
@@ -336,6 +509,7 @@ func (s *DataStore) DeleteAttestedNode(ctx context.Context, req *datastore.Delet
 		return nil, ErrNoSuchAttestedNode
 	}
 	delete(s.attestedNodes, req.SpiffeId)
+	s.recordChangeEvent(datastore.ChangeEvent_ATTESTED_NODE, req.SpiffeId, datastore.ChangeEvent_DELETED)
 
 	return &datastore.DeleteAttestedNodeResponse{
 		Node: cloneAttestedNode(node),
@@ -347,6 +521,8 @@ func (s *DataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNode
 	defer s.mu.Unlock()
 
 	s.nodeSelectors[req.Selectors.SpiffeId] = cloneSelectors(req.Selectors.Selectors)
+	s.recordChangeEvent(datastore.ChangeEvent_ATTESTED_NODE, req.Selectors.SpiffeId, datastore.ChangeEvent_UPDATED)
+
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
 
@@ -364,10 +540,38 @@ func (s *DataStore) GetNodeSelectors(ctx context.Context, req *datastore.GetNode
 	}, nil
 }
 
+func (s *DataStore) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spiffeIDs := make([]string, 0, len(s.nodeSelectors))
+	for spiffeID := range s.nodeSelectors {
+		spiffeIDs = append(spiffeIDs, spiffeID)
+	}
+	sort.Strings(spiffeIDs)
+
+	resp := new(datastore.ListNodeSelectorsResponse)
+	for _, spiffeID := range spiffeIDs {
+		selectors := s.nodeSelectors[spiffeID]
+		if len(selectors) == 0 {
+			continue
+		}
+		resp.Selectors = append(resp.Selectors, &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: cloneSelectors(selectors),
+		})
+	}
+	return resp, nil
+}
+
 func (s *DataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.validateBundleLinks(req.Entry.FederatesWith); err != nil {
+		return nil, err
+	}
+
 	entryID, err := newRegistrationEntryID()
 	if err != nil {
 		return nil, err
@@ -375,11 +579,10 @@ func (s *DataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.
 
 	entry := cloneRegistrationEntry(req.Entry)
 	entry.EntryId = entryID
+	entry.RevisionNumber = 0
 	s.registrationEntries[entryID] = entry
-
-	if err := s.addBundleLinks(entryID, req.Entry.FederatesWith); err != nil {
-		return nil, err
-	}
+	s.addBundleLinks(entryID, entry.FederatesWith)
+	s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, entryID, datastore.ChangeEvent_CREATED)
 
 	return &datastore.CreateRegistrationEntryResponse{
 		Entry: cloneRegistrationEntry(entry),
@@ -404,6 +607,16 @@ func (s *DataStore) ListRegistrationEntries(ctx context.Context, req *datastore.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if req.Pagination != nil && req.Pagination.PageSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty selector set")
+	}
+	if req.ByFederatesWith != nil && len(req.ByFederatesWith.TrustDomains) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list by empty federates with set")
+	}
+
 	// add the registration entries to the map
 	entriesSet := make(map[string]*common.RegistrationEntry)
 	for _, entry := range s.registrationEntries {
@@ -413,38 +626,64 @@ func (s *DataStore) ListRegistrationEntries(ctx context.Context, req *datastore.
 		if req.BySpiffeId != nil && entry.SpiffeId != req.BySpiffeId.Value {
 			continue
 		}
+		if req.ByFederatesWith != nil {
+			switch req.ByFederatesWith.Match {
+			case datastore.ByFederatesWith_MATCH_ALL:
+				if !federatesWithAll(entry, req.ByFederatesWith.TrustDomains) {
+					continue
+				}
+			case datastore.ByFederatesWith_MATCH_ANY:
+				if !federatesWithAny(entry, req.ByFederatesWith.TrustDomains) {
+					continue
+				}
+			default:
+				return nil, fmt.Errorf("unhandled federates with match behavior %q", req.ByFederatesWith.Match)
+			}
+		}
+		if req.ByDownstream != nil && entry.Downstream != req.ByDownstream.Value {
+			continue
+		}
+		if req.ByAdmin != nil && entry.Admin != req.ByAdmin.Value {
+			continue
+		}
+		if req.ByDnsName != nil && !containsString(entry.DnsNames, req.ByDnsName.Value) {
+			continue
+		}
+		if req.ByExpiresAfter != nil && entry.EntryExpiry != 0 && entry.EntryExpiry <= req.ByExpiresAfter.Value {
+			continue
+		}
+		if req.ByExpiresBefore != nil && (entry.EntryExpiry == 0 || entry.EntryExpiry >= req.ByExpiresBefore.Value) {
+			continue
+		}
 
 		entriesSet[entry.EntryId] = entry
 	}
 
-	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
-		var selectorsList [][]*common.Selector
-		selectorSet := selector.NewSetFromRaw(req.BySelectors.Selectors)
+	if req.BySelectors != nil {
+		bySelectors := selector.NewSetFromRaw(req.BySelectors.Selectors)
+		var matches func(entrySelectors selector.Set) bool
 		switch req.BySelectors.Match {
 		case datastore.BySelectors_MATCH_EXACT:
-			selectorsList = append(selectorsList, selectorSet.Raw())
+			matches = bySelectors.Equal
 		case datastore.BySelectors_MATCH_SUBSET:
-			for combination := range selectorSet.Power() {
-				selectorsList = append(selectorsList, combination.Raw())
+			matches = func(entrySelectors selector.Set) bool {
+				return entrySelectors.Size() > 0 && bySelectors.IncludesSet(entrySelectors)
+			}
+		case datastore.BySelectors_MATCH_ANY:
+			matches = func(entrySelectors selector.Set) bool {
+				return includesAnySelector(entrySelectors, req.BySelectors.Selectors)
+			}
+		case datastore.BySelectors_MATCH_SUPERSET:
+			matches = func(entrySelectors selector.Set) bool {
+				return entrySelectors.IncludesSet(bySelectors)
 			}
 		default:
 			return nil, fmt.Errorf("unhandled match behavior %q", req.BySelectors.Match)
 		}
 
-		// filter entries that don't match at least one selector set
+		// filter entries whose selectors don't match
 		for entryID, entry := range entriesSet {
-			matchesOne := false
-			for _, selectors := range selectorsList {
-				if !matchesSelectors(entry.Selectors, selectors) {
-					continue
-				}
-				if len(entry.Selectors) != len(selectors) {
-					continue
-				}
-				matchesOne = true
-				break
-			}
-			if !matchesOne {
+			if !matches(selector.NewSetFromRaw(entry.Selectors)) {
 				delete(entriesSet, entryID)
 			}
 		}
@@ -458,8 +697,8 @@ func (s *DataStore) ListRegistrationEntries(ctx context.Context, req *datastore.
 	util.SortRegistrationEntries(entries)
 
 	p := req.Pagination
-	// in case pagination is defined and page size greater than zero apply pagination
-	if p != nil && p.PageSize > 0 {
+	// in case pagination is defined apply pagination
+	if p != nil {
 		// as default start in first position
 		init := 0
 
@@ -515,16 +754,69 @@ func (s *DataStore) UpdateRegistrationEntry(ctx context.Context, req *datastore.
 	if !ok {
 		return nil, ErrNoSuchRegistrationEntry
 	}
-
-	s.removeBundleLinks(oldEntry.EntryId, oldEntry.FederatesWith)
-
-	entry := cloneRegistrationEntry(req.Entry)
-	s.registrationEntries[req.Entry.EntryId] = entry
-
-	if err := s.addBundleLinks(entry.EntryId, req.Entry.FederatesWith); err != nil {
+	if err := checkRevisionNumber(oldEntry, req.ExpectedRevisionNumber); err != nil {
 		return nil, err
 	}
 
+	entry := cloneRegistrationEntry(oldEntry)
+	mask := req.InputMask
+	if mask == nil {
+		mask = &common.RegistrationEntryMask{
+			Selectors:       true,
+			ParentId:        true,
+			SpiffeId:        true,
+			Ttl:             true,
+			FederatesWith:   true,
+			Admin:           true,
+			Downstream:      true,
+			EntryExpiry:     true,
+			DnsNames:        true,
+			X509SvidProfile: true,
+			JwtSvidTtl:      true,
+		}
+	}
+	if mask.Selectors {
+		entry.Selectors = cloneSelectors(req.Entry.Selectors)
+	}
+	if mask.ParentId {
+		entry.ParentId = req.Entry.ParentId
+	}
+	if mask.SpiffeId {
+		entry.SpiffeId = req.Entry.SpiffeId
+	}
+	if mask.Ttl {
+		entry.Ttl = req.Entry.Ttl
+	}
+	if mask.FederatesWith {
+		if err := s.validateBundleLinks(req.Entry.FederatesWith); err != nil {
+			return nil, err
+		}
+		s.removeBundleLinks(oldEntry.EntryId, oldEntry.FederatesWith)
+		entry.FederatesWith = append([]string(nil), req.Entry.FederatesWith...)
+		s.addBundleLinks(entry.EntryId, entry.FederatesWith)
+	}
+	if mask.Admin {
+		entry.Admin = req.Entry.Admin
+	}
+	if mask.Downstream {
+		entry.Downstream = req.Entry.Downstream
+	}
+	if mask.EntryExpiry {
+		entry.EntryExpiry = req.Entry.EntryExpiry
+	}
+	if mask.DnsNames {
+		entry.DnsNames = append([]string(nil), req.Entry.DnsNames...)
+	}
+	if mask.X509SvidProfile {
+		entry.X509SvidProfile = req.Entry.X509SvidProfile
+	}
+	if mask.JwtSvidTtl {
+		entry.JwtSvidTtl = req.Entry.JwtSvidTtl
+	}
+	entry.RevisionNumber = oldEntry.RevisionNumber + 1
+	s.registrationEntries[entry.EntryId] = entry
+	s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, entry.EntryId, datastore.ChangeEvent_UPDATED)
+
 	return &datastore.UpdateRegistrationEntryResponse{
 		Entry: cloneRegistrationEntry(entry),
 	}, nil
@@ -538,9 +830,13 @@ func (s *DataStore) DeleteRegistrationEntry(ctx context.Context, req *datastore.
 	if !ok {
 		return nil, ErrNoSuchRegistrationEntry
 	}
+	if err := checkRevisionNumber(registrationEntry, req.ExpectedRevisionNumber); err != nil {
+		return nil, err
+	}
 	delete(s.registrationEntries, req.EntryId)
 
 	s.removeBundleLinks(req.EntryId, registrationEntry.FederatesWith)
+	s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, req.EntryId, datastore.ChangeEvent_DELETED)
 
 	return &datastore.DeleteRegistrationEntryResponse{
 		Entry: cloneRegistrationEntry(registrationEntry),
@@ -554,6 +850,8 @@ func (s *DataStore) PruneRegistrationEntries(ctx context.Context, req *datastore
 	for key, entry := range s.registrationEntries {
 		if entry.EntryExpiry != 0 && entry.EntryExpiry < req.ExpiresBefore {
 			delete(s.registrationEntries, key)
+			s.removeBundleLinks(key, entry.FederatesWith)
+			s.recordChangeEvent(datastore.ChangeEvent_REGISTRATION_ENTRY, key, datastore.ChangeEvent_DELETED)
 		}
 	}
 
@@ -606,6 +904,20 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	}, nil
 }
 
+func (s *DataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &datastore.ListJoinTokensResponse{}
+	for _, joinToken := range s.tokens {
+		resp.JoinTokens = append(resp.JoinTokens, cloneJoinToken(joinToken))
+	}
+	sort.Slice(resp.JoinTokens, func(i, j int) bool {
+		return resp.JoinTokens[i].Token < resp.JoinTokens[j].Token
+	})
+	return resp, nil
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -619,6 +931,175 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func (s *DataStore) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship := req.FederationRelationship
+
+	if _, ok := s.relationships[relationship.TrustDomainId]; ok {
+		return nil, ErrFederationRelationshipAlreadyExists
+	}
+
+	s.relationships[relationship.TrustDomainId] = cloneFederationRelationship(relationship)
+
+	return &datastore.CreateFederationRelationshipResponse{
+		FederationRelationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+func (s *DataStore) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship, ok := s.relationships[req.TrustDomainId]
+	if !ok {
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		FederationRelationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+func (s *DataStore) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// get an ordered list of keys so tests can rely on ordering for stability
+	keys := make([]string, 0, len(s.relationships))
+	for key := range s.relationships {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keys, pagination, err := paginateKeys(keys, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListFederationRelationshipsResponse{
+		Pagination: pagination,
+	}
+	for _, key := range keys {
+		resp.FederationRelationships = append(resp.FederationRelationships, cloneFederationRelationship(s.relationships[key]))
+	}
+
+	return resp, nil
+}
+
+func (s *DataStore) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship := req.FederationRelationship
+
+	if _, ok := s.relationships[relationship.TrustDomainId]; !ok {
+		return nil, ErrNoSuchFederationRelationship
+	}
+
+	s.relationships[relationship.TrustDomainId] = cloneFederationRelationship(relationship)
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		FederationRelationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+func (s *DataStore) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship, ok := s.relationships[req.TrustDomainId]
+	if !ok {
+		return nil, ErrNoSuchFederationRelationship
+	}
+	delete(s.relationships, req.TrustDomainId)
+
+	return &datastore.DeleteFederationRelationshipResponse{
+		FederationRelationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+// ListChangeEvents lists the change events recorded after the given revision
+func (s *DataStore) ListChangeEvents(ctx context.Context, req *datastore.ListChangeEventsRequest) (*datastore.ListChangeEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cannot list change events with a negative limit")
+	}
+
+	resp := new(datastore.ListChangeEventsResponse)
+	if len(s.events) > 0 {
+		resp.LatestRevision = s.events[len(s.events)-1].Revision
+	}
+	for _, event := range s.events {
+		if event.Revision <= req.AfterRevision {
+			continue
+		}
+		if req.Limit > 0 && len(resp.Events) == int(req.Limit) {
+			break
+		}
+		resp.Events = append(resp.Events, cloneChangeEvent(event))
+	}
+	return resp, nil
+}
+
+// PruneChangeEvents deletes all change events recorded before the given time
+func (s *DataStore) PruneChangeEvents(ctx context.Context, req *datastore.PruneChangeEventsRequest) (*datastore.PruneChangeEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []*datastore.ChangeEvent
+	for _, event := range s.events {
+		if event.CreatedAt >= req.CreatedBefore {
+			events = append(events, event)
+		}
+	}
+	s.events = events
+
+	return &datastore.PruneChangeEventsResponse{}, nil
+}
+
+// FetchCAJournal fetches the CA journal of the given trust domain
+func (s *DataStore) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	journal, ok := s.caJournals[req.TrustDomainId]
+	if !ok {
+		return &datastore.FetchCAJournalResponse{}, nil
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		CaJournal: cloneCAJournal(journal),
+	}, nil
+}
+
+// SetCAJournal sets the CA journal of a trust domain if its revision matches
+// the stored revision
+func (s *DataStore) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	journal := cloneCAJournal(req.CaJournal)
+
+	var revision int64
+	if current, ok := s.caJournals[journal.TrustDomainId]; ok {
+		revision = current.Revision
+	}
+	if journal.Revision != revision {
+		return nil, status.Errorf(codes.Aborted, "CA journal revision mismatch: expected %d, current %d", journal.Revision, revision)
+	}
+
+	journal.Revision++
+	s.caJournals[journal.TrustDomainId] = journal
+
+	return &datastore.SetCAJournalResponse{
+		CaJournal: cloneCAJournal(journal),
+	}, nil
+}
+
 func (s *DataStore) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return &spi.ConfigureResponse{}, nil
 }
@@ -627,11 +1108,28 @@ func (*DataStore) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*sp
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
-func (s *DataStore) addBundleLinks(entryID string, bundleIDs []string) error {
+func (s *DataStore) recordChangeEvent(resourceType datastore.ChangeEvent_ResourceType, resourceID string, eventType datastore.ChangeEvent_Type) {
+	s.revision++
+	s.events = append(s.events, &datastore.ChangeEvent{
+		Revision:     s.revision,
+		Type:         eventType,
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		CreatedAt:    time.Now().Unix(),
+	})
+}
+
+func (s *DataStore) validateBundleLinks(bundleIDs []string) error {
 	for _, bundleID := range bundleIDs {
 		if _, ok := s.bundles[bundleID]; !ok {
 			return ErrNoSuchBundle
 		}
+	}
+	return nil
+}
+
+func (s *DataStore) addBundleLinks(entryID string, bundleIDs []string) {
+	for _, bundleID := range bundleIDs {
 		bundleEntries := s.bundleEntries[bundleID]
 		if bundleEntries == nil {
 			bundleEntries = make(map[string]bool)
@@ -639,7 +1137,6 @@ func (s *DataStore) addBundleLinks(entryID string, bundleIDs []string) error {
 		}
 		bundleEntries[entryID] = true
 	}
-	return nil
 }
 
 func (s *DataStore) removeBundleLinks(entryID string, bundleIDs []string) {
@@ -648,18 +1145,89 @@ func (s *DataStore) removeBundleLinks(entryID string, bundleIDs []string) {
 	}
 }
 
+// paginateKeys returns the page of sorted keys that follows the key in the
+// pagination token, along with the pagination to return to the caller.
+func paginateKeys(keys []string, p *datastore.Pagination) ([]string, *datastore.Pagination, error) {
+	if p == nil {
+		return keys, nil, nil
+	}
+	if p.PageSize == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
+	}
+
+	init := sort.Search(len(keys), func(i int) bool {
+		return keys[i] > p.Token
+	})
+	end := init + int(p.PageSize)
+	if end > len(keys) {
+		end = len(keys)
+	}
+	keys = keys[init:end]
+
+	var token string
+	if len(keys) > 0 {
+		token = keys[len(keys)-1]
+	}
+	return keys, &datastore.Pagination{
+		PageSize: p.PageSize,
+		Token:    token,
+	}, nil
+}
+
+// federatesWithAll returns true if the entry federates with all of the given
+// trust domain IDs.
+func federatesWithAll(e *common.RegistrationEntry, trustDomainIDs []string) bool {
+	for _, trustDomainID := range trustDomainIDs {
+		found := false
+		for _, federatesWith := range e.FederatesWith {
+			if federatesWith == trustDomainID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func federatesWithAny(e *common.RegistrationEntry, trustDomainIDs []string) bool {
+	for _, trustDomainID := range trustDomainIDs {
+		if containsString(e.FederatesWith, trustDomainID) {
+			return true
+		}
+	}
+	return false
+}
+
+func includesAnySelector(set selector.Set, selectors []*common.Selector) bool {
+	for _, s := range selectors {
+		if set.Includes(&selector.Selector{Type: s.Type, Value: s.Value}) {
+			return true
+		}
+	}
+	return false
+}
+
 func cloneBundle(bundle *common.Bundle) *common.Bundle {
 	return proto.Clone(bundle).(*common.Bundle)
 }
 
 func cloneAttestedNode(attestedNodeEntry *common.AttestedNode) *common.AttestedNode {
-	return proto.Clone(attestedNodeEntry).(*common.AttestedNode)
+	node := proto.Clone(attestedNodeEntry).(*common.AttestedNode)
+	node.Banned = nodeutil.IsAgentBanned(node)
+	return node
 }
 
 func cloneSelectors(selectors []*common.Selector) []*common.Selector {
 	return proto.Clone(&common.Selectors{Entries: selectors}).(*common.Selectors).Entries
 }
 
+func cloneAgentPlugins(agentPlugins []*common.AgentPlugin) []*common.AgentPlugin {
+	return proto.Clone(&common.AttestedNode{AgentPlugins: agentPlugins}).(*common.AttestedNode).AgentPlugins
+}
+
 func cloneRegistrationEntry(registrationEntry *common.RegistrationEntry) *common.RegistrationEntry {
 	return proto.Clone(registrationEntry).(*common.RegistrationEntry)
 }
@@ -668,6 +1236,18 @@ func cloneJoinToken(token *datastore.JoinToken) *datastore.JoinToken {
 	return proto.Clone(token).(*datastore.JoinToken)
 }
 
+func cloneFederationRelationship(relationship *datastore.FederationRelationship) *datastore.FederationRelationship {
+	return proto.Clone(relationship).(*datastore.FederationRelationship)
+}
+
+func cloneCAJournal(journal *datastore.CAJournal) *datastore.CAJournal {
+	return proto.Clone(journal).(*datastore.CAJournal)
+}
+
+func cloneChangeEvent(event *datastore.ChangeEvent) *datastore.ChangeEvent {
+	return proto.Clone(event).(*datastore.ChangeEvent)
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -676,20 +1256,11 @@ func newRegistrationEntryID() (string, error) {
 	return u.String(), nil
 }
 
-func matchesSelectors(a, b []*common.Selector) bool {
-	a = append([]*common.Selector{}, a...)
-	util.SortSelectors(a)
-	b = append([]*common.Selector{}, b...)
-	util.SortSelectors(b)
-	if len(a) != len(b) {
-		return false
+func checkRevisionNumber(entry *common.RegistrationEntry, expected *wrappers.Int64Value) error {
+	if expected != nil && expected.Value != entry.RevisionNumber {
+		return status.Errorf(codes.Aborted, "registration entry revision number mismatch: expected %d, current %d", expected.Value, entry.RevisionNumber)
 	}
-	for i := 0; i < len(a); i++ {
-		if !proto.Equal(a[i], b[i]) {
-			return false
-		}
-	}
-	return true
+	return nil
 }
 
 func removeString(list []string, s string) []string {
@@ -701,3 +1272,12 @@ func removeString(list []string, s string) []string {
 	}
 	return out
 }
+
+func containsString(list []string, s string) bool {
+	for _, entry := range list {
+		if entry == s {
+			return true
+		}
+	}
+	return false
+}
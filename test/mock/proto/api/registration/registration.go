@@ -117,7 +117,7 @@ func (mr *MockRegistrationClientMockRecorder) CreateJoinToken(arg0, arg1 interfa
 }
 
 // DeleteEntry mocks base method
-func (m *MockRegistrationClient) DeleteEntry(arg0 context.Context, arg1 *registration.RegistrationEntryID, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
+func (m *MockRegistrationClient) DeleteEntry(arg0 context.Context, arg1 *registration.DeleteEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
@@ -580,7 +580,7 @@ func (mr *MockRegistrationServerMockRecorder) CreateJoinToken(arg0, arg1 interfa
 }
 
 // DeleteEntry mocks base method
-func (m *MockRegistrationServer) DeleteEntry(arg0 context.Context, arg1 *registration.RegistrationEntryID) (*common.RegistrationEntry, error) {
+func (m *MockRegistrationServer) DeleteEntry(arg0 context.Context, arg1 *registration.DeleteEntryRequest) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteEntry", arg0, arg1)
 	ret0, _ := ret[0].(*common.RegistrationEntry)